@@ -3,11 +3,23 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/lukaszraczylo/git-velocity/internal/aggregator"
 	"github.com/lukaszraczylo/git-velocity/internal/app"
+	"github.com/lukaszraczylo/git-velocity/internal/compare"
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/domain/scoring"
+	"github.com/lukaszraczylo/git-velocity/internal/generator/site"
+	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+	"github.com/lukaszraczylo/git-velocity/internal/merge"
+	"github.com/lukaszraczylo/git-velocity/internal/redact"
 	"github.com/lukaszraczylo/git-velocity/internal/server"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 	"github.com/lukaszraczylo/git-velocity/pkg/version"
 )
 
@@ -15,11 +27,13 @@ var (
 	configPath string
 	outputDir  string
 	verbose    bool
+	offline    bool
+	verify     bool
 )
 
 func main() {
 	if err := newRootCmd().Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, redact.String(err.Error()))
 		os.Exit(1)
 	}
 }
@@ -45,6 +59,10 @@ static HTML dashboards with charts and gamification features.`,
 	// Add subcommands
 	rootCmd.AddCommand(newAnalyzeCmd())
 	rootCmd.AddCommand(newServeCmd())
+	rootCmd.AddCommand(newDiffCmd())
+	rootCmd.AddCommand(newMergeCmd())
+	rootCmd.AddCommand(newScoreCmd())
+	rootCmd.AddCommand(newCacheCmd())
 	rootCmd.AddCommand(newVersionCmd())
 
 	return rootCmd
@@ -60,12 +78,22 @@ This command will:
 1. Fetch data from the configured GitHub repositories
 2. Calculate velocity metrics for each contributor
 3. Generate scores and achievements
-4. Create a static HTML site with charts and leaderboards`,
+4. Create a static HTML site with charts and leaderboards
+
+With --offline, no network calls are made: metrics are rebuilt from the
+raw data snapshot saved in the cache directory by a previous run.
+
+With --verify, the line counts of a sample of commits are compared with
+GitHub's commit stats and the mismatches reported at the end of the run.`,
 		RunE: runAnalyze,
 	}
 
 	cmd.Flags().StringVarP(&outputDir, "output", "o",
-		"./dist", "Output directory for generated site")
+		"", "Output directory for generated site (default: output.directory from the config)")
+	cmd.Flags().BoolVar(&offline, "offline", false,
+		"Rebuild from the cached raw data snapshot without network access")
+	cmd.Flags().BoolVar(&verify, "verify", false,
+		"Compare line counts of sampled commits with GitHub's commit stats")
 
 	return cmd
 }
@@ -79,7 +107,13 @@ func newServeCmd() *cobra.Command {
 		Short: "Start local preview server",
 		Long: `Start a local HTTP server to preview the generated dashboard.
 
-This is useful for testing the generated site before deployment.`,
+This is useful for testing the generated site before deployment.
+
+The server also exposes read-only JSON endpoints backed by the generated
+data: /api/leaderboard, /api/contributors/{login}, /api/repos/{owner}/{repo}
+and /api/teams. /api/graphql answers GraphQL queries over the same metrics,
+whose schema is served at /api/graphql/schema. Open dashboards subscribe to
+/api/events and refresh automatically when a new analyze run rewrites the data.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runServe(dir, port)
 		},
@@ -93,6 +127,123 @@ This is useful for testing the generated site before deployment.`,
 	return cmd
 }
 
+func newDiffCmd() *cobra.Command {
+	var asJSON bool
+	var outputFile string
+	var maxScoreDrop float64
+
+	cmd := &cobra.Command{
+		Use:   "diff <base> <head>",
+		Short: "Compare two analysis runs",
+		Long: `Compare two analysis runs and report score deltas, new and removed
+contributors, achievement changes and repository totals.
+
+Each argument may be an output directory, its data directory or a
+global.json snapshot. Use --max-score-drop in CI to fail when the total
+score regresses by more than the given percentage.
+
+When the runs were scored with different point values, the base run is
+rescored with the head run's, using the other scoring settings from
+--config, so that only changes in activity show up as score deltas.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(args[0], args[1], asJSON, outputFile, maxScoreDrop, cmd.Flags().Changed("config"))
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false,
+		"Print the report as JSON")
+	cmd.Flags().StringVarP(&outputFile, "output", "o",
+		"", "Write the JSON report to a file")
+	cmd.Flags().Float64Var(&maxScoreDrop, "max-score-drop", 0,
+		"Fail if the total score drops by more than this percentage (0 disables)")
+
+	return cmd
+}
+
+func newMergeCmd() *cobra.Command {
+	var mergeOutput string
+
+	cmd := &cobra.Command{
+		Use:   "merge <run>... -o <dir>",
+		Short: "Combine separately generated dashboards into one",
+		Long: `Combine the data of several analysis runs into a single org-wide
+dashboard, for teams that analyze their repositories separately.
+
+Each argument may be an output directory, its data directory or a
+global.json snapshot. Contributors are deduplicated by login and rescored
+with the scoring settings from --config; built-in defaults are used when
+the configuration file does not exist.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMerge(args, mergeOutput, cmd.Flags().Changed("config"))
+		},
+	}
+
+	cmd.Flags().StringVarP(&mergeOutput, "output", "o",
+		"", "Output directory for the combined site")
+	_ = cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+func newScoreCmd() *cobra.Command {
+	var simulatePath string
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "score",
+		Short: "Re-score cached data with alternative point values",
+		Long: `Recompute scores from the raw data snapshot saved by the last analyze run,
+without fetching anything from GitHub.
+
+With --simulate, the points file (same keys as scoring.points) overrides the
+configured point values and the command reports how scores and ranks would change.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScore(simulatePath, asJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&simulatePath, "simulate", "",
+		"Points file with alternative point values")
+	cmd.Flags().BoolVar(&asJSON, "json", false,
+		"Print the report as JSON")
+	_ = cmd.MarkFlagRequired("simulate")
+
+	return cmd
+}
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and manage the API response cache",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "stats",
+		Short: "Show cache size and hit rates per key type",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheStats()
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached data, including the raw data snapshot",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheClear()
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "prune",
+		Short: "Remove cache entries older than the configured TTL",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCachePrune()
+		},
+	})
+
+	return cmd
+}
+
 func newVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
@@ -106,11 +257,19 @@ func newVersionCmd() *cobra.Command {
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
+	newApp := app.New
+	if offline {
+		newApp = app.NewOffline
+	}
+
 	// Create and run the application
-	application, err := app.New(configPath, outputDir, verbose)
+	application, err := newApp(configPath, outputDir, verbose)
 	if err != nil {
 		return fmt.Errorf("failed to initialize application: %w", err)
 	}
+	if verify {
+		application.EnableVerification()
+	}
 
 	return application.Run(cmd.Context())
 }
@@ -124,3 +283,185 @@ func runServe(dir, port string) error {
 
 	return srv.Start()
 }
+
+func runMerge(paths []string, output string, configRequired bool) error {
+	runs := make([]*models.GlobalMetrics, 0, len(paths))
+	for _, path := range paths {
+		run, err := compare.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to load run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	// Merging reads generated data only, so credentials are not required
+	cfg, err := offlineConfig(configRequired)
+	if err != nil {
+		return err
+	}
+
+	result := merge.Merge(runs)
+	for _, w := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	if len(cfg.Teams) == 0 {
+		cfg.Teams = result.TeamConfigs()
+	}
+	if err := aggregator.ApplyCustomMetrics(result.Metrics, cfg.CustomMetrics); err != nil {
+		return err
+	}
+
+	metrics := scoring.NewCalculator(cfg).Calculate(result.Metrics)
+
+	gen, err := site.NewGenerator(output, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create generator: %w", err)
+	}
+	if err := gen.Generate(metrics); err != nil {
+		return fmt.Errorf("failed to generate site: %w", err)
+	}
+
+	fmt.Printf("Merged %d runs: %d repositories, %d contributors\n",
+		len(runs), len(metrics.Repositories), metrics.TotalContributors)
+	fmt.Printf("Output: %s\n", output)
+	return nil
+}
+
+// offlineConfig loads the configuration file without credentials, falling
+// back to the defaults when the file does not exist and was not asked for
+func offlineConfig(required bool) (*config.Config, error) {
+	if _, err := os.Stat(configPath); err != nil && !required {
+		return config.DefaultConfig(), nil
+	}
+	cfg, err := config.LoadOffline(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+func runScore(simulatePath string, asJSON bool) error {
+	// Simulation only reads the snapshot, so credentials are not required
+	application, err := app.NewOffline(configPath, outputDir, verbose)
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+
+	return application.Simulate(simulatePath, asJSON, os.Stdout)
+}
+
+func runDiff(basePath, headPath string, asJSON bool, outputFile string, maxScoreDrop float64, configRequired bool) error {
+	base, err := compare.Load(basePath)
+	if err != nil {
+		return fmt.Errorf("failed to load base run: %w", err)
+	}
+	head, err := compare.Load(headPath)
+	if err != nil {
+		return fmt.Errorf("failed to load head run: %w", err)
+	}
+
+	// Scores calculated with different point values can't be compared, so
+	// the base run is rescored with the head run's
+	rescored := false
+	if head.Points != nil {
+		cfg, err := offlineConfig(configRequired)
+		if err != nil {
+			return err
+		}
+		if cfg.Scoring.Points, err = config.PointsFromValues(head.Points, cfg.Scoring.Points); err != nil {
+			return fmt.Errorf("failed to read the head run's point values: %w", err)
+		}
+		cfg.Scoring.Enabled = true
+		base, rescored = scoring.NewCalculator(cfg).Rescore(base)
+	}
+
+	report := compare.Compare(base, head)
+	report.Rescored = rescored
+
+	if outputFile != "" {
+		f, err := os.Create(filepath.Clean(outputFile))
+		if err != nil {
+			return fmt.Errorf("failed to create report file: %w", err)
+		}
+		defer f.Close()
+		if err := report.WriteJSON(f); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+
+	if asJSON {
+		err = report.WriteJSON(os.Stdout)
+	} else {
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		return err
+	}
+
+	if maxScoreDrop > 0 && report.ScoreDropPercent() > maxScoreDrop {
+		return fmt.Errorf("total score dropped by %.1f%% (allowed: %.1f%%)", report.ScoreDropPercent(), maxScoreDrop)
+	}
+
+	return nil
+}
+
+// openCache opens the file cache configured in the config file.
+// Credentials are not required to manage the cache.
+func openCache() (*cache.FileCache, error) {
+	cfg, err := config.LoadOffline(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	ttl, err := cfg.GetCacheTTL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cache TTL: %w", err)
+	}
+	return cache.NewFileCache(cfg.Cache.Directory, ttl)
+}
+
+func runCacheStats() error {
+	c, err := openCache()
+	if err != nil {
+		return err
+	}
+	stats, err := c.Stats()
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	fmt.Printf("Cache directory: %s\n", stats.Directory)
+	fmt.Printf("Entries: %d (%d expired), total size: %s\n\n", stats.Entries, stats.Expired, github.FormatBytes(stats.Bytes))
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tENTRIES\tEXPIRED\tSIZE\tHITS\tMISSES\tHIT RATE")
+	for _, ts := range stats.Types {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%d\t%d\t%.1f%%\n",
+			ts.Type, ts.Entries, ts.Expired, github.FormatBytes(ts.Bytes), ts.Hits, ts.Misses, ts.HitRate())
+	}
+	return tw.Flush()
+}
+
+func runCacheClear() error {
+	c, err := openCache()
+	if err != nil {
+		return err
+	}
+	if err := c.Clear(); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	fmt.Println("Cache cleared")
+	return nil
+}
+
+func runCachePrune() error {
+	c, err := openCache()
+	if err != nil {
+		return err
+	}
+	removed, freed, err := c.Prune()
+	if err != nil {
+		return fmt.Errorf("failed to prune cache: %w", err)
+	}
+	fmt.Printf("Pruned %d entries (%s freed)\n", removed, github.FormatBytes(freed))
+	return nil
+}
@@ -0,0 +1,237 @@
+// Package absence reads contributors' out-of-office days from the
+// configuration and from ICS or CSV calendar files.
+package absence
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+const dateLayout = "2006-01-02"
+
+// Calendar is a set of out-of-office days, keyed by YYYY-MM-DD
+type Calendar map[string]bool
+
+// Calendars holds the calendar of each contributor, keyed by lowercase login
+type Calendars map[string]Calendar
+
+// For returns the calendar of login, or nil when it has none
+func (c Calendars) For(login string) Calendar {
+	return c[strings.ToLower(login)]
+}
+
+// Load builds the calendars of the configured contributors from their inline
+// absences and calendar files. Contributors without absences are left out.
+func Load(contributors []config.ContributorConfig) (Calendars, error) {
+	calendars := make(Calendars)
+	for i := range contributors {
+		cc := &contributors[i]
+		cal := make(Calendar)
+		for j := range cc.Absences {
+			start, end, err := cc.Absences[j].Dates()
+			if err != nil {
+				return nil, fmt.Errorf("contributor %s: %w", cc.Login, err)
+			}
+			cal.addRange(start, end)
+		}
+		if cc.Calendar != "" {
+			if err := cal.readFile(cc.Calendar); err != nil {
+				return nil, fmt.Errorf("contributor %s: %w", cc.Login, err)
+			}
+		}
+		if len(cal) > 0 {
+			key := strings.ToLower(cc.Login)
+			if existing, ok := calendars[key]; ok {
+				for day := range cal {
+					existing[day] = true
+				}
+				continue
+			}
+			calendars[key] = cal
+		}
+	}
+	return calendars, nil
+}
+
+// Has reports whether t falls on an out-of-office day
+func (c Calendar) Has(t time.Time) bool {
+	return c[t.Format(dateLayout)]
+}
+
+// Available returns how many days of the period the contributor was out of
+// office and how many remain available. Open-ended periods have no day count.
+func (c Calendar) Available(period models.Period) (absent, available int) {
+	if period.Start.IsZero() || period.End.Before(period.Start) {
+		return 0, 0
+	}
+	from := truncate(period.Start)
+	to := truncate(period.End)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if c.Has(d) {
+			absent++
+		} else {
+			available++
+		}
+	}
+	return absent, available
+}
+
+func (c Calendar) addRange(start, end time.Time) {
+	for d := truncate(start); !d.After(end); d = d.AddDate(0, 0, 1) {
+		c[d.Format(dateLayout)] = true
+	}
+}
+
+func (c Calendar) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open calendar: %w", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ics":
+		err = c.readICS(f)
+	case ".csv":
+		err = c.readCSV(f)
+	default:
+		err = fmt.Errorf("unsupported calendar file: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read calendar %s: %w", path, err)
+	}
+	return nil
+}
+
+// readCSV reads rows of "start,end" dates; the end date is optional and a
+// header row or lines starting with # are skipped
+func (c Calendar) readCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ac := config.AbsenceConfig{Start: strings.TrimSpace(record[0])}
+		if len(record) > 1 {
+			ac.End = strings.TrimSpace(record[1])
+		}
+		start, end, err := ac.Dates()
+		if err != nil {
+			if line == 1 {
+				continue // Header
+			}
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		c.addRange(start, end)
+	}
+}
+
+// readICS reads the days between DTSTART and DTEND of each VEVENT
+func (c Calendar) readICS(r io.Reader) error {
+	var (
+		inEvent    bool
+		start, end string
+	)
+	for _, line := range unfold(r) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+		switch name {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end = true, "", ""
+			}
+		case "DTSTART":
+			if inEvent {
+				start = value
+			}
+		case "DTEND":
+			if inEvent {
+				end = value
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if err := c.addEvent(start, end); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c Calendar) addEvent(dtstart, dtend string) error {
+	start, err := parseICSTime(dtstart)
+	if err != nil {
+		return fmt.Errorf("invalid DTSTART %q: %w", dtstart, err)
+	}
+	if dtend == "" {
+		c.addRange(start, start)
+		return nil
+	}
+	end, err := parseICSTime(dtend)
+	if err != nil {
+		return fmt.Errorf("invalid DTEND %q: %w", dtend, err)
+	}
+	// DTEND is exclusive: an all-day event ends the day before, and a timed
+	// event ending at midnight doesn't take up the following day
+	if end.Equal(truncate(end)) {
+		end = end.AddDate(0, 0, -1)
+	}
+	if end.Before(start) {
+		end = start
+	}
+	c.addRange(start, end)
+	return nil
+}
+
+// parseICSTime parses DATE and DATE-TIME values; times are taken as written,
+// since only the calendar day matters
+func parseICSTime(value string) (time.Time, error) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "Z")
+	if len(value) == len("20060102") {
+		return time.Parse("20060102", value)
+	}
+	return time.Parse("20060102T150405", value)
+}
+
+// unfold joins continuation lines, which start with a space or tab
+func unfold(r io.Reader) []string {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func truncate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,100 @@
+package absence
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Holiday\r\n" +
+	"DTSTART;VALUE=DATE:20240304\r\n" +
+	"DTEND;VALUE=DATE:20240306\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Doctor\r\n" +
+	" appointment\r\n" +
+	"DTSTART:20240311T090000Z\r\n" +
+	"DTEND:20240311T120000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=Europe/Warsaw:20240320T000000\r\n" +
+	"DTEND;TZID=Europe/Warsaw:20240322T000000\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	ics := writeFile(t, "alice.ics", testICS)
+	csv := writeFile(t, "bob.csv", "start,end\n# Summer\n2024-07-01, 2024-07-02\n2024-07-10\n")
+
+	calendars, err := Load([]config.ContributorConfig{
+		{Login: "Alice", Calendar: ics, Absences: []config.AbsenceConfig{{Start: "2024-03-29"}}},
+		{Login: "bob", Calendar: csv},
+		{Login: "carol", Start: "2024-01-01"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, Calendar{
+		"2024-03-04": true, "2024-03-05": true, // All-day DTEND is exclusive
+		"2024-03-11": true,
+		"2024-03-20": true, "2024-03-21": true, // Ending at midnight excludes the last day
+		"2024-03-29": true,
+	}, calendars.For("alice"))
+	assert.Equal(t, Calendar{"2024-07-01": true, "2024-07-02": true, "2024-07-10": true}, calendars.For("BOB"))
+	assert.Nil(t, calendars.For("carol"), "contributors without absences have no calendar")
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load([]config.ContributorConfig{{Login: "alice", Calendar: "missing.ics"}})
+	assert.ErrorContains(t, err, "contributor alice")
+
+	csv := writeFile(t, "bad.csv", "2024-07-01\nnot-a-date\n")
+	_, err = Load([]config.ContributorConfig{{Login: "bob", Calendar: csv}})
+	assert.ErrorContains(t, err, "line 2")
+
+	ics := writeFile(t, "bad.ics", "BEGIN:VEVENT\nDTSTART:tomorrow\nEND:VEVENT\n")
+	_, err = Load([]config.ContributorConfig{{Login: "carol", Calendar: ics}})
+	assert.ErrorContains(t, err, "invalid DTSTART")
+}
+
+func TestCalendar_Available(t *testing.T) {
+	t.Parallel()
+
+	cal := Calendar{"2024-01-31": true, "2024-02-01": true, "2024-02-02": true}
+	period := models.Period{
+		Start: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC),
+	}
+
+	absent, available := cal.Available(period)
+	assert.Equal(t, 2, absent, "days outside the period are ignored")
+	assert.Equal(t, 27, available)
+
+	absent, available = cal.Available(models.Period{End: period.End})
+	assert.Zero(t, absent)
+	assert.Zero(t, available, "open-ended periods have no day count")
+
+	var none Calendar
+	_, available = none.Available(period)
+	assert.Equal(t, 29, available)
+}
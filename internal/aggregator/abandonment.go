@@ -0,0 +1,88 @@
+package aggregator
+
+import "github.com/lukaszraczylo/git-velocity/pkg/models"
+
+// A repository's PR abandonment is high when at least highAbandonmentRate
+// percent of its resolved PRs were closed without merging, out of at least
+// minAbandonmentPRs resolved PRs so that a single closed PR doesn't count
+const (
+	highAbandonmentRate = 30.0
+	minAbandonmentPRs   = 5
+)
+
+// applyAbandonment reports the PRs closed without merging: the share of
+// resolved (merged or closed) PRs they make up and the average hours from
+// opening them to closing them, per contributor and per repository. Runs
+// after the PR loop has counted PRsMerged and PRsClosed.
+func (a *Aggregator) applyAbandonment(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+) {
+	toClose := make(map[*models.ContributorMetrics]*mean)
+	repoToClose := make(map[string]*mean)
+	repoMerged := make(map[string]int)
+	for _, pr := range data.PullRequests {
+		if pr.IsMerged() {
+			repoMerged[pr.Repository]++
+			continue
+		}
+		if pr.State != models.PRStateClosed {
+			continue
+		}
+		rm, ok := repoMap[pr.Repository]
+		if !ok {
+			continue
+		}
+		rm.PRsAbandoned++
+		if pr.ClosedAt == nil || pr.ClosedAt.Before(pr.CreatedAt) {
+			continue
+		}
+		hours := pr.ClosedAt.Sub(pr.CreatedAt).Hours()
+		if repoToClose[pr.Repository] == nil {
+			repoToClose[pr.Repository] = &mean{}
+		}
+		repoToClose[pr.Repository].add(hours)
+		login := pr.Author.Login
+		for _, cm := range []*models.ContributorMetrics{contributorMap[login], repoContributorMap[pr.Repository][login]} {
+			if cm == nil {
+				continue
+			}
+			if toClose[cm] == nil {
+				toClose[cm] = &mean{}
+			}
+			toClose[cm].add(hours)
+		}
+	}
+
+	contributorAbandonment := func(cm *models.ContributorMetrics) {
+		if cm.PRsClosed > 0 {
+			cm.AbandonmentRate = float64(cm.PRsClosed) / float64(cm.PRsMerged+cm.PRsClosed) * 100
+		}
+		if m := toClose[cm]; m != nil {
+			cm.AvgTimeToClose = m.Value()
+		}
+	}
+	for _, cm := range contributorMap {
+		contributorAbandonment(cm)
+	}
+	for _, contributors := range repoContributorMap {
+		for _, rcm := range contributors {
+			contributorAbandonment(rcm)
+		}
+	}
+
+	for repo, rm := range repoMap {
+		resolved := repoMerged[repo] + rm.PRsAbandoned
+		if resolved == 0 {
+			continue
+		}
+		rate := float64(rm.PRsAbandoned) / float64(resolved) * 100
+		rm.AbandonmentRate = &rate
+		rm.HighAbandonment = resolved >= minAbandonmentPRs && rate >= highAbandonmentRate
+		if m := repoToClose[repo]; m != nil {
+			rm.AvgTimeToClose = m.Value()
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_Abandonment(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	hours := func(h int) *time.Time {
+		t := at.Add(time.Duration(h) * time.Hour)
+		return &t
+	}
+	pr := func(repo string, number int, author string, state models.PRState, closedAt *time.Time) models.PullRequest {
+		p := models.PullRequest{
+			Number: number, Author: models.Author{Login: author}, Repository: repo,
+			CreatedAt: at, State: state, ClosedAt: closedAt,
+		}
+		if state == models.PRStateMerged {
+			p.MergedAt = closedAt
+		}
+		return p
+	}
+
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			// acme/api: 3 of 5 resolved PRs abandoned
+			pr("acme/api", 1, "alice", models.PRStateClosed, hours(10)),
+			pr("acme/api", 2, "alice", models.PRStateClosed, hours(20)),
+			pr("acme/api", 3, "bob", models.PRStateClosed, nil), // No close time
+			pr("acme/api", 4, "alice", models.PRStateMerged, hours(2)),
+			pr("acme/api", 5, "bob", models.PRStateMerged, hours(2)),
+			pr("acme/api", 6, "bob", models.PRStateOpen, nil),
+			// acme/web: 1 of 2 abandoned, too few to call out
+			pr("acme/web", 1, "alice", models.PRStateClosed, hours(30)),
+			pr("acme/web", 2, "alice", models.PRStateMerged, hours(1)),
+			// acme/docs: none resolved
+			pr("acme/docs", 1, "bob", models.PRStateOpen, nil),
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	repos := make(map[string]models.RepositoryMetrics)
+	for _, rm := range metrics.Repositories {
+		repos[rm.FullName] = rm
+	}
+	api := repos["acme/api"]
+	assert.Equal(t, 3, api.PRsAbandoned)
+	require.NotNil(t, api.AbandonmentRate)
+	assert.InDelta(t, 60.0, *api.AbandonmentRate, 0.001)
+	assert.InDelta(t, 15.0, api.AvgTimeToClose, 0.001)
+	assert.True(t, api.HighAbandonment)
+
+	web := repos["acme/web"]
+	require.NotNil(t, web.AbandonmentRate)
+	assert.InDelta(t, 50.0, *web.AbandonmentRate, 0.001)
+	assert.False(t, web.HighAbandonment, "too few resolved PRs")
+
+	assert.Nil(t, repos["acme/docs"].AbandonmentRate)
+
+	for _, c := range metrics.Contributors {
+		switch c.Login {
+		case "alice":
+			assert.Equal(t, 3, c.PRsClosed)
+			assert.InDelta(t, 60.0, c.AbandonmentRate, 0.001)
+			assert.InDelta(t, 20.0, c.AvgTimeToClose, 0.001)
+		case "bob":
+			assert.Equal(t, 1, c.PRsClosed)
+			assert.InDelta(t, 50.0, c.AbandonmentRate, 0.001)
+			assert.Zero(t, c.AvgTimeToClose, "no close time")
+		}
+	}
+	for _, c := range api.Contributors {
+		if c.Login == "alice" {
+			assert.InDelta(t, 200.0/3, c.AbandonmentRate, 0.001)
+			assert.InDelta(t, 15.0, c.AvgTimeToClose, 0.001)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package aggregator
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyAdoption sets the stars and forks repositories gained over the period,
+// week by week next to their commits and merged PRs. Totals are the current
+// ones less the stars and forks added after the period.
+func applyAdoption(data *models.RawData, repoMap map[string]*models.RepositoryMetrics, period models.Period) {
+	weeks, start, end := timelineWeeks(period)
+	if len(weeks) == 0 {
+		return
+	}
+	inPeriod := func(t time.Time) bool { return !t.Before(start) && !t.After(end) }
+
+	for name, adoption := range data.Adoption {
+		rm, ok := repoMap[name]
+		if !ok {
+			continue
+		}
+		m := &models.AdoptionMetrics{
+			Stars:     adoption.Stars,
+			Forks:     adoption.Forks,
+			Watchers:  adoption.Watchers,
+			Truncated: adoption.Truncated,
+		}
+
+		stars := make([]float64, len(weeks))
+		for _, at := range adoption.StarredAt {
+			switch {
+			case at.After(end):
+				m.Stars--
+			case inPeriod(at):
+				m.StarsGained++
+				stars[weekIndex(weeks, at)]++
+			}
+		}
+		forks := make([]float64, len(weeks))
+		for _, at := range adoption.ForkedAt {
+			switch {
+			case at.After(end):
+				m.Forks--
+			case inPeriod(at):
+				m.ForksGained++
+				forks[weekIndex(weeks, at)]++
+			}
+		}
+
+		commits := make([]float64, len(weeks))
+		for _, commit := range data.Commits {
+			if commit.Repository == name && inPeriod(commit.Date) {
+				commits[weekIndex(weeks, commit.Date)]++
+			}
+		}
+		merged := make([]float64, len(weeks))
+		for _, pr := range data.PullRequests {
+			if pr.Repository == name && pr.MergedAt != nil && inPeriod(*pr.MergedAt) {
+				merged[weekIndex(weeks, *pr.MergedAt)]++
+			}
+		}
+
+		labels := make([]string, len(weeks))
+		for i, w := range weeks {
+			labels[i] = w.Format("Jan 2")
+		}
+		m.Timeline = &models.VelocityTimeline{
+			Labels: labels,
+			Series: []models.VelocityTimelineSeries{
+				{Name: "Stars", Color: "#f59e0b", Data: stars},
+				{Name: "Forks", Color: "#ec4899", Data: forks},
+				{Name: "Commits", Color: "#10b981", Data: commits},
+				{Name: "PRs Merged", Color: "#3b82f6", Data: merged},
+			},
+		}
+		rm.Adoption = m
+	}
+}
+
+// weekIndex returns the index of the week t falls in
+func weekIndex(weeks []time.Time, t time.Time) int {
+	for i := len(weeks) - 1; i >= 0; i-- {
+		if !t.Before(weeks[i]) {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,67 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_Adoption(t *testing.T) {
+	t.Parallel()
+
+	// Mondays, so every date starts a week of the timeline
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 17, 23, 59, 59, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 12, 0, 0, 0, time.UTC) }
+	merged := day(12)
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: day(5), Repository: "acme/api"},
+			{SHA: "b", Author: models.Author{Login: "alice"}, Date: day(11), Repository: "acme/api"},
+			{SHA: "c", Author: models.Author{Login: "alice"}, Date: day(11), Repository: "acme/web"},
+		},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "alice"}, CreatedAt: day(5), MergedAt: &merged, State: models.PRStateMerged, Repository: "acme/api"},
+		},
+		Adoption: map[string]models.RepositoryAdoption{
+			"acme/api": {
+				Stars: 100, Forks: 10, Watchers: 7,
+				// Two stars after the period, three in it
+				StarredAt: []time.Time{day(25), day(20), day(13), day(12), day(6)},
+				ForkedAt:  []time.Time{day(8)},
+			},
+		},
+	}
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	require.Len(t, metrics.Repositories, 2)
+
+	api := metrics.Repositories[0]
+	require.NotNil(t, api.Adoption)
+	assert.Equal(t, 98, api.Adoption.Stars, "stars at the end of the period")
+	assert.Equal(t, 10, api.Adoption.Forks)
+	assert.Equal(t, 7, api.Adoption.Watchers)
+	assert.Equal(t, 3, api.Adoption.StarsGained)
+	assert.Equal(t, 1, api.Adoption.ForksGained)
+
+	timeline := api.Adoption.Timeline
+	require.NotNil(t, timeline)
+	assert.Equal(t, []string{"Mar 4", "Mar 11"}, timeline.Labels)
+	series := map[string][]float64{}
+	for _, s := range timeline.Series {
+		series[s.Name] = s.Data
+	}
+	assert.Equal(t, []float64{1, 2}, series["Stars"])
+	assert.Equal(t, []float64{1, 0}, series["Forks"])
+	assert.Equal(t, []float64{1, 1}, series["Commits"], "only the repository's commits")
+	assert.Equal(t, []float64{0, 1}, series["PRs Merged"])
+
+	assert.Nil(t, metrics.Repositories[1].Adoption, "not fetched for acme/web")
+}
@@ -1,13 +1,16 @@
 package aggregator
 
 import (
+	"maps"
+	"math"
 	"slices"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/lukaszraczylo/git-velocity/internal/absence"
 	"github.com/lukaszraczylo/git-velocity/internal/config"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
 // UserProfile contains GitHub user profile information for deduplication
@@ -23,6 +26,9 @@ type UserProfile struct {
 type Aggregator struct {
 	config       *config.Config
 	userProfiles map[string]UserProfile // GitHub login -> profile
+
+	// Identities filtered as bots by the last Aggregate
+	bots map[string]*models.BotActivity
 }
 
 // New creates a new Aggregator
@@ -57,10 +63,45 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 	// This helps normalize commit authors to their GitHub usernames
 	emailToLogin := buildEmailToLoginMapping(data, a.userProfiles)
 
+	// Corporate emails of the organization's SAML SSO identities, when enabled
+	identities := a.externalIdentities(data)
+	mapIdentityEmails(data, identities, emailToLogin)
+
+	absences, err := absence.Load(a.config.Contributors)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build login-to-login mapping for sanitized logins (e.g., lukasz-raczylo -> lukaszraczylo)
 	// Also returns verified login info with avatar URLs
 	loginToLogin, loginToInfo := buildLoginMapping(data)
 
+	// Drop bot activity, now that commit authors can be resolved to logins
+	raw := data
+	data = a.withoutBots(data, emailToLogin, loginToLogin)
+
+	// Count shared service accounts towards their team rather than a person
+	data = a.withServiceAccounts(data, emailToLogin, loginToLogin)
+
+	// Resolve commit authors the way the commit loop below does
+	commitLogin := func(commit models.Commit) string {
+		login := commit.Author.Login
+		if mappedLogin, ok := emailToLogin[commit.Author.Email]; ok {
+			login = mappedLogin
+		}
+		if mappedLogin, ok := loginToLogin[login]; ok {
+			login = mappedLogin
+		}
+		return login
+	}
+
+	// Drop the activity of contributors outside the organizations when excluded
+	members := a.orgMembers(raw)
+	data = a.withoutExternal(data, members, commitLogin)
+
+	// Patches the same contributor applied to several repositories
+	spread, duplicates := a.patchCopies(data, commitLogin)
+
 	// Build contributor map (global stats across all repos)
 	contributorMap := make(map[string]*models.ContributorMetrics)
 	repoMap := make(map[string]*models.RepositoryMetrics)
@@ -73,8 +114,20 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 	// Per-repo activity days
 	repoActivityDays := make(map[string]map[string]map[string]bool) // repo -> login -> set of date strings
 
+	// Daily activity per contributor and repository, for rolling averages
+	activity := newActivityLog(a.config.Scoring)
+
+	// Activity timestamps per contributor, for recency weighting and the
+	// membership share of joiners and leavers
+	activityTimes := make(map[string][]time.Time)
+	decayHalfLife := a.config.Scoring.DecayHalfLifeDays
+	memberships := a.memberships()
+
 	// Helper to track activity day for a contributor
 	trackActivityDay := func(login, repo string, date time.Time) {
+		if _, ok := memberships[strings.ToLower(login)]; ok || decayHalfLife > 0 {
+			activityTimes[login] = append(activityTimes[login], date)
+		}
 		dateStr := date.Format("2006-01-02")
 		// Global activity tracking
 		if activityDays[login] == nil {
@@ -98,12 +151,9 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 	// Per-repo unique files per contributor
 	repoContributorFiles := make(map[string]map[string]map[string]bool) // repo -> login -> set of file paths
 
-	// Track counts of items with valid time data (for accurate average calculations)
-	// These track only PRs/reviews that have valid time data, not total counts
-	reviewsWithResponseTime := make(map[string]int)         // login -> count of reviews with valid ResponseTime
-	repoReviewsWithResponseTime := make(map[string]map[string]int) // repo -> login -> count
-	prsWithTimeToMerge := make(map[string]int)              // login -> count of PRs with valid TimeToMerge
-	repoPRsWithTimeToMerge := make(map[string]map[string]int)      // repo -> login -> count
+	// Durations averaged once every PR and review is counted, only over
+	// the PRs and reviews that have them
+	times := newTimingsLog()
 
 	// Helper to get or create per-repo contributor
 	getRepoContributor := func(repo, login, name, avatarURL string) *models.ContributorMetrics {
@@ -167,16 +217,10 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 		}
 
 		cm := contributorMap[login]
-		cm.CommitCount++
-		if commit.HasTests {
-			cm.CommitsWithTests++
-		}
-		cm.LinesAdded += commit.Additions
-		cm.LinesDeleted += commit.Deletions
-		cm.MeaningfulLinesAdded += commit.MeaningfulAdditions
-		cm.MeaningfulLinesDeleted += commit.MeaningfulDeletions
-		cm.CommentLinesAdded += commit.CommentAdditions
-		cm.CommentLinesDeleted += commit.CommentDeletions
+		// A patch copied to several repositories counts once towards the contributor
+		if !duplicates[commit.Repository+"@"+commit.SHA] {
+			countCommit(cm, &commit)
+		}
 		// Track unique files (don't sum - we'll count unique files at the end)
 		if contributorFiles[login] == nil {
 			contributorFiles[login] = make(map[string]bool)
@@ -187,16 +231,7 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 
 		// Update per-repo contributor stats
 		rcm := getRepoContributor(commit.Repository, login, cm.Name, cm.AvatarURL)
-		rcm.CommitCount++
-		if commit.HasTests {
-			rcm.CommitsWithTests++
-		}
-		rcm.LinesAdded += commit.Additions
-		rcm.LinesDeleted += commit.Deletions
-		rcm.MeaningfulLinesAdded += commit.MeaningfulAdditions
-		rcm.MeaningfulLinesDeleted += commit.MeaningfulDeletions
-		rcm.CommentLinesAdded += commit.CommentAdditions
-		rcm.CommentLinesDeleted += commit.CommentDeletions
+		countCommit(rcm, &commit)
 		// Track unique files per repo (don't sum - we'll count unique files at the end)
 		if repoContributorFiles[commit.Repository] == nil {
 			repoContributorFiles[commit.Repository] = make(map[string]map[string]bool)
@@ -208,68 +243,9 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 			repoContributorFiles[commit.Repository][login][filePath] = true
 		}
 
-		// Track activity patterns based on commit time
-		hour := commit.Date.Hour()
-		weekday := commit.Date.Weekday()
-
-		// Early bird: commits between 6am-9am (for achievements)
-		// Aligned with the early morning multiplier range
-		if hour >= 6 && hour < 9 {
-			cm.EarlyBirdCount++
-			rcm.EarlyBirdCount++
-		}
-		// Night owl: commits after 9pm (for achievements)
-		if hour >= 21 || hour < 5 {
-			cm.NightOwlCount++
-			rcm.NightOwlCount++
-		}
-		// Nosferatu: commits between midnight and 4am (for achievements)
-		if hour >= 0 && hour < 4 {
-			cm.MidnightCount++
-			rcm.MidnightCount++
-		}
-		// Weekend warrior
-		if weekday == time.Saturday || weekday == time.Sunday {
-			cm.WeekendWarrior++
-			rcm.WeekendWarrior++
-		}
-		// Out of hours: commits outside 9am-5pm (legacy, kept for achievements)
-		if hour < 9 || hour >= 17 {
-			cm.OutOfHoursCount++
-			rcm.OutOfHoursCount++
-		}
-
-		// Time-based commit counts for multiplier scoring:
-		// - 9am-5pm (9-16): Regular hours x1
-		// - 5pm-9pm (17-20): Evening x2
-		// - 9pm-midnight (21-23): Late night x2.5
-		// - midnight-6am (0-5): Overnight x5
-		// - 6am-9am (6-8): Early morning x2
-		switch {
-		case hour >= 9 && hour < 17:
-			// Regular hours: 9am-5pm (x1)
-			cm.RegularHoursCount++
-			rcm.RegularHoursCount++
-		case hour >= 17 && hour < 21:
-			// Evening: 5pm-9pm (x2)
-			cm.EveningCount++
-			rcm.EveningCount++
-		case hour >= 21 && hour <= 23:
-			// Late night: 9pm-midnight (x2.5)
-			cm.LateNightCount++
-			rcm.LateNightCount++
-		case hour >= 0 && hour < 6:
-			// Overnight: midnight-6am (x5)
-			cm.OvernightCount++
-			rcm.OvernightCount++
-		case hour >= 6 && hour < 9:
-			// Early morning: 6am-9am (x2)
-			cm.EarlyMorningCount++
-			rcm.EarlyMorningCount++
-		}
-
 		// Track activity day for this commit
 		trackActivityDay(login, commit.Repository, commit.Date)
+		activity.commit(login, &commit)
 
 		// Track repository participation
 		if !slices.Contains(cm.RepositoriesContributed, commit.Repository) {
@@ -289,9 +265,11 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 	// Calculate active days and streaks for each contributor
 	for login, days := range activityDays {
 		if cm, ok := contributorMap[login]; ok {
+			off := absences.For(login)
 			cm.ActiveDays = len(days)
-			cm.LongestStreak, cm.CurrentStreak = calculateStreaks(days)
-			cm.WorkWeekStreak = calculateWorkWeekStreak(days)
+			cm.LongestStreak, cm.CurrentStreak = calculateStreaks(days, off)
+			cm.WorkWeekStreak = calculateWorkWeekStreak(days, off)
+			setAvailability(cm, days, off, period)
 		}
 	}
 
@@ -331,6 +309,7 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 
 		// Track activity day for PR creation
 		trackActivityDay(login, pr.Repository, pr.CreatedAt)
+		activity.pullRequest(login, &pr)
 
 		prSize := pr.Additions + pr.Deletions
 
@@ -338,15 +317,7 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 			cm.PRsMerged++
 			rcm.PRsMerged++
 			if pr.TimeToMerge != nil {
-				// Accumulate for average calculation
-				cm.AvgTimeToMerge += pr.TimeToMerge.Hours()
-				rcm.AvgTimeToMerge += pr.TimeToMerge.Hours()
-				// Track count of PRs with valid time data for accurate average
-				prsWithTimeToMerge[login]++
-				if repoPRsWithTimeToMerge[pr.Repository] == nil {
-					repoPRsWithTimeToMerge[pr.Repository] = make(map[string]int)
-				}
-				repoPRsWithTimeToMerge[pr.Repository][login]++
+				times.timeToMerge(pr.Repository, login, pr.TimeToMerge.Hours())
 			}
 
 			// Track largest PR
@@ -405,6 +376,7 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 
 		// Track activity day for review submission
 		trackActivityDay(login, review.Repository, review.SubmittedAt)
+		activity.review(login, &review)
 
 		if review.IsApproval() {
 			cm.ApprovalsGiven++
@@ -427,14 +399,7 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 		}
 
 		if review.ResponseTime != nil {
-			cm.AvgReviewTime += review.ResponseTime.Hours()
-			rcm.AvgReviewTime += review.ResponseTime.Hours()
-			// Track count of reviews with valid time data for accurate average
-			reviewsWithResponseTime[login]++
-			if repoReviewsWithResponseTime[review.Repository] == nil {
-				repoReviewsWithResponseTime[review.Repository] = make(map[string]int)
-			}
-			repoReviewsWithResponseTime[review.Repository][login]++
+			times.reviewTime(review.Repository, login, review.ResponseTime.Hours())
 		}
 
 		// Track unique reviewees
@@ -476,6 +441,10 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 
 	// Process issues
 	for _, issue := range data.Issues {
+		// Update repository metrics, whether or not the author is known
+		a.updateRepoMetrics(repoMap, issue.Repository, period)
+		repoMap[issue.Repository].TotalIssues++
+
 		login := issue.Author.Login
 		if login == "" {
 			continue
@@ -552,6 +521,7 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 
 		cm := contributorMap[login]
 		cm.IssueComments++
+		a.updateRepoMetrics(repoMap, comment.Repository, period)
 
 		// Track activity day for issue comment
 		trackActivityDay(login, comment.Repository, comment.CreatedAt)
@@ -601,6 +571,60 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 		}
 	}
 
+	// Credit for rolling a patch out to several repositories
+	applyPatchPropagation(spread, contributorMap)
+
+	// Linear issue references and completion credit (no-op unless integration is enabled)
+	a.applyLinearMetrics(data, contributorMap, repoContributorMap, commitLogin)
+
+	// Broken and fixed default branch builds (no-op unless build statuses were fetched)
+	a.applyBuildMetrics(data, contributorMap, repoContributorMap, repoMap)
+
+	// Coverage deltas of merged PRs (no-op unless coverage was fetched)
+	a.applyCoverageMetrics(data, contributorMap, repoContributorMap, repoMap)
+
+	// Commits pushed to default branches without a PR (no-op unless mainlines were read)
+	a.applyDirectPushes(data, contributorMap, repoContributorMap, repoMap, commitLogin)
+
+	// Merged PRs bypassing review
+	a.applyMergeCompliance(data, repoMap)
+
+	// Auto-merge and merge queue adoption, after compliance to credit only compliant merges
+	a.applyAutoMerge(data, contributorMap, repoContributorMap, repoMap)
+
+	// Estimated effort of merged PRs (no-op unless the effort hook ran)
+	a.applyEffort(data, contributorMap, repoContributorMap, repoMap)
+
+	// PRs closed without merging
+	a.applyAbandonment(data, contributorMap, repoContributorMap, repoMap)
+
+	// Merged PRs linking an issue
+	a.applyTraceability(data, contributorMap, repoContributorMap, repoMap)
+
+	// Kudos reactions given and received (no-op unless reactions were fetched)
+	a.applyKudos(data, contributorMap, repoContributorMap)
+
+	// Tone of review comments (no-op unless options.review_tone is enabled)
+	a.applyReviewTone(data, contributorMap, repoContributorMap, repoMap)
+
+	// Review rounds of reviewed PRs
+	a.applyReviewIterations(data, contributorMap, repoContributorMap, repoMap)
+
+	// Time spent in draft and drafts abandoned (transitions need use_graphql)
+	a.applyDraftMetrics(data, contributorMap, repoContributorMap, period.End)
+
+	// Lint findings fixed over the period (no-op unless lint reports were collected)
+	a.applyLintMetrics(data, contributorMap, repoContributorMap, repoMap, commitLogin)
+
+	// Security fixes, including approved bot security updates
+	a.applySecurityMetrics(raw, data, contributorMap, repoContributorMap, repoMap, commitLogin)
+
+	// Most churned files per repository and the work shrinking them
+	a.applyHotspots(data, contributorMap, repoContributorMap, repoMap, commitLogin)
+
+	// Refactoring commits (Code Gardener)
+	a.applyRefactoringMetrics(data, contributorMap, repoContributorMap, commitLogin)
+
 	// Build reverse mapping: raw PR author login -> normalized login
 	// This is needed because contributorMap keys are normalized but pr.Author.Login is not
 	prAuthorToNormalizedLogin := make(map[string]string)
@@ -619,15 +643,8 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 
 	// Calculate averages and finalize contributor metrics
 	for login, cm := range contributorMap {
-		// Calculate average time to merge (only from PRs that have TimeToMerge data)
-		if count := prsWithTimeToMerge[login]; count > 0 {
-			cm.AvgTimeToMerge = cm.AvgTimeToMerge / float64(count)
-		}
-
-		// Calculate average review time (only from reviews that have ResponseTime data)
-		if count := reviewsWithResponseTime[login]; count > 0 {
-			cm.AvgReviewTime = cm.AvgReviewTime / float64(count)
-		}
+		// Average time to merge and review time
+		times.global[login].apply(cm)
 
 		// Calculate average PR size (only for merged PRs to exclude abandoned PRs)
 		if cm.PRsMerged > 0 {
@@ -652,17 +669,78 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 		if reviewees, ok := reviewerReviewees[login]; ok {
 			cm.UniqueReviewees = len(reviewees)
 		}
+
+		// List repositories by name rather than by first activity
+		slices.Sort(cm.RepositoriesContributed)
 	}
 
+	// Flag contributors outside the organizations
+	markExternal(members, contributorMap, repoContributorMap)
+
+	// Community contributions and maintainer responsiveness (no-op unless enabled)
+	community := a.applyCommunityMetrics(data, members, contributorMap, repoContributorMap, repoMap)
+
+	// Weight activity by recency
+	if decayHalfLife > 0 {
+		start := period.Start
+		if start.IsZero() {
+			// Open-ended periods start at the earliest activity
+			for _, times := range activityTimes {
+				for _, t := range times {
+					if start.IsZero() || t.Before(start) {
+						start = t
+					}
+				}
+			}
+		}
+		for login, times := range activityTimes {
+			if cm, ok := contributorMap[login]; ok {
+				cm.RecencyWeight = recencyWeight(times, start, period.End, decayHalfLife)
+			}
+		}
+	}
+
+	// Activity of joiners and leavers within their membership, for pro-rating
+	for login, times := range activityTimes {
+		m, ok := memberships[strings.ToLower(login)]
+		if cm, exists := contributorMap[login]; ok && exists {
+			cm.MembershipShare = m.share(times)
+		}
+	}
+
+	// Rolling averages and trends over the end of the period
+	for login, days := range activity.contributors {
+		if cm, ok := contributorMap[login]; ok {
+			cm.Rolling, cm.Trend = rollingAverages(days, period.End)
+			cm.Bests = personalBests(days)
+		}
+	}
+	for repo, days := range activity.repos {
+		if rm, ok := repoMap[repo]; ok {
+			rm.Rolling, rm.Trend = rollingAverages(days, period.End)
+		}
+	}
+
+	// Contributors kept off the leaderboards, still counted in totals
+	a.markOptedOut(data, contributorMap, repoContributorMap)
+
+	// Display names from the identity provider
+	nameFromIdentities(identities, contributorMap, repoContributorMap)
+
 	// Convert maps to slices
 	var contributors []models.ContributorMetrics
-	for _, cm := range contributorMap {
-		contributors = append(contributors, *cm)
+	for login, cm := range contributorMap {
+		if !isServiceAccountLogin(login) {
+			contributors = append(contributors, *cm)
+		}
 	}
 
 	// Sort contributors by commit count
 	sort.Slice(contributors, func(i, j int) bool {
-		return contributors[i].CommitCount > contributors[j].CommitCount
+		if contributors[i].CommitCount != contributors[j].CommitCount {
+			return contributors[i].CommitCount > contributors[j].CommitCount
+		}
+		return contributors[i].Login < contributors[j].Login
 	})
 
 	// Calculate per-repo contributor averages and streaks
@@ -671,9 +749,11 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 		if repoDays, ok := repoActivityDays[repo]; ok {
 			for login, days := range repoDays {
 				if rcm, ok := repoContribs[login]; ok {
+					off := absences.For(login)
 					rcm.ActiveDays = len(days)
-					rcm.LongestStreak, rcm.CurrentStreak = calculateStreaks(days)
-					rcm.WorkWeekStreak = calculateWorkWeekStreak(days)
+					rcm.LongestStreak, rcm.CurrentStreak = calculateStreaks(days, off)
+					rcm.WorkWeekStreak = calculateWorkWeekStreak(days, off)
+					setAvailability(rcm, days, off, period)
 				}
 			}
 		}
@@ -689,18 +769,8 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 
 		// Calculate averages for per-repo contributors
 		for login, rcm := range repoContribs {
-			// Use count of PRs with valid time data for accurate average
-			if repoPRCounts, ok := repoPRsWithTimeToMerge[repo]; ok {
-				if count := repoPRCounts[login]; count > 0 {
-					rcm.AvgTimeToMerge = rcm.AvgTimeToMerge / float64(count)
-				}
-			}
-			// Use count of reviews with valid time data for accurate average
-			if repoReviewCounts, ok := repoReviewsWithResponseTime[repo]; ok {
-				if count := repoReviewCounts[login]; count > 0 {
-					rcm.AvgReviewTime = rcm.AvgReviewTime / float64(count)
-				}
-			}
+			// Average time to merge and review time in this repository
+			times.repos[repo][login].apply(rcm)
 
 			// Calculate average PR size for this repo (only for merged PRs to exclude abandoned PRs)
 			if rcm.PRsMerged > 0 {
@@ -738,30 +808,44 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 		}
 	}
 
+	a.applyRepositoryHealth(data, repoMap, period)
+
+	// Stars and forks gained next to development activity (no-op unless fetched)
+	applyAdoption(data, repoMap, period)
+
 	var repositories []models.RepositoryMetrics
 	for _, rm := range repoMap {
 		// Add per-repo contributors (with repo-specific stats)
 		if repoContribs, ok := repoContributorMap[rm.FullName]; ok {
-			for _, rcm := range repoContribs {
-				rm.Contributors = append(rm.Contributors, *rcm)
+			for login, rcm := range repoContribs {
+				if !isServiceAccountLogin(login) {
+					rm.Contributors = append(rm.Contributors, *rcm)
+				}
 			}
 		}
 		// Sort contributors by commit count
 		sort.Slice(rm.Contributors, func(i, j int) bool {
-			return rm.Contributors[i].CommitCount > rm.Contributors[j].CommitCount
+			if rm.Contributors[i].CommitCount != rm.Contributors[j].CommitCount {
+				return rm.Contributors[i].CommitCount > rm.Contributors[j].CommitCount
+			}
+			return rm.Contributors[i].Login < rm.Contributors[j].Login
 		})
 		rm.ActiveContributors = len(rm.Contributors)
 		repositories = append(repositories, *rm)
 	}
+	sort.Slice(repositories, func(i, j int) bool {
+		return repositories[i].FullName < repositories[j].FullName
+	})
 
 	// Build team metrics
 	var teams []models.TeamMetrics
-	for _, teamCfg := range a.config.Teams {
+	for _, teamCfg := range a.teamConfigs(contributorMap) {
 		team := models.TeamMetrics{
-			Name:    teamCfg.Name,
-			Color:   teamCfg.Color,
-			Members: teamCfg.Members,
-			Period:  period,
+			Name:     teamCfg.Name,
+			Color:    teamCfg.Color,
+			Members:  teamCfg.Members,
+			Period:   period,
+			Capacity: teamCfg.Capacity,
 		}
 
 		var totalScore int
@@ -780,6 +864,16 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 			}
 		}
 
+		if cm, ok := contributorMap[serviceAccountLogin(teamCfg.Name)]; ok {
+			team.ServiceAccounts = cm
+			team.AggregatedMetrics.CommitCount += cm.CommitCount
+			team.AggregatedMetrics.LinesAdded += cm.LinesAdded
+			team.AggregatedMetrics.LinesDeleted += cm.LinesDeleted
+			team.AggregatedMetrics.PRsOpened += cm.PRsOpened
+			team.AggregatedMetrics.PRsMerged += cm.PRsMerged
+			team.AggregatedMetrics.ReviewsGiven += cm.ReviewsGiven
+		}
+
 		team.TotalScore = totalScore
 		if len(team.MemberMetrics) > 0 {
 			team.AvgScore = float64(totalScore) / float64(len(team.MemberMetrics))
@@ -788,6 +882,27 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 		teams = append(teams, team)
 	}
 
+	// Activity inside the paths teams own, whoever did it
+	a.applyPathOwnership(data, teams, commitLogin)
+
+	// When reviews happen and how long they take, overall and per team
+	reviewLatency := applyReviewLatency(data, teams)
+
+	// Reviews across distant time zones per team
+	a.applyTimezoneCollaboration(data, teams, period, commitLogin, loginToLogin)
+
+	// Roll up products and portfolios of repositories
+	groups := buildGroups(a.config.Groups, repositories, period)
+
+	// Repositories requiring each other's packages (nil unless manifests were read)
+	dependencies := applyDependencies(data, repositories, teams)
+
+	// Changelog of the merged PRs, for RELEASE_NOTES.md
+	var releaseNotes *models.ReleaseNotes
+	if a.config.HasOutputFormat(config.FormatReleaseNotes) {
+		releaseNotes = buildReleaseNotes(data, period)
+	}
+
 	// Calculate totals
 	var totalCommits, totalPRs, totalReviews, totalLinesAdded, totalLinesDeleted int
 	var totalMeaningfulLinesAdded, totalMeaningfulLinesDeleted int
@@ -803,12 +918,21 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 
 	// Build velocity timeline (weekly aggregation)
 	velocityTimeline := buildVelocityTimeline(data, period, a.config.Scoring)
+	a.addForecasts(velocityTimeline, activity, repositories, teams, period)
 
-	return &models.GlobalMetrics{
+	// Achievements earned from period activity only
+	if a.config.Scoring.AchievementScope == config.AchievementScopePeriod {
+		if err := a.applyPeriodActivity(raw, dateRange, repositories, contributors); err != nil {
+			return nil, err
+		}
+	}
+
+	metrics := &models.GlobalMetrics{
 		Period:                      period,
 		Repositories:                repositories,
 		Contributors:                contributors,
 		Teams:                       teams,
+		Groups:                      groups,
 		TotalContributors:           len(contributors),
 		TotalCommits:                totalCommits,
 		TotalPRs:                    totalPRs,
@@ -818,7 +942,84 @@ func (a *Aggregator) Aggregate(data *models.RawData, dateRange *config.ParsedDat
 		TotalMeaningfulLinesAdded:   totalMeaningfulLinesAdded,
 		TotalMeaningfulLinesDeleted: totalMeaningfulLinesDeleted,
 		VelocityTimeline:            velocityTimeline,
-	}, nil
+		Community:                   community,
+		Dependencies:                dependencies,
+		ReleaseNotes:                releaseNotes,
+		ReviewLatency:               reviewLatency,
+	}
+	if err := ApplyCustomMetrics(metrics, a.config.CustomMetrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// countCommit adds a commit's count, line changes and time-of-day patterns to m
+func countCommit(m *models.ContributorMetrics, commit *models.Commit) {
+	m.CommitCount++
+	if commit.HasTests {
+		m.CommitsWithTests++
+	}
+	m.LinesAdded += commit.Additions
+	m.LinesDeleted += commit.Deletions
+	m.MeaningfulLinesAdded += commit.MeaningfulAdditions
+	m.MeaningfulLinesDeleted += commit.MeaningfulDeletions
+	m.CommentLinesAdded += commit.CommentAdditions
+	m.CommentLinesDeleted += commit.CommentDeletions
+
+	// Track activity patterns based on commit time
+	hour := commit.Date.Hour()
+	weekday := commit.Date.Weekday()
+
+	// Early bird: commits between 6am-9am (for achievements)
+	// Aligned with the early morning multiplier range
+	if hour >= 6 && hour < 9 {
+		m.EarlyBirdCount++
+	}
+	// Night owl: commits after 9pm (for achievements)
+	if hour >= 21 || hour < 5 {
+		m.NightOwlCount++
+	}
+	// Nosferatu: commits between midnight and 4am (for achievements)
+	if hour >= 0 && hour < 4 {
+		m.MidnightCount++
+	}
+	// Weekend warrior
+	if weekday == time.Saturday || weekday == time.Sunday {
+		m.WeekendWarrior++
+	}
+	// Out of hours: commits outside 9am-5pm (legacy, kept for achievements)
+	if hour < 9 || hour >= 17 {
+		m.OutOfHoursCount++
+	}
+
+	// Time-based commit counts for multiplier scoring:
+	// - 9am-5pm (9-16): Regular hours x1
+	// - 5pm-9pm (17-20): Evening x2
+	// - 9pm-midnight (21-23): Late night x2.5
+	// - midnight-6am (0-5): Overnight x5
+	// - 6am-9am (6-8): Early morning x2
+	switch {
+	case hour >= 9 && hour < 17:
+
+		// Regular hours: 9am-5pm (x1)
+		m.RegularHoursCount++
+	case hour >= 17 && hour < 21:
+
+		// Evening: 5pm-9pm (x2)
+		m.EveningCount++
+	case hour >= 21 && hour <= 23:
+
+		// Late night: 9pm-midnight (x2.5)
+		m.LateNightCount++
+	case hour >= 0 && hour < 6:
+
+		// Overnight: midnight-6am (x5)
+		m.OvernightCount++
+	case hour >= 6 && hour < 9:
+
+		// Early morning: 6am-9am (x2)
+		m.EarlyMorningCount++
+	}
 }
 
 func (a *Aggregator) updateRepoMetrics(repoMap map[string]*models.RepositoryMetrics, fullName string, period models.Period) {
@@ -992,12 +1193,14 @@ func buildEmailToLoginMapping(data *models.RawData, userProfiles map[string]User
 		}
 	}
 
-	// For each email not yet mapped, check if ANY name matches a verified login
-	for email, names := range emailToNames {
+	// For each email not yet mapped, check if ANY name matches a verified login,
+	// trying names in order so that the same login wins every run
+	for email, nameSet := range emailToNames {
 		if mapping[email] != "" {
 			continue
 		}
-		for name := range names {
+		names := slices.Sorted(maps.Keys(nameSet))
+		for _, name := range names {
 			// Clean up name (remove quotes, trim)
 			nameLower := strings.ToLower(strings.Trim(name, "\"' "))
 			if verifiedLogin, ok := verifiedLogins[nameLower]; ok {
@@ -1008,12 +1211,12 @@ func buildEmailToLoginMapping(data *models.RawData, userProfiles map[string]User
 
 		// Still not mapped? Try fuzzy matching by normalizing name (removing spaces, hyphens)
 		if mapping[email] == "" {
-			for name := range names {
+			for _, name := range names {
 				// Normalize: lowercase, remove spaces, hyphens, underscores
 				normalized := normalizeForComparison(name)
-				for verifiedLower, verifiedLogin := range verifiedLogins {
+				for _, verifiedLower := range slices.Sorted(maps.Keys(verifiedLogins)) {
 					if normalized == normalizeForComparison(verifiedLower) {
-						mapping[email] = verifiedLogin
+						mapping[email] = verifiedLogins[verifiedLower]
 						break
 					}
 				}
@@ -1284,11 +1487,12 @@ func buildLoginMapping(data *models.RawData) (map[string]string, map[string]logi
 	return loginMapping, loginToInfo
 }
 
-// buildVelocityTimeline creates weekly aggregated velocity data for trend visualization
-func buildVelocityTimeline(data *models.RawData, period models.Period, scoringConfig config.ScoringConfig) *models.VelocityTimeline {
+// timelineWeeks returns the starts of the weeks the velocity timeline covers,
+// and the range of activity it counts
+func timelineWeeks(period models.Period) (weeks []time.Time, start, end time.Time) {
 	// Determine date range
-	start := period.Start
-	end := period.End
+	start = period.Start
+	end = period.End
 
 	// Ensure we have valid dates
 	if start.IsZero() {
@@ -1309,11 +1513,15 @@ func buildVelocityTimeline(data *models.RawData, period models.Period, scoringCo
 	weekStart = time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, weekStart.Location())
 
 	// Build list of weeks
-	var weeks []time.Time
 	for w := weekStart; w.Before(end) || w.Equal(end); w = w.AddDate(0, 0, 7) {
 		weeks = append(weeks, w)
 	}
+	return weeks, start, end
+}
 
+// buildVelocityTimeline creates weekly aggregated velocity data for trend visualization
+func buildVelocityTimeline(data *models.RawData, period models.Period, scoringConfig config.ScoringConfig) *models.VelocityTimeline {
+	weeks, start, end := timelineWeeks(period)
 	if len(weeks) == 0 {
 		return nil
 	}
@@ -1334,65 +1542,7 @@ func buildVelocityTimeline(data *models.RawData, period models.Period, scoringCo
 		return 0
 	}
 
-	// Get scoring points from config (defaults are in PointsConfig struct)
-	pointsCommit := scoringConfig.Points.Commit
-	pointsPROpened := scoringConfig.Points.PROpened
-	pointsPRMerged := scoringConfig.Points.PRMerged
-	pointsReview := scoringConfig.Points.PRReviewed
-
-	// Use defaults if zero
-	if pointsCommit == 0 {
-		pointsCommit = 10
-	}
-	if pointsPROpened == 0 {
-		pointsPROpened = 25
-	}
-	if pointsPRMerged == 0 {
-		pointsPRMerged = 50
-	}
-	if pointsReview == 0 {
-		pointsReview = 30
-	}
-
-	// Get time-based multipliers with defaults
-	multRegular := scoringConfig.Points.MultiplierRegularHours
-	if multRegular == 0 {
-		multRegular = 1.0
-	}
-	multEvening := scoringConfig.Points.MultiplierEvening
-	if multEvening == 0 {
-		multEvening = 2.0
-	}
-	multLateNight := scoringConfig.Points.MultiplierLateNight
-	if multLateNight == 0 {
-		multLateNight = 2.5
-	}
-	multOvernight := scoringConfig.Points.MultiplierOvernight
-	if multOvernight == 0 {
-		multOvernight = 5.0
-	}
-	multEarlyMorning := scoringConfig.Points.MultiplierEarlyMorning
-	if multEarlyMorning == 0 {
-		multEarlyMorning = 2.0
-	}
-
-	// Helper to get time-based multiplier for a commit
-	getTimeMultiplier := func(hour int) float64 {
-		switch {
-		case hour >= 9 && hour < 17:
-			return multRegular // Regular hours: 9am-5pm
-		case hour >= 17 && hour < 21:
-			return multEvening // Evening: 5pm-9pm
-		case hour >= 21 && hour <= 23:
-			return multLateNight // Late night: 9pm-midnight
-		case hour >= 0 && hour < 6:
-			return multOvernight // Overnight: midnight-6am
-		case hour >= 6 && hour < 9:
-			return multEarlyMorning // Early morning: 6am-9am
-		default:
-			return multRegular
-		}
-	}
+	points := newEventPoints(scoringConfig)
 
 	// Aggregate commits by week (with time-based multipliers)
 	for _, commit := range data.Commits {
@@ -1403,28 +1553,20 @@ func buildVelocityTimeline(data *models.RawData, period models.Period, scoringCo
 		if idx >= 0 && idx < len(weeks) {
 			weekCommits[idx]++
 			// Apply time-based multiplier to commit score
-			multiplier := getTimeMultiplier(commit.Date.Hour())
-			weekScore[idx] += float64(pointsCommit) * multiplier
+			weekScore[idx] += points.commitAt(commit.Date.Hour())
 		}
 	}
 
 	// Aggregate PRs by week (use merged date if available, otherwise created date)
-	for _, pr := range data.PullRequests {
-		prDate := pr.CreatedAt
-		if pr.MergedAt != nil {
-			prDate = *pr.MergedAt
-		}
+	for i := range data.PullRequests {
+		prDate, prPoints := points.pullRequest(&data.PullRequests[i])
 		if prDate.Before(start) || prDate.After(end) {
 			continue
 		}
 		idx := findWeekIndex(prDate)
 		if idx >= 0 && idx < len(weeks) {
 			weekPRs[idx]++
-			if pr.IsMerged() {
-				weekScore[idx] += float64(pointsPRMerged)
-			} else {
-				weekScore[idx] += float64(pointsPROpened)
-			}
+			weekScore[idx] += prPoints
 		}
 	}
 
@@ -1436,7 +1578,7 @@ func buildVelocityTimeline(data *models.RawData, period models.Period, scoringCo
 		idx := findWeekIndex(review.SubmittedAt)
 		if idx >= 0 && idx < len(weeks) {
 			weekReviews[idx]++
-			weekScore[idx] += float64(pointsReview)
+			weekScore[idx] += points.review
 		}
 	}
 
@@ -1457,9 +1599,63 @@ func buildVelocityTimeline(data *models.RawData, period models.Period, scoringCo
 	}
 }
 
+// setAvailability records the out-of-office and available days of the period
+// and the percentage of available days the contributor was active on
+func setAvailability(cm *models.ContributorMetrics, days map[string]bool, off absence.Calendar, period models.Period) {
+	cm.AbsenceDays, cm.AvailableDays = off.Available(period)
+	if cm.AvailableDays == 0 {
+		return
+	}
+	active := 0
+	for day := range days {
+		if !off[day] {
+			active++
+		}
+	}
+	cm.ActivityRate = float64(min(active, cm.AvailableDays)) / float64(cm.AvailableDays) * 100
+}
+
+// recencyWeight returns the mean exponential-decay weight of the activity
+// times, relative to the mean weight of activity spread evenly over the
+// period, so steady contributors keep a weight of 1 while mostly recent
+// activity weighs more and mostly old activity less
+func recencyWeight(times []time.Time, start, end time.Time, halfLifeDays float64) float64 {
+	span := end.Sub(start).Hours()
+	halfLife := halfLifeDays * 24
+	if len(times) == 0 || span <= 0 || halfLife <= 0 {
+		return 1
+	}
+
+	var sum float64
+	for _, t := range times {
+		age := end.Sub(t).Hours()
+		age = math.Min(math.Max(age, 0), span)
+		sum += math.Pow(0.5, age/halfLife)
+	}
+	mean := sum / float64(len(times))
+
+	// Mean weight of a uniform distribution over the period
+	uniform := halfLife / (span * math.Ln2) * (1 - math.Pow(0.5, span/halfLife))
+	return math.Round(mean/uniform*100) / 100
+}
+
+// bridged reports whether every day between from and to (both excluded) is
+// an out-of-office day, so a streak carries over the gap
+func bridged(from, to time.Time, off absence.Calendar) bool {
+	if len(off) == 0 {
+		return false
+	}
+	for d := from.AddDate(0, 0, 1); d.Before(to); d = d.AddDate(0, 0, 1) {
+		if !off.Has(d) {
+			return false
+		}
+	}
+	return true
+}
+
 // calculateWorkWeekStreak calculates the longest streak of consecutive weekdays
-// Weekends (Sat/Sun) don't break the streak - they're simply skipped
-func calculateWorkWeekStreak(days map[string]bool) int {
+// Weekends (Sat/Sun) and out-of-office days don't break the streak - they're simply skipped
+func calculateWorkWeekStreak(days map[string]bool, off absence.Calendar) int {
 	if len(days) == 0 {
 		return 0
 	}
@@ -1505,8 +1701,9 @@ func calculateWorkWeekStreak(days map[string]bool) int {
 
 		// Calculate expected next weekday
 		expectedNext := prev.AddDate(0, 0, 1)
-		// Skip over weekend days
-		for expectedNext.Weekday() == time.Saturday || expectedNext.Weekday() == time.Sunday {
+		// Skip over weekend and out-of-office days
+		for expectedNext.Weekday() == time.Saturday || expectedNext.Weekday() == time.Sunday ||
+			(off.Has(expectedNext) && expectedNext.Before(curr)) {
 			expectedNext = expectedNext.AddDate(0, 0, 1)
 		}
 
@@ -1524,8 +1721,9 @@ func calculateWorkWeekStreak(days map[string]bool) int {
 	return longest
 }
 
-// calculateStreaks calculates the longest and current streak of consecutive days
-func calculateStreaks(days map[string]bool) (longest, current int) {
+// calculateStreaks calculates the longest and current streak of consecutive days.
+// Gaps made up only of out-of-office days don't break a streak.
+func calculateStreaks(days map[string]bool, off absence.Calendar) (longest, current int) {
 	if len(days) == 0 {
 		return 0, 0
 	}
@@ -1556,7 +1754,7 @@ func calculateStreaks(days map[string]bool) (longest, current int) {
 		// Use integer day difference to avoid floating point precision issues with DST
 		diffHours := dates[i].Sub(dates[i-1]).Hours()
 		diffDays := int(diffHours/24 + 0.5) // Round to nearest integer
-		if diffDays == 1 {
+		if diffDays == 1 || bridged(dates[i-1], dates[i], off) {
 			streak++
 			if streak > longest {
 				longest = streak
@@ -1573,7 +1771,7 @@ func calculateStreaks(days map[string]bool) (longest, current int) {
 	diffHours := today.Sub(lastActive).Hours()
 	daysSinceLastActive := int(diffHours/24 + 0.5) // Round to nearest integer
 
-	if daysSinceLastActive <= 1 {
+	if daysSinceLastActive <= 1 || bridged(lastActive, today, off) {
 		current = streak
 	} else {
 		current = 0
@@ -1582,7 +1780,7 @@ func calculateStreaks(days map[string]bool) (longest, current int) {
 	return longest, current
 }
 
-// countIssueReferences counts the number of issue references in a commit message
+// countIssueReferences counts the number of issue references in a commit message or PR
 // Detects patterns like: fixes #123, closes #456, resolves #789, refs #12, etc.
 func countIssueReferences(message string) int {
 	count := 0
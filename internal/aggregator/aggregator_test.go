@@ -1,14 +1,16 @@
 package aggregator
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lukaszraczylo/git-velocity/internal/absence"
 	"github.com/lukaszraczylo/git-velocity/internal/config"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
 func TestNew(t *testing.T) {
@@ -244,6 +246,56 @@ func TestAggregator_AggregateIssues(t *testing.T) {
 	assert.Equal(t, 1, repo.Contributors[0].IssuesClosed)
 }
 
+func TestAggregator_RepositoryIssues(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: at, Repository: "acme/api", Message: "Fix crash, fixes #1"},
+		},
+		Issues: []models.Issue{
+			{Number: 1, State: models.IssueStateClosed, Author: models.Author{Login: "bob"}, Repository: "acme/api", CreatedAt: at, ClosedBy: &models.Author{Login: "alice"}},
+			{Number: 2, State: models.IssueStateOpen, Author: models.Author{Login: "bob"}, Repository: "acme/web", CreatedAt: at},
+			{Number: 3, State: models.IssueStateOpen, Repository: "acme/web", CreatedAt: at}, // Deleted author
+		},
+		IssueComments: []models.IssueComment{
+			{ID: 1, Issue: 2, Repository: "acme/web", Author: models.Author{Login: "alice"}, CreatedAt: at},
+			{ID: 2, Issue: 9, Repository: "acme/docs", Author: models.Author{Login: "carol"}, CreatedAt: at},
+		},
+	}
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{})
+	require.NoError(t, err)
+
+	repos := make(map[string]models.RepositoryMetrics)
+	for _, rm := range metrics.Repositories {
+		repos[rm.FullName] = rm
+	}
+	require.Len(t, repos, 3)
+	assert.Equal(t, 1, repos["acme/api"].TotalIssues)
+	assert.Equal(t, 2, repos["acme/web"].TotalIssues)
+	assert.Zero(t, repos["acme/docs"].TotalIssues)
+
+	contributor := func(repo, login string) models.ContributorMetrics {
+		for _, c := range repos[repo].Contributors {
+			if c.Login == login {
+				return c
+			}
+		}
+		t.Fatalf("%s is not a contributor of %s", login, repo)
+		return models.ContributorMetrics{}
+	}
+	api := contributor("acme/api", "alice")
+	assert.Equal(t, 1, api.IssuesClosed)
+	assert.Equal(t, 1, api.IssueReferencesInCommits)
+	assert.Zero(t, api.IssueComments)
+	assert.Equal(t, 1, contributor("acme/api", "bob").IssuesOpened)
+	assert.Equal(t, 1, contributor("acme/web", "bob").IssuesOpened)
+	assert.Equal(t, 1, contributor("acme/web", "alice").IssueComments)
+	assert.Equal(t, 1, contributor("acme/docs", "carol").IssueComments)
+}
+
 func TestAggregator_AggregateTeams(t *testing.T) {
 	t.Parallel()
 
@@ -542,7 +594,7 @@ func TestCalculateWorkWeekStreak(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calculateWorkWeekStreak(tt.dates)
+			result := calculateWorkWeekStreak(tt.dates, nil)
 			assert.Equal(t, tt.expectedStreak, result)
 		})
 	}
@@ -563,7 +615,7 @@ func TestCalculateWorkWeekStreak_LongestStreak(t *testing.T) {
 		"2024-01-22": true, // Monday (weekend doesn't break)
 	}
 
-	result := calculateWorkWeekStreak(dates)
+	result := calculateWorkWeekStreak(dates, nil)
 	assert.Equal(t, 6, result) // Mon-Fri + Mon = 6 weekdays in a row
 }
 
@@ -647,6 +699,122 @@ func TestAggregator_WorkWeekStreakTracking(t *testing.T) {
 	assert.Equal(t, 3, contrib.WorkWeekStreak)
 }
 
+func TestAggregator_AbsencesBridgeStreaks(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Contributors = []config.ContributorConfig{
+		{Login: "User1", Absences: []config.AbsenceConfig{{Start: "2024-01-10", End: "2024-01-12"}}},
+	}
+	agg := New(cfg)
+
+	var commits []models.Commit
+	for _, day := range []int{8, 9, 15, 16} { // Mon, Tue, then Mon, Tue after a vacation
+		commits = append(commits, models.Commit{
+			SHA:        fmt.Sprintf("sha%d", day),
+			Author:     models.Author{Login: "user1"},
+			Date:       time.Date(2024, 1, day, 10, 0, 0, 0, time.UTC),
+			Repository: "owner/repo",
+		})
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	metrics, err := agg.Aggregate(&models.RawData{Commits: commits}, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	require.Len(t, metrics.Contributors, 1)
+	contrib := metrics.Contributors[0]
+	assert.Equal(t, 4, contrib.WorkWeekStreak, "the vacation and weekend don't break the streak")
+	assert.Equal(t, 2, contrib.LongestStreak, "the weekend still breaks a daily streak")
+	assert.Equal(t, 3, contrib.AbsenceDays)
+	assert.Equal(t, 28, contrib.AvailableDays)
+	assert.InDelta(t, 4.0/28*100, contrib.ActivityRate, 0.001)
+
+	// Per-repo contributors use the same calendar
+	assert.Equal(t, 4, metrics.Repositories[0].Contributors[0].WorkWeekStreak)
+}
+
+func TestRecencyWeight(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 90)
+
+	var steady, recent, old []time.Time
+	for d := 0; d < 90; d++ {
+		steady = append(steady, start.AddDate(0, 0, d).Add(12*time.Hour))
+	}
+	for d := 80; d < 90; d++ {
+		recent = append(recent, start.AddDate(0, 0, d))
+	}
+	for d := 0; d < 10; d++ {
+		old = append(old, start.AddDate(0, 0, d))
+	}
+
+	assert.InDelta(t, 1.0, recencyWeight(steady, start, end, 30), 0.01, "steady activity keeps its weight")
+	assert.Greater(t, recencyWeight(recent, start, end, 30), 1.5)
+	assert.Less(t, recencyWeight(old, start, end, 30), 0.5)
+	assert.Equal(t, 1.0, recencyWeight(nil, start, end, 30))
+}
+
+func TestAggregator_RecencyWeight(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.DecayHalfLifeDays = 14
+	agg := New(cfg)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "early"}, Date: start.AddDate(0, 0, 2), Repository: "owner/repo"},
+			{SHA: "b", Author: models.Author{Login: "late"}, Date: end.AddDate(0, 0, -2), Repository: "owner/repo"},
+		},
+	}
+
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	weights := make(map[string]float64)
+	for _, cm := range metrics.Contributors {
+		weights[cm.Login] = cm.RecencyWeight
+	}
+	assert.Greater(t, weights["late"], 1.0)
+	assert.Less(t, weights["early"], 0.1)
+
+	// Without a half-life no weight is recorded
+	metrics, err = New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	assert.Zero(t, metrics.Contributors[0].RecencyWeight)
+}
+
+func TestCalculateStreaks_Absences(t *testing.T) {
+	t.Parallel()
+
+	days := map[string]bool{"2024-01-01": true, "2024-01-02": true, "2024-01-05": true}
+	off := absence.Calendar{"2024-01-03": true, "2024-01-04": true}
+
+	longest, _ := calculateStreaks(days, nil)
+	assert.Equal(t, 2, longest)
+
+	longest, _ = calculateStreaks(days, off)
+	assert.Equal(t, 3, longest, "absent days bridge the gap without counting")
+
+	// A vacation running up to today keeps the current streak
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	recent := map[string]bool{today.AddDate(0, 0, -3).Format("2006-01-02"): true}
+	vacation := absence.Calendar{
+		today.AddDate(0, 0, -2).Format("2006-01-02"): true,
+		today.AddDate(0, 0, -1).Format("2006-01-02"): true,
+	}
+	_, current := calculateStreaks(recent, nil)
+	assert.Equal(t, 0, current)
+	_, current = calculateStreaks(recent, vacation)
+	assert.Equal(t, 1, current)
+}
+
 // Note: Bot filtering tests removed - bot filtering happens in app.go before data reaches aggregator
 // The aggregator receives already filtered data
 
@@ -994,15 +1162,8 @@ func TestAggregator_IssueComments(t *testing.T) {
 	cfg := config.DefaultConfig()
 	agg := New(cfg)
 
+	// Issue activity alone creates the repository
 	data := &models.RawData{
-		// Need a commit to create the repository
-		Commits: []models.Commit{
-			{
-				SHA:        "abc123",
-				Author:     models.Author{Login: "user1"},
-				Repository: "owner/repo",
-			},
-		},
 		IssueComments: []models.IssueComment{
 			{
 				ID:         1,
@@ -1109,3 +1270,62 @@ func TestAggregator_IssueReferencesInCommits(t *testing.T) {
 	require.NotNil(t, user2)
 	assert.Equal(t, 1, user2.IssueReferencesInCommits) // user2 has 1 issue reference (resolves #3)
 }
+
+func TestAggregator_LinearIntegration(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Integrations.Linear = config.LinearConfig{Enabled: true, TeamKeys: []string{"ENG"}}
+	agg := New(cfg)
+
+	mergedAt := time.Now()
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			{Number: 1, Title: "ENG-1: login", State: models.PRStateMerged, MergedAt: &mergedAt, Author: models.Author{Login: "user1"}, Repository: "owner/repo"},
+			{Number: 2, Title: "Unlinked change", HeadBranch: "fix-typo", State: models.PRStateMerged, MergedAt: &mergedAt, Author: models.Author{Login: "user1"}, Repository: "owner/repo"},
+		},
+		Commits: []models.Commit{
+			{SHA: "a", Message: "ENG-1 follow-up", Author: models.Author{Login: "user2"}, Repository: "owner/repo"},
+			{SHA: "b", Message: "Implements ENG-2", Author: models.Author{Login: "user2"}, Repository: "owner/repo"},
+		},
+		LinearIssues: map[string]models.LinearIssue{
+			"ENG-1": {Identifier: "ENG-1", StateType: "completed"},
+			"ENG-2": {Identifier: "ENG-2", StateType: "completed"},
+		},
+	}
+
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{})
+	require.NoError(t, err)
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, c := range metrics.Contributors {
+		byLogin[c.Login] = c
+	}
+
+	// user1 gets credit for ENG-1 via the merged PR; half of their PRs are linked
+	assert.Equal(t, 1, byLogin["user1"].LinearIssuesReferenced)
+	assert.Equal(t, 1, byLogin["user1"].LinearIssuesCompleted)
+	assert.InDelta(t, 50.0, byLogin["user1"].LinearLinkageRate, 0.01)
+
+	// user2 referenced both but only gets commit credit for ENG-2 (ENG-1 credited to PR author)
+	assert.Equal(t, 2, byLogin["user2"].LinearIssuesReferenced)
+	assert.Equal(t, 1, byLogin["user2"].LinearIssuesCompleted)
+}
+
+func TestAggregator_LinearDisabled(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	agg := New(cfg)
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Message: "ENG-1 change", Author: models.Author{Login: "user1"}, Repository: "owner/repo"},
+		},
+	}
+
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{})
+	require.NoError(t, err)
+	require.Len(t, metrics.Contributors, 1)
+	assert.Equal(t, 0, metrics.Contributors[0].LinearIssuesReferenced)
+}
@@ -0,0 +1,99 @@
+package aggregator
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// mergeQueueBot is the account merging PRs through GitHub's merge queue
+const mergeQueueBot = "github-merge-queue"
+
+// applyAutoMerge reports how each repository's merged PRs were merged
+// automatically: by auto-merge once their checks and reviews passed, or
+// through the merge queue. The wait runs from joining the queue, or enabling
+// auto-merge when there was no queue, to the merge; both times need
+// use_graphql. Whoever enabled auto-merge or queued a PR is credited when it
+// was merged in line with the review policy, so applyMergeCompliance must
+// run first.
+func (a *Aggregator) applyAutoMerge(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+) {
+	nonCompliant := make(map[string]bool) // repository#number
+	for repo, rm := range repoMap {
+		for _, v := range rm.NonCompliantMerges {
+			nonCompliant[prKey(repo, v.Number)] = true
+		}
+	}
+
+	merged := make(map[string]int)
+	waits := make(map[string][]time.Duration)
+	for _, pr := range data.PullRequests {
+		rm, ok := repoMap[pr.Repository]
+		if !ok || !pr.IsMerged() {
+			continue
+		}
+		merged[pr.Repository]++
+
+		autoMerged := pr.AutoMergeBy != "" || pr.AutoMergeAt != nil
+		queued := pr.QueuedBy != "" || pr.QueuedAt != nil || isMergeQueueBot(pr.MergedBy)
+		if !autoMerged && !queued {
+			continue
+		}
+		rm.AutomatedMerges++
+		if autoMerged {
+			rm.AutoMerges++
+		}
+		if queued {
+			rm.QueuedMerges++
+		}
+
+		since := pr.QueuedAt
+		if since == nil {
+			since = pr.AutoMergeAt
+		}
+		if since != nil && pr.MergedAt != nil && !pr.MergedAt.Before(*since) {
+			waits[pr.Repository] = append(waits[pr.Repository], pr.MergedAt.Sub(*since))
+		}
+
+		enabler := pr.AutoMergeBy
+		if enabler == "" {
+			enabler = pr.QueuedBy
+		}
+		if enabler == "" || isMergeQueueBot(enabler) || nonCompliant[prKey(pr.Repository, pr.Number)] {
+			continue
+		}
+		if cm, ok := contributorMap[enabler]; ok {
+			cm.AutoMergesEnabled++
+		}
+		if rcm, ok := repoContributorMap[pr.Repository][enabler]; ok {
+			rcm.AutoMergesEnabled++
+		}
+	}
+
+	for repo, count := range merged {
+		rm := repoMap[repo]
+		if rm.AutomatedMerges == 0 {
+			continue
+		}
+		rate := float64(rm.AutomatedMerges) / float64(count) * 100
+		rm.AutoMergeRate = &rate
+		if w := waits[repo]; len(w) > 0 {
+			var total time.Duration
+			for _, d := range w {
+				total += d
+			}
+			rm.AvgQueueWait = total.Hours() / float64(len(w))
+		}
+	}
+}
+
+// isMergeQueueBot reports whether a login is the merge queue's, with or
+// without the [bot] suffix
+func isMergeQueueBot(login string) bool {
+	return strings.EqualFold(strings.TrimSuffix(login, "[bot]"), mergeQueueBot)
+}
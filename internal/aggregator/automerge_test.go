@@ -0,0 +1,76 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_AutoMerge(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	hours := func(h int) *time.Time {
+		t := at.Add(time.Duration(h) * time.Hour)
+		return &t
+	}
+	pr := func(number int, author string, mergedAt *time.Time) models.PullRequest {
+		return models.PullRequest{
+			Number: number, Author: models.Author{Login: author}, Repository: "acme/repo",
+			CreatedAt: at, State: models.PRStateMerged, MergedAt: mergedAt,
+		}
+	}
+
+	autoMerged := pr(1, "alice", hours(3))
+	autoMerged.AutoMergeBy, autoMerged.AutoMergeAt = "bob", hours(1)
+	queued := pr(2, "bob", hours(6))
+	queued.QueuedBy, queued.QueuedAt, queued.MergedBy = "bob", hours(5), "github-merge-queue[bot]"
+	unapproved := pr(3, "alice", hours(4)) // Auto-merged without an approval
+	unapproved.AutoMergeBy = "alice"
+	manual := pr(4, "alice", hours(2))
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: at, Repository: "acme/repo"},
+			{SHA: "b", Author: models.Author{Login: "bob"}, Date: at, Repository: "acme/repo"},
+		},
+		PullRequests: []models.PullRequest{autoMerged, queued, unapproved, manual},
+		Reviews: []models.Review{
+			{PullRequest: 1, Repository: "acme/repo", Author: models.Author{Login: "bob"}, State: models.ReviewApproved, SubmittedAt: *hours(1)},
+			{PullRequest: 2, Repository: "acme/repo", Author: models.Author{Login: "alice"}, State: models.ReviewApproved, SubmittedAt: *hours(4)},
+			{PullRequest: 4, Repository: "acme/repo", Author: models.Author{Login: "bob"}, State: models.ReviewApproved, SubmittedAt: *hours(1)},
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	require.Len(t, metrics.Repositories, 1)
+	rm := metrics.Repositories[0]
+	assert.Equal(t, 2, rm.AutoMerges)
+	assert.Equal(t, 1, rm.QueuedMerges)
+	assert.Equal(t, 3, rm.AutomatedMerges)
+	require.NotNil(t, rm.AutoMergeRate)
+	assert.InDelta(t, 75.0, *rm.AutoMergeRate, 0.001)
+	// 2h from enabling auto-merge and 1h in the queue; the unapproved PR has no times
+	assert.InDelta(t, 1.5, rm.AvgQueueWait, 0.001)
+
+	// Bob is credited for both, Alice not for merging without an approval
+	for _, c := range metrics.Contributors {
+		assert.Equal(t, map[string]int{"bob": 2}[c.Login], c.AutoMergesEnabled, c.Login)
+	}
+
+	// Nothing is reported without automated merges
+	data.PullRequests = []models.PullRequest{manual}
+	metrics, err = New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	assert.Nil(t, metrics.Repositories[0].AutoMergeRate)
+	assert.Zero(t, metrics.Repositories[0].AutomatedMerges)
+}
@@ -0,0 +1,110 @@
+package aggregator
+
+import (
+	"sort"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// withoutBots returns data without the activity of bots and records what was
+// dropped for Bots. Commit authors are checked under the login they resolve
+// to, so bot commits that only carry a noreply email are caught as well.
+func (a *Aggregator) withoutBots(data *models.RawData, emailToLogin, loginToLogin map[string]string) *models.RawData {
+	a.bots = make(map[string]*models.BotActivity)
+	if a.config.Options.IncludeBots {
+		return data
+	}
+
+	filtered := *data
+	filtered.Commits = nil
+	for _, commit := range data.Commits {
+		login := commit.Author.Login
+		if mapped, ok := emailToLogin[commit.Author.Email]; ok {
+			login = mapped
+		}
+		if mapped, ok := loginToLogin[login]; ok {
+			login = mapped
+		}
+		if bot := a.bot(login, commit.Author.Login); bot != nil {
+			bot.Commits++
+			continue
+		}
+		filtered.Commits = append(filtered.Commits, commit)
+	}
+
+	filtered.PullRequests = nil
+	for _, pr := range data.PullRequests {
+		if bot := a.bot(pr.Author.Login); bot != nil {
+			bot.PullRequests++
+			continue
+		}
+		filtered.PullRequests = append(filtered.PullRequests, pr)
+	}
+	filtered.Reviews = nil
+	for _, review := range data.Reviews {
+		if bot := a.bot(review.Author.Login); bot != nil {
+			bot.Reviews++
+			continue
+		}
+		filtered.Reviews = append(filtered.Reviews, review)
+	}
+	filtered.Issues = nil
+	for _, issue := range data.Issues {
+		if bot := a.bot(issue.Author.Login); bot != nil {
+			bot.Issues++
+			continue
+		}
+		filtered.Issues = append(filtered.Issues, issue)
+	}
+	filtered.IssueComments = nil
+	for _, comment := range data.IssueComments {
+		if bot := a.bot(comment.Author.Login); bot != nil {
+			bot.IssueComments++
+			continue
+		}
+		filtered.IssueComments = append(filtered.IssueComments, comment)
+	}
+	// Review comments are only read for their tone, so bots leaving just
+	// those aren't listed
+	filtered.ReviewComments = nil
+	for _, comment := range data.ReviewComments {
+		if a.config.BotPattern(comment.Author.Login) == "" {
+			filtered.ReviewComments = append(filtered.ReviewComments, comment)
+		}
+	}
+	return &filtered
+}
+
+// bot returns the audit entry of the first of logins matching a bot pattern,
+// or nil when none does
+func (a *Aggregator) bot(logins ...string) *models.BotActivity {
+	for _, login := range logins {
+		pattern := a.config.BotPattern(login)
+		if pattern == "" {
+			continue
+		}
+		entry, ok := a.bots[login]
+		if !ok {
+			entry = &models.BotActivity{Login: login, Pattern: pattern}
+			a.bots[login] = entry
+		}
+		return entry
+	}
+	return nil
+}
+
+// Bots returns the identities the last Aggregate filtered as bots, most
+// active first
+func (a *Aggregator) Bots() []models.BotActivity {
+	bots := make([]models.BotActivity, 0, len(a.bots))
+	for _, bot := range a.bots {
+		bots = append(bots, *bot)
+	}
+	sort.Slice(bots, func(i, j int) bool {
+		if bots[i].Total() != bots[j].Total() {
+			return bots[i].Total() > bots[j].Total()
+		}
+		return bots[i].Login < bots[j].Login
+	})
+	return bots
+}
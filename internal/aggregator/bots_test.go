@@ -0,0 +1,70 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_FiltersBots(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: at, Repository: "owner/repo"},
+			// Git-derived login; the noreply email resolves it to the service account
+			{SHA: "b", Author: models.Author{Login: "deploy-robot", Email: "42+svc-deploy-01@users.noreply.github.com"}, Date: at, Repository: "owner/repo"},
+		},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "alice", ID: 1}, CreatedAt: at, Repository: "owner/repo"},
+			{Number: 2, Author: models.Author{Login: "svc-deploy-01", ID: 42}, CreatedAt: at, Repository: "owner/repo"},
+		},
+		Reviews: []models.Review{
+			{Author: models.Author{Login: "renovate[bot]"}, SubmittedAt: at, Repository: "owner/repo", PullRequest: 1},
+		},
+		Issues: []models.Issue{
+			{Number: 3, Author: models.Author{Login: "github-actions[bot]"}, CreatedAt: at, Repository: "owner/repo"},
+		},
+		IssueComments: []models.IssueComment{
+			{ID: 4, Issue: 3, Author: models.Author{Login: "codecov-commenter"}, CreatedAt: at, Repository: "owner/repo"},
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Options.AdditionalBotPatterns = []string{`re:^svc-[a-z]+-\d+$`}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	agg := New(cfg)
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	require.Len(t, metrics.Contributors, 1)
+	alice := metrics.Contributors[0]
+	assert.Equal(t, "alice", alice.Login)
+	assert.Equal(t, 1, metrics.TotalCommits)
+	assert.Equal(t, 1, metrics.TotalPRs)
+	assert.Zero(t, metrics.TotalReviews)
+
+	// Every filtered identity is audited with the pattern it matched
+	assert.Equal(t, []models.BotActivity{
+		{Login: "svc-deploy-01", Pattern: `re:^svc-[a-z]+-\d+$`, Commits: 1, PullRequests: 1},
+		{Login: "codecov-commenter", Pattern: "codecov*", IssueComments: 1},
+		{Login: "github-actions[bot]", Pattern: "*[bot]", Issues: 1},
+		{Login: "renovate[bot]", Pattern: "*[bot]", Reviews: 1},
+	}, agg.Bots())
+
+	// With include_bots everything counts
+	cfg.Options.IncludeBots = true
+	agg = New(cfg)
+	metrics, err = agg.Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	assert.Greater(t, len(metrics.Contributors), 1)
+	assert.Empty(t, agg.Bots())
+}
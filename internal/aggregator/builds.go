@@ -0,0 +1,69 @@
+package aggregator
+
+import (
+	"sort"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyBuildMetrics replays the CI results of each repository's merged PRs in
+// merge order. A failing merge commit after a passing one breaks the build and
+// a passing one after a failure fixes it; pending results and PRs without CI
+// don't change the state. The default branch is assumed green before the
+// first result of the period.
+func (a *Aggregator) applyBuildMetrics(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+) {
+	byRepo := make(map[string][]models.PullRequest)
+	for _, pr := range data.PullRequests {
+		if !pr.IsMerged() || pr.Author.Login == "" {
+			continue
+		}
+		if pr.BuildStatus == models.BuildSuccess || pr.BuildStatus == models.BuildFailure {
+			byRepo[pr.Repository] = append(byRepo[pr.Repository], pr)
+		}
+	}
+
+	for repo, prs := range byRepo {
+		sort.SliceStable(prs, func(i, j int) bool {
+			return prs[i].MergedAt.Before(*prs[j].MergedAt)
+		})
+
+		passed, broken := 0, 0
+		green := true
+		for _, pr := range prs {
+			login := pr.Author.Login
+			failed := pr.BuildStatus == models.BuildFailure
+			switch {
+			case failed && green:
+				broken++
+				if cm, ok := contributorMap[login]; ok {
+					cm.BuildsBroken++
+				}
+				if rcm, ok := repoContributorMap[repo][login]; ok {
+					rcm.BuildsBroken++
+				}
+			case !failed && !green:
+				if cm, ok := contributorMap[login]; ok {
+					cm.BuildsFixed++
+				}
+				if rcm, ok := repoContributorMap[repo][login]; ok {
+					rcm.BuildsFixed++
+				}
+			}
+			if !failed {
+				passed++
+			}
+			green = !failed
+		}
+
+		if rm, ok := repoMap[repo]; ok {
+			rate := float64(passed) / float64(len(prs)) * 100
+			rm.BuildSuccessRate = &rate
+			rm.BuildsBroken = broken
+		}
+	}
+}
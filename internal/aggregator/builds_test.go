@@ -0,0 +1,68 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_BuildMetrics(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	merged := func(number int, login string, hours int, status string) models.PullRequest {
+		mergedAt := at.Add(time.Duration(hours) * time.Hour)
+		return models.PullRequest{
+			Number:      number,
+			State:       models.PRStateMerged,
+			Author:      models.Author{Login: login},
+			Repository:  "owner/repo",
+			CreatedAt:   at,
+			MergedAt:    &mergedAt,
+			BuildStatus: status,
+		}
+	}
+
+	// Listed out of merge order on purpose
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			merged(3, "bob", 3, models.BuildFailure), // still red, no new breakage
+			merged(1, "alice", 1, models.BuildSuccess),
+			merged(2, "alice", 2, models.BuildFailure), // breaks
+			merged(4, "carol", 4, models.BuildPending), // ignored
+			merged(5, "bob", 5, models.BuildSuccess),   // fixes
+			merged(6, "carol", 6, models.BuildFailure), // breaks
+			merged(7, "carol", 7, ""),                  // no CI
+		},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, cm := range metrics.Contributors {
+		byLogin[cm.Login] = cm
+	}
+	assert.Equal(t, 1, byLogin["alice"].BuildsBroken)
+	assert.Equal(t, 1, byLogin["bob"].BuildsFixed)
+	assert.Zero(t, byLogin["bob"].BuildsBroken)
+	assert.Equal(t, 1, byLogin["carol"].BuildsBroken)
+
+	require.Len(t, metrics.Repositories, 1)
+	repo := metrics.Repositories[0]
+	require.NotNil(t, repo.BuildSuccessRate)
+	assert.InDelta(t, 40.0, *repo.BuildSuccessRate, 0.001) // 2 of 5 known results passed
+	assert.Equal(t, 2, repo.BuildsBroken)
+
+	for _, rcm := range repo.Contributors {
+		assert.Equal(t, byLogin[rcm.Login].BuildsBroken, rcm.BuildsBroken)
+		assert.Equal(t, byLogin[rcm.Login].BuildsFixed, rcm.BuildsFixed)
+	}
+}
@@ -0,0 +1,259 @@
+package aggregator
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// maintainerAssociations are the author associations of maintainers
+var maintainerAssociations = []string{models.AssociationOwner, models.AssociationMember, models.AssociationCollaborator}
+
+// maintainers returns the lowercase logins of the maintainers: configured
+// ones, team members, organization members and the authors of PRs or issues
+// GitHub associates as owners, members or collaborators
+func (a *Aggregator) maintainers(data *models.RawData, members map[string]bool) map[string]bool {
+	maintainers := make(map[string]bool)
+	for _, login := range a.config.Community.Maintainers {
+		maintainers[strings.ToLower(login)] = true
+	}
+	for _, team := range a.config.Teams {
+		for _, login := range team.Members {
+			maintainers[strings.ToLower(login)] = true
+		}
+	}
+	for login := range members {
+		maintainers[login] = true
+	}
+	for _, pr := range data.PullRequests {
+		if slices.Contains(maintainerAssociations, pr.Association) {
+			maintainers[strings.ToLower(pr.Author.Login)] = true
+		}
+	}
+	for _, issue := range data.Issues {
+		if slices.Contains(maintainerAssociations, issue.Association) {
+			maintainers[strings.ToLower(issue.Author.Login)] = true
+		}
+	}
+	return maintainers
+}
+
+// applyCommunityMetrics summarizes community contributions globally and per
+// repository, and flags first-time contributors
+func (a *Aggregator) applyCommunityMetrics(data *models.RawData, members map[string]bool, contributorMap map[string]*models.ContributorMetrics, repoContributorMap map[string]map[string]*models.ContributorMetrics, repoMap map[string]*models.RepositoryMetrics) *models.CommunityMetrics {
+	if !a.config.Community.Enabled {
+		return nil
+	}
+	maintainers := a.maintainers(data, members)
+
+	byRepo := make(map[string]*models.RawData)
+	for name := range repoMap {
+		byRepo[name] = &models.RawData{}
+	}
+	for _, pr := range data.PullRequests {
+		if repo, ok := byRepo[pr.Repository]; ok {
+			repo.PullRequests = append(repo.PullRequests, pr)
+		}
+	}
+	for _, review := range data.Reviews {
+		if repo, ok := byRepo[review.Repository]; ok {
+			repo.Reviews = append(repo.Reviews, review)
+		}
+	}
+	for _, issue := range data.Issues {
+		if repo, ok := byRepo[issue.Repository]; ok {
+			repo.Issues = append(repo.Issues, issue)
+		}
+	}
+	for _, comment := range data.IssueComments {
+		if repo, ok := byRepo[comment.Repository]; ok {
+			repo.IssueComments = append(repo.IssueComments, comment)
+		}
+	}
+	for name, repo := range byRepo {
+		repoMap[name].Community = communityMetrics(repo, maintainers)
+		for _, first := range repoMap[name].Community.FirstTimeContributors {
+			if rcm, ok := repoContributorMap[name][first.Login]; ok {
+				rcm.FirstTimeContributor = true
+			}
+		}
+	}
+
+	community := communityMetrics(data, maintainers)
+	for _, first := range community.FirstTimeContributors {
+		if cm, ok := contributorMap[first.Login]; ok {
+			cm.FirstTimeContributor = true
+		}
+	}
+	return community
+}
+
+// communityMetrics summarizes the PRs and issues of non-maintainers in data
+func communityMetrics(data *models.RawData, maintainers map[string]bool) *models.CommunityMetrics {
+	isCommunity := func(login string) bool {
+		return login != "" && !maintainers[strings.ToLower(login)]
+	}
+
+	// First maintainer response to each PR and issue, keyed by repository#number
+	type response struct {
+		login string
+		at    time.Time
+	}
+	first := make(map[string]response)
+	respond := func(key, login string, at, created time.Time) {
+		if !maintainers[strings.ToLower(login)] || at.Before(created) {
+			return
+		}
+		if r, ok := first[key]; !ok || at.Before(r.at) || (at.Equal(r.at) && login < r.login) {
+			first[key] = response{login, at}
+		}
+	}
+	key := func(repo string, number int) string {
+		return repo + "#" + strconv.Itoa(number)
+	}
+
+	// PRs wait for a response from being ready for review; drafts don't
+	created := make(map[string]time.Time)
+	for _, pr := range data.PullRequests {
+		if ready := pr.ReadyAt(); ready != nil {
+			created[key(pr.Repository, pr.Number)] = *ready
+		}
+	}
+	for _, issue := range data.Issues {
+		created[key(issue.Repository, issue.Number)] = issue.CreatedAt
+	}
+	for _, review := range data.Reviews {
+		k := key(review.Repository, review.PullRequest)
+		if at, ok := created[k]; ok {
+			respond(k, review.Author.Login, review.SubmittedAt, at)
+		}
+	}
+	// PR conversation comments are issue comments too
+	for _, comment := range data.IssueComments {
+		k := key(comment.Repository, comment.Issue)
+		if at, ok := created[k]; ok {
+			respond(k, comment.Author.Login, comment.CreatedAt, at)
+		}
+	}
+
+	metrics := &models.CommunityMetrics{}
+
+	contributors := make(map[string]*models.CommunityContributor)
+	firstTimers := make(map[string]bool)
+	returning := make(map[string]bool)
+	contributor := func(author models.Author, at time.Time) *models.CommunityContributor {
+		c, ok := contributors[author.Login]
+		if !ok {
+			c = &models.CommunityContributor{Login: author.Login, AvatarURL: author.AvatarURL, FirstContribution: at}
+			contributors[author.Login] = c
+		}
+		if at.Before(c.FirstContribution) {
+			c.FirstContribution = at
+		}
+		return c
+	}
+
+	responders := make(map[string]*models.MaintainerResponsiveness)
+	var prHours, issueHours float64
+	var prResponses, issueResponses int
+	countResponse := func(k string, at time.Time, open bool) (float64, bool) {
+		r, ok := first[k]
+		if !ok {
+			if open {
+				metrics.AwaitingResponse++
+			}
+			return 0, false
+		}
+		hours := r.at.Sub(at).Hours()
+		m, ok := responders[r.login]
+		if !ok {
+			m = &models.MaintainerResponsiveness{Login: r.login}
+			responders[r.login] = m
+		}
+		m.Responses++
+		m.AvgResponseHours += hours
+		return hours, true
+	}
+
+	for _, pr := range data.PullRequests {
+		if !isCommunity(pr.Author.Login) {
+			continue
+		}
+		c := contributor(pr.Author, pr.CreatedAt)
+		c.PullRequests++
+		metrics.PullRequests++
+		if pr.IsMerged() {
+			c.PullRequestsMerged++
+			metrics.PullRequestsMerged++
+		}
+		switch pr.Association {
+		case models.AssociationFirstTimeContributor, models.AssociationFirstTimer:
+			firstTimers[pr.Author.Login] = true
+		case models.AssociationContributor:
+			returning[pr.Author.Login] = true
+		}
+		ready := pr.ReadyAt()
+		if ready == nil {
+			continue
+		}
+		if hours, ok := countResponse(key(pr.Repository, pr.Number), *ready, pr.State == models.PRStateOpen && !pr.Draft); ok {
+			prHours += hours
+			prResponses++
+		}
+	}
+	for _, issue := range data.Issues {
+		if !isCommunity(issue.Author.Login) {
+			continue
+		}
+		c := contributor(issue.Author, issue.CreatedAt)
+		c.Issues++
+		metrics.Issues++
+		if hours, ok := countResponse(key(issue.Repository, issue.Number), issue.CreatedAt, !issue.IsClosed()); ok {
+			issueHours += hours
+			issueResponses++
+		}
+	}
+
+	metrics.Contributors = len(contributors)
+	if prResponses > 0 {
+		metrics.AvgPRResponseHours = prHours / float64(prResponses)
+	}
+	if issueResponses > 0 {
+		metrics.AvgIssueResponseHours = issueHours / float64(issueResponses)
+	}
+	for login, c := range contributors {
+		switch {
+		case firstTimers[login]:
+			metrics.FirstTimeContributors = append(metrics.FirstTimeContributors, *c)
+		case returning[login]:
+			metrics.ReturningContributors = append(metrics.ReturningContributors, *c)
+		}
+	}
+	byFirstContribution := func(list []models.CommunityContributor) {
+		sort.Slice(list, func(i, j int) bool {
+			if !list[i].FirstContribution.Equal(list[j].FirstContribution) {
+				return list[i].FirstContribution.Before(list[j].FirstContribution)
+			}
+			return list[i].Login < list[j].Login
+		})
+	}
+	byFirstContribution(metrics.FirstTimeContributors)
+	byFirstContribution(metrics.ReturningContributors)
+
+	for _, m := range responders {
+		m.AvgResponseHours /= float64(m.Responses)
+		metrics.Responsiveness = append(metrics.Responsiveness, *m)
+	}
+	sort.Slice(metrics.Responsiveness, func(i, j int) bool {
+		if metrics.Responsiveness[i].Responses != metrics.Responsiveness[j].Responses {
+			return metrics.Responsiveness[i].Responses > metrics.Responsiveness[j].Responses
+		}
+		return metrics.Responsiveness[i].Login < metrics.Responsiveness[j].Login
+	})
+
+	return metrics
+}
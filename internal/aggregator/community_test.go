@@ -0,0 +1,79 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_CommunityMetrics(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	merged := at.Add(48 * time.Hour)
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "alice"}, Association: models.AssociationMember, CreatedAt: at, Repository: "acme/repo", State: models.PRStateMerged, MergedAt: &merged},
+			{Number: 2, Author: models.Author{Login: "newbie"}, Association: models.AssociationFirstTimeContributor, CreatedAt: at, Repository: "acme/repo", State: models.PRStateMerged, MergedAt: &merged},
+			{Number: 3, Author: models.Author{Login: "regular"}, Association: models.AssociationContributor, CreatedAt: at.Add(time.Hour), Repository: "acme/repo", State: models.PRStateOpen},
+			// Listed as a maintainer in the configuration
+			{Number: 4, Author: models.Author{Login: "triager"}, Association: models.AssociationContributor, CreatedAt: at, Repository: "acme/repo", State: models.PRStateOpen},
+		},
+		Reviews: []models.Review{
+			{PullRequest: 2, Author: models.Author{Login: "alice"}, SubmittedAt: at.Add(4 * time.Hour), Repository: "acme/repo"},
+			// A community review isn't a maintainer response
+			{PullRequest: 3, Author: models.Author{Login: "newbie"}, SubmittedAt: at.Add(2 * time.Hour), Repository: "acme/repo"},
+		},
+		Issues: []models.Issue{
+			{Number: 5, Author: models.Author{Login: "reporter"}, Association: "NONE", CreatedAt: at, Repository: "acme/repo", State: models.IssueStateOpen},
+		},
+		IssueComments: []models.IssueComment{
+			{ID: 1, Issue: 5, Author: models.Author{Login: "bob"}, CreatedAt: at.Add(10 * time.Hour), Repository: "acme/repo"},
+			{ID: 2, Issue: 2, Author: models.Author{Login: "bob"}, CreatedAt: at.Add(2 * time.Hour), Repository: "acme/repo"},
+		},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	cfg := config.DefaultConfig()
+	cfg.Teams = []config.TeamConfig{{Name: "Core", Members: []string{"bob"}}}
+	cfg.Community = config.CommunityConfig{Enabled: true, Maintainers: []string{"Triager"}}
+	metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	community := metrics.Community
+	require.NotNil(t, community)
+	assert.Equal(t, 3, community.Contributors)
+	assert.Equal(t, 2, community.PullRequests)
+	assert.Equal(t, 1, community.PullRequestsMerged)
+	assert.Equal(t, 1, community.Issues)
+	assert.Equal(t, []models.CommunityContributor{
+		{Login: "newbie", PullRequests: 1, PullRequestsMerged: 1, FirstContribution: at},
+	}, community.FirstTimeContributors)
+	assert.Equal(t, []models.CommunityContributor{
+		{Login: "regular", PullRequests: 1, FirstContribution: at.Add(time.Hour)},
+	}, community.ReturningContributors)
+
+	// bob commented on #2 before alice reviewed it
+	assert.InDelta(t, 2, community.AvgPRResponseHours, 0.001)
+	assert.InDelta(t, 10, community.AvgIssueResponseHours, 0.001)
+	assert.Equal(t, 1, community.AwaitingResponse, "#3 is open without a maintainer response")
+	assert.Equal(t, []models.MaintainerResponsiveness{{Login: "bob", Responses: 2, AvgResponseHours: 6}}, community.Responsiveness)
+
+	require.Len(t, metrics.Repositories, 1)
+	assert.Equal(t, community, metrics.Repositories[0].Community)
+	for _, c := range metrics.Contributors {
+		assert.Equal(t, c.Login == "newbie", c.FirstTimeContributor, c.Login)
+	}
+
+	// Disabled by default
+	metrics, err = New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	assert.Nil(t, metrics.Community)
+	assert.Nil(t, metrics.Repositories[0].Community)
+}
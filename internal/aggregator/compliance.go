@@ -0,0 +1,86 @@
+package aggregator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyMergeCompliance checks each repository's merged PRs against the
+// review policy: an approval by someone other than the author before the
+// merge, and no change request left standing when the author merges it
+// themselves. A reviewer's change request stands until they approve or it is
+// dismissed. Self-merges need to know who merged, which listing PRs over
+// REST doesn't return.
+func (a *Aggregator) applyMergeCompliance(data *models.RawData, repoMap map[string]*models.RepositoryMetrics) {
+	reviews := make(map[string][]models.Review) // repository#number
+	for _, review := range data.Reviews {
+		k := prKey(review.Repository, review.PullRequest)
+		reviews[k] = append(reviews[k], review)
+	}
+
+	merged := make(map[string]int)
+	for _, pr := range data.PullRequests {
+		rm, ok := repoMap[pr.Repository]
+		if !ok || !pr.IsMerged() || pr.MergedAt == nil {
+			continue
+		}
+		merged[pr.Repository]++
+
+		approved := false
+		standing := make(map[string]bool) // Reviewers whose change request stands
+		prReviews := reviews[prKey(pr.Repository, pr.Number)]
+		sort.SliceStable(prReviews, func(i, j int) bool { return prReviews[i].SubmittedAt.Before(prReviews[j].SubmittedAt) })
+		for _, review := range prReviews {
+			reviewer := strings.ToLower(review.Author.Login)
+			if reviewer == "" || strings.EqualFold(reviewer, pr.Author.Login) || review.SubmittedAt.After(*pr.MergedAt) {
+				continue
+			}
+			switch review.State {
+			case models.ReviewApproved:
+				approved = true
+				delete(standing, reviewer)
+			case models.ReviewChangesRequested:
+				standing[reviewer] = true
+			case models.ReviewDismissed:
+				delete(standing, reviewer)
+			}
+		}
+
+		overridden := len(standing) > 0 && pr.MergedBy != "" && strings.EqualFold(pr.MergedBy, pr.Author.Login)
+		if approved && !overridden {
+			continue
+		}
+		if !approved {
+			rm.UnapprovedMerges++
+		}
+		if overridden {
+			rm.OverriddenReviews++
+		}
+		rm.NonCompliantMerges = append(rm.NonCompliantMerges, models.PolicyViolation{
+			Number:     pr.Number,
+			Title:      pr.Title,
+			URL:        pr.URL,
+			Author:     pr.Author.Login,
+			MergedBy:   pr.MergedBy,
+			MergedAt:   *pr.MergedAt,
+			Unapproved: !approved,
+			Overridden: overridden,
+		})
+	}
+
+	for repo, count := range merged {
+		rm := repoMap[repo]
+		rate := float64(count-len(rm.NonCompliantMerges)) / float64(count) * 100
+		rm.MergeCompliance = &rate
+		// Latest first
+		violations := rm.NonCompliantMerges
+		sort.Slice(violations, func(i, j int) bool {
+			if !violations[i].MergedAt.Equal(violations[j].MergedAt) {
+				return violations[i].MergedAt.After(violations[j].MergedAt)
+			}
+			return violations[i].Number > violations[j].Number
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_MergeCompliance(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	hours := func(h int) *time.Time {
+		t := at.Add(time.Duration(h) * time.Hour)
+		return &t
+	}
+	pr := func(number int, mergedBy string) models.PullRequest {
+		return models.PullRequest{
+			Number: number, Title: "PR", Author: models.Author{Login: "alice"}, Repository: "acme/repo",
+			CreatedAt: at, State: models.PRStateMerged, MergedAt: hours(10), MergedBy: mergedBy,
+		}
+	}
+	review := func(number, hour int, login string, state models.ReviewState) models.Review {
+		return models.Review{PullRequest: number, Repository: "acme/repo", Author: models.Author{Login: login}, State: state, SubmittedAt: *hours(hour)}
+	}
+
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			pr(1, "bob"),   // Approved
+			pr(2, "alice"), // Never approved
+			pr(3, "alice"), // Approved by one reviewer, merged over another's change request
+			pr(4, "alice"), // Change request resolved by approving
+			pr(5, ""),      // Approved only after the merge
+			{Number: 6, Author: models.Author{Login: "alice"}, Repository: "acme/repo", CreatedAt: at, State: models.PRStateOpen},
+		},
+		Reviews: []models.Review{
+			review(1, 1, "bob", models.ReviewApproved),
+			review(2, 1, "alice", models.ReviewApproved), // The author's own reply
+			review(3, 1, "bob", models.ReviewApproved),
+			review(3, 2, "carol", models.ReviewChangesRequested),
+			review(3, 3, "carol", models.ReviewCommented),
+			review(4, 1, "bob", models.ReviewChangesRequested),
+			review(4, 2, "bob", models.ReviewApproved),
+			review(5, 11, "bob", models.ReviewApproved),
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	require.Len(t, metrics.Repositories, 1)
+
+	repo := metrics.Repositories[0]
+	assert.Equal(t, 2, repo.UnapprovedMerges)
+	assert.Equal(t, 1, repo.OverriddenReviews)
+	require.NotNil(t, repo.MergeCompliance)
+	assert.InDelta(t, 40, *repo.MergeCompliance, 0.001)
+
+	var numbers []int
+	for _, v := range repo.NonCompliantMerges {
+		numbers = append(numbers, v.Number)
+		assert.Equal(t, v.Number != 3, v.Unapproved, v.Number)
+		assert.Equal(t, v.Number == 3, v.Overridden, v.Number)
+	}
+	assert.Equal(t, []int{5, 3, 2}, numbers)
+}
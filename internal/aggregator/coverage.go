@@ -0,0 +1,54 @@
+package aggregator
+
+import (
+	"sort"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// minCoverageGain is the smallest coverage increase, in percentage points,
+// credited as an improvement; smaller changes are report noise
+const minCoverageGain = 0.01
+
+// applyCoverageMetrics attributes coverage deltas to PR authors. Each merged
+// PR with a coverage report is compared with the previous one merged into the
+// same repository; the first PR of the period has no baseline.
+func (a *Aggregator) applyCoverageMetrics(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+) {
+	byRepo := make(map[string][]models.PullRequest)
+	for _, pr := range data.PullRequests {
+		if pr.IsMerged() && pr.Author.Login != "" && pr.Coverage != nil {
+			byRepo[pr.Repository] = append(byRepo[pr.Repository], pr)
+		}
+	}
+
+	for repo, prs := range byRepo {
+		sort.SliceStable(prs, func(i, j int) bool {
+			return prs[i].MergedAt.Before(*prs[j].MergedAt)
+		})
+
+		for i := 1; i < len(prs); i++ {
+			login := prs[i].Author.Login
+			delta := *prs[i].Coverage - *prs[i-1].Coverage
+			for _, cm := range []*models.ContributorMetrics{contributorMap[login], repoContributorMap[repo][login]} {
+				if cm == nil {
+					continue
+				}
+				cm.CoverageDelta += delta
+				if delta >= minCoverageGain {
+					cm.CoverageImproved++
+				}
+			}
+		}
+
+		if rm, ok := repoMap[repo]; ok {
+			last := *prs[len(prs)-1].Coverage
+			rm.Coverage = &last
+			rm.CoverageDelta = last - *prs[0].Coverage
+		}
+	}
+}
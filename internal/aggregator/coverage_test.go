@@ -0,0 +1,68 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_CoverageMetrics(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	merged := func(number int, login string, hours int, coverage *float64) models.PullRequest {
+		mergedAt := at.Add(time.Duration(hours) * time.Hour)
+		return models.PullRequest{
+			Number:     number,
+			State:      models.PRStateMerged,
+			Author:     models.Author{Login: login},
+			Repository: "owner/repo",
+			CreatedAt:  at,
+			MergedAt:   &mergedAt,
+			Coverage:   coverage,
+		}
+	}
+	pct := func(v float64) *float64 { return &v }
+
+	// Listed out of merge order on purpose
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			merged(3, "bob", 3, pct(79)),     // -3
+			merged(1, "alice", 1, pct(80)),   // baseline
+			merged(2, "alice", 2, pct(82)),   // +2
+			merged(4, "carol", 4, nil),       // no report
+			merged(5, "bob", 5, pct(79.005)), // below the noise threshold
+			merged(6, "carol", 6, pct(81)),   // +1.995
+		},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, cm := range metrics.Contributors {
+		byLogin[cm.Login] = cm
+	}
+	assert.Equal(t, 1, byLogin["alice"].CoverageImproved)
+	assert.InDelta(t, 2.0, byLogin["alice"].CoverageDelta, 0.001)
+	assert.Zero(t, byLogin["bob"].CoverageImproved)
+	assert.InDelta(t, -2.995, byLogin["bob"].CoverageDelta, 0.001)
+	assert.Equal(t, 1, byLogin["carol"].CoverageImproved)
+
+	require.Len(t, metrics.Repositories, 1)
+	repo := metrics.Repositories[0]
+	require.NotNil(t, repo.Coverage)
+	assert.InDelta(t, 81.0, *repo.Coverage, 0.001)
+	assert.InDelta(t, 1.0, repo.CoverageDelta, 0.001)
+
+	for _, rcm := range repo.Contributors {
+		assert.Equal(t, byLogin[rcm.Login].CoverageImproved, rcm.CoverageImproved)
+	}
+}
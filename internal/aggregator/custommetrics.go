@@ -0,0 +1,48 @@
+package aggregator
+
+import (
+	"fmt"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/metricexpr"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// ApplyCustomMetrics computes the configured custom metrics of every
+// contributor, overall, per repository and per team, in the order they are
+// defined so that later ones can use the earlier. Merged runs call it again,
+// as ratios cannot be summed across runs.
+func ApplyCustomMetrics(metrics *models.GlobalMetrics, defs []config.CustomMetricConfig) error {
+	if len(defs) == 0 {
+		return nil
+	}
+	exprs := make([]*metricexpr.Expression, len(defs))
+	for i, def := range defs {
+		expr, err := metricexpr.Compile(def.Expression)
+		if err != nil {
+			return fmt.Errorf("custom metric %s: %w", def.Name, err)
+		}
+		exprs[i] = expr
+	}
+
+	apply := func(cm *models.ContributorMetrics) {
+		cm.Custom = make(map[string]float64, len(defs))
+		for i, def := range defs {
+			cm.Custom[def.Name] = exprs[i].Eval(metricexpr.Vars(cm))
+		}
+	}
+	for i := range metrics.Contributors {
+		apply(&metrics.Contributors[i])
+	}
+	for i := range metrics.Repositories {
+		for j := range metrics.Repositories[i].Contributors {
+			apply(&metrics.Repositories[i].Contributors[j])
+		}
+	}
+	for i := range metrics.Teams {
+		for j := range metrics.Teams[i].MemberMetrics {
+			apply(&metrics.Teams[i].MemberMetrics[j])
+		}
+	}
+	return nil
+}
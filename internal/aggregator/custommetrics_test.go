@@ -0,0 +1,73 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_CustomMetrics(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	merged := at.Add(2 * time.Hour)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: at, Repository: "acme/repo", Additions: 30, Deletions: 10},
+			{SHA: "b", Author: models.Author{Login: "bob"}, Date: at, Repository: "acme/repo", Additions: 5},
+		},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "alice"}, Repository: "acme/repo", CreatedAt: at, State: models.PRStateMerged, MergedAt: &merged},
+		},
+		Reviews: []models.Review{
+			{PullRequest: 1, Repository: "acme/repo", Author: models.Author{Login: "bob"}, State: models.ReviewApproved, SubmittedAt: at.Add(time.Hour)},
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	cfg := config.DefaultConfig()
+	cfg.Teams = []config.TeamConfig{{Name: "Core", Members: []string{"alice", "bob"}}}
+	cfg.CustomMetrics = []config.CustomMetricConfig{
+		{Name: "net_lines", Expression: "lines_added - lines_deleted"},
+		{Name: "review_ratio", Expression: "reviews_given / prs_merged"},
+		{Name: "reviewer", Expression: "reviews_given > 0 && net_lines < 10"},
+	}
+	metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	want := map[string]map[string]float64{
+		// A division by zero is 0
+		"alice": {"net_lines": 20, "review_ratio": 0, "reviewer": 0},
+		"bob":   {"net_lines": 5, "review_ratio": 0, "reviewer": 1},
+	}
+	require.Len(t, metrics.Contributors, 2)
+	for _, c := range metrics.Contributors {
+		assert.Equal(t, want[c.Login], c.Custom, c.Login)
+	}
+	for _, c := range metrics.Repositories[0].Contributors {
+		assert.Equal(t, want[c.Login], c.Custom, c.Login)
+	}
+	require.Len(t, metrics.Teams, 1)
+	for _, c := range metrics.Teams[0].MemberMetrics {
+		assert.Equal(t, want[c.Login], c.Custom, c.Login)
+	}
+
+	// Nothing is added without custom metrics
+	metrics, err = New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	assert.Nil(t, metrics.Contributors[0].Custom)
+}
+
+func TestApplyCustomMetrics_InvalidExpression(t *testing.T) {
+	t.Parallel()
+
+	err := ApplyCustomMetrics(&models.GlobalMetrics{}, []config.CustomMetricConfig{{Name: "broken", Expression: "prs_merged +"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "custom metric broken")
+}
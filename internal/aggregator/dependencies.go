@@ -0,0 +1,158 @@
+package aggregator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyDependencies links repositories requiring packages published by other
+// analyzed repositories. Each repository is owned by the team whose members
+// made most of its commits; edges between repositories of different teams
+// are marked as cross-team coupling. Returns nil unless manifests were read.
+func applyDependencies(data *models.RawData, repositories []models.RepositoryMetrics, teams []models.TeamMetrics) *models.DependencyGraph {
+	if len(data.Manifests) == 0 {
+		return nil
+	}
+
+	repoIndex := make(map[string]int, len(repositories))
+	for i := range repositories {
+		repoIndex[repositories[i].FullName] = i
+	}
+
+	// Packages published by analyzed repositories
+	publishers := make(map[models.Package][]string)
+	for _, m := range data.Manifests {
+		if _, ok := repoIndex[m.Repository]; !ok {
+			continue
+		}
+		for _, pkg := range m.Provides {
+			publishers[pkg] = append(publishers[pkg], m.Repository)
+		}
+	}
+
+	owners := repositoryOwners(repositories, teams)
+	graph := &models.DependencyGraph{Nodes: []models.DependencyNode{}, Edges: []models.DependencyEdge{}}
+	for _, rm := range repositories {
+		graph.Nodes = append(graph.Nodes, models.DependencyNode{Repository: rm.FullName, Team: owners[rm.FullName]})
+	}
+
+	manifests := append([]models.RepositoryManifest(nil), data.Manifests...)
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Repository < manifests[j].Repository })
+	for _, m := range manifests {
+		from, ok := repoIndex[m.Repository]
+		if !ok {
+			continue
+		}
+		packages := make(map[string][]models.Package) // Upstream repository -> packages
+		for _, pkg := range m.Requires {
+			for _, upstream := range publishers[pkg] {
+				if upstream != m.Repository {
+					packages[upstream] = append(packages[upstream], pkg)
+				}
+			}
+		}
+
+		upstreams := sortedKeys(packages)
+		for _, upstream := range upstreams {
+			fromTeam, toTeam := owners[m.Repository], owners[upstream]
+			graph.Edges = append(graph.Edges, models.DependencyEdge{
+				From:      m.Repository,
+				To:        upstream,
+				Packages:  packages[upstream],
+				CrossTeam: fromTeam != "" && toTeam != "" && fromTeam != toTeam,
+			})
+			to := &repositories[repoIndex[upstream]]
+			to.Downstream = append(to.Downstream, m.Repository)
+		}
+		if len(upstreams) > 0 {
+			repositories[from].Upstream = upstreams
+		}
+	}
+
+	for i := range teams {
+		teams[i].Upstream = teamUpstream(&teams[i], repositories)
+	}
+	return graph
+}
+
+// repositoryOwners maps repositories to the team whose members made most of
+// their commits, the first team by name on ties. Repositories no team member
+// committed to have no owner.
+func repositoryOwners(repositories []models.RepositoryMetrics, teams []models.TeamMetrics) map[string]string {
+	teamOf := make(map[string]string)
+	for _, team := range teams {
+		for _, member := range team.Members {
+			login := strings.ToLower(member)
+			if current, ok := teamOf[login]; !ok || team.Name < current {
+				teamOf[login] = team.Name
+			}
+		}
+	}
+
+	owners := make(map[string]string)
+	for _, rm := range repositories {
+		commits := make(map[string]int)
+		for _, c := range rm.Contributors {
+			if team, ok := teamOf[strings.ToLower(c.Login)]; ok && c.CommitCount > 0 {
+				commits[team] += c.CommitCount
+			}
+		}
+		best := ""
+		for _, team := range sortedKeys(commits) {
+			if best == "" || commits[team] > commits[best] {
+				best = team
+			}
+		}
+		if best != "" {
+			owners[rm.FullName] = best
+		}
+	}
+	return owners
+}
+
+// teamUpstream lists the repositories required by the repositories a team's
+// members contributed to, other than those repositories themselves
+func teamUpstream(team *models.TeamMetrics, repositories []models.RepositoryMetrics) []string {
+	members := make(map[string]bool, len(team.Members))
+	for _, member := range team.Members {
+		members[strings.ToLower(member)] = true
+	}
+
+	worked := make(map[string]bool)
+	for _, rm := range repositories {
+		for _, c := range rm.Contributors {
+			if members[strings.ToLower(c.Login)] {
+				worked[rm.FullName] = true
+				break
+			}
+		}
+	}
+
+	upstream := make(map[string]bool)
+	for _, rm := range repositories {
+		if !worked[rm.FullName] {
+			continue
+		}
+		for _, name := range rm.Upstream {
+			if !worked[name] {
+				upstream[name] = true
+			}
+		}
+	}
+	if len(upstream) == 0 {
+		return nil
+	}
+	return sortedKeys(upstream)
+}
+
+// sortedKeys returns the keys of a map in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,102 @@
+package aggregator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_AggregateDependencies(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Teams = []config.TeamConfig{
+		{Name: "Platform", Members: []string{"alice"}},
+		{Name: "Web", Members: []string{"bob", "carol"}},
+	}
+	agg := New(cfg)
+
+	proto := models.Package{Name: "github.com/acme/proto", Ecosystem: models.EcosystemGo}
+	ui := models.Package{Name: "@acme/ui", Ecosystem: models.EcosystemNPM}
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a1", Author: models.Author{Login: "alice"}, Repository: "acme/proto"},
+			{SHA: "a2", Author: models.Author{Login: "alice"}, Repository: "acme/api"},
+			{SHA: "b1", Author: models.Author{Login: "bob"}, Repository: "acme/web"},
+			{SHA: "b2", Author: models.Author{Login: "bob"}, Repository: "acme/ui"},
+			{SHA: "c1", Author: models.Author{Login: "carol"}, Repository: "acme/ui"},
+		},
+		Manifests: []models.RepositoryManifest{
+			{Repository: "acme/web", Provides: []models.Package{{Name: "@acme/web", Ecosystem: models.EcosystemNPM}}, Requires: []models.Package{ui, {Name: "vue", Ecosystem: models.EcosystemNPM}}},
+			{Repository: "acme/api", Provides: []models.Package{{Name: "github.com/acme/api", Ecosystem: models.EcosystemGo}}, Requires: []models.Package{proto}},
+			{Repository: "acme/ui", Provides: []models.Package{ui}, Requires: []models.Package{}},
+			{Repository: "acme/proto", Provides: []models.Package{proto}, Requires: []models.Package{}},
+			// Not analyzed: its packages don't link repositories
+			{Repository: "acme/archived", Provides: []models.Package{{Name: "vue", Ecosystem: models.EcosystemNPM}}},
+		},
+	}
+
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{})
+	require.NoError(t, err)
+
+	graph := metrics.Dependencies
+	require.NotNil(t, graph)
+	assert.Equal(t, []models.DependencyNode{
+		{Repository: "acme/api", Team: "Platform"},
+		{Repository: "acme/proto", Team: "Platform"},
+		{Repository: "acme/ui", Team: "Web"},
+		{Repository: "acme/web", Team: "Web"},
+	}, graph.Nodes)
+	assert.Equal(t, []models.DependencyEdge{
+		{From: "acme/api", To: "acme/proto", Packages: []models.Package{proto}},
+		{From: "acme/web", To: "acme/ui", Packages: []models.Package{ui}},
+	}, graph.Edges)
+
+	repos := make(map[string]models.RepositoryMetrics)
+	for _, rm := range metrics.Repositories {
+		repos[rm.FullName] = rm
+	}
+	assert.Equal(t, []string{"acme/proto"}, repos["acme/api"].Upstream)
+	assert.Equal(t, []string{"acme/api"}, repos["acme/proto"].Downstream)
+	assert.Empty(t, repos["acme/proto"].Upstream)
+
+	// The teams' own repositories aren't upstream of their work
+	for _, team := range metrics.Teams {
+		assert.Empty(t, team.Upstream, team.Name)
+	}
+}
+
+func TestApplyDependencies_CrossTeam(t *testing.T) {
+	t.Parallel()
+
+	proto := models.Package{Name: "github.com/acme/proto", Ecosystem: models.EcosystemGo}
+	data := &models.RawData{Manifests: []models.RepositoryManifest{
+		{Repository: "acme/api", Requires: []models.Package{proto}},
+		{Repository: "acme/proto", Provides: []models.Package{proto}},
+	}}
+	repositories := []models.RepositoryMetrics{
+		{FullName: "acme/api", Contributors: []models.ContributorMetrics{{Login: "Bob", CommitCount: 3}, {Login: "alice", CommitCount: 1}}},
+		{FullName: "acme/proto", Contributors: []models.ContributorMetrics{{Login: "alice", CommitCount: 5}}},
+	}
+	teams := []models.TeamMetrics{
+		{Name: "Platform", Members: []string{"alice"}},
+		{Name: "Web", Members: []string{"bob"}},
+	}
+
+	graph := applyDependencies(data, repositories, teams)
+	require.NotNil(t, graph)
+	require.Len(t, graph.Edges, 1)
+	assert.True(t, graph.Edges[0].CrossTeam, "the API is owned by Web, the protocol by Platform")
+	assert.Equal(t, "Web", graph.Nodes[0].Team)
+
+	// Web works on the API, which requires Platform's protocol
+	assert.Equal(t, []string{"acme/proto"}, teams[1].Upstream)
+	// Platform worked on both, so neither is upstream of its work
+	assert.Empty(t, teams[0].Upstream)
+
+	assert.Nil(t, applyDependencies(&models.RawData{}, repositories, teams), "no graph without manifests")
+}
@@ -0,0 +1,132 @@
+package aggregator
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/domain/scoring"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// tiedFixture is activity of several contributors across several
+// repositories, where everyone does the same amount of work so that every
+// ordering falls back to its tie-breakers
+func tiedFixture() *models.RawData {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	data := &models.RawData{}
+	repos := []string{"org/api", "org/web", "org/docs", "org/infra"}
+	logins := []string{"dana", "alex", "chris", "blake", "eve"}
+
+	for r, repo := range repos {
+		for l, login := range logins {
+			author := models.Author{Login: login, Name: login, Email: login + "@example.com"}
+			day := start.AddDate(0, 0, r+l)
+			sha := fmt.Sprintf("%s-%s", repo, login)
+			data.Commits = append(data.Commits, models.Commit{
+				SHA:           sha,
+				Message:       "change",
+				Author:        author,
+				Committer:     author,
+				Date:          day,
+				Additions:     20,
+				Deletions:     10,
+				FilesChanged:  2,
+				FilesModified: []string{"a.go", "b_test.go"},
+				FileStats:     []models.FileStat{{Path: "a.go", Additions: 10, Deletions: 5}, {Path: "b_test.go", Additions: 10, Deletions: 5}},
+				Repository:    repo,
+				HasTests:      true,
+			})
+
+			number := 100*r + l + 1
+			merged := day.Add(4 * time.Hour)
+			reviewer := logins[(l+1)%len(logins)]
+			review := models.Review{
+				ID:          int64(number),
+				PullRequest: number,
+				Repository:  repo,
+				Author:      models.Author{Login: reviewer},
+				State:       models.ReviewApproved,
+				SubmittedAt: day.Add(time.Hour),
+			}
+			data.PullRequests = append(data.PullRequests, models.PullRequest{
+				Number:     number,
+				Title:      "feature",
+				State:      models.PRStateMerged,
+				Author:     author,
+				Repository: repo,
+				CreatedAt:  day,
+				UpdatedAt:  merged,
+				MergedAt:   &merged,
+				Additions:  20,
+				Deletions:  10,
+				Reviews:    []models.Review{review},
+			})
+			data.Reviews = append(data.Reviews, review)
+			data.Issues = append(data.Issues, models.Issue{
+				Number:     number,
+				Title:      "bug",
+				State:      models.IssueStateOpen,
+				Author:     author,
+				Repository: repo,
+				CreatedAt:  day,
+				UpdatedAt:  day,
+			})
+		}
+	}
+	return data
+}
+
+// analyze runs the fixture through aggregation and scoring and encodes the result
+func analyze(t *testing.T, data *models.RawData) []byte {
+	t.Helper()
+
+	cfg := config.DefaultConfig()
+	cfg.Teams = []config.TeamConfig{
+		{Name: "Core", Members: []string{"dana", "alex"}},
+		{Name: "Platform", Members: []string{"chris", "blake"}},
+	}
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	metrics = scoring.NewCalculator(cfg).Calculate(metrics)
+
+	out, err := json.MarshalIndent(metrics, "", "  ")
+	require.NoError(t, err)
+	return append(out, '\n')
+}
+
+func TestAggregate_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	want := analyze(t, tiedFixture())
+	for i := range 20 {
+		got := analyze(t, tiedFixture())
+		if !bytes.Equal(want, got) {
+			assert.Equal(t, string(want), string(got), "run %d differs", i+2)
+			return
+		}
+	}
+
+	golden := filepath.Join("testdata", "tied.golden.json")
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0750))
+		require.NoError(t, os.WriteFile(golden, want, 0600))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err, "run go test -update to create the golden file")
+	assert.Equal(t, string(expected), string(want))
+}
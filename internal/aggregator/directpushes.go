@@ -0,0 +1,78 @@
+package aggregator
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// rebaseWindow is how far a mainline commit's commit time may be from a PR's
+// merge to count as one of its rebased commits
+const rebaseWindow = time.Minute
+
+// applyDirectPushes counts the commits of the period pushed to a default
+// branch without a PR. A commit on the branch's first-parent history came
+// through a PR when it is a PR's merge or squash commit, or was committed
+// within a minute of a PR being merged, as GitHub commits rebased PRs all
+// at once. Every other mainline commit was pushed directly.
+func (a *Aggregator) applyDirectPushes(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+	commitLogin func(models.Commit) string,
+) {
+	if len(data.Mainline) == 0 {
+		return
+	}
+
+	mergeCommits := make(map[string]bool)
+	merges := make(map[string][]time.Time) // repository -> merge times
+	for _, pr := range data.PullRequests {
+		if !pr.IsMerged() {
+			continue
+		}
+		if pr.MergeCommitSHA != "" {
+			mergeCommits[pr.MergeCommitSHA] = true
+		}
+		if pr.MergedAt != nil {
+			merges[pr.Repository] = append(merges[pr.Repository], *pr.MergedAt)
+		}
+	}
+
+	direct := make(map[string]bool) // repository@SHA
+	for repo, mainline := range data.Mainline {
+		for _, c := range mainline {
+			if mergeCommits[c.SHA] || mergedNear(merges[repo], c.CommittedAt) {
+				continue
+			}
+			direct[repo+"@"+c.SHA] = true
+		}
+	}
+
+	for _, commit := range data.Commits {
+		if !direct[commit.Repository+"@"+commit.SHA] {
+			continue
+		}
+		if rm, ok := repoMap[commit.Repository]; ok {
+			rm.DirectPushes++
+		}
+		login := commitLogin(commit)
+		if cm, ok := contributorMap[login]; ok {
+			cm.DirectPushes++
+		}
+		if rcm, ok := repoContributorMap[commit.Repository][login]; ok {
+			rcm.DirectPushes++
+		}
+	}
+}
+
+// mergedNear reports whether any of the merge times is within rebaseWindow of at
+func mergedNear(merges []time.Time, at time.Time) bool {
+	for _, m := range merges {
+		if d := at.Sub(m); d > -rebaseWindow && d < rebaseWindow {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_DirectPushes(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	hours := func(h int) time.Time { return at.Add(time.Duration(h) * time.Hour) }
+	commit := func(sha, login string, when time.Time) models.Commit {
+		return models.Commit{SHA: sha, Author: models.Author{Login: login}, Date: when, Repository: "acme/repo"}
+	}
+	squashed, rebased := hours(1), hours(5)
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			commit("squash", "alice", hours(1)),
+			commit("direct1", "bob", hours(2)),
+			commit("branch", "alice", hours(3)), // Only on a feature branch
+			commit("rebase1", "alice", hours(4)),
+			commit("rebase2", "alice", hours(4)),
+			commit("direct2", "bob", hours(6)),
+		},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "alice"}, Repository: "acme/repo", CreatedAt: at, State: models.PRStateMerged, MergedAt: &squashed, MergeCommitSHA: "squash"},
+			{Number: 2, Author: models.Author{Login: "alice"}, Repository: "acme/repo", CreatedAt: at, State: models.PRStateMerged, MergedAt: &rebased, MergeCommitSHA: "rebase2"},
+		},
+		Mainline: map[string][]models.MainlineCommit{
+			"acme/repo": {
+				{SHA: "direct2", CommittedAt: hours(6)},
+				{SHA: "rebase2", CommittedAt: rebased},
+				{SHA: "rebase1", CommittedAt: rebased.Add(-time.Second)},
+				{SHA: "direct1", CommittedAt: hours(2)},
+				{SHA: "squash", CommittedAt: squashed},
+			},
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	cfg := config.DefaultConfig()
+	metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	require.Len(t, metrics.Repositories, 1)
+	assert.Equal(t, 2, metrics.Repositories[0].DirectPushes)
+	for _, c := range metrics.Contributors {
+		assert.Equal(t, map[string]int{"bob": 2}[c.Login], c.DirectPushes, c.Login)
+	}
+	for _, c := range metrics.Repositories[0].Contributors {
+		assert.Equal(t, map[string]int{"bob": 2}[c.Login], c.DirectPushes, c.Login)
+	}
+
+	// Nothing is reported without the mainline
+	data.Mainline = nil
+	metrics, err = New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	assert.Zero(t, metrics.Repositories[0].DirectPushes)
+}
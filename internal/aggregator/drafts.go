@@ -0,0 +1,59 @@
+package aggregator
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyDraftMetrics credits PR authors with their draft PRs: how many they
+// opened as or converted to drafts, marked ready or abandoned, and the time
+// spent in draft. Drafts still open count until the end of the period.
+func (a *Aggregator) applyDraftMetrics(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	until time.Time,
+) {
+	touched := make(map[*models.ContributorMetrics]bool)
+	for i := range data.PullRequests {
+		pr := &data.PullRequests[i]
+		login := pr.Author.Login
+		if login == "" || !pr.WasDraft() {
+			continue
+		}
+
+		// Time from opening a draft to marking it ready
+		var toReady *time.Duration
+		if pr.OpenedAsDraft() {
+			if ready := pr.ReadyAt(); ready != nil {
+				d := ready.Sub(pr.CreatedAt)
+				toReady = &d
+			}
+		}
+		inDraft := pr.TimeInDraft(until)
+
+		for _, cm := range []*models.ContributorMetrics{contributorMap[login], repoContributorMap[pr.Repository][login]} {
+			if cm == nil {
+				continue
+			}
+			touched[cm] = true
+			cm.DraftPRs++
+			cm.AvgTimeInDraft += inDraft.Hours()
+			if toReady != nil {
+				cm.DraftsReady++
+				cm.AvgDraftToReady += toReady.Hours()
+			}
+			if pr.Draft && pr.State == models.PRStateClosed {
+				cm.DraftsAbandoned++
+			}
+		}
+	}
+
+	for cm := range touched {
+		cm.AvgTimeInDraft /= float64(cm.DraftPRs)
+		if cm.DraftsReady > 0 {
+			cm.AvgDraftToReady /= float64(cm.DraftsReady)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_DraftMetrics(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	hours := func(h int) time.Time { return at.Add(time.Duration(h) * time.Hour) }
+	merged, closed := hours(10), hours(3)
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			// Opened as a draft, ready after 4 hours
+			{Number: 1, Author: models.Author{Login: "alice"}, CreatedAt: at, Repository: "acme/repo", State: models.PRStateMerged, MergedAt: &merged,
+				DraftEvents: []models.DraftEvent{{At: hours(4)}}},
+			// Abandoned while a draft
+			{Number: 2, Author: models.Author{Login: "alice"}, CreatedAt: at, Repository: "acme/repo", State: models.PRStateClosed, ClosedAt: &closed, Draft: true},
+			// Never a draft
+			{Number: 3, Author: models.Author{Login: "alice"}, CreatedAt: at, Repository: "acme/repo", State: models.PRStateOpen},
+			// Community drafts: one marked ready, one still open as a draft
+			{Number: 4, Author: models.Author{Login: "outsider"}, CreatedAt: at, Repository: "acme/repo", State: models.PRStateOpen,
+				DraftEvents: []models.DraftEvent{{At: hours(5)}}},
+			{Number: 5, Author: models.Author{Login: "outsider"}, CreatedAt: at, Repository: "acme/repo", State: models.PRStateOpen, Draft: true},
+		},
+		Reviews: []models.Review{
+			// Feedback on the draft doesn't count as a response to the ready PR
+			{PullRequest: 4, Author: models.Author{Login: "alice"}, SubmittedAt: hours(1), Repository: "acme/repo"},
+			{PullRequest: 4, Author: models.Author{Login: "alice"}, SubmittedAt: hours(7), Repository: "acme/repo"},
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := hours(24)
+
+	cfg := config.DefaultConfig()
+	cfg.Teams = []config.TeamConfig{{Name: "Core", Members: []string{"alice"}}}
+	cfg.Community = config.CommunityConfig{Enabled: true}
+	metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, c := range metrics.Contributors {
+		byLogin[c.Login] = c
+	}
+	alice := byLogin["alice"]
+	assert.Equal(t, 2, alice.DraftPRs)
+	assert.Equal(t, 1, alice.DraftsReady)
+	assert.Equal(t, 1, alice.DraftsAbandoned)
+	assert.InDelta(t, 3.5, alice.AvgTimeInDraft, 0.001)
+	assert.InDelta(t, 4, alice.AvgDraftToReady, 0.001)
+
+	// The open draft counts until the end of the period
+	outsider := byLogin["outsider"]
+	assert.Equal(t, 2, outsider.DraftPRs)
+	assert.InDelta(t, (5+24)/2.0, outsider.AvgTimeInDraft, 0.001)
+
+	require.Len(t, metrics.Repositories, 1)
+	require.Len(t, metrics.Repositories[0].Contributors, 2)
+	assert.Equal(t, 1, metrics.Repositories[0].Contributors[0].DraftsAbandoned+metrics.Repositories[0].Contributors[1].DraftsAbandoned)
+
+	community := metrics.Community
+	require.NotNil(t, community)
+	assert.InDelta(t, 2, community.AvgPRResponseHours, 0.001)
+	assert.Zero(t, community.AwaitingResponse, "drafts don't await a response")
+}
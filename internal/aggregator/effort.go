@@ -0,0 +1,27 @@
+package aggregator
+
+import "github.com/lukaszraczylo/git-velocity/pkg/models"
+
+// applyEffort credits the effort estimated for merged PRs to their authors
+// and repositories (no-op unless the effort hook estimated them)
+func (a *Aggregator) applyEffort(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+) {
+	for _, pr := range data.PullRequests {
+		if !pr.IsMerged() || pr.Effort == nil {
+			continue
+		}
+		login := pr.Author.Login
+		for _, cm := range []*models.ContributorMetrics{contributorMap[login], repoContributorMap[pr.Repository][login]} {
+			if cm != nil {
+				cm.EffortPoints += pr.Effort.Points
+			}
+		}
+		if rm, ok := repoMap[pr.Repository]; ok {
+			rm.EffortPoints += pr.Effort.Points
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_Effort(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	merged := at.Add(time.Hour)
+	pr := func(number int, author string, mergedAt *time.Time, effort *models.Effort) models.PullRequest {
+		state := models.PRStateOpen
+		if mergedAt != nil {
+			state = models.PRStateMerged
+		}
+		return models.PullRequest{
+			Number: number, Author: models.Author{Login: author}, Repository: "acme/repo",
+			CreatedAt: at, State: state, MergedAt: mergedAt, Effort: effort,
+		}
+	}
+
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			pr(1, "alice", &merged, &models.Effort{Points: 3}),
+			pr(2, "alice", &merged, &models.Effort{Points: 5, Size: "L"}),
+			pr(3, "alice", nil, &models.Effort{Points: 8}), // Not merged yet
+			pr(4, "bob", &merged, nil),                     // Not estimated
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	efforts := make(map[string]float64)
+	for _, c := range metrics.Contributors {
+		efforts[c.Login] = c.EffortPoints
+	}
+	assert.Equal(t, map[string]float64{"alice": 8, "bob": 0}, efforts)
+	require.Len(t, metrics.Repositories, 1)
+	assert.InDelta(t, 8.0, metrics.Repositories[0].EffortPoints, 0.001)
+	for _, c := range metrics.Repositories[0].Contributors {
+		assert.Equal(t, efforts[c.Login], c.EffortPoints, c.Login)
+	}
+}
@@ -0,0 +1,91 @@
+package aggregator
+
+import (
+	"slices"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/forecast"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// addForecasts projects commits and PRs of the timeline, each repository and
+// each team past the timeline, when forecasting is enabled. Weeks the period
+// only partly covers are left out of the history, so a period ending
+// mid-week doesn't read as a slowdown.
+func (a *Aggregator) addForecasts(timeline *models.VelocityTimeline, activity *activityLog, repositories []models.RepositoryMetrics, teams []models.TeamMetrics, period models.Period) {
+	if !a.config.Forecast.Enabled || timeline == nil {
+		return
+	}
+	weeks, start, end := timelineWeeks(period)
+	from, to := completeWeeks(weeks, start, end)
+	if to-from < forecast.MinWeeks {
+		return
+	}
+	f := forecast.New(a.config.Forecast)
+	history := weeks[from:to]
+	lastWeek := history[len(history)-1]
+
+	var series []forecast.Series
+	for _, s := range timeline.Series {
+		if s.Name == "Commits" || s.Name == "PRs" {
+			series = append(series, forecast.Series{Name: s.Name, Data: s.Data[from:to]})
+		}
+	}
+	timeline.Forecast = f.Project(lastWeek, series...)
+
+	for i := range repositories {
+		if days, ok := activity.repos[repositories[i].FullName]; ok {
+			repositories[i].Forecast = f.Project(lastWeek, weeklySeries(history, days)...)
+		}
+	}
+	for i := range teams {
+		var members []map[string]*dayTotals
+		for _, member := range append(slices.Clone(teams[i].Members), serviceAccountLogin(teams[i].Name)) {
+			if days, ok := activity.contributors[member]; ok {
+				members = append(members, days)
+			}
+		}
+		if len(members) > 0 {
+			teams[i].Forecast = f.Project(lastWeek, weeklySeries(history, members...)...)
+		}
+	}
+}
+
+// completeWeeks returns the range of weeks lying wholly within start and end
+func completeWeeks(weeks []time.Time, start, end time.Time) (from, to int) {
+	startDay := start.Format("2006-01-02")
+	endDay := end.Format("2006-01-02")
+	for from < len(weeks) && weeks[from].Format("2006-01-02") < startDay {
+		from++
+	}
+	to = len(weeks)
+	for to > from && weeks[to-1].AddDate(0, 0, 6).Format("2006-01-02") > endDay {
+		to--
+	}
+	return from, to
+}
+
+// weeklySeries sums daily commits and PRs into the given weeks
+func weeklySeries(weeks []time.Time, daily ...map[string]*dayTotals) []forecast.Series {
+	commits := make([]float64, len(weeks))
+	prs := make([]float64, len(weeks))
+	first, _ := time.Parse("2006-01-02", weeks[0].Format("2006-01-02"))
+	for _, days := range daily {
+		for day, totals := range days {
+			d, err := time.Parse("2006-01-02", day)
+			if err != nil || d.Before(first) {
+				continue
+			}
+			idx := int(d.Sub(first).Hours()/24) / 7
+			if idx >= len(weeks) {
+				continue
+			}
+			commits[idx] += totals.commits
+			prs[idx] += totals.prs
+		}
+	}
+	return []forecast.Series{
+		{Name: "Commits", Data: commits},
+		{Name: "PRs", Data: prs},
+	}
+}
@@ -0,0 +1,41 @@
+package aggregator
+
+import (
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// buildGroups rolls up the repositories of each configured group. Groups
+// matching no analyzed repository are kept with zero totals, so the
+// dashboard still lists them.
+func buildGroups(groupCfgs []config.GroupConfig, repositories []models.RepositoryMetrics, period models.Period) []models.GroupMetrics {
+	var groups []models.GroupMetrics
+	for i := range groupCfgs {
+		cfg := &groupCfgs[i]
+		group := models.GroupMetrics{
+			Name:         cfg.Name,
+			Color:        cfg.Color,
+			Repositories: []string{},
+			Period:       period,
+		}
+
+		contributors := make(map[string]bool)
+		for _, rm := range repositories {
+			if !cfg.Includes(rm.FullName) {
+				continue
+			}
+			group.Repositories = append(group.Repositories, rm.FullName)
+			group.TotalCommits += rm.TotalCommits
+			group.TotalPRs += rm.TotalPRs
+			group.TotalReviews += rm.TotalReviews
+			group.TotalLinesAdded += rm.TotalLinesAdded
+			group.TotalLinesDeleted += rm.TotalLinesDeleted
+			for _, c := range rm.Contributors {
+				contributors[c.Login] = true
+			}
+		}
+		group.ActiveContributors = len(contributors)
+		groups = append(groups, group)
+	}
+	return groups
+}
@@ -0,0 +1,48 @@
+package aggregator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_AggregateGroups(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Groups = []config.GroupConfig{
+		{Name: "Payments", Repos: []string{"acme/ledger", "acme/payments-*"}, Color: "#10b981"},
+		{Name: "Mobile", Repos: []string{"acme/ios"}},
+	}
+	agg := New(cfg)
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a1", Author: models.Author{Login: "alice"}, Repository: "acme/ledger", Additions: 10, Deletions: 2},
+			{SHA: "a2", Author: models.Author{Login: "alice"}, Repository: "acme/payments-api", Additions: 5},
+			{SHA: "b1", Author: models.Author{Login: "bob"}, Repository: "acme/payments-api", Additions: 1},
+			{SHA: "c1", Author: models.Author{Login: "carol"}, Repository: "acme/website", Additions: 100},
+		},
+	}
+
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{})
+	require.NoError(t, err)
+
+	require.Len(t, metrics.Groups, 2)
+	payments := metrics.Groups[0]
+	assert.Equal(t, "Payments", payments.Name)
+	assert.Equal(t, "#10b981", payments.Color)
+	assert.Equal(t, []string{"acme/ledger", "acme/payments-api"}, payments.Repositories)
+	assert.Equal(t, 3, payments.TotalCommits)
+	assert.Equal(t, 16, payments.TotalLinesAdded)
+	assert.Equal(t, 2, payments.TotalLinesDeleted)
+	assert.Equal(t, 2, payments.ActiveContributors, "contributors are counted once across repositories")
+
+	mobile := metrics.Groups[1]
+	assert.Empty(t, mobile.Repositories, "groups without analyzed repositories are kept")
+	assert.Zero(t, mobile.TotalCommits)
+}
@@ -0,0 +1,107 @@
+package aggregator
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyRepositoryHealth scores repository settings and counts audit-log events
+// per repository. Every analyzed repository gets an audit summary when the
+// integration is enabled, so a zero count means no events were recorded rather
+// than that none were fetched.
+func (a *Aggregator) applyRepositoryHealth(data *models.RawData, repoMap map[string]*models.RepositoryMetrics, period models.Period) {
+	auditEnabled := a.config.Integrations.AuditLog.Enabled
+
+	// Match case-insensitively, the audit log needn't use the configured casing
+	byName := make(map[string]*models.RepositoryMetrics, len(repoMap))
+	for name, rm := range repoMap {
+		byName[strings.ToLower(name)] = rm
+
+		settings, ok := data.RepositorySettings[name]
+		if !ok && !auditEnabled {
+			continue
+		}
+		rm.Health = &models.RepositoryHealth{}
+		if ok {
+			rm.Health.Checks = healthChecks(settings)
+			rm.Health.Score = healthScore(rm.Health.Checks)
+		}
+		if auditEnabled {
+			rm.Health.Audit = &models.AuditSummary{Events: make(map[string]int)}
+		}
+	}
+
+	if !auditEnabled {
+		return
+	}
+
+	for _, event := range data.AuditEvents {
+		rm, ok := byName[strings.ToLower(event.Repository)]
+		if !ok || event.CreatedAt.Before(period.Start) || event.CreatedAt.After(period.End) {
+			continue
+		}
+
+		audit := rm.Health.Audit
+		audit.Events[event.Action]++
+		switch event.Action {
+		case config.AuditBranchProtectionOverride:
+			audit.BranchProtectionOverrides++
+		case config.AuditRejectedRefUpdate:
+			audit.RejectedPushes++
+		case config.AuditForcePushPolicyChange:
+			audit.ForcePushPolicyChanges++
+		}
+	}
+}
+
+// healthChecks builds the settings checklist of a repository
+func healthChecks(s models.RepositorySettings) []models.HealthCheck {
+	protection := models.HealthCheck{ID: "branch_protection", Status: models.HealthUnknown, Value: s.DefaultBranch}
+	if s.BranchProtected != nil {
+		protection.Status = checkStatus(*s.BranchProtected)
+	}
+
+	reviews := models.HealthCheck{ID: "required_reviews", Status: models.HealthUnknown}
+	if s.RequiredReviews != nil {
+		reviews.Status = checkStatus(*s.RequiredReviews > 0)
+		reviews.Value = strconv.Itoa(*s.RequiredReviews)
+	}
+
+	return []models.HealthCheck{
+		protection,
+		reviews,
+		{ID: "codeowners", Status: checkStatus(s.CodeOwners)},
+		{ID: "license", Status: checkStatus(s.License != ""), Value: s.License},
+	}
+}
+
+// healthScore returns the percentage of known checks that pass, or nil when
+// none could be evaluated
+func healthScore(checks []models.HealthCheck) *int {
+	known, passed := 0, 0
+	for _, check := range checks {
+		switch check.Status {
+		case models.HealthPass:
+			known++
+			passed++
+		case models.HealthFail:
+			known++
+		}
+	}
+	if known == 0 {
+		return nil
+	}
+	score := int(math.Round(float64(passed) * 100 / float64(known)))
+	return &score
+}
+
+func checkStatus(ok bool) string {
+	if ok {
+		return models.HealthPass
+	}
+	return models.HealthFail
+}
@@ -0,0 +1,112 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_RepositoryHealthAudit(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: at, Repository: "Owner/api"},
+			{SHA: "b", Author: models.Author{Login: "alice"}, Date: at, Repository: "Owner/web"},
+		},
+		AuditEvents: []models.AuditEvent{
+			{Action: config.AuditBranchProtectionOverride, Actor: "admin", Repository: "owner/api", CreatedAt: at},
+			{Action: config.AuditBranchProtectionOverride, Actor: "admin", Repository: "owner/api", CreatedAt: at},
+			{Action: config.AuditRejectedRefUpdate, Actor: "alice", Repository: "owner/api", CreatedAt: at},
+			{Action: config.AuditForcePushPolicyChange, Actor: "admin", Repository: "owner/api", CreatedAt: at},
+			{Action: "repo.access", Actor: "admin", Repository: "owner/api", CreatedAt: at},
+			// Outside the period and for a repository not analyzed
+			{Action: config.AuditBranchProtectionOverride, Actor: "admin", Repository: "owner/api", CreatedAt: at.AddDate(0, -2, 0)},
+			{Action: config.AuditBranchProtectionOverride, Actor: "admin", Repository: "owner/other", CreatedAt: at},
+		},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+	dateRange := &config.ParsedDateRange{Start: &start, End: &end}
+
+	cfg := config.DefaultConfig()
+	metrics, err := New(cfg).Aggregate(data, dateRange)
+	require.NoError(t, err)
+	for _, repo := range metrics.Repositories {
+		assert.Nil(t, repo.Health, "health is only reported when the audit log is enabled")
+	}
+
+	cfg.Integrations.AuditLog.Enabled = true
+	metrics, err = New(cfg).Aggregate(data, dateRange)
+	require.NoError(t, err)
+
+	audit := make(map[string]*models.AuditSummary)
+	for _, repo := range metrics.Repositories {
+		require.NotNil(t, repo.Health)
+		audit[repo.FullName] = repo.Health.Audit
+	}
+	require.NotNil(t, audit["Owner/api"])
+	assert.Equal(t, 2, audit["Owner/api"].BranchProtectionOverrides)
+	assert.Equal(t, 1, audit["Owner/api"].RejectedPushes)
+	assert.Equal(t, 1, audit["Owner/api"].ForcePushPolicyChanges)
+	assert.Equal(t, 1, audit["Owner/api"].Events["repo.access"])
+
+	require.NotNil(t, audit["Owner/web"])
+	assert.Zero(t, audit["Owner/web"].BranchProtectionOverrides)
+	assert.Empty(t, audit["Owner/web"].Events)
+}
+
+func TestAggregator_RepositoryHealthChecks(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	protected, reviews := true, 2
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: at, Repository: "owner/api"},
+			{SHA: "b", Author: models.Author{Login: "alice"}, Date: at, Repository: "owner/web"},
+			{SHA: "c", Author: models.Author{Login: "alice"}, Date: at, Repository: "owner/docs"},
+		},
+		RepositorySettings: map[string]models.RepositorySettings{
+			"owner/api": {DefaultBranch: "main", BranchProtected: &protected, RequiredReviews: &reviews, CodeOwners: true, License: "MIT"},
+			// Protection settings not readable with the token
+			"owner/web": {DefaultBranch: "main", License: "Apache-2.0"},
+		},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	health := make(map[string]*models.RepositoryHealth)
+	for _, repo := range metrics.Repositories {
+		health[repo.FullName] = repo.Health
+	}
+
+	require.NotNil(t, health["owner/api"])
+	require.NotNil(t, health["owner/api"].Score)
+	assert.Equal(t, 100, *health["owner/api"].Score)
+	assert.Nil(t, health["owner/api"].Audit)
+	assert.Equal(t, []models.HealthCheck{
+		{ID: "branch_protection", Status: models.HealthPass, Value: "main"},
+		{ID: "required_reviews", Status: models.HealthPass, Value: "2"},
+		{ID: "codeowners", Status: models.HealthPass},
+		{ID: "license", Status: models.HealthPass, Value: "MIT"},
+	}, health["owner/api"].Checks)
+
+	// Unknown checks don't count towards the score
+	require.NotNil(t, health["owner/web"])
+	require.NotNil(t, health["owner/web"].Score)
+	assert.Equal(t, 50, *health["owner/web"].Score)
+	assert.Equal(t, models.HealthUnknown, health["owner/web"].Checks[0].Status)
+	assert.Equal(t, models.HealthUnknown, health["owner/web"].Checks[1].Status)
+
+	assert.Nil(t, health["owner/docs"], "no settings were fetched")
+}
@@ -0,0 +1,108 @@
+package aggregator
+
+import (
+	"sort"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyHotspots ranks each repository's files by churn (commits changing the
+// file × lines changed) and keeps the top options.hotspot_limit. Commits that
+// shrink a hotspot file count as stabilization work for their author.
+func (a *Aggregator) applyHotspots(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+	commitLogin func(models.Commit) string,
+) {
+	limit := a.config.Options.HotspotLimit
+	if limit <= 0 {
+		return
+	}
+
+	type fileChurn struct {
+		hotspot models.FileHotspot
+		authors map[string]bool
+	}
+	files := make(map[string]map[string]*fileChurn) // repo -> path -> churn
+	for _, commit := range data.Commits {
+		login := commitLogin(commit)
+		for _, fs := range commit.FileStats {
+			if files[commit.Repository] == nil {
+				files[commit.Repository] = make(map[string]*fileChurn)
+			}
+			fc := files[commit.Repository][fs.Path]
+			if fc == nil {
+				fc = &fileChurn{hotspot: models.FileHotspot{Path: fs.Path}, authors: make(map[string]bool)}
+				files[commit.Repository][fs.Path] = fc
+			}
+			fc.hotspot.Changes++
+			fc.hotspot.Additions += fs.Additions
+			fc.hotspot.Deletions += fs.Deletions
+			fc.authors[login] = true
+		}
+	}
+
+	for repo, byPath := range files {
+		hotspots := make([]models.FileHotspot, 0, len(byPath))
+		for _, fc := range byPath {
+			h := fc.hotspot
+			// A file changed once isn't churning, however large the change
+			if h.Changes < 2 {
+				continue
+			}
+			h.Churn = h.Changes * (h.Additions + h.Deletions)
+			h.Authors = len(fc.authors)
+			hotspots = append(hotspots, h)
+		}
+		sort.Slice(hotspots, func(i, j int) bool {
+			if hotspots[i].Churn != hotspots[j].Churn {
+				return hotspots[i].Churn > hotspots[j].Churn
+			}
+			return hotspots[i].Path < hotspots[j].Path
+		})
+		if len(hotspots) > limit {
+			hotspots = hotspots[:limit]
+		}
+		if len(hotspots) == 0 {
+			continue
+		}
+
+		index := make(map[string]int, len(hotspots))
+		for i, h := range hotspots {
+			index[h.Path] = i
+		}
+		for _, commit := range data.Commits {
+			if commit.Repository != repo {
+				continue
+			}
+			login := commitLogin(commit)
+			shrunk := false
+			for _, fs := range commit.FileStats {
+				i, ok := index[fs.Path]
+				if !ok || fs.Deletions <= fs.Additions {
+					continue
+				}
+				if hotspots[i].Stabilizers == nil {
+					hotspots[i].Stabilizers = make(map[string]int)
+				}
+				hotspots[i].Stabilizers[login]++
+				shrunk = true
+			}
+			if !shrunk {
+				continue
+			}
+			if cm, ok := contributorMap[login]; ok {
+				cm.HotspotStabilizations++
+			}
+			if rcm, ok := repoContributorMap[repo][login]; ok {
+				rcm.HotspotStabilizations++
+			}
+		}
+
+		if rm, ok := repoMap[repo]; ok {
+			rm.Hotspots = hotspots
+		}
+	}
+}
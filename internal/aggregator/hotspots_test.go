@@ -0,0 +1,70 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_Hotspots(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	commit := func(sha, login string, stats ...models.FileStat) models.Commit {
+		c := models.Commit{
+			SHA:        sha,
+			Author:     models.Author{Login: login, Email: login + "@example.com"},
+			Date:       at,
+			Repository: "owner/repo",
+			FileStats:  stats,
+		}
+		for _, fs := range stats {
+			c.FilesModified = append(c.FilesModified, fs.Path)
+		}
+		return c
+	}
+	stat := func(path string, additions, deletions int) models.FileStat {
+		return models.FileStat{Path: path, Additions: additions, Deletions: deletions}
+	}
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			commit("a1", "alice", stat("core.go", 100, 0), stat("big.go", 500, 0)),
+			commit("a2", "alice", stat("core.go", 50, 10), stat("util.go", 5, 0)),
+			commit("b1", "bob", stat("core.go", 5, 40), stat("util.go", 1, 3)),
+			commit("b2", "bob", stat("docs.go", 1, 0)),
+		},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	cfg := config.DefaultConfig()
+	cfg.Options.HotspotLimit = 1
+	metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	require.Len(t, metrics.Repositories, 1)
+	hotspots := metrics.Repositories[0].Hotspots
+	require.Len(t, hotspots, 1, "limited to the top file; big.go changed only once")
+	assert.Equal(t, models.FileHotspot{
+		Path:        "core.go",
+		Changes:     3,
+		Additions:   155,
+		Deletions:   50,
+		Churn:       615,
+		Authors:     2,
+		Stabilizers: map[string]int{"bob": 1},
+	}, hotspots[0])
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, cm := range metrics.Contributors {
+		byLogin[cm.Login] = cm
+	}
+	assert.Equal(t, 1, byLogin["bob"].HotspotStabilizations)
+	assert.Zero(t, byLogin["alice"].HotspotStabilizations, "only grew files")
+}
@@ -0,0 +1,60 @@
+package aggregator
+
+import (
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// externalIdentities returns the SAML SSO identities by lowercase login,
+// when the integration is enabled
+func (a *Aggregator) externalIdentities(data *models.RawData) map[string]models.ExternalIdentity {
+	if !a.config.Integrations.SAMLIdentities.Enabled || len(data.ExternalIdentities) == 0 {
+		return nil
+	}
+	identities := make(map[string]models.ExternalIdentity, len(data.ExternalIdentities))
+	for _, identity := range data.ExternalIdentities {
+		identities[strings.ToLower(identity.Login)] = identity
+	}
+	return identities
+}
+
+// mapIdentityEmails maps the commit emails a SAML identity lists to its
+// login. The identity provider knows better than the heuristics matching
+// emails by author name, so its mappings replace theirs.
+func mapIdentityEmails(data *models.RawData, identities map[string]models.ExternalIdentity, emailToLogin map[string]string) {
+	if len(identities) == 0 {
+		return
+	}
+	corporate := make(map[string]string)
+	for _, identity := range identities {
+		for _, email := range identity.Emails {
+			corporate[strings.ToLower(email)] = identity.Login
+		}
+	}
+	for _, commit := range data.Commits {
+		if login, ok := corporate[strings.ToLower(commit.Author.Email)]; ok {
+			emailToLogin[commit.Author.Email] = login
+		}
+	}
+}
+
+// nameFromIdentities names contributors as their SAML identity does
+func nameFromIdentities(identities map[string]models.ExternalIdentity, contributorMap map[string]*models.ContributorMetrics, repoContributorMap map[string]map[string]*models.ContributorMetrics) {
+	if len(identities) == 0 {
+		return
+	}
+	rename := func(login string, cm *models.ContributorMetrics) {
+		if identity, ok := identities[strings.ToLower(login)]; ok && identity.Name != "" {
+			cm.Name = identity.Name
+		}
+	}
+	for login, cm := range contributorMap {
+		rename(login, cm)
+	}
+	for _, contribs := range repoContributorMap {
+		for login, rcm := range contribs {
+			rename(login, rcm)
+		}
+	}
+}
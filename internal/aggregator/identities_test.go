@@ -0,0 +1,53 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_ExternalIdentities(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "workstation", Name: "A. S.", Email: "E1987@Corp.example"}, Date: at, Repository: "acme/repo"},
+			{SHA: "b", Author: models.Author{Login: "alice", Name: "alice", Email: "alice@users.noreply.github.com"}, Date: at, Repository: "acme/repo"},
+		},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "alice", Name: "ally"}, Repository: "acme/repo", CreatedAt: at, State: models.PRStateOpen},
+		},
+		ExternalIdentities: []models.ExternalIdentity{
+			{Login: "alice", Name: "Alice Smith", Emails: []string{"e1987@corp.example"}},
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+	dateRange := &config.ParsedDateRange{Start: &start, End: &end}
+
+	cfg := config.DefaultConfig()
+	cfg.Integrations.SAMLIdentities.Enabled = true
+	metrics, err := New(cfg).Aggregate(data, dateRange)
+	require.NoError(t, err)
+	require.Len(t, metrics.Contributors, 1, "the corporate email maps to the login")
+	assert.Equal(t, "alice", metrics.Contributors[0].Login)
+	assert.Equal(t, 2, metrics.Contributors[0].CommitCount)
+	assert.Equal(t, "Alice Smith", metrics.Contributors[0].Name)
+	require.Len(t, metrics.Repositories, 1)
+	require.Len(t, metrics.Repositories[0].Contributors, 1)
+	assert.Equal(t, "Alice Smith", metrics.Repositories[0].Contributors[0].Name)
+
+	// Identities are ignored with the integration disabled
+	metrics, err = New(config.DefaultConfig()).Aggregate(data, dateRange)
+	require.NoError(t, err)
+	assert.Len(t, metrics.Contributors, 2)
+	for _, c := range metrics.Contributors {
+		assert.NotEqual(t, "Alice Smith", c.Name)
+	}
+}
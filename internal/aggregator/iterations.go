@@ -0,0 +1,85 @@
+package aggregator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyReviewIterations credits PR authors and repositories with the average
+// number of review rounds their reviewed PRs went through
+func (a *Aggregator) applyReviewIterations(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+) {
+	reviews := make(map[string][]models.Review) // repository#number
+	for _, review := range data.Reviews {
+		k := prKey(review.Repository, review.PullRequest)
+		reviews[k] = append(reviews[k], review)
+	}
+
+	touched := make(map[*models.ContributorMetrics]bool)
+	repoPRs := make(map[string]int)
+	repoRounds := make(map[string]int)
+	for _, pr := range data.PullRequests {
+		login := pr.Author.Login
+		rounds := reviewRounds(login, reviews[prKey(pr.Repository, pr.Number)])
+		if login == "" || rounds == 0 {
+			continue
+		}
+		repoPRs[pr.Repository]++
+		repoRounds[pr.Repository] += rounds
+		for _, cm := range []*models.ContributorMetrics{contributorMap[login], repoContributorMap[pr.Repository][login]} {
+			if cm == nil {
+				continue
+			}
+			touched[cm] = true
+			cm.PRsWithReviews++
+			cm.AvgReviewIterations += float64(rounds)
+		}
+	}
+
+	for cm := range touched {
+		cm.AvgReviewIterations /= float64(cm.PRsWithReviews)
+	}
+	for repo, prs := range repoPRs {
+		if rm, ok := repoMap[repo]; ok {
+			rm.AvgReviewIterations = float64(repoRounds[repo]) / float64(prs)
+		}
+	}
+}
+
+// reviewRounds counts the review rounds of a PR: its first review, then each
+// review of new commits after changes were requested. Replies of the author,
+// which GitHub records as reviews too, don't count. Reviews without the
+// commit they were submitted on start a new round whenever they follow a
+// change request.
+func reviewRounds(author string, reviews []models.Review) int {
+	sorted := make([]models.Review, 0, len(reviews))
+	for _, r := range reviews {
+		if !strings.EqualFold(r.Author.Login, author) {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].SubmittedAt.Before(sorted[j].SubmittedAt) })
+
+	rounds := 0
+	var requested *models.Review // Change request awaiting new commits
+	for i := range sorted {
+		r := &sorted[i]
+		switch {
+		case rounds == 0:
+			rounds = 1
+		case requested != nil && (r.CommitID == "" || requested.CommitID == "" || r.CommitID != requested.CommitID):
+			rounds++
+			requested = nil
+		}
+		if r.RequestsChanges() {
+			requested = r
+		}
+	}
+	return rounds
+}
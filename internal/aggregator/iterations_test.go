@@ -0,0 +1,107 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestReviewRounds(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	review := func(hour int, login string, state models.ReviewState, commit string) models.Review {
+		return models.Review{Author: models.Author{Login: login}, State: state, SubmittedAt: at.Add(time.Duration(hour) * time.Hour), CommitID: commit}
+	}
+
+	tests := []struct {
+		name    string
+		reviews []models.Review
+		want    int
+	}{
+		{name: "not reviewed", want: 0},
+		{name: "approved at once", reviews: []models.Review{review(1, "bob", models.ReviewApproved, "a1")}, want: 1},
+		{
+			name: "changes requested, then approved on new commits",
+			reviews: []models.Review{
+				review(5, "bob", models.ReviewApproved, "b2"),
+				review(1, "bob", models.ReviewChangesRequested, "a1"),
+			},
+			want: 2,
+		},
+		{
+			name: "follow-ups on the same commit are the same round",
+			reviews: []models.Review{
+				review(1, "bob", models.ReviewChangesRequested, "a1"),
+				review(2, "carol", models.ReviewCommented, "a1"),
+				review(3, "alice", models.ReviewCommented, "b2"), // The author replying
+				review(4, "bob", models.ReviewChangesRequested, "b2"),
+				review(5, "bob", models.ReviewApproved, "c3"),
+			},
+			want: 3,
+		},
+		{
+			name: "comments without a change request don't start rounds",
+			reviews: []models.Review{
+				review(1, "bob", models.ReviewCommented, "a1"),
+				review(2, "bob", models.ReviewApproved, "b2"),
+			},
+			want: 1,
+		},
+		{
+			name: "without commit IDs any review after a change request is a new round",
+			reviews: []models.Review{
+				review(1, "bob", models.ReviewChangesRequested, ""),
+				review(2, "bob", models.ReviewApproved, ""),
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, reviewRounds("alice", tt.reviews))
+		})
+	}
+}
+
+func TestAggregator_ReviewIterations(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	merged := at.Add(24 * time.Hour)
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "alice"}, CreatedAt: at, Repository: "acme/repo", State: models.PRStateMerged, MergedAt: &merged},
+			{Number: 2, Author: models.Author{Login: "alice"}, CreatedAt: at, Repository: "acme/repo", State: models.PRStateMerged, MergedAt: &merged},
+			{Number: 3, Author: models.Author{Login: "alice"}, CreatedAt: at, Repository: "acme/repo", State: models.PRStateOpen},
+		},
+		Reviews: []models.Review{
+			{PullRequest: 1, Author: models.Author{Login: "bob"}, State: models.ReviewChangesRequested, SubmittedAt: at.Add(time.Hour), CommitID: "a1", Repository: "acme/repo"},
+			{PullRequest: 1, Author: models.Author{Login: "bob"}, State: models.ReviewChangesRequested, SubmittedAt: at.Add(2 * time.Hour), CommitID: "b2", Repository: "acme/repo"},
+			{PullRequest: 1, Author: models.Author{Login: "bob"}, State: models.ReviewApproved, SubmittedAt: at.Add(3 * time.Hour), CommitID: "c3", Repository: "acme/repo"},
+			{PullRequest: 2, Author: models.Author{Login: "bob"}, State: models.ReviewApproved, SubmittedAt: at.Add(time.Hour), CommitID: "d4", Repository: "acme/repo"},
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	for _, c := range metrics.Contributors {
+		if c.Login == "alice" {
+			assert.Equal(t, 2, c.PRsWithReviews, "#3 wasn't reviewed")
+			assert.InDelta(t, 2, c.AvgReviewIterations, 0.001)
+		} else {
+			assert.Zero(t, c.AvgReviewIterations, c.Login)
+		}
+	}
+	require.Len(t, metrics.Repositories, 1)
+	assert.InDelta(t, 2, metrics.Repositories[0].AvgReviewIterations, 0.001)
+}
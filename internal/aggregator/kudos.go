@@ -0,0 +1,51 @@
+package aggregator
+
+import (
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyKudos counts the kudos reactions on PRs, reviews and issue comments:
+// received by their author and given by whoever reacted. Reactions to one's
+// own work and those of bots don't count.
+func (a *Aggregator) applyKudos(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+) {
+	count := func(repo, author string, reactions []models.Reaction) {
+		for _, r := range reactions {
+			if r.Login == "" || strings.EqualFold(r.Login, author) || a.isBotLogin(r.Login) {
+				continue
+			}
+			if cm, ok := contributorMap[author]; ok {
+				cm.KudosReceived++
+			}
+			if rcm, ok := repoContributorMap[repo][author]; ok {
+				rcm.KudosReceived++
+			}
+			if cm, ok := contributorMap[r.Login]; ok {
+				cm.KudosGiven++
+			}
+			if rcm, ok := repoContributorMap[repo][r.Login]; ok {
+				rcm.KudosGiven++
+			}
+		}
+	}
+
+	for _, pr := range data.PullRequests {
+		count(pr.Repository, pr.Author.Login, pr.Reactions)
+	}
+	for _, review := range data.Reviews {
+		count(review.Repository, review.Author.Login, review.Reactions)
+	}
+	for _, comment := range data.IssueComments {
+		count(comment.Repository, comment.Author.Login, comment.Reactions)
+	}
+}
+
+// isBotLogin reports whether a login is filtered as a bot
+func (a *Aggregator) isBotLogin(login string) bool {
+	return !a.config.Options.IncludeBots && a.config.BotPattern(login) != ""
+}
@@ -0,0 +1,72 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_Kudos(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	merged := at.Add(2 * time.Hour)
+	kudos := func(logins ...string) []models.Reaction {
+		var reactions []models.Reaction
+		for _, login := range logins {
+			reactions = append(reactions, models.Reaction{Login: login, Content: models.ReactionHeart})
+		}
+		return reactions
+	}
+
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			{
+				Number: 1, Title: "Add caching", Author: models.Author{Login: "alice"}, Repository: "acme/api",
+				CreatedAt: at, State: models.PRStateMerged, MergedAt: &merged,
+				Reactions: kudos("bob", "alice", "dependabot[bot]"), // Own and bot reactions don't count
+			},
+			{
+				Number: 2, Title: "Fix typo", Author: models.Author{Login: "bob"}, Repository: "acme/api",
+				CreatedAt: at, State: models.PRStateMerged, MergedAt: &merged,
+			},
+		},
+		Reviews: []models.Review{
+			{
+				ID: 10, PullRequest: 2, Repository: "acme/api", Author: models.Author{Login: "alice"},
+				State: models.ReviewApproved, SubmittedAt: at.Add(time.Hour), Reactions: kudos("bob"),
+			},
+		},
+		IssueComments: []models.IssueComment{
+			{
+				ID: 20, Issue: 3, Repository: "acme/api", Author: models.Author{Login: "bob"},
+				CreatedAt: at, Reactions: kudos("alice", "outsider"),
+			},
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	got := make(map[string][2]int)
+	for _, c := range metrics.Contributors {
+		got[c.Login] = [2]int{c.KudosReceived, c.KudosGiven}
+	}
+	assert.Equal(t, [2]int{2, 1}, got["alice"], "received on the PR and review, given on the comment")
+	assert.Equal(t, [2]int{2, 2}, got["bob"], "received on the comment, including from an outsider")
+
+	require.Len(t, metrics.Repositories, 1)
+	for _, c := range metrics.Repositories[0].Contributors {
+		if c.Login == "alice" {
+			assert.Equal(t, 2, c.KudosReceived)
+			assert.Equal(t, 1, c.KudosGiven)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package aggregator
+
+import (
+	"github.com/lukaszraczylo/git-velocity/internal/linear"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// linearRefs tracks Linear issue references for a single contributor
+type linearRefs struct {
+	referenced map[string]bool // issue identifiers referenced anywhere
+	credited   map[string]bool // issue identifiers this contributor gets completion credit for
+	linkedPRs  int             // opened PRs referencing at least one issue
+}
+
+func newLinearRefs() *linearRefs {
+	return &linearRefs{
+		referenced: make(map[string]bool),
+		credited:   make(map[string]bool),
+	}
+}
+
+// applyLinearMetrics detects Linear issue identifiers in PR titles, branch names and
+// commit messages, and attributes reference counts, completion credit and PR linkage
+// rate to contributors. Completion credit goes to authors of merged PRs referencing
+// the issue; commit authors only get credit when no merged PR references it.
+func (a *Aggregator) applyLinearMetrics(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	resolveCommitLogin func(commit models.Commit) string,
+) {
+	cfg := a.config.Integrations.Linear
+	if !cfg.Enabled {
+		return
+	}
+
+	detector := linear.NewDetector(cfg.TeamKeys)
+
+	global := make(map[string]*linearRefs)
+	perRepo := make(map[string]map[string]*linearRefs)
+	refsFor := func(repo, login string) (*linearRefs, *linearRefs) {
+		if global[login] == nil {
+			global[login] = newLinearRefs()
+		}
+		if perRepo[repo] == nil {
+			perRepo[repo] = make(map[string]*linearRefs)
+		}
+		if perRepo[repo][login] == nil {
+			perRepo[repo][login] = newLinearRefs()
+		}
+		return global[login], perRepo[repo][login]
+	}
+
+	isCompleted := func(id string) bool {
+		issue, ok := data.LinearIssues[id]
+		return ok && issue.IsCompleted()
+	}
+
+	// Issues credited via merged PRs take precedence over commit-only references
+	creditedByPR := make(map[string]bool)
+
+	for _, pr := range data.PullRequests {
+		login := pr.Author.Login
+		if login == "" {
+			continue
+		}
+		ids := detector.Find(pr.Title, pr.HeadBranch)
+		if len(ids) == 0 {
+			continue
+		}
+
+		g, r := refsFor(pr.Repository, login)
+		g.linkedPRs++
+		r.linkedPRs++
+		for _, id := range ids {
+			g.referenced[id] = true
+			r.referenced[id] = true
+			if pr.IsMerged() && isCompleted(id) {
+				g.credited[id] = true
+				r.credited[id] = true
+				creditedByPR[id] = true
+			}
+		}
+	}
+
+	for _, commit := range data.Commits {
+		if isMergeCommit(commit.Message) {
+			continue
+		}
+		login := resolveCommitLogin(commit)
+		if login == "" {
+			continue
+		}
+		ids := detector.Find(commit.Message)
+		if len(ids) == 0 {
+			continue
+		}
+
+		g, r := refsFor(commit.Repository, login)
+		for _, id := range ids {
+			g.referenced[id] = true
+			r.referenced[id] = true
+			if !creditedByPR[id] && isCompleted(id) {
+				g.credited[id] = true
+				r.credited[id] = true
+			}
+		}
+	}
+
+	apply := func(cm *models.ContributorMetrics, refs *linearRefs) {
+		cm.LinearIssuesReferenced = len(refs.referenced)
+		cm.LinearIssuesCompleted = len(refs.credited)
+		if cm.PRsOpened > 0 {
+			cm.LinearLinkageRate = float64(refs.linkedPRs) / float64(cm.PRsOpened) * 100
+		}
+	}
+
+	for login, refs := range global {
+		if cm, ok := contributorMap[login]; ok {
+			apply(cm, refs)
+		}
+	}
+	for repo, logins := range perRepo {
+		for login, refs := range logins {
+			if rcm, ok := repoContributorMap[repo][login]; ok {
+				apply(rcm, refs)
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package aggregator
+
+import (
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyLintMetrics attributes lint debt reduction. Findings that disappeared
+// from a file between the start and end of the period are credited to the
+// author of the last commit in the period changing that file; files nobody
+// changed are left uncredited, as their findings went away through config or
+// linter changes.
+func (a *Aggregator) applyLintMetrics(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+	commitLogin func(models.Commit) string,
+) {
+	if len(data.LintReports) == 0 {
+		return
+	}
+
+	// Last commit author per repository and file
+	lastAuthor := make(map[string]map[string]models.Commit)
+	for _, commit := range data.Commits {
+		files := lastAuthor[commit.Repository]
+		if files == nil {
+			files = make(map[string]models.Commit)
+			lastAuthor[commit.Repository] = files
+		}
+		for _, file := range commit.FilesModified {
+			if prev, ok := files[file]; !ok || commit.Date.After(prev.Date) {
+				files[file] = commit
+			}
+		}
+	}
+
+	for _, report := range data.LintReports {
+		files := lastAuthor[report.Repository]
+
+		startTotal, endTotal := 0, 0
+		for _, n := range report.End {
+			endTotal += n
+		}
+		for path, n := range report.Start {
+			startTotal += n
+			fixed := n - report.End[path]
+			if fixed <= 0 {
+				continue
+			}
+			commit, ok := lookupFile(files, path)
+			if !ok {
+				continue
+			}
+			login := commitLogin(commit)
+			if cm, ok := contributorMap[login]; ok {
+				cm.TechDebtReduction += fixed
+			}
+			if rcm, ok := repoContributorMap[report.Repository][login]; ok {
+				rcm.TechDebtReduction += fixed
+			}
+		}
+
+		if rm, ok := repoMap[report.Repository]; ok {
+			rm.LintFindings = &endTotal
+			rm.LintDelta = endTotal - startTotal
+		}
+	}
+}
+
+// lookupFile finds the commit for a report path, which may carry a prefix
+// (such as an absolute checkout directory) in front of the repository path
+func lookupFile(files map[string]models.Commit, path string) (models.Commit, bool) {
+	if commit, ok := files[path]; ok {
+		return commit, true
+	}
+	// The longest matching repository path wins when several are suffixes
+	var match models.Commit
+	matched := ""
+	for file, commit := range files {
+		if len(file) > len(matched) && strings.HasSuffix(path, "/"+file) {
+			match, matched = commit, file
+		}
+	}
+	return match, matched != ""
+}
@@ -0,0 +1,72 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_LintMetrics(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	commit := func(sha, login string, hours int, files ...string) models.Commit {
+		return models.Commit{
+			SHA:           sha,
+			Author:        models.Author{Login: login, Email: login + "@example.com"},
+			Date:          at.Add(time.Duration(hours) * time.Hour),
+			Repository:    "owner/repo",
+			FilesModified: files,
+		}
+	}
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			commit("a1", "alice", 1, "api.go", "db.go"),
+			commit("b1", "bob", 2, "db.go"), // last to change db.go
+			commit("c1", "carol", 3, "web/app.js"),
+		},
+		LintReports: []models.LintReport{{
+			Repository: "owner/repo",
+			Start: map[string]int{
+				"api.go":             3,
+				"db.go":              5,
+				"/ci/src/web/app.js": 2, // absolute ESLint path
+				"untouched.go":       4, // fixed without a commit in the period
+				"new.go":             0,
+			},
+			End: map[string]int{
+				"db.go":  1,
+				"new.go": 2,
+			},
+		}},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, cm := range metrics.Contributors {
+		byLogin[cm.Login] = cm
+	}
+	assert.Equal(t, 3, byLogin["alice"].TechDebtReduction)
+	assert.Equal(t, 4, byLogin["bob"].TechDebtReduction)
+	assert.Equal(t, 2, byLogin["carol"].TechDebtReduction)
+
+	require.Len(t, metrics.Repositories, 1)
+	repo := metrics.Repositories[0]
+	require.NotNil(t, repo.LintFindings)
+	assert.Equal(t, 3, *repo.LintFindings)
+	assert.Equal(t, -11, repo.LintDelta)
+
+	for _, rcm := range repo.Contributors {
+		assert.Equal(t, byLogin[rcm.Login].TechDebtReduction, rcm.TechDebtReduction)
+	}
+}
@@ -0,0 +1,112 @@
+package aggregator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// orgMembers returns the lowercase logins of the members of the analyzed
+// organizations, or nil when the cross-check is disabled or no member list
+// was fetched
+func (a *Aggregator) orgMembers(data *models.RawData) map[string]bool {
+	if !a.config.Options.OrgMembers.Enabled || len(data.OrgMembers) == 0 {
+		return nil
+	}
+	members := make(map[string]bool)
+	for _, logins := range data.OrgMembers {
+		for _, login := range logins {
+			members[strings.ToLower(login)] = true
+		}
+	}
+	return members
+}
+
+// isExternal reports whether login belongs to none of the organizations.
+// Service account buckets are never external.
+func isExternal(members map[string]bool, login string) bool {
+	return members != nil && login != "" && !members[strings.ToLower(login)] && !isServiceAccountLogin(login)
+}
+
+// withoutExternal returns data without the activity of contributors outside
+// the organizations when options.org_members.external is exclude
+func (a *Aggregator) withoutExternal(data *models.RawData, members map[string]bool, commitLogin func(models.Commit) string) *models.RawData {
+	if members == nil || a.config.Options.OrgMembers.External != config.ExternalExclude {
+		return data
+	}
+
+	filtered := *data
+	filtered.Commits = nil
+	for _, commit := range data.Commits {
+		if !isExternal(members, commitLogin(commit)) {
+			filtered.Commits = append(filtered.Commits, commit)
+		}
+	}
+	filtered.PullRequests = nil
+	for _, pr := range data.PullRequests {
+		if !isExternal(members, pr.Author.Login) {
+			filtered.PullRequests = append(filtered.PullRequests, pr)
+		}
+	}
+	filtered.Reviews = nil
+	for _, review := range data.Reviews {
+		if !isExternal(members, review.Author.Login) {
+			filtered.Reviews = append(filtered.Reviews, review)
+		}
+	}
+	filtered.Issues = nil
+	for _, issue := range data.Issues {
+		if !isExternal(members, issue.Author.Login) {
+			filtered.Issues = append(filtered.Issues, issue)
+		}
+	}
+	filtered.IssueComments = nil
+	for _, comment := range data.IssueComments {
+		if !isExternal(members, comment.Author.Login) {
+			filtered.IssueComments = append(filtered.IssueComments, comment)
+		}
+	}
+	filtered.ReviewComments = nil
+	for _, comment := range data.ReviewComments {
+		if !isExternal(members, comment.Author.Login) {
+			filtered.ReviewComments = append(filtered.ReviewComments, comment)
+		}
+	}
+	return &filtered
+}
+
+// markExternal flags the contributors outside the organizations, globally
+// and per repository
+func markExternal(members map[string]bool, contributorMap map[string]*models.ContributorMetrics, repoContributorMap map[string]map[string]*models.ContributorMetrics) {
+	for login, cm := range contributorMap {
+		cm.External = isExternal(members, login)
+	}
+	for _, repoContribs := range repoContributorMap {
+		for login, rcm := range repoContribs {
+			rcm.External = isExternal(members, login)
+		}
+	}
+}
+
+// teamConfigs returns the configured teams, followed with community by a
+// team of the external contributors who are in none of them
+func (a *Aggregator) teamConfigs(contributorMap map[string]*models.ContributorMetrics) []config.TeamConfig {
+	opts := a.config.Options.OrgMembers
+	if !opts.Enabled || opts.External != config.ExternalCommunity {
+		return a.config.Teams
+	}
+
+	var community []string
+	for login, cm := range contributorMap {
+		if cm.External && a.config.GetTeamForUser(login) == nil {
+			community = append(community, login)
+		}
+	}
+	if len(community) == 0 {
+		return a.config.Teams
+	}
+	slices.Sort(community)
+	return append(slices.Clone(a.config.Teams), config.TeamConfig{Name: opts.Team, Members: community})
+}
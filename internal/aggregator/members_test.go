@@ -0,0 +1,93 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_OrgMembers(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: at, Repository: "acme/repo"},
+			// Resolved to carol through her pull request
+			{SHA: "b", Author: models.Author{Login: "Carol Smith", Email: "carol@example.com"}, Date: at, Repository: "acme/repo"},
+		},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "carol", Email: "carol@example.com"}, CreatedAt: at, Repository: "acme/repo"},
+		},
+		Reviews: []models.Review{
+			{Author: models.Author{Login: "alice"}, SubmittedAt: at, Repository: "acme/repo", PullRequest: 1},
+		},
+		Issues: []models.Issue{
+			{Number: 2, Author: models.Author{Login: "dave"}, CreatedAt: at, Repository: "acme/repo"},
+		},
+		OrgMembers: map[string][]string{"acme": {"Alice", "bob"}},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+	dateRange := &config.ParsedDateRange{Start: &start, End: &end}
+
+	aggregate := func(t *testing.T, enabled bool, external string) *models.GlobalMetrics {
+		t.Helper()
+		cfg := config.DefaultConfig()
+		cfg.Teams = []config.TeamConfig{{Name: "Core", Members: []string{"alice", "dave"}}}
+		cfg.Options.OrgMembers.Enabled = enabled
+		cfg.Options.OrgMembers.External = external
+		metrics, err := New(cfg).Aggregate(data, dateRange)
+		require.NoError(t, err)
+		return metrics
+	}
+	external := func(metrics *models.GlobalMetrics) map[string]bool {
+		flags := make(map[string]bool)
+		for _, c := range metrics.Contributors {
+			flags[c.Login] = c.External
+		}
+		return flags
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		metrics := aggregate(t, false, config.ExternalFlag)
+		assert.Equal(t, map[string]bool{"alice": false, "carol": false, "dave": false}, external(metrics))
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		t.Parallel()
+		metrics := aggregate(t, true, config.ExternalFlag)
+		assert.Equal(t, map[string]bool{"alice": false, "carol": true, "dave": true}, external(metrics))
+		require.Len(t, metrics.Repositories, 1)
+		for _, c := range metrics.Repositories[0].Contributors {
+			assert.Equal(t, c.Login != "alice", c.External, c.Login)
+		}
+		assert.Len(t, metrics.Teams, 1)
+	})
+
+	t.Run("exclude", func(t *testing.T) {
+		t.Parallel()
+		metrics := aggregate(t, true, config.ExternalExclude)
+		assert.Equal(t, map[string]bool{"alice": false}, external(metrics))
+		assert.Equal(t, 1, metrics.TotalCommits)
+		assert.Zero(t, metrics.TotalPRs)
+		assert.Equal(t, 1, metrics.TotalReviews)
+	})
+
+	t.Run("community", func(t *testing.T) {
+		t.Parallel()
+		metrics := aggregate(t, true, config.ExternalCommunity)
+		require.Len(t, metrics.Teams, 2)
+		community := metrics.Teams[1]
+		assert.Equal(t, "Community", community.Name)
+		assert.Equal(t, []string{"carol"}, community.Members, "configured team members stay in their team")
+		assert.Equal(t, 1, community.AggregatedMetrics.CommitCount)
+		assert.Equal(t, 1, community.AggregatedMetrics.PRsOpened)
+	})
+}
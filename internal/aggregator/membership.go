@@ -0,0 +1,43 @@
+package aggregator
+
+import (
+	"strings"
+	"time"
+)
+
+// membership is the window a joiner or leaver was a member in; either end
+// is nil when open
+type membership struct {
+	start, end *time.Time
+}
+
+// memberships returns the membership windows of the contributors configured
+// with a start or end date, by lowercased login
+func (a *Aggregator) memberships() map[string]membership {
+	windows := make(map[string]membership)
+	for i := range a.config.Contributors {
+		cc := &a.config.Contributors[i]
+		start, end, err := cc.Membership()
+		if err != nil || (start == nil && end == nil) {
+			continue // Invalid dates are rejected by config validation
+		}
+		windows[strings.ToLower(cc.Login)] = membership{start: start, end: end}
+	}
+	return windows
+}
+
+// share returns the share of the activity at times within the window, nil
+// without any activity
+func (m membership) share(times []time.Time) *float64 {
+	if len(times) == 0 {
+		return nil
+	}
+	inside := 0
+	for _, t := range times {
+		if (m.start == nil || !t.Before(*m.start)) && (m.end == nil || !t.After(*m.end)) {
+			inside++
+		}
+	}
+	share := float64(inside) / float64(len(times))
+	return &share
+}
@@ -0,0 +1,45 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_MembershipShare(t *testing.T) {
+	t.Parallel()
+
+	commit := func(login string, at time.Time) models.Commit {
+		return models.Commit{SHA: login + at.Format("0102"), Author: models.Author{Login: login}, Repository: "acme/api", Date: at}
+	}
+	data := &models.RawData{
+		Commits: []models.Commit{
+			commit("joiner", time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)), // Before joining
+			commit("joiner", time.Date(2025, 1, 20, 12, 0, 0, 0, time.UTC)),
+			commit("joiner", time.Date(2025, 1, 25, 12, 0, 0, 0, time.UTC)),
+			commit("joiner", time.Date(2025, 1, 28, 12, 0, 0, 0, time.UTC)),
+			commit("fulltime", time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)),
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Contributors = []config.ContributorConfig{{Login: "Joiner", Start: "2025-01-15"}}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, c := range metrics.Contributors {
+		byLogin[c.Login] = c
+	}
+	require.NotNil(t, byLogin["joiner"].MembershipShare)
+	assert.InDelta(t, 0.75, *byLogin["joiner"].MembershipShare, 0.001, "one commit before joining")
+	require.Contains(t, byLogin, "fulltime")
+	assert.Nil(t, byLogin["fulltime"].MembershipShare, "only joiners and leavers")
+}
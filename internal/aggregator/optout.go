@@ -0,0 +1,36 @@
+package aggregator
+
+import (
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// markOptedOut flags the contributors who opted out of the leaderboards, in
+// the configuration or with the opt-out file in their profile repository.
+// Their metrics are kept so team and repository totals still count them.
+func (a *Aggregator) markOptedOut(data *models.RawData, contributorMap map[string]*models.ContributorMetrics, repoContributorMap map[string]map[string]*models.ContributorMetrics) {
+	optedOut := make(map[string]bool)
+	for _, c := range a.config.Contributors {
+		if c.OptOut {
+			optedOut[strings.ToLower(c.Login)] = true
+		}
+	}
+	if a.config.Options.ProfileOptOut {
+		for _, login := range data.OptOuts {
+			optedOut[strings.ToLower(login)] = true
+		}
+	}
+	if len(optedOut) == 0 {
+		return
+	}
+
+	for login, cm := range contributorMap {
+		cm.OptedOut = optedOut[strings.ToLower(login)]
+	}
+	for _, contribs := range repoContributorMap {
+		for login, rcm := range contribs {
+			rcm.OptedOut = optedOut[strings.ToLower(login)]
+		}
+	}
+}
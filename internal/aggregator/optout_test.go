@@ -0,0 +1,50 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_OptedOut(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	commit := func(sha, login string) models.Commit {
+		return models.Commit{SHA: sha, Author: models.Author{Login: login}, Date: at, Repository: "acme/repo"}
+	}
+	data := &models.RawData{
+		Commits: []models.Commit{commit("a", "alice"), commit("b", "bob"), commit("c", "carol")},
+		OptOuts: []string{"Carol"},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 1)
+
+	optedOut := func(cfg *config.Config) map[string]bool {
+		metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+		require.NoError(t, err)
+		assert.Equal(t, 3, metrics.TotalContributors, "everyone still counts")
+		assert.Equal(t, 3, metrics.TotalCommits)
+		flags := make(map[string]bool)
+		for _, c := range metrics.Contributors {
+			flags[c.Login] = c.OptedOut
+		}
+		for _, c := range metrics.Repositories[0].Contributors {
+			assert.Equal(t, flags[c.Login], c.OptedOut, c.Login)
+		}
+		return flags
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Contributors = []config.ContributorConfig{{Login: "bob", OptOut: true}}
+	assert.Equal(t, map[string]bool{"alice": false, "bob": true, "carol": false}, optedOut(cfg),
+		"profile opt-outs are ignored unless enabled")
+
+	cfg.Options.ProfileOptOut = true
+	assert.Equal(t, map[string]bool{"alice": false, "bob": true, "carol": true}, optedOut(cfg))
+}
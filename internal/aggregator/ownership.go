@@ -0,0 +1,137 @@
+package aggregator
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/pathfilter"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// ownershipRule is a path_ownership entry with its paths compiled
+type ownershipRule struct {
+	config.PathOwnership
+	filter *pathfilter.Filter
+}
+
+// owns reports whether the rule covers a file of a repository
+func (r *ownershipRule) owns(repository, file string) bool {
+	return r.AppliesTo(repository) && r.filter.Match(file)
+}
+
+// applyPathOwnership attributes the commits, pull requests and reviews
+// touching the paths a team owns to that team, whoever made them. Commit
+// lines count only within the owned files; PRs need their changed files,
+// which are collected for repositories covered by path_ownership.
+func (a *Aggregator) applyPathOwnership(data *models.RawData, teams []models.TeamMetrics, commitLogin func(models.Commit) string) {
+	for i := range teams {
+		team := &teams[i]
+		var rules []ownershipRule
+		var paths []string
+		for _, rule := range a.config.PathOwnershipFor(team.Name) {
+			// Patterns are checked when the config is validated
+			filter, err := pathfilter.New(rule.Paths)
+			if err != nil || filter == nil {
+				continue
+			}
+			rules = append(rules, ownershipRule{PathOwnership: rule, filter: filter})
+			for _, p := range rule.Paths {
+				if !slices.Contains(paths, p) {
+					paths = append(paths, p)
+				}
+			}
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		owned := func(repository, file string) bool {
+			for i := range rules {
+				if rules[i].owns(repository, file) {
+					return true
+				}
+			}
+			return false
+		}
+
+		members := make(map[string]bool, len(team.Members))
+		for _, m := range team.Members {
+			members[strings.ToLower(m)] = true
+		}
+
+		m := &models.PathOwnershipMetrics{Paths: paths, Contributors: []models.PathContributor{}}
+		commits := make(map[string]int)
+		for _, commit := range data.Commits {
+			touched := false
+			if len(commit.FileStats) > 0 {
+				for _, fs := range commit.FileStats {
+					if owned(commit.Repository, fs.Path) {
+						touched = true
+						m.LinesAdded += fs.Additions
+						m.LinesDeleted += fs.Deletions
+					}
+				}
+			} else {
+				// Snapshots without line counts per file only count the commit
+				for _, f := range commit.FilesModified {
+					if owned(commit.Repository, f) {
+						touched = true
+						break
+					}
+				}
+			}
+			if !touched {
+				continue
+			}
+			m.Commits++
+			if login := commitLogin(commit); login != "" {
+				commits[login]++
+				if members[strings.ToLower(login)] {
+					m.MemberCommits++
+				}
+			}
+		}
+		for login, n := range commits {
+			m.Contributors = append(m.Contributors, models.PathContributor{Login: login, Commits: n, Member: members[strings.ToLower(login)]})
+		}
+		sort.Slice(m.Contributors, func(i, j int) bool {
+			if m.Contributors[i].Commits != m.Contributors[j].Commits {
+				return m.Contributors[i].Commits > m.Contributors[j].Commits
+			}
+			return m.Contributors[i].Login < m.Contributors[j].Login
+		})
+
+		ownedPRs := make(map[string]bool) // repository#number
+		for _, pr := range data.PullRequests {
+			touched := false
+			for _, f := range pr.FilesModified {
+				if owned(pr.Repository, f) {
+					touched = true
+					break
+				}
+			}
+			if !touched {
+				continue
+			}
+			ownedPRs[prKey(pr.Repository, pr.Number)] = true
+			m.PRsOpened++
+			if pr.IsMerged() {
+				m.PRsMerged++
+			}
+		}
+		for _, review := range data.Reviews {
+			if ownedPRs[prKey(review.Repository, review.PullRequest)] {
+				m.Reviews++
+			}
+		}
+
+		team.Ownership = m
+	}
+}
+
+// prKey identifies a pull request across repositories
+func prKey(repository string, number int) string {
+	return repository + "#" + strconv.Itoa(number)
+}
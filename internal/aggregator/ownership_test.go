@@ -0,0 +1,85 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_PathOwnership(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Teams = []config.TeamConfig{
+		{Name: "Payments", Members: []string{"alice"}},
+		{Name: "Platform"}, // Defined by its paths alone
+		{Name: "Mobile", Members: []string{"carol"}},
+	}
+	cfg.PathOwnership = []config.PathOwnership{
+		{Team: "Payments", Paths: []string{"services/payments"}, Repos: []string{"acme/monorepo"}},
+		{Team: "Platform", Paths: []string{"infra/**", "**/Dockerfile"}},
+	}
+	agg := New(cfg)
+
+	merged := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a1", Author: models.Author{Login: "alice"}, Repository: "acme/monorepo", FileStats: []models.FileStat{
+				{Path: "services/payments/api.go", Additions: 10, Deletions: 2},
+				{Path: "services/payments/Dockerfile", Additions: 3},
+				{Path: "docs/README.md", Additions: 50},
+			}},
+			{SHA: "b1", Author: models.Author{Login: "bob"}, Repository: "acme/monorepo", FileStats: []models.FileStat{
+				{Path: "services/payments/ledger.go", Additions: 5, Deletions: 5},
+			}},
+			{SHA: "b2", Author: models.Author{Login: "bob"}, Repository: "acme/website", FilesModified: []string{"infra/deploy.yaml"}, Additions: 7},
+			// Payments only owns its path in the monorepo
+			{SHA: "c1", Author: models.Author{Login: "carol"}, Repository: "acme/website", FileStats: []models.FileStat{
+				{Path: "services/payments/page.vue", Additions: 8},
+			}},
+		},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Repository: "acme/monorepo", Author: models.Author{Login: "bob"}, State: "closed", MergedAt: &merged, FilesModified: []string{"services/payments/ledger.go"}},
+			{Number: 2, Repository: "acme/monorepo", Author: models.Author{Login: "carol"}, State: "open", FilesModified: []string{"services/payments/api.go", "infra/main.tf"}},
+			{Number: 3, Repository: "acme/monorepo", Author: models.Author{Login: "carol"}, State: "open"},
+		},
+		Reviews: []models.Review{
+			{ID: 1, PullRequest: 1, Repository: "acme/monorepo", Author: models.Author{Login: "alice"}, State: models.ReviewApproved},
+			{ID: 2, PullRequest: 3, Repository: "acme/monorepo", Author: models.Author{Login: "alice"}, State: models.ReviewApproved},
+		},
+	}
+
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{})
+	require.NoError(t, err)
+	require.Len(t, metrics.Teams, 3)
+
+	payments := metrics.Teams[0].Ownership
+	require.NotNil(t, payments)
+	assert.Equal(t, []string{"services/payments"}, payments.Paths)
+	assert.Equal(t, 2, payments.Commits)
+	assert.Equal(t, 1, payments.MemberCommits)
+	assert.Equal(t, 18, payments.LinesAdded, "only lines in owned files count")
+	assert.Equal(t, 7, payments.LinesDeleted)
+	assert.Equal(t, 2, payments.PRsOpened)
+	assert.Equal(t, 1, payments.PRsMerged)
+	assert.Equal(t, 1, payments.Reviews)
+	assert.Equal(t, []models.PathContributor{
+		{Login: "alice", Commits: 1, Member: true},
+		{Login: "bob", Commits: 1},
+	}, payments.Contributors)
+
+	platform := metrics.Teams[1].Ownership
+	require.NotNil(t, platform)
+	assert.Equal(t, []string{"infra/**", "**/Dockerfile"}, platform.Paths)
+	assert.Equal(t, 2, platform.Commits, "a commit touching the paths counts once")
+	assert.Equal(t, 3, platform.LinesAdded, "commits without per-file lines add no lines")
+	assert.Zero(t, platform.MemberCommits)
+	assert.Equal(t, 1, platform.PRsOpened)
+
+	assert.Nil(t, metrics.Teams[2].Ownership, "teams without paths have no ownership")
+}
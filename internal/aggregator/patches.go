@@ -0,0 +1,70 @@
+package aggregator
+
+import (
+	"sort"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// patchCopies finds patches a contributor applied to more than one
+// repository, such as a fix copied across services. It returns the number of
+// repositories each such patch reached per login and, unless
+// scoring.count_duplicate_patches is set, the copies to leave out of the
+// contributor's totals keyed by repository@sha: every copy but the earliest.
+func (a *Aggregator) patchCopies(data *models.RawData, commitLogin func(models.Commit) string) (map[string][]int, map[string]bool) {
+	type copyKey struct{ login, patchID string }
+	copies := make(map[copyKey][]models.Commit)
+	for _, commit := range data.Commits {
+		if commit.PatchID == "" {
+			continue
+		}
+		key := copyKey{commitLogin(commit), commit.PatchID}
+		copies[key] = append(copies[key], commit)
+	}
+
+	spread := make(map[string][]int)
+	duplicates := make(map[string]bool)
+	for key, commits := range copies {
+		repos := make(map[string]bool)
+		for _, c := range commits {
+			repos[c.Repository] = true
+		}
+		// Cherry-picks within a repository aren't propagation
+		if len(repos) < 2 {
+			continue
+		}
+		spread[key.login] = append(spread[key.login], len(repos))
+
+		if a.config.Scoring.CountDuplicatePatches {
+			continue
+		}
+		sort.Slice(commits, func(i, j int) bool {
+			if !commits[i].Date.Equal(commits[j].Date) {
+				return commits[i].Date.Before(commits[j].Date)
+			}
+			return commits[i].Repository < commits[j].Repository
+		})
+		original := commits[0].Repository
+		for _, c := range commits[1:] {
+			if c.Repository != original {
+				duplicates[c.Repository+"@"+c.SHA] = true
+			}
+		}
+	}
+	return spread, duplicates
+}
+
+// applyPatchPropagation credits contributors for the patches they rolled out
+// to several repositories
+func applyPatchPropagation(spread map[string][]int, contributorMap map[string]*models.ContributorMetrics) {
+	for login, repos := range spread {
+		cm, ok := contributorMap[login]
+		if !ok {
+			continue
+		}
+		for _, n := range repos {
+			cm.PropagatedPatches++
+			cm.PatchPropagation += n - 1
+		}
+	}
+}
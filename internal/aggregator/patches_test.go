@@ -0,0 +1,74 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_PatchPropagation(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	commit := func(sha, login, repo, patchID string, hours int) models.Commit {
+		return models.Commit{
+			SHA:        sha,
+			Author:     models.Author{Login: login, Email: login + "@example.com"},
+			Date:       at.Add(time.Duration(hours) * time.Hour),
+			Repository: repo,
+			Additions:  10,
+			PatchID:    patchID,
+		}
+	}
+	data := &models.RawData{
+		Commits: []models.Commit{
+			commit("a3", "alice", "owner/three", "fix", 3),
+			commit("a1", "alice", "owner/one", "fix", 1), // the original
+			commit("a2", "alice", "owner/two", "fix", 2),
+			commit("a4", "alice", "owner/one", "feature", 4),
+			commit("b1", "bob", "owner/two", "fix", 5),      // someone else's copy is their own work
+			commit("b2", "bob", "owner/two", "backport", 6), // cherry-picked within a repository
+			commit("b3", "bob", "owner/two", "backport", 7),
+		},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	aggregate := func(cfg *config.Config) map[string]models.ContributorMetrics {
+		metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+		require.NoError(t, err)
+
+		for _, repo := range metrics.Repositories {
+			for _, rcm := range repo.Contributors {
+				if rcm.Login == "alice" {
+					assert.Equal(t, 10, rcm.LinesAdded/rcm.CommitCount, "every copy counts in its repository")
+				}
+			}
+		}
+		byLogin := make(map[string]models.ContributorMetrics)
+		for _, cm := range metrics.Contributors {
+			byLogin[cm.Login] = cm
+		}
+		return byLogin
+	}
+
+	byLogin := aggregate(config.DefaultConfig())
+	assert.Equal(t, 2, byLogin["alice"].CommitCount)
+	assert.Equal(t, 20, byLogin["alice"].LinesAdded)
+	assert.Equal(t, 1, byLogin["alice"].PropagatedPatches)
+	assert.Equal(t, 2, byLogin["alice"].PatchPropagation)
+	assert.Equal(t, 3, byLogin["bob"].CommitCount)
+	assert.Zero(t, byLogin["bob"].PropagatedPatches)
+	assert.ElementsMatch(t, []string{"owner/one", "owner/two", "owner/three"}, byLogin["alice"].RepositoriesContributed)
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.CountDuplicatePatches = true
+	byLogin = aggregate(cfg)
+	assert.Equal(t, 4, byLogin["alice"].CommitCount)
+	assert.Equal(t, 2, byLogin["alice"].PatchPropagation)
+}
@@ -0,0 +1,95 @@
+package aggregator
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyPeriodActivity aggregates the activity dated within the period again
+// and attaches it to the contributors of the run, globally and per
+// repository, for achievements to be earned from. Collected PRs and issues
+// may have been opened long before the period and only updated in it.
+func (a *Aggregator) applyPeriodActivity(data *models.RawData, dateRange *config.ParsedDateRange, repositories []models.RepositoryMetrics, contributors []models.ContributorMetrics) error {
+	cfg := *a.config
+	cfg.Scoring.AchievementScope = config.AchievementScopeCollected
+	inner := New(&cfg)
+	inner.SetUserProfiles(a.userProfiles)
+	scoped, err := inner.Aggregate(withinPeriod(data, dateRange), dateRange)
+	if err != nil {
+		return err
+	}
+
+	global := make(map[string]*models.ContributorMetrics, len(scoped.Contributors))
+	for i := range scoped.Contributors {
+		global[scoped.Contributors[i].Login] = &scoped.Contributors[i]
+	}
+	perRepo := make(map[string]*models.ContributorMetrics)
+	for i := range scoped.Repositories {
+		for j := range scoped.Repositories[i].Contributors {
+			c := &scoped.Repositories[i].Contributors[j]
+			perRepo[scoped.Repositories[i].FullName+"@"+c.Login] = c
+		}
+	}
+
+	// Contributors without activity in the period earn nothing
+	activity := func(found *models.ContributorMetrics, login string) *models.ContributorMetrics {
+		if found == nil {
+			return &models.ContributorMetrics{Login: login}
+		}
+		return found
+	}
+	for i := range contributors {
+		contributors[i].PeriodActivity = activity(global[contributors[i].Login], contributors[i].Login)
+	}
+	for i := range repositories {
+		for j := range repositories[i].Contributors {
+			c := &repositories[i].Contributors[j]
+			c.PeriodActivity = activity(perRepo[repositories[i].FullName+"@"+c.Login], c.Login)
+		}
+	}
+	return nil
+}
+
+// withinPeriod keeps the commits, PRs, reviews, issues and issue comments
+// dated within the date range: commits by author date, the rest by when
+// they were opened or submitted
+func withinPeriod(data *models.RawData, dateRange *config.ParsedDateRange) *models.RawData {
+	within := func(t time.Time) bool {
+		return (dateRange.Start == nil || !t.Before(*dateRange.Start)) && (dateRange.End == nil || !t.After(*dateRange.End))
+	}
+
+	filtered := *data
+	filtered.Commits = nil
+	for _, c := range data.Commits {
+		if within(c.Date) {
+			filtered.Commits = append(filtered.Commits, c)
+		}
+	}
+	filtered.PullRequests = nil
+	for _, pr := range data.PullRequests {
+		if within(pr.CreatedAt) {
+			filtered.PullRequests = append(filtered.PullRequests, pr)
+		}
+	}
+	filtered.Reviews = nil
+	for _, r := range data.Reviews {
+		if within(r.SubmittedAt) {
+			filtered.Reviews = append(filtered.Reviews, r)
+		}
+	}
+	filtered.Issues = nil
+	for _, issue := range data.Issues {
+		if within(issue.CreatedAt) {
+			filtered.Issues = append(filtered.Issues, issue)
+		}
+	}
+	filtered.IssueComments = nil
+	for _, c := range data.IssueComments {
+		if within(c.CreatedAt) {
+			filtered.IssueComments = append(filtered.IssueComments, c)
+		}
+	}
+	return &filtered
+}
@@ -0,0 +1,87 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_PeriodActivity(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 14, 23, 59, 59, 0, time.UTC)
+	before := start.AddDate(0, -2, 0)
+	during := start.AddDate(0, 0, 3)
+	merged := during.Add(time.Hour)
+	alice := models.Author{Login: "alice"}
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: alice, Date: during, Repository: "acme/repo"},
+		},
+		PullRequests: []models.PullRequest{
+			// Opened before the sprint, merged in it
+			{Number: 1, Author: alice, Repository: "acme/repo", CreatedAt: before, State: models.PRStateMerged, MergedAt: &merged},
+			{Number: 2, Author: alice, Repository: "acme/repo", CreatedAt: during, State: models.PRStateOpen},
+		},
+		Issues: []models.Issue{
+			{Number: 3, Author: alice, Repository: "acme/repo", CreatedAt: before, State: models.IssueStateOpen},
+		},
+	}
+	dateRange := &config.ParsedDateRange{Start: &start, End: &end}
+
+	// By default achievements see everything collected
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, dateRange)
+	require.NoError(t, err)
+	require.Len(t, metrics.Contributors, 1)
+	assert.Nil(t, metrics.Contributors[0].PeriodActivity)
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.AchievementScope = config.AchievementScopePeriod
+	metrics, err = New(cfg).Aggregate(data, dateRange)
+	require.NoError(t, err)
+
+	require.Len(t, metrics.Contributors, 1)
+	c := metrics.Contributors[0]
+	assert.Equal(t, 2, c.PRsOpened, "the dashboard still shows everything collected")
+	require.NotNil(t, c.PeriodActivity)
+	assert.Equal(t, 1, c.PeriodActivity.PRsOpened)
+	assert.Equal(t, 0, c.PeriodActivity.PRsMerged)
+	assert.Equal(t, 0, c.PeriodActivity.IssuesOpened)
+	assert.Equal(t, 1, c.PeriodActivity.CommitCount)
+
+	require.Len(t, metrics.Repositories, 1)
+	require.Len(t, metrics.Repositories[0].Contributors, 1)
+	require.NotNil(t, metrics.Repositories[0].Contributors[0].PeriodActivity)
+	assert.Equal(t, 1, metrics.Repositories[0].Contributors[0].PeriodActivity.PRsOpened)
+}
+
+func TestAggregator_PeriodActivityNone(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 14, 23, 59, 59, 0, time.UTC)
+	before := start.AddDate(0, -1, 0)
+
+	data := &models.RawData{
+		Issues: []models.Issue{
+			{Number: 1, Author: models.Author{Login: "bob"}, Repository: "acme/repo", CreatedAt: before, State: models.IssueStateOpen},
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Scoring.AchievementScope = config.AchievementScopePeriod
+	metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	for _, c := range metrics.Contributors {
+		require.NotNil(t, c.PeriodActivity, c.Login)
+		assert.Equal(t, c.Login, c.PeriodActivity.Login)
+		assert.Zero(t, c.PeriodActivity.IssuesOpened)
+	}
+}
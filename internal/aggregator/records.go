@@ -0,0 +1,74 @@
+package aggregator
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// recordMinReviews is the number of timed reviews a month needs for its
+// average response time to count, so a single quick review sets no record
+const recordMinReviews = 5
+
+// personalBests finds a contributor's best calendar week by points and
+// fastest reviewing month in their daily activity
+func personalBests(days map[string]*dayTotals) *models.PersonalBests {
+	weeks := make(map[time.Time]*models.BestWeek)
+	type monthTotals struct {
+		hours   float64
+		reviews int
+	}
+	months := make(map[string]*monthTotals)
+
+	for day, totals := range days {
+		date, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		// Weeks run Monday to Sunday
+		monday := date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+		week := weeks[monday]
+		if week == nil {
+			week = &models.BestWeek{Start: monday}
+			weeks[monday] = week
+		}
+		week.Score += totals.score
+		week.Commits += int(totals.commits)
+		week.PRs += int(totals.prs)
+		week.Reviews += totals.reviews
+
+		if totals.timedReviews > 0 {
+			month := date.Format("2006-01")
+			if months[month] == nil {
+				months[month] = &monthTotals{}
+			}
+			months[month].hours += totals.reviewHours
+			months[month].reviews += totals.timedReviews
+		}
+	}
+
+	bests := &models.PersonalBests{}
+	for _, week := range weeks {
+		// Ties go to the earlier week
+		if b := bests.BestWeek; b == nil || week.Score > b.Score || (week.Score == b.Score && week.Start.Before(b.Start)) {
+			bests.BestWeek = week
+		}
+	}
+	if bests.BestWeek != nil {
+		bests.BestWeek.Score = round2(bests.BestWeek.Score)
+	}
+	for month, totals := range months {
+		if totals.reviews < recordMinReviews {
+			continue
+		}
+		avg := round2(totals.hours / float64(totals.reviews))
+		if b := bests.FastestReviewMonth; b == nil || avg < b.AvgReviewTime || (avg == b.AvgReviewTime && month < b.Month) {
+			bests.FastestReviewMonth = &models.ReviewMonth{Month: month, AvgReviewTime: avg, Reviews: totals.reviews}
+		}
+	}
+
+	if bests.BestWeek == nil && bests.FastestReviewMonth == nil {
+		return nil
+	}
+	return bests
+}
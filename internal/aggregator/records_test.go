@@ -0,0 +1,74 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestPersonalBests(t *testing.T) {
+	t.Parallel()
+
+	hours := func(h float64) *time.Duration {
+		d := time.Duration(h * float64(time.Hour))
+		return &d
+	}
+	review := func(number int, at time.Time, response float64) models.Review {
+		return models.Review{
+			PullRequest: number, Repository: "acme/repo", Author: models.Author{Login: "bob"},
+			State: models.ReviewApproved, SubmittedAt: at, ResponseTime: hours(response),
+		}
+	}
+
+	// Three commits in the week of Monday 4 March, one the week after
+	commit := func(sha string, at time.Time) models.Commit {
+		return models.Commit{SHA: sha, Author: models.Author{Login: "alice"}, Date: at, Repository: "acme/repo"}
+	}
+	march4 := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			commit("a", march4),
+			commit("b", march4.AddDate(0, 0, 2)),
+			commit("c", march4.AddDate(0, 0, 6)), // Sunday
+			commit("d", march4.AddDate(0, 0, 7)),
+		},
+	}
+	// Five timed reviews in March, averaging 1.8h; four faster ones in April are too few
+	for i := range 5 {
+		data.Reviews = append(data.Reviews, review(i+1, march4.AddDate(0, 0, i), float64(1+i%3)))
+	}
+	april := time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		data.Reviews = append(data.Reviews, review(i+10, april.AddDate(0, 0, i), 0.5))
+	}
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	bests := make(map[string]*models.PersonalBests)
+	for _, c := range metrics.Contributors {
+		bests[c.Login] = c.Bests
+	}
+
+	require.NotNil(t, bests["alice"])
+	week := bests["alice"].BestWeek
+	require.NotNil(t, week)
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), week.Start)
+	assert.Equal(t, 3, week.Commits)
+	assert.InDelta(t, 30.0, week.Score, 0.001)
+	assert.Nil(t, bests["alice"].FastestReviewMonth, "no reviews")
+
+	require.NotNil(t, bests["bob"])
+	month := bests["bob"].FastestReviewMonth
+	require.NotNil(t, month)
+	assert.Equal(t, "2024-03", month.Month)
+	assert.Equal(t, 5, month.Reviews)
+	assert.InDelta(t, 1.8, month.AvgReviewTime, 0.001)
+}
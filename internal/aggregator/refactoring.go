@@ -0,0 +1,58 @@
+package aggregator
+
+import (
+	"github.com/lukaszraczylo/git-velocity/internal/diff"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+const (
+	// refactoringRatio is how many non-test lines a commit must delete per line
+	// it adds to count as refactoring
+	refactoringRatio = 2
+	// refactoringMinDeletions keeps small cleanups from counting as refactoring
+	refactoringMinDeletions = 20
+)
+
+// applyRefactoringMetrics credits refactoring commits: commits deleting at
+// least twice as many lines of non-test code as they add. Test and
+// documentation files are left out so that deleting obsolete tests or docs
+// doesn't count, and adding tests alongside a refactoring doesn't hide it.
+func (a *Aggregator) applyRefactoringMetrics(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	commitLogin func(models.Commit) string,
+) {
+	for _, commit := range data.Commits {
+		removed, ok := refactoringLines(commit)
+		if !ok {
+			continue
+		}
+		login := commitLogin(commit)
+		if cm, ok := contributorMap[login]; ok {
+			cm.RefactoringCommits++
+			cm.NetLinesRemoved += removed
+		}
+		if rcm, ok := repoContributorMap[commit.Repository][login]; ok {
+			rcm.RefactoringCommits++
+			rcm.NetLinesRemoved += removed
+		}
+	}
+}
+
+// refactoringLines returns the net non-test lines a commit removes, and
+// whether the commit counts as refactoring
+func refactoringLines(commit models.Commit) (int, bool) {
+	additions, deletions := 0, 0
+	for _, fs := range commit.FileStats {
+		if diff.IsTestFile(fs.Path) || diff.IsDocumentationFile(fs.Path) {
+			continue
+		}
+		additions += fs.Additions
+		deletions += fs.Deletions
+	}
+	if deletions < refactoringMinDeletions || deletions < refactoringRatio*additions {
+		return 0, false
+	}
+	return deletions - additions, true
+}
@@ -0,0 +1,67 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_RefactoringMetrics(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	commit := func(sha, login string, stats ...models.FileStat) models.Commit {
+		c := models.Commit{
+			SHA:        sha,
+			Author:     models.Author{Login: login, Email: login + "@example.com"},
+			Date:       at,
+			Repository: "owner/repo",
+			FileStats:  stats,
+		}
+		for _, fs := range stats {
+			c.FilesModified = append(c.FilesModified, fs.Path)
+			c.Additions += fs.Additions
+			c.Deletions += fs.Deletions
+		}
+		return c
+	}
+	stat := func(path string, additions, deletions int) models.FileStat {
+		return models.FileStat{Path: path, Additions: additions, Deletions: deletions}
+	}
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			commit("a1", "alice", stat("core.go", 10, 60)),                             // refactoring, 50 net
+			commit("a2", "alice", stat("core.go", 5, 40), stat("core_test.go", 80, 0)), // new tests don't hide it, 35 net
+			commit("a3", "alice", stat("core.go", 30, 50)),                             // below the ratio
+			commit("b1", "bob", stat("core_test.go", 0, 300)),                          // deleting tests only
+			commit("b2", "bob", stat("README.md", 0, 100), stat("util.go", 1, 10)),     // docs don't count, too small
+			commit("c1", "carol", stat("util.go", 0, 19)),                              // below the minimum
+		},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, cm := range metrics.Contributors {
+		byLogin[cm.Login] = cm
+	}
+	assert.Equal(t, 2, byLogin["alice"].RefactoringCommits)
+	assert.Equal(t, 85, byLogin["alice"].NetLinesRemoved)
+	assert.Zero(t, byLogin["bob"].RefactoringCommits)
+	assert.Zero(t, byLogin["carol"].RefactoringCommits)
+
+	require.Len(t, metrics.Repositories, 1)
+	for _, rcm := range metrics.Repositories[0].Contributors {
+		assert.Equal(t, byLogin[rcm.Login].RefactoringCommits, rcm.RefactoringCommits)
+		assert.Equal(t, byLogin[rcm.Login].NetLinesRemoved, rcm.NetLinesRemoved)
+	}
+}
@@ -0,0 +1,128 @@
+package aggregator
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// conventionalTitle matches conventional commit titles: type(scope)!: summary
+var conventionalTitle = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// changePrefixes maps conventional commit types to types of change
+var changePrefixes = map[string]string{
+	"feat":     models.ChangeFeature,
+	"feature":  models.ChangeFeature,
+	"fix":      models.ChangeFix,
+	"bugfix":   models.ChangeFix,
+	"hotfix":   models.ChangeFix,
+	"perf":     models.ChangePerformance,
+	"refactor": models.ChangeRefactor,
+	"docs":     models.ChangeDocs,
+	"doc":      models.ChangeDocs,
+	"test":     models.ChangeTests,
+	"tests":    models.ChangeTests,
+	"deps":     models.ChangeDependencies,
+	"chore":    models.ChangeMaintenance,
+	"build":    models.ChangeMaintenance,
+	"ci":       models.ChangeMaintenance,
+	"style":    models.ChangeMaintenance,
+	"revert":   models.ChangeMaintenance,
+}
+
+// changeLabels maps common PR labels to types of change
+var changeLabels = map[string]string{
+	"breaking":        models.ChangeBreaking,
+	"breaking-change": models.ChangeBreaking,
+	"breaking change": models.ChangeBreaking,
+	"feature":         models.ChangeFeature,
+	"enhancement":     models.ChangeFeature,
+	"bug":             models.ChangeFix,
+	"bugfix":          models.ChangeFix,
+	"fix":             models.ChangeFix,
+	"performance":     models.ChangePerformance,
+	"perf":            models.ChangePerformance,
+	"refactor":        models.ChangeRefactor,
+	"refactoring":     models.ChangeRefactor,
+	"documentation":   models.ChangeDocs,
+	"docs":            models.ChangeDocs,
+	"test":            models.ChangeTests,
+	"tests":           models.ChangeTests,
+	"testing":         models.ChangeTests,
+	"dependencies":    models.ChangeDependencies,
+	"deps":            models.ChangeDependencies,
+	"chore":           models.ChangeMaintenance,
+	"maintenance":     models.ChangeMaintenance,
+	"ci":              models.ChangeMaintenance,
+}
+
+// buildReleaseNotes lists the PRs merged in the period by type of change.
+// A conventional commit prefix in the title decides the type, or else the
+// PR's labels; breaking changes are listed apart whatever their type.
+func buildReleaseNotes(data *models.RawData, period models.Period) *models.ReleaseNotes {
+	entries := make(map[string][]models.ReleaseEntry)
+	for _, pr := range data.PullRequests {
+		if !pr.IsMerged() || pr.MergedAt == nil || pr.MergedAt.Before(period.Start) || pr.MergedAt.After(period.End) {
+			continue
+		}
+		change, scope, title := classifyChange(pr.Title, pr.Labels)
+		entries[change] = append(entries[change], models.ReleaseEntry{
+			Repository: pr.Repository,
+			Number:     pr.Number,
+			Title:      title,
+			Scope:      scope,
+			URL:        pr.URL,
+			Author:     pr.Author.Login,
+			MergedAt:   *pr.MergedAt,
+		})
+	}
+	return models.NewReleaseNotes(entries)
+}
+
+// classifyChange returns a PR's type of change, its conventional commit
+// scope, and its title without the prefix
+func classifyChange(title string, labels []string) (change, scope, summary string) {
+	summary = strings.TrimSpace(title)
+	breaking := false
+	if m := conventionalTitle.FindStringSubmatch(summary); m != nil {
+		if prefix, ok := changePrefixes[strings.ToLower(m[1])]; ok {
+			change, scope, summary = prefix, m[2], capitalize(m[4])
+			breaking = m[3] == "!"
+			// Dependency bumps are usually build(deps) or chore(deps)
+			if strings.EqualFold(scope, "deps") {
+				change = models.ChangeDependencies
+			}
+		}
+	}
+
+	for _, label := range labels {
+		labelChange, ok := changeLabels[strings.ToLower(strings.TrimSpace(label))]
+		switch {
+		case !ok:
+		case labelChange == models.ChangeBreaking:
+			breaking = true
+		case change == "":
+			change = labelChange
+		}
+	}
+
+	if breaking {
+		return models.ChangeBreaking, scope, summary
+	}
+	if change == "" {
+		change = models.ChangeOther
+	}
+	return change, scope, summary
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,96 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestClassifyChange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		title   string
+		labels  []string
+		change  string
+		scope   string
+		summary string
+	}{
+		{"feat(api): add rate limiting", nil, models.ChangeFeature, "api", "Add rate limiting"},
+		{"fix: retry on timeouts", []string{"enhancement"}, models.ChangeFix, "", "Retry on timeouts"},
+		{"refactor!: drop the v1 client", nil, models.ChangeBreaking, "", "Drop the v1 client"},
+		{"build(deps): bump golang.org/x/net", nil, models.ChangeDependencies, "deps", "Bump golang.org/x/net"},
+		{"Crash on empty config", []string{"Bug"}, models.ChangeFix, "", "Crash on empty config"},
+		{"New onboarding flow", []string{"enhancement", "breaking-change"}, models.ChangeBreaking, "", "New onboarding flow"},
+		{"WIP: something", nil, models.ChangeOther, "", "WIP: something"},
+	}
+	for _, tt := range tests {
+		change, scope, summary := classifyChange(tt.title, tt.labels)
+		assert.Equal(t, tt.change, change, tt.title)
+		assert.Equal(t, tt.scope, scope, tt.title)
+		assert.Equal(t, tt.summary, summary, tt.title)
+	}
+}
+
+func TestAggregator_ReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	hours := func(h int) *time.Time {
+		t := at.Add(time.Duration(h) * time.Hour)
+		return &t
+	}
+	pr := func(number int, author, title string, mergedAt *time.Time) models.PullRequest {
+		state := models.PRStateMerged
+		if mergedAt == nil {
+			state = models.PRStateOpen
+		}
+		return models.PullRequest{
+			Number: number, Title: title, Author: models.Author{Login: author}, Repository: "acme/repo",
+			CreatedAt: at, State: state, MergedAt: mergedAt,
+		}
+	}
+
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			pr(1, "bob", "feat: dark mode", hours(2)),
+			pr(2, "alice", "fix: crash on start", hours(3)),
+			pr(3, "alice", "feat: export to CSV", hours(1)),
+			pr(4, "alice", "feat: not merged yet", nil),
+			pr(5, "bob", "feat: merged after the period", hours(24*30)),
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	// Only built with the release_notes format
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	assert.Nil(t, metrics.ReleaseNotes)
+
+	cfg := config.DefaultConfig()
+	cfg.Output.Format = append(cfg.Output.Format, config.FormatReleaseNotes)
+	metrics, err = New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	notes := metrics.ReleaseNotes
+	require.NotNil(t, notes)
+
+	require.Len(t, notes.Sections, 2)
+	assert.Equal(t, models.ChangeFeature, notes.Sections[0].Type)
+	var numbers []int
+	for _, e := range notes.Sections[0].Entries {
+		numbers = append(numbers, e.Number)
+	}
+	assert.Equal(t, []int{3, 1}, numbers) // By author
+	assert.Equal(t, models.ChangeFix, notes.Sections[1].Type)
+
+	assert.Equal(t, []models.ReleaseAuthor{
+		{Login: "alice", PRs: 2, Types: map[string]int{models.ChangeFeature: 1, models.ChangeFix: 1}},
+		{Login: "bob", PRs: 1, Types: map[string]int{models.ChangeFeature: 1}},
+	}, notes.Authors)
+}
@@ -0,0 +1,122 @@
+package aggregator
+
+import (
+	"slices"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applyReviewLatency builds the review latency heatmaps of all reviews, and
+// sets those of the reviews each team's members gave on the teams
+func applyReviewLatency(data *models.RawData, teams []models.TeamMetrics) *models.ReviewLatency {
+	all := &latencyLog{}
+	teamLogs := make([]*latencyLog, len(teams))
+	memberTeams := make(map[string][]int) // login -> indexes of their teams
+	for i, team := range teams {
+		teamLogs[i] = &latencyLog{}
+		for _, member := range team.Members {
+			if !slices.Contains(memberTeams[member], i) {
+				memberTeams[member] = append(memberTeams[member], i)
+			}
+		}
+	}
+
+	for i := range data.Reviews {
+		review := &data.Reviews[i]
+		if review.Author.Login == "" || review.SubmittedAt.IsZero() {
+			continue
+		}
+		all.add(review)
+		for _, t := range memberTeams[review.Author.Login] {
+			teamLogs[t].add(review)
+		}
+	}
+
+	for i := range teams {
+		teams[i].ReviewLatency = teamLogs[i].latency()
+	}
+	return all.latency()
+}
+
+// latencyLog collects reviews by weekday and hour in UTC
+type latencyLog struct {
+	reviews   int
+	submitted [7][24]int
+	hours     [7][24][]float64 // Response times by when the review was requested
+}
+
+// add records when a review was submitted and, when it was timed, how long
+// it took by when it was requested
+func (l *latencyLog) add(review *models.Review) {
+	at := review.SubmittedAt.UTC()
+	l.reviews++
+	l.submitted[weekdayIndex(at)][at.Hour()]++
+	if review.ResponseTime == nil || *review.ResponseTime < 0 {
+		return
+	}
+	requested := at.Add(-*review.ResponseTime)
+	day, hour := weekdayIndex(requested), requested.Hour()
+	l.hours[day][hour] = append(l.hours[day][hour], review.ResponseTime.Hours())
+}
+
+// latency summarizes the reviews collected, or returns nil without any
+func (l *latencyLog) latency() *models.ReviewLatency {
+	if l.reviews == 0 {
+		return nil
+	}
+	m := &models.ReviewLatency{Reviews: l.reviews, Heatmap: []models.ReviewLatencyCell{}}
+	var byHour [24][]float64
+	var all []float64
+	for day := range l.hours {
+		for hour, hours := range l.hours[day] {
+			if l.submitted[day][hour] == 0 && len(hours) == 0 {
+				continue
+			}
+			m.Heatmap = append(m.Heatmap, models.ReviewLatencyCell{
+				Weekday:   day,
+				Hour:      hour,
+				Submitted: l.submitted[day][hour],
+				Latency:   latencyStats(hours),
+			})
+			byHour[hour] = append(byHour[hour], hours...)
+			all = append(all, hours...)
+		}
+	}
+	for hour, hours := range byHour {
+		m.Hours[hour] = latencyStats(hours)
+	}
+	m.Overall = latencyStats(all)
+	m.Timed = len(all)
+	return m
+}
+
+// weekdayIndex numbers the days of the week from Monday (0) to Sunday (6)
+func weekdayIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// latencyStats summarizes the distribution of response times in hours
+func latencyStats(hours []float64) models.LatencyStats {
+	if len(hours) == 0 {
+		return models.LatencyStats{}
+	}
+	sorted := slices.Clone(hours)
+	slices.Sort(sorted)
+	return models.LatencyStats{
+		Count:  len(sorted),
+		Median: quantile(sorted, 0.5),
+		P75:    quantile(sorted, 0.75),
+		P90:    quantile(sorted, 0.9),
+	}
+}
+
+// quantile interpolates the q quantile of sorted values
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(pos)
+	if lower+1 >= len(sorted) {
+		return sorted[lower]
+	}
+	return sorted[lower] + (pos-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
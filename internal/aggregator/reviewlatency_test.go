@@ -0,0 +1,84 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestApplyReviewLatency(t *testing.T) {
+	t.Parallel()
+
+	review := func(login string, submitted time.Time, hours int) models.Review {
+		r := models.Review{Author: models.Author{Login: login}, SubmittedAt: submitted}
+		if hours >= 0 {
+			d := time.Duration(hours) * time.Hour
+			r.ResponseTime = &d
+		}
+		return r
+	}
+	// Monday 15 January 2024, in UTC and in a zone an hour ahead
+	monday := func(hour int) time.Time { return time.Date(2024, 1, 15, hour, 0, 0, 0, time.UTC) }
+	cet := time.FixedZone("CET", 3600)
+
+	data := &models.RawData{Reviews: []models.Review{
+		review("alice", monday(10), 2),                                    // Requested Monday 8:00
+		review("alice", time.Date(2024, 1, 15, 11, 30, 0, 0, cet), 3),     // Submitted 10:30 UTC, requested 7:30
+		review("bob", monday(10).Add(-24*time.Hour), 20),                  // Sunday 10:00, requested Saturday 14:00
+		review("bob", monday(9), -1),                                      // Not timed
+		review("bob", monday(9).Add(-9*time.Hour), 1),                     // Monday 0:00, requested Sunday 23:00
+		{Author: models.Author{Login: "carol"}, SubmittedAt: time.Time{}}, // Never submitted
+		review("", monday(9), 1),                                          // Unknown reviewer
+	}}
+	teams := []models.TeamMetrics{
+		{Name: "Platform", Members: []string{"alice", "alice"}},
+		{Name: "Idle", Members: []string{"dave"}},
+	}
+
+	latency := applyReviewLatency(data, teams)
+	require.NotNil(t, latency)
+	assert.Equal(t, 5, latency.Reviews)
+	assert.Equal(t, 4, latency.Timed)
+	assert.Equal(t, []models.ReviewLatencyCell{
+		{Weekday: 0, Hour: 0, Submitted: 1},
+		{Weekday: 0, Hour: 7, Latency: models.LatencyStats{Count: 1, Median: 3, P75: 3, P90: 3}},
+		{Weekday: 0, Hour: 8, Latency: models.LatencyStats{Count: 1, Median: 2, P75: 2, P90: 2}},
+		{Weekday: 0, Hour: 9, Submitted: 1},
+		{Weekday: 0, Hour: 10, Submitted: 2},
+		{Weekday: 5, Hour: 14, Latency: models.LatencyStats{Count: 1, Median: 20, P75: 20, P90: 20}},
+		{Weekday: 6, Hour: 10, Submitted: 1},
+		{Weekday: 6, Hour: 23, Latency: models.LatencyStats{Count: 1, Median: 1, P75: 1, P90: 1}},
+	}, latency.Heatmap)
+	assert.Equal(t, models.LatencyStats{Count: 1, Median: 20, P75: 20, P90: 20}, latency.Hours[14])
+	assert.Equal(t, models.LatencyStats{}, latency.Hours[12])
+	assert.Equal(t, models.LatencyStats{Count: 4, Median: 2.5, P75: 7.25, P90: 14.9}, roundStats(latency.Overall))
+
+	platform := teams[0].ReviewLatency
+	require.NotNil(t, platform)
+	assert.Equal(t, 2, platform.Reviews, "members listed twice count once")
+	assert.Equal(t, 2, platform.Timed)
+	assert.Nil(t, teams[1].ReviewLatency, "no reviews")
+
+	assert.Nil(t, applyReviewLatency(&models.RawData{}, nil))
+}
+
+func TestQuantile(t *testing.T) {
+	t.Parallel()
+
+	sorted := []float64{1, 2, 3, 4, 10}
+	assert.InDelta(t, 3, quantile(sorted, 0.5), 1e-9)
+	assert.InDelta(t, 4, quantile(sorted, 0.75), 1e-9)
+	assert.InDelta(t, 7.6, quantile(sorted, 0.9), 1e-9)
+	assert.InDelta(t, 10, quantile(sorted, 1), 1e-9)
+	assert.InDelta(t, 5, quantile([]float64{5}, 0.9), 1e-9)
+}
+
+// roundStats rounds the response times to avoid comparing floating point noise
+func roundStats(s models.LatencyStats) models.LatencyStats {
+	round := func(v float64) float64 { return float64(int(v*100+0.5)) / 100 }
+	return models.LatencyStats{Count: s.Count, Median: round(s.Median), P75: round(s.P75), P90: round(s.P90)}
+}
@@ -0,0 +1,153 @@
+package aggregator
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+var (
+	codeBlockPattern    = regexp.MustCompile("(?s)```.*?(```|$)")
+	inlineCodePattern   = regexp.MustCompile("`[^`\n]*`")
+	urlPattern          = regexp.MustCompile(`https?://\S+`)
+	emojiCodePattern    = regexp.MustCompile(`:[a-z0-9_+-]*[a-z][a-z0-9_+-]*:`)
+	listMarkerPattern   = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+`)
+	commentLabelPattern = regexp.MustCompile(`(?i)^(?:nit|nitpick|minor|optional|suggestion|issue|question|todo)(?:\s*\([^)]*\))?\s*:\s*`)
+)
+
+// imperativeVerbs start the directives common in review comments ("rename
+// this", "don't panic here"). A sentence opening with "please" is one too.
+var imperativeVerbs = map[string]bool{
+	"add": true, "avoid": true, "change": true, "check": true, "consider": true,
+	"convert": true, "delete": true, "don't": true, "dont": true, "drop": true,
+	"extract": true, "fix": true, "handle": true, "inline": true, "keep": true,
+	"make": true, "move": true, "never": true, "please": true, "put": true,
+	"remove": true, "rename": true, "replace": true, "return": true, "revert": true,
+	"simplify": true, "split": true, "update": true, "use": true, "wrap": true,
+}
+
+// applyReviewTone summarizes the tone of review comments, both those
+// submitted with a review and those inline on the diff, per repository, and
+// per reviewer only when per_contributor is set
+func (a *Aggregator) applyReviewTone(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+) {
+	cfg := a.config.Options.ReviewTone
+	if !cfg.Enabled {
+		return
+	}
+
+	add := func(repo, login, body string) {
+		rm, ok := repoMap[repo]
+		if !ok {
+			return
+		}
+		tone := commentTone(body)
+		if tone == nil {
+			return
+		}
+		rm.ReviewTone = models.AddReviewTone(rm.ReviewTone, tone)
+		if !cfg.PerContributor {
+			return
+		}
+		if cm, ok := contributorMap[login]; ok {
+			cm.ReviewTone = models.AddReviewTone(cm.ReviewTone, tone)
+		}
+		if rcm, ok := repoContributorMap[repo][login]; ok {
+			rcm.ReviewTone = models.AddReviewTone(rcm.ReviewTone, tone)
+		}
+	}
+
+	for _, review := range data.Reviews {
+		add(review.Repository, review.Author.Login, review.Body)
+	}
+	for _, comment := range data.ReviewComments {
+		add(comment.Repository, comment.Author.Login, comment.Body)
+	}
+}
+
+// commentTone returns the tone of a single review comment, or nil when it
+// has no prose outside code blocks and quotes
+func commentTone(body string) *models.ReviewTone {
+	text := codeBlockPattern.ReplaceAllString(body, "\n")
+	text = inlineCodePattern.ReplaceAllString(text, "code")
+	text = urlPattern.ReplaceAllString(text, "")
+
+	tone := &models.ReviewTone{Comments: 1}
+	question, emoji := false, false
+	for _, sentence := range commentSentences(text) {
+		tone.Sentences++
+		if strings.HasSuffix(sentence, "?") {
+			question = true
+		}
+		if isImperative(sentence) {
+			tone.Imperatives++
+		}
+		if hasEmoji(sentence) {
+			emoji = true
+		}
+	}
+	if tone.Sentences == 0 {
+		return nil
+	}
+	if question {
+		tone.Questions = 1
+	}
+	if emoji {
+		tone.WithEmoji = 1
+	}
+	return models.AddReviewTone(tone, nil)
+}
+
+// commentSentences splits the prose of a comment into sentences, leaving out
+// quoted lines and list markers
+func commentSentences(text string) []string {
+	var sentences []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, ">") {
+			continue
+		}
+		line = listMarkerPattern.ReplaceAllString(line, "")
+
+		start := 0
+		runes := []rune(line)
+		for i, r := range runes {
+			end := i == len(runes)-1
+			if !end && (!strings.ContainsRune(".!?", r) || !unicode.IsSpace(runes[i+1])) {
+				continue
+			}
+			sentence := strings.TrimSpace(string(runes[start : i+1]))
+			start = i + 1
+			if strings.IndexFunc(sentence, unicode.IsLetter) >= 0 || hasEmoji(sentence) {
+				sentences = append(sentences, sentence)
+			}
+		}
+	}
+	return sentences
+}
+
+// isImperative reports whether a sentence opens with an imperative verb,
+// after a conventional comment label such as "nit:"
+func isImperative(sentence string) bool {
+	sentence = commentLabelPattern.ReplaceAllString(sentence, "")
+	word, _, _ := strings.Cut(strings.ToLower(sentence), " ")
+	word = strings.ReplaceAll(word, "’", "'")
+	word = strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && r != '\'' })
+	return imperativeVerbs[word]
+}
+
+// hasEmoji reports whether text uses an emoji or a :shortcode:
+func hasEmoji(text string) bool {
+	if emojiCodePattern.MatchString(text) {
+		return true
+	}
+	return strings.IndexFunc(text, func(r rune) bool {
+		return (r >= 0x1F300 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF)
+	}) >= 0
+}
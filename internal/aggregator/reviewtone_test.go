@@ -0,0 +1,131 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestCommentTone(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body string
+		want *models.ReviewTone
+	}{
+		"empty approval": {
+			body: "",
+		},
+		"only code": {
+			body: "```suggestion\nreturn nil\n```",
+		},
+		"question": {
+			body: "Why not reuse `parseDate` here? Looks good otherwise.",
+			want: &models.ReviewTone{Comments: 1, Sentences: 2, Questions: 1},
+		},
+		"directives": {
+			body: "nit: rename this to `count`.\n- Please add a test\n- Don't ignore the error",
+			want: &models.ReviewTone{Comments: 1, Sentences: 3, Imperatives: 3},
+		},
+		"emoji": {
+			body: "Nice one 🎉",
+			want: &models.ReviewTone{Comments: 1, Sentences: 1, WithEmoji: 1},
+		},
+		"shortcode": {
+			body: "LGTM :shipit:",
+			want: &models.ReviewTone{Comments: 1, Sentences: 1, WithEmoji: 1},
+		},
+		"quotes, urls and times are ignored": {
+			body: "> Use a map here?\nSee https://example.com/a:b: for the 10:30 run.",
+			want: &models.ReviewTone{Comments: 1, Sentences: 1},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := commentTone(tt.body)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, *models.AddReviewTone(tt.want, nil), *got)
+		})
+	}
+}
+
+func TestAggregator_ReviewTone(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			{Number: 1, Title: "Add caching", Author: models.Author{Login: "alice"}, Repository: "acme/api", CreatedAt: at, State: models.PRStateOpen},
+		},
+		Reviews: []models.Review{
+			{ID: 1, PullRequest: 1, Repository: "acme/api", Author: models.Author{Login: "bob"}, State: models.ReviewCommented, SubmittedAt: at.Add(time.Hour), Body: "Why a mutex? Use a channel."},
+			{ID: 2, PullRequest: 1, Repository: "acme/api", Author: models.Author{Login: "carol"}, State: models.ReviewApproved, SubmittedAt: at.Add(2 * time.Hour), Body: "Great work 👍"},
+			{ID: 3, PullRequest: 1, Repository: "acme/api", Author: models.Author{Login: "carol"}, State: models.ReviewApproved, SubmittedAt: at.Add(3 * time.Hour)},
+		},
+		ReviewComments: []models.ReviewComment{
+			{ID: 11, ReviewID: 1, PullRequest: 1, Repository: "acme/api", Author: models.Author{Login: "bob"}, Body: "Handle the error here.", Path: "cache.go", Line: 12, CreatedAt: at.Add(time.Hour)},
+			{ID: 12, PullRequest: 1, Repository: "acme/api", Author: models.Author{Login: "github-actions[bot]"}, Body: "Fix the lint warning?", Path: "cache.go", Line: 3, CreatedAt: at},
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+	dateRange := &config.ParsedDateRange{Start: &start, End: &end}
+
+	tests := map[string]struct {
+		enabled        bool
+		perContributor bool
+	}{
+		"disabled":        {},
+		"per repository":  {enabled: true},
+		"per contributor": {enabled: true, perContributor: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.DefaultConfig()
+			cfg.Options.ReviewTone = config.ReviewToneConfig{Enabled: tt.enabled, PerContributor: tt.perContributor}
+			metrics, err := New(cfg).Aggregate(data, dateRange)
+			require.NoError(t, err)
+			require.Len(t, metrics.Repositories, 1)
+
+			tone := metrics.Repositories[0].ReviewTone
+			if !tt.enabled {
+				assert.Nil(t, tone)
+			} else {
+				require.NotNil(t, tone)
+				assert.Equal(t, 3, tone.Comments, "reviews without a comment and bots are left out")
+				assert.Equal(t, 4, tone.Sentences)
+				assert.InDelta(t, 100.0/3, tone.QuestionRatio, 0.001)
+				assert.InDelta(t, 50.0, tone.ImperativeDensity, 0.001)
+				assert.InDelta(t, 100.0/3, tone.EmojiRatio, 0.001)
+			}
+
+			contributors := make(map[string]models.ContributorMetrics)
+			for _, c := range metrics.Contributors {
+				contributors[c.Login] = c
+			}
+			require.Contains(t, contributors, "bob")
+			bob := contributors["bob"]
+			if !tt.perContributor {
+				assert.Nil(t, bob.ReviewTone, "never per person by default")
+				return
+			}
+			require.NotNil(t, bob.ReviewTone)
+			assert.Equal(t, 2, bob.ReviewTone.Comments, "inline comments count too")
+			assert.Equal(t, 1, bob.ReviewTone.Questions)
+			assert.Equal(t, 2, bob.ReviewTone.Imperatives)
+		})
+	}
+}
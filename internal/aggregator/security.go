@@ -0,0 +1,105 @@
+package aggregator
+
+import (
+	"fmt"
+
+	"github.com/lukaszraczylo/git-velocity/internal/security"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// applySecurityMetrics counts security fixes per contributor and repository:
+//   - merged PRs with a security label or a GHSA/CVE ID in their title or branch
+//   - commits referencing an advisory that no security PR of the repository references
+//   - security PRs opened by bots (Dependabot security updates), credited to
+//     the humans who approved them
+//
+// raw is the data before bot filtering, so bot-authored fixes can be found.
+func (a *Aggregator) applySecurityMetrics(
+	raw, data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+	commitLogin func(models.Commit) string,
+) {
+	detector := security.NewDetector(a.config.Options.SecurityLabels)
+
+	credit := func(repo, login string) {
+		if cm, ok := contributorMap[login]; ok {
+			cm.SecurityFixes++
+		}
+		if rcm, ok := repoContributorMap[repo][login]; ok {
+			rcm.SecurityFixes++
+		}
+	}
+	countRepo := func(repo string) {
+		if rm, ok := repoMap[repo]; ok {
+			rm.SecurityFixes++
+		}
+	}
+
+	prKey := func(pr models.PullRequest) string {
+		return fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+	}
+
+	// Advisories referenced by security PRs, per repository
+	covered := make(map[string]map[string]bool)
+	isFix := func(pr models.PullRequest) bool {
+		if !pr.IsMerged() || !detector.IsFix(pr.Labels, pr.Title, pr.HeadBranch) {
+			return false
+		}
+		if covered[pr.Repository] == nil {
+			covered[pr.Repository] = make(map[string]bool)
+		}
+		for _, id := range security.Advisories(pr.Title, pr.HeadBranch) {
+			covered[pr.Repository][id] = true
+		}
+		countRepo(pr.Repository)
+		return true
+	}
+
+	kept := make(map[string]bool)
+	for _, pr := range data.PullRequests {
+		kept[prKey(pr)] = true
+		if isFix(pr) {
+			credit(pr.Repository, pr.Author.Login)
+		}
+	}
+
+	var botFixes []models.PullRequest
+	for _, pr := range raw.PullRequests {
+		if !kept[prKey(pr)] && isFix(pr) {
+			botFixes = append(botFixes, pr)
+		}
+	}
+
+	// Bot security updates: each approving reviewer gets the credit once
+	for _, pr := range botFixes {
+		approvers := make(map[string]bool)
+		for _, review := range data.Reviews {
+			if review.Repository == pr.Repository && review.PullRequest == pr.Number &&
+				review.State == models.ReviewApproved && review.Author.Login != "" {
+				approvers[review.Author.Login] = true
+			}
+		}
+		for login := range approvers {
+			credit(pr.Repository, login)
+		}
+	}
+
+	for _, commit := range data.Commits {
+		if isMergeCommit(commit.Message) {
+			continue
+		}
+		uncovered := false
+		for _, id := range security.Advisories(commit.Message) {
+			if !covered[commit.Repository][id] {
+				uncovered = true
+				break
+			}
+		}
+		if uncovered {
+			countRepo(commit.Repository)
+			credit(commit.Repository, commitLogin(commit))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_SecurityMetrics(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	merged := func(number int, login, title string, labels ...string) models.PullRequest {
+		mergedAt := at.Add(time.Duration(number) * time.Hour)
+		return models.PullRequest{
+			Number:     number,
+			Title:      title,
+			State:      models.PRStateMerged,
+			Author:     models.Author{Login: login},
+			Repository: "owner/repo",
+			HeadBranch: "branch",
+			CreatedAt:  at,
+			MergedAt:   &mergedAt,
+			Labels:     labels,
+		}
+	}
+	commit := func(sha, login, message string) models.Commit {
+		return models.Commit{
+			SHA:        sha,
+			Message:    message,
+			Author:     models.Author{Login: login, Email: login + "@example.com"},
+			Date:       at,
+			Repository: "owner/repo",
+		}
+	}
+
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			merged(1, "alice", "Harden session cookies", "Security"),
+			merged(2, "alice", "Fix GHSA-jfh8-c2jp-5v3q"),
+			merged(3, "dependabot[bot]", "Bump lodash from 4.17.15 to 4.17.21", "dependencies", "security"),
+			merged(4, "bob", "Fix typo", "docs"),
+		},
+		Reviews: []models.Review{
+			{PullRequest: 3, Repository: "owner/repo", Author: models.Author{Login: "bob"}, State: models.ReviewApproved, SubmittedAt: at},
+			{PullRequest: 3, Repository: "owner/repo", Author: models.Author{Login: "bob"}, State: models.ReviewApproved, SubmittedAt: at},
+			{PullRequest: 3, Repository: "owner/repo", Author: models.Author{Login: "carol"}, State: models.ReviewCommented, SubmittedAt: at},
+		},
+		Commits: []models.Commit{
+			commit("c1", "alice", "Patch GHSA-jfh8-c2jp-5v3q"), // covered by PR #2
+			commit("c2", "carol", "Backport fix for CVE-2024-3094"),
+		},
+	}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, cm := range metrics.Contributors {
+		byLogin[cm.Login] = cm
+	}
+	assert.Equal(t, 2, byLogin["alice"].SecurityFixes)
+	assert.Equal(t, 1, byLogin["bob"].SecurityFixes, "approved the Dependabot security update")
+	assert.Equal(t, 1, byLogin["carol"].SecurityFixes)
+
+	require.Len(t, metrics.Repositories, 1)
+	assert.Equal(t, 4, metrics.Repositories[0].SecurityFixes)
+}
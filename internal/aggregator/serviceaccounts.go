@@ -0,0 +1,95 @@
+package aggregator
+
+import (
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// serviceAccountPrefix starts the login a team's service accounts are
+// counted under; it can't clash with GitHub logins
+const serviceAccountPrefix = "service-accounts:"
+
+// serviceAccountLogin returns the login a team's service accounts are
+// counted under
+func serviceAccountLogin(team string) string {
+	return serviceAccountPrefix + team
+}
+
+// isServiceAccountLogin reports whether login is a team's service account bucket
+func isServiceAccountLogin(login string) bool {
+	return strings.HasPrefix(login, serviceAccountPrefix)
+}
+
+// withServiceAccounts returns data with the activity of configured service
+// accounts attributed to their team's bucket. Commits are matched by their
+// email as well as their raw and resolved logins.
+func (a *Aggregator) withServiceAccounts(data *models.RawData, emailToLogin, loginToLogin map[string]string) *models.RawData {
+	if !a.hasServiceAccounts() {
+		return data
+	}
+
+	filtered := *data
+	filtered.Commits = make([]models.Commit, len(data.Commits))
+	for i, commit := range data.Commits {
+		login := commit.Author.Login
+		if mapped, ok := emailToLogin[commit.Author.Email]; ok {
+			login = mapped
+		}
+		if mapped, ok := loginToLogin[login]; ok {
+			login = mapped
+		}
+		a.attribute(&commit.Author, commit.Author.Email, login, commit.Author.Login)
+		filtered.Commits[i] = commit
+	}
+
+	filtered.PullRequests = make([]models.PullRequest, len(data.PullRequests))
+	for i, pr := range data.PullRequests {
+		a.attribute(&pr.Author, pr.Author.Login)
+		filtered.PullRequests[i] = pr
+	}
+	filtered.Reviews = make([]models.Review, len(data.Reviews))
+	for i, review := range data.Reviews {
+		a.attribute(&review.Author, review.Author.Login)
+		filtered.Reviews[i] = review
+	}
+	filtered.Issues = make([]models.Issue, len(data.Issues))
+	for i, issue := range data.Issues {
+		a.attribute(&issue.Author, issue.Author.Login)
+		filtered.Issues[i] = issue
+	}
+	filtered.IssueComments = make([]models.IssueComment, len(data.IssueComments))
+	for i, comment := range data.IssueComments {
+		a.attribute(&comment.Author, comment.Author.Login)
+		filtered.IssueComments[i] = comment
+	}
+	filtered.ReviewComments = make([]models.ReviewComment, len(data.ReviewComments))
+	for i, comment := range data.ReviewComments {
+		a.attribute(&comment.Author, comment.Author.Login)
+		filtered.ReviewComments[i] = comment
+	}
+	return &filtered
+}
+
+// attribute replaces author with its team's service account bucket when any
+// of the identities is a service account
+func (a *Aggregator) attribute(author *models.Author, identities ...string) {
+	for _, identity := range identities {
+		if team := a.config.GetServiceAccountTeam(identity); team != nil {
+			login := serviceAccountLogin(team.Name)
+			// The email is the bucket too, so commit email mappings can't
+			// resolve the author back to a person
+			*author = models.Author{Login: login, Name: team.Name + " service accounts", Email: login}
+			return
+		}
+	}
+}
+
+func (a *Aggregator) hasServiceAccounts() bool {
+	for _, team := range a.config.Teams {
+		if len(team.ServiceAccounts) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_ServiceAccounts(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: at, Repository: "owner/repo", Additions: 10},
+			{SHA: "b", Author: models.Author{Login: "deploy", Email: "deploy@example.com"}, Date: at, Repository: "owner/repo", Additions: 5},
+			{SHA: "c", Author: models.Author{Login: "platform-ops"}, Date: at, Repository: "owner/repo", Additions: 1},
+		},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "platform-ops"}, CreatedAt: at, Repository: "owner/repo"},
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Teams = []config.TeamConfig{{
+		Name:            "Platform",
+		Members:         []string{"alice"},
+		ServiceAccounts: []string{"platform-ops", "Deploy@example.com"},
+	}}
+	start := at.AddDate(0, 0, -9)
+	end := at.AddDate(0, 0, 20)
+
+	metrics, err := New(cfg).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	// Service accounts are no individual contributors...
+	require.Len(t, metrics.Contributors, 1)
+	assert.Equal(t, "alice", metrics.Contributors[0].Login)
+	require.Len(t, metrics.Repositories, 1)
+	assert.Len(t, metrics.Repositories[0].Contributors, 1)
+	assert.Equal(t, 3, metrics.Repositories[0].TotalCommits)
+
+	// ...but count towards their team
+	require.Len(t, metrics.Teams, 1)
+	team := metrics.Teams[0]
+	require.Len(t, team.MemberMetrics, 1)
+	require.NotNil(t, team.ServiceAccounts)
+	assert.Equal(t, 2, team.ServiceAccounts.CommitCount)
+	assert.Equal(t, 1, team.ServiceAccounts.PRsOpened)
+	assert.Equal(t, 3, team.AggregatedMetrics.CommitCount)
+	assert.Equal(t, 16, team.AggregatedMetrics.LinesAdded)
+	assert.Equal(t, 1, team.AggregatedMetrics.PRsOpened)
+}
@@ -0,0 +1,216 @@
+package aggregator
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// contributorZone is the time zone a contributor works in: the configured
+// location, or a fixed UTC offset inferred from their commits
+type contributorZone struct {
+	location *time.Location
+	name     string // IANA name, when configured
+	inferred bool
+}
+
+// offset returns the zone's offset from UTC at t
+func (z contributorZone) offset(t time.Time) time.Duration {
+	_, seconds := t.In(z.location).Zone()
+	return time.Duration(seconds) * time.Second
+}
+
+// contributorZones returns the time zones of the contributors by lowercased
+// login. Configured zones win; otherwise a contributor works in the UTC offset
+// most of their commits were authored in, the smallest one on a tie.
+func (a *Aggregator) contributorZones(data *models.RawData, commitLogin func(models.Commit) string) map[string]contributorZone {
+	offsets := make(map[string]map[int]int) // login -> offset in seconds -> commits
+	for _, commit := range data.Commits {
+		login := strings.ToLower(commitLogin(commit))
+		if login == "" || commit.Date.IsZero() {
+			continue
+		}
+		_, offset := commit.Date.Zone()
+		if offsets[login] == nil {
+			offsets[login] = make(map[int]int)
+		}
+		offsets[login][offset]++
+	}
+
+	zones := make(map[string]contributorZone, len(offsets))
+	for login, counts := range offsets {
+		best, most := 0, 0
+		for offset, commits := range counts {
+			if commits > most || (commits == most && offset < best) {
+				best, most = offset, commits
+			}
+		}
+		zones[login] = contributorZone{location: time.FixedZone("", best), inferred: true}
+	}
+	for i := range a.config.Contributors {
+		cc := &a.config.Contributors[i]
+		// Time zones are checked when the config is validated
+		if loc, err := cc.Location(); err == nil && loc != nil {
+			zones[strings.ToLower(cc.Login)] = contributorZone{location: loc, name: cc.Timezone}
+		}
+	}
+	return zones
+}
+
+// zoneDistance returns how far apart two UTC offsets are on the clock, so
+// UTC+12 and UTC-11 are an hour apart
+func zoneDistance(a, b time.Duration) time.Duration {
+	d := (a - b) % (24 * time.Hour)
+	if d < 0 {
+		d = -d
+	}
+	return min(d, 24*time.Hour-d)
+}
+
+// applyTimezoneCollaboration measures how often the reviews each team's
+// members gave or received cross distant time zones
+func (a *Aggregator) applyTimezoneCollaboration(
+	data *models.RawData,
+	teams []models.TeamMetrics,
+	period models.Period,
+	commitLogin func(models.Commit) string,
+	loginToLogin map[string]string,
+) {
+	if len(teams) == 0 {
+		return
+	}
+	zones := a.contributorZones(data, commitLogin)
+	distant := a.config.Options.DistantTimezone()
+
+	authors := make(map[string]string) // repository#number -> PR author
+	for _, pr := range data.PullRequests {
+		login := pr.Author.Login
+		if mapped, ok := loginToLogin[login]; ok {
+			login = mapped
+		}
+		authors[prKey(pr.Repository, pr.Number)] = login
+	}
+
+	tallies := make([]*timezoneTally, len(teams))
+	memberTeams := make(map[string][]int) // lowercased login -> indexes of their teams
+	for i, team := range teams {
+		tallies[i] = &timezoneTally{pairs: make(map[[2]string]*timezonePair)}
+		for _, member := range team.Members {
+			key := strings.ToLower(member)
+			if !slices.Contains(memberTeams[key], i) {
+				memberTeams[key] = append(memberTeams[key], i)
+			}
+		}
+	}
+
+	for _, review := range data.Reviews {
+		reviewer := review.Author.Login
+		author := authors[prKey(review.Repository, review.PullRequest)]
+		if reviewer == "" || author == "" || strings.EqualFold(reviewer, author) {
+			continue
+		}
+		reviewerZone, ok := zones[strings.ToLower(reviewer)]
+		if !ok {
+			continue
+		}
+		authorZone, ok := zones[strings.ToLower(author)]
+		if !ok {
+			continue
+		}
+		apart := zoneDistance(reviewerZone.offset(review.SubmittedAt), authorZone.offset(review.SubmittedAt))
+
+		// A review between two members of a team counts once for it
+		involved := slices.Clone(memberTeams[strings.ToLower(reviewer)])
+		for _, t := range memberTeams[strings.ToLower(author)] {
+			if !slices.Contains(involved, t) {
+				involved = append(involved, t)
+			}
+		}
+		for _, t := range involved {
+			tallies[t].add(reviewer, author, review.SubmittedAt, apart, apart >= distant)
+		}
+	}
+
+	for i := range teams {
+		teams[i].Timezones = tallies[i].collaboration(teams[i].Members, zones, period.End)
+	}
+}
+
+// timezoneTally counts the reviews of a team between contributors of known time zones
+type timezoneTally struct {
+	reviews int
+	cross   int
+	pairs   map[[2]string]*timezonePair // reviewer, author
+}
+
+type timezonePair struct {
+	reviews int
+	latest  time.Time
+	apart   time.Duration // At the latest review
+}
+
+func (t *timezoneTally) add(reviewer, author string, at time.Time, apart time.Duration, cross bool) {
+	t.reviews++
+	if !cross {
+		return
+	}
+	t.cross++
+	key := [2]string{reviewer, author}
+	p := t.pairs[key]
+	if p == nil {
+		p = &timezonePair{}
+		t.pairs[key] = p
+	}
+	p.reviews++
+	if !at.Before(p.latest) {
+		p.latest, p.apart = at, apart
+	}
+}
+
+// collaboration summarizes the tally with the time zones of the team's
+// members at the end of the period, or returns nil when no zone is known
+func (t *timezoneTally) collaboration(members []string, zones map[string]contributorZone, end time.Time) *models.TimezoneCollaboration {
+	c := &models.TimezoneCollaboration{Reviews: t.reviews, CrossTimezone: t.cross, Members: []models.MemberTimezone{}}
+	if t.reviews > 0 {
+		c.Index = float64(t.cross) / float64(t.reviews) * 100
+	}
+	seen := make(map[string]bool)
+	for _, member := range members {
+		if seen[strings.ToLower(member)] {
+			continue
+		}
+		seen[strings.ToLower(member)] = true
+		zone, ok := zones[strings.ToLower(member)]
+		if !ok {
+			c.Unknown = append(c.Unknown, member)
+			continue
+		}
+		c.Members = append(c.Members, models.MemberTimezone{
+			Login:    member,
+			Offset:   zone.offset(end).Hours(),
+			Zone:     zone.name,
+			Inferred: zone.inferred,
+		})
+	}
+	if len(c.Members) == 0 {
+		return nil
+	}
+	sort.SliceStable(c.Members, func(i, j int) bool { return c.Members[i].Offset < c.Members[j].Offset })
+
+	for key, p := range t.pairs {
+		c.Pairs = append(c.Pairs, models.TimezonePair{Reviewer: key[0], Author: key[1], Reviews: p.reviews, Hours: p.apart.Hours()})
+	}
+	sort.Slice(c.Pairs, func(i, j int) bool {
+		if c.Pairs[i].Reviews != c.Pairs[j].Reviews {
+			return c.Pairs[i].Reviews > c.Pairs[j].Reviews
+		}
+		if c.Pairs[i].Reviewer != c.Pairs[j].Reviewer {
+			return c.Pairs[i].Reviewer < c.Pairs[j].Reviewer
+		}
+		return c.Pairs[i].Author < c.Pairs[j].Author
+	})
+	return c
+}
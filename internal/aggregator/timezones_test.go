@@ -0,0 +1,123 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestApplyTimezoneCollaboration(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Contributors = []config.ContributorConfig{{Login: "Carol", Timezone: "America/New_York"}}
+	a := New(cfg)
+
+	commit := func(login string, offsetHours float64) models.Commit {
+		zone := time.FixedZone("", int(offsetHours*3600))
+		return models.Commit{Author: models.Author{Login: login}, Date: time.Date(2024, 7, 1, 10, 0, 0, 0, zone)}
+	}
+	pr := func(number int, author string) models.PullRequest {
+		return models.PullRequest{Repository: "org/api", Number: number, Author: models.Author{Login: author}}
+	}
+	july := time.Date(2024, 7, 2, 12, 0, 0, 0, time.UTC)
+	review := func(number int, reviewer string) models.Review {
+		return models.Review{Repository: "org/api", PullRequest: number, Author: models.Author{Login: reviewer}, SubmittedAt: july}
+	}
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			commit("alice", 2), commit("alice", 2), commit("alice", 0), // Warsaw, sometimes on a UTC machine
+			commit("bob", 5.5),
+			commit("carol", 9), // Configured zones win
+			commit("dave", 1),
+		},
+		PullRequests: []models.PullRequest{pr(1, "alice"), pr(2, "bob"), pr(3, "carol"), pr(4, "erin")},
+		Reviews: []models.Review{
+			review(1, "bob"),   // 3.5h apart: not distant
+			review(1, "carol"), // 6h apart (New York in summer)
+			review(1, "carol"),
+			review(2, "alice"), // 3.5h
+			review(3, "dave"),  // 5h
+			review(1, "alice"), // Their own PR
+			review(4, "alice"), // Author's zone unknown
+		},
+	}
+	teams := []models.TeamMetrics{
+		{Name: "Platform", Members: []string{"alice", "bob", "Bob", "erin"}},
+		{Name: "Americas", Members: []string{"carol"}},
+		{Name: "Unknown", Members: []string{"frank"}},
+	}
+	period := models.Period{End: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)}
+
+	a.applyTimezoneCollaboration(data, teams, period, func(c models.Commit) string { return c.Author.Login }, nil)
+
+	platform := teams[0].Timezones
+	require.NotNil(t, platform)
+	assert.Equal(t, 4, platform.Reviews)
+	assert.Equal(t, 2, platform.CrossTimezone)
+	assert.InDelta(t, 50, platform.Index, 1e-9)
+	assert.Equal(t, []models.MemberTimezone{
+		{Login: "alice", Offset: 2, Inferred: true},
+		{Login: "bob", Offset: 5.5, Inferred: true},
+	}, platform.Members)
+	assert.Equal(t, []string{"erin"}, platform.Unknown)
+	assert.Equal(t, []models.TimezonePair{{Reviewer: "carol", Author: "alice", Reviews: 2, Hours: 6}}, platform.Pairs)
+
+	americas := teams[1].Timezones
+	require.NotNil(t, americas)
+	assert.Equal(t, 3, americas.Reviews)
+	assert.Equal(t, 3, americas.CrossTimezone)
+	assert.Equal(t, []models.MemberTimezone{{Login: "carol", Offset: -5, Zone: "America/New_York"}}, americas.Members, "offsets at the end of the period")
+	assert.Equal(t, []models.TimezonePair{
+		{Reviewer: "carol", Author: "alice", Reviews: 2, Hours: 6},
+		{Reviewer: "dave", Author: "carol", Reviews: 1, Hours: 5},
+	}, americas.Pairs)
+
+	assert.Nil(t, teams[2].Timezones, "no member's zone is known")
+}
+
+func TestApplyTimezoneCollaboration_Distance(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Options.DistantTimezoneHours = 6
+	a := New(cfg)
+	at := func(offsetHours int) time.Time {
+		return time.Date(2024, 7, 1, 10, 0, 0, 0, time.FixedZone("", offsetHours*3600))
+	}
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{Author: models.Author{Login: "alice"}, Date: at(12)},
+			{Author: models.Author{Login: "bob"}, Date: at(-11)},
+			{Author: models.Author{Login: "carol"}, Date: at(-5)},
+		},
+		PullRequests: []models.PullRequest{{Repository: "org/api", Number: 1, Author: models.Author{Login: "alice"}}},
+		Reviews: []models.Review{
+			{Repository: "org/api", PullRequest: 1, Author: models.Author{Login: "bob"}, SubmittedAt: at(0)},   // An hour apart across the date line
+			{Repository: "org/api", PullRequest: 1, Author: models.Author{Login: "carol"}, SubmittedAt: at(0)}, // 7 hours
+		},
+	}
+	teams := []models.TeamMetrics{{Name: "Pacific", Members: []string{"alice"}}}
+
+	a.applyTimezoneCollaboration(data, teams, models.Period{End: at(0)}, func(c models.Commit) string { return c.Author.Login }, nil)
+
+	require.NotNil(t, teams[0].Timezones)
+	assert.Equal(t, 2, teams[0].Timezones.Reviews)
+	assert.Equal(t, 1, teams[0].Timezones.CrossTimezone)
+	assert.Equal(t, []models.TimezonePair{{Reviewer: "carol", Author: "alice", Reviews: 1, Hours: 7}}, teams[0].Timezones.Pairs)
+}
+
+func TestZoneDistance(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 3*time.Hour, zoneDistance(2*time.Hour, -time.Hour))
+	assert.Equal(t, time.Hour, zoneDistance(12*time.Hour, -11*time.Hour))
+	assert.Equal(t, 12*time.Hour, zoneDistance(-12*time.Hour, 0))
+	assert.Equal(t, 90*time.Minute, zoneDistance(5*time.Hour+30*time.Minute, 4*time.Hour))
+}
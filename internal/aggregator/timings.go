@@ -0,0 +1,80 @@
+package aggregator
+
+import "github.com/lukaszraczylo/git-velocity/pkg/models"
+
+// mean accumulates values towards their average
+type mean struct {
+	Sum   float64
+	Count int
+}
+
+func (m *mean) add(v float64) {
+	m.Sum += v
+	m.Count++
+}
+
+// Value returns the average of the values added, or 0 without any
+func (m mean) Value() float64 {
+	if m.Count == 0 {
+		return 0
+	}
+	return m.Sum / float64(m.Count)
+}
+
+// timings accumulates the durations averaged into a contributor's metrics.
+// They are kept apart from the metrics, which only receive the finished
+// averages, so that no step can read a sum as an average.
+type timings struct {
+	TimeToMerge mean // Hours from opening to merging their merged PRs
+	ReviewTime  mean // Hours from review request to their reviews
+}
+
+// timingsLog holds the timings of each contributor, overall and per repository
+type timingsLog struct {
+	global map[string]*timings            // login -> timings
+	repos  map[string]map[string]*timings // repo -> login -> timings
+}
+
+func newTimingsLog() *timingsLog {
+	return &timingsLog{
+		global: make(map[string]*timings),
+		repos:  make(map[string]map[string]*timings),
+	}
+}
+
+// of returns the timings of a contributor overall and in a repository
+func (l *timingsLog) of(repo, login string) (global, perRepo *timings) {
+	if l.global[login] == nil {
+		l.global[login] = &timings{}
+	}
+	if l.repos[repo] == nil {
+		l.repos[repo] = make(map[string]*timings)
+	}
+	if l.repos[repo][login] == nil {
+		l.repos[repo][login] = &timings{}
+	}
+	return l.global[login], l.repos[repo][login]
+}
+
+// timeToMerge records the hours a contributor's PR took to merge
+func (l *timingsLog) timeToMerge(repo, login string, hours float64) {
+	global, perRepo := l.of(repo, login)
+	global.TimeToMerge.add(hours)
+	perRepo.TimeToMerge.add(hours)
+}
+
+// reviewTime records the hours a contributor took to review a PR
+func (l *timingsLog) reviewTime(repo, login string, hours float64) {
+	global, perRepo := l.of(repo, login)
+	global.ReviewTime.add(hours)
+	perRepo.ReviewTime.add(hours)
+}
+
+// apply sets the averages of the timings on a contributor's metrics
+func (t *timings) apply(cm *models.ContributorMetrics) {
+	if t == nil {
+		return
+	}
+	cm.AvgTimeToMerge = t.TimeToMerge.Value()
+	cm.AvgReviewTime = t.ReviewTime.Value()
+}
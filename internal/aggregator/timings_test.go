@@ -0,0 +1,157 @@
+package aggregator
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestMean(t *testing.T) {
+	t.Parallel()
+
+	var m mean
+	assert.Zero(t, m.Value(), "no values")
+	m.add(2)
+	m.add(4)
+	m.add(9)
+	assert.Equal(t, mean{Sum: 15, Count: 3}, m)
+	assert.InDelta(t, 5.0, m.Value(), 0.0001)
+}
+
+func TestTimings_Apply(t *testing.T) {
+	t.Parallel()
+
+	log := newTimingsLog()
+	log.timeToMerge("acme/api", "alice", 10)
+	log.timeToMerge("acme/web", "alice", 20)
+	log.reviewTime("acme/api", "alice", 3)
+
+	cm := &models.ContributorMetrics{}
+	log.global["alice"].apply(cm)
+	assert.InDelta(t, 15.0, cm.AvgTimeToMerge, 0.0001)
+	assert.InDelta(t, 3.0, cm.AvgReviewTime, 0.0001)
+
+	rcm := &models.ContributorMetrics{}
+	log.repos["acme/web"]["alice"].apply(rcm)
+	assert.InDelta(t, 20.0, rcm.AvgTimeToMerge, 0.0001)
+	assert.Zero(t, rcm.AvgReviewTime, "no reviews in the repository")
+
+	// Contributors without timings keep no averages
+	none := &models.ContributorMetrics{}
+	log.global["bob"].apply(none)
+	log.repos["acme/docs"]["bob"].apply(none)
+	assert.Zero(t, none.AvgTimeToMerge)
+}
+
+// TestAggregator_AverageInvariants checks the averages against the PRs and
+// reviews they are taken over: each is the mean of the durations known,
+// lies between their extremes, and the overall average is the mean of the
+// per-repository averages weighted by their counts
+func TestAggregator_AverageInvariants(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	hours := func(h int) *time.Duration {
+		d := time.Duration(h) * time.Hour
+		return &d
+	}
+	data := &models.RawData{}
+	repos := []string{"acme/api", "acme/web", "acme/docs"}
+	for i := range 30 {
+		repo := repos[i%len(repos)]
+		author, reviewer := "alice", "bob"
+		if i%4 == 0 {
+			author, reviewer = "bob", "alice"
+		}
+		merged := at.Add(time.Duration(i+1) * time.Hour)
+		pr := models.PullRequest{
+			Number: i + 1, Title: fmt.Sprintf("PR %d", i+1), Repository: repo,
+			Author: models.Author{Login: author}, State: models.PRStateMerged,
+			CreatedAt: at, MergedAt: &merged,
+		}
+		if i%5 != 0 { // Some PRs have no time to merge
+			pr.TimeToMerge = hours(i + 1)
+		}
+		review := models.Review{
+			ID: int64(i + 1), PullRequest: i + 1, Repository: repo,
+			Author: models.Author{Login: reviewer}, State: models.ReviewApproved,
+			SubmittedAt: at.Add(time.Hour),
+		}
+		if i%3 != 0 { // Some reviews have no response time
+			review.ResponseTime = hours(i%7 + 1)
+		}
+		data.PullRequests = append(data.PullRequests, pr)
+		data.Reviews = append(data.Reviews, review)
+	}
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{})
+	require.NoError(t, err)
+
+	// The durations each average is taken over, overall and per repository
+	type durations struct{ merge, review []float64 }
+	want := make(map[string]*durations)
+	get := func(key string) *durations {
+		if want[key] == nil {
+			want[key] = &durations{}
+		}
+		return want[key]
+	}
+	for _, pr := range data.PullRequests {
+		if pr.TimeToMerge != nil {
+			get(pr.Author.Login).merge = append(get(pr.Author.Login).merge, pr.TimeToMerge.Hours())
+			get(pr.Repository + "@" + pr.Author.Login).merge = append(get(pr.Repository+"@"+pr.Author.Login).merge, pr.TimeToMerge.Hours())
+		}
+	}
+	for _, r := range data.Reviews {
+		if r.ResponseTime != nil {
+			get(r.Author.Login).review = append(get(r.Author.Login).review, r.ResponseTime.Hours())
+			get(r.Repository + "@" + r.Author.Login).review = append(get(r.Repository+"@"+r.Author.Login).review, r.ResponseTime.Hours())
+		}
+	}
+	checkMean := func(values []float64, got float64, msg string) {
+		if len(values) == 0 {
+			assert.Zero(t, got, msg)
+			return
+		}
+		var m mean
+		for _, v := range values {
+			m.add(v)
+		}
+		assert.InDelta(t, m.Value(), got, 0.0001, msg)
+		assert.GreaterOrEqual(t, got, slices.Min(values), msg)
+		assert.LessOrEqual(t, got, slices.Max(values), msg)
+	}
+
+	require.Len(t, metrics.Contributors, 2)
+	for _, c := range metrics.Contributors {
+		d := get(c.Login)
+		checkMean(d.merge, c.AvgTimeToMerge, c.Login+" time to merge")
+		checkMean(d.review, c.AvgReviewTime, c.Login+" review time")
+
+		// Weighted by the durations counted in each repository
+		var merge, review mean
+		for _, rm := range metrics.Repositories {
+			for _, rc := range rm.Contributors {
+				if rc.Login != c.Login {
+					continue
+				}
+				rd := get(rm.FullName + "@" + rc.Login)
+				checkMean(rd.merge, rc.AvgTimeToMerge, rm.FullName+" "+rc.Login+" time to merge")
+				checkMean(rd.review, rc.AvgReviewTime, rm.FullName+" "+rc.Login+" review time")
+				merge.Sum += rc.AvgTimeToMerge * float64(len(rd.merge))
+				merge.Count += len(rd.merge)
+				review.Sum += rc.AvgReviewTime * float64(len(rd.review))
+				review.Count += len(rd.review)
+			}
+		}
+		assert.InDelta(t, c.AvgTimeToMerge, merge.Value(), 0.0001, c.Login)
+		assert.InDelta(t, c.AvgReviewTime, review.Value(), 0.0001, c.Login)
+	}
+}
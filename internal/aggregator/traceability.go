@@ -0,0 +1,58 @@
+package aggregator
+
+import "github.com/lukaszraczylo/git-velocity/pkg/models"
+
+// applyTraceability counts the merged PRs linking an issue (#123) in their
+// title or body, and their percentage of the PRs merged, per contributor and
+// per repository. Runs after the PR loop has counted PRsMerged.
+func (a *Aggregator) applyTraceability(
+	data *models.RawData,
+	contributorMap map[string]*models.ContributorMetrics,
+	repoContributorMap map[string]map[string]*models.ContributorMetrics,
+	repoMap map[string]*models.RepositoryMetrics,
+) {
+	repoMerged := make(map[string]int)
+	for _, pr := range data.PullRequests {
+		rm, ok := repoMap[pr.Repository]
+		if !ok || !pr.IsMerged() {
+			continue
+		}
+		repoMerged[pr.Repository]++
+		if !linksIssue(pr) {
+			continue
+		}
+		rm.LinkedPRs++
+		login := pr.Author.Login
+		if cm, ok := contributorMap[login]; ok {
+			cm.LinkedPRs++
+		}
+		if rcm, ok := repoContributorMap[pr.Repository][login]; ok {
+			rcm.LinkedPRs++
+		}
+	}
+
+	contributorTraceability := func(cm *models.ContributorMetrics) {
+		if cm.PRsMerged > 0 {
+			cm.IssueTraceability = float64(cm.LinkedPRs) / float64(cm.PRsMerged) * 100
+		}
+	}
+	for _, cm := range contributorMap {
+		contributorTraceability(cm)
+	}
+	for _, contributors := range repoContributorMap {
+		for _, rcm := range contributors {
+			contributorTraceability(rcm)
+		}
+	}
+
+	for repo, merged := range repoMerged {
+		rm := repoMap[repo]
+		rate := float64(rm.LinkedPRs) / float64(merged) * 100
+		rm.IssueTraceability = &rate
+	}
+}
+
+// linksIssue reports whether a PR references an issue in its title or body
+func linksIssue(pr models.PullRequest) bool {
+	return countIssueReferences(pr.Title) > 0 || countIssueReferences(pr.Body) > 0
+}
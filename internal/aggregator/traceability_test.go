@@ -0,0 +1,72 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_Traceability(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	merged := at.Add(2 * time.Hour)
+	pr := func(repo string, number int, author, title, body string, state models.PRState) models.PullRequest {
+		p := models.PullRequest{
+			Number: number, Title: title, Body: body, Author: models.Author{Login: author},
+			Repository: repo, CreatedAt: at, State: state,
+		}
+		if state == models.PRStateMerged {
+			p.MergedAt = &merged
+		}
+		return p
+	}
+
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{
+			pr("acme/api", 1, "alice", "Fix login (#12)", "", models.PRStateMerged),
+			pr("acme/api", 2, "alice", "Add rate limits", "Closes acme/tracker#7", models.PRStateMerged),
+			pr("acme/api", 3, "alice", "Bump deps", "Routine update", models.PRStateMerged),
+			pr("acme/api", 4, "bob", "Refactor", "See #3", models.PRStateOpen), // Not merged
+			pr("acme/api", 5, "bob", "Tidy up", "", models.PRStateMerged),
+			// acme/web: nothing merged
+			pr("acme/web", 1, "bob", "Fixes #4", "", models.PRStateClosed),
+		},
+	}
+	start := at.AddDate(0, 0, -1)
+	end := at.AddDate(0, 0, 7)
+
+	metrics, err := New(config.DefaultConfig()).Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	repos := make(map[string]models.RepositoryMetrics)
+	for _, rm := range metrics.Repositories {
+		repos[rm.FullName] = rm
+	}
+	api := repos["acme/api"]
+	assert.Equal(t, 2, api.LinkedPRs)
+	require.NotNil(t, api.IssueTraceability)
+	assert.InDelta(t, 50.0, *api.IssueTraceability, 0.001)
+	assert.Nil(t, repos["acme/web"].IssueTraceability, "no merged PRs")
+
+	for _, c := range metrics.Contributors {
+		switch c.Login {
+		case "alice":
+			assert.Equal(t, 2, c.LinkedPRs)
+			assert.InDelta(t, 200.0/3, c.IssueTraceability, 0.001)
+		case "bob":
+			assert.Zero(t, c.LinkedPRs)
+			assert.Zero(t, c.IssueTraceability)
+		}
+	}
+	for _, c := range api.Contributors {
+		if c.Login == "alice" {
+			assert.Equal(t, 2, c.LinkedPRs)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package aggregator
+
+import (
+	"math"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// rollingWindows are the lengths, in days, of the rolling averages; the
+// trend compares the first with the last
+var rollingWindows = []int{7, 30}
+
+// eventPoints approximates the points of single events, as the velocity
+// timeline does, without the aggregate bonuses of the full score
+type eventPoints struct {
+	commit, prOpened, prMerged, review float64
+	regular, evening, lateNight        float64
+	overnight, earlyMorning            float64
+}
+
+func newEventPoints(scoringConfig config.ScoringConfig) eventPoints {
+	orDefault := func(v, def float64) float64 {
+		if v == 0 {
+			return def
+		}
+		return v
+	}
+	points := scoringConfig.Points
+	return eventPoints{
+		commit:       orDefault(float64(points.Commit), 10),
+		prOpened:     orDefault(float64(points.PROpened), 25),
+		prMerged:     orDefault(float64(points.PRMerged), 50),
+		review:       orDefault(float64(points.PRReviewed), 30),
+		regular:      orDefault(points.MultiplierRegularHours, 1.0),
+		evening:      orDefault(points.MultiplierEvening, 2.0),
+		lateNight:    orDefault(points.MultiplierLateNight, 2.5),
+		overnight:    orDefault(points.MultiplierOvernight, 5.0),
+		earlyMorning: orDefault(points.MultiplierEarlyMorning, 2.0),
+	}
+}
+
+// commitAt returns the points of a commit made at the given hour
+func (p eventPoints) commitAt(hour int) float64 {
+	switch {
+	case hour >= 9 && hour < 17:
+		return p.commit * p.regular // Regular hours: 9am-5pm
+	case hour >= 17 && hour < 21:
+		return p.commit * p.evening // Evening: 5pm-9pm
+	case hour >= 21 && hour <= 23:
+		return p.commit * p.lateNight // Late night: 9pm-midnight
+	case hour >= 0 && hour < 6:
+		return p.commit * p.overnight // Overnight: midnight-6am
+	case hour >= 6 && hour < 9:
+		return p.commit * p.earlyMorning // Early morning: 6am-9am
+	default:
+		return p.commit * p.regular
+	}
+}
+
+// pullRequest returns the points of a pull request and the date it counts on:
+// merged PRs on their merge date, others on their creation date
+func (p eventPoints) pullRequest(pr *models.PullRequest) (time.Time, float64) {
+	if pr.MergedAt != nil {
+		return *pr.MergedAt, p.prMerged
+	}
+	return pr.CreatedAt, p.prOpened
+}
+
+// dayTotals holds the activity of one day
+type dayTotals struct {
+	commits, prs, score float64
+	reviews             int
+	reviewHours         float64 // Summed response times of the timed reviews
+	timedReviews        int
+}
+
+// activityLog collects daily activity per contributor and per repository
+type activityLog struct {
+	points       eventPoints
+	contributors map[string]map[string]*dayTotals // login -> YYYY-MM-DD -> totals
+	repos        map[string]map[string]*dayTotals // repo -> YYYY-MM-DD -> totals
+}
+
+func newActivityLog(scoringConfig config.ScoringConfig) *activityLog {
+	return &activityLog{
+		points:       newEventPoints(scoringConfig),
+		contributors: make(map[string]map[string]*dayTotals),
+		repos:        make(map[string]map[string]*dayTotals),
+	}
+}
+
+func (l *activityLog) add(login, repo string, date time.Time, commits, prs int, score float64) {
+	day := date.Format("2006-01-02")
+	for _, series := range []struct {
+		m   map[string]map[string]*dayTotals
+		key string
+	}{{l.contributors, login}, {l.repos, repo}} {
+		if series.key == "" {
+			continue
+		}
+		if series.m[series.key] == nil {
+			series.m[series.key] = make(map[string]*dayTotals)
+		}
+		totals := series.m[series.key][day]
+		if totals == nil {
+			totals = &dayTotals{}
+			series.m[series.key][day] = totals
+		}
+		totals.commits += float64(commits)
+		totals.prs += float64(prs)
+		totals.score += score
+	}
+}
+
+func (l *activityLog) commit(login string, commit *models.Commit) {
+	l.add(login, commit.Repository, commit.Date, 1, 0, l.points.commitAt(commit.Date.Hour()))
+}
+
+func (l *activityLog) pullRequest(login string, pr *models.PullRequest) {
+	date, score := l.points.pullRequest(pr)
+	l.add(login, pr.Repository, date, 0, 1, score)
+}
+
+func (l *activityLog) review(login string, review *models.Review) {
+	l.add(login, review.Repository, review.SubmittedAt, 0, 0, l.points.review)
+	// Review counts and times are kept for contributors' personal bests
+	totals := l.contributors[login][review.SubmittedAt.Format("2006-01-02")]
+	if totals == nil {
+		return
+	}
+	totals.reviews++
+	if review.ResponseTime != nil {
+		totals.reviewHours += review.ResponseTime.Hours()
+		totals.timedReviews++
+	}
+}
+
+// rollingAverages returns the per-day averages of each rolling window ending
+// on the last day of the period, and the trend between them
+func rollingAverages(days map[string]*dayTotals, end time.Time) ([]models.RollingAverage, *models.Trend) {
+	if len(days) == 0 {
+		return nil, nil
+	}
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+
+	averages := make([]models.RollingAverage, 0, len(rollingWindows))
+	for _, window := range rollingWindows {
+		var sum dayTotals
+		for i := range window {
+			if totals, ok := days[last.AddDate(0, 0, -i).Format("2006-01-02")]; ok {
+				sum.commits += totals.commits
+				sum.prs += totals.prs
+				sum.score += totals.score
+			}
+		}
+		n := float64(window)
+		averages = append(averages, models.RollingAverage{
+			WindowDays: window,
+			Commits:    round2(sum.commits / n),
+			PRs:        round2(sum.prs / n),
+			Score:      round2(sum.score / n),
+		})
+	}
+	return averages, models.NewTrend(averages)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,141 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestAggregator_RollingAverages(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Points = config.PointsConfig{Commit: 10, PROpened: 25, PRMerged: 50, PRReviewed: 30}
+	agg := New(cfg)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+	at := func(day int) time.Time { return time.Date(2024, 1, day, 10, 0, 0, 0, time.UTC) }
+	merged := at(30)
+
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: at(31), Repository: "owner/api"},
+			{SHA: "b", Author: models.Author{Login: "alice"}, Date: at(29), Repository: "owner/api"},
+			{SHA: "c", Author: models.Author{Login: "bob"}, Date: at(5), Repository: "owner/web"},
+			{SHA: "d", Author: models.Author{Login: "bob"}, Date: at(28), Repository: "owner/web"},
+		},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "alice"}, CreatedAt: at(3), MergedAt: &merged, Repository: "owner/api"},
+		},
+		Reviews: []models.Review{
+			{Author: models.Author{Login: "bob"}, SubmittedAt: at(2), Repository: "owner/api"},
+		},
+	}
+
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	byLogin := make(map[string]models.ContributorMetrics)
+	for _, cm := range metrics.Contributors {
+		byLogin[cm.Login] = cm
+	}
+
+	// alice: 2 commits and a PR merged within the last 7 days
+	alice := byLogin["alice"]
+	assert.Equal(t, []models.RollingAverage{
+		{WindowDays: 7, Commits: 0.29, PRs: 0.14, Score: 10},
+		{WindowDays: 30, Commits: 0.07, PRs: 0.03, Score: 2.33},
+	}, alice.Rolling)
+	require.NotNil(t, alice.Trend)
+	assert.Equal(t, models.TrendUp, alice.Trend.Direction)
+
+	// bob: only the commit on Jan 28 falls in the 7-day window
+	bob := byLogin["bob"]
+	assert.Equal(t, 1.43, bob.Rolling[0].Score)
+	assert.Equal(t, 1.67, bob.Rolling[1].Score)
+	assert.Equal(t, models.TrendDown, bob.Trend.Direction)
+
+	for _, repo := range metrics.Repositories {
+		require.NotNil(t, repo.Trend, repo.FullName)
+		if repo.FullName == "owner/api" {
+			assert.Equal(t, 0.29, repo.Rolling[0].Commits)
+		}
+	}
+}
+
+func TestAggregator_Forecast(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Forecast.Enabled = true
+	cfg.Teams = []config.TeamConfig{{Name: "Core", Members: []string{"alice"}}}
+	agg := New(cfg)
+
+	// Wednesday to Saturday: the first and last weeks are partial
+	start := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 10, 23, 59, 59, 0, time.UTC)
+
+	data := &models.RawData{}
+	commit := func(date time.Time) {
+		data.Commits = append(data.Commits, models.Commit{
+			SHA:        date.Format(time.RFC3339Nano),
+			Author:     models.Author{Login: "alice"},
+			Date:       date,
+			Repository: "owner/api",
+		})
+	}
+	commit(time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC))
+	// 1, 2, 3 and 4 commits in the complete weeks starting Jan 8
+	for week := range 4 {
+		for i := 0; i <= week; i++ {
+			commit(time.Date(2024, 1, 8+7*week, 10, i, 0, 0, time.UTC))
+		}
+	}
+
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+
+	expected := []float64{5, 6, 7, 8}
+	forecastCommits := func(f *models.Forecast) []float64 {
+		require.NotNil(t, f)
+		assert.Equal(t, []string{"Feb 5", "Feb 12", "Feb 19", "Feb 26"}, f.Labels)
+		for _, s := range f.Series {
+			if s.Name == "Commits" {
+				return s.Data
+			}
+		}
+		return nil
+	}
+
+	require.NotNil(t, metrics.VelocityTimeline)
+	assert.Equal(t, expected, forecastCommits(metrics.VelocityTimeline.Forecast))
+	require.Len(t, metrics.Repositories, 1)
+	assert.Equal(t, expected, forecastCommits(metrics.Repositories[0].Forecast))
+	require.Len(t, metrics.Teams, 1)
+	assert.Equal(t, expected, forecastCommits(metrics.Teams[0].Forecast))
+}
+
+func TestAggregator_ForecastDisabled(t *testing.T) {
+	t.Parallel()
+
+	agg := New(config.DefaultConfig())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+	data := &models.RawData{
+		Commits: []models.Commit{
+			{SHA: "a", Author: models.Author{Login: "alice"}, Date: start.AddDate(0, 0, 10), Repository: "owner/api"},
+		},
+	}
+
+	metrics, err := agg.Aggregate(data, &config.ParsedDateRange{Start: &start, End: &end})
+	require.NoError(t, err)
+	require.NotNil(t, metrics.VelocityTimeline)
+	assert.Nil(t, metrics.VelocityTimeline.Forecast)
+	assert.Nil(t, metrics.Repositories[0].Forecast)
+}
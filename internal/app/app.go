@@ -2,59 +2,302 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/lukaszraczylo/git-velocity/internal/aggregator"
+	"github.com/lukaszraczylo/git-velocity/internal/archive"
 	"github.com/lukaszraczylo/git-velocity/internal/config"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
+	"github.com/lukaszraczylo/git-velocity/internal/coverage"
 	"github.com/lukaszraczylo/git-velocity/internal/domain/scoring"
 	"github.com/lukaszraczylo/git-velocity/internal/generator/site"
 	"github.com/lukaszraczylo/git-velocity/internal/git"
 	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/internal/hooks"
+	"github.com/lukaszraczylo/git-velocity/internal/httpx"
+	"github.com/lukaszraczylo/git-velocity/internal/linear"
+	"github.com/lukaszraczylo/git-velocity/internal/lint"
+	"github.com/lukaszraczylo/git-velocity/internal/manifest"
+	"github.com/lukaszraczylo/git-velocity/internal/pathfilter"
+	"github.com/lukaszraczylo/git-velocity/internal/redact"
+	"github.com/lukaszraczylo/git-velocity/internal/snapshot"
+	"github.com/lukaszraczylo/git-velocity/internal/telemetry"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
 // App is the main application orchestrator
 type App struct {
-	config    *config.Config
-	outputDir string
-	verbose   bool
-	client    *github.Client
-	gitRepo   *git.Repository
+	config     *config.Config
+	configPath string
+	outputDir  string
+	verbose    bool
+	client     DataSource
+	gitRepo    *git.Repository
+
+	// Replace the GitHub API transport and the clone remote, so that tests
+	// run the whole pipeline against recorded fixtures
+	transport  http.RoundTripper
+	remoteBase string
+
+	// How each repository was fetched, for the run report
+	fetches []models.RepositoryFetch
+
+	// Repositories narrowed to paths, whose commits count only part of their
+	// lines, and the line verification of analyze --verify
+	scoped       map[string]bool
+	verification *models.LineVerification
 }
 
-// New creates a new application instance
+// New creates a new application instance. An empty outputDir falls back to
+// output.directory from the config.
 func New(configPath, outputDir string, verbose bool) (*App, error) {
 	// Load configuration
 	cfg, err := config.Load(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
+	if outputDir == "" {
+		outputDir = cfg.Output.Directory
+	}
+
+	return &App{
+		config:     cfg,
+		configPath: configPath,
+		outputDir:  outputDir,
+		verbose:    verbose,
+	}, nil
+}
+
+// NewOffline creates an application instance that rebuilds metrics from the
+// cached raw data snapshot without any network access
+func NewOffline(configPath, outputDir string, verbose bool) (*App, error) {
+	cfg, err := config.LoadOffline(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if outputDir == "" {
+		outputDir = cfg.Output.Directory
+	}
 
 	return &App{
-		config:    cfg,
-		outputDir: outputDir,
-		verbose:   verbose,
+		config:     cfg,
+		configPath: configPath,
+		outputDir:  outputDir,
+		verbose:    verbose,
 	}, nil
 }
 
+// EnableVerification compares line counts with GitHub's commit stats
+// (analyze --verify), on top of options.verify in the config
+func (a *App) EnableVerification() {
+	a.config.Options.Verify.Enabled = true
+}
+
+// telemetryFlushTimeout bounds exporting the remaining spans when a run ends
+const telemetryFlushTimeout = 10 * time.Second
+
 // Run executes the main application workflow
-func (a *App) Run(ctx context.Context) error {
+func (a *App) Run(ctx context.Context) (err error) {
 	startTime := time.Now()
+
+	shutdown, err := telemetry.Setup(ctx, a.config.Telemetry)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	defer func() {
+		// Flush spans even when the run failed, without hanging on an unreachable collector
+		flushCtx, cancel := context.WithTimeout(context.Background(), telemetryFlushTimeout)
+		defer cancel()
+		if err := shutdown(flushCtx); err != nil {
+			a.log("Warning: failed to export traces: %v", err)
+		}
+	}()
+
+	ctx, span := telemetry.Start(ctx, "analyze", attribute.Bool("offline", a.config.Options.Offline))
+	defer func() { telemetry.End(span, err) }()
+
 	a.log("Starting Git Velocity analysis...")
 
-	// Initialize GitHub client
-	a.log("Initializing GitHub client...")
-	client, err := github.NewClient(ctx, a.config)
+	if command := a.config.Hooks.PreAnalyze; command != "" {
+		dateRange, err := a.config.GetParsedDateRange()
+		if err != nil {
+			return fmt.Errorf("failed to parse date range: %w", err)
+		}
+		if err := a.runHook(ctx, hooks.PreAnalyze, command, dateRange); err != nil {
+			return err
+		}
+	}
+
+	var snap *snapshot.Snapshot
+	if a.config.Options.Offline {
+		if a.config.Options.Verify.Enabled {
+			a.log("Warning: line verification needs the GitHub API, skipping it offline")
+		}
+		a.log("Offline mode: loading raw data snapshot from %s...", a.config.Cache.Directory)
+		snap, err = snapshot.Load(a.config.Cache.Directory)
+		if err != nil {
+			return fmt.Errorf("failed to load raw data snapshot: %w", err)
+		}
+		a.log("Loaded %d commits, %d PRs, %d reviews, %d issues (collected %s)",
+			len(snap.Data.Commits), len(snap.Data.PullRequests), len(snap.Data.Reviews), len(snap.Data.Issues),
+			snap.CreatedAt.Format("2006-01-02 15:04"))
+	} else {
+		snap, err = a.fetch(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Aggregate metrics
+	a.log("Aggregating metrics...")
+	_, aggSpan := telemetry.Start(ctx, "aggregate")
+	globalMetrics, bots, err := a.aggregate(snap, a.config)
+	telemetry.End(aggSpan, err)
 	if err != nil {
-		return fmt.Errorf("failed to create GitHub client: %w", err)
+		return err
+	}
+	if len(bots) > 0 {
+		a.log("Filtered %d bot identities (listed in data/bots.json)", len(bots))
+	}
+
+	// Calculate scores
+	if a.config.Scoring.Enabled {
+		a.log("Calculating scores and achievements...")
+		_, scoreSpan := telemetry.Start(ctx, "score")
+		scorer := scoring.NewCalculator(a.config)
+		globalMetrics = scorer.Calculate(globalMetrics)
+		telemetry.End(scoreSpan, nil)
+	}
+
+	// Generate the site
+	a.log("Generating static site...")
+	gen, err := site.NewGenerator(a.outputDir, a.config)
+	if err != nil {
+		return fmt.Errorf("failed to create site generator: %w", err)
+	}
+
+	// Read before the generator replaces it
+	previous := a.previousRun()
+
+	run := a.runReport(startTime)
+	gen.SetRunReport(run)
+	gen.SetBots(bots)
+	avatars, err := a.avatarSource(ctx)
+	if err != nil {
+		return err
+	}
+	gen.SetAvatarSource(avatars)
+
+	_, genSpan := telemetry.Start(ctx, "generate")
+	err = gen.Generate(globalMetrics)
+	telemetry.End(genSpan, err)
+	if err != nil {
+		return fmt.Errorf("failed to generate site: %w", err)
+	}
+
+	if err := a.exportWarehouses(ctx, globalMetrics, startTime); err != nil {
+		return err
+	}
+	if err := a.publishEvents(ctx, previous, globalMetrics, startTime); err != nil {
+		return err
+	}
+
+	if command := a.config.Hooks.PostGenerate; command != "" {
+		if err := a.runHook(ctx, hooks.PostGenerate, command, snap.DateRange()); err != nil {
+			return err
+		}
+	}
+
+	duration := time.Since(startTime)
+	a.log("Analysis complete! Dashboard generated in %s", a.outputDir)
+	a.log("Total time: %s", duration.Round(time.Millisecond))
+
+	// Surface API usage and degradation last so they are not lost in the progress output
+	if run.API != nil {
+		for _, line := range github.UsageLines(run.API) {
+			a.log("%s", line)
+		}
+		for _, line := range a.client.ResilienceReport().Lines() {
+			a.log("%s", line)
+		}
+	}
+	if run.Verification != nil {
+		for _, line := range verificationLines(run.Verification) {
+			a.log("%s", line)
+		}
+	}
+	for _, f := range run.Repositories {
+		if f.PullRequests == models.FetchPartial || f.PullRequests == models.FetchRESTFallback {
+			a.log("Pull requests of %s fell back to REST (%s)", f.Repository, f.PullRequests)
+		}
+		if f.Issues == models.FetchPartial || f.Issues == models.FetchRESTFallback {
+			a.log("Issues of %s fell back to REST (%s)", f.Repository, f.Issues)
+		}
+	}
+
+	return nil
+}
+
+// runReport describes how this run collected its data
+func (a *App) runReport(startTime time.Time) *models.RunReport {
+	run := &models.RunReport{
+		StartedAt: startTime.UTC(),
+		Duration:  time.Since(startTime).Seconds(),
+		Offline:   a.config.Options.Offline,
+
+		Repositories: a.fetches,
+		Verification: a.verification,
+	}
+	if a.client != nil {
+		usage := a.client.APIUsage()
+		run.API = &usage
+	}
+	return run
+}
+
+// fetch collects all raw data from GitHub and the local clones
+func (a *App) fetch(ctx context.Context) (_ *snapshot.Snapshot, err error) {
+	ctx, span := telemetry.Start(ctx, "fetch")
+	defer func() { telemetry.End(span, err) }()
+
+	if a.config.Network.InsecureSkipVerify {
+		a.log("Warning: TLS certificate verification is disabled (network.insecure_skip_verify); trust your proxy's CA with network.ca_bundle instead")
+	}
+
+	// Initialize GitHub client (set beforehand by tests)
+	if a.client == nil && a.config.Source.Exported() {
+		source, err := a.openExport()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := source.Close(); err != nil {
+				a.log("Warning: failed to remove the extracted migration export: %v", err)
+			}
+		}()
+		a.client = source
+	}
+	if a.client == nil {
+		a.log("Initializing GitHub client...")
+		client, err := github.NewClientWithTransport(ctx, a.config, a.transport)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+		}
+		a.client = client
 	}
-	a.client = client
 
 	// Set up progress callback
-	client.SetProgressCallback(func(msg string) {
+	a.client.SetProgressCallback(func(msg string) {
 		a.log("%s", msg)
 	})
 
@@ -62,77 +305,211 @@ func (a *App) Run(ctx context.Context) error {
 	a.log("Initializing local git repository manager...")
 	gitRepo, err := git.NewRepository(a.config.Options.CloneDirectory)
 	if err != nil {
-		return fmt.Errorf("failed to create git repository manager: %w", err)
+		return nil, fmt.Errorf("failed to create git repository manager: %w", err)
 	}
 	gitRepo.SetProgressCallback(func(msg string) {
 		a.log("%s", msg)
 	})
+	if network := a.config.Network; network.CABundle != "" || network.InsecureSkipVerify {
+		var bundle []byte
+		if network.CABundle != "" {
+			if bundle, err = httpx.ReadCABundle(network.CABundle); err != nil {
+				return nil, err
+			}
+		}
+		gitRepo.SetTLS(bundle, network.InsecureSkipVerify)
+	}
+	if a.config.Options.CloneProtocol == config.CloneSSH {
+		ssh := a.config.Options.SSH
+		if err := gitRepo.UseSSH(git.SSHOptions{
+			User:       ssh.User,
+			KeyPath:    ssh.KeyPath,
+			Passphrase: ssh.KeyPassphrase,
+			KnownHosts: ssh.KnownHosts,
+		}); err != nil {
+			return nil, err
+		}
+		a.log("Cloning over SSH")
+	}
+	gitRepo.SetCommitBranches(a.config.Options.CommitBranches)
+	if a.remoteBase != "" {
+		gitRepo.SetRemoteBase(a.remoteBase)
+	}
+	if systemGit := a.config.Options.SystemGit; systemGit.Enabled {
+		if err := gitRepo.UseSystemGit(systemGit.Path, systemGit.MinSizeMB); err != nil {
+			a.log("Warning: %v; reading all commits with go-git", err)
+		} else {
+			a.log("Reading commits of clones from %d MB with the system git binary", systemGit.MinSizeMB)
+		}
+	}
 	a.gitRepo = gitRepo
 
 	// Parse date range
 	dateRange, err := a.config.GetParsedDateRange()
 	if err != nil {
-		return fmt.Errorf("failed to parse date range: %w", err)
+		return nil, fmt.Errorf("failed to parse date range: %w", err)
 	}
 
 	// Collect data from all repositories
 	a.log("Fetching data from repositories...")
 	rawData, err := a.collectData(ctx, dateRange)
 	if err != nil {
-		return fmt.Errorf("failed to collect data: %w", err)
+		return nil, fmt.Errorf("failed to collect data: %w", err)
 	}
 
 	a.log("Collected %d commits, %d PRs, %d reviews, %d issues",
 		len(rawData.Commits), len(rawData.PullRequests), len(rawData.Reviews), len(rawData.Issues))
 
+	// Look up Linear issue states for closed-issue credit (optional)
+	if a.config.Integrations.Linear.Enabled && a.config.Integrations.Linear.APIKey != "" {
+		a.log("Fetching Linear issue states...")
+		linearCtx, linearSpan := telemetry.Start(ctx, "fetch_linear_issues")
+		err := a.fetchLinearIssues(linearCtx, rawData)
+		telemetry.End(linearSpan, err)
+		if err != nil {
+			a.log("Warning: failed to fetch Linear issues: %v", err)
+			// Continue anyway, references are still counted without state
+		}
+	}
+
+	// Look up the coverage of merge commits for coverage deltas (optional)
+	if a.config.HasCoverage() {
+		a.log("Fetching coverage of merged PRs...")
+		coverageCtx, coverageSpan := telemetry.Start(ctx, "fetch_coverage")
+		err := a.fetchCoverage(coverageCtx, rawData)
+		telemetry.End(coverageSpan, err)
+		if err != nil {
+			a.log("Warning: failed to fetch coverage: %v", err)
+			// Continue anyway, PRs without coverage get no delta
+		}
+	}
+
+	// Annotate PRs with effort estimates from the effort hook (optional)
+	if a.config.Hooks.Effort != "" {
+		a.log("Estimating PR effort...")
+		effortCtx, effortSpan := telemetry.Start(ctx, "estimate_effort")
+		err := a.estimateEffort(effortCtx, rawData)
+		telemetry.End(effortSpan, err)
+		if err != nil {
+			a.log("Warning: failed to estimate PR effort: %v", err)
+			// Continue anyway, PRs without estimates add no effort
+		}
+	}
+
+	// Enrich repository health with organization audit-log events (optional)
+	if a.config.Integrations.AuditLog.Enabled {
+		a.log("Fetching organization audit log...")
+		auditCtx, auditSpan := telemetry.Start(ctx, "fetch_audit_log")
+		err := a.fetchAuditLog(auditCtx, dateRange, rawData)
+		telemetry.End(auditSpan, err)
+		if err != nil {
+			a.log("Warning: failed to fetch audit log (requires GitHub Enterprise Cloud and the read:audit_log scope): %v", err)
+			// Continue anyway, repository health is reported without audit events
+		}
+	}
+
+	// Contributors opting out of the leaderboards in their profile repository (optional)
+	if a.config.Options.ProfileOptOut {
+		a.log("Checking profile repositories for opt-outs...")
+		optOutCtx, optOutSpan := telemetry.Start(ctx, "fetch_profile_opt_outs")
+		err := a.fetchProfileOptOuts(optOutCtx, rawData)
+		telemetry.End(optOutSpan, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check profile opt-outs: %w", err)
+		}
+	}
+
+	// Name contributors after their SAML SSO identities (optional, Enterprise Cloud)
+	if a.config.Integrations.SAMLIdentities.Enabled {
+		a.log("Fetching SAML identities...")
+		samlCtx, samlSpan := telemetry.Start(ctx, "fetch_saml_identities")
+		err := a.fetchExternalIdentities(samlCtx, rawData)
+		telemetry.End(samlSpan, err)
+		if err != nil {
+			a.log("Warning: failed to fetch SAML identities (requires GitHub Enterprise Cloud with SAML SSO and an organization owner's admin:org token): %v", err)
+			// Continue anyway, contributors keep their GitHub names
+		}
+	}
+
+	// Cross-check contributors against the organization members (optional)
+	if a.config.Options.OrgMembers.Enabled {
+		a.log("Fetching organization members...")
+		membersCtx, membersSpan := telemetry.Start(ctx, "fetch_org_members")
+		err := a.fetchOrgMembers(membersCtx, rawData)
+		telemetry.End(membersSpan, err)
+		if err != nil {
+			a.log("Warning: failed to fetch organization members: %v", err)
+			// Continue anyway, contributors are reported without the external flag
+		}
+	}
+
+	// Compare line counts with GitHub's commit stats (optional)
+	if a.config.Options.Verify.Enabled {
+		if a.config.Source.Exported() {
+			a.log("Skipping line verification: exports have no commit stats to compare against")
+		} else {
+			a.log("Verifying line counts against GitHub...")
+			verifyCtx, verifySpan := telemetry.Start(ctx, "verify_lines")
+			verification, err := a.verifyLines(verifyCtx, rawData.Commits)
+			telemetry.End(verifySpan, err)
+			if err != nil {
+				a.log("Warning: failed to verify line counts: %v", err)
+				// Continue anyway, verification only reports on the data
+			}
+			a.verification = verification
+		}
+	}
+
 	// Fetch user profiles for better deduplication
 	// This gets public emails and names from GitHub profiles to help match commit authors
 	a.log("Fetching user profiles for deduplication...")
-	userProfiles, err := a.fetchUserProfiles(ctx, rawData)
+	profileCtx, profileSpan := telemetry.Start(ctx, "fetch_user_profiles")
+	userProfiles, err := a.fetchUserProfiles(profileCtx, rawData)
+	telemetry.End(profileSpan, err)
 	if err != nil {
 		a.log("Warning: failed to fetch some user profiles: %v", err)
 		// Continue anyway, deduplication will still work with other methods
 	}
 	a.log("Fetched %d user profiles", len(userProfiles))
 
-	// Aggregate metrics
-	a.log("Aggregating metrics...")
-	agg := aggregator.New(a.config)
-	agg.SetUserProfiles(userProfiles)
-	globalMetrics, err := agg.Aggregate(rawData, dateRange)
-	if err != nil {
-		return fmt.Errorf("failed to aggregate metrics: %w", err)
+	if err := a.client.SaveCacheStats(); err != nil {
+		a.log("Warning: failed to save cache statistics: %v", err)
 	}
 
-	// Calculate scores
-	if a.config.Scoring.Enabled {
-		a.log("Calculating scores and achievements...")
-		scorer := scoring.NewCalculator(a.config)
-		globalMetrics = scorer.Calculate(globalMetrics)
-	}
+	snap := snapshot.New(rawData, userProfiles, dateRange)
 
-	// Generate the site
-	a.log("Generating static site...")
-	gen, err := site.NewGenerator(a.outputDir, a.config)
-	if err != nil {
-		return fmt.Errorf("failed to create site generator: %w", err)
-	}
-
-	if err := gen.Generate(globalMetrics); err != nil {
-		return fmt.Errorf("failed to generate site: %w", err)
+	// Keep a raw data snapshot so metrics can be rebuilt without refetching
+	if a.config.Cache.Enabled {
+		if err := snapshot.Save(a.config.Cache.Directory, snap); err != nil {
+			a.log("Warning: failed to save raw data snapshot: %v", err)
+		}
 	}
 
-	duration := time.Since(startTime)
-	a.log("Analysis complete! Dashboard generated in %s", a.outputDir)
-	a.log("Total time: %s", duration.Round(time.Millisecond))
+	return snap, nil
+}
 
-	return nil
+// aggregate builds metrics from snapshot data with the given configuration
+// aggregate computes metrics from a snapshot, returning the identities that
+// were filtered as bots along with them
+func (a *App) aggregate(snap *snapshot.Snapshot, cfg *config.Config) (*models.GlobalMetrics, []models.BotActivity, error) {
+	agg := aggregator.New(cfg)
+	agg.SetUserProfiles(snap.UserProfiles)
+	metrics, err := agg.Aggregate(snap.Data, snap.DateRange())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to aggregate metrics: %w", err)
+	}
+	return metrics, agg.Bots(), nil
 }
 
 func (a *App) collectData(ctx context.Context, dateRange *config.ParsedDateRange) (*models.RawData, error) {
 	data := &models.RawData{}
 
 	for _, repo := range a.config.Repositories {
+		scope, err := pathfilter.New(repo.Paths)
+		if err != nil {
+			return nil, fmt.Errorf("invalid paths for %s: %w", repo.Owner, err)
+		}
+
 		if repo.Pattern != "" {
 			// Pattern-based repository selection (e.g., "org/*")
 			repos, err := a.client.ListOrgRepos(ctx, repo.Owner, repo.Pattern)
@@ -141,14 +518,14 @@ func (a *App) collectData(ctx context.Context, dateRange *config.ParsedDateRange
 			}
 
 			for _, r := range repos {
-				if err := a.collectRepoData(ctx, repo.Owner, r, dateRange, data); err != nil {
+				if err := a.collectRepoData(ctx, repo.Owner, r, scope, dateRange, data); err != nil {
 					a.log("Warning: failed to collect data for %s/%s: %v", repo.Owner, r, err)
 					// Continue with other repos
 				}
 			}
 		} else {
 			// Single repository
-			if err := a.collectRepoData(ctx, repo.Owner, repo.Name, dateRange, data); err != nil {
+			if err := a.collectRepoData(ctx, repo.Owner, repo.Name, scope, dateRange, data); err != nil {
 				return nil, fmt.Errorf("failed to collect data for %s/%s: %w", repo.Owner, repo.Name, err)
 			}
 		}
@@ -157,128 +534,410 @@ func (a *App) collectData(ctx context.Context, dateRange *config.ParsedDateRange
 	return data, nil
 }
 
-func (a *App) collectRepoData(ctx context.Context, owner, name string, dateRange *config.ParsedDateRange, data *models.RawData) error {
+func (a *App) collectRepoData(ctx context.Context, owner, name string, scope *pathfilter.Filter, dateRange *config.ParsedDateRange, data *models.RawData) (err error) {
 	repoName := fmt.Sprintf("%s/%s", owner, name)
+	ctx, span := telemetry.Start(ctx, "collect_repo", attribute.String("repository", repoName), attribute.Bool("scoped", scope != nil))
+	defer func() { telemetry.End(span, err) }()
+
 	a.log("  Fetching data from %s...", repoName)
+	if scope != nil {
+		if a.scoped == nil {
+			a.scoped = make(map[string]bool)
+		}
+		a.scoped[repoName] = true
+	}
 
 	// Clone/update repository locally (required for accurate commit data)
-	token := a.config.Auth.GithubToken
-
-	// Determine clone options (shallow clone if enabled)
-	var cloneOpts *git.CloneOptions
-	if a.config.Options.ShallowClone && dateRange.Start != nil {
-		// Get commit count since start date to determine shallow clone depth
-		commitCount, countErr := a.client.GetCommitCountSince(ctx, owner, name, *dateRange.Start)
-		if countErr != nil {
-			a.log("    Warning: failed to get commit count for shallow clone: %v", countErr)
-			// Proceed with full clone
-		} else if commitCount > 0 {
-			// Add buffer for safety margin
-			depth := commitCount + a.config.Options.ShallowCloneBuffer
-			cloneOpts = &git.CloneOptions{Depth: depth}
-			a.log("    Using shallow clone (depth: %d = %d commits + %d buffer)", depth, commitCount, a.config.Options.ShallowCloneBuffer)
-		}
+	var token string
+	if tokens := a.config.GithubTokens(); len(tokens) > 0 {
+		token = tokens[0]
 	}
 
-	if err := a.gitRepo.EnsureClonedWithOptions(ctx, owner, name, token, cloneOpts); err != nil {
+	cloneOpts := a.cloneOptions(ctx, owner, name, dateRange)
+	cloneCtx, cloneSpan := telemetry.Start(ctx, "clone", attribute.Bool("shallow", cloneOpts != nil))
+	err = a.gitRepo.EnsureClonedWithOptions(cloneCtx, owner, name, token, cloneOpts)
+	telemetry.End(cloneSpan, err)
+	if err != nil {
 		return fmt.Errorf("failed to clone repository %s: %w", repoName, err)
 	}
 
 	// Fetch commits from local git clone
-	commits, err := a.gitRepo.FetchCommits(ctx, owner, name, dateRange.Start, dateRange.End)
+	commitCtx, commitSpan := telemetry.Start(ctx, "fetch_commits")
+	commits, err := a.gitRepo.FetchCommits(commitCtx, owner, name, dateRange.Start, dateRange.End, scope)
+	commitSpan.SetAttributes(attribute.Int("commits", len(commits)))
+	telemetry.End(commitSpan, err)
 	if err != nil {
 		return fmt.Errorf("failed to fetch commits: %w", err)
 	}
 
-	// Filter out bots
-	for _, c := range commits {
-		if !a.config.IsBot(c.Author.Login) {
-			data.Commits = append(data.Commits, c)
+	// Bots are kept in the raw data and filtered when aggregating, where
+	// commit authors are resolved to logins and the matches are audited
+	data.Commits = append(data.Commits, commits...)
+
+	// Fetch pull requests and reviews
+	prCtx, prSpan := telemetry.Start(ctx, "fetch_pull_requests")
+	err = a.collectPullRequests(prCtx, owner, name, scope, dateRange, data)
+	telemetry.End(prSpan, err)
+	if err != nil {
+		return err
+	}
+
+	// Fetch issues and comments
+	issueCtx, issueSpan := telemetry.Start(ctx, "fetch_issues")
+	err = a.collectIssues(issueCtx, owner, name, dateRange, data)
+	telemetry.End(issueSpan, err)
+	if err != nil {
+		return err
+	}
+
+	// Fetch the kudos reactions on PRs, reviews and comments (optional)
+	if a.config.Options.Reactions {
+		reactionCtx, reactionSpan := telemetry.Start(ctx, "fetch_reactions")
+		a.collectReactions(reactionCtx, owner, name, data)
+		telemetry.End(reactionSpan, nil)
+	}
+
+	// Fetch the inline review comments for the tone of reviews (optional)
+	if a.config.Options.ReviewTone.Enabled {
+		commentCtx, commentSpan := telemetry.Start(ctx, "fetch_review_comments")
+		commentErr := a.collectReviewComments(commentCtx, owner, name, dateRange, data)
+		telemetry.End(commentSpan, commentErr)
+		if commentErr != nil {
+			a.log("    Warning: failed to fetch review comments: %v", commentErr)
+			// Continue anyway, the tone is summarized from the review bodies alone
 		}
 	}
 
-	// Fetch pull requests and reviews
-	// Use GraphQL if available (much fewer API calls), otherwise fall back to REST
-	if a.client.HasGraphQL() {
-		prs, reviews, err := a.client.FetchPRsWithReviewsGraphQL(ctx, owner, name, dateRange.Start, dateRange.End)
-		if err != nil {
-			a.log("    Warning: GraphQL fetch failed, falling back to REST: %v", err)
-			// Fall back to REST
-			prs, reviews, err = a.fetchPRsAndReviewsREST(ctx, owner, name, dateRange, data)
-			if err != nil {
-				return err
-			}
+	// Collect lint findings at the period boundaries (optional)
+	if lintCfg := a.config.LintFor(owner, name); lintCfg != nil {
+		lintCtx, lintSpan := telemetry.Start(ctx, "collect_lint")
+		lintErr := a.collectLint(lintCtx, owner, name, lintCfg, dateRange, data)
+		telemetry.End(lintSpan, lintErr)
+		if lintErr != nil {
+			a.log("    Warning: failed to collect lint findings: %v", lintErr)
+			// Continue anyway, the repository is reported without lint debt
+		}
+	}
+
+	// Read the packages published and required for the dependency graph (optional)
+	if a.config.Options.Dependencies {
+		_, manifestSpan := telemetry.Start(ctx, "read_manifests")
+		manifestErr := a.collectManifest(owner, name, data)
+		telemetry.End(manifestSpan, manifestErr)
+		if manifestErr != nil {
+			a.log("    Warning: failed to read dependency manifests: %v", manifestErr)
+			// Continue anyway, the repository is left out of the dependency graph
 		}
+	}
 
-		// Filter out bots
-		for _, pr := range prs {
-			if !a.config.IsBot(pr.Author.Login) {
-				data.PullRequests = append(data.PullRequests, pr)
+	// Read the default branch history to find direct pushes (optional)
+	if a.config.Options.DirectPushes {
+		_, mainlineSpan := telemetry.Start(ctx, "read_mainline")
+		mainlineErr := a.collectMainline(owner, name, dateRange, data)
+		telemetry.End(mainlineSpan, mainlineErr)
+		if mainlineErr != nil {
+			a.log("    Warning: failed to read the default branch history: %v", mainlineErr)
+			// Continue anyway, no direct pushes are reported for the repository
+		}
+	}
+
+	// Fetch repository settings for health checks
+	settingsCtx, settingsSpan := telemetry.Start(ctx, "fetch_repository_settings")
+	settings, settingsErr := a.client.FetchRepositorySettings(settingsCtx, owner, name)
+	telemetry.End(settingsSpan, settingsErr)
+	if settingsErr != nil {
+		a.log("    Warning: failed to fetch repository settings: %v", settingsErr)
+		// Continue anyway, the repository is reported without health checks
+	} else {
+		if data.RepositorySettings == nil {
+			data.RepositorySettings = make(map[string]models.RepositorySettings)
+		}
+		data.RepositorySettings[repoName] = settings
+	}
+
+	// Fetch stars and forks over time for the adoption chart (optional)
+	if a.config.Options.Adoption.Enabled {
+		var since time.Time
+		if dateRange.Start != nil {
+			since = *dateRange.Start
+		}
+		adoptionCtx, adoptionSpan := telemetry.Start(ctx, "fetch_adoption")
+		adoption, adoptionErr := a.client.FetchAdoption(adoptionCtx, owner, name, since, a.config.Options.Adoption.MaxPages)
+		telemetry.End(adoptionSpan, adoptionErr)
+		if adoptionErr != nil {
+			a.log("    Warning: failed to fetch stars and forks: %v", adoptionErr)
+			// Continue anyway, the repository is reported without adoption
+		} else {
+			if data.Adoption == nil {
+				data.Adoption = make(map[string]models.RepositoryAdoption)
 			}
+			data.Adoption[repoName] = adoption
 		}
-		for _, r := range reviews {
-			if !a.config.IsBot(r.Author.Login) {
-				data.Reviews = append(data.Reviews, r)
+	}
+
+	return nil
+}
+
+// cloneOptions decides how a repository is cloned: shallow with
+// options.shallow_clone, deep enough for the commits of the date range,
+// and in full when they cannot be counted (nil)
+func (a *App) cloneOptions(ctx context.Context, owner, name string, dateRange *config.ParsedDateRange) *git.CloneOptions {
+	if !a.config.Options.ShallowClone || dateRange.Start == nil {
+		return nil
+	}
+
+	// Get commit count since start date to determine shallow clone depth
+	commitCount, err := a.client.GetCommitCountSince(ctx, owner, name, *dateRange.Start)
+	if err != nil {
+		a.log("    Warning: failed to get commit count for shallow clone: %v", err)
+		// Proceed with full clone
+		return nil
+	}
+	if commitCount <= 0 {
+		return nil
+	}
+
+	// Add buffer for safety margin
+	depth := commitCount + a.config.Options.ShallowCloneBuffer
+	a.log("    Using shallow clone (depth: %d = %d commits + %d buffer)", depth, commitCount, a.config.Options.ShallowCloneBuffer)
+	return &git.CloneOptions{Depth: depth}
+}
+
+// collectPullRequests adds a repository's pull requests and reviews to data
+func (a *App) collectPullRequests(ctx context.Context, owner, name string, scope *pathfilter.Filter, dateRange *config.ParsedDateRange, data *models.RawData) error {
+	var prs []models.PullRequest
+	var reviews []models.Review
+	var err error
+	path := a.listPath()
+
+	// Use GraphQL if available (much fewer API calls), otherwise fall back to
+	// REST. The search fetch mode needs the REST Search API.
+	if a.client.HasGraphQL() && !a.config.SearchPullRequests() {
+		path = models.FetchGraphQL
+		prs, reviews, err = a.client.FetchPRsWithReviewsGraphQL(ctx, owner, name, dateRange.Start, dateRange.End)
+		if err != nil {
+			// Keep what GraphQL fetched and only fetch the rest over REST
+			path = fallbackPath(len(prs))
+			a.log("    Warning: GraphQL fetch failed after %d PRs, fetching the rest over REST: %v", len(prs), err)
+			known := make(map[int]bool, len(prs))
+			for _, pr := range prs {
+				known[pr.Number] = true
 			}
+			var restPRs []models.PullRequest
+			var restReviews []models.Review
+			restPRs, restReviews, err = a.fetchPRsAndReviewsREST(ctx, owner, name, dateRange, known)
+			prs, reviews = append(prs, restPRs...), append(reviews, restReviews...)
 		}
 	} else {
-		// Use REST API
-		prs, reviews, err := a.fetchPRsAndReviewsREST(ctx, owner, name, dateRange, data)
+		prs, reviews, err = a.fetchPRsAndReviewsREST(ctx, owner, name, dateRange, nil)
+	}
+	if err != nil {
+		return err
+	}
+	a.recordFetch(owner, name, func(f *models.RepositoryFetch) { f.PullRequests = path })
+
+	if scope != nil {
+		prs, reviews = a.scopePullRequests(ctx, owner, name, scope, prs, reviews)
+	} else if a.config.OwnsPaths(owner + "/" + name) {
+		a.attachPullRequestFiles(ctx, owner, name, prs)
+	}
+
+	if a.config.Options.BuildStatus {
+		a.fetchBuildStatuses(ctx, owner, name, prs)
+	}
+
+	data.PullRequests = append(data.PullRequests, prs...)
+	data.Reviews = append(data.Reviews, reviews...)
+
+	return nil
+}
+
+// fetchBuildStatuses looks up the CI result of each merged PR's merge commit
+func (a *App) fetchBuildStatuses(ctx context.Context, owner, name string, prs []models.PullRequest) {
+	fetched, failed := 0, 0
+	for i := range prs {
+		pr := &prs[i]
+		if !pr.IsMerged() || pr.MergeCommitSHA == "" {
+			continue
+		}
+		status, err := a.client.FetchBuildStatus(ctx, owner, name, pr.MergeCommitSHA)
 		if err != nil {
-			return err
+			a.log("    Warning: failed to fetch build status of PR #%d: %v", pr.Number, err)
+			continue
 		}
-		// Filter out bots and add to data
-		for _, pr := range prs {
-			if !a.config.IsBot(pr.Author.Login) {
-				data.PullRequests = append(data.PullRequests, pr)
-			}
+		pr.BuildStatus = status
+		fetched++
+		if status == models.BuildFailure {
+			failed++
 		}
-		for _, r := range reviews {
-			if !a.config.IsBot(r.Author.Login) {
-				data.Reviews = append(data.Reviews, r)
+	}
+	if fetched > 0 {
+		a.log("    Fetched build status of %d merged PRs (%d failed)", fetched, failed)
+	}
+}
+
+// collectLint adds a repository's lint findings at the start and end of the
+// period, read from the configured reports or by running the lint command
+func (a *App) collectLint(ctx context.Context, owner, name string, cfg *config.LintConfig, dateRange *config.ParsedDateRange, data *models.RawData) error {
+	report := models.LintReport{Repository: fmt.Sprintf("%s/%s", owner, name)}
+
+	if cfg.Command != "" {
+		// Without a start date there is no start boundary to lint
+		if dateRange.Start == nil {
+			return fmt.Errorf("lint command requires a date_range start")
+		}
+		dir := a.gitRepo.Dir(owner, name)
+		run := func(at *time.Time) (map[string]int, error) {
+			out, err := a.gitRepo.RunAt(ctx, owner, name, at, cfg.Command)
+			if err != nil {
+				return nil, fmt.Errorf("lint command failed: %w", err)
 			}
+			findings, err := lint.Parse(out)
+			if err != nil {
+				return nil, err
+			}
+			return lint.StripPrefix(findings, dir), nil
+		}
+
+		var err error
+		if report.Start, err = run(dateRange.Start); err != nil {
+			return err
+		}
+		if report.End, err = run(dateRange.End); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if report.Start, err = lint.ParseFile(cfg.Start); err != nil {
+			return err
+		}
+		if report.End, err = lint.ParseFile(cfg.End); err != nil {
+			return err
 		}
 	}
 
-	// Fetch issues and comments
-	// Use GraphQL if available (much fewer API calls), otherwise fall back to REST
-	if a.client.HasGraphQL() {
+	data.LintReports = append(data.LintReports, report)
+	return nil
+}
+
+// collectManifest adds the packages a repository publishes and requires,
+// read from the go.mod and package.json files of its clone
+func (a *App) collectManifest(owner, name string, data *models.RawData) error {
+	repoName := fmt.Sprintf("%s/%s", owner, name)
+	// Path-scoped entries of a monorepo share the manifests of its clone
+	for _, m := range data.Manifests {
+		if m.Repository == repoName {
+			return nil
+		}
+	}
+
+	files, err := a.gitRepo.ReadFiles(owner, name, manifest.IsManifest)
+	if err != nil {
+		return err
+	}
+	m, err := manifest.Read(repoName, files)
+	if err != nil {
+		return err
+	}
+	data.Manifests = append(data.Manifests, m)
+	return nil
+}
+
+// collectMainline adds the first-parent history of a repository's default
+// branch, read from its clone
+func (a *App) collectMainline(owner, name string, dateRange *config.ParsedDateRange, data *models.RawData) error {
+	repoName := fmt.Sprintf("%s/%s", owner, name)
+	// Path-scoped entries of a monorepo share the history of its clone
+	if _, ok := data.Mainline[repoName]; ok {
+		return nil
+	}
+
+	mainline, err := a.gitRepo.Mainline(owner, name, dateRange.Start)
+	if err != nil {
+		return err
+	}
+	if data.Mainline == nil {
+		data.Mainline = make(map[string][]models.MainlineCommit)
+	}
+	data.Mainline[repoName] = mainline
+	return nil
+}
+
+// collectIssues adds a repository's issues and comments to data
+func (a *App) collectIssues(ctx context.Context, owner, name string, dateRange *config.ParsedDateRange, data *models.RawData) error {
+	path := a.listPath()
+
+	// Use GraphQL if available (much fewer API calls), otherwise fall back to
+	// REST. The search fetch strategy needs the REST Search API.
+	if a.client.HasGraphQL() && !a.config.SearchIssues() {
+		path = models.FetchGraphQL
 		issues, comments, err := a.client.FetchIssuesWithCommentsGraphQL(ctx, owner, name, dateRange.Start, dateRange.End)
+		data.Issues = append(data.Issues, issues...)
+		data.IssueComments = append(data.IssueComments, comments...)
 		if err != nil {
-			a.log("    Warning: GraphQL fetch failed, falling back to REST: %v", err)
-			// Fall back to REST
-			if err := a.fetchIssuesAndCommentsREST(ctx, owner, name, dateRange, data); err != nil {
-				return err
-			}
-		} else {
-
-			// Filter out bots
+			// Keep what GraphQL fetched and only fetch the rest over REST
+			path = fallbackPath(len(issues))
+			a.log("    Warning: GraphQL fetch failed after %d issues, fetching the rest over REST: %v", len(issues), err)
+			known := make(map[int]bool, len(issues))
 			for _, issue := range issues {
-				if !a.config.IsBot(issue.Author.Login) {
-					data.Issues = append(data.Issues, issue)
-				}
+				known[issue.Number] = true
 			}
-			for _, comment := range comments {
-				if !a.config.IsBot(comment.Author.Login) {
-					data.IssueComments = append(data.IssueComments, comment)
-				}
+			if err := a.fetchIssuesAndCommentsREST(ctx, owner, name, dateRange, data, known); err != nil {
+				return err
 			}
 		}
 	} else {
 		// Use REST API
-		if err := a.fetchIssuesAndCommentsREST(ctx, owner, name, dateRange, data); err != nil {
+		if err := a.fetchIssuesAndCommentsREST(ctx, owner, name, dateRange, data, nil); err != nil {
 			return err
 		}
 	}
+	a.recordFetch(owner, name, func(f *models.RepositoryFetch) { f.Issues = path })
 
 	return nil
 }
 
+// listPath is how data is fetched without GraphQL: over REST, or read from
+// an export
+func (a *App) listPath() string {
+	switch {
+	case a.config.Source.Archive != "":
+		return models.FetchArchive
+	case a.config.Source.GHArchive != "":
+		return models.FetchGHArchive
+	}
+	return models.FetchREST
+}
+
+// fallbackPath is how data was fetched when GraphQL failed after returning
+// count items
+func fallbackPath(count int) string {
+	if count > 0 {
+		return models.FetchPartial
+	}
+	return models.FetchRESTFallback
+}
+
+// recordFetch updates the run report's record of how a repository was fetched
+func (a *App) recordFetch(owner, name string, update func(*models.RepositoryFetch)) {
+	repoName := fmt.Sprintf("%s/%s", owner, name)
+	for i := range a.fetches {
+		if a.fetches[i].Repository == repoName {
+			update(&a.fetches[i])
+			return
+		}
+	}
+	a.fetches = append(a.fetches, models.RepositoryFetch{Repository: repoName})
+	update(&a.fetches[len(a.fetches)-1])
+}
+
 func (a *App) log(format string, args ...interface{}) {
+	// Never let credentials from URLs or API errors reach the logs
+	msg := redact.String(fmt.Sprintf(format, args...))
 	if a.verbose {
-		log.Printf(format, args...)
+		log.Print(msg)
 	} else {
-		fmt.Fprintf(os.Stderr, format+"\n", args...)
+		fmt.Fprintln(os.Stderr, msg)
 	}
 }
 
@@ -329,12 +988,268 @@ func (a *App) fetchUserProfiles(ctx context.Context, data *models.RawData) (map[
 	return profiles, nil
 }
 
-// fetchPRsAndReviewsREST fetches PRs and reviews using the REST API (fallback when GraphQL fails)
-func (a *App) fetchPRsAndReviewsREST(ctx context.Context, owner, name string, dateRange *config.ParsedDateRange, data *models.RawData) ([]models.PullRequest, []models.Review, error) {
-	prs, err := a.client.FetchPullRequests(ctx, owner, name, dateRange.Start, dateRange.End)
+// fetchLinearIssues detects Linear issue identifiers in PRs and commits and looks up their state
+func (a *App) fetchLinearIssues(ctx context.Context, data *models.RawData) error {
+	detector := linear.NewDetector(a.config.Integrations.Linear.TeamKeys)
+
+	idSet := make(map[string]bool)
+	for _, pr := range data.PullRequests {
+		for _, id := range detector.Find(pr.Title, pr.HeadBranch) {
+			idSet[id] = true
+		}
+	}
+	for _, c := range data.Commits {
+		for _, id := range detector.Find(c.Message) {
+			idSet[id] = true
+		}
+	}
+
+	if len(idSet) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+
+	client := linear.NewClient(a.config.Integrations.Linear.APIKey)
+	transport, err := httpx.New(a.config.Network)
+	if err != nil {
+		return err
+	}
+	client.SetTransport(transport)
+
+	issues, err := client.FetchIssues(ctx, ids)
+	if err != nil {
+		return err
+	}
+	data.LinearIssues = issues
+	a.log("Resolved %d of %d referenced Linear issues", len(issues), len(ids))
+
+	return nil
+}
+
+// fetchCoverage sets the coverage of each merged PR's merge commit, read from the
+// repository's report directory or, failing that, the Codecov API
+func (a *App) fetchCoverage(ctx context.Context, data *models.RawData) error {
+	var codecov *coverage.CodecovClient
+	if cfg := a.config.Integrations.Codecov; cfg.Enabled {
+		codecov = coverage.NewCodecovClient(cfg.URL, cfg.Token, cfg.Service)
+		transport, err := httpx.New(a.config.Network)
+		if err != nil {
+			return err
+		}
+		codecov.SetTransport(transport)
+	}
+
+	found := 0
+	for i := range data.PullRequests {
+		pr := &data.PullRequests[i]
+		if !pr.IsMerged() || pr.MergeCommitSHA == "" {
+			continue
+		}
+		owner, name, _ := strings.Cut(pr.Repository, "/")
+
+		var pct float64
+		var ok bool
+		var err error
+		if dir := a.config.CoverageReports(owner, name); dir != "" {
+			pct, ok, err = coverage.FromDir(dir, pr.MergeCommitSHA)
+		} else if codecov != nil {
+			pct, ok, err = codecov.Commit(ctx, owner, name, pr.MergeCommitSHA)
+		}
+		if err != nil {
+			return fmt.Errorf("coverage of %s#%d: %w", pr.Repository, pr.Number, err)
+		}
+		if ok {
+			pr.Coverage = &pct
+			found++
+		}
+	}
+	a.log("Found coverage for %d merged PRs", found)
+
+	return nil
+}
+
+// estimateEffort annotates PRs with the estimates of the effort hook
+func (a *App) estimateEffort(ctx context.Context, data *models.RawData) error {
+	efforts, err := hooks.EstimateEffort(ctx, a.config.Hooks.Effort, data.PullRequests)
+	if err != nil {
+		return err
+	}
+	estimated := 0
+	for i := range data.PullRequests {
+		pr := &data.PullRequests[i]
+		if effort, ok := efforts[hooks.EffortKey(pr.Repository, pr.Number)]; ok {
+			pr.Effort = &effort
+			estimated++
+		}
+	}
+	a.log("Estimated the effort of %d of %d PRs", estimated, len(data.PullRequests))
+
+	return nil
+}
+
+// fetchAuditLog fetches audit-log events for every organization owning a configured repository
+func (a *App) fetchAuditLog(ctx context.Context, dateRange *config.ParsedDateRange, data *models.RawData) error {
+	actions := a.config.AuditLogActions()
+	seen := make(map[string]bool)
+	for _, repo := range a.config.Repositories {
+		org := strings.ToLower(repo.Owner)
+		if seen[org] {
+			continue
+		}
+		seen[org] = true
+
+		events, err := a.client.FetchAuditLog(ctx, repo.Owner, actions, dateRange.Start, dateRange.End)
+		if err != nil {
+			return err
+		}
+		data.AuditEvents = append(data.AuditEvents, events...)
+	}
+	a.log("Fetched %d audit-log events", len(data.AuditEvents))
+
+	return nil
+}
+
+// openExport opens the migration export or the GH Archive files read
+// instead of the API
+func (a *App) openExport() (*archive.Source, error) {
+	src := a.config.Source
+	if src.GHArchive != "" {
+		a.log("Reading GH Archive events from %s...", src.GHArchive)
+		var owners []string
+		for _, repo := range a.config.Repositories {
+			owners = append(owners, repo.Owner)
+		}
+		return archive.OpenGHArchive(src.GHArchive, owners)
+	}
+
+	a.log("Reading migration export %s...", src.Archive)
+	source, err := archive.Open(src.Archive)
+	if err != nil {
+		return nil, err
+	}
+	a.remoteBase = source.RepositoriesDir()
+	// Clone the exported bare repositories in-process, without a git binary
+	client.InstallProtocol("file", server.DefaultServer)
+	return source, nil
+}
+
+// fetchProfileOptOuts checks the profile repository of every contributor
+// with a login for the opt-out file
+func (a *App) fetchProfileOptOuts(ctx context.Context, data *models.RawData) error {
+	seen := make(map[string]bool)
+	var logins []string
+	add := func(login string) {
+		if login != "" && !seen[strings.ToLower(login)] {
+			seen[strings.ToLower(login)] = true
+			logins = append(logins, login)
+		}
+	}
+	for _, c := range data.Commits {
+		add(c.Author.Login)
+	}
+	for _, pr := range data.PullRequests {
+		add(pr.Author.Login)
+	}
+	for _, r := range data.Reviews {
+		add(r.Author.Login)
+	}
+	for _, issue := range data.Issues {
+		add(issue.Author.Login)
+	}
+	for _, c := range data.IssueComments {
+		add(c.Author.Login)
+	}
+
+	var optOuts []string
+	for _, login := range logins {
+		optedOut, err := a.client.FetchProfileOptOut(ctx, login)
+		if err != nil {
+			return err
+		}
+		if optedOut {
+			optOuts = append(optOuts, login)
+		}
+	}
+	sort.Strings(optOuts)
+	data.OptOuts = optOuts
+	a.log("%d of %d contributors opted out of the leaderboards", len(optOuts), len(logins))
+
+	return nil
+}
+
+// fetchOrgMembers fetches the member list of every organization owning a
+// configured repository. Owners that are user accounts are skipped.
+func (a *App) fetchOrgMembers(ctx context.Context, data *models.RawData) error {
+	members := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, repo := range a.config.Repositories {
+		org := strings.ToLower(repo.Owner)
+		if seen[org] {
+			continue
+		}
+		seen[org] = true
+
+		logins, err := a.client.FetchOrgMembers(ctx, repo.Owner)
+		if errors.Is(err, github.ErrNotOrganization) {
+			a.log("  %s is not an organization, skipping its members", repo.Owner)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(logins) > 0 {
+			members[org] = logins
+		}
+	}
+	data.OrgMembers = members
+	a.log("Fetched the members of %d organizations", len(members))
+
+	return nil
+}
+
+// fetchExternalIdentities fetches the SAML identities of every organization
+// owning a configured repository. Owners without SAML SSO are skipped.
+func (a *App) fetchExternalIdentities(ctx context.Context, data *models.RawData) error {
+	seen := make(map[string]bool)
+	for _, repo := range a.config.Repositories {
+		org := strings.ToLower(repo.Owner)
+		if seen[org] {
+			continue
+		}
+		seen[org] = true
+
+		identities, err := a.client.FetchExternalIdentities(ctx, repo.Owner)
+		if errors.Is(err, github.ErrNoSAMLProvider) {
+			a.log("  %s has no SAML identity provider, skipping it", repo.Owner)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		data.ExternalIdentities = append(data.ExternalIdentities, identities...)
+	}
+	a.log("Fetched %d SAML identities", len(data.ExternalIdentities))
+
+	return nil
+}
+
+// fetchPRsAndReviewsREST fetches PRs and reviews using the REST API, skipping
+// the known PRs already fetched over GraphQL
+func (a *App) fetchPRsAndReviewsREST(ctx context.Context, owner, name string, dateRange *config.ParsedDateRange, known map[int]bool) ([]models.PullRequest, []models.Review, error) {
+	listed, err := a.client.FetchPullRequests(ctx, owner, name, dateRange.Start, dateRange.End)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to fetch pull requests: %w", err)
 	}
+	var prs []models.PullRequest
+	for _, pr := range listed {
+		if !known[pr.Number] {
+			prs = append(prs, pr)
+		}
+	}
 	a.log("    Found %d pull requests", len(prs))
 
 	// Fetch reviews for each PR
@@ -352,33 +1267,63 @@ func (a *App) fetchPRsAndReviewsREST(ctx context.Context, owner, name string, da
 	return prs, reviews, nil
 }
 
-// fetchIssuesAndCommentsREST fetches issues and comments using the REST API (fallback when GraphQL fails)
-func (a *App) fetchIssuesAndCommentsREST(ctx context.Context, owner, name string, dateRange *config.ParsedDateRange, data *models.RawData) error {
-	issues, err := a.client.FetchIssues(ctx, owner, name, dateRange.Start, dateRange.End)
+// fetchIssuesAndCommentsREST fetches issues and comments using the REST API,
+// skipping the known issues already fetched over GraphQL
+func (a *App) fetchIssuesAndCommentsREST(ctx context.Context, owner, name string, dateRange *config.ParsedDateRange, data *models.RawData, known map[int]bool) error {
+	listed, err := a.client.FetchIssues(ctx, owner, name, dateRange.Start, dateRange.End)
 	if err != nil {
 		return fmt.Errorf("failed to fetch issues: %w", err)
 	}
-	a.log("    Found %d issues", len(issues))
-
-	// Filter out bots and add to data
-	for _, issue := range issues {
-		if !a.config.IsBot(issue.Author.Login) {
-			data.Issues = append(data.Issues, issue)
+	var issues []models.Issue
+	for _, issue := range listed {
+		if !known[issue.Number] {
+			issues = append(issues, issue)
 		}
 	}
+	a.log("    Found %d issues", len(issues))
+
+	data.Issues = append(data.Issues, issues...)
 
 	// Fetch all comments for the repository within date range
-	comments, err := a.client.FetchIssueComments(ctx, owner, name, dateRange.Start, dateRange.End)
+	listedComments, err := a.client.FetchIssueComments(ctx, owner, name, dateRange.Start, dateRange.End)
 	if err != nil {
 		a.log("    Warning: failed to fetch issue comments: %v", err)
 	} else {
-		for _, comment := range comments {
-			if !a.config.IsBot(comment.Author.Login) {
-				data.IssueComments = append(data.IssueComments, comment)
+		var comments []models.IssueComment
+		for _, c := range listedComments {
+			if !known[c.Issue] {
+				comments = append(comments, c)
 			}
 		}
+		data.IssueComments = append(data.IssueComments, comments...)
 		a.log("    Found %d issue comments (REST)", len(comments))
 	}
 
 	return nil
 }
+
+// collectReviewComments adds the inline review comments on a repository's
+// collected pull requests to data
+func (a *App) collectReviewComments(ctx context.Context, owner, name string, dateRange *config.ParsedDateRange, data *models.RawData) error {
+	listed, err := a.client.FetchReviewComments(ctx, owner, name, dateRange.Start, dateRange.End)
+	if err != nil {
+		return err
+	}
+
+	repoName := fmt.Sprintf("%s/%s", owner, name)
+	collected := make(map[int]bool)
+	for _, pr := range data.PullRequests {
+		if pr.Repository == repoName {
+			collected[pr.Number] = true
+		}
+	}
+	var comments []models.ReviewComment
+	for _, c := range listed {
+		if collected[c.PullRequest] {
+			comments = append(comments, c)
+		}
+	}
+	data.ReviewComments = append(data.ReviewComments, comments...)
+	a.log("    Found %d review comments", len(comments))
+	return nil
+}
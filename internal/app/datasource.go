@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// DataSource is the GitHub data the application collects. It is implemented
+// by *github.Client; tests orchestrate runs against fakes.
+type DataSource interface {
+	SetProgressCallback(cb github.ProgressCallback)
+	HasGraphQL() bool
+
+	// Repositories
+	ListOrgRepos(ctx context.Context, org, pattern string) ([]string, error)
+	GetCommitCountSince(ctx context.Context, owner, repo string, since time.Time) (int, error)
+	FetchRepositorySettings(ctx context.Context, owner, repo string) (models.RepositorySettings, error)
+	FetchBuildStatus(ctx context.Context, owner, repo, sha string) (string, error)
+	FetchAdoption(ctx context.Context, owner, repo string, since time.Time, maxPages int) (models.RepositoryAdoption, error)
+	FetchCommitStats(ctx context.Context, owner, repo, sha string) (github.CommitStats, error)
+
+	// Pull requests and reviews
+	FetchPullRequests(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.PullRequest, error)
+	FetchPRsWithReviewsGraphQL(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.PullRequest, []models.Review, error)
+	FetchReviews(ctx context.Context, owner, repo string, prNumber int) ([]models.Review, error)
+	FetchPullRequestFiles(ctx context.Context, owner, repo string, prNumber int) ([]models.PullRequestFile, error)
+	FetchReviewComments(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.ReviewComment, error)
+
+	// Reactions
+	FetchIssueReactions(ctx context.Context, owner, repo string, number int) ([]models.Reaction, error)
+	FetchCommentReactions(ctx context.Context, owner, repo string, id int64) ([]models.Reaction, error)
+	FetchReviewReactions(ctx context.Context, owner, repo string, prNumber int) (map[int64][]models.Reaction, error)
+
+	// Issues and comments
+	FetchIssues(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.Issue, error)
+	FetchIssueComments(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.IssueComment, error)
+	FetchIssuesWithCommentsGraphQL(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.Issue, []models.IssueComment, error)
+
+	// Organization
+	FetchUserProfiles(ctx context.Context, logins []string) (map[string]github.UserProfile, error)
+	FetchAuditLog(ctx context.Context, org string, actions []string, since, until *time.Time) ([]models.AuditEvent, error)
+	FetchOrgMembers(ctx context.Context, org string) ([]string, error)
+	FetchProfileOptOut(ctx context.Context, login string) (bool, error)
+	FetchExternalIdentities(ctx context.Context, org string) ([]models.ExternalIdentity, error)
+
+	// Run reporting
+	APIUsage() models.APIUsage
+	ResilienceReport() github.ResilienceReport
+	SaveCacheStats() error
+}
+
+var _ DataSource = (*github.Client)(nil)
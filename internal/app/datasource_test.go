@@ -0,0 +1,501 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/git"
+	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/internal/snapshot"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// fakeSource is a DataSource serving canned data and recording the calls made
+type fakeSource struct {
+	mu    sync.Mutex
+	calls []string
+
+	graphQL     bool
+	graphQLErr  error
+	gqlPartial  int // Items GraphQL returns before failing with graphQLErr
+	commitCount int
+	countErr    error
+
+	prs            []models.PullRequest
+	reviews        []models.Review
+	reviewComments []models.ReviewComment
+	issues         []models.Issue
+	comments       []models.IssueComment
+	members        []string
+	optOuts        []string
+
+	identities  []models.ExternalIdentity
+	reactions   map[string][]models.Reaction  // By pr:<number>, review:<id> or comment:<id>
+	commitStats map[string]github.CommitStats // By SHA, missing ones fail
+}
+
+var _ DataSource = (*fakeSource)(nil)
+
+func (f *fakeSource) called(method string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, method)
+}
+
+func (f *fakeSource) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeSource) SetProgressCallback(github.ProgressCallback) {}
+
+func (f *fakeSource) HasGraphQL() bool { return f.graphQL }
+
+func (f *fakeSource) ListOrgRepos(context.Context, string, string) ([]string, error) {
+	f.called("ListOrgRepos")
+	return nil, nil
+}
+
+func (f *fakeSource) GetCommitCountSince(context.Context, string, string, time.Time) (int, error) {
+	f.called("GetCommitCountSince")
+	return f.commitCount, f.countErr
+}
+
+func (f *fakeSource) FetchRepositorySettings(context.Context, string, string) (models.RepositorySettings, error) {
+	f.called("FetchRepositorySettings")
+	return models.RepositorySettings{}, nil
+}
+
+func (f *fakeSource) FetchBuildStatus(context.Context, string, string, string) (string, error) {
+	f.called("FetchBuildStatus")
+	return models.BuildSuccess, nil
+}
+
+func (f *fakeSource) FetchAdoption(context.Context, string, string, time.Time, int) (models.RepositoryAdoption, error) {
+	f.called("FetchAdoption")
+	return models.RepositoryAdoption{}, nil
+}
+
+func (f *fakeSource) FetchCommitStats(_ context.Context, _, _, sha string) (github.CommitStats, error) {
+	f.called("FetchCommitStats")
+	stats, ok := f.commitStats[sha]
+	if !ok {
+		return github.CommitStats{}, fmt.Errorf("no commit found for %s", sha)
+	}
+	return stats, nil
+}
+
+func (f *fakeSource) FetchPullRequests(context.Context, string, string, *time.Time, *time.Time) ([]models.PullRequest, error) {
+	f.called("FetchPullRequests")
+	return f.prs, nil
+}
+
+func (f *fakeSource) FetchPRsWithReviewsGraphQL(context.Context, string, string, *time.Time, *time.Time) ([]models.PullRequest, []models.Review, error) {
+	f.called("FetchPRsWithReviewsGraphQL")
+	if f.graphQLErr != nil {
+		prs := f.prs[:f.gqlPartial]
+		var reviews []models.Review
+		for _, r := range f.reviews {
+			if slices.ContainsFunc(prs, func(pr models.PullRequest) bool { return pr.Number == r.PullRequest }) {
+				reviews = append(reviews, r)
+			}
+		}
+		return prs, reviews, f.graphQLErr
+	}
+	return f.prs, f.reviews, nil
+}
+
+func (f *fakeSource) FetchReviews(_ context.Context, _, _ string, prNumber int) ([]models.Review, error) {
+	f.called("FetchReviews")
+	var reviews []models.Review
+	for _, r := range f.reviews {
+		if r.PullRequest == prNumber {
+			reviews = append(reviews, r)
+		}
+	}
+	return reviews, nil
+}
+
+func (f *fakeSource) FetchPullRequestFiles(context.Context, string, string, int) ([]models.PullRequestFile, error) {
+	f.called("FetchPullRequestFiles")
+	return nil, nil
+}
+
+func (f *fakeSource) FetchReviewComments(context.Context, string, string, *time.Time, *time.Time) ([]models.ReviewComment, error) {
+	f.called("FetchReviewComments")
+	return f.reviewComments, nil
+}
+
+func (f *fakeSource) FetchIssueReactions(_ context.Context, _, _ string, number int) ([]models.Reaction, error) {
+	f.called("FetchIssueReactions")
+	return f.reactions[fmt.Sprintf("pr:%d", number)], nil
+}
+
+func (f *fakeSource) FetchCommentReactions(_ context.Context, _, _ string, id int64) ([]models.Reaction, error) {
+	f.called("FetchCommentReactions")
+	return f.reactions[fmt.Sprintf("comment:%d", id)], nil
+}
+
+func (f *fakeSource) FetchReviewReactions(_ context.Context, _, _ string, prNumber int) (map[int64][]models.Reaction, error) {
+	f.called("FetchReviewReactions")
+	reactions := make(map[int64][]models.Reaction)
+	for _, r := range f.reviews {
+		if r.PullRequest == prNumber {
+			reactions[r.ID] = f.reactions[fmt.Sprintf("review:%d", r.ID)]
+		}
+	}
+	return reactions, nil
+}
+
+func (f *fakeSource) FetchIssues(context.Context, string, string, *time.Time, *time.Time) ([]models.Issue, error) {
+	f.called("FetchIssues")
+	return f.issues, nil
+}
+
+func (f *fakeSource) FetchIssueComments(context.Context, string, string, *time.Time, *time.Time) ([]models.IssueComment, error) {
+	f.called("FetchIssueComments")
+	return f.comments, nil
+}
+
+func (f *fakeSource) FetchIssuesWithCommentsGraphQL(context.Context, string, string, *time.Time, *time.Time) ([]models.Issue, []models.IssueComment, error) {
+	f.called("FetchIssuesWithCommentsGraphQL")
+	if f.graphQLErr != nil {
+		issues := f.issues[:f.gqlPartial]
+		var comments []models.IssueComment
+		for _, c := range f.comments {
+			if slices.ContainsFunc(issues, func(i models.Issue) bool { return i.Number == c.Issue }) {
+				comments = append(comments, c)
+			}
+		}
+		return issues, comments, f.graphQLErr
+	}
+	return f.issues, f.comments, nil
+}
+
+func (f *fakeSource) FetchUserProfiles(context.Context, []string) (map[string]github.UserProfile, error) {
+	f.called("FetchUserProfiles")
+	return nil, nil
+}
+
+func (f *fakeSource) FetchAuditLog(context.Context, string, []string, *time.Time, *time.Time) ([]models.AuditEvent, error) {
+	f.called("FetchAuditLog")
+	return nil, nil
+}
+
+func (f *fakeSource) FetchOrgMembers(_ context.Context, org string) ([]string, error) {
+	f.called("FetchOrgMembers")
+	if org == "alice" {
+		return nil, fmt.Errorf("%s: %w", org, github.ErrNotOrganization)
+	}
+	return f.members, nil
+}
+
+func (f *fakeSource) FetchProfileOptOut(_ context.Context, login string) (bool, error) {
+	f.called("FetchProfileOptOut")
+	return slices.Contains(f.optOuts, login), nil
+}
+
+func (f *fakeSource) FetchExternalIdentities(_ context.Context, org string) ([]models.ExternalIdentity, error) {
+	f.called("FetchExternalIdentities")
+	if org == "alice" {
+		return nil, fmt.Errorf("%s: %w", org, github.ErrNoSAMLProvider)
+	}
+	return f.identities, nil
+}
+
+func (f *fakeSource) APIUsage() models.APIUsage { return models.APIUsage{} }
+
+func (f *fakeSource) ResilienceReport() github.ResilienceReport { return github.ResilienceReport{} }
+
+func (f *fakeSource) SaveCacheStats() error { return nil }
+
+// fakeApp is an application collecting from source
+func fakeApp(source DataSource) *App {
+	return &App{config: config.DefaultConfig(), client: source}
+}
+
+func marchRange() *config.ParsedDateRange {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	return &config.ParsedDateRange{Start: &start, End: &end}
+}
+
+func TestApp_CloneOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		shallow   bool
+		noStart   bool
+		count     int
+		countErr  error
+		want      *git.CloneOptions
+		wantCount bool
+	}{
+		"shallow clones disabled": {shallow: false, count: 40},
+		"no start date":           {shallow: true, noStart: true, count: 40},
+		"commit count failed":     {shallow: true, countErr: errors.New("rate limited"), wantCount: true},
+		"no commits in range":     {shallow: true, count: 0, wantCount: true},
+		"depth with buffer":       {shallow: true, count: 40, wantCount: true, want: &git.CloneOptions{Depth: 140}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			source := &fakeSource{commitCount: tt.count, countErr: tt.countErr}
+			a := fakeApp(source)
+			a.config.Options.ShallowClone = tt.shallow
+			a.config.Options.ShallowCloneBuffer = 100
+			dateRange := marchRange()
+			if tt.noStart {
+				dateRange.Start = nil
+			}
+
+			assert.Equal(t, tt.want, a.cloneOptions(context.Background(), "org", "repo", dateRange))
+			assert.Equal(t, tt.wantCount, len(source.Calls()) > 0)
+		})
+	}
+}
+
+func TestApp_CollectPullRequestsGraphQLFallback(t *testing.T) {
+	t.Parallel()
+
+	prs := []models.PullRequest{
+		{Number: 1, Repository: "org/repo", Author: models.Author{Login: "alice"}},
+		{Number: 2, Repository: "org/repo", Author: models.Author{Login: "bob"}},
+	}
+	reviews := []models.Review{
+		{ID: 10, PullRequest: 1, Repository: "org/repo", Author: models.Author{Login: "bob"}},
+		{ID: 20, PullRequest: 2, Repository: "org/repo", Author: models.Author{Login: "alice"}},
+	}
+
+	tests := map[string]struct {
+		source *fakeSource
+		calls  []string
+		path   string
+	}{
+		"graphql": {
+			source: &fakeSource{graphQL: true},
+			calls:  []string{"FetchPRsWithReviewsGraphQL"},
+			path:   models.FetchGraphQL,
+		},
+		"graphql failed": {
+			source: &fakeSource{graphQL: true, graphQLErr: errors.New("timeout")},
+			calls:  []string{"FetchPRsWithReviewsGraphQL", "FetchPullRequests", "FetchReviews", "FetchReviews"},
+			path:   models.FetchRESTFallback,
+		},
+		"graphql failed after the first page": {
+			// Reviews are only fetched over REST for the PRs GraphQL missed
+			source: &fakeSource{graphQL: true, graphQLErr: errors.New("timeout"), gqlPartial: 1},
+			calls:  []string{"FetchPRsWithReviewsGraphQL", "FetchPullRequests", "FetchReviews"},
+			path:   models.FetchPartial,
+		},
+		"rest": {
+			source: &fakeSource{},
+			calls:  []string{"FetchPullRequests", "FetchReviews", "FetchReviews"},
+			path:   models.FetchREST,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.source.prs, tt.source.reviews = prs, reviews
+			a := fakeApp(tt.source)
+			data := &models.RawData{}
+			require.NoError(t, a.collectPullRequests(context.Background(), "org", "repo", nil, marchRange(), data))
+
+			assert.Equal(t, tt.calls, tt.source.Calls())
+			assert.Equal(t, prs, data.PullRequests)
+			assert.Equal(t, reviews, data.Reviews)
+			assert.Equal(t, []models.RepositoryFetch{{Repository: "org/repo", PullRequests: tt.path}}, a.fetches)
+		})
+	}
+}
+
+func TestApp_CollectIssuesGraphQLFallback(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{
+		graphQL:    true,
+		graphQLErr: errors.New("timeout"),
+		gqlPartial: 1,
+		issues:     []models.Issue{{Number: 2, Repository: "org/repo"}, {Number: 3, Repository: "org/repo"}},
+		comments:   []models.IssueComment{{ID: 20, Issue: 2, Repository: "org/repo"}, {ID: 30, Issue: 3, Repository: "org/repo"}},
+	}
+	a := fakeApp(source)
+	data := &models.RawData{}
+	require.NoError(t, a.collectIssues(context.Background(), "org", "repo", marchRange(), data))
+
+	assert.Equal(t, []string{"FetchIssuesWithCommentsGraphQL", "FetchIssues", "FetchIssueComments"}, source.Calls())
+	assert.Equal(t, source.issues, data.Issues, "each issue once")
+	assert.Equal(t, source.comments, data.IssueComments, "each comment once")
+
+	// Pull requests and issues fall back independently
+	source.graphQLErr = nil
+	require.NoError(t, a.collectPullRequests(context.Background(), "org", "repo", nil, marchRange(), data))
+	assert.Equal(t, []models.RepositoryFetch{{Repository: "org/repo", PullRequests: models.FetchGraphQL, Issues: models.FetchPartial}}, a.fetches)
+}
+
+func TestApp_BotFiltering(t *testing.T) {
+	t.Parallel()
+
+	merged := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	pr := func(number int, login string) models.PullRequest {
+		return models.PullRequest{
+			Number:     number,
+			Title:      "change",
+			State:      models.PRStateMerged,
+			Author:     models.Author{Login: login},
+			Repository: "org/repo",
+			CreatedAt:  merged.Add(-time.Hour),
+			UpdatedAt:  merged,
+			MergedAt:   &merged,
+		}
+	}
+	source := &fakeSource{prs: []models.PullRequest{pr(1, "alice"), pr(2, "dependabot[bot]"), pr(3, "ci-runner")}}
+	a := fakeApp(source)
+	a.config.Options.AdditionalBotPatterns = []string{"ci-*"}
+
+	data := &models.RawData{}
+	require.NoError(t, a.collectPullRequests(context.Background(), "org", "repo", nil, marchRange(), data))
+	require.Len(t, data.PullRequests, 3, "bots are kept in the raw data")
+
+	metrics, bots, err := a.aggregate(snapshot.New(data, nil, marchRange()), a.config)
+	require.NoError(t, err)
+
+	var logins []string
+	for _, c := range metrics.Contributors {
+		logins = append(logins, c.Login)
+	}
+	assert.Equal(t, []string{"alice"}, logins)
+
+	var botLogins []string
+	for _, b := range bots {
+		botLogins = append(botLogins, b.Login)
+	}
+	assert.ElementsMatch(t, []string{"dependabot[bot]", "ci-runner"}, botLogins)
+}
+
+func TestApp_FetchProfileOptOuts(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{optOuts: []string{"bob"}}
+	a := fakeApp(source)
+
+	data := &models.RawData{
+		Commits:      []models.Commit{{Author: models.Author{Login: "alice"}}, {Author: models.Author{Email: "x@example.com"}}},
+		PullRequests: []models.PullRequest{{Author: models.Author{Login: "bob"}}},
+		Reviews:      []models.Review{{Author: models.Author{Login: "alice"}}},
+		Issues:       []models.Issue{{Author: models.Author{Login: "carol"}}},
+	}
+	require.NoError(t, a.fetchProfileOptOuts(context.Background(), data))
+	assert.Len(t, source.Calls(), 3, "each login once")
+	assert.Equal(t, []string{"bob"}, data.OptOuts)
+}
+
+func TestApp_FetchOrgMembers(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{members: []string{"alice", "bob"}}
+	a := fakeApp(source)
+	a.config.Repositories = []config.RepositoryConfig{
+		{Owner: "acme", Name: "api"},
+		{Owner: "ACME", Name: "web"},
+		{Owner: "alice", Name: "dotfiles"},
+	}
+
+	data := &models.RawData{}
+	require.NoError(t, a.fetchOrgMembers(context.Background(), data))
+	assert.Equal(t, []string{"FetchOrgMembers", "FetchOrgMembers"}, source.Calls(), "each owner once")
+	assert.Equal(t, map[string][]string{"acme": {"alice", "bob"}}, data.OrgMembers, "user accounts are skipped")
+}
+
+func TestApp_FetchExternalIdentities(t *testing.T) {
+	t.Parallel()
+
+	identities := []models.ExternalIdentity{{Login: "alice", Name: "Alice Smith", Emails: []string{"alice@corp.example"}}}
+	source := &fakeSource{identities: identities}
+	a := fakeApp(source)
+	a.config.Repositories = []config.RepositoryConfig{
+		{Owner: "acme", Name: "api"},
+		{Owner: "ACME", Name: "web"},
+		{Owner: "alice", Name: "dotfiles"},
+	}
+
+	data := &models.RawData{}
+	require.NoError(t, a.fetchExternalIdentities(context.Background(), data))
+	assert.Equal(t, []string{"FetchExternalIdentities", "FetchExternalIdentities"}, source.Calls(), "each owner once")
+	assert.Equal(t, identities, data.ExternalIdentities, "owners without SAML SSO are skipped")
+}
+
+func TestApp_CollectReactions(t *testing.T) {
+	t.Parallel()
+
+	heart := []models.Reaction{{Login: "bob", Content: models.ReactionHeart}}
+	tests := map[string]struct {
+		graphQL bool
+		calls   []string
+		review  []models.Reaction
+	}{
+		"rest only": {
+			calls: []string{"FetchIssueReactions", "FetchCommentReactions"},
+		},
+		"graphql": {
+			graphQL: true,
+			calls:   []string{"FetchIssueReactions", "FetchReviewReactions", "FetchCommentReactions"},
+			review:  heart,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			source := &fakeSource{
+				graphQL:   tt.graphQL,
+				reviews:   []models.Review{{ID: 10, PullRequest: 1, Repository: "org/repo"}},
+				reactions: map[string][]models.Reaction{"pr:1": heart, "review:10": heart, "comment:20": heart},
+			}
+			data := &models.RawData{
+				PullRequests: []models.PullRequest{{Number: 1, Repository: "org/repo"}, {Number: 2, Repository: "org/other"}},
+				Reviews:      slices.Clone(source.reviews),
+				IssueComments: []models.IssueComment{
+					{ID: 20, Issue: 3, Repository: "org/repo"},
+					{Issue: 3, Repository: "org/repo"}, // No ID to look its reactions up by
+				},
+			}
+			fakeApp(source).collectReactions(context.Background(), "org", "repo", data)
+
+			assert.Equal(t, tt.calls, source.Calls(), "only the repository's items")
+			assert.Equal(t, heart, data.PullRequests[0].Reactions)
+			assert.Equal(t, tt.review, data.Reviews[0].Reactions)
+			assert.Equal(t, heart, data.IssueComments[0].Reactions)
+			assert.Empty(t, data.IssueComments[1].Reactions)
+		})
+	}
+}
+
+func TestApp_CollectReviewComments(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{reviewComments: []models.ReviewComment{
+		{ID: 10, PullRequest: 1, Repository: "org/repo", Body: "Handle the error here."},
+		{ID: 20, PullRequest: 2, Repository: "org/repo", Body: "On a PR outside the paths"},
+	}}
+	data := &models.RawData{
+		PullRequests: []models.PullRequest{{Number: 1, Repository: "org/repo"}, {Number: 2, Repository: "org/other"}},
+	}
+	require.NoError(t, fakeApp(source).collectReviewComments(context.Background(), "org", "repo", marchRange(), data))
+
+	assert.Equal(t, []string{"FetchReviewComments"}, source.Calls())
+	assert.Equal(t, source.reviewComments[:1], data.ReviewComments, "only those on collected PRs")
+}
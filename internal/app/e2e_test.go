@@ -0,0 +1,224 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/cassette"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// e2eDir holds the configuration, recorded API responses and golden output
+// of the end-to-end test
+const e2eDir = "testdata/e2e"
+
+// e2eSkipped are generated files that differ between runs or only restate code
+var e2eSkipped = []string{"run.json", "locale.json", "icons.json", "schema"}
+
+// fixtureCommit is a commit of the repository cloned by the end-to-end test
+type fixtureCommit struct {
+	login string
+	at    string
+	files map[string]string
+}
+
+// fixtureHistory matches the pull requests of the recorded API responses
+var fixtureHistory = []fixtureCommit{
+	{"alice", "2024-03-04T09:00:00Z", map[string]string{"go.mod": "module acme/widgets\n", "main.go": "package main\n\nfunc main() {}\n"}},
+	{"alice", "2024-03-05T10:30:00Z", map[string]string{"widget.go": "package main\n\ntype Widget struct{}\n", "widget_test.go": "package main\n"}},
+	{"bob", "2024-03-06T14:00:00Z", map[string]string{"README.md": "# Widgets\n\nWidgets for everyone.\n"}},
+	{"bob", "2024-03-12T16:45:00Z", map[string]string{"widget.go": "package main\n\ntype Widget struct {\n\tName string\n}\n"}},
+	{"carol", "2024-03-14T08:15:00Z", map[string]string{"gadget.go": "package main\n\ntype Gadget struct{}\n", "gadget_test.go": "package main\n"}},
+	{"alice", "2024-03-20T11:00:00Z", map[string]string{"main.go": "package main\n\nfunc main() {\n\t_ = Widget{}\n}\n"}},
+	{"carol", "2024-03-27T17:30:00Z", map[string]string{"gadget.go": "package main\n\ntype Gadget struct {\n\tWidgets []Widget\n}\n"}},
+}
+
+// buildFixtureRepository commits fixtureHistory to a new bare repository at dir
+func buildFixtureRepository(t *testing.T, dir string) {
+	t.Helper()
+
+	worktree := memfs.New()
+	repo, err := gogit.Init(filesystem.NewStorage(osfs.New(dir), cache.NewObjectLRUDefault()), worktree)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	for i, c := range fixtureHistory {
+		at, err := time.Parse(time.RFC3339, c.at)
+		require.NoError(t, err)
+		for _, name := range slices.Sorted(maps.Keys(c.files)) {
+			require.NoError(t, util.WriteFile(worktree, name, []byte(c.files[name]), 0600))
+			_, err := wt.Add(name)
+			require.NoError(t, err)
+		}
+		sig := &object.Signature{Name: c.login, Email: c.login + "@acme.example", When: at}
+		_, err = wt.Commit(fmt.Sprintf("Change %d", i+1), &gogit.CommitOptions{Author: sig, Committer: sig})
+		require.NoError(t, err)
+	}
+}
+
+// TestRun_EndToEnd runs the whole pipeline, from the API and the clone to the
+// generated dashboard data, against recorded responses and compares the data
+// with the golden files of the current schema version. After an intended
+// change of the output, run go test ./internal/app -run EndToEnd -update.
+func TestRun_EndToEnd(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("E2E_DIR", dir)
+	t.Setenv("SOURCE_DATE_EPOCH", "1711929600") // 2024-04-01
+
+	// Serve file remotes in-process instead of with git-upload-pack
+	client.InstallProtocol("file", server.DefaultServer)
+	remote := filepath.Join(dir, "remote")
+	buildFixtureRepository(t, filepath.Join(remote, "acme", "widgets"))
+
+	recorded, err := cassette.Load(filepath.Join(e2eDir, "cassette.json"))
+	require.NoError(t, err)
+	replayer := cassette.NewReplayer(recorded)
+
+	a, err := New(filepath.Join(e2eDir, "config.yaml"), "", false)
+	require.NoError(t, err)
+	a.transport = replayer
+	a.remoteBase = remote
+
+	require.NoError(t, a.Run(context.Background()))
+	assert.Empty(t, replayer.Misses(), "requests missing from the cassette")
+
+	golden := filepath.Join(e2eDir, "golden", fmt.Sprintf("v%d", models.SchemaVersion))
+	compareGolden(t, filepath.Join(dir, "dist", "data"), golden)
+}
+
+// compareGolden compares the generated files below dir with the golden files,
+// rewriting them with -update
+func compareGolden(t *testing.T, dir, golden string) {
+	t.Helper()
+
+	generated := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if slices.Contains(e2eSkipped, d.Name()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		generated[filepath.ToSlash(rel)], err = os.ReadFile(path) // #nosec G304 -- generated by the test
+		return err
+	})
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.RemoveAll(golden))
+		for rel, data := range generated {
+			path := filepath.Join(golden, filepath.FromSlash(rel))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+			require.NoError(t, os.WriteFile(path, data, 0600))
+		}
+	}
+
+	expected := map[string][]byte{}
+	err = filepath.WalkDir(golden, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(golden, path)
+		if err != nil {
+			return err
+		}
+		expected[filepath.ToSlash(rel)], err = os.ReadFile(path) // #nosec G304 -- golden files of the test
+		return err
+	})
+	require.NoError(t, err, "no golden files for schema version %d; run go test -update to create them", models.SchemaVersion)
+
+	for _, rel := range slices.Sorted(maps.Keys(generated)) {
+		want, ok := expected[rel]
+		if !assert.True(t, ok, "%s is not in the golden files", rel) {
+			continue
+		}
+		assert.Equal(t, string(want), string(generated[rel]), rel)
+	}
+	for rel := range expected {
+		_, ok := generated[rel]
+		assert.True(t, ok, "%s was not generated", rel)
+	}
+}
+
+// TestRun_Archive runs the pipeline against a migration export without any
+// API access or authentication
+func TestRun_Archive(t *testing.T) {
+	dir := t.TempDir()
+	export := filepath.Join(dir, "export")
+	buildFixtureRepository(t, filepath.Join(export, "repositories", "acme", "widgets.git"))
+	records := map[string]string{
+		"repositories_000001.json": `[{"type": "repository", "url": "https://github.com/acme/widgets", "default_branch": "master"}]`,
+		"pull_requests_000001.json": `[{"type": "pull_request", "url": "https://github.com/acme/widgets/pull/1", "user": "https://github.com/bob",
+			"base": {"ref": "master"}, "head": {"ref": "readme"}, "created_at": "2024-03-06T09:00:00Z", "merged_at": "2024-03-06T15:00:00Z"}]`,
+		"pull_request_reviews_000001.json": `[{"type": "pull_request_review", "url": "https://github.com/acme/widgets/pull/1/files#pullrequestreview-1",
+			"pull_request": "https://github.com/acme/widgets/pull/1", "user": "https://github.com/alice", "state": 40, "submitted_at": "2024-03-06T12:00:00Z"}]`,
+	}
+	for name, content := range records {
+		require.NoError(t, os.WriteFile(filepath.Join(export, name), []byte(content), 0600))
+	}
+
+	configPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(fmt.Sprintf(`repositories:
+  - owner: acme
+    pattern: "*"
+source:
+  archive: %q
+date_range:
+  start: "2024-03-01"
+  end: "2024-03-31"
+output:
+  directory: %q
+cache:
+  enabled: false
+  directory: %q
+options:
+  clone_directory: %q
+`, export, filepath.Join(dir, "dist"), filepath.Join(dir, "cache"), filepath.Join(dir, "clones"))), 0600))
+
+	a, err := New(configPath, "", false)
+	require.NoError(t, err)
+	require.NoError(t, a.Run(context.Background()))
+
+	data, err := os.ReadFile(filepath.Join(dir, "dist", "data", "global.json")) // #nosec G304 -- generated by the test
+	require.NoError(t, err)
+	var global models.GlobalMetrics
+	require.NoError(t, json.Unmarshal(data, &global))
+	assert.Equal(t, len(fixtureHistory), global.TotalCommits)
+	assert.Equal(t, 1, global.TotalPRs)
+	assert.Equal(t, 1, global.TotalReviews)
+	assert.Equal(t, []models.RepositoryFetch{{Repository: "acme/widgets", PullRequests: models.FetchArchive, Issues: models.FetchArchive}}, a.fetches)
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/compare"
+	"github.com/lukaszraczylo/git-velocity/internal/events"
+	"github.com/lukaszraczylo/git-velocity/internal/httpx"
+	"github.com/lukaszraczylo/git-velocity/internal/telemetry"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// previousRun returns the metrics of the run whose output this one replaces,
+// for the events to compare with, or nil when events are off or there is none
+func (a *App) previousRun() *models.GlobalMetrics {
+	if !a.config.Events.Enabled() {
+		return nil
+	}
+	previous, err := compare.Load(a.outputDir)
+	if err != nil {
+		return nil
+	}
+	return previous
+}
+
+// publishEvents publishes what changed since the previous run to the
+// configured event streams
+func (a *App) publishEvents(ctx context.Context, previous, metrics *models.GlobalMetrics, runAt time.Time) (err error) {
+	if !a.config.Events.Enabled() {
+		return nil
+	}
+	ctx, span := telemetry.Start(ctx, "publish_events")
+	defer func() { telemetry.End(span, err) }()
+
+	transport, err := httpx.New(a.config.Network)
+	if err != nil {
+		return err
+	}
+
+	evts := events.Build(previous, metrics, a.config, runAt)
+	for _, publisher := range events.Publishers(a.config.Events, transport) {
+		if err := publisher.Publish(ctx, evts); err != nil {
+			return fmt.Errorf("failed to publish events to %s: %w", publisher.Name(), err)
+		}
+	}
+	a.log("Published %d events", len(evts))
+	return nil
+}
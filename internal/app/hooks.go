@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/hooks"
+	"github.com/lukaszraczylo/git-velocity/internal/telemetry"
+)
+
+// runHook runs the pre_analyze or post_generate hook with the run context in
+// its environment, logging what it prints
+func (a *App) runHook(ctx context.Context, hook, command string, dateRange *config.ParsedDateRange) (err error) {
+	ctx, span := telemetry.Start(ctx, hook)
+	defer func() { telemetry.End(span, err) }()
+
+	a.log("Running %s hook...", hook)
+	run := hooks.RunContext{
+		Hook:       hook,
+		ConfigPath: a.configPath,
+		OutputDir:  a.outputDir,
+		CacheDir:   a.config.Cache.Directory,
+		Offline:    a.config.Options.Offline,
+		Start:      dateRange.Start,
+		End:        dateRange.End,
+	}
+	out, err := hooks.Run(ctx, command, nil, run.Env())
+	if err != nil {
+		return fmt.Errorf("%s hook failed: %w", hook, err)
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		if line != "" {
+			a.log("  %s", line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/hooks"
+)
+
+func TestApp_RunHook(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell commands")
+	}
+
+	dir := t.TempDir()
+	a := fakeApp(&fakeSource{})
+	a.outputDir = filepath.Join(dir, "dist")
+	a.configPath = filepath.Join(dir, "config.yaml")
+	a.config.Cache.Directory = filepath.Join(dir, "cache")
+
+	envFile := filepath.Join(dir, "env")
+	require.NoError(t, a.runHook(context.Background(), hooks.PostGenerate, "env | grep ^GIT_VELOCITY_ | sort > "+envFile, marchRange()))
+
+	env, err := os.ReadFile(envFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GIT_VELOCITY_RUN_CACHE_DIR=" + filepath.Join(dir, "cache"),
+		"GIT_VELOCITY_RUN_CONFIG=" + filepath.Join(dir, "config.yaml"),
+		"GIT_VELOCITY_RUN_DATA_DIR=" + filepath.Join(dir, "dist", "data"),
+		"GIT_VELOCITY_RUN_HOOK=post_generate",
+		"GIT_VELOCITY_RUN_OFFLINE=false",
+		"GIT_VELOCITY_RUN_OUTPUT_DIR=" + filepath.Join(dir, "dist"),
+		"GIT_VELOCITY_RUN_PERIOD_END=2024-03-31T00:00:00Z",
+		"GIT_VELOCITY_RUN_PERIOD_START=2024-03-01T00:00:00Z",
+	}, strings.Split(strings.TrimSpace(string(env)), "\n"))
+
+	err = a.runHook(context.Background(), hooks.PreAnalyze, "exit 1", marchRange())
+	assert.ErrorContains(t, err, "pre_analyze hook failed")
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/lukaszraczylo/git-velocity/internal/archive"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// collectReactions adds the kudos reactions on a repository's pull requests,
+// reviews and issue comments to data. The REST API has no reactions on
+// reviews, so theirs are only fetched over GraphQL.
+func (a *App) collectReactions(ctx context.Context, owner, name string, data *models.RawData) {
+	repoName := fmt.Sprintf("%s/%s", owner, name)
+	fetched, failed := 0, 0
+	var lastErr error
+	// fail records a failed fetch and reports whether to stop, when the
+	// source has no reactions at all
+	fail := func(err error) bool {
+		failed++
+		lastErr = err
+		return errors.Is(err, archive.ErrNotExported)
+	}
+	defer func() {
+		if failed > 0 {
+			a.log("    Warning: failed to fetch reactions of %d items: %v", failed, lastErr)
+		}
+		if fetched > 0 {
+			a.log("    Fetched %d kudos reactions", fetched)
+		}
+	}()
+
+	var reviewed []int
+	for _, review := range data.Reviews {
+		if review.Repository == repoName && !slices.Contains(reviewed, review.PullRequest) {
+			reviewed = append(reviewed, review.PullRequest)
+		}
+	}
+
+	for i := range data.PullRequests {
+		pr := &data.PullRequests[i]
+		if pr.Repository != repoName {
+			continue
+		}
+		reactions, err := a.client.FetchIssueReactions(ctx, owner, name, pr.Number)
+		if err != nil {
+			if fail(err) {
+				return
+			}
+			continue
+		}
+		pr.Reactions = reactions
+		fetched += len(reactions)
+	}
+
+	if a.client.HasGraphQL() {
+		slices.Sort(reviewed)
+		byReview := make(map[int64][]models.Reaction)
+		for _, number := range reviewed {
+			reactions, err := a.client.FetchReviewReactions(ctx, owner, name, number)
+			if err != nil {
+				fail(err)
+				continue
+			}
+			for id, r := range reactions {
+				byReview[id] = r
+			}
+		}
+		for i := range data.Reviews {
+			review := &data.Reviews[i]
+			if review.Repository == repoName && review.ID != 0 {
+				review.Reactions = byReview[review.ID]
+				fetched += len(review.Reactions)
+			}
+		}
+	}
+
+	for i := range data.IssueComments {
+		comment := &data.IssueComments[i]
+		if comment.Repository != repoName || comment.ID == 0 {
+			continue
+		}
+		reactions, err := a.client.FetchCommentReactions(ctx, owner, name, comment.ID)
+		if err != nil {
+			if fail(err) {
+				return
+			}
+			continue
+		}
+		comment.Reactions = reactions
+		fetched += len(reactions)
+	}
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lukaszraczylo/git-velocity/internal/pathfilter"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// scopePullRequests keeps only pull requests that change files selected by
+// scope, with their line counts restricted to those files, and the reviews
+// of the pull requests that remain
+func (a *App) scopePullRequests(ctx context.Context, owner, name string, scope *pathfilter.Filter, prs []models.PullRequest, reviews []models.Review) ([]models.PullRequest, []models.Review) {
+	a.log("    Fetching changed files of %d pull requests for path scoping...", len(prs))
+
+	kept := make(map[int]bool, len(prs))
+	var scoped []models.PullRequest
+	for _, pr := range prs {
+		files, err := a.client.FetchPullRequestFiles(ctx, owner, name, pr.Number)
+		if err != nil {
+			// Keep the PR unscoped rather than silently dropping work
+			a.log("    Warning: failed to fetch files for PR #%d, counting it in full: %v", pr.Number, err)
+			kept[pr.Number] = true
+			scoped = append(scoped, pr)
+			continue
+		}
+		if pr, ok := scopePullRequest(pr, files, scope); ok {
+			kept[pr.Number] = true
+			scoped = append(scoped, pr)
+		}
+	}
+
+	var scopedReviews []models.Review
+	for _, r := range reviews {
+		if kept[r.PullRequest] {
+			scopedReviews = append(scopedReviews, r)
+		}
+	}
+
+	a.log("    %d of %d pull requests touch the scoped paths", len(scoped), len(prs))
+	return scoped, scopedReviews
+}
+
+// scopePullRequest restricts pr to the files selected by scope.
+// It returns false when the pull request changes none of them.
+func scopePullRequest(pr models.PullRequest, files []models.PullRequestFile, scope *pathfilter.Filter) (models.PullRequest, bool) {
+	pr.Additions, pr.Deletions, pr.FilesChanged = 0, 0, 0
+	pr.FilesModified = nil
+
+	for _, f := range files {
+		if !scope.MatchAny(f.Path, f.PreviousPath) {
+			continue
+		}
+		pr.Additions += f.Additions
+		pr.Deletions += f.Deletions
+		pr.FilesChanged++
+		pr.FilesModified = append(pr.FilesModified, f.Path)
+	}
+
+	return pr, pr.FilesChanged > 0
+}
+
+// attachPullRequestFiles records the paths each pull request changes, so
+// path_ownership can attribute it to the teams owning them
+func (a *App) attachPullRequestFiles(ctx context.Context, owner, name string, prs []models.PullRequest) {
+	a.log("    Fetching changed files of %d pull requests for path ownership...", len(prs))
+
+	for i := range prs {
+		pr := &prs[i]
+		files, err := a.client.FetchPullRequestFiles(ctx, owner, name, pr.Number)
+		if err != nil {
+			a.log("    Warning: failed to fetch files for PR #%d, it is left out of path ownership: %v", pr.Number, err)
+			continue
+		}
+		pr.FilesModified = make([]string, 0, len(files))
+		for _, f := range files {
+			pr.FilesModified = append(pr.FilesModified, f.Path)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/pathfilter"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestScopePullRequest(t *testing.T) {
+	t.Parallel()
+
+	scope, err := pathfilter.New([]string{"services/payments/**"})
+	require.NoError(t, err)
+
+	pr := models.PullRequest{Number: 1, Additions: 100, Deletions: 50, FilesChanged: 3}
+	files := []models.PullRequestFile{
+		{Path: "services/payments/api.go", Additions: 10, Deletions: 2},
+		{Path: "services/billing/api.go", Additions: 80, Deletions: 40},
+		{Path: "services/payments/legacy.go", PreviousPath: "services/old/legacy.go", Additions: 10, Deletions: 8},
+	}
+
+	scoped, ok := scopePullRequest(pr, files, scope)
+	require.True(t, ok)
+	assert.Equal(t, 20, scoped.Additions)
+	assert.Equal(t, 10, scoped.Deletions)
+	assert.Equal(t, 2, scoped.FilesChanged)
+	assert.Equal(t, []string{"services/payments/api.go", "services/payments/legacy.go"}, scoped.FilesModified)
+
+	// A move out of scope still counts as touching it
+	moved := []models.PullRequestFile{{Path: "services/shared/util.go", PreviousPath: "services/payments/util.go"}}
+	_, ok = scopePullRequest(pr, moved, scope)
+	assert.True(t, ok)
+
+	outside := []models.PullRequestFile{{Path: "web/index.ts", Additions: 5}}
+	_, ok = scopePullRequest(pr, outside, scope)
+	assert.False(t, ok)
+}
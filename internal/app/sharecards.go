@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/generator/site"
+	"github.com/lukaszraczylo/git-velocity/internal/httpx"
+	"github.com/lukaszraczylo/git-velocity/pkg/version"
+)
+
+// maxAvatarBytes limits the size of an avatar downloaded for share cards
+const maxAvatarBytes = 2 << 20
+
+// avatarSource downloads the avatars drawn on share cards, or returns nil
+// when share cards are off
+func (a *App) avatarSource(ctx context.Context) (site.AvatarSource, error) {
+	if !a.config.Output.ShareCards {
+		return nil, nil
+	}
+	transport, err := httpx.New(a.config.Network)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Transport: transport, Timeout: 10 * time.Second}
+
+	return func(url string) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "git-velocity/"+version.Version)
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("avatar request returned status %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxAvatarBytes))
+	}, nil
+}
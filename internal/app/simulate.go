@@ -0,0 +1,55 @@
+package app
+
+import (
+	"io"
+
+	"github.com/lukaszraczylo/git-velocity/internal/compare"
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/domain/scoring"
+	"github.com/lukaszraczylo/git-velocity/internal/snapshot"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Simulate re-scores the cached raw data snapshot with an alternative points file
+// and reports how the leaderboard would change. Nothing is fetched from the network.
+func (a *App) Simulate(pointsPath string, asJSON bool, w io.Writer) error {
+	snap, err := snapshot.Load(a.config.Cache.Directory)
+	if err != nil {
+		return err
+	}
+	a.log("Using raw data snapshot from %s (%d commits, %d PRs, %d reviews, %d issues)",
+		snap.CreatedAt.Format("2006-01-02 15:04"), len(snap.Data.Commits), len(snap.Data.PullRequests),
+		len(snap.Data.Reviews), len(snap.Data.Issues))
+
+	points, err := config.LoadPoints(pointsPath, a.config.Scoring.Points)
+	if err != nil {
+		return err
+	}
+
+	current, err := a.score(snap, a.config)
+	if err != nil {
+		return err
+	}
+
+	simulatedConfig := *a.config
+	simulatedConfig.Scoring.Points = points
+	simulated, err := a.score(snap, &simulatedConfig)
+	if err != nil {
+		return err
+	}
+
+	report := compare.Compare(current, simulated)
+	if asJSON {
+		return report.WriteJSON(w)
+	}
+	return report.WriteText(w)
+}
+
+// score aggregates and scores snapshot data with the given configuration
+func (a *App) score(snap *snapshot.Snapshot, cfg *config.Config) (*models.GlobalMetrics, error) {
+	metrics, _, err := a.aggregate(snap, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return scoring.NewCalculator(cfg).Calculate(metrics), nil
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/snapshot"
+)
+
+func TestApp_StaleSnapshot(t *testing.T) {
+	t.Parallel()
+
+	// A snapshot from before the raw data gained fields, stamped with the
+	// output schema version
+	const stale = `{"schema_version": 1, "data": {"commits": []}}`
+
+	tests := map[string]func(a *App) error{
+		"analyze --offline": func(a *App) error {
+			a.config.Options.Offline = true
+			return a.Run(context.Background())
+		},
+		"score --simulate": func(a *App) error {
+			return a.Simulate("points.yaml", false, io.Discard)
+		},
+	}
+	for name, run := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			source := &fakeSource{}
+			a := fakeApp(source)
+			a.config.Cache.Directory = t.TempDir()
+			require.NoError(t, os.WriteFile(snapshot.Path(a.config.Cache.Directory), []byte(stale), 0600))
+
+			err := run(a)
+			assert.ErrorContains(t, err, "run analyze again")
+			assert.Empty(t, source.Calls(), "nothing is fetched instead")
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// maxLoggedMismatches bounds the mismatching commits listed at the end of a
+// run; run.json lists all of them
+const maxLoggedMismatches = 5
+
+// verifyLines compares the line counts of a sample of commits with GitHub's
+// commit stats, so bugs of the diff analyzer show up as mismatches
+func (a *App) verifyLines(ctx context.Context, commits []models.Commit) (*models.LineVerification, error) {
+	sample := sampleCommits(commits, a.scoped, a.config.Options.Verify.Sample)
+	a.log("  Comparing %d of %d commits with GitHub", len(sample), len(commits))
+
+	v := &models.LineVerification{Sampled: len(sample)}
+	var additions, deletions, githubAdditions, githubDeletions int
+	for _, c := range sample {
+		owner, name, _ := strings.Cut(c.Repository, "/")
+		stats, err := a.client.FetchCommitStats(ctx, owner, name, c.SHA)
+		if err != nil {
+			return nil, err
+		}
+
+		additions += c.Additions
+		deletions += c.Deletions
+		githubAdditions += stats.Additions
+		githubDeletions += stats.Deletions
+		if c.Additions != stats.Additions || c.Deletions != stats.Deletions {
+			v.Mismatches = append(v.Mismatches, models.LineMismatch{
+				Repository:      c.Repository,
+				SHA:             c.SHA,
+				Additions:       c.Additions,
+				Deletions:       c.Deletions,
+				GitHubAdditions: stats.Additions,
+				GitHubDeletions: stats.Deletions,
+			})
+		}
+	}
+
+	v.Mismatched = len(v.Mismatches)
+	if v.Sampled > 0 {
+		v.Percent = float64(v.Mismatched) / float64(v.Sampled) * 100
+	}
+	v.AdditionsDelta = deltaPercent(additions, githubAdditions)
+	v.DeletionsDelta = deltaPercent(deletions, githubDeletions)
+	return v, nil
+}
+
+// sampleCommits picks up to n commits spread evenly over commits (all of
+// them when n is 0), skipping path-scoped repositories whose commits count
+// only part of the lines GitHub reports
+func sampleCommits(commits []models.Commit, scoped map[string]bool, n int) []models.Commit {
+	var candidates []models.Commit
+	for _, c := range commits {
+		if !scoped[c.Repository] {
+			candidates = append(candidates, c)
+		}
+	}
+	if n == 0 || len(candidates) <= n {
+		return candidates
+	}
+
+	sample := make([]models.Commit, 0, n)
+	step := float64(len(candidates)) / float64(n)
+	for i := range n {
+		sample = append(sample, candidates[int(float64(i)*step)])
+	}
+	return sample
+}
+
+// deltaPercent returns how far local is from the GitHub count, in percent
+func deltaPercent(local, github int) float64 {
+	if github == 0 {
+		return 0
+	}
+	return float64(local-github) / float64(github) * 100
+}
+
+// verificationLines summarizes a line verification for the end of a run
+func verificationLines(v *models.LineVerification) []string {
+	if v.Mismatched == 0 {
+		return []string{fmt.Sprintf("Line verification: all %d sampled commits match GitHub", v.Sampled)}
+	}
+
+	lines := []string{fmt.Sprintf("Line verification: %d of %d sampled commits differ from GitHub (%.1f%%); additions %+.1f%%, deletions %+.1f%%",
+		v.Mismatched, v.Sampled, v.Percent, v.AdditionsDelta, v.DeletionsDelta)}
+	for i, m := range v.Mismatches {
+		if i == maxLoggedMismatches {
+			lines = append(lines, fmt.Sprintf("  ... and %d more (see data/run.json)", len(v.Mismatches)-i))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  %s@%s: +%d -%d locally, +%d -%d on GitHub",
+			m.Repository, shortSHA(m.SHA), m.Additions, m.Deletions, m.GitHubAdditions, m.GitHubDeletions))
+	}
+	return lines
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestSampleCommits(t *testing.T) {
+	t.Parallel()
+
+	var commits []models.Commit
+	for i := range 10 {
+		commits = append(commits, models.Commit{SHA: fmt.Sprint(i), Repository: "acme/api"})
+	}
+	commits = append(commits, models.Commit{SHA: "scoped", Repository: "acme/mono"})
+	scoped := map[string]bool{"acme/mono": true}
+
+	shas := func(commits []models.Commit) []string {
+		var shas []string
+		for _, c := range commits {
+			shas = append(shas, c.SHA)
+		}
+		return shas
+	}
+	assert.Equal(t, []string{"0", "2", "4", "6", "8"}, shas(sampleCommits(commits, scoped, 5)), "spread evenly")
+	assert.Equal(t, []string{"0", "3", "6"}, shas(sampleCommits(commits, scoped, 3)))
+	assert.Len(t, sampleCommits(commits, scoped, 0), 10, "0 samples every commit")
+	assert.Len(t, sampleCommits(commits, scoped, 50), 10, "scoped repositories are skipped")
+}
+
+func TestApp_VerifyLines(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{commitStats: map[string]github.CommitStats{
+		"aaa": {Additions: 10, Deletions: 2},
+		"bbb": {Additions: 30, Deletions: 8},
+	}}
+	a := fakeApp(source)
+	commits := []models.Commit{
+		{SHA: "aaa", Repository: "acme/api", Additions: 10, Deletions: 2},
+		{SHA: "bbb", Repository: "acme/api", Additions: 20, Deletions: 8},
+	}
+
+	v, err := a.verifyLines(context.Background(), commits)
+	require.NoError(t, err)
+	assert.Equal(t, 2, v.Sampled)
+	assert.Equal(t, 1, v.Mismatched)
+	assert.InDelta(t, 50.0, v.Percent, 0.001)
+	assert.InDelta(t, -25.0, v.AdditionsDelta, 0.001, "30 of 40 GitHub additions counted")
+	assert.InDelta(t, 0.0, v.DeletionsDelta, 0.001)
+	assert.Equal(t, []models.LineMismatch{{
+		Repository: "acme/api", SHA: "bbb", Additions: 20, Deletions: 8, GitHubAdditions: 30, GitHubDeletions: 8,
+	}}, v.Mismatches)
+	assert.Equal(t, []string{
+		"Line verification: 1 of 2 sampled commits differ from GitHub (50.0%); additions -25.0%, deletions +0.0%",
+		"  acme/api@bbb: +20 -8 locally, +30 -8 on GitHub",
+	}, verificationLines(v))
+
+	_, err = a.verifyLines(context.Background(), append(commits, models.Commit{SHA: "ccc", Repository: "acme/api"}))
+	require.ErrorContains(t, err, "no commit found for ccc")
+}
+
+func TestVerificationLines_Match(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"Line verification: all 20 sampled commits match GitHub"},
+		verificationLines(&models.LineVerification{Sampled: 20}))
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/httpx"
+	"github.com/lukaszraczylo/git-velocity/internal/telemetry"
+	"github.com/lukaszraczylo/git-velocity/internal/warehouse"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// exportWarehouses appends the fact tables of the run to the configured
+// data warehouses
+func (a *App) exportWarehouses(ctx context.Context, metrics *models.GlobalMetrics, runAt time.Time) (err error) {
+	if !a.config.Warehouse.Enabled() {
+		return nil
+	}
+	ctx, span := telemetry.Start(ctx, "export")
+	defer func() { telemetry.End(span, err) }()
+
+	if err := a.config.ResolveWarehouseTokens(ctx); err != nil {
+		return err
+	}
+	transport, err := httpx.New(a.config.Network)
+	if err != nil {
+		return err
+	}
+
+	tables := warehouse.Facts(metrics, a.config, runAt)
+	for _, exporter := range warehouse.Exporters(a.config.Warehouse, transport) {
+		a.log("Exporting to %s...", exporter.Name())
+		if err := exporter.Export(ctx, tables); err != nil {
+			return fmt.Errorf("failed to export to %s: %w", exporter.Name(), err)
+		}
+	}
+	a.log("Exported %d contributor and %d repository rows", len(tables[0].Rows), len(tables[1].Rows))
+	return nil
+}
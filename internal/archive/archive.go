@@ -0,0 +1,130 @@
+// Package archive reads exported GitHub data as a data source instead of the
+// API: a migration export (the archive of an organization or user
+// migration), analyzed fully offline, or GH Archive event files for
+// backfills beyond the reach of API pagination and rate limits.
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotExported is returned for data an export doesn't contain
+var ErrNotExported = errors.New("not included in the export")
+
+// Open reads a migration export from a directory or a .tar.gz archive,
+// which is extracted to a temporary directory removed by Close
+func Open(path string) (*Source, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	dir, temp := path, false
+	if !info.IsDir() {
+		if dir, err = os.MkdirTemp("", "git-velocity-archive-"); err != nil {
+			return nil, fmt.Errorf("failed to create extraction directory: %w", err)
+		}
+		temp = true
+		if err := extract(path, dir); err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, fmt.Errorf("failed to extract %s: %w", path, err)
+		}
+	}
+
+	s, err := load(dir)
+	if err != nil {
+		if temp {
+			_ = os.RemoveAll(dir)
+		}
+		return nil, err
+	}
+	s.temp = temp
+	return s, nil
+}
+
+// Close removes the directory a .tar.gz archive was extracted to
+func (s *Source) Close() error {
+	if !s.temp {
+		return nil
+	}
+	return os.RemoveAll(s.dir)
+}
+
+// RepositoriesDir returns the directory of the exported bare repositories,
+// laid out as <owner>/<name>.git, or "" when they are cloned from GitHub
+func (s *Source) RepositoriesDir() string {
+	if s.dir == "" {
+		return ""
+	}
+	return filepath.Join(s.dir, "repositories")
+}
+
+// extract unpacks the regular files and directories of a .tar.gz archive
+// into dir, refusing entries that would land outside of it
+func extract(path, dir string) error {
+	f, err := os.Open(filepath.Clean(path)) // #nosec G304 -- path is the configured archive
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.FromSlash(strings.TrimPrefix(hdr.Name, "./"))
+		if name == "" || name == "." {
+			continue
+		}
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("entry %q is outside of the archive", hdr.Name)
+		}
+		target := filepath.Join(dir, name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0750); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr); err != nil {
+				return err
+			}
+		}
+		// Links and other entries are never part of migration exports
+	}
+}
+
+func writeFile(path string, r io.Reader) error {
+	out, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) // #nosec G304 -- path is checked to be within the extraction directory
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil { // #nosec G110 -- the archive is the user's own export
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
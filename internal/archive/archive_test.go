@@ -0,0 +1,231 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// fixtureExport holds the JSON records of a small organization migration
+var fixtureExport = map[string]string{
+	"users_000001.json": `[
+		{"type": "user", "url": "https://github.com/alice", "login": "alice", "name": "Alice A", "avatar_url": "https://avatars.example/alice",
+		 "emails": [{"address": "old@example.com", "primary": false}, {"address": "alice@example.com", "primary": true}]},
+		{"type": "user", "url": "https://github.com/bob", "login": "bob", "name": "Bob B"}
+	]`,
+	"organizations_000001.json": `[
+		{"type": "organization", "url": "https://github.com/acme", "login": "acme",
+		 "members": [{"user": "https://github.com/alice", "role": "admin"}, {"user": "https://github.com/bob", "role": "direct_member"}]}
+	]`,
+	"repositories_000001.json": `[
+		{"type": "repository", "url": "https://github.com/acme/widgets", "name": "widgets", "default_branch": "main"},
+		{"type": "repository", "url": "https://github.com/acme/gadgets", "name": "gadgets", "default_branch": "trunk"}
+	]`,
+	"pull_requests_000001.json": `[
+		{"type": "pull_request", "url": "https://github.com/acme/widgets/pull/1", "user": "https://github.com/alice",
+		 "repository": "https://github.com/acme/widgets", "title": "Add widgets",
+		 "base": {"ref": "main"}, "head": {"ref": "widgets"}, "labels": ["https://github.com/acme/widgets/labels/good%20first%20issue"],
+		 "created_at": "2024-03-04T09:00:00Z", "merged_at": "2024-03-05T10:00:00Z", "closed_at": "2024-03-05T10:00:00Z"},
+		{"type": "pull_request", "url": "https://github.com/acme/widgets/pull/2", "user": "https://github.com/bob",
+		 "repository": "https://github.com/acme/widgets", "title": "Old change",
+		 "base": {"ref": "main"}, "head": {"ref": "old"}, "created_at": "2023-01-01T00:00:00Z", "closed_at": "2023-01-02T00:00:00Z"},
+		{"type": "pull_request", "url": "https://github.com/acme/widgets/pull/4", "user": "https://github.com/bob",
+		 "repository": "https://github.com/acme/widgets", "title": "Still open",
+		 "base": {"ref": "main"}, "head": {"ref": "open"}, "created_at": "2024-03-20T00:00:00Z"}
+	]`,
+	"pull_request_reviews_000001.json": `[
+		{"type": "pull_request_review", "url": "https://github.com/acme/widgets/pull/1/files#pullrequestreview-12",
+		 "pull_request": "https://github.com/acme/widgets/pull/1", "user": "https://github.com/bob", "state": 40,
+		 "created_at": "2024-03-04T12:00:00Z", "submitted_at": "2024-03-04T12:00:00Z"},
+		{"type": "pull_request_review", "url": "https://github.com/acme/widgets/pull/1/files#pullrequestreview-11",
+		 "pull_request": "https://github.com/acme/widgets/pull/1", "user": "https://github.com/bob", "state": 30, "body": "Needs tests",
+		 "created_at": "2024-03-04T10:00:00Z", "submitted_at": "2024-03-04T10:00:00Z"}
+	]`,
+	"issues_000001.json": `[
+		{"type": "issue", "url": "https://github.com/acme/widgets/issues/3", "user": "https://github.com/bob",
+		 "repository": "https://github.com/acme/widgets", "title": "Widgets are square",
+		 "created_at": "2024-03-06T00:00:00Z", "closed_at": "2024-03-08T00:00:00Z"}
+	]`,
+	"issue_comments_000001.json": `[
+		{"type": "issue_comment", "url": "https://github.com/acme/widgets/issues/3#issuecomment-31",
+		 "issue": "https://github.com/acme/widgets/issues/3", "user": "https://github.com/alice", "body": "Round ones next",
+		 "created_at": "2024-03-07T00:00:00Z"},
+		{"type": "issue_comment", "url": "https://github.com/acme/widgets/pull/1#issuecomment-32",
+		 "pull_request": "https://github.com/acme/widgets/pull/1", "user": "https://github.com/bob", "body": "Nice",
+		 "created_at": "2024-03-04T11:00:00Z"}
+	]`,
+}
+
+func writeExport(t *testing.T, dir string) {
+	t.Helper()
+	for name, content := range fixtureExport {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+}
+
+func date(s string) *time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return &t
+}
+
+func TestOpen_Directory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeExport(t, dir)
+	s, err := Open(dir)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+	assert.DirExists(t, dir, "directories are read in place")
+	assert.Equal(t, filepath.Join(dir, "repositories"), s.RepositoriesDir())
+
+	ctx := context.Background()
+	since, until := date("2024-03-01T00:00:00Z"), date("2024-03-31T23:59:59Z")
+
+	repos, err := s.ListOrgRepos(ctx, "ACME", "*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gadgets", "widgets"}, repos)
+	repos, err = s.ListOrgRepos(ctx, "acme", "wid*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"widgets"}, repos)
+
+	settings, err := s.FetchRepositorySettings(ctx, "acme", "gadgets")
+	require.NoError(t, err)
+	assert.Equal(t, "trunk", settings.DefaultBranch)
+	_, err = s.FetchRepositorySettings(ctx, "acme", "missing")
+	require.Error(t, err)
+
+	prs, err := s.FetchPullRequests(ctx, "acme", "widgets", since, until)
+	require.NoError(t, err)
+	require.Len(t, prs, 2, "PR #2 was closed before the range")
+	assert.Equal(t, 4, prs[0].Number)
+	assert.Equal(t, models.PRStateOpen, prs[0].State)
+	merged := prs[1]
+	assert.Equal(t, models.PRStateMerged, merged.State)
+	assert.Equal(t, "acme/widgets", merged.Repository)
+	assert.Equal(t, models.Author{Login: "alice", Name: "Alice A", AvatarURL: "https://avatars.example/alice"}, merged.Author)
+	assert.Equal(t, "main", merged.BaseBranch)
+	assert.Equal(t, []string{"good first issue"}, merged.Labels)
+	assert.Equal(t, *date("2024-03-05T10:00:00Z"), merged.UpdatedAt)
+
+	reviews, err := s.FetchReviews(ctx, "acme", "widgets", 1)
+	require.NoError(t, err)
+	require.Len(t, reviews, 2)
+	assert.Equal(t, int64(11), reviews[0].ID, "ordered by submission")
+	assert.Equal(t, models.ReviewChangesRequested, reviews[0].State)
+	assert.Equal(t, models.ReviewApproved, reviews[1].State)
+	assert.Equal(t, 1, reviews[1].PullRequest)
+
+	issues, err := s.FetchIssues(ctx, "acme", "widgets", since, until)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, 3, issues[0].Number)
+	assert.True(t, issues[0].IsClosed())
+
+	comments, err := s.FetchIssueComments(ctx, "acme", "widgets", since, until)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, 3, comments[0].Issue)
+	assert.Equal(t, int64(31), comments[0].ID)
+	assert.Equal(t, 1, comments[1].Issue, "PR conversation comments are issue comments")
+
+	profiles, err := s.FetchUserProfiles(ctx, []string{"Alice", "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]github.UserProfile{
+		"Alice": {Login: "alice", Name: "Alice A", Email: "alice@example.com", AvatarURL: "https://avatars.example/alice"},
+	}, profiles)
+
+	members, err := s.FetchOrgMembers(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, members)
+	_, err = s.FetchOrgMembers(ctx, "alice")
+	require.ErrorIs(t, err, github.ErrNotOrganization)
+
+	_, err = s.FetchAuditLog(ctx, "acme", nil, since, until)
+	require.ErrorIs(t, err, ErrNotExported)
+	_, err = s.FetchAdoption(ctx, "acme", "widgets", *since, 1)
+	require.ErrorIs(t, err, ErrNotExported)
+	_, err = s.FetchReviewComments(ctx, "acme", "widgets", since, until)
+	require.ErrorIs(t, err, ErrNotExported)
+}
+
+func TestOpen_TarGz(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "export.tar.gz")
+	writeTarGz(t, path, fixtureExport)
+
+	s, err := Open(path)
+	require.NoError(t, err)
+	repos, err := s.ListOrgRepos(context.Background(), "acme", "*")
+	require.NoError(t, err)
+	assert.Len(t, repos, 2)
+
+	require.NoError(t, s.Close())
+	assert.NoDirExists(t, s.dir, "the extracted export is removed")
+}
+
+func TestOpen_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Open(filepath.Join(t.TempDir(), "missing.tar.gz"))
+	require.Error(t, err)
+
+	_, err = Open(t.TempDir())
+	require.ErrorContains(t, err, "no repositories found")
+
+	escaping := filepath.Join(t.TempDir(), "escaping.tar.gz")
+	writeTarGz(t, escaping, map[string]string{"../outside.json": "[]"})
+	_, err = Open(escaping)
+	require.ErrorContains(t, err, "outside of the archive")
+}
+
+func writeTarGz(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path) // #nosec G304 -- test file
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./" + name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+}
+
+func TestParseURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url    string
+		repo   string
+		number int
+		ok     bool
+	}{
+		{"https://github.com/acme/widgets", "acme/widgets", 0, true},
+		{"https://github.com/acme/widgets/pull/7", "acme/widgets", 7, true},
+		{"https://github.com/acme/widgets/issues/8#issuecomment-1", "acme/widgets", 8, true},
+		{"https://github.com/acme", "", 0, false},
+		{"https://github.com/acme/widgets/pull/x", "", 0, false},
+	}
+	for _, tt := range tests {
+		repo, number, ok := parseURL(tt.url)
+		assert.Equal(t, tt.ok, ok, tt.url)
+		assert.Equal(t, tt.repo, repo, tt.url)
+		assert.Equal(t, tt.number, number, tt.url)
+	}
+}
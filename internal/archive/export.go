@@ -0,0 +1,392 @@
+package archive
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Records of a migration export. Users, repositories, pull requests and
+// issues reference each other by their github.com URLs.
+type (
+	exportUser struct {
+		URL       string `json:"url"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+		Emails    []struct {
+			Address string `json:"address"`
+			Primary bool   `json:"primary"`
+		} `json:"emails"`
+	}
+
+	exportOrganization struct {
+		Login   string `json:"login"`
+		Members []struct {
+			User string `json:"user"`
+		} `json:"members"`
+	}
+
+	exportRepository struct {
+		URL           string `json:"url"`
+		DefaultBranch string `json:"default_branch"`
+	}
+
+	exportRef struct {
+		Ref string `json:"ref"`
+	}
+
+	exportPullRequest struct {
+		URL       string     `json:"url"`
+		User      string     `json:"user"`
+		Title     string     `json:"title"`
+		Body      string     `json:"body"`
+		Base      exportRef  `json:"base"`
+		Head      exportRef  `json:"head"`
+		Labels    []string   `json:"labels"`
+		MergedAt  *time.Time `json:"merged_at"`
+		ClosedAt  *time.Time `json:"closed_at"`
+		CreatedAt time.Time  `json:"created_at"`
+	}
+
+	exportReview struct {
+		URL         string          `json:"url"`
+		PullRequest string          `json:"pull_request"`
+		User        string          `json:"user"`
+		Body        string          `json:"body"`
+		State       json.RawMessage `json:"state"`
+		CreatedAt   time.Time       `json:"created_at"`
+		SubmittedAt *time.Time      `json:"submitted_at"`
+	}
+
+	exportIssue struct {
+		URL       string     `json:"url"`
+		User      string     `json:"user"`
+		Title     string     `json:"title"`
+		Labels    []string   `json:"labels"`
+		ClosedAt  *time.Time `json:"closed_at"`
+		CreatedAt time.Time  `json:"created_at"`
+	}
+
+	exportComment struct {
+		URL         string    `json:"url"`
+		Issue       string    `json:"issue"`
+		PullRequest string    `json:"pull_request"`
+		User        string    `json:"user"`
+		Body        string    `json:"body"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+)
+
+// reviewStates maps the numeric review states of migration exports
+var reviewStates = map[string]models.ReviewState{
+	"1":  models.ReviewCommented,
+	"30": models.ReviewChangesRequested,
+	"40": models.ReviewApproved,
+	"50": models.ReviewDismissed,
+}
+
+// load reads the JSON records of the migration export in dir
+func load(dir string) (*Source, error) {
+	s := &Source{
+		dir:      dir,
+		branches: make(map[string]string),
+		users:    make(map[string]github.UserProfile),
+		members:  make(map[string][]string),
+		prs:      make(map[string][]models.PullRequest),
+		reviews:  make(map[string]map[int][]models.Review),
+		issues:   make(map[string][]models.Issue),
+		comments: make(map[string][]models.IssueComment),
+	}
+
+	var users []exportUser
+	if err := readRecords(dir, "users", &users); err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		login := u.Login
+		if login == "" {
+			login = lastSegment(u.URL)
+		}
+		profile := github.UserProfile{Login: login, Name: u.Name, AvatarURL: u.AvatarURL}
+		for _, email := range u.Emails {
+			if email.Primary || profile.Email == "" {
+				profile.Email = email.Address
+			}
+		}
+		s.users[strings.ToLower(login)] = profile
+	}
+
+	var orgs []exportOrganization
+	if err := readRecords(dir, "organizations", &orgs); err != nil {
+		return nil, err
+	}
+	for _, org := range orgs {
+		key := strings.ToLower(org.Login)
+		for _, m := range org.Members {
+			s.members[key] = append(s.members[key], lastSegment(m.User))
+		}
+	}
+
+	var repos []exportRepository
+	if err := readRecords(dir, "repositories", &repos); err != nil {
+		return nil, err
+	}
+	for _, r := range repos {
+		name, _, ok := parseURL(r.URL)
+		if !ok {
+			continue
+		}
+		s.repos = append(s.repos, name)
+		s.branches[strings.ToLower(name)] = r.DefaultBranch
+	}
+	if len(s.repos) == 0 {
+		return nil, fmt.Errorf("no repositories found in %s; is it a migration export?", dir)
+	}
+	sort.Strings(s.repos)
+
+	var prs []exportPullRequest
+	if err := readRecords(dir, "pull_requests", &prs); err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		repo, number, ok := parseURL(pr.URL)
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(repo)
+		s.prs[key] = append(s.prs[key], s.convertPullRequest(pr, repo, number))
+	}
+
+	var reviews []exportReview
+	if err := readRecords(dir, "pull_request_reviews", &reviews); err != nil {
+		return nil, err
+	}
+	for _, r := range reviews {
+		repo, number, ok := parseURL(r.PullRequest)
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(repo)
+		if s.reviews[key] == nil {
+			s.reviews[key] = make(map[int][]models.Review)
+		}
+		s.reviews[key][number] = append(s.reviews[key][number], s.convertReview(r, repo, number))
+	}
+
+	var issues []exportIssue
+	if err := readRecords(dir, "issues", &issues); err != nil {
+		return nil, err
+	}
+	for _, i := range issues {
+		repo, number, ok := parseURL(i.URL)
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(repo)
+		s.issues[key] = append(s.issues[key], s.convertIssue(i, repo, number))
+	}
+
+	var comments []exportComment
+	if err := readRecords(dir, "issue_comments", &comments); err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		parent := c.Issue
+		if parent == "" {
+			parent = c.PullRequest
+		}
+		repo, number, ok := parseURL(parent)
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(repo)
+		s.comments[key] = append(s.comments[key], models.IssueComment{
+			ID:         fragmentID(c.URL),
+			Issue:      number,
+			Repository: repo,
+			Author:     s.author(c.User),
+			Body:       c.Body,
+			CreatedAt:  c.CreatedAt,
+		})
+	}
+
+	return s, nil
+}
+
+// readRecords decodes the <kind>_NNNNNN.json files of the export into records
+func readRecords[T any](dir, kind string, records *[]T) error {
+	files, err := filepath.Glob(filepath.Join(dir, kind+"_*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Clean(file)) // #nosec G304 -- file is within the configured archive
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		var page []T
+		if err := json.Unmarshal(data, &page); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+		*records = append(*records, page...)
+	}
+	return nil
+}
+
+// author returns the author a user URL refers to, with the name and avatar
+// of their exported profile
+func (s *Source) author(userURL string) models.Author {
+	login := lastSegment(userURL)
+	if login == "" {
+		return models.Author{}
+	}
+	author := models.Author{Login: login}
+	if profile, ok := s.users[strings.ToLower(login)]; ok {
+		author.Name = profile.Name
+		author.AvatarURL = profile.AvatarURL
+	}
+	return author
+}
+
+func (s *Source) convertPullRequest(pr exportPullRequest, repo string, number int) models.PullRequest {
+	state := models.PRStateOpen
+	if pr.MergedAt != nil {
+		state = models.PRStateMerged
+	} else if pr.ClosedAt != nil {
+		state = models.PRStateClosed
+	}
+
+	// Exports don't record the last update; the latest known change stands in
+	updatedAt := pr.CreatedAt
+	for _, at := range []*time.Time{pr.ClosedAt, pr.MergedAt} {
+		if at != nil && at.After(updatedAt) {
+			updatedAt = *at
+		}
+	}
+
+	return models.PullRequest{
+		Number:     number,
+		Title:      pr.Title,
+		Body:       pr.Body,
+		State:      state,
+		Author:     s.author(pr.User),
+		Repository: repo,
+		BaseBranch: pr.Base.Ref,
+		HeadBranch: pr.Head.Ref,
+		CreatedAt:  pr.CreatedAt,
+		UpdatedAt:  updatedAt,
+		MergedAt:   pr.MergedAt,
+		ClosedAt:   pr.ClosedAt,
+		Labels:     labelNames(pr.Labels),
+		URL:        pr.URL,
+	}
+}
+
+func (s *Source) convertReview(r exportReview, repo string, number int) models.Review {
+	submittedAt := r.CreatedAt
+	if r.SubmittedAt != nil {
+		submittedAt = *r.SubmittedAt
+	}
+
+	// Numeric in exports, though the API names are accepted too
+	state := models.ReviewState(strings.ToUpper(strings.Trim(string(r.State), `"`)))
+	if mapped, ok := reviewStates[string(state)]; ok {
+		state = mapped
+	}
+
+	return models.Review{
+		ID:          fragmentID(r.URL),
+		PullRequest: number,
+		Repository:  repo,
+		Author:      s.author(r.User),
+		State:       state,
+		SubmittedAt: submittedAt,
+		Body:        r.Body,
+	}
+}
+
+func (s *Source) convertIssue(i exportIssue, repo string, number int) models.Issue {
+	state := models.IssueStateOpen
+	updatedAt := i.CreatedAt
+	if i.ClosedAt != nil {
+		state = models.IssueStateClosed
+		updatedAt = *i.ClosedAt
+	}
+
+	return models.Issue{
+		Number:     number,
+		Title:      i.Title,
+		State:      state,
+		Author:     s.author(i.User),
+		Repository: repo,
+		CreatedAt:  i.CreatedAt,
+		UpdatedAt:  updatedAt,
+		ClosedAt:   i.ClosedAt,
+		Labels:     labelNames(i.Labels),
+		URL:        i.URL,
+	}
+}
+
+// parseURL splits a github.com URL of a repository, pull request or issue
+// into the owner/name of the repository and the number (0 for repositories)
+func parseURL(raw string) (repo string, number int, ok bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", 0, false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", 0, false
+	}
+	repo = parts[0] + "/" + parts[1]
+	if len(parts) >= 4 {
+		if number, err = strconv.Atoi(parts[3]); err != nil {
+			return "", 0, false
+		}
+	}
+	return repo, number, true
+}
+
+// lastSegment returns the last path segment of a URL, e.g. the login of a
+// user URL or the name of a label URL
+func lastSegment(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	segment := u.Path[strings.LastIndex(u.Path, "/")+1:]
+	if unescaped, err := url.PathUnescape(segment); err == nil {
+		return unescaped
+	}
+	return segment
+}
+
+// labelNames returns the names of exported label URLs
+func labelNames(urls []string) []string {
+	var names []string
+	for _, u := range urls {
+		if name := lastSegment(u); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// fragmentID returns the ID in a URL fragment like #issuecomment-123
+func fragmentID(raw string) int64 {
+	_, fragment, _ := strings.Cut(raw, "#")
+	id, _ := strconv.ParseInt(fragment[strings.LastIndex(fragment, "-")+1:], 10, 64)
+	return id
+}
@@ -0,0 +1,451 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// GH Archive (gharchive.org) events. Every event carries the pull request,
+// review, issue or comment as it was at the time of the event.
+type (
+	ghaEvent struct {
+		Type      string          `json:"type"`
+		Actor     ghaUser         `json:"actor"`
+		Repo      ghaRepo         `json:"repo"`
+		Payload   json.RawMessage `json:"payload"`
+		CreatedAt time.Time       `json:"created_at"`
+	}
+
+	ghaRepo struct {
+		Name string `json:"name"`
+	}
+
+	ghaUser struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	}
+
+	ghaLabel struct {
+		Name string `json:"name"`
+	}
+
+	ghaPullRequest struct {
+		Number int       `json:"number"`
+		Title  string    `json:"title"`
+		Body   string    `json:"body"`
+		User   ghaUser   `json:"user"`
+		Merged bool      `json:"merged"`
+		Base   ghaBranch `json:"base"`
+		Head   struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Labels            []ghaLabel `json:"labels"`
+		HTMLURL           string     `json:"html_url"`
+		AuthorAssociation string     `json:"author_association"`
+		MergeCommitSHA    string     `json:"merge_commit_sha"`
+		Additions         int        `json:"additions"`
+		Deletions         int        `json:"deletions"`
+		ChangedFiles      int        `json:"changed_files"`
+		Commits           int        `json:"commits"`
+		Comments          int        `json:"comments"`
+		CreatedAt         time.Time  `json:"created_at"`
+		UpdatedAt         time.Time  `json:"updated_at"`
+		MergedAt          *time.Time `json:"merged_at"`
+		MergedBy          *ghaUser   `json:"merged_by"`
+		ClosedAt          *time.Time `json:"closed_at"`
+		AutoMerge         *struct {
+			EnabledBy *ghaUser `json:"enabled_by"`
+		} `json:"auto_merge"`
+	}
+
+	ghaBranch struct {
+		Ref  string `json:"ref"`
+		Repo struct {
+			DefaultBranch string `json:"default_branch"`
+		} `json:"repo"`
+	}
+
+	ghaIssue struct {
+		Number            int             `json:"number"`
+		Title             string          `json:"title"`
+		User              ghaUser         `json:"user"`
+		State             string          `json:"state"`
+		Labels            []ghaLabel      `json:"labels"`
+		Comments          int             `json:"comments"`
+		HTMLURL           string          `json:"html_url"`
+		AuthorAssociation string          `json:"author_association"`
+		PullRequest       json.RawMessage `json:"pull_request"`
+		CreatedAt         time.Time       `json:"created_at"`
+		UpdatedAt         time.Time       `json:"updated_at"`
+		ClosedAt          *time.Time      `json:"closed_at"`
+	}
+
+	ghaPayload struct {
+		Action      string          `json:"action"`
+		PullRequest *ghaPullRequest `json:"pull_request"`
+		Issue       *ghaIssue       `json:"issue"`
+		Review      *struct {
+			ID          int64      `json:"id"`
+			User        ghaUser    `json:"user"`
+			State       string     `json:"state"`
+			Body        string     `json:"body"`
+			SubmittedAt *time.Time `json:"submitted_at"`
+			CommitID    string     `json:"commit_id"`
+		} `json:"review"`
+		Comment *struct {
+			ID        int64     `json:"id"`
+			User      ghaUser   `json:"user"`
+			Body      string    `json:"body"`
+			CreatedAt time.Time `json:"created_at"`
+		} `json:"comment"`
+	}
+)
+
+// ghaEventTypes are the events read from GH Archive files
+var ghaEventTypes = map[string]bool{
+	"PullRequestEvent":       true,
+	"PullRequestReviewEvent": true,
+	"IssuesEvent":            true,
+	"IssueCommentEvent":      true,
+}
+
+// OpenGHArchive reads the hourly GH Archive files (.json.gz or .json) in dir,
+// keeping the events of the repositories of owners. Repositories are cloned
+// from GitHub as usual; GH Archive only covers public repositories.
+func OpenGHArchive(dir string, owners []string) (*Source, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	wanted := make(map[string]bool, len(owners))
+	for _, owner := range owners {
+		wanted[strings.ToLower(owner)] = true
+	}
+
+	r := &ghaReader{
+		source: &Source{
+			branches: make(map[string]string),
+			users:    make(map[string]github.UserProfile),
+			prs:      make(map[string][]models.PullRequest),
+			reviews:  make(map[string]map[int][]models.Review),
+			issues:   make(map[string][]models.Issue),
+			comments: make(map[string][]models.IssueComment),
+		},
+		wanted:   wanted,
+		repos:    make(map[string]string),
+		prs:      make(map[string]*ghaVersion[models.PullRequest]),
+		reviews:  make(map[int64]models.Review),
+		issues:   make(map[string]*ghaVersion[models.Issue]),
+		comments: make(map[int64]*ghaVersion[models.IssueComment]),
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".json") && !strings.HasSuffix(file, ".json.gz") {
+			continue
+		}
+		if err := r.readFile(file); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+	}
+	if len(r.repos) == 0 {
+		return nil, fmt.Errorf("no events of the configured repositories found in %s", dir)
+	}
+	return r.finish(), nil
+}
+
+// ghaVersion is the latest known state of an item and when it was seen
+type ghaVersion[T any] struct {
+	at      time.Time
+	item    T
+	deleted bool
+}
+
+// ghaReader replays events, keeping the latest state of each item
+type ghaReader struct {
+	source *Source
+	wanted map[string]bool // Lowercase owners
+
+	repos    map[string]string // owner/name by lowercase owner/name
+	prs      map[string]*ghaVersion[models.PullRequest]
+	reviews  map[int64]models.Review
+	issues   map[string]*ghaVersion[models.Issue]
+	comments map[int64]*ghaVersion[models.IssueComment]
+}
+
+func (r *ghaReader) readFile(path string) error {
+	f, err := os.Open(filepath.Clean(path)) // #nosec G304 -- file is within the configured directory
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	var in io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gz.Close() }()
+		in = gz
+	}
+
+	// Events are one JSON object per line, some of them megabytes long
+	br := bufio.NewReaderSize(in, 1<<20)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if err := r.readEvent(line); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (r *ghaReader) readEvent(line []byte) error {
+	var event ghaEvent
+	if err := json.Unmarshal(line, &event); err != nil {
+		return err
+	}
+	if !ghaEventTypes[event.Type] {
+		return nil
+	}
+	owner, _, ok := strings.Cut(event.Repo.Name, "/")
+	if !ok || !r.wanted[strings.ToLower(owner)] {
+		return nil
+	}
+
+	var payload ghaPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("%s of %s: %w", event.Type, event.Repo.Name, err)
+	}
+
+	repo := event.Repo.Name
+	r.repos[strings.ToLower(repo)] = repo
+	r.user(event.Actor)
+
+	switch event.Type {
+	case "PullRequestEvent", "PullRequestReviewEvent":
+		if payload.PullRequest == nil || payload.PullRequest.Number == 0 {
+			return nil
+		}
+		pr := payload.PullRequest
+		if branch := pr.Base.Repo.DefaultBranch; branch != "" {
+			r.source.branches[strings.ToLower(repo)] = branch
+		}
+		key := itemKey(repo, pr.Number)
+		if event.Type == "PullRequestEvent" {
+			update(r.prs, key, &ghaVersion[models.PullRequest]{at: event.CreatedAt, item: r.convertPullRequest(*pr, repo)})
+		} else if _, ok := r.prs[key]; !ok {
+			// Review events carry a leaner pull request, only kept until
+			// a pull request event is seen
+			r.prs[key] = &ghaVersion[models.PullRequest]{item: r.convertPullRequest(*pr, repo)}
+		}
+		if review := payload.Review; review != nil && review.ID != 0 {
+			submittedAt := event.CreatedAt
+			if review.SubmittedAt != nil {
+				submittedAt = *review.SubmittedAt
+			}
+			r.reviews[review.ID] = models.Review{
+				ID:          review.ID,
+				PullRequest: pr.Number,
+				Repository:  repo,
+				Author:      r.user(review.User),
+				State:       models.ReviewState(strings.ToUpper(review.State)),
+				SubmittedAt: submittedAt,
+				Body:        review.Body,
+				CommitID:    review.CommitID,
+			}
+		}
+
+	case "IssuesEvent":
+		if payload.Issue != nil && payload.Issue.Number != 0 && payload.Issue.PullRequest == nil {
+			update(r.issues, itemKey(repo, payload.Issue.Number), &ghaVersion[models.Issue]{at: event.CreatedAt, item: r.convertIssue(*payload.Issue, repo)})
+		}
+
+	case "IssueCommentEvent":
+		if payload.Issue == nil || payload.Comment == nil || payload.Comment.ID == 0 {
+			return nil
+		}
+		comment := models.IssueComment{
+			ID:         payload.Comment.ID,
+			Issue:      payload.Issue.Number,
+			Repository: repo,
+			Author:     r.user(payload.Comment.User),
+			Body:       payload.Comment.Body,
+			CreatedAt:  payload.Comment.CreatedAt,
+		}
+		update(r.comments, comment.ID, &ghaVersion[models.IssueComment]{at: event.CreatedAt, item: comment, deleted: payload.Action == "deleted"})
+	}
+	return nil
+}
+
+// update records v as the state of key unless a later state is known already
+func update[K comparable, T any](versions map[K]*ghaVersion[T], key K, v *ghaVersion[T]) {
+	if known, ok := versions[key]; !ok || !v.at.Before(known.at) {
+		versions[key] = v
+	}
+}
+
+// user records the profile of a GitHub user and returns them as an author
+func (r *ghaReader) user(u ghaUser) models.Author {
+	if u.Login == "" {
+		return models.Author{}
+	}
+	// Nested users of some events lack the ID or avatar
+	key := strings.ToLower(u.Login)
+	profile := r.source.users[key]
+	profile.Login = u.Login
+	if u.ID != 0 {
+		profile.ID = u.ID
+	}
+	if u.AvatarURL != "" {
+		profile.AvatarURL = u.AvatarURL
+	}
+	r.source.users[key] = profile
+	return models.Author{ID: u.ID, Login: u.Login, AvatarURL: u.AvatarURL}
+}
+
+func (r *ghaReader) convertPullRequest(pr ghaPullRequest, repo string) models.PullRequest {
+	state := models.PRStateOpen
+	if pr.Merged || pr.MergedAt != nil {
+		state = models.PRStateMerged
+	} else if pr.ClosedAt != nil {
+		state = models.PRStateClosed
+	}
+
+	var labels []string
+	for _, l := range pr.Labels {
+		labels = append(labels, l.Name)
+	}
+
+	var autoMergeBy string
+	if pr.AutoMerge != nil && pr.AutoMerge.EnabledBy != nil {
+		autoMergeBy = pr.AutoMerge.EnabledBy.Login
+	}
+
+	var mergeCommitSHA, mergedBy string
+	if state == models.PRStateMerged {
+		mergeCommitSHA = pr.MergeCommitSHA
+		if pr.MergedBy != nil {
+			mergedBy = pr.MergedBy.Login
+		}
+	}
+
+	return models.PullRequest{
+		Number:         pr.Number,
+		Title:          pr.Title,
+		Body:           pr.Body,
+		State:          state,
+		Author:         r.user(pr.User),
+		Repository:     repo,
+		BaseBranch:     pr.Base.Ref,
+		HeadBranch:     pr.Head.Ref,
+		CreatedAt:      pr.CreatedAt,
+		UpdatedAt:      pr.UpdatedAt,
+		MergedAt:       pr.MergedAt,
+		MergedBy:       mergedBy,
+		AutoMergeBy:    autoMergeBy,
+		ClosedAt:       pr.ClosedAt,
+		Additions:      pr.Additions,
+		Deletions:      pr.Deletions,
+		FilesChanged:   pr.ChangedFiles,
+		CommitCount:    pr.Commits,
+		Comments:       pr.Comments,
+		Labels:         labels,
+		URL:            pr.HTMLURL,
+		Association:    pr.AuthorAssociation,
+		MergeCommitSHA: mergeCommitSHA,
+	}
+}
+
+func (r *ghaReader) convertIssue(issue ghaIssue, repo string) models.Issue {
+	state := models.IssueStateOpen
+	if issue.State == "closed" {
+		state = models.IssueStateClosed
+	}
+
+	var labels []string
+	for _, l := range issue.Labels {
+		labels = append(labels, l.Name)
+	}
+
+	return models.Issue{
+		Number:      issue.Number,
+		Title:       issue.Title,
+		State:       state,
+		Author:      r.user(issue.User),
+		Repository:  repo,
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+		ClosedAt:    issue.ClosedAt,
+		Comments:    issue.Comments,
+		Labels:      labels,
+		URL:         issue.HTMLURL,
+		Association: issue.AuthorAssociation,
+	}
+}
+
+// finish moves the latest state of every item into the source
+func (r *ghaReader) finish() *Source {
+	s := r.source
+	for _, repo := range r.repos {
+		s.repos = append(s.repos, repo)
+	}
+	sort.Strings(s.repos)
+
+	for _, v := range r.prs {
+		key := strings.ToLower(v.item.Repository)
+		s.prs[key] = append(s.prs[key], v.item)
+	}
+	// Ordered by ID, so that reviews and comments at the same time are too
+	reviewIDs := slices.Sorted(maps.Keys(r.reviews))
+	for _, id := range reviewIDs {
+		review := r.reviews[id]
+		key := strings.ToLower(review.Repository)
+		if s.reviews[key] == nil {
+			s.reviews[key] = make(map[int][]models.Review)
+		}
+		s.reviews[key][review.PullRequest] = append(s.reviews[key][review.PullRequest], review)
+	}
+	for _, v := range r.issues {
+		key := strings.ToLower(v.item.Repository)
+		s.issues[key] = append(s.issues[key], v.item)
+	}
+	for _, id := range slices.Sorted(maps.Keys(r.comments)) {
+		v := r.comments[id]
+		if v.deleted {
+			continue
+		}
+		key := strings.ToLower(v.item.Repository)
+		s.comments[key] = append(s.comments[key], v.item)
+	}
+	return s
+}
+
+func itemKey(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", strings.ToLower(repo), number)
+}
@@ -0,0 +1,144 @@
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// fixtureEvents are two hours of GH Archive events, one JSON object per line
+var fixtureEvents = map[string][]string{
+	"2024-03-04-9.json.gz": {
+		`{"type":"PullRequestEvent","actor":{"id":1,"login":"alice","avatar_url":"https://avatars.example/1"},"repo":{"name":"acme/widgets"},"created_at":"2024-03-04T09:00:00Z",
+		  "payload":{"action":"opened","number":1,"pull_request":{"number":1,"title":"Add widgets","user":{"id":1,"login":"alice"},"merged":false,
+		  "base":{"ref":"main","repo":{"default_branch":"main"}},"head":{"ref":"widgets"},"author_association":"FIRST_TIME_CONTRIBUTOR",
+		  "created_at":"2024-03-04T09:00:00Z","updated_at":"2024-03-04T09:00:00Z"}}}`,
+		`{"type":"PushEvent","actor":{"login":"alice"},"repo":{"name":"acme/widgets"},"created_at":"2024-03-04T09:10:00Z","payload":{}}`,
+		`{"type":"PullRequestEvent","actor":{"login":"mallory"},"repo":{"name":"other/repo"},"created_at":"2024-03-04T09:20:00Z",
+		  "payload":{"action":"opened","number":5,"pull_request":{"number":5,"user":{"login":"mallory"}}}}`,
+		`{"type":"PullRequestReviewEvent","actor":{"id":2,"login":"bob"},"repo":{"name":"acme/widgets"},"created_at":"2024-03-04T09:30:00Z",
+		  "payload":{"action":"created","review":{"id":12,"user":{"id":2,"login":"bob"},"state":"approved","submitted_at":"2024-03-04T09:30:00Z"},
+		  "pull_request":{"number":1,"user":{"login":"alice"},"base":{"ref":"main"},"head":{"ref":"widgets"},"created_at":"2024-03-04T09:00:00Z"}}}`,
+		`{"type":"PullRequestReviewEvent","actor":{"id":2,"login":"bob"},"repo":{"name":"acme/widgets"},"created_at":"2024-03-04T09:40:00Z",
+		  "payload":{"action":"created","review":{"id":13,"user":{"id":2,"login":"bob"},"state":"commented","submitted_at":"2024-03-04T09:40:00Z"},
+		  "pull_request":{"number":7,"title":"Only reviewed","user":{"login":"carol"},"base":{"ref":"main"},"head":{"ref":"x"},"created_at":"2024-03-04T08:00:00Z"}}}`,
+		`{"type":"IssueCommentEvent","actor":{"login":"bob"},"repo":{"name":"acme/widgets"},"created_at":"2024-03-04T09:50:00Z",
+		  "payload":{"action":"created","issue":{"number":1,"pull_request":{}},"comment":{"id":31,"user":{"login":"bob"},"body":"Nice","created_at":"2024-03-04T09:50:00Z"}}}`,
+	},
+	"2024-03-04-10.json": {
+		`{"type":"PullRequestEvent","actor":{"id":1,"login":"alice"},"repo":{"name":"acme/widgets"},"created_at":"2024-03-04T10:00:00Z",
+		  "payload":{"action":"closed","number":1,"pull_request":{"number":1,"title":"Add widgets","user":{"id":1,"login":"alice"},"merged":true,
+		  "base":{"ref":"main"},"author_association":"FIRST_TIME_CONTRIBUTOR","head":{"ref":"widgets"},"additions":40,"deletions":2,"changed_files":3,"commits":2,"merge_commit_sha":"abc",
+		  "labels":[{"name":"feature"}],"html_url":"https://github.com/acme/widgets/pull/1",
+		  "created_at":"2024-03-04T09:00:00Z","updated_at":"2024-03-04T10:00:00Z","merged_at":"2024-03-04T10:00:00Z","closed_at":"2024-03-04T10:00:00Z"}}}`,
+		`{"type":"IssuesEvent","actor":{"login":"bob"},"repo":{"name":"acme/widgets"},"created_at":"2024-03-04T10:05:00Z",
+		  "payload":{"action":"opened","issue":{"number":3,"title":"Widgets are square","user":{"login":"bob"},"state":"open","created_at":"2024-03-04T10:05:00Z"}}}`,
+		`{"type":"IssueCommentEvent","actor":{"login":"alice"},"repo":{"name":"acme/widgets"},"created_at":"2024-03-04T10:10:00Z",
+		  "payload":{"action":"created","issue":{"number":3},"comment":{"id":32,"user":{"login":"alice"},"body":"Oops","created_at":"2024-03-04T10:10:00Z"}}}`,
+		`{"type":"IssueCommentEvent","actor":{"login":"alice"},"repo":{"name":"acme/widgets"},"created_at":"2024-03-04T10:11:00Z",
+		  "payload":{"action":"deleted","issue":{"number":3},"comment":{"id":32,"user":{"login":"alice"},"body":"Oops","created_at":"2024-03-04T10:10:00Z"}}}`,
+	},
+}
+
+func writeEvents(t *testing.T, dir string) {
+	t.Helper()
+	for name, events := range fixtureEvents {
+		var lines []string
+		for _, event := range events {
+			lines = append(lines, strings.Join(strings.Fields(event), " "))
+		}
+		content := []byte(strings.Join(lines, "\n") + "\n")
+
+		f, err := os.Create(filepath.Join(dir, name)) // #nosec G304 -- test file
+		require.NoError(t, err)
+		if strings.HasSuffix(name, ".gz") {
+			gz := gzip.NewWriter(f)
+			_, err = gz.Write(content)
+			require.NoError(t, err)
+			require.NoError(t, gz.Close())
+		} else {
+			_, err = f.Write(content)
+			require.NoError(t, err)
+		}
+		require.NoError(t, f.Close())
+	}
+}
+
+func TestOpenGHArchive(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeEvents(t, dir)
+	s, err := OpenGHArchive(dir, []string{"ACME"})
+	require.NoError(t, err)
+	assert.Empty(t, s.RepositoriesDir(), "repositories are cloned from GitHub")
+
+	ctx := context.Background()
+	since, until := date("2024-03-01T00:00:00Z"), date("2024-03-31T23:59:59Z")
+
+	repos, err := s.ListOrgRepos(ctx, "acme", "*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"widgets"}, repos, "other owners are skipped")
+	repos, err = s.ListOrgRepos(ctx, "other", "*")
+	require.NoError(t, err)
+	assert.Empty(t, repos)
+
+	settings, err := s.FetchRepositorySettings(ctx, "acme", "widgets")
+	require.NoError(t, err)
+	assert.Equal(t, "main", settings.DefaultBranch)
+
+	prs, err := s.FetchPullRequests(ctx, "acme", "widgets", since, until)
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, 7, prs[0].Number, "PRs only seen in reviews are kept")
+	merged := prs[1]
+	assert.Equal(t, models.PRStateMerged, merged.State, "the latest event wins")
+	assert.Equal(t, 40, merged.Additions)
+	assert.Equal(t, 3, merged.FilesChanged)
+	assert.Equal(t, "abc", merged.MergeCommitSHA)
+	assert.Equal(t, []string{"feature"}, merged.Labels)
+	assert.Equal(t, "FIRST_TIME_CONTRIBUTOR", merged.Association)
+
+	reviews, err := s.FetchReviews(ctx, "acme", "widgets", 1)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, models.ReviewApproved, reviews[0].State)
+	assert.Equal(t, models.Author{ID: 2, Login: "bob"}, reviews[0].Author)
+
+	issues, err := s.FetchIssues(ctx, "acme", "widgets", since, until)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, 3, issues[0].Number)
+	assert.False(t, issues[0].IsClosed())
+
+	comments, err := s.FetchIssueComments(ctx, "acme", "widgets", since, until)
+	require.NoError(t, err)
+	require.Len(t, comments, 1, "deleted comments are dropped")
+	assert.Equal(t, int64(31), comments[0].ID)
+	assert.Equal(t, 1, comments[0].Issue)
+
+	profiles, err := s.FetchUserProfiles(ctx, []string{"alice"})
+	require.NoError(t, err)
+	assert.Equal(t, github.UserProfile{ID: 1, Login: "alice", AvatarURL: "https://avatars.example/1"}, profiles["alice"])
+
+	_, err = s.FetchOrgMembers(ctx, "acme")
+	require.ErrorIs(t, err, ErrNotExported)
+}
+
+func TestOpenGHArchive_NoEvents(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeEvents(t, dir)
+	_, err := OpenGHArchive(dir, []string{"nobody"})
+	require.ErrorContains(t, err, "no events")
+}
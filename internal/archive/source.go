@@ -0,0 +1,233 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Source serves the data of a migration export the way the GitHub client
+// serves the API's. Everything is read into memory when it is opened.
+type Source struct {
+	dir  string
+	temp bool // dir was extracted from a .tar.gz and is removed on Close
+
+	repos    []string          // owner/name
+	branches map[string]string // Default branch by lowercase owner/name
+	users    map[string]github.UserProfile
+	members  map[string][]string // Logins by lowercase organization; nil when not exported
+
+	// By lowercase owner/name
+	prs      map[string][]models.PullRequest
+	reviews  map[string]map[int][]models.Review
+	issues   map[string][]models.Issue
+	comments map[string][]models.IssueComment
+}
+
+// SetProgressCallback does nothing: exports are read when they are opened
+func (s *Source) SetProgressCallback(github.ProgressCallback) {}
+
+// HasGraphQL is false: pull requests and reviews are read like over REST
+func (s *Source) HasGraphQL() bool {
+	return false
+}
+
+// ListOrgRepos lists the exported repositories of an owner whose name
+// matches pattern. Of GH Archive files, those are the repositories with events.
+func (s *Source) ListOrgRepos(_ context.Context, org, pattern string) ([]string, error) {
+	var repos []string
+	for _, repo := range s.repos {
+		owner, name, _ := strings.Cut(repo, "/")
+		if !strings.EqualFold(owner, org) {
+			continue
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			repos = append(repos, name)
+		}
+	}
+	return repos, nil
+}
+
+// GetCommitCountSince returns 0: repositories are cloned in full
+func (s *Source) GetCommitCountSince(context.Context, string, string, time.Time) (int, error) {
+	return 0, nil
+}
+
+// FetchRepositorySettings returns the default branch of a repository.
+// Protection rules and files aren't exported, so their checks stay unknown.
+func (s *Source) FetchRepositorySettings(_ context.Context, owner, repo string) (models.RepositorySettings, error) {
+	branch, ok := s.branches[key(owner, repo)]
+	if !ok {
+		return models.RepositorySettings{}, fmt.Errorf("the default branch of %s/%s is %w", owner, repo, ErrNotExported)
+	}
+	return models.RepositorySettings{DefaultBranch: branch}, nil
+}
+
+// FetchBuildStatus returns no status: CI results aren't exported
+func (s *Source) FetchBuildStatus(context.Context, string, string, string) (string, error) {
+	return "", nil
+}
+
+// FetchIssueReactions fails: reactions aren't exported
+func (s *Source) FetchIssueReactions(context.Context, string, string, int) ([]models.Reaction, error) {
+	return nil, fmt.Errorf("reactions are %w", ErrNotExported)
+}
+
+// FetchCommentReactions fails: reactions aren't exported
+func (s *Source) FetchCommentReactions(context.Context, string, string, int64) ([]models.Reaction, error) {
+	return nil, fmt.Errorf("reactions are %w", ErrNotExported)
+}
+
+// FetchReviewReactions fails: reactions aren't exported
+func (s *Source) FetchReviewReactions(context.Context, string, string, int) (map[int64][]models.Reaction, error) {
+	return nil, fmt.Errorf("reactions are %w", ErrNotExported)
+}
+
+// FetchAdoption fails: stargazers and forks aren't exported
+func (s *Source) FetchAdoption(context.Context, string, string, time.Time, int) (models.RepositoryAdoption, error) {
+	return models.RepositoryAdoption{}, fmt.Errorf("stars and forks are %w", ErrNotExported)
+}
+
+// FetchCommitStats fails: the exports hold no line counts to compare against
+func (s *Source) FetchCommitStats(context.Context, string, string, string) (github.CommitStats, error) {
+	return github.CommitStats{}, fmt.Errorf("commit stats are %w", ErrNotExported)
+}
+
+// FetchPullRequests returns the pull requests merged, closed or (while
+// open) created within the date range
+func (s *Source) FetchPullRequests(_ context.Context, owner, repo string, since, until *time.Time) ([]models.PullRequest, error) {
+	var prs []models.PullRequest
+	for _, pr := range s.prs[key(owner, repo)] {
+		at := pr.CreatedAt
+		if pr.MergedAt != nil {
+			at = *pr.MergedAt
+		} else if pr.ClosedAt != nil {
+			at = *pr.ClosedAt
+		}
+		if inRange(at, since, until) {
+			prs = append(prs, pr)
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].Number > prs[j].Number })
+	return prs, nil
+}
+
+// FetchPRsWithReviewsGraphQL fails: exports are read like over REST
+func (s *Source) FetchPRsWithReviewsGraphQL(context.Context, string, string, *time.Time, *time.Time) ([]models.PullRequest, []models.Review, error) {
+	return nil, nil, fmt.Errorf("GraphQL is %w", ErrNotExported)
+}
+
+// FetchReviews returns the reviews of a pull request
+func (s *Source) FetchReviews(_ context.Context, owner, repo string, prNumber int) ([]models.Review, error) {
+	reviews := slices.Clone(s.reviews[key(owner, repo)][prNumber])
+	sort.SliceStable(reviews, func(i, j int) bool { return reviews[i].SubmittedAt.Before(reviews[j].SubmittedAt) })
+	return reviews, nil
+}
+
+// FetchPullRequestFiles fails: the files of pull requests aren't exported
+func (s *Source) FetchPullRequestFiles(context.Context, string, string, int) ([]models.PullRequestFile, error) {
+	return nil, fmt.Errorf("pull request files are %w", ErrNotExported)
+}
+
+// FetchReviewComments fails: inline review comments aren't read from exports
+func (s *Source) FetchReviewComments(context.Context, string, string, *time.Time, *time.Time) ([]models.ReviewComment, error) {
+	return nil, fmt.Errorf("review comments are %w", ErrNotExported)
+}
+
+// FetchIssues returns the issues created within the date range
+func (s *Source) FetchIssues(_ context.Context, owner, repo string, since, until *time.Time) ([]models.Issue, error) {
+	var issues []models.Issue
+	for _, issue := range s.issues[key(owner, repo)] {
+		if inRange(issue.CreatedAt, since, until) {
+			issues = append(issues, issue)
+		}
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Number > issues[j].Number })
+	return issues, nil
+}
+
+// FetchIssueComments returns the comments on issues and pull requests
+// created within the date range
+func (s *Source) FetchIssueComments(_ context.Context, owner, repo string, since, until *time.Time) ([]models.IssueComment, error) {
+	var comments []models.IssueComment
+	for _, comment := range s.comments[key(owner, repo)] {
+		if inRange(comment.CreatedAt, since, until) {
+			comments = append(comments, comment)
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].CreatedAt.After(comments[j].CreatedAt) })
+	return comments, nil
+}
+
+// FetchIssuesWithCommentsGraphQL fails: exports are read like over REST
+func (s *Source) FetchIssuesWithCommentsGraphQL(context.Context, string, string, *time.Time, *time.Time) ([]models.Issue, []models.IssueComment, error) {
+	return nil, nil, fmt.Errorf("GraphQL is %w", ErrNotExported)
+}
+
+// FetchUserProfiles returns the exported profiles of logins
+func (s *Source) FetchUserProfiles(_ context.Context, logins []string) (map[string]github.UserProfile, error) {
+	profiles := make(map[string]github.UserProfile)
+	for _, login := range logins {
+		if profile, ok := s.users[strings.ToLower(login)]; ok {
+			profiles[login] = profile
+		}
+	}
+	return profiles, nil
+}
+
+// FetchAuditLog fails: audit logs aren't exported
+func (s *Source) FetchAuditLog(context.Context, string, []string, *time.Time, *time.Time) ([]models.AuditEvent, error) {
+	return nil, fmt.Errorf("audit logs are %w", ErrNotExported)
+}
+
+// FetchOrgMembers returns the members of an exported organization
+func (s *Source) FetchOrgMembers(_ context.Context, org string) ([]string, error) {
+	if s.members == nil {
+		return nil, fmt.Errorf("organization members are %w", ErrNotExported)
+	}
+	members, ok := s.members[strings.ToLower(org)]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", org, github.ErrNotOrganization)
+	}
+	return members, nil
+}
+
+// FetchProfileOptOut fails: profile repositories aren't exported
+func (s *Source) FetchProfileOptOut(context.Context, string) (bool, error) {
+	return false, fmt.Errorf("profile repositories are %w", ErrNotExported)
+}
+
+// FetchExternalIdentities fails: SAML identities aren't exported
+func (s *Source) FetchExternalIdentities(context.Context, string) ([]models.ExternalIdentity, error) {
+	return nil, fmt.Errorf("SAML identities are %w", ErrNotExported)
+}
+
+// APIUsage is empty: no API calls are made
+func (s *Source) APIUsage() models.APIUsage {
+	return models.APIUsage{}
+}
+
+// ResilienceReport is empty: no API calls are made
+func (s *Source) ResilienceReport() github.ResilienceReport {
+	return github.ResilienceReport{}
+}
+
+// SaveCacheStats does nothing: nothing is cached
+func (s *Source) SaveCacheStats() error {
+	return nil
+}
+
+func key(owner, repo string) string {
+	return strings.ToLower(owner + "/" + repo)
+}
+
+func inRange(at time.Time, since, until *time.Time) bool {
+	return (since == nil || !at.Before(*since)) && (until == nil || !at.After(*until))
+}
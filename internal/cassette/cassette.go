@@ -0,0 +1,263 @@
+// Package cassette records HTTP interactions with the GitHub API to JSON
+// files and replays them, so that the whole analyze pipeline runs in tests
+// without network access or tokens.
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	json "github.com/goccy/go-json"
+)
+
+// Version is the version of the cassette file format
+const Version = 1
+
+// Cassette is a recorded sequence of HTTP interactions
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one request and the response it got. Request headers are
+// never recorded, which keeps tokens out of the files.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request identifies a recorded request by method, URL and, for GraphQL, body
+type Request struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Response is a recorded response with a JSON body
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// recordedHeaders are the response headers kept when recording; the rest
+// only describe the connection or the account that recorded
+var recordedHeaders = []string{"Content-Type", "Link"}
+
+// Load reads a cassette file
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- fixture path from tests
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	if c.Version != Version {
+		return nil, fmt.Errorf("cassette %s has version %d, expected %d (record it again)", path, c.Version, Version)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path
+func (c *Cassette) Save(path string) error {
+	c.Version = Version
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// Replayer is an http.RoundTripper answering requests from a cassette.
+// Interactions recorded for the same request are replayed in order, the
+// last one repeating once all were used.
+type Replayer struct {
+	mu     sync.Mutex
+	c      *Cassette
+	used   []bool
+	misses []string
+}
+
+// NewReplayer replays the interactions of c
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{c: c, used: make([]bool, len(c.Interactions))}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := newRequest(req, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for i, in := range r.c.Interactions {
+		if !in.Request.matches(key) {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		r.misses = append(r.misses, key.String())
+		return nil, fmt.Errorf("cassette: no recorded response for %s", key)
+	}
+	r.used[match] = true
+	return r.c.Interactions[match].Response.http(req), nil
+}
+
+// Misses returns the requests that had no recorded response
+func (r *Replayer) Misses() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.misses)
+}
+
+// Recorder is an http.RoundTripper recording the interactions of another
+// transport into a cassette
+type Recorder struct {
+	mu        sync.Mutex
+	transport http.RoundTripper
+	c         Cassette
+}
+
+// NewRecorder records the interactions of transport (http.DefaultTransport when nil)
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport, c: Cassette{Version: Version}}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	recorded := Response{Status: resp.StatusCode, Body: compact(respBody)}
+	for _, name := range recordedHeaders {
+		if v := resp.Header.Get(name); v != "" {
+			if recorded.Headers == nil {
+				recorded.Headers = map[string]string{}
+			}
+			recorded.Headers[name] = v
+		}
+	}
+
+	r.mu.Lock()
+	r.c.Interactions = append(r.c.Interactions, Interaction{Request: newRequest(req, body), Response: recorded})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Cassette returns the interactions recorded so far
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Version: Version, Interactions: slices.Clone(r.c.Interactions)}
+}
+
+// readBody reads the body of a request, leaving it readable for the transport
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func newRequest(req *http.Request, body []byte) Request {
+	return Request{Method: req.Method, URL: req.URL.String(), Body: compact(body)}
+}
+
+// matches compares requests by method, URL and the compacted JSON body
+func (r Request) matches(other Request) bool {
+	return r.Method == other.Method && r.URL == other.URL && bytes.Equal(compact(r.Body), other.Body)
+}
+
+func (r Request) String() string {
+	if len(r.Body) == 0 {
+		return r.Method + " " + r.URL
+	}
+	return fmt.Sprintf("%s %s %s", r.Method, r.URL, r.Body)
+}
+
+// http builds the response to req
+func (r Response) http(req *http.Request) *http.Response {
+	header := http.Header{}
+	for name, v := range r.Headers {
+		header.Set(name, v)
+	}
+	if header.Get("Content-Type") == "" && len(r.Body) > 0 {
+		header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	body := []byte(r.Body)
+	var text string
+	if len(body) > 0 && body[0] == '"' && json.Unmarshal(body, &text) == nil {
+		body = []byte(text) // Recorded from a body that was not JSON
+	}
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// compact strips the insignificant whitespace of a JSON body, so that
+// hand-edited cassettes still match; other bodies are kept as JSON strings
+func compact(body []byte) json.RawMessage {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, body); err != nil {
+		quoted, _ := json.Marshal(strings.ToValidUTF8(string(body), "�"))
+		return quoted
+	}
+	return buf.Bytes()
+}
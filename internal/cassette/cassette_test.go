@@ -0,0 +1,100 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+			return
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(`{ "calls": ` + strings.Repeat("1", calls) + ` }`))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(nil)
+	client := &http.Client{Transport: recorder}
+	status, body := get(t, client, server.URL+"/repos")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{ "calls": 1 }`, body, "responses pass through unchanged")
+	get(t, client, server.URL+"/repos")
+	get(t, client, server.URL+"/missing")
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/graphql", strings.NewReader(`{"query": "{ viewer }"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "bearer secret")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, recorder.Cassette().Save(path))
+	saved, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(saved), "secret", "request headers are not recorded")
+	assert.NotContains(t, string(saved), "X-Ratelimit-Remaining")
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Interactions, 4)
+	replayer := NewReplayer(loaded)
+	client = &http.Client{Transport: replayer}
+	server.Close()
+
+	// Recorded responses replay in order, the last one repeating
+	for _, want := range []string{`{"calls":1}`, `{"calls":11}`, `{"calls":11}`} {
+		_, body := get(t, client, server.URL+"/repos")
+		assert.JSONEq(t, want, body)
+	}
+	status, _ = get(t, client, server.URL+"/missing")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	// Bodies match regardless of formatting
+	resp, err = client.Post(server.URL+"/graphql", "application/json", strings.NewReader("{\n  \"query\": \"{ viewer }\"\n}"))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	_, err = client.Get(server.URL + "/unknown")
+	require.Error(t, err)
+	assert.Equal(t, []string{"GET " + server.URL + "/unknown"}, replayer.Misses())
+}
+
+func TestLoad_Version(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 99, "interactions": []}`), 0600))
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "version 99")
+}
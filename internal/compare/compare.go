@@ -0,0 +1,274 @@
+package compare
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Report describes the differences between two analysis runs
+type Report struct {
+	SchemaVersion       int                `json:"schema_version"`
+	Totals              TotalsDelta        `json:"totals"`
+	Contributors        []ContributorDelta `json:"contributors"`
+	NewContributors     []string           `json:"new_contributors"`
+	RemovedContributors []string           `json:"removed_contributors"`
+	Repositories        []RepositoryDelta  `json:"repositories"`
+
+	// The base run was scored with other point values and its scores were
+	// recalculated with the head run's
+	Rescored bool `json:"rescored,omitempty"`
+}
+
+// IntDelta holds a before/after pair for an integer metric
+type IntDelta struct {
+	Before int `json:"before"`
+	After  int `json:"after"`
+	Delta  int `json:"delta"`
+}
+
+func newIntDelta(before, after int) IntDelta {
+	return IntDelta{Before: before, After: after, Delta: after - before}
+}
+
+// Changed reports whether the value differs between runs
+func (d IntDelta) Changed() bool {
+	return d.Delta != 0
+}
+
+// TotalsDelta holds changes in global totals
+type TotalsDelta struct {
+	Score        IntDelta `json:"score"` // Sum of all contributor scores
+	Contributors IntDelta `json:"contributors"`
+	Commits      IntDelta `json:"commits"`
+	PRs          IntDelta `json:"prs"`
+	Reviews      IntDelta `json:"reviews"`
+	LinesAdded   IntDelta `json:"lines_added"`
+	LinesDeleted IntDelta `json:"lines_deleted"`
+}
+
+// ContributorDelta holds changes for a contributor present in either run
+type ContributorDelta struct {
+	Login              string   `json:"login"`
+	Score              IntDelta `json:"score"`
+	Rank               IntDelta `json:"rank"`
+	AchievementsGained []string `json:"achievements_gained,omitempty"`
+	AchievementsLost   []string `json:"achievements_lost,omitempty"`
+}
+
+// RepositoryDelta holds changes in repository totals
+type RepositoryDelta struct {
+	FullName           string   `json:"full_name"`
+	Commits            IntDelta `json:"commits"`
+	PRs                IntDelta `json:"prs"`
+	Reviews            IntDelta `json:"reviews"`
+	ActiveContributors IntDelta `json:"active_contributors"`
+	Added              bool     `json:"added,omitempty"`   // Only present in the head run
+	Removed            bool     `json:"removed,omitempty"` // Only present in the base run
+}
+
+// Load reads global metrics from a run. The path may be an output directory,
+// its data directory, or a global.json snapshot file.
+func Load(path string) (*models.GlobalMetrics, error) {
+	candidates := []string{
+		filepath.Join(path, "data", "global.json"),
+		filepath.Join(path, "global.json"),
+		path,
+	}
+
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Clean(candidate))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", candidate, err)
+		}
+
+		var doc models.GlobalDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", candidate, err)
+		}
+		if doc.SchemaVersion > models.SchemaVersion {
+			return nil, fmt.Errorf("%s uses schema version %d, newer than supported version %d", candidate, doc.SchemaVersion, models.SchemaVersion)
+		}
+		if doc.GlobalMetrics == nil {
+			doc.GlobalMetrics = &models.GlobalMetrics{}
+		}
+		return doc.GlobalMetrics, nil
+	}
+
+	return nil, fmt.Errorf("no global.json found in %s", path)
+}
+
+// Compare builds a report of changes from base to head
+func Compare(base, head *models.GlobalMetrics) *Report {
+	report := &Report{
+		SchemaVersion:       models.SchemaVersion,
+		Contributors:        []ContributorDelta{},
+		NewContributors:     []string{},
+		RemovedContributors: []string{},
+		Repositories:        []RepositoryDelta{},
+	}
+
+	report.Totals = TotalsDelta{
+		Score:        newIntDelta(totalScore(base), totalScore(head)),
+		Contributors: newIntDelta(base.TotalContributors, head.TotalContributors),
+		Commits:      newIntDelta(base.TotalCommits, head.TotalCommits),
+		PRs:          newIntDelta(base.TotalPRs, head.TotalPRs),
+		Reviews:      newIntDelta(base.TotalReviews, head.TotalReviews),
+		LinesAdded:   newIntDelta(base.TotalLinesAdded, head.TotalLinesAdded),
+		LinesDeleted: newIntDelta(base.TotalLinesDeleted, head.TotalLinesDeleted),
+	}
+
+	// Contributors
+	baseContributors := make(map[string]models.ContributorMetrics, len(base.Contributors))
+	for _, c := range base.Contributors {
+		baseContributors[c.Login] = c
+	}
+	headContributors := make(map[string]models.ContributorMetrics, len(head.Contributors))
+	for _, c := range head.Contributors {
+		headContributors[c.Login] = c
+	}
+
+	for login, after := range headContributors {
+		before, existed := baseContributors[login]
+		if !existed {
+			report.NewContributors = append(report.NewContributors, login)
+		}
+		gained, lost := diffStrings(before.Achievements, after.Achievements)
+		report.Contributors = append(report.Contributors, ContributorDelta{
+			Login:              login,
+			Score:              newIntDelta(before.Score.Total, after.Score.Total),
+			Rank:               newIntDelta(before.Score.Rank, after.Score.Rank),
+			AchievementsGained: gained,
+			AchievementsLost:   lost,
+		})
+	}
+	for login, before := range baseContributors {
+		if _, ok := headContributors[login]; ok {
+			continue
+		}
+		report.RemovedContributors = append(report.RemovedContributors, login)
+		report.Contributors = append(report.Contributors, ContributorDelta{
+			Login:            login,
+			Score:            newIntDelta(before.Score.Total, 0),
+			Rank:             newIntDelta(before.Score.Rank, 0),
+			AchievementsLost: sortedCopy(before.Achievements),
+		})
+	}
+
+	// Largest score changes first, then by login for stable output
+	sort.Slice(report.Contributors, func(i, j int) bool {
+		di, dj := abs(report.Contributors[i].Score.Delta), abs(report.Contributors[j].Score.Delta)
+		if di != dj {
+			return di > dj
+		}
+		return report.Contributors[i].Login < report.Contributors[j].Login
+	})
+	sort.Strings(report.NewContributors)
+	sort.Strings(report.RemovedContributors)
+
+	// Repositories
+	baseRepos := make(map[string]models.RepositoryMetrics, len(base.Repositories))
+	for _, r := range base.Repositories {
+		baseRepos[r.FullName] = r
+	}
+	headRepos := make(map[string]models.RepositoryMetrics, len(head.Repositories))
+	for _, r := range head.Repositories {
+		headRepos[r.FullName] = r
+	}
+
+	for name, after := range headRepos {
+		before, existed := baseRepos[name]
+		delta := newRepositoryDelta(name, before, after)
+		delta.Added = !existed
+		report.Repositories = append(report.Repositories, delta)
+	}
+	for name, before := range baseRepos {
+		if _, ok := headRepos[name]; ok {
+			continue
+		}
+		delta := newRepositoryDelta(name, before, models.RepositoryMetrics{})
+		delta.Removed = true
+		report.Repositories = append(report.Repositories, delta)
+	}
+	sort.Slice(report.Repositories, func(i, j int) bool {
+		return report.Repositories[i].FullName < report.Repositories[j].FullName
+	})
+
+	return report
+}
+
+// ScoreDropPercent returns how much the total score fell from base to head, as a percentage.
+// Increases are reported as 0.
+func (r *Report) ScoreDropPercent() float64 {
+	if r.Totals.Score.Before <= 0 || r.Totals.Score.Delta >= 0 {
+		return 0
+	}
+	return float64(-r.Totals.Score.Delta) / float64(r.Totals.Score.Before) * 100
+}
+
+func newRepositoryDelta(name string, before, after models.RepositoryMetrics) RepositoryDelta {
+	return RepositoryDelta{
+		FullName:           name,
+		Commits:            newIntDelta(before.TotalCommits, after.TotalCommits),
+		PRs:                newIntDelta(before.TotalPRs, after.TotalPRs),
+		Reviews:            newIntDelta(before.TotalReviews, after.TotalReviews),
+		ActiveContributors: newIntDelta(before.ActiveContributors, after.ActiveContributors),
+	}
+}
+
+func totalScore(m *models.GlobalMetrics) int {
+	total := 0
+	for _, c := range m.Contributors {
+		total += c.Score.Total
+	}
+	return total
+}
+
+// diffStrings returns sorted elements added to and removed from a set
+func diffStrings(before, after []string) (added, removed []string) {
+	beforeSet := make(map[string]bool, len(before))
+	for _, s := range before {
+		beforeSet[s] = true
+	}
+	afterSet := make(map[string]bool, len(after))
+	for _, s := range after {
+		afterSet[s] = true
+		if !beforeSet[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range before {
+		if !afterSet[s] {
+			removed = append(removed, s)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func sortedCopy(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	out := append([]string(nil), s...)
+	sort.Strings(out)
+	return out
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
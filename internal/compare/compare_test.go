@@ -0,0 +1,158 @@
+package compare
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func baseMetrics() *models.GlobalMetrics {
+	return &models.GlobalMetrics{
+		TotalContributors: 2,
+		TotalCommits:      10,
+		Contributors: []models.ContributorMetrics{
+			{Login: "alice", Score: models.Score{Total: 100, Rank: 1}, Achievements: []string{"commit-1"}},
+			{Login: "bob", Score: models.Score{Total: 50, Rank: 2}, Achievements: []string{"review-1"}},
+		},
+		Repositories: []models.RepositoryMetrics{
+			{FullName: "org/api", TotalCommits: 6, ActiveContributors: 2},
+			{FullName: "org/legacy", TotalCommits: 4, ActiveContributors: 1},
+		},
+	}
+}
+
+func headMetrics() *models.GlobalMetrics {
+	return &models.GlobalMetrics{
+		TotalContributors: 2,
+		TotalCommits:      15,
+		Contributors: []models.ContributorMetrics{
+			{Login: "alice", Score: models.Score{Total: 80, Rank: 1}, Achievements: []string{"commit-1", "commit-10"}},
+			{Login: "carol", Score: models.Score{Total: 40, Rank: 2}},
+		},
+		Repositories: []models.RepositoryMetrics{
+			{FullName: "org/api", TotalCommits: 10, ActiveContributors: 2},
+			{FullName: "org/web", TotalCommits: 5, ActiveContributors: 1},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	report := Compare(baseMetrics(), headMetrics())
+
+	assert.Equal(t, IntDelta{Before: 150, After: 120, Delta: -30}, report.Totals.Score)
+	assert.Equal(t, 5, report.Totals.Commits.Delta)
+	assert.Equal(t, []string{"carol"}, report.NewContributors)
+	assert.Equal(t, []string{"bob"}, report.RemovedContributors)
+
+	byLogin := make(map[string]ContributorDelta)
+	for _, c := range report.Contributors {
+		byLogin[c.Login] = c
+	}
+	require.Len(t, byLogin, 3)
+
+	assert.Equal(t, -20, byLogin["alice"].Score.Delta)
+	assert.Equal(t, []string{"commit-10"}, byLogin["alice"].AchievementsGained)
+	assert.Empty(t, byLogin["alice"].AchievementsLost)
+
+	assert.Equal(t, -50, byLogin["bob"].Score.Delta)
+	assert.Equal(t, []string{"review-1"}, byLogin["bob"].AchievementsLost)
+
+	assert.Equal(t, 40, byLogin["carol"].Score.Delta)
+
+	// Sorted by absolute score change
+	assert.Equal(t, "bob", report.Contributors[0].Login)
+
+	require.Len(t, report.Repositories, 3)
+	assert.Equal(t, "org/api", report.Repositories[0].FullName)
+	assert.Equal(t, 4, report.Repositories[0].Commits.Delta)
+	assert.True(t, report.Repositories[1].Removed)
+	assert.Equal(t, "org/legacy", report.Repositories[1].FullName)
+	assert.True(t, report.Repositories[2].Added)
+
+	assert.InDelta(t, 20.0, report.ScoreDropPercent(), 0.001)
+}
+
+func TestReport_ScoreDropPercent_Increase(t *testing.T) {
+	t.Parallel()
+
+	report := Compare(headMetrics(), baseMetrics())
+	assert.Zero(t, report.ScoreDropPercent())
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dataDir := filepath.Join(dir, "data")
+	require.NoError(t, os.MkdirAll(dataDir, 0750))
+
+	data, err := json.Marshal(models.NewGlobalDocument(baseMetrics(), time.Now()))
+	require.NoError(t, err)
+	globalPath := filepath.Join(dataDir, "global.json")
+	require.NoError(t, os.WriteFile(globalPath, data, 0600))
+
+	// Output directory, data directory and file path all resolve
+	for _, path := range []string{dir, dataDir, globalPath} {
+		metrics, err := Load(path)
+		require.NoError(t, err, path)
+		assert.Equal(t, 10, metrics.TotalCommits, path)
+		assert.Len(t, metrics.Contributors, 2, path)
+	}
+
+	_, err = Load(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestLoad_NewerSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "global.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schema_version": 999}`), 0600))
+
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "newer than supported")
+}
+
+func TestReport_WriteText(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, Compare(baseMetrics(), headMetrics()).WriteText(&buf))
+
+	out := buf.String()
+	assert.Contains(t, out, "New contributors: carol")
+	assert.Contains(t, out, "Removed contributors: bob")
+	assert.Contains(t, out, "+commit-10")
+	assert.Contains(t, out, "org/web (new)")
+	assert.Contains(t, out, "org/legacy (removed)")
+	assert.NotContains(t, out, "recalculated")
+
+	report := Compare(baseMetrics(), headMetrics())
+	report.Rescored = true
+	buf.Reset()
+	require.NoError(t, report.WriteText(&buf))
+	assert.True(t, strings.HasPrefix(buf.String(), "Base scores recalculated with the head run's point values\n"))
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, Compare(baseMetrics(), headMetrics()).WriteJSON(&buf))
+
+	var decoded Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, models.SchemaVersion, decoded.SchemaVersion)
+	assert.Equal(t, -30, decoded.Totals.Score.Delta)
+}
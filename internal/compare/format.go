@@ -0,0 +1,129 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	json "github.com/goccy/go-json"
+)
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes a human-readable summary of the report.
+// Only contributors and repositories that changed are listed.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if r.Rescored {
+		fmt.Fprintln(tw, "Base scores recalculated with the head run's point values")
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprintln(tw, "Totals")
+	fmt.Fprintln(tw, "  METRIC\tBEFORE\tAFTER\tDELTA")
+	totals := []struct {
+		name  string
+		delta IntDelta
+	}{
+		{"score", r.Totals.Score},
+		{"contributors", r.Totals.Contributors},
+		{"commits", r.Totals.Commits},
+		{"prs", r.Totals.PRs},
+		{"reviews", r.Totals.Reviews},
+		{"lines added", r.Totals.LinesAdded},
+		{"lines deleted", r.Totals.LinesDeleted},
+	}
+	for _, t := range totals {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%s\n", t.name, t.delta.Before, t.delta.After, signed(t.delta.Delta))
+	}
+
+	if len(r.NewContributors) > 0 {
+		fmt.Fprintf(tw, "\nNew contributors: %s\n", strings.Join(r.NewContributors, ", "))
+	}
+	if len(r.RemovedContributors) > 0 {
+		fmt.Fprintf(tw, "\nRemoved contributors: %s\n", strings.Join(r.RemovedContributors, ", "))
+	}
+
+	var changed []ContributorDelta
+	for _, c := range r.Contributors {
+		if c.Score.Changed() || c.Rank.Changed() || len(c.AchievementsGained) > 0 || len(c.AchievementsLost) > 0 {
+			changed = append(changed, c)
+		}
+	}
+	if len(changed) > 0 {
+		fmt.Fprintln(tw, "\nContributors")
+		fmt.Fprintln(tw, "  LOGIN\tSCORE\tDELTA\tRANK\tACHIEVEMENTS")
+		for _, c := range changed {
+			fmt.Fprintf(tw, "  %s\t%d\t%s\t%s\t%s\n",
+				c.Login, c.Score.After, signed(c.Score.Delta), rankChange(c.Rank), achievementChange(c))
+		}
+	}
+
+	var repos []RepositoryDelta
+	for _, repo := range r.Repositories {
+		if repo.Added || repo.Removed || repo.Commits.Changed() || repo.PRs.Changed() ||
+			repo.Reviews.Changed() || repo.ActiveContributors.Changed() {
+			repos = append(repos, repo)
+		}
+	}
+	if len(repos) > 0 {
+		fmt.Fprintln(tw, "\nRepositories")
+		fmt.Fprintln(tw, "  REPOSITORY\tCOMMITS\tPRS\tREVIEWS\tCONTRIBUTORS")
+		for _, repo := range repos {
+			name := repo.FullName
+			switch {
+			case repo.Added:
+				name += " (new)"
+			case repo.Removed:
+				name += " (removed)"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", name,
+				signed(repo.Commits.Delta), signed(repo.PRs.Delta),
+				signed(repo.Reviews.Delta), signed(repo.ActiveContributors.Delta))
+		}
+	}
+
+	return tw.Flush()
+}
+
+func signed(n int) string {
+	if n > 0 {
+		return fmt.Sprintf("+%d", n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+func rankChange(d IntDelta) string {
+	switch {
+	case d.Before == 0 && d.After == 0:
+		return "-"
+	case d.Before == 0:
+		return fmt.Sprintf("#%d (new)", d.After)
+	case d.After == 0:
+		return fmt.Sprintf("#%d -> -", d.Before)
+	case d.Before == d.After:
+		return fmt.Sprintf("#%d", d.After)
+	default:
+		return fmt.Sprintf("#%d -> #%d", d.Before, d.After)
+	}
+}
+
+func achievementChange(c ContributorDelta) string {
+	var parts []string
+	for _, a := range c.AchievementsGained {
+		parts = append(parts, "+"+a)
+	}
+	for _, a := range c.AchievementsLost {
+		parts = append(parts, "-"+a)
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
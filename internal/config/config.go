@@ -1,11 +1,16 @@
 package config
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -13,31 +18,125 @@ import (
 
 // Load reads and parses a configuration file
 func Load(path string) (*Config, error) {
-	cleanPath := filepath.Clean(path)
-	data, err := os.ReadFile(cleanPath) // #nosec G304 -- path is user-provided config file
+	return load(path, nil)
+}
+
+// LoadOffline reads a configuration file for an offline run.
+// Authentication is not required because nothing is fetched from GitHub.
+func LoadOffline(path string) (*Config, error) {
+	return load(path, func(cfg *Config) {
+		cfg.Options.Offline = true
+	})
+}
+
+// load reads, parses and validates a configuration file, applying
+// overrides (if any) after parsing and before validation
+func load(path string, override func(*Config)) (*Config, error) {
+	// Read the file and its includes, expanding environment variables and
+	// rejecting unknown keys
+	src, err := readSource(filepath.Clean(path))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, err
 	}
 
-	// Expand environment variables
-	expanded := expandEnvVars(string(data))
-
-	// Start with defaults
+	// Start with defaults, tuned by the preset (if any) the file selects
 	cfg := DefaultConfig()
 
 	// Parse YAML
-	if err := yaml.Unmarshal([]byte(expanded), cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	if src.root != nil {
+		if preset := mappingValue(src.root, "preset"); preset != nil {
+			applyPreset(cfg, preset.Value)
+		}
+		if err := src.root.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	// GIT_VELOCITY_* variables take precedence over the file
+	overridden, err := applyEnvOverrides(cfg, os.Environ())
+	if err != nil {
+		return nil, fmt.Errorf("invalid environment override: %w", err)
 	}
 
-	// Validate configuration
+	if override != nil {
+		override(cfg)
+	}
+
+	// Resolve the token from token_command/keyring (not needed offline or
+	// for exports)
+	if !cfg.Options.Offline && !cfg.Source.Exported() {
+		if err := cfg.ResolveSecrets(); err != nil {
+			return nil, fmt.Errorf("failed to resolve credentials: %w", err)
+		}
+	}
+
+	// Validate configuration, pointing errors at their place in the file
 	if err := Validate(cfg); err != nil {
+		var errs ValidationErrors
+		if errors.As(err, &errs) {
+			locate(src, errs, overridden)
+		}
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
 	return cfg, nil
 }
 
+// applyPreset changes the defaults of cfg for a preset; settings in the file
+// still override them. Unknown presets are left to validation.
+func applyPreset(cfg *Config, preset string) {
+	switch preset {
+	case PresetOSS:
+		cfg.Community.Enabled = true
+		cfg.Options.OrgMembers.Enabled = true
+	}
+}
+
+// LoadPoints reads a points override file on top of base.
+// The file contains the same keys as scoring.points; keys that are not
+// present keep their value from base.
+func LoadPoints(path string, base PointsConfig) (PointsConfig, error) {
+	data, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- path is user-provided points file
+	if err != nil {
+		return base, fmt.Errorf("failed to read points file: %w", err)
+	}
+
+	points := base
+	if err := decodeStrict([]byte(expandEnvVars(string(data))), &points); err != nil {
+		return base, fmt.Errorf("failed to parse points file: %w", err)
+	}
+
+	return points, nil
+}
+
+// Values returns the point values keyed like scoring.points, as recorded in
+// global.json to tell which values a run was scored with
+func (p PointsConfig) Values() map[string]float64 {
+	values := make(map[string]float64)
+	data, err := yaml.Marshal(p)
+	if err == nil {
+		err = yaml.Unmarshal(data, &values)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("config: encoding points: %v", err)) // Numbers always encode
+	}
+	return values
+}
+
+// PointsFromValues restores point values recorded by Values on top of base.
+// Keys that are not present keep their value from base.
+func PointsFromValues(values map[string]float64, base PointsConfig) (PointsConfig, error) {
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return base, err
+	}
+	points := base
+	if err := decodeStrict(data, &points); err != nil {
+		return base, fmt.Errorf("invalid point values: %w", err)
+	}
+	return points, nil
+}
+
 // expandEnvVars replaces ${VAR} patterns with environment variable values
 func expandEnvVars(input string) string {
 	re := regexp.MustCompile(`\$\{([^}]+)\}`)
@@ -149,9 +248,29 @@ func (c *Config) GetCacheTTL() (time.Duration, error) {
 	return time.ParseDuration(c.Cache.TTL)
 }
 
+// GetCircuitBreakerCooldown returns the circuit breaker cooldown as a time.Duration
+func (c *Config) GetCircuitBreakerCooldown() (time.Duration, error) {
+	if c.Options.CircuitBreaker.Cooldown == "" {
+		return time.Minute, nil
+	}
+	return time.ParseDuration(c.Options.CircuitBreaker.Cooldown)
+}
+
 // HasGithubToken returns true if token authentication is configured
 func (c *Config) HasGithubToken() bool {
-	return c.Auth.GithubToken != ""
+	return len(c.GithubTokens()) > 0
+}
+
+// GithubTokens returns github_token followed by the pooled github_tokens,
+// skipping empty and repeated entries (such as unset environment variables)
+func (c *Config) GithubTokens() []string {
+	var tokens []string
+	for _, token := range append([]string{c.Auth.GithubToken}, c.Auth.GithubTokens...) {
+		if token != "" && !slices.Contains(tokens, token) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
 }
 
 // HasGithubApp returns true if GitHub App authentication is configured
@@ -192,59 +311,321 @@ func (c *Config) GetTeamForUser(username string) *TeamConfig {
 	return nil
 }
 
-// IsBot checks if a username matches bot patterns (hardcoded defaults + user-defined)
-func (c *Config) IsBot(username string) bool {
-	if c.Options.IncludeBots {
-		return false
+// Includes reports whether the group contains a repository, by its full
+// name. Patterns are matched case-insensitively, like GitHub names.
+func (g *GroupConfig) Includes(fullName string) bool {
+	return matchRepo(g.Repos, fullName)
+}
+
+// AppliesTo reports whether the ownership rule covers a repository, by its
+// full name. Rules without repositories cover every repository.
+func (p *PathOwnership) AppliesTo(fullName string) bool {
+	return len(p.Repos) == 0 || matchRepo(p.Repos, fullName)
+}
+
+// GetTeam returns the team with the given name, or nil
+func (c *Config) GetTeam(name string) *TeamConfig {
+	for i := range c.Teams {
+		if c.Teams[i].Name == name {
+			return &c.Teams[i]
+		}
 	}
+	return nil
+}
 
-	lower := strings.ToLower(username)
+// PathOwnershipFor returns the path ownership rules of a team
+func (c *Config) PathOwnershipFor(team string) []PathOwnership {
+	var rules []PathOwnership
+	for _, rule := range c.PathOwnership {
+		if rule.Team == team {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
 
-	// Check hardcoded default patterns first
-	for _, pattern := range DefaultBotPatterns() {
-		pattern = strings.ToLower(pattern)
-		if matchPattern(lower, pattern) {
+// OwnsPaths reports whether path ownership rules cover a repository
+func (c *Config) OwnsPaths(fullName string) bool {
+	for i := range c.PathOwnership {
+		if c.PathOwnership[i].AppliesTo(fullName) {
 			return true
 		}
 	}
+	return false
+}
 
-	// Check user-defined additional patterns
-	for _, pattern := range c.Options.AdditionalBotPatterns {
-		pattern = strings.ToLower(pattern)
-		if matchPattern(lower, pattern) {
+// matchRepo reports whether a repository full name matches any of the
+// owner/name patterns, case-insensitively like GitHub names
+func matchRepo(patterns []string, fullName string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(fullName)); ok {
 			return true
 		}
 	}
-
 	return false
 }
 
-// matchPattern performs simple glob-style pattern matching
-func matchPattern(s, pattern string) bool {
-	// Handle exact match
-	if !strings.Contains(pattern, "*") {
-		return s == pattern
+// HasOutputFormat reports whether an output format is enabled
+func (c *Config) HasOutputFormat(format string) bool {
+	return slices.Contains(c.Output.Format, format)
+}
+
+// SearchPullRequests reports whether merged pull requests are found with the
+// Search API, by pr_fetch_mode or the search fetch strategy
+func (c *Config) SearchPullRequests() bool {
+	return c.Options.PRFetchMode == PRFetchSearch || c.Options.FetchStrategy == FetchSearch
+}
+
+// SearchIssues reports whether issues are found with the Search API
+func (c *Config) SearchIssues() bool {
+	return c.Options.FetchStrategy == FetchSearch
+}
+
+// GetServiceAccountTeam returns the team a service account login or commit
+// email belongs to, or nil if it is not a service account
+func (c *Config) GetServiceAccountTeam(account string) *TeamConfig {
+	if account == "" {
+		return nil
+	}
+	for i := range c.Teams {
+		for _, sa := range c.Teams[i].ServiceAccounts {
+			if strings.EqualFold(sa, account) {
+				return &c.Teams[i]
+			}
+		}
+	}
+	return nil
+}
+
+// AuditLogActions returns the audit-log actions to fetch: the defaults followed by
+// any configured extras, without duplicates
+func (c *Config) AuditLogActions() []string {
+	actions := slices.Clone(DefaultAuditLogActions)
+	for _, action := range c.Integrations.AuditLog.Actions {
+		if !slices.Contains(actions, action) {
+			actions = append(actions, action)
+		}
 	}
+	return actions
+}
+
+// DistantTimezone returns how far apart the time zones of a reviewer and a
+// PR's author must be for a review to cross distant time zones
+func (o OptionsConfig) DistantTimezone() time.Duration {
+	return time.Duration(cmp.Or(o.DistantTimezoneHours, DefaultDistantTimezoneHours) * float64(time.Hour))
+}
+
+// ContributorsTable returns the name of the contributor fact table
+func (t WarehouseTablesConfig) ContributorsTable() string {
+	return cmp.Or(t.Contributors, DefaultContributorsTable)
+}
+
+// RepositoriesTable returns the name of the repository fact table
+func (t WarehouseTablesConfig) RepositoriesTable() string {
+	return cmp.Or(t.Repositories, DefaultRepositoriesTable)
+}
+
+// PeriodsTable returns the name of the period fact table
+func (t WarehouseTablesConfig) PeriodsTable() string {
+	return cmp.Or(t.Periods, DefaultPeriodsTable)
+}
+
+// TopicName returns the topic events are produced to
+func (k KafkaConfig) TopicName() string {
+	return cmp.Or(k.Topic, DefaultKafkaTopic)
+}
+
+// SubjectPrefix returns the prefix of the subjects events are published to
+func (n NATSConfig) SubjectPrefix() string {
+	return cmp.Or(n.Subject, DefaultNATSSubject)
+}
 
-	// Handle prefix match (pattern*)
-	if strings.HasSuffix(pattern, "*") && !strings.HasPrefix(pattern, "*") {
-		return strings.HasPrefix(s, strings.TrimSuffix(pattern, "*"))
+// CoverageReports returns the coverage report directory configured for a repository, if any
+func (c *Config) CoverageReports(owner, name string) string {
+	for _, repo := range c.Repositories {
+		if repo.Coverage != "" && repo.matches(owner, name) {
+			return repo.Coverage
+		}
 	}
+	return ""
+}
 
-	// Handle suffix match (*pattern)
-	if strings.HasPrefix(pattern, "*") && !strings.HasSuffix(pattern, "*") {
-		return strings.HasSuffix(s, strings.TrimPrefix(pattern, "*"))
+// LintFor returns the lint debt settings configured for a repository, if any
+func (c *Config) LintFor(owner, name string) *LintConfig {
+	for _, repo := range c.Repositories {
+		if repo.Lint != nil && repo.matches(owner, name) {
+			return repo.Lint
+		}
 	}
+	return nil
+}
 
-	// Handle contains match (*pattern*)
-	if strings.HasPrefix(pattern, "*") && strings.HasSuffix(pattern, "*") {
-		inner := strings.TrimPrefix(strings.TrimSuffix(pattern, "*"), "*")
-		return strings.Contains(s, inner)
+// matches reports whether an analyzed repository is selected by this entry,
+// by name or pattern
+func (r RepositoryConfig) matches(owner, name string) bool {
+	if !strings.EqualFold(r.Owner, owner) {
+		return false
+	}
+	if strings.EqualFold(r.Name, name) {
+		return true
+	}
+	if r.Pattern != "" {
+		ok, _ := path.Match(strings.ToLower(r.Pattern), strings.ToLower(name))
+		return ok
 	}
+	return false
+}
 
+// HasCoverage reports whether coverage is collected for any repository
+func (c *Config) HasCoverage() bool {
+	if c.Integrations.Codecov.Enabled {
+		return true
+	}
+	for _, repo := range c.Repositories {
+		if repo.Coverage != "" {
+			return true
+		}
+	}
 	return false
 }
 
+// GetContributor returns the settings for a given username, or nil if none are configured
+func (c *Config) GetContributor(username string) *ContributorConfig {
+	for i := range c.Contributors {
+		if strings.EqualFold(c.Contributors[i].Login, username) {
+			return &c.Contributors[i]
+		}
+	}
+	return nil
+}
+
+// Membership returns the parsed start and end dates; either is nil when unset.
+// The end date covers the whole day.
+func (cc *ContributorConfig) Membership() (start, end *time.Time, err error) {
+	if cc.Start != "" {
+		t, err := time.Parse("2006-01-02", cc.Start)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid start date for contributor %s: %w", cc.Login, err)
+		}
+		start = &t
+	}
+	if cc.End != "" {
+		t, err := time.Parse("2006-01-02", cc.End)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid end date for contributor %s: %w", cc.Login, err)
+		}
+		t = t.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+		end = &t
+	}
+	return start, end, nil
+}
+
+// Location returns the time zone they work in, or nil when it isn't configured
+func (cc *ContributorConfig) Location() (*time.Location, error) {
+	if cc.Timezone == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(cc.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone for contributor %s: %w", cc.Login, err)
+	}
+	return loc, nil
+}
+
+// Dates returns the first and last day of the absence
+func (ac *AbsenceConfig) Dates() (start, end time.Time, err error) {
+	start, err = time.Parse("2006-01-02", ac.Start)
+	if err != nil {
+		return start, end, fmt.Errorf("invalid absence start date: %w", err)
+	}
+	if ac.End == "" {
+		return start, start, nil
+	}
+	end, err = time.Parse("2006-01-02", ac.End)
+	if err != nil {
+		return start, end, fmt.Errorf("invalid absence end date: %w", err)
+	}
+	return start, end, nil
+}
+
+// BotRegexPrefix marks a bot pattern as a regular expression, e.g.
+// "re:^svc-[a-z]+-\d+$"; other patterns are globs
+const BotRegexPrefix = "re:"
+
+// botRegexps caches compiled regular expression bot patterns
+var botRegexps sync.Map // expression -> *regexp.Regexp
+
+// IsBot checks if a username matches bot patterns (hardcoded defaults + user-defined)
+func (c *Config) IsBot(username string) bool {
+	return c.BotPattern(username) != ""
+}
+
+// BotPattern returns the first bot pattern matching a username, checking the
+// hardcoded defaults before user-defined patterns, or "" for humans
+func (c *Config) BotPattern(username string) string {
+	if c.Options.IncludeBots || username == "" {
+		return ""
+	}
+
+	for _, patterns := range [][]string{DefaultBotPatterns(), c.Options.AdditionalBotPatterns} {
+		for _, pattern := range patterns {
+			if matchBotPattern(username, pattern) {
+				return pattern
+			}
+		}
+	}
+
+	return ""
+}
+
+// matchBotPattern matches a username against a glob or, with BotRegexPrefix,
+// a regular expression; both ignore case
+func matchBotPattern(username, pattern string) bool {
+	if expr, ok := strings.CutPrefix(pattern, BotRegexPrefix); ok {
+		re, err := compileBotRegex(expr)
+		return err == nil && re.MatchString(username)
+	}
+	return matchPattern(strings.ToLower(username), strings.ToLower(pattern))
+}
+
+// compileBotRegex compiles a regular expression bot pattern case-insensitively
+func compileBotRegex(expr string) (*regexp.Regexp, error) {
+	if re, ok := botRegexps.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile("(?i)" + expr)
+	if err != nil {
+		return nil, err
+	}
+	botRegexps.Store(expr, re)
+	return re, nil
+}
+
+// matchPattern performs simple glob-style pattern matching, where each *
+// matches any run of characters
+func matchPattern(s, pattern string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return s == pattern // Exact match
+	}
+
+	// The first part is a prefix and the last a suffix; the ones between
+	// must appear in order
+	first, last := parts[0], parts[len(parts)-1]
+	if !strings.HasPrefix(s, first) {
+		return false
+	}
+	s = s[len(first):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, last)
+}
+
 // GetCustomPeriods returns parsed custom periods
 func (c *Config) GetCustomPeriods() ([]ParsedCustomPeriod, error) {
 	var periods []ParsedCustomPeriod
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"testing"
 	"time"
 
@@ -484,6 +488,21 @@ func TestConfig_HasGithubToken(t *testing.T) {
 	}
 }
 
+func TestGithubTokens(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{Auth: AuthConfig{
+		GithubToken:  "ghp_main",
+		GithubTokens: []string{"ghp_second", "", "ghp_main", "ghp_third"},
+	}}
+	assert.Equal(t, []string{"ghp_main", "ghp_second", "ghp_third"}, cfg.GithubTokens())
+
+	// A pool without github_token is enough
+	cfg = &Config{Auth: AuthConfig{GithubTokens: []string{"ghp_second"}}}
+	assert.True(t, cfg.HasGithubToken())
+	assert.Equal(t, []string{"ghp_second"}, cfg.GithubTokens())
+}
+
 func TestConfig_HasGithubApp(t *testing.T) {
 	t.Parallel()
 
@@ -612,6 +631,35 @@ func TestConfig_GetTeamForUser(t *testing.T) {
 	}
 }
 
+func TestGroupConfig_Includes(t *testing.T) {
+	t.Parallel()
+
+	group := GroupConfig{Name: "Payments", Repos: []string{"acme/ledger", "acme/payments-*"}}
+
+	assert.True(t, group.Includes("acme/ledger"))
+	assert.True(t, group.Includes("ACME/Ledger"), "names are case-insensitive")
+	assert.True(t, group.Includes("acme/payments-api"))
+	assert.False(t, group.Includes("acme/ledger-ui"))
+	assert.False(t, group.Includes("other/payments-api"))
+}
+
+func TestPathOwnership_AppliesTo(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{PathOwnership: []PathOwnership{
+		{Team: "Payments", Paths: []string{"services/payments"}, Repos: []string{"acme/monorepo"}},
+		{Team: "Platform", Paths: []string{"infra/**"}},
+		{Team: "Payments", Paths: []string{"libs/billing"}},
+	}}
+
+	assert.True(t, cfg.PathOwnership[0].AppliesTo("Acme/Monorepo"))
+	assert.False(t, cfg.PathOwnership[0].AppliesTo("acme/website"))
+	assert.True(t, cfg.PathOwnership[1].AppliesTo("acme/website"), "rules without repositories cover all")
+	assert.True(t, cfg.OwnsPaths("acme/website"))
+	assert.Len(t, cfg.PathOwnershipFor("Payments"), 2)
+	assert.Empty(t, cfg.PathOwnershipFor("Mobile"))
+}
+
 func TestConfig_IsBot(t *testing.T) {
 	t.Parallel()
 
@@ -702,6 +750,42 @@ func TestConfig_IsBot_AdditionalPatterns(t *testing.T) {
 	assert.False(t, cfg.IsBot("alice"))
 }
 
+func TestConfig_IsBot_RegexPatterns(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Options: OptionsConfig{
+			AdditionalBotPatterns: []string{`re:^svc-[a-z]+-\d+$`, "re:-(deployer|releaser)$"},
+		},
+	}
+
+	assert.True(t, cfg.IsBot("svc-build-01"))
+	assert.True(t, cfg.IsBot("SVC-Build-7"), "regular expressions ignore case")
+	assert.True(t, cfg.IsBot("nightly-releaser"))
+	assert.False(t, cfg.IsBot("svc-build"))
+	assert.False(t, cfg.IsBot("deployer-jane"))
+	assert.True(t, cfg.IsBot("dependabot[bot]"), "defaults still apply")
+	assert.False(t, cfg.IsBot(""))
+}
+
+func TestConfig_BotPattern(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Options: OptionsConfig{
+			AdditionalBotPatterns: []string{"renovate-approve", "re:^ci-"},
+		},
+	}
+
+	assert.Equal(t, "renovate*", cfg.BotPattern("renovate-approve"), "defaults are checked first")
+	assert.Equal(t, "re:^ci-", cfg.BotPattern("ci-runner"))
+	assert.Equal(t, "*[bot]", cfg.BotPattern("dependabot[bot]"))
+	assert.Empty(t, cfg.BotPattern("alice"))
+
+	cfg.Options.IncludeBots = true
+	assert.Empty(t, cfg.BotPattern("dependabot[bot]"))
+}
+
 func TestConfig_IsBot_IncludeBots(t *testing.T) {
 	t.Parallel()
 
@@ -763,6 +847,24 @@ func TestMatchPattern(t *testing.T) {
 			pattern:  "world*",
 			expected: false,
 		},
+		{
+			name:     "inner wildcard",
+			s:        "ci-runner-bot",
+			pattern:  "ci-*-bot",
+			expected: true,
+		},
+		{
+			name:     "inner wildcard no match",
+			s:        "ci-runner",
+			pattern:  "ci-*-bot",
+			expected: false,
+		},
+		{
+			name:     "overlapping prefix and suffix",
+			s:        "ab",
+			pattern:  "ab*b",
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -947,3 +1049,251 @@ func TestLoad_InvalidYAML(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse config file")
 }
+
+func TestLoadPoints(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "points.yaml")
+	err := os.WriteFile(path, []byte("commit: 25\npr_merged: 100\n"), 0600)
+	require.NoError(t, err)
+
+	base := DefaultConfig().Scoring.Points
+	points, err := LoadPoints(path, base)
+	require.NoError(t, err)
+
+	assert.Equal(t, 25, points.Commit)
+	assert.Equal(t, 100, points.PRMerged)
+	// Keys not in the file keep their base value
+	assert.Equal(t, base.PRReviewed, points.PRReviewed)
+	assert.Equal(t, base.MultiplierEvening, points.MultiplierEvening)
+}
+
+func TestPointsValues(t *testing.T) {
+	t.Parallel()
+
+	points := DefaultConfig().Scoring.Points
+	points.LinesAdded = 0.25
+	values := points.Values()
+	assert.Equal(t, float64(points.Commit), values["commit"])
+	assert.Equal(t, 0.25, values["lines_added"])
+	assert.Equal(t, points.MultiplierOvernight, values["multiplier_overnight"])
+
+	restored, err := PointsFromValues(values, PointsConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, points, restored)
+
+	// Missing keys keep their base value
+	restored, err = PointsFromValues(map[string]float64{"commit": 3}, points)
+	require.NoError(t, err)
+	assert.Equal(t, 3, restored.Commit)
+	assert.Equal(t, points.PRMerged, restored.PRMerged)
+
+	_, err = PointsFromValues(map[string]float64{"comit": 3}, points)
+	assert.ErrorContains(t, err, "invalid point values")
+}
+
+func TestLoadPoints_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadPoints("/nonexistent/points.yaml", PointsConfig{})
+	assert.ErrorContains(t, err, "failed to read points file")
+
+	path := filepath.Join(t.TempDir(), "points.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("commit: ["), 0600))
+	_, err = LoadPoints(path, PointsConfig{})
+	assert.ErrorContains(t, err, "failed to parse points file")
+}
+
+func TestLoad_UnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `repositories:
+  - owner: org
+    name: repo
+    pahts: ["src/**"]
+scorring:
+  enabled: true
+output:
+  directory: ./dist
+  fromat: [html]
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	_, err := LoadOffline(configPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse config file")
+	assert.Contains(t, err.Error(), `line 4, column 5: repositories[0].pahts: unknown field (did you mean "paths"?)`)
+	assert.Contains(t, err.Error(), `line 5, column 1: scorring: unknown field (did you mean "scoring"?)`)
+	assert.Contains(t, err.Error(), `line 9, column 3: output.fromat: unknown field (did you mean "format"?)`)
+}
+
+func TestLoad_ValidationErrorPositions(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `repositories:
+  - owner: org
+    name: repo
+  - name: other
+granularity: [daily, hourly]
+output:
+  directory: ./dist
+  format: [html, pdf]
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	_, err := LoadOffline(configPath)
+	require.Error(t, err)
+
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	positions := make(map[string][2]int)
+	for _, e := range errs {
+		positions[e.Field] = [2]int{e.Line, e.Column}
+	}
+	assert.Equal(t, [2]int{4, 5}, positions["repositories[1].owner"], "missing keys point at their parent")
+	assert.Equal(t, [2]int{5, 22}, positions["granularity[1]"])
+	assert.Equal(t, [2]int{8, 18}, positions["output.format[1]"])
+	assert.Contains(t, err.Error(), "line 5, column 22: granularity[1]: invalid granularity: hourly")
+}
+
+func TestLoadPoints_UnknownKey(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "points.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("commit: 25\npr_mergd: 100\n"), 0600))
+
+	_, err := LoadPoints(path, PointsConfig{})
+	assert.ErrorContains(t, err, `line 2, column 1: pr_mergd: unknown field (did you mean "pr_merged"?)`)
+}
+
+func TestLoadOffline_NoAuth(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configPath, []byte("repositories:\n  - owner: org\n    name: repo\n"), 0600)
+	require.NoError(t, err)
+
+	_, err = Load(configPath)
+	assert.ErrorContains(t, err, "auth")
+
+	cfg, err := LoadOffline(configPath)
+	require.NoError(t, err)
+	assert.True(t, cfg.Options.Offline)
+}
+
+func TestLoad_Preset(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `preset: oss
+repositories:
+  - owner: org
+    name: repo
+options:
+  org_members:
+    enabled: false
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, err := LoadOffline(configPath)
+	require.NoError(t, err)
+	assert.True(t, cfg.Community.Enabled, "enabled by the preset")
+	assert.False(t, cfg.Options.OrgMembers.Enabled, "the file overrides the preset")
+	assert.Equal(t, ExternalFlag, cfg.Options.OrgMembers.External, "other defaults are kept")
+}
+
+func TestConfig_ResolveSecrets(t *testing.T) {
+	original := runCommand
+	t.Cleanup(func() { runCommand = original })
+
+	var calls [][]string
+	runCommand = func(ctx context.Context, name string, args ...string) (string, error) {
+		calls = append(calls, append([]string{name}, args...))
+		if name == "secret-tool" || name == "security" {
+			return "ghp_fromkeyring", nil
+		}
+		return "ghp_fromcommand", nil
+	}
+
+	t.Run("explicit token wins", func(t *testing.T) {
+		calls = nil
+		cfg := &Config{Auth: AuthConfig{GithubToken: "ghp_direct", TokenCommand: "gh auth token"}}
+		require.NoError(t, cfg.ResolveSecrets())
+		assert.Equal(t, "ghp_direct", cfg.Auth.GithubToken)
+		assert.Empty(t, calls)
+	})
+
+	t.Run("token command", func(t *testing.T) {
+		calls = nil
+		cfg := &Config{Auth: AuthConfig{TokenCommand: "gh auth token"}}
+		require.NoError(t, cfg.ResolveSecrets())
+		assert.Equal(t, "ghp_fromcommand", cfg.Auth.GithubToken)
+		require.Len(t, calls, 1)
+		assert.Equal(t, "gh auth token", calls[0][len(calls[0])-1])
+	})
+
+	t.Run("keyring", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("keyring lookup is not supported on windows")
+		}
+		calls = nil
+		cfg := &Config{Auth: AuthConfig{Keyring: &KeyringConfig{}}}
+		require.NoError(t, cfg.ResolveSecrets())
+		assert.Equal(t, "ghp_fromkeyring", cfg.Auth.GithubToken)
+		require.Len(t, calls, 1)
+		assert.Contains(t, calls[0], DefaultKeyringService)
+		assert.Contains(t, calls[0], DefaultKeyringAccount)
+	})
+
+	t.Run("command failure", func(t *testing.T) {
+		runCommand = func(ctx context.Context, name string, args ...string) (string, error) {
+			return "", fmt.Errorf("exit status 1")
+		}
+		cfg := &Config{Auth: AuthConfig{TokenCommand: "false"}}
+		assert.ErrorContains(t, cfg.ResolveSecrets(), "auth.token_command failed")
+	})
+
+	t.Run("empty command output", func(t *testing.T) {
+		runCommand = func(ctx context.Context, name string, args ...string) (string, error) {
+			return "", nil
+		}
+		cfg := &Config{Auth: AuthConfig{TokenCommand: "true"}}
+		assert.ErrorContains(t, cfg.ResolveSecrets(), "empty token")
+	})
+
+	t.Run("warehouse token commands", func(t *testing.T) {
+		calls = nil
+		runCommand = func(ctx context.Context, name string, args ...string) (string, error) {
+			calls = append(calls, append([]string{name}, args...))
+			return "ya29.fromcommand", nil
+		}
+		cfg := &Config{Warehouse: WarehouseConfig{
+			BigQuery:  BigQueryConfig{Enabled: true, TokenCommand: "gcloud auth print-access-token"},
+			Snowflake: SnowflakeConfig{Enabled: true, Token: "direct", TokenCommand: "snowsql-token"},
+		}}
+		require.NoError(t, cfg.ResolveWarehouseTokens(context.Background()))
+		assert.Equal(t, "ya29.fromcommand", cfg.Warehouse.BigQuery.Token)
+		assert.Equal(t, "direct", cfg.Warehouse.Snowflake.Token)
+		require.Len(t, calls, 1)
+
+		runCommand = func(ctx context.Context, name string, args ...string) (string, error) {
+			return "", nil
+		}
+		cfg.Warehouse.BigQuery.Token = ""
+		assert.ErrorContains(t, cfg.ResolveWarehouseTokens(context.Background()), "warehouse.bigquery.token_command returned an empty token")
+	})
+}
+
+func TestConfig_AuditLogActions(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{}
+	assert.Equal(t, DefaultAuditLogActions, cfg.AuditLogActions())
+
+	cfg.Integrations.AuditLog.Actions = []string{"git.push", AuditRejectedRefUpdate}
+	actions := cfg.AuditLogActions()
+	assert.Equal(t, append(slices.Clone(DefaultAuditLogActions), "git.push"), actions)
+	assert.Len(t, DefaultAuditLogActions, 3, "defaults must not be modified")
+}
@@ -0,0 +1,255 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decodeStrict parses YAML into out, rejecting keys out has no field for so
+// that a typo doesn't silently leave a section at its defaults. Unknown keys
+// are reported together with their line and column and the closest known key.
+func decodeStrict(data []byte, out any) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return nil // Empty file
+	}
+
+	if errs := unknownKeys(doc.Content[0], reflect.TypeOf(out), ""); len(errs) > 0 {
+		return errs
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// unknownKeys walks a YAML node alongside the Go type it decodes into and
+// returns an error for every mapping key without a matching field
+func unknownKeys(node *yaml.Node, t reflect.Type, path string) ValidationErrors {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	var errs ValidationErrors
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				// Merge key: the merged mappings belong to the same struct
+				errs = append(errs, unknownKeys(value, t, path)...)
+				continue
+			}
+			field, ok := fields[key.Value]
+			if !ok {
+				errs = append(errs, unknownKey(key, joinPath(path, key.Value), fields))
+				continue
+			}
+			errs = append(errs, unknownKeys(value, field, joinPath(path, key.Value))...)
+		}
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			errs = append(errs, unknownKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			errs = append(errs, unknownKeys(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value))...)
+		}
+	}
+	return errs
+}
+
+func unknownKey(key *yaml.Node, path string, fields map[string]reflect.Type) ValidationError {
+	message := "unknown field"
+	if suggestion := closest(key.Value, slices.Collect(maps.Keys(fields))); suggestion != "" {
+		message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+	}
+	return ValidationError{Field: path, Message: message, Line: key.Line, Column: key.Column}
+}
+
+// yamlFields maps the YAML keys of a struct to their field types
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			for k, v := range yamlFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// closest returns the known key nearest to key by edit distance, or "" when
+// none is close enough to be a likely typo
+func closest(key string, candidates []string) string {
+	threshold := max(1, len(key)/3)
+	best, bestDistance := "", threshold+1
+	for _, name := range candidates {
+		d := editDistance(key, name)
+		if d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// editDistance is the number of single-character insertions, deletions,
+// substitutions and adjacent transpositions turning a into b
+func editDistance(a, b string) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// fieldSegment matches one segment of a validation error field, e.g.
+// "repositories[0]" or "owner"
+var fieldSegment = regexp.MustCompile(`^([^.\[]+)((?:\[\d+\])*)`)
+
+// locate sets the file, line and column of each error to the YAML node its
+// field refers to, or the closest enclosing node present in the config.
+// Errors in options set by environment overrides (config path -> variable)
+// name the variable instead.
+func locate(src *configSource, errs ValidationErrors, overridden map[string]string) {
+	for i := range errs {
+		if name := overrideOf(errs[i].Field, overridden); name != "" {
+			errs[i].Message += fmt.Sprintf(" (set by %s)", name)
+			continue
+		}
+		if errs[i].Line > 0 || src.root == nil {
+			continue
+		}
+		if node := find(src.root, errs[i].Field); node != nil {
+			errs[i].File = src.origins[node]
+			errs[i].Line, errs[i].Column = node.Line, node.Column
+		}
+	}
+}
+
+// overrideOf returns the environment variable that set field or a section
+// containing it, if any
+func overrideOf(field string, overridden map[string]string) string {
+	for path, name := range overridden {
+		if field == path || strings.HasPrefix(field, path+".") || strings.HasPrefix(field, path+"[") {
+			return name
+		}
+	}
+	return ""
+}
+
+// find returns the node field refers to, or its closest enclosing node
+// below root; nil when not even the first key is present
+func find(root *yaml.Node, field string) *yaml.Node {
+	var found *yaml.Node
+	node := root
+	rest := field
+	for rest != "" {
+		m := fieldSegment.FindStringSubmatch(rest)
+		if m == nil {
+			break
+		}
+		rest = strings.TrimPrefix(rest[len(m[0]):], ".")
+
+		next := mappingValue(node, m[1])
+		if next == nil {
+			break
+		}
+		node = next
+		found = node
+
+		for _, idx := range strings.Split(strings.Trim(m[2], "[]"), "][") {
+			if idx == "" {
+				continue
+			}
+			n, _ := strconv.Atoi(idx)
+			if node.Kind != yaml.SequenceNode || n >= len(node.Content) {
+				return found
+			}
+			node = node.Content[n]
+			found = node
+		}
+	}
+	return found
+}
+
+// mappingValue returns the value of key in a mapping node, following aliases
+// and merge keys
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "<<" {
+			if v := mappingValue(node.Content[i+1], key); v != nil {
+				return v
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// EnvPrefix starts the names of environment variables overriding config
+// options, e.g. GIT_VELOCITY_OUTPUT_DIRECTORY for output.directory
+const EnvPrefix = "GIT_VELOCITY_"
+
+// RunEnvPrefix starts the names of the variables describing a run to its
+// hooks. They aren't overrides, so a hook can run git-velocity itself.
+const RunEnvPrefix = EnvPrefix + "RUN_"
+
+// envOverrides maps override variable names to the config paths they set,
+// e.g. GIT_VELOCITY_SCORING_POINTS_COMMIT to scoring.points.commit
+var envOverrides = func() map[string]string {
+	names := make(map[string]string)
+	collectEnvNames(reflect.TypeOf(Config{}), "", names)
+	return names
+}()
+
+// collectEnvNames adds the override variable of every option below t. Nested
+// sections are descended into; anything else, lists of sections included, is
+// an option set as a whole.
+func collectEnvNames(t reflect.Type, path string, names map[string]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		name := EnvPrefix + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+		if strings.HasPrefix(name, RunEnvPrefix) {
+			panic(fmt.Sprintf("config: the override of %s is reserved for hooks", path))
+		}
+		if other, ok := names[name]; ok {
+			panic(fmt.Sprintf("config: %s and %s share the override %s", other, path, name))
+		}
+		names[name] = path
+		return
+	}
+	for key, field := range yamlFields(t) {
+		collectEnvNames(field, joinPath(path, key), names)
+	}
+}
+
+// applyEnvOverrides sets the config options named by GIT_VELOCITY_* variables
+// in environ, other than the GIT_VELOCITY_RUN_* ones. Values are parsed as YAML, so lists and sections can be given
+// as flow sequences and mappings; lists of strings also accept plain
+// comma-separated values. It returns the config paths that were overridden,
+// mapped to the variable that set them.
+func applyEnvOverrides(cfg *Config, environ []string) (map[string]string, error) {
+	overridden := make(map[string]string)
+	var errs ValidationErrors
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, EnvPrefix) || strings.HasPrefix(name, RunEnvPrefix) {
+			continue
+		}
+		path, known := envOverrides[name]
+		if !known {
+			errs = append(errs, ValidationError{Field: name, Message: "unknown config override" + suggestEnv(name)})
+			continue
+		}
+		if err := setPath(reflect.ValueOf(cfg).Elem(), strings.Split(path, "."), value); err != nil {
+			errs = append(errs, ValidationError{Field: name, Message: err.Error()})
+			continue
+		}
+		overridden[path] = name
+	}
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+		return nil, errs
+	}
+	return overridden, nil
+}
+
+// setPath decodes value into the option at path below v, allocating
+// sections that are still nil on the way
+func setPath(v reflect.Value, path []string, value string) error {
+	for _, key := range path {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = fieldByKey(v, key)
+	}
+
+	target := v.Addr().Interface()
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String && !strings.HasPrefix(strings.TrimSpace(value), "[") {
+		var items []string
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+		return nil
+	}
+	if v.Kind() == reflect.String {
+		// Taken verbatim, so values like "yes" or "1.0" stay strings
+		v.SetString(value)
+		return nil
+	}
+
+	// Replace rather than merge into the value from the file
+	v.Set(reflect.Zero(v.Type()))
+	if err := decodeStrict([]byte(value), target); err != nil {
+		return fmt.Errorf("invalid value %q: %w", value, err)
+	}
+	return nil
+}
+
+// fieldByKey returns the field of struct v with the given YAML key
+func fieldByKey(v reflect.Value, key string) reflect.Value {
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		if f.IsExported() && name == key {
+			return v.Field(i)
+		}
+	}
+	panic(fmt.Sprintf("config: no field %q in %s", key, t))
+}
+
+// suggestEnv names the known override variable name was most likely meant
+// to be: the shortest one it abbreviates, or else the closest one
+func suggestEnv(name string) string {
+	var suggestion string
+	for known := range envOverrides {
+		if strings.HasPrefix(known, name) && (suggestion == "" || len(known) < len(suggestion) ||
+			(len(known) == len(suggestion) && known < suggestion)) {
+			suggestion = known
+		}
+	}
+	if suggestion == "" {
+		suggestion = closest(name, slices.Collect(maps.Keys(envOverrides)))
+	}
+	if suggestion == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %s?)", suggestion)
+}
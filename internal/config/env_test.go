@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/hooks"
+)
+
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Output.Format = []string{"html"}
+	overridden, err := applyEnvOverrides(cfg, []string{
+		"PATH=/usr/bin",
+		"GIT_VELOCITY_OUTPUT_DIRECTORY=/tmp/site",
+		"GIT_VELOCITY_OUTPUT_FORMAT=html, json",
+		"GIT_VELOCITY_OUTPUT_BADGES=false",
+		"GIT_VELOCITY_SCORING_POINTS_COMMIT=25",
+		"GIT_VELOCITY_SCORING_POINTS_MULTIPLIER_EVENING=1.5",
+		"GIT_VELOCITY_GRANULARITY=[weekly]",
+		"GIT_VELOCITY_AUTH_KEYRING_SERVICE=ci",
+		"GIT_VELOCITY_TELEMETRY_HEADERS={Authorization: Bearer x}",
+		"GIT_VELOCITY_REPOSITORIES=[{owner: org, name: api}, {owner: org, pattern: 'web-*'}]",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/tmp/site", cfg.Output.Directory)
+	assert.Equal(t, []string{"html", "json"}, cfg.Output.Format)
+	assert.False(t, cfg.Output.Badges)
+	assert.Equal(t, 25, cfg.Scoring.Points.Commit)
+	assert.Equal(t, 1.5, cfg.Scoring.Points.MultiplierEvening)
+	assert.Equal(t, []string{"weekly"}, cfg.Granularity)
+	require.NotNil(t, cfg.Auth.Keyring, "nil sections are allocated")
+	assert.Equal(t, "ci", cfg.Auth.Keyring.Service)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer x"}, cfg.Telemetry.Headers)
+	assert.Equal(t, []RepositoryConfig{{Owner: "org", Name: "api"}, {Owner: "org", Pattern: "web-*"}}, cfg.Repositories)
+
+	assert.Equal(t, "GIT_VELOCITY_OUTPUT_DIRECTORY", overridden["output.directory"])
+	assert.Len(t, overridden, 9)
+}
+
+func TestApplyEnvOverrides_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := applyEnvOverrides(DefaultConfig(), []string{
+		"GIT_VELOCITY_OUTPUT_DIR=/tmp/site",
+		"GIT_VELOCITY_SCORING_POINTS_COMMIT=many",
+		"GIT_VELOCITY_REPOSITORIES=[{owner: org, nmae: api}]",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GIT_VELOCITY_OUTPUT_DIR: unknown config override (did you mean GIT_VELOCITY_OUTPUT_DIRECTORY?)")
+	assert.Contains(t, err.Error(), `GIT_VELOCITY_SCORING_POINTS_COMMIT: invalid value "many"`)
+	assert.Contains(t, err.Error(), `nmae: unknown field (did you mean "name"?)`)
+}
+
+func TestEnvOverrides_CoverAllOptions(t *testing.T) {
+	t.Parallel()
+
+	for name, path := range map[string]string{
+		"GIT_VELOCITY_VERSION":                          "version",
+		"GIT_VELOCITY_AUTH_GITHUB_TOKEN":                "auth.github_token",
+		"GIT_VELOCITY_AUTH_GITHUB_APP_PRIVATE_KEY_PATH": "auth.github_app.private_key_path",
+		"GIT_VELOCITY_DATE_RANGE_START":                 "date_range.start",
+		"GIT_VELOCITY_OPTIONS_CIRCUIT_BREAKER_COOLDOWN": "options.circuit_breaker.cooldown",
+		"GIT_VELOCITY_INTEGRATIONS_LINEAR_TEAM_KEYS":    "integrations.linear.team_keys",
+		"GIT_VELOCITY_FORECAST_SEASON_LENGTH":           "forecast.season_length",
+		"GIT_VELOCITY_TEAMS":                            "teams",
+	} {
+		assert.Equal(t, path, envOverrides[name], name)
+	}
+}
+
+func TestLoad_EnvOverrides(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "repositories:\n  - owner: org\n    name: repo\noutput:\n  directory: ./dist\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	t.Setenv("GIT_VELOCITY_OUTPUT_DIRECTORY", "./public")
+	cfg, err := LoadOffline(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "./public", cfg.Output.Directory, "the environment wins over the file")
+
+	// Validation errors name the variable rather than a line of the file
+	t.Setenv("GIT_VELOCITY_OUTPUT_LOCALE", "xx")
+	_, err = LoadOffline(configPath)
+	assert.ErrorContains(t, err, "output.locale: unsupported locale: xx (must be one of")
+	assert.ErrorContains(t, err, "(set by GIT_VELOCITY_OUTPUT_LOCALE)")
+}
+
+func TestLoad_HookEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	content := "repositories:\n  - owner: org\n    name: repo\noutput:\n  directory: ./dist\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	// A post_generate hook running git-velocity sees the variables of its run
+	end := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	run := hooks.RunContext{Hook: hooks.PostGenerate, ConfigPath: configPath, OutputDir: filepath.Join(dir, "dist"), CacheDir: filepath.Join(dir, "cache"), End: &end}
+	for _, kv := range run.Env() {
+		name, value, _ := strings.Cut(kv, "=")
+		require.True(t, strings.HasPrefix(name, RunEnvPrefix), name)
+		t.Setenv(name, value)
+	}
+
+	cfg, err := LoadOffline(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "./dist", cfg.Output.Directory)
+}
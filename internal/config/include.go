@@ -0,0 +1,194 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// includeKey lists files merged underneath the file naming them
+const includeKey = "include"
+
+// configSource is a config file with its includes merged in
+type configSource struct {
+	path string     // The file that was loaded
+	root *yaml.Node // Merged top-level mapping; nil for an empty file
+
+	// File each node came from, for nodes of included files
+	origins map[*yaml.Node]string
+}
+
+// readSource reads the config file at path and the files it includes.
+// Each file is checked for unknown keys on its own, so errors name the file
+// they are in.
+func readSource(path string) (*configSource, error) {
+	src := &configSource{path: path, origins: make(map[*yaml.Node]string)}
+	root, err := src.read(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	src.root = root
+	return src, nil
+}
+
+// read parses one file and merges it over its includes; stack holds the
+// files including it, to detect cycles
+func (s *configSource) read(path string, stack []string) (*yaml.Node, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if i := slices.Index(stack, abs); i >= 0 {
+		return nil, fmt.Errorf("include cycle: %s", strings.Join(append(stack[i:], abs), " -> "))
+	}
+	stack = append(stack, abs)
+
+	data, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- path is user-provided config file or include
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(expandEnvVars(string(data))), &doc); err != nil {
+		return nil, s.parseError(path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil // Empty file
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, s.parseError(path, fmt.Errorf("line %d, column %d: top level must be a mapping", root.Line, root.Column))
+	}
+
+	includes, err := s.includes(path, root)
+	if err != nil {
+		return nil, err
+	}
+	if errs := unknownKeys(root, reflect.TypeOf(Config{}), ""); len(errs) > 0 {
+		return nil, s.parseError(path, errs)
+	}
+	if path != s.path {
+		s.record(root, path)
+	}
+
+	var merged *yaml.Node
+	for _, include := range includes {
+		node, err := s.read(include, stack)
+		if err != nil {
+			return nil, err
+		}
+		merged = s.merge(merged, node)
+	}
+	return s.merge(merged, root), nil
+}
+
+// includes removes the include key from root and returns the files it
+// lists, relative to the including file and with globs expanded
+func (s *configSource) includes(path string, root *yaml.Node) ([]string, error) {
+	var patterns []string
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != includeKey {
+			continue
+		}
+		value := root.Content[i+1]
+		var err error
+		if value.Kind == yaml.ScalarNode {
+			patterns = []string{value.Value}
+		} else {
+			err = value.Decode(&patterns)
+		}
+		if err != nil {
+			return nil, s.parseError(path, fmt.Errorf("line %d, column %d: include must be a file or a list of files", value.Line, value.Column))
+		}
+		root.Content = slices.Delete(root.Content, i, i+2)
+		break
+	}
+
+	var files []string
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(path), pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, s.parseError(path, fmt.Errorf("invalid include pattern %q: %w", pattern, err))
+		}
+		if len(matches) == 0 {
+			return nil, s.parseError(path, fmt.Errorf("include %q matched no files", pattern))
+		}
+		files = append(files, matches...) // Glob returns matches sorted
+	}
+	return files, nil
+}
+
+// parseError wraps err, naming the file when it is an include
+func (s *configSource) parseError(path string, err error) error {
+	if path == s.path {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return fmt.Errorf("failed to parse config file: %s: %w", path, err)
+}
+
+// record remembers path as the origin of node and everything below it
+func (s *configSource) record(node *yaml.Node, path string) {
+	s.origins[node] = path
+	for _, child := range node.Content {
+		s.record(child, path)
+	}
+}
+
+// merge merges over onto base. Mappings are merged key by key, lists of
+// mappings (repositories, teams, ...) are concatenated, and anything else in
+// over replaces base.
+func (s *configSource) merge(base, over *yaml.Node) *yaml.Node {
+	if base == nil {
+		return over
+	}
+	if over == nil {
+		return base
+	}
+
+	var merged yaml.Node
+	switch {
+	case base.Kind == yaml.MappingNode && over.Kind == yaml.MappingNode:
+		merged = *over
+		merged.Content = slices.Clone(base.Content)
+		for i := 0; i+1 < len(over.Content); i += 2 {
+			key, value := over.Content[i], over.Content[i+1]
+			if j := mappingIndex(&merged, key.Value); j >= 0 {
+				merged.Content[j+1] = s.merge(merged.Content[j+1], value)
+			} else {
+				merged.Content = append(merged.Content, key, value)
+			}
+		}
+	case isEntryList(base) && isEntryList(over):
+		merged = *over
+		merged.Content = append(slices.Clone(base.Content), over.Content...)
+	default:
+		return over
+	}
+	if origin, ok := s.origins[over]; ok {
+		s.origins[&merged] = origin
+	}
+	return &merged
+}
+
+// mappingIndex returns the index of key in a mapping node's content, or -1
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// isEntryList reports whether node is a non-empty list of mappings
+func isEntryList(node *yaml.Node) bool {
+	return node.Kind == yaml.SequenceNode && len(node.Content) > 0 && node.Content[0].Kind == yaml.MappingNode
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigs writes files (name -> content) into a temporary directory and
+// returns its path
+func writeConfigs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestLoad_Include(t *testing.T) {
+	t.Parallel()
+
+	dir := writeConfigs(t, map[string]string{
+		"base.yaml": `granularity: [daily, weekly]
+scoring:
+  points:
+    commit: 5
+    pr_merged: 40
+output:
+  directory: ./base-dist
+  format: [html, json]
+`,
+		"teams/backend.yaml": `repositories:
+  - owner: org
+    name: api
+teams:
+  - name: Backend
+    members: [alice]
+`,
+		"teams/frontend.yaml": `repositories:
+  - owner: org
+    name: web
+teams:
+  - name: Frontend
+    members: [bob]
+`,
+		"config.yaml": `include:
+  - base.yaml
+  - teams/*.yaml
+repositories:
+  - owner: org
+    name: docs
+scoring:
+  points:
+    commit: 8
+output:
+  format: [json]
+`,
+	})
+
+	cfg, err := LoadOffline(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, err)
+
+	// Mappings merge key by key, with the including file winning
+	assert.Equal(t, 8, cfg.Scoring.Points.Commit)
+	assert.Equal(t, 40, cfg.Scoring.Points.PRMerged)
+	assert.Equal(t, "./base-dist", cfg.Output.Directory)
+	// Lists of values are replaced, lists of entries concatenated
+	assert.Equal(t, []string{"json"}, cfg.Output.Format)
+	assert.Equal(t, []string{"daily", "weekly"}, cfg.Granularity)
+	assert.Equal(t, []RepositoryConfig{
+		{Owner: "org", Name: "api"},
+		{Owner: "org", Name: "web"},
+		{Owner: "org", Name: "docs"},
+	}, cfg.Repositories)
+	require.Len(t, cfg.Teams, 2)
+	assert.Equal(t, "Backend", cfg.Teams[0].Name)
+	assert.Equal(t, "Frontend", cfg.Teams[1].Name)
+}
+
+func TestLoad_IncludeNested(t *testing.T) {
+	t.Parallel()
+
+	dir := writeConfigs(t, map[string]string{
+		"org/defaults.yaml": "output:\n  locale: de\n",
+		"org/base.yaml":     "include: defaults.yaml\noutput:\n  directory: ./org\n",
+		"config.yaml":       "include: org/base.yaml\nrepositories:\n  - owner: org\n    name: api\n",
+	})
+
+	cfg, err := LoadOffline(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "de", cfg.Output.Locale, "includes are relative to the including file")
+	assert.Equal(t, "./org", cfg.Output.Directory)
+}
+
+func TestLoad_IncludeErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		files  map[string]string
+		errors []string
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"config.yaml": "include: a.yaml\n",
+				"a.yaml":      "include: config.yaml\n",
+			},
+			errors: []string{"include cycle:", "config.yaml -> ", "a.yaml -> ", "config.yaml"},
+		},
+		{
+			name:   "no match",
+			files:  map[string]string{"config.yaml": "include: teams/*.yaml\n"},
+			errors: []string{`teams/*.yaml" matched no files`},
+		},
+		{
+			name:   "not a list",
+			files:  map[string]string{"config.yaml": "include:\n  file: a.yaml\n"},
+			errors: []string{"line 2, column 3: include must be a file or a list of files"},
+		},
+		{
+			name: "unknown key in include",
+			files: map[string]string{
+				"config.yaml": "include: base.yaml\n",
+				"base.yaml":   "outptu:\n  directory: ./dist\n",
+			},
+			errors: []string{"base.yaml: line 1, column 1: outptu: unknown field (did you mean \"output\"?)"},
+		},
+		{
+			name: "validation error in include",
+			files: map[string]string{
+				"config.yaml": "include: base.yaml\nrepositories:\n  - owner: org\n    name: api\n",
+				"base.yaml":   "scoring:\n  normalization: median\n",
+			},
+			errors: []string{"base.yaml, line 2, column 18: scoring.normalization: invalid normalization: median"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := writeConfigs(t, tt.files)
+			_, err := LoadOffline(filepath.Join(dir, "config.yaml"))
+			require.Error(t, err)
+			for _, want := range tt.errors {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
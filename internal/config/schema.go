@@ -1,20 +1,38 @@
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/icons"
+)
 
 // Config represents the main configuration structure
 type Config struct {
-	Version       string             `yaml:"version"`
-	Auth          AuthConfig         `yaml:"auth"`
-	Repositories  []RepositoryConfig `yaml:"repositories"`
-	DateRange     DateRangeConfig    `yaml:"date_range"`
-	Granularity   []string           `yaml:"granularity"`
-	CustomPeriods []CustomPeriod     `yaml:"custom_periods,omitempty"`
-	Teams         []TeamConfig       `yaml:"teams,omitempty"`
-	Scoring       ScoringConfig      `yaml:"scoring"`
-	Output        OutputConfig       `yaml:"output"`
-	Cache         CacheConfig        `yaml:"cache"`
-	Options       OptionsConfig      `yaml:"options"`
+	Version       string               `yaml:"version"`
+	Preset        string               `yaml:"preset,omitempty"` // Defaults tuned for a kind of project: oss
+	Auth          AuthConfig           `yaml:"auth"`
+	Repositories  []RepositoryConfig   `yaml:"repositories"`
+	Source        SourceConfig         `yaml:"source,omitempty"`
+	DateRange     DateRangeConfig      `yaml:"date_range"`
+	Granularity   []string             `yaml:"granularity"`
+	CustomPeriods []CustomPeriod       `yaml:"custom_periods,omitempty"`
+	Teams         []TeamConfig         `yaml:"teams,omitempty"`
+	Groups        []GroupConfig        `yaml:"groups,omitempty"`
+	PathOwnership []PathOwnership      `yaml:"path_ownership,omitempty"`
+	Contributors  []ContributorConfig  `yaml:"contributors,omitempty"`
+	Scoring       ScoringConfig        `yaml:"scoring"`
+	CustomMetrics []CustomMetricConfig `yaml:"custom_metrics,omitempty"`
+	Forecast      ForecastConfig       `yaml:"forecast,omitempty"`
+	Output        OutputConfig         `yaml:"output"`
+	Cache         CacheConfig          `yaml:"cache"`
+	Options       OptionsConfig        `yaml:"options"`
+	Integrations  IntegrationsConfig   `yaml:"integrations,omitempty"`
+	Hooks         HooksConfig          `yaml:"hooks,omitempty"`
+	Community     CommunityConfig      `yaml:"community,omitempty"`
+	Telemetry     TelemetryConfig      `yaml:"telemetry,omitempty"`
+	Warehouse     WarehouseConfig      `yaml:"warehouse,omitempty"`
+	Events        EventsConfig         `yaml:"events,omitempty"`
+	Network       NetworkConfig        `yaml:"network,omitempty"`
 }
 
 // AuthConfig holds authentication configuration
@@ -22,10 +40,38 @@ type AuthConfig struct {
 	// Token-based authentication
 	GithubToken string `yaml:"github_token,omitempty"`
 
+	// Further tokens pooled with github_token to multiply the API rate
+	// limit; requests rotate among those with quota left
+	GithubTokens []string `yaml:"github_tokens,omitempty"`
+
+	// How pooled tokens are picked: "request" rotates on every request,
+	// "repository" keeps each repository's REST calls on one token
+	TokenRotation string `yaml:"token_rotation,omitempty"`
+
+	// TokenCommand is run through the shell when github_token is empty;
+	// its trimmed stdout is used as the token (e.g. "gh auth token")
+	TokenCommand string `yaml:"token_command,omitempty"`
+
+	// Keyring reads the token from the OS keyring when github_token and
+	// token_command are empty
+	Keyring *KeyringConfig `yaml:"keyring,omitempty"`
+
 	// GitHub App authentication
 	GithubApp *GithubAppConfig `yaml:"github_app,omitempty"`
 }
 
+// Token rotation modes
+const (
+	TokenRotationRequest    = "request"    // Next token with quota left on every request
+	TokenRotationRepository = "repository" // One token per repository, moving on once it runs out
+)
+
+// KeyringConfig identifies a token stored in the OS keyring
+type KeyringConfig struct {
+	Service string `yaml:"service"` // default: git-velocity
+	Account string `yaml:"account"` // default: github_token
+}
+
 // GithubAppConfig holds GitHub App authentication details
 type GithubAppConfig struct {
 	AppID          int64  `yaml:"app_id"`
@@ -34,11 +80,45 @@ type GithubAppConfig struct {
 	PrivateKey     string `yaml:"private_key,omitempty"`
 }
 
+// SourceConfig reads the repositories from exported data instead of the
+// GitHub API
+type SourceConfig struct {
+	// Directory or .tar.gz of a GitHub migration export, analyzed read-only
+	// without network access or authentication
+	Archive string `yaml:"archive,omitempty"`
+
+	// Directory of hourly GH Archive files (YYYY-MM-DD-H.json.gz) with the
+	// events of public repositories, which are still cloned from GitHub
+	GHArchive string `yaml:"gharchive,omitempty"`
+}
+
+// Exported reports whether GitHub data is read from an export rather than
+// the API, which needs no authentication
+func (s SourceConfig) Exported() bool {
+	return s.Archive != "" || s.GHArchive != ""
+}
+
 // RepositoryConfig defines a repository to analyze
 type RepositoryConfig struct {
-	Owner   string `yaml:"owner"`
-	Name    string `yaml:"name,omitempty"`
-	Pattern string `yaml:"pattern,omitempty"` // For wildcard matching
+	Owner   string   `yaml:"owner"`
+	Name    string   `yaml:"name,omitempty"`
+	Pattern string   `yaml:"pattern,omitempty"` // For wildcard matching
+	Paths   []string `yaml:"paths,omitempty"`   // Only count changes under these paths (monorepo scoping, "services/payments/**")
+
+	// Directory of coverage reports named by commit SHA (<sha>.xml for
+	// Cobertura, <sha>.info or <sha>.lcov for LCOV)
+	Coverage string `yaml:"coverage,omitempty"`
+
+	// Static-analysis findings at the period boundaries, for lint debt reduction
+	Lint *LintConfig `yaml:"lint,omitempty"`
+}
+
+// LintConfig reads golangci-lint or ESLint JSON findings at the start and end
+// of the period, either from report files or by running a command in the clone
+type LintConfig struct {
+	Start   string `yaml:"start,omitempty"`   // Report at the period start
+	End     string `yaml:"end,omitempty"`     // Report at the period end
+	Command string `yaml:"command,omitempty"` // Prints a JSON report; run at the last commit before each boundary
 }
 
 // DateRangeConfig specifies the analysis time range
@@ -56,17 +136,111 @@ type CustomPeriod struct {
 
 // TeamConfig defines a team and its members
 type TeamConfig struct {
-	Name    string   `yaml:"name"`
-	Members []string `yaml:"members"`
-	Color   string   `yaml:"color,omitempty"`
+	Name     string   `yaml:"name"`
+	Members  []string `yaml:"members"`
+	Color    string   `yaml:"color,omitempty"`
+	Capacity float64  `yaml:"capacity,omitempty"` // Full-time equivalents; defaults to the member count
+
+	// Shared logins or commit emails whose activity counts towards the team's
+	// totals without appearing as an individual contributor
+	ServiceAccounts []string `yaml:"service_accounts,omitempty"`
+}
+
+// GroupConfig gathers repositories into a product or portfolio, whose
+// metrics are rolled up between the organization and its repositories
+type GroupConfig struct {
+	Name  string   `yaml:"name"`
+	Repos []string `yaml:"repos"` // owner/name, or a pattern such as acme/payments-*
+	Color string   `yaml:"color,omitempty"`
+}
+
+// PathOwnership attributes the activity in matching paths to a team, whoever
+// does it, for monorepos every team commits to
+type PathOwnership struct {
+	Team  string   `yaml:"team"`
+	Paths []string `yaml:"paths"`           // Patterns like repositories[].paths
+	Repos []string `yaml:"repos,omitempty"` // owner/name or a pattern; default: every repository
+}
+
+// ContributorConfig holds settings for an individual contributor
+type ContributorConfig struct {
+	Login string `yaml:"login"`
+	Start string `yaml:"start,omitempty"` // Joined on this date (YYYY-MM-DD); scores are pro-rated
+	End   string `yaml:"end,omitempty"`   // Left on this date (YYYY-MM-DD); scores are pro-rated
+
+	// Out-of-office days, listed inline and/or read from an .ics or .csv
+	// calendar file. They don't break streaks and don't count as available.
+	Absences []AbsenceConfig `yaml:"absences,omitempty"`
+	Calendar string          `yaml:"calendar,omitempty"`
+
+	// Leave them off the leaderboards and contributor pages; their work
+	// still counts towards team, repository and organization totals
+	OptOut bool `yaml:"opt_out,omitempty"`
+
+	// IANA time zone they work in (Europe/Warsaw); when unset it is inferred
+	// from the UTC offsets of their commits
+	Timezone string `yaml:"timezone,omitempty"`
+}
+
+// CustomMetricConfig defines a metric computed from the other metrics of a
+// contributor, added to their JSON under custom and scored when Points is set
+type CustomMetricConfig struct {
+	Name       string  `yaml:"name"`             // snake_case key of the metric
+	Expression string  `yaml:"expression"`       // e.g. reviews_given / max(prs_merged, 1)
+	Points     float64 `yaml:"points,omitempty"` // Points per unit of the metric
+}
+
+// AbsenceConfig is an out-of-office period; both dates are included
+type AbsenceConfig struct {
+	Start string `yaml:"start"`         // YYYY-MM-DD
+	End   string `yaml:"end,omitempty"` // YYYY-MM-DD; defaults to the start date
 }
 
 // ScoringConfig holds gamification scoring configuration
 type ScoringConfig struct {
-	Enabled bool         `yaml:"enabled"`
-	Points  PointsConfig `yaml:"points"`
+	Enabled       bool         `yaml:"enabled"`
+	Points        PointsConfig `yaml:"points"`
+	Normalization string       `yaml:"normalization"` // none, percentile, zscore or per_active_day
+
+	// Half-life in days for weighting activity by recency; 0 scores all
+	// activity in the period equally
+	DecayHalfLifeDays float64 `yaml:"decay_half_life_days,omitempty"`
+
+	// Team statistic teams are ranked by: total, mean, median or
+	// trimmed_mean; empty keeps the configured team order
+	TeamRanking string `yaml:"team_ranking,omitempty"`
+
+	// Score every copy of a patch applied to several repositories; by
+	// default only the earliest copy counts towards commits and lines
+	CountDuplicatePatches bool `yaml:"count_duplicate_patches,omitempty"`
+
+	// Activity achievements are earned from: collected, everything fetched
+	// for the run, or period, only activity dated within the period
+	AchievementScope string `yaml:"achievement_scope,omitempty"`
 }
 
+// Achievement scopes
+const (
+	AchievementScopeCollected = "collected" // Including PRs and issues opened before the period but updated in it
+	AchievementScopePeriod    = "period"    // Only commits, PRs, reviews, issues and comments dated within the period
+)
+
+// Leaderboard normalization modes
+const (
+	NormalizationNone         = "none"           // Rank by raw score
+	NormalizationPercentile   = "percentile"     // Percentile of the score distribution
+	NormalizationZScore       = "zscore"         // Standard deviations from the mean score
+	NormalizationPerActiveDay = "per_active_day" // Score divided by active days
+)
+
+// Team ranking statistics
+const (
+	TeamRankingTotal       = "total"        // Sum of member scores
+	TeamRankingMean        = "mean"         // Mean member score
+	TeamRankingMedian      = "median"       // Median member score
+	TeamRankingTrimmedMean = "trimmed_mean" // Mean without the top and bottom 20% of members
+)
+
 // GetAchievements returns the hardcoded achievements (not configurable to prevent manipulation)
 func (s *ScoringConfig) GetAchievements() []AchievementConfig {
 	return defaultAchievements()
@@ -86,6 +260,18 @@ type PointsConfig struct {
 	IssueClosed     int     `yaml:"issue_closed"`
 	IssueComment    int     `yaml:"issue_comment"`          // Commenting on an issue
 	IssueReference  int     `yaml:"issue_reference_commit"` // Commit referencing an issue (fixes #123, etc.)
+	LinearCompleted int     `yaml:"linear_issue_completed"` // Completed Linear issue linked to a merged PR/commit
+	BuildBroken     int     `yaml:"build_broken"`           // Merged PR breaking the default branch build (negative for a penalty)
+	BuildFixed      int     `yaml:"build_fixed"`            // Merged PR fixing the default branch build
+	CoverageUp      int     `yaml:"coverage_improved"`      // Merged PR raising test coverage
+	LintFixed       int     `yaml:"lint_finding_fixed"`     // Static-analysis finding fixed over the period
+	SecurityFix     int     `yaml:"security_fix"`           // Security fix (on top of the points for the PR or commit itself)
+	Refactoring     int     `yaml:"refactoring_commit"`     // Commit removing far more non-test code than it adds
+	PatchPropagated int     `yaml:"patch_propagated"`       // Per repository a patch was copied to beyond the first
+	DirectPush      int     `yaml:"direct_push"`            // Commit pushed to the default branch without a PR (negative for a penalty)
+	AutoMerge       int     `yaml:"auto_merge_enabled"`     // Compliant merged PR they enabled auto-merge on or added to the merge queue
+	EffortPoint     float64 `yaml:"effort_point"`           // Per estimated effort point of merged PRs, when hooks.effort is set
+	Kudos           int     `yaml:"kudos_received"`         // Per kudos reaction received, when options.reactions is enabled
 	FastReview1h    int     `yaml:"fast_review_1h"`
 	FastReview4h    int     `yaml:"fast_review_4h"`
 	FastReview24h   int     `yaml:"fast_review_24h"`
@@ -116,9 +302,65 @@ type AchievementCondition struct {
 
 // OutputConfig specifies output generation settings
 type OutputConfig struct {
-	Directory string       `yaml:"directory"`
-	Format    []string     `yaml:"format"` // html, json
-	Deploy    DeployConfig `yaml:"deploy"`
+	Directory  string       `yaml:"directory"`
+	Format     []string     `yaml:"format"`       // html, json, release_notes
+	Badges     bool         `yaml:"badges"`       // Generate shields.io endpoint JSON files
+	Wallboard  bool         `yaml:"wallboard"`    // Generate wallboard.html, a rotating kiosk page for office TVs
+	HallOfFame bool         `yaml:"hall_of_fame"` // Carry data/hall-of-fame.json, the lifetime achievements and records, across runs
+	ShareCards bool         `yaml:"share_cards"`  // Draw a share image of every achievement earned in the run under shares/
+	Locale     string       `yaml:"locale"`       // Dashboard language: en, de, pl or fr
+	Icons      IconsConfig  `yaml:"icons"`        // How achievement icons are drawn
+	Deploy     DeployConfig `yaml:"deploy"`
+
+	// Reference no CDN: fonts fall back to the system's and icons are drawn
+	// from a generated local stylesheet, for restricted networks
+	OfflineAssets bool `yaml:"offline_assets"`
+
+	// Integrity hashes and a Content Security Policy, for hosting the
+	// dashboard under strict content policies
+	Security SiteSecurityConfig `yaml:"security"`
+
+	// Public URL the dashboard is published at; enables sitemap.xml, link
+	// preview tags and the social preview image
+	SiteURL string `yaml:"site_url,omitempty"`
+
+	// Size of the leaderboard files, for organizations with thousands of contributors
+	Leaderboard LeaderboardOutputConfig `yaml:"leaderboard,omitempty"`
+}
+
+// LeaderboardOutputConfig sizes data/leaderboard.json and the summary of
+// its top entries; the dashboard itself always shows every contributor
+type LeaderboardOutputConfig struct {
+	PageSize int `yaml:"page_size,omitempty"` // Entries per page under data/leaderboard/ (0 = one file)
+	Top      int `yaml:"top"`                 // Entries of data/leaderboard-top.json
+	MinScore int `yaml:"min_score,omitempty"` // Leave entries scoring less out of the leaderboard files
+}
+
+// SiteSecurityConfig hardens the generated site
+type SiteSecurityConfig struct {
+	SRI bool   `yaml:"sri"`           // Integrity hashes on the bundled scripts and styles of index.html
+	CSP string `yaml:"csp,omitempty"` // Content Security Policy: meta (a tag in every page), headers (a _headers file) or both
+}
+
+// Output formats
+const (
+	FormatHTML         = "html"
+	FormatJSON         = "json"
+	FormatReleaseNotes = "release_notes" // RELEASE_NOTES.md of the PRs merged in the period
+)
+
+// Where the Content Security Policy is written
+const (
+	CSPMeta    = "meta"
+	CSPHeaders = "headers"
+	CSPBoth    = "both"
+)
+
+// IconsConfig selects the icon set of achievements, so dashboards can render
+// without access to the FontAwesome CDN
+type IconsConfig struct {
+	Set  string `yaml:"set"`            // fontawesome, emoji or svg
+	Path string `yaml:"path,omitempty"` // Directory of the svg pack: <achievement-id>.svg or <icon>.svg files
 }
 
 // DeployConfig specifies deployment options
@@ -134,16 +376,389 @@ type CacheConfig struct {
 	TTL       string `yaml:"ttl"` // Duration string like "24h"
 }
 
+// IntegrationsConfig holds settings for third-party issue trackers
+type IntegrationsConfig struct {
+	Linear   LinearConfig   `yaml:"linear,omitempty"`
+	AuditLog AuditLogConfig `yaml:"audit_log,omitempty"`
+	Codecov  CodecovConfig  `yaml:"codecov,omitempty"`
+
+	SAMLIdentities SAMLIdentitiesConfig `yaml:"saml_identities,omitempty"`
+}
+
+// SAMLIdentitiesConfig enables reading the organization's SAML SSO external
+// identities, to name contributors as the identity provider does and match
+// commits made with corporate emails. Only GitHub Enterprise Cloud
+// organizations with SAML SSO expose them, to a token of an organization
+// owner with the admin:org scope, over GraphQL.
+type SAMLIdentitiesConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// HooksConfig holds external commands run through the shell at points of a run
+type HooksConfig struct {
+	// PreAnalyze runs before any data is fetched or loaded, PostGenerate
+	// once the site is generated; both get the run context in GIT_VELOCITY_*
+	// environment variables, and a failure fails the run
+	PreAnalyze   string `yaml:"pre_analyze,omitempty"`
+	PostGenerate string `yaml:"post_generate,omitempty"`
+
+	// Effort reads every fetched PR as a JSON array on stdin and prints
+	// effort estimates (story points or t-shirt sizes) as a JSON array
+	Effort string `yaml:"effort,omitempty"`
+}
+
+// CodecovConfig enables coverage lookups of merge commits through the Codecov API,
+// for repositories without a coverage report directory
+type CodecovConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Token   string `yaml:"token,omitempty"`   // API token, required for private repositories
+	URL     string `yaml:"url,omitempty"`     // Self-hosted Codecov (default: https://api.codecov.io)
+	Service string `yaml:"service,omitempty"` // Git provider as named by Codecov (default: github)
+}
+
+// LinearConfig configures detection of Linear issue IDs (e.g., ENG-123)
+type LinearConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	APIKey   string   `yaml:"api_key,omitempty"` // Optional: enables issue state lookups
+	TeamKeys []string `yaml:"team_keys"`         // Linear team keys to match (e.g., ENG, OPS)
+}
+
+// AuditLogConfig enables ingestion of organization audit-log events into repository
+// health metrics. The audit log API is only available to GitHub Enterprise Cloud
+// organizations and requires a token with the read:audit_log scope.
+type AuditLogConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Actions []string `yaml:"actions,omitempty"` // Extra audit-log actions to count (added to the defaults)
+}
+
+// Audit-log actions counted by default
+const (
+	AuditBranchProtectionOverride = "protected_branch.policy_override"
+	AuditRejectedRefUpdate        = "protected_branch.rejected_ref_update"
+	AuditForcePushPolicyChange    = "protected_branch.update_allow_force_pushes_enforcement_level"
+)
+
+// DefaultAuditLogActions are always fetched when the audit log integration is enabled
+var DefaultAuditLogActions = []string{
+	AuditBranchProtectionOverride,
+	AuditRejectedRefUpdate,
+	AuditForcePushPolicyChange,
+}
+
 // OptionsConfig holds advanced options
 type OptionsConfig struct {
 	ConcurrentRequests    int         `yaml:"concurrent_requests"`
 	IncludeBots           bool        `yaml:"include_bots"`
 	AdditionalBotPatterns []string    `yaml:"additional_bot_patterns"` // User-defined patterns (added to hardcoded defaults)
 	CloneDirectory        string      `yaml:"clone_directory"`         // Directory for local git clones
+	CloneProtocol         string      `yaml:"clone_protocol"`          // https (token) or ssh (key or agent)
+	SSH                   SSHConfig   `yaml:"ssh,omitempty"`           // SSH authentication for clone_protocol: ssh
 	ShallowClone          bool        `yaml:"shallow_clone"`           // Use shallow clone based on date range (faster cloning)
 	ShallowCloneBuffer    int         `yaml:"shallow_clone_buffer"`    // Extra commits to fetch beyond date range (default: 100)
 	UseGraphQL            bool        `yaml:"use_graphql"`             // Use GraphQL API for batched queries (fewer API calls)
 	UserAliases           []UserAlias `yaml:"user_aliases,omitempty"`  // Manual email/name to login mappings
+	Offline               bool        `yaml:"offline"`                 // Rebuild from the cached raw data snapshot without network access
+
+	// Resilience against a degraded GitHub API (complements the per-call retry)
+	RetryBudget    int                  `yaml:"retry_budget"`    // Total retries of transient errors per run across all API calls (0 = unlimited)
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"` // Stop calling a host after repeated 5xx responses
+
+	// Polite mode: hold API requests back to this rate, so a run can share a
+	// token with other automation (0 = unlimited)
+	MaxRequestsPerHour int `yaml:"max_requests_per_hour,omitempty"`
+
+	// How merged pull requests are found: "updated" lists them by last
+	// update, "search" asks the Search API for the merge dates in range
+	PRFetchMode string `yaml:"pr_fetch_mode"`
+
+	// How pull requests and issues are fetched: "list" pages through them,
+	// "search" retrieves only those inside the date range via the Search
+	// API, falling back to listing once its rate limit is hit
+	FetchStrategy string `yaml:"fetch_strategy"`
+
+	// Fetch the CI result of every merged PR's merge commit (one extra
+	// request per PR) to track broken and fixed default branch builds
+	BuildStatus bool `yaml:"build_status"`
+
+	// Fetch the 👍, ❤️ and 🎉 reactions on PRs, reviews and issue comments
+	// (one extra request per item) to count the kudos contributors give and
+	// receive; reactions on reviews need use_graphql
+	Reactions bool `yaml:"reactions"`
+
+	// PR labels marking security fixes (case-insensitive); PRs referencing
+	// a GHSA or CVE ID in their title or branch count regardless
+	SecurityLabels []string `yaml:"security_labels"`
+
+	// Files listed per repository in hotspots.json (0 disables hotspots)
+	HotspotLimit int `yaml:"hotspot_limit"`
+
+	// Hours apart the time zones of a reviewer and a PR's author must be
+	// for the review to count as cross-timezone collaboration (default: 4)
+	DistantTimezoneHours float64 `yaml:"distant_timezone_hours,omitempty"`
+
+	// Read the commits of large clones with the system git binary
+	SystemGit SystemGitConfig `yaml:"system_git"`
+
+	// Branches whose commits are counted: "all" branches and tags, only
+	// the "default" branch, or "main_branches" (the default branch plus
+	// main, master, develop, trunk and release branches)
+	CommitBranches string `yaml:"commit_branches"`
+
+	// Cross-check contributors against the member lists of the
+	// organizations owning the repositories
+	OrgMembers OrgMembersConfig `yaml:"org_members,omitempty"`
+
+	// Let contributors opt out of the leaderboards themselves by adding a
+	// .git-velocity-opt-out file to their profile repository (<login>/<login>)
+	ProfileOptOut bool `yaml:"profile_opt_out"`
+
+	// Fetch stargazers and forks with timestamps to chart adoption next to
+	// velocity on repository pages
+	Adoption AdoptionConfig `yaml:"adoption,omitempty"`
+
+	// Read go.mod and package.json files to link repositories requiring
+	// each other's packages, exported as a dependency graph
+	Dependencies bool `yaml:"dependencies"`
+
+	// Walk the default branch of each clone to find commits pushed to it
+	// directly rather than merged through a PR
+	DirectPushes bool `yaml:"direct_pushes"`
+
+	// Compare the line counts of a sample of commits with GitHub's commit
+	// stats to catch diff analyzer bugs (also enabled by analyze --verify)
+	Verify VerifyConfig `yaml:"verify,omitempty"`
+
+	// Summarize the tone of review comments per repository with local
+	// heuristics (questions, imperatives, emoji) to monitor review culture
+	ReviewTone ReviewToneConfig `yaml:"review_tone,omitempty"`
+}
+
+// DefaultDistantTimezoneHours is how far apart distant time zones are unless configured
+const DefaultDistantTimezoneHours = 4
+
+// VerifyConfig cross-checks locally counted additions and deletions
+// against the GitHub API
+type VerifyConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Sample  int  `yaml:"sample,omitempty"` // Commits compared per run, spread evenly over the collected commits (default: 20, 0 = all)
+}
+
+// ReviewToneConfig summarizes how review comments are phrased. The
+// heuristics run locally on the fetched review and inline comments; no text is sent anywhere.
+type ReviewToneConfig struct {
+	Enabled        bool `yaml:"enabled"`
+	PerContributor bool `yaml:"per_contributor,omitempty"` // Also summarize each reviewer's comments (default: off, per repository only)
+}
+
+// AdoptionConfig fetches when repositories gained their stars and forks
+type AdoptionConfig struct {
+	Enabled  bool `yaml:"enabled"`
+	MaxPages int  `yaml:"max_pages,omitempty"` // Pages of 100 stargazers and of 100 forks read per repository (default: 50, 0 = unlimited)
+}
+
+// OrgMembersConfig flags contributors who are not members of the analyzed
+// organizations, such as community contributors and former employees
+type OrgMembersConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	External string `yaml:"external,omitempty"` // flag, exclude or community (default: flag)
+	Team     string `yaml:"team,omitempty"`     // Team of external contributors with community (default: Community)
+}
+
+// Handling of contributors outside the organizations
+const (
+	ExternalFlag      = "flag"      // Mark them as external
+	ExternalExclude   = "exclude"   // Drop their activity
+	ExternalCommunity = "community" // Mark them and group them in a team
+)
+
+// CommunityConfig reports the pull requests and issues of contributors who
+// aren't maintainers, for open-source projects
+type CommunityConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Logins counted as maintainers on top of the repository owners, members
+	// and collaborators, the configured team members and organization members
+	Maintainers []string `yaml:"maintainers,omitempty"`
+}
+
+// Presets
+const (
+	PresetOSS = "oss" // Open-source projects: community metrics and organization member flags
+)
+
+// SystemGitConfig delegates reading commits to the git binary for clones
+// where go-git's diffing is too slow
+type SystemGitConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	MinSizeMB int    `yaml:"min_size_mb"`    // Clones whose .git directory takes at least this much (default: 500)
+	Path      string `yaml:"path,omitempty"` // git binary (default: git from PATH)
+}
+
+// SSHConfig configures SSH authentication for cloning
+type SSHConfig struct {
+	User          string `yaml:"user,omitempty"`           // default: git
+	KeyPath       string `yaml:"key_path,omitempty"`       // Private key file; empty uses the SSH agent
+	KeyPassphrase string `yaml:"key_passphrase,omitempty"` // Passphrase of an encrypted key
+	KnownHosts    string `yaml:"known_hosts,omitempty"`    // known_hosts file (default: SSH_KNOWN_HOSTS or ~/.ssh/known_hosts)
+}
+
+// Commit branch selections
+const (
+	CommitBranchesAll     = "all"           // Every branch and tag
+	CommitBranchesDefault = "default"       // The default branch only
+	CommitBranchesMain    = "main_branches" // The default and other long-lived branches
+)
+
+// Clone protocols
+const (
+	CloneHTTPS = "https" // Clone with the GitHub token over HTTPS
+	CloneSSH   = "ssh"   // Clone with an SSH key or agent
+)
+
+// ForecastConfig configures projections of the weekly timeline
+type ForecastConfig struct {
+	Enabled      bool    `yaml:"enabled"`
+	Method       string  `yaml:"method"`        // linear or holt_winters
+	Weeks        int     `yaml:"weeks"`         // Weeks to project (default: 4)
+	SeasonLength int     `yaml:"season_length"` // Weeks per season for holt_winters (default: 4)
+	Confidence   float64 `yaml:"confidence"`    // Confidence level of the bands (default: 0.9)
+}
+
+// Fetch strategies
+const (
+	FetchList   = "list"   // Page through pull requests and issues
+	FetchSearch = "search" // Search pull requests and issues by date
+)
+
+// Pull request fetch modes
+const (
+	PRFetchUpdated = "updated" // List PRs by last update and stop past the date range
+	PRFetchSearch  = "search"  // Search PRs by merge date; slower, exact on long-lived repos
+)
+
+// Forecast methods
+const (
+	ForecastLinear      = "linear"       // Least-squares trend line
+	ForecastHoltWinters = "holt_winters" // Additive Holt-Winters smoothing
+)
+
+// NetworkConfig configures TLS for corporate networks. Proxies are taken
+// from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+type NetworkConfig struct {
+	CABundle           string `yaml:"ca_bundle,omitempty"`            // PEM file of extra CA certificates to trust, e.g. of a TLS-intercepting proxy
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"` // Skip TLS certificate verification (insecure; prefer ca_bundle)
+}
+
+// TelemetryConfig configures OpenTelemetry tracing of analysis runs
+type TelemetryConfig struct {
+	Enabled     bool              `yaml:"enabled"`
+	Endpoint    string            `yaml:"endpoint,omitempty"`     // OTLP/HTTP collector, "host:port" or URL (default: OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318)
+	Insecure    bool              `yaml:"insecure,omitempty"`     // Use plain HTTP for a "host:port" endpoint
+	Headers     map[string]string `yaml:"headers,omitempty"`      // Extra request headers, e.g. for collector authentication
+	ServiceName string            `yaml:"service_name,omitempty"` // Reported service.name (default: git-velocity)
+}
+
+// WarehouseConfig exports fact tables of every run to data warehouses, for
+// long-term analytics joined with HR or delivery data. Rows are appended, so
+// the tables keep the history of all runs.
+type WarehouseConfig struct {
+	BigQuery  BigQueryConfig        `yaml:"bigquery,omitempty"`
+	Snowflake SnowflakeConfig       `yaml:"snowflake,omitempty"`
+	Tables    WarehouseTablesConfig `yaml:"tables,omitempty"`
+}
+
+// Enabled reports whether any warehouse export is enabled
+func (w WarehouseConfig) Enabled() bool {
+	return w.BigQuery.Enabled || w.Snowflake.Enabled
+}
+
+// Default warehouse table names
+const (
+	DefaultContributorsTable = "velocity_contributors"
+	DefaultRepositoriesTable = "velocity_repositories"
+	DefaultPeriodsTable      = "velocity_periods"
+)
+
+// WarehouseTablesConfig names the fact tables, created when they don't exist
+type WarehouseTablesConfig struct {
+	Contributors string `yaml:"contributors,omitempty"` // A row per contributor and run (default: velocity_contributors)
+	Repositories string `yaml:"repositories,omitempty"` // A row per repository and run (default: velocity_repositories)
+	Periods      string `yaml:"periods,omitempty"`      // A row of totals per run (default: velocity_periods)
+}
+
+// BigQueryConfig streams the fact tables into a BigQuery dataset
+type BigQueryConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	Project      string `yaml:"project"`
+	Dataset      string `yaml:"dataset"`
+	Token        string `yaml:"token,omitempty"`         // OAuth 2.0 access token
+	TokenCommand string `yaml:"token_command,omitempty"` // Prints an access token, e.g. gcloud auth print-access-token
+	URL          string `yaml:"url,omitempty"`           // API endpoint (default: https://bigquery.googleapis.com)
+}
+
+// SnowflakeConfig inserts the fact tables through the Snowflake SQL API
+type SnowflakeConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	Account      string `yaml:"account"` // Account identifier, e.g. myorg-analytics
+	Database     string `yaml:"database"`
+	Schema       string `yaml:"schema"`
+	Warehouse    string `yaml:"warehouse,omitempty"` // Virtual warehouse running the inserts (default: the user's)
+	Role         string `yaml:"role,omitempty"`
+	Token        string `yaml:"token,omitempty"`         // OAuth token, or a key-pair JWT with token_type KEYPAIR_JWT
+	TokenType    string `yaml:"token_type,omitempty"`    // OAUTH (default) or KEYPAIR_JWT
+	TokenCommand string `yaml:"token_command,omitempty"` // Prints a token
+	URL          string `yaml:"url,omitempty"`           // API endpoint (default: https://<account>.snowflakecomputing.com)
+}
+
+// Snowflake token types
+const (
+	SnowflakeOAuth      = "OAUTH"
+	SnowflakeKeyPairJWT = "KEYPAIR_JWT"
+)
+
+// EventsConfig publishes the events of every run, such as scores changing
+// and achievements being earned, for downstream systems to react to
+type EventsConfig struct {
+	Kafka KafkaConfig `yaml:"kafka,omitempty"`
+	NATS  NATSConfig  `yaml:"nats,omitempty"`
+}
+
+// Enabled reports whether any event stream is enabled
+func (e EventsConfig) Enabled() bool {
+	return e.Kafka.Enabled || e.NATS.Enabled
+}
+
+// Default event destinations
+const (
+	DefaultKafkaTopic  = "git-velocity-events"
+	DefaultNATSSubject = "git-velocity"
+)
+
+// KafkaConfig produces events to a Kafka topic through a REST proxy
+// (Confluent REST Proxy, Redpanda HTTP Proxy or Karapace)
+type KafkaConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	URL      string `yaml:"url"`             // REST proxy, e.g. https://kafka-rest.internal:8082
+	Topic    string `yaml:"topic,omitempty"` // Default: git-velocity-events
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	Token    string `yaml:"token,omitempty"` // Bearer token, instead of a username and password
+}
+
+// NATSConfig publishes events to NATS subjects named <subject>.<event type>
+type NATSConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	URL      string `yaml:"url"`               // nats://host:4222, or tls://host:4222
+	Subject  string `yaml:"subject,omitempty"` // Subject prefix (default: git-velocity)
+	Token    string `yaml:"token,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// CircuitBreakerConfig configures the per-host circuit breaker around GitHub API calls
+type CircuitBreakerConfig struct {
+	Threshold int    `yaml:"threshold"` // Consecutive 5xx responses before the circuit opens (0 = disabled)
+	Cooldown  string `yaml:"cooldown"`  // How long the circuit stays open before a trial request (default: 1m)
 }
 
 // DefaultBotPatterns returns the hardcoded bot patterns that are always applied
@@ -184,7 +799,8 @@ func DefaultConfig() *Config {
 		Version:     "1.0",
 		Granularity: []string{"daily", "weekly", "monthly"},
 		Scoring: ScoringConfig{
-			Enabled: true,
+			Enabled:       true,
+			Normalization: NormalizationNone,
 			Points: PointsConfig{
 				Commit:                 10,
 				CommitWithTests:        15,
@@ -198,6 +814,13 @@ func DefaultConfig() *Config {
 				IssueClosed:            20,
 				IssueComment:           5,
 				IssueReference:         5,
+				LinearCompleted:        20,
+				CoverageUp:             15,
+				LintFixed:              2,
+				SecurityFix:            40,
+				Refactoring:            10,
+				PatchPropagated:        5,
+				Kudos:                  2,
 				FastReview1h:           50,
 				FastReview4h:           25,
 				FastReview24h:          10,
@@ -209,9 +832,22 @@ func DefaultConfig() *Config {
 				MultiplierEarlyMorning: 2.0,
 			},
 		},
+		Forecast: ForecastConfig{
+			Method:       ForecastLinear,
+			Weeks:        4,
+			SeasonLength: 4,
+			Confidence:   0.9,
+		},
 		Output: OutputConfig{
 			Directory: "./dist",
-			Format:    []string{"html", "json"},
+			Format:    []string{FormatHTML, FormatJSON},
+			Badges:    true,
+			Locale:    "en",
+			Icons:     IconsConfig{Set: icons.FontAwesome},
+			Security:  SiteSecurityConfig{SRI: true},
+			Leaderboard: LeaderboardOutputConfig{
+				Top: 10,
+			},
 			Deploy: DeployConfig{
 				GHPages:  true,
 				Artifact: true,
@@ -227,9 +863,24 @@ func DefaultConfig() *Config {
 			IncludeBots:           false,
 			AdditionalBotPatterns: []string{}, // Users can add custom patterns here
 			CloneDirectory:        "./.repos",
+			CloneProtocol:         CloneHTTPS,
 			ShallowClone:          true, // Default to shallow clone for faster cloning
 			ShallowCloneBuffer:    25,   // Extra commits beyond date range for safety margin
 			UseGraphQL:            true, // Default to GraphQL for fewer API calls
+			RetryBudget:           100,
+			CircuitBreaker: CircuitBreakerConfig{
+				Threshold: 5,
+				Cooldown:  "1m",
+			},
+			PRFetchMode:    PRFetchUpdated,
+			FetchStrategy:  FetchList,
+			SecurityLabels: []string{"security", "vulnerability"},
+			HotspotLimit:   10,
+			SystemGit:      SystemGitConfig{MinSizeMB: 500},
+			CommitBranches: CommitBranchesAll,
+			OrgMembers:     OrgMembersConfig{External: ExternalFlag, Team: "Community"},
+			Adoption:       AdoptionConfig{MaxPages: 50},
+			Verify:         VerifyConfig{Sample: 20},
 		},
 	}
 }
@@ -404,5 +1055,28 @@ func defaultAchievements() []AchievementConfig {
 		{ID: "issue-ref-25", Name: "Traceability Pro", Description: "Referenced issues in 25 commits", Icon: "fa-sitemap", Condition: AchievementCondition{Type: "issue_references", Threshold: 25}},
 		{ID: "issue-ref-50", Name: "Issue Tracker", Description: "Referenced issues in 50 commits", Icon: "fa-chart-gantt", Condition: AchievementCondition{Type: "issue_references", Threshold: 50}},
 		{ID: "issue-ref-100", Name: "Traceability Master", Description: "Referenced issues in 100 commits", Icon: "fa-network-wired", Condition: AchievementCondition{Type: "issue_references", Threshold: 100}},
+
+		// ===== MERGED PRS LINKING ISSUES (Tiers: 5, 10, 25, 50) =====
+		{ID: "linked-pr-5", Name: "Context Giver", Description: "Linked issues in 5 merged PRs", Icon: "fa-clipboard-list", Condition: AchievementCondition{Type: "linked_prs", Threshold: 5}},
+		{ID: "linked-pr-10", Name: "Paper Trail", Description: "Linked issues in 10 merged PRs", Icon: "fa-scroll", Condition: AchievementCondition{Type: "linked_prs", Threshold: 10}},
+		{ID: "linked-pr-25", Name: "Ticket Closer", Description: "Linked issues in 25 merged PRs", Icon: "fa-clipboard-check", Condition: AchievementCondition{Type: "linked_prs", Threshold: 25}},
+		{ID: "linked-pr-50", Name: "Chain of Custody", Description: "Linked issues in 50 merged PRs", Icon: "fa-share-nodes", Condition: AchievementCondition{Type: "linked_prs", Threshold: 50}},
+
+		// ===== ISSUE TRACEABILITY (Tiers: 75%, 90%, 100% of at least 10 merged PRs) =====
+		{ID: "traceable-75", Name: "Breadcrumb Trail", Description: "Linked issues in 75% of at least 10 merged PRs", Icon: "fa-list-check", Condition: AchievementCondition{Type: "issue_traceability", Threshold: 75}},
+		{ID: "traceable-90", Name: "Audit Ready", Description: "Linked issues in 90% of at least 10 merged PRs", Icon: "fa-magnifying-glass", Condition: AchievementCondition{Type: "issue_traceability", Threshold: 90}},
+		{ID: "traceable-100", Name: "Fully Traceable", Description: "Linked issues in every one of at least 10 merged PRs", Icon: "fa-circle-check", Condition: AchievementCondition{Type: "issue_traceability", Threshold: 100}},
+
+		// ===== COVERAGE IMPROVED (Tiers: 1, 5, 10, 25) =====
+		{ID: "coverage-1", Name: "Safety Net", Description: "Raised test coverage with a merged PR", Icon: "fa-shield", Condition: AchievementCondition{Type: "coverage_improved", Threshold: 1}},
+		{ID: "coverage-5", Name: "Test Advocate", Description: "Raised test coverage with 5 merged PRs", Icon: "fa-vial", Condition: AchievementCondition{Type: "coverage_improved", Threshold: 5}},
+		{ID: "coverage-10", Name: "Quality Guardian", Description: "Raised test coverage with 10 merged PRs", Icon: "fa-shield-halved", Condition: AchievementCondition{Type: "coverage_improved", Threshold: 10}},
+		{ID: "coverage-25", Name: "Coverage Champion", Description: "Raised test coverage with 25 merged PRs", Icon: "fa-trophy", Condition: AchievementCondition{Type: "coverage_improved", Threshold: 25}},
+
+		// ===== SECURITY FIXES (Tiers: 1, 5, 10, 25) =====
+		{ID: "security-1", Name: "Patch Responder", Description: "Shipped your first security fix", Icon: "fa-bandage", Condition: AchievementCondition{Type: "security_fixes", Threshold: 1}},
+		{ID: "security-5", Name: "Vulnerability Hunter", Description: "Shipped 5 security fixes", Icon: "fa-bug-slash", Condition: AchievementCondition{Type: "security_fixes", Threshold: 5}},
+		{ID: "security-10", Name: "Security Sentinel", Description: "Shipped 10 security fixes", Icon: "fa-user-shield", Condition: AchievementCondition{Type: "security_fixes", Threshold: 10}},
+		{ID: "security-25", Name: "Security Champion", Description: "Shipped 25 security fixes", Icon: "fa-lock", Condition: AchievementCondition{Type: "security_fixes", Threshold: 25}},
 	}
 }
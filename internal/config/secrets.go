@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/redact"
+)
+
+const (
+	// DefaultKeyringService is the keyring service name used when none is configured
+	DefaultKeyringService = "git-velocity"
+	// DefaultKeyringAccount is the keyring account name used when none is configured
+	DefaultKeyringAccount = "github_token"
+)
+
+// secretCommandTimeout bounds token_command and keyring lookups
+const secretCommandTimeout = 30 * time.Second
+
+// runCommand executes a command and returns its trimmed stdout.
+// It is a variable so tests can stub out the OS.
+var runCommand = func(ctx context.Context, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204 -- command comes from the user's own config
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// ResolveSecrets fills in the GitHub token from token_command or the OS keyring
+// when it is not set directly, and registers all configured secrets for redaction.
+func (c *Config) ResolveSecrets() error {
+	if !c.HasGithubToken() {
+		ctx, cancel := context.WithTimeout(context.Background(), secretCommandTimeout)
+		defer cancel()
+
+		switch {
+		case c.Auth.TokenCommand != "":
+			token, err := runShell(ctx, c.Auth.TokenCommand)
+			if err != nil {
+				return fmt.Errorf("auth.token_command failed: %w", redact.Error(err))
+			}
+			if token == "" {
+				return fmt.Errorf("auth.token_command returned an empty token")
+			}
+			c.Auth.GithubToken = token
+		case c.Auth.Keyring != nil:
+			token, err := readKeyring(ctx, c.Auth.Keyring)
+			if err != nil {
+				return fmt.Errorf("failed to read token from keyring: %w", err)
+			}
+			c.Auth.GithubToken = token
+		}
+	}
+
+	for _, token := range c.GithubTokens() {
+		redact.Register(token)
+	}
+	redact.Register(c.Options.SSH.KeyPassphrase)
+	redact.Register(c.Integrations.Linear.APIKey)
+	redact.Register(c.Integrations.Codecov.Token)
+	if c.Auth.GithubApp != nil {
+		redact.Register(c.Auth.GithubApp.PrivateKey)
+	}
+	for _, value := range c.Telemetry.Headers {
+		redact.Register(value)
+	}
+	redact.Register(c.Warehouse.BigQuery.Token)
+	redact.Register(c.Warehouse.Snowflake.Token)
+	redact.Register(c.Events.Kafka.Password)
+	redact.Register(c.Events.Kafka.Token)
+	redact.Register(c.Events.NATS.Password)
+	redact.Register(c.Events.NATS.Token)
+
+	return nil
+}
+
+// ResolveWarehouseTokens fills in the warehouse tokens from their
+// token_command when they are not set directly. It is called right before
+// exporting rather than with the other secrets, as warehouse tokens are
+// often short-lived and a run can take longer than they last.
+func (c *Config) ResolveWarehouseTokens(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, secretCommandTimeout)
+	defer cancel()
+
+	for _, w := range []struct {
+		field   string
+		enabled bool
+		command string
+		token   *string
+	}{
+		{"warehouse.bigquery", c.Warehouse.BigQuery.Enabled, c.Warehouse.BigQuery.TokenCommand, &c.Warehouse.BigQuery.Token},
+		{"warehouse.snowflake", c.Warehouse.Snowflake.Enabled, c.Warehouse.Snowflake.TokenCommand, &c.Warehouse.Snowflake.Token},
+	} {
+		if !w.enabled || w.command == "" || *w.token != "" {
+			continue
+		}
+		token, err := runShell(ctx, w.command)
+		if err != nil {
+			return fmt.Errorf("%s.token_command failed: %w", w.field, redact.Error(err))
+		}
+		if token == "" {
+			return fmt.Errorf("%s.token_command returned an empty token", w.field)
+		}
+		redact.Register(token)
+		*w.token = token
+	}
+	return nil
+}
+
+// runShell runs a command line through the platform shell
+func runShell(ctx context.Context, command string) (string, error) {
+	if runtime.GOOS == "windows" {
+		return runCommand(ctx, "cmd", "/C", command)
+	}
+	return runCommand(ctx, "sh", "-c", command)
+}
+
+// readKeyring looks up a generic password in the OS keyring using the
+// platform's command-line tool, so no cgo or extra dependencies are needed
+func readKeyring(ctx context.Context, kc *KeyringConfig) (string, error) {
+	service := kc.Service
+	if service == "" {
+		service = DefaultKeyringService
+	}
+	account := kc.Account
+	if account == "" {
+		account = DefaultKeyringAccount
+	}
+
+	var token string
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		token, err = runCommand(ctx, "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux", "freebsd", "openbsd", "netbsd":
+		// libsecret (GNOME Keyring, KWallet via Secret Service)
+		token, err = runCommand(ctx, "secret-tool", "lookup", "service", service, "account", account)
+	default:
+		return "", fmt.Errorf("keyring is not supported on %s; use auth.token_command instead", runtime.GOOS)
+	}
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", fmt.Errorf("no token stored for service %q, account %q", service, account)
+	}
+	return token, nil
+}
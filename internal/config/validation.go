@@ -2,17 +2,42 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/internal/i18n"
+	"github.com/lukaszraczylo/git-velocity/internal/icons"
+	"github.com/lukaszraczylo/git-velocity/internal/metricexpr"
+	"github.com/lukaszraczylo/git-velocity/internal/pathfilter"
 )
 
+// customMetricName matches the snake_case names of custom metrics
+var customMetricName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
 // ValidationError represents a configuration validation error
 type ValidationError struct {
 	Field   string
 	Message string
+
+	// Position of the offending value in the config, when known; File is
+	// set when it is in an included file
+	File   string
+	Line   int
+	Column int
 }
 
 func (e ValidationError) Error() string {
-	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	switch {
+	case e.File != "":
+		return fmt.Sprintf("%s, line %d, column %d: %s: %s", e.File, e.Line, e.Column, e.Field, e.Message)
+	case e.Line > 0:
+		return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, e.Field, e.Message)
+	default:
+		return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
 }
 
 // ValidationErrors is a collection of validation errors
@@ -34,14 +59,40 @@ func (e ValidationErrors) Error() string {
 func Validate(cfg *Config) error {
 	var errs ValidationErrors
 
-	// Validate authentication
-	if !cfg.HasGithubToken() && !cfg.HasGithubApp() {
+	// Validate authentication (not needed when rebuilding offline from cached
+	// data or reading an export)
+	if !cfg.Options.Offline && !cfg.Source.Exported() && !cfg.HasGithubToken() && !cfg.HasGithubApp() {
 		errs = append(errs, ValidationError{
 			Field:   "auth",
 			Message: "either github_token or github_app must be configured",
 		})
 	}
 
+	switch cfg.Auth.TokenRotation {
+	case "", TokenRotationRequest, TokenRotationRepository:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "auth.token_rotation",
+			Message: fmt.Sprintf("must be %q or %q", TokenRotationRequest, TokenRotationRepository),
+		})
+	}
+
+	switch cfg.Preset {
+	case "", PresetOSS:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "preset",
+			Message: fmt.Sprintf("invalid preset: %s (must be oss)", cfg.Preset),
+		})
+	}
+
+	if cfg.Source.Archive != "" && cfg.Source.GHArchive != "" {
+		errs = append(errs, ValidationError{
+			Field:   "source",
+			Message: "archive and gharchive can't be combined",
+		})
+	}
+
 	// Validate repositories
 	if len(cfg.Repositories) == 0 {
 		errs = append(errs, ValidationError{
@@ -63,6 +114,18 @@ func Validate(cfg *Config) error {
 				Message: "either name or pattern must be specified",
 			})
 		}
+		if err := pathfilter.Validate(repo.Paths); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("repositories[%d].paths", i),
+				Message: err.Error(),
+			})
+		}
+		if lint := repo.Lint; lint != nil && lint.Command == "" && (lint.Start == "" || lint.End == "") {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("repositories[%d].lint", i),
+				Message: "either command or both start and end reports must be specified",
+			})
+		}
 	}
 
 	// Validate date range
@@ -81,10 +144,10 @@ func Validate(cfg *Config) error {
 		"weekly":  true,
 		"monthly": true,
 	}
-	for _, g := range cfg.Granularity {
+	for i, g := range cfg.Granularity {
 		if !validGranularities[g] {
 			errs = append(errs, ValidationError{
-				Field:   "granularity",
+				Field:   fmt.Sprintf("granularity[%d]", i),
 				Message: fmt.Sprintf("invalid granularity: %s (must be daily, weekly, or monthly)", g),
 			})
 		}
@@ -98,15 +161,231 @@ func Validate(cfg *Config) error {
 				Message: "team name is required",
 			})
 		}
-		if len(team.Members) == 0 {
+		if len(team.Members) == 0 && len(cfg.PathOwnershipFor(team.Name)) == 0 {
 			errs = append(errs, ValidationError{
 				Field:   fmt.Sprintf("teams[%d].members", i),
-				Message: "team must have at least one member",
+				Message: "team must have at least one member or own paths in path_ownership",
+			})
+		}
+		if team.Capacity < 0 {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("teams[%d].capacity", i),
+				Message: "team capacity must not be negative",
+			})
+		}
+		for j, account := range team.ServiceAccounts {
+			field := fmt.Sprintf("teams[%d].service_accounts[%d]", i, j)
+			if strings.TrimSpace(account) == "" {
+				errs = append(errs, ValidationError{
+					Field:   field,
+					Message: "service account must not be empty",
+				})
+				continue
+			}
+			if owner := cfg.GetServiceAccountTeam(account); owner != &cfg.Teams[i] {
+				errs = append(errs, ValidationError{
+					Field:   field,
+					Message: fmt.Sprintf("service account %s is already listed by team %s", account, owner.Name),
+				})
+			}
+			if member := cfg.GetTeamForUser(account); member != nil {
+				errs = append(errs, ValidationError{
+					Field:   field,
+					Message: fmt.Sprintf("service account %s is also a member of team %s", account, member.Name),
+				})
+			}
+		}
+	}
+
+	// Validate groups
+	groupNames := make(map[string]bool)
+	for i, group := range cfg.Groups {
+		if group.Name == "" {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("groups[%d].name", i),
+				Message: "group name is required",
+			})
+		} else if groupNames[strings.ToLower(group.Name)] {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("groups[%d].name", i),
+				Message: fmt.Sprintf("group %s is already defined", group.Name),
+			})
+		}
+		groupNames[strings.ToLower(group.Name)] = true
+		if len(group.Repos) == 0 {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("groups[%d].repos", i),
+				Message: "group must have at least one repository",
+			})
+		}
+		for j, repo := range group.Repos {
+			owner, name, ok := strings.Cut(repo, "/")
+			if _, err := path.Match(repo, ""); err != nil || !ok || owner == "" || name == "" {
+				errs = append(errs, ValidationError{
+					Field:   fmt.Sprintf("groups[%d].repos[%d]", i, j),
+					Message: fmt.Sprintf("invalid repository: %s (must be owner/name or a pattern such as owner/payments-*)", repo),
+				})
+			}
+		}
+	}
+
+	// Validate path ownership
+	for i, rule := range cfg.PathOwnership {
+		if rule.Team == "" {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("path_ownership[%d].team", i),
+				Message: "team is required",
+			})
+		} else if cfg.GetTeam(rule.Team) == nil {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("path_ownership[%d].team", i),
+				Message: fmt.Sprintf("unknown team: %s (must be listed under teams)", rule.Team),
+			})
+		}
+		if len(rule.Paths) == 0 {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("path_ownership[%d].paths", i),
+				Message: "at least one path is required",
+			})
+		} else if err := pathfilter.Validate(rule.Paths); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("path_ownership[%d].paths", i),
+				Message: err.Error(),
+			})
+		}
+		for j, repo := range rule.Repos {
+			owner, name, ok := strings.Cut(repo, "/")
+			if _, err := path.Match(repo, ""); err != nil || !ok || owner == "" || name == "" {
+				errs = append(errs, ValidationError{
+					Field:   fmt.Sprintf("path_ownership[%d].repos[%d]", i, j),
+					Message: fmt.Sprintf("invalid repository: %s (must be owner/name or a pattern such as owner/payments-*)", repo),
+				})
+			}
+		}
+	}
+
+	// Validate contributors
+	for i := range cfg.Contributors {
+		cc := &cfg.Contributors[i]
+		if cc.Login == "" {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("contributors[%d].login", i),
+				Message: "contributor login is required",
 			})
 		}
+		start, end, err := cc.Membership()
+		if err != nil {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("contributors[%d]", i),
+				Message: err.Error(),
+			})
+		} else if start != nil && end != nil && end.Before(*start) {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("contributors[%d].end", i),
+				Message: "end date must not be before start date",
+			})
+		}
+		for j := range cc.Absences {
+			start, end, err := cc.Absences[j].Dates()
+			if err != nil {
+				errs = append(errs, ValidationError{
+					Field:   fmt.Sprintf("contributors[%d].absences[%d]", i, j),
+					Message: err.Error(),
+				})
+			} else if end.Before(start) {
+				errs = append(errs, ValidationError{
+					Field:   fmt.Sprintf("contributors[%d].absences[%d].end", i, j),
+					Message: "end date must not be before start date",
+				})
+			}
+		}
+		if _, err := cc.Location(); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("contributors[%d].timezone", i),
+				Message: err.Error(),
+			})
+		}
+		if cc.Calendar != "" {
+			switch strings.ToLower(filepath.Ext(cc.Calendar)) {
+			case ".ics", ".csv":
+			default:
+				errs = append(errs, ValidationError{
+					Field:   fmt.Sprintf("contributors[%d].calendar", i),
+					Message: fmt.Sprintf("unsupported calendar file: %s (must be .ics or .csv)", cc.Calendar),
+				})
+			}
+		}
+	}
+
+	// Validate custom metrics; expressions may refer to the ones defined before
+	customMetrics := make(map[string]bool)
+	for i, cm := range cfg.CustomMetrics {
+		switch {
+		case !customMetricName.MatchString(cm.Name):
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("custom_metrics[%d].name", i),
+				Message: fmt.Sprintf("invalid name: %q (must be snake_case)", cm.Name),
+			})
+		case metricexpr.IsField(cm.Name):
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("custom_metrics[%d].name", i),
+				Message: fmt.Sprintf("name %s is a built-in metric", cm.Name),
+			})
+		case customMetrics[cm.Name]:
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("custom_metrics[%d].name", i),
+				Message: fmt.Sprintf("duplicate custom metric: %s", cm.Name),
+			})
+		}
+		if expr, err := metricexpr.Compile(cm.Expression); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("custom_metrics[%d].expression", i),
+				Message: err.Error(),
+			})
+		} else {
+			for _, name := range expr.Vars() {
+				if !metricexpr.IsField(name) && !customMetrics[name] {
+					errs = append(errs, ValidationError{
+						Field:   fmt.Sprintf("custom_metrics[%d].expression", i),
+						Message: fmt.Sprintf("unknown metric: %s", name),
+					})
+				}
+			}
+		}
+		customMetrics[cm.Name] = true
 	}
 
 	// Validate scoring
+	switch cfg.Scoring.Normalization {
+	case "", NormalizationNone, NormalizationPercentile, NormalizationZScore, NormalizationPerActiveDay:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "scoring.normalization",
+			Message: fmt.Sprintf("invalid normalization: %s (must be none, percentile, zscore or per_active_day)", cfg.Scoring.Normalization),
+		})
+	}
+	if cfg.Scoring.DecayHalfLifeDays < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "scoring.decay_half_life_days",
+			Message: "decay half-life must not be negative",
+		})
+	}
+	switch cfg.Scoring.AchievementScope {
+	case "", AchievementScopeCollected, AchievementScopePeriod:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "scoring.achievement_scope",
+			Message: fmt.Sprintf("invalid achievement scope: %s (must be collected or period)", cfg.Scoring.AchievementScope),
+		})
+	}
+	switch cfg.Scoring.TeamRanking {
+	case "", TeamRankingTotal, TeamRankingMean, TeamRankingMedian, TeamRankingTrimmedMean:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "scoring.team_ranking",
+			Message: fmt.Sprintf("invalid team ranking: %s (must be total, mean, median or trimmed_mean)", cfg.Scoring.TeamRanking),
+		})
+	}
 	if cfg.Scoring.Enabled {
 		if cfg.Scoring.Points.Commit < 0 {
 			errs = append(errs, ValidationError{
@@ -119,6 +398,36 @@ func Validate(cfg *Config) error {
 
 	// Note: Achievements are hardcoded and not user-configurable to prevent manipulation
 
+	// Validate forecast
+	if cfg.Forecast.Enabled {
+		switch cfg.Forecast.Method {
+		case ForecastLinear, ForecastHoltWinters:
+		default:
+			errs = append(errs, ValidationError{
+				Field:   "forecast.method",
+				Message: fmt.Sprintf("invalid forecast method: %s (must be linear or holt_winters)", cfg.Forecast.Method),
+			})
+		}
+		if cfg.Forecast.Weeks < 1 || cfg.Forecast.Weeks > 52 {
+			errs = append(errs, ValidationError{
+				Field:   "forecast.weeks",
+				Message: "forecast weeks must be between 1 and 52",
+			})
+		}
+		if cfg.Forecast.Method == ForecastHoltWinters && cfg.Forecast.SeasonLength < 2 {
+			errs = append(errs, ValidationError{
+				Field:   "forecast.season_length",
+				Message: "season length must be at least 2 weeks",
+			})
+		}
+		if cfg.Forecast.Confidence <= 0 || cfg.Forecast.Confidence >= 1 {
+			errs = append(errs, ValidationError{
+				Field:   "forecast.confidence",
+				Message: "confidence must be between 0 and 1",
+			})
+		}
+	}
+
 	// Validate output
 	if cfg.Output.Directory == "" {
 		errs = append(errs, ValidationError{
@@ -127,15 +436,62 @@ func Validate(cfg *Config) error {
 		})
 	}
 
-	validFormats := map[string]bool{"html": true, "json": true}
-	for _, format := range cfg.Output.Format {
+	validFormats := map[string]bool{FormatHTML: true, FormatJSON: true, FormatReleaseNotes: true}
+	for i, format := range cfg.Output.Format {
 		if !validFormats[format] {
 			errs = append(errs, ValidationError{
-				Field:   "output.format",
-				Message: fmt.Sprintf("invalid format: %s (must be html or json)", format),
+				Field:   fmt.Sprintf("output.format[%d]", i),
+				Message: fmt.Sprintf("invalid format: %s (must be html, json or release_notes)", format),
+			})
+		}
+	}
+
+	if cfg.Output.Locale != "" && !i18n.IsSupported(cfg.Output.Locale) {
+		errs = append(errs, ValidationError{
+			Field:   "output.locale",
+			Message: fmt.Sprintf("unsupported locale: %s (must be one of %s)", cfg.Output.Locale, strings.Join(i18n.Supported(), ", ")),
+		})
+	}
+	if cfg.Output.Icons.Set != "" && !icons.IsSupported(cfg.Output.Icons.Set) {
+		errs = append(errs, ValidationError{
+			Field:   "output.icons.set",
+			Message: fmt.Sprintf("unsupported icon set: %s (must be one of %s)", cfg.Output.Icons.Set, strings.Join(icons.Supported(), ", ")),
+		})
+	}
+	switch cfg.Output.Security.CSP {
+	case "", CSPMeta, CSPHeaders, CSPBoth:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "output.security.csp",
+			Message: fmt.Sprintf("invalid CSP placement: %s (must be meta, headers or both)", cfg.Output.Security.CSP),
+		})
+	}
+	if cfg.Output.SiteURL != "" {
+		if u, err := url.Parse(cfg.Output.SiteURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, ValidationError{
+				Field:   "output.site_url",
+				Message: fmt.Sprintf("invalid site URL: %s (must be an absolute http or https URL)", cfg.Output.SiteURL),
 			})
 		}
 	}
+	if cfg.Output.Leaderboard.PageSize < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "output.leaderboard.page_size",
+			Message: "page size must not be negative",
+		})
+	}
+	if cfg.Output.Leaderboard.Top < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "output.leaderboard.top",
+			Message: "top entries must not be negative",
+		})
+	}
+	if cfg.Output.Icons.Set == icons.SVG && cfg.Output.Icons.Path == "" {
+		errs = append(errs, ValidationError{
+			Field:   "output.icons.path",
+			Message: "the svg icon set needs the directory of the pack",
+		})
+	}
 
 	// Validate cache
 	if cfg.Cache.Enabled {
@@ -166,9 +522,244 @@ func Validate(cfg *Config) error {
 			Message: "should not exceed 20 to avoid rate limiting",
 		})
 	}
+	if cfg.Options.HotspotLimit < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "options.hotspot_limit",
+			Message: "must not be negative",
+		})
+	}
+	if cfg.Options.DistantTimezoneHours < 0 || cfg.Options.DistantTimezoneHours > 12 {
+		errs = append(errs, ValidationError{
+			Field:   "options.distant_timezone_hours",
+			Message: "must be between 0 and 12",
+		})
+	}
+	if cfg.Options.SystemGit.MinSizeMB < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "options.system_git.min_size_mb",
+			Message: "must not be negative",
+		})
+	}
+	for i, pattern := range cfg.Options.AdditionalBotPatterns {
+		if expr, ok := strings.CutPrefix(pattern, BotRegexPrefix); ok {
+			if _, err := compileBotRegex(expr); err != nil {
+				errs = append(errs, ValidationError{
+					Field:   fmt.Sprintf("options.additional_bot_patterns[%d]", i),
+					Message: fmt.Sprintf("invalid regular expression: %v", err),
+				})
+			}
+		}
+	}
+	if cfg.Options.RetryBudget < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "options.retry_budget",
+			Message: "must not be negative (use 0 for unlimited)",
+		})
+	}
+	if cfg.Options.MaxRequestsPerHour < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "options.max_requests_per_hour",
+			Message: "must not be negative (use 0 for unlimited)",
+		})
+	}
+	switch cfg.Options.PRFetchMode {
+	case "", PRFetchUpdated, PRFetchSearch:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "options.pr_fetch_mode",
+			Message: fmt.Sprintf("invalid PR fetch mode: %s (must be updated or search)", cfg.Options.PRFetchMode),
+		})
+	}
+	switch cfg.Options.FetchStrategy {
+	case "", FetchList, FetchSearch:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "options.fetch_strategy",
+			Message: fmt.Sprintf("invalid fetch strategy: %s (must be list or search)", cfg.Options.FetchStrategy),
+		})
+	}
+	switch cfg.Options.CloneProtocol {
+	case "", CloneHTTPS, CloneSSH:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "options.clone_protocol",
+			Message: fmt.Sprintf("invalid clone protocol: %s (must be https or ssh)", cfg.Options.CloneProtocol),
+		})
+	}
+	switch cfg.Options.CommitBranches {
+	case "", CommitBranchesAll, CommitBranchesDefault, CommitBranchesMain:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "options.commit_branches",
+			Message: fmt.Sprintf("invalid commit branches: %s (must be all, default or main_branches)", cfg.Options.CommitBranches),
+		})
+	}
+	switch cfg.Options.OrgMembers.External {
+	case "", ExternalFlag, ExternalExclude, ExternalCommunity:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "options.org_members.external",
+			Message: fmt.Sprintf("invalid external contributor handling: %s (must be flag, exclude or community)", cfg.Options.OrgMembers.External),
+		})
+	}
+	if cfg.Options.Adoption.MaxPages < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "options.adoption.max_pages",
+			Message: "must not be negative (use 0 for no limit)",
+		})
+	}
+	if cfg.Options.Verify.Sample < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "options.verify.sample",
+			Message: "must not be negative (use 0 to compare every commit)",
+		})
+	}
+	if cfg.Options.CircuitBreaker.Threshold < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "options.circuit_breaker.threshold",
+			Message: "must not be negative (use 0 to disable)",
+		})
+	}
+	if cooldown, err := cfg.GetCircuitBreakerCooldown(); err != nil || cooldown <= 0 {
+		msg := "must be a positive duration"
+		if err != nil {
+			msg = fmt.Sprintf("invalid duration: %v", err)
+		}
+		errs = append(errs, ValidationError{
+			Field:   "options.circuit_breaker.cooldown",
+			Message: msg,
+		})
+	}
+
+	// Validate integrations
+	if cfg.Integrations.Linear.Enabled && len(cfg.Integrations.Linear.TeamKeys) == 0 {
+		errs = append(errs, ValidationError{
+			Field:   "integrations.linear.team_keys",
+			Message: "at least one team key is required when Linear integration is enabled",
+		})
+	}
+	for i, action := range cfg.Integrations.AuditLog.Actions {
+		if strings.TrimSpace(action) == "" || strings.ContainsAny(action, " \t:") {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("integrations.audit_log.actions[%d]", i),
+				Message: fmt.Sprintf("invalid audit-log action %q (expected e.g. %q)", action, AuditBranchProtectionOverride),
+			})
+		}
+	}
+	if cfg.Integrations.SAMLIdentities.Enabled && !cfg.Options.UseGraphQL && !cfg.Source.Exported() {
+		errs = append(errs, ValidationError{
+			Field:   "integrations.saml_identities.enabled",
+			Message: "SAML identities are only available over GraphQL (set options.use_graphql)",
+		})
+	}
+
+	errs = append(errs, validateWarehouse(cfg.Warehouse)...)
+	errs = append(errs, validateEvents(cfg.Events)...)
 
 	if len(errs) > 0 {
 		return errs
 	}
 	return nil
 }
+
+// warehouseIdentifier matches the table names both warehouses accept unquoted
+var warehouseIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateWarehouse checks the warehouse export settings
+func validateWarehouse(w WarehouseConfig) ValidationErrors {
+	var errs ValidationErrors
+	required := func(field, value string) {
+		if strings.TrimSpace(value) == "" {
+			errs = append(errs, ValidationError{Field: field, Message: "is required"})
+		}
+	}
+
+	if bq := w.BigQuery; bq.Enabled {
+		required("warehouse.bigquery.project", bq.Project)
+		required("warehouse.bigquery.dataset", bq.Dataset)
+		if bq.Token == "" && bq.TokenCommand == "" {
+			errs = append(errs, ValidationError{Field: "warehouse.bigquery.token", Message: "token or token_command is required"})
+		}
+	}
+	if sf := w.Snowflake; sf.Enabled {
+		if sf.URL == "" {
+			required("warehouse.snowflake.account", sf.Account)
+		}
+		required("warehouse.snowflake.database", sf.Database)
+		required("warehouse.snowflake.schema", sf.Schema)
+		if sf.Token == "" && sf.TokenCommand == "" {
+			errs = append(errs, ValidationError{Field: "warehouse.snowflake.token", Message: "token or token_command is required"})
+		}
+		switch sf.TokenType {
+		case "", SnowflakeOAuth, SnowflakeKeyPairJWT:
+		default:
+			errs = append(errs, ValidationError{
+				Field:   "warehouse.snowflake.token_type",
+				Message: fmt.Sprintf("invalid token type: %s (must be %s or %s)", sf.TokenType, SnowflakeOAuth, SnowflakeKeyPairJWT),
+			})
+		}
+	}
+
+	names := make(map[string]string)
+	for _, table := range []struct{ field, name string }{
+		{"warehouse.tables.contributors", w.Tables.ContributorsTable()},
+		{"warehouse.tables.repositories", w.Tables.RepositoriesTable()},
+		{"warehouse.tables.periods", w.Tables.PeriodsTable()},
+	} {
+		if !warehouseIdentifier.MatchString(table.name) {
+			errs = append(errs, ValidationError{
+				Field:   table.field,
+				Message: fmt.Sprintf("invalid table name %q (letters, digits and underscores, not starting with a digit)", table.name),
+			})
+			continue
+		}
+		if other, ok := names[strings.ToLower(table.name)]; ok {
+			errs = append(errs, ValidationError{
+				Field:   table.field,
+				Message: fmt.Sprintf("table %q is also used by %s", table.name, other),
+			})
+		}
+		names[strings.ToLower(table.name)] = table.field
+	}
+	return errs
+}
+
+// kafkaTopic matches the names Kafka accepts for topics
+var kafkaTopic = regexp.MustCompile(`^[A-Za-z0-9._-]{1,249}$`)
+
+// natsSubject matches subjects of dot-separated tokens without wildcards
+var natsSubject = regexp.MustCompile(`^[^.*>\s]+(\.[^.*>\s]+)*$`)
+
+// validateEvents checks the event stream settings
+func validateEvents(e EventsConfig) ValidationErrors {
+	var errs ValidationErrors
+	if k := e.Kafka; k.Enabled {
+		if u, err := url.Parse(k.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, ValidationError{Field: "events.kafka.url", Message: "must be the http(s) URL of a Kafka REST proxy"})
+		}
+		if !kafkaTopic.MatchString(k.TopicName()) {
+			errs = append(errs, ValidationError{
+				Field:   "events.kafka.topic",
+				Message: fmt.Sprintf("invalid topic %q (letters, digits, '.', '_' and '-')", k.TopicName()),
+			})
+		}
+		if k.Token != "" && k.Username != "" {
+			errs = append(errs, ValidationError{Field: "events.kafka.token", Message: "cannot be combined with username"})
+		}
+	}
+	if n := e.NATS; n.Enabled {
+		if u, err := url.Parse(n.URL); err != nil || (u.Scheme != "nats" && u.Scheme != "tls") || u.Host == "" {
+			errs = append(errs, ValidationError{Field: "events.nats.url", Message: "must be a nats:// or tls:// URL"})
+		}
+		if !natsSubject.MatchString(n.SubjectPrefix()) {
+			errs = append(errs, ValidationError{
+				Field:   "events.nats.subject",
+				Message: fmt.Sprintf("invalid subject %q (dot-separated tokens without spaces or wildcards)", n.SubjectPrefix()),
+			})
+		}
+		if n.Token != "" && n.Username != "" {
+			errs = append(errs, ValidationError{Field: "events.nats.token", Message: "cannot be combined with username"})
+		}
+	}
+	return errs
+}
@@ -5,6 +5,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/i18n"
+	"github.com/lukaszraczylo/git-velocity/internal/icons"
 )
 
 func TestValidate(t *testing.T) {
@@ -17,7 +20,1128 @@ func TestValidate(t *testing.T) {
 		errorField  string
 	}{
 		{
-			name: "valid config with token",
+			name: "valid config with token",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily", "weekly"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html", "json"},
+				},
+				Cache: CacheConfig{
+					Enabled:   true,
+					Directory: "./.cache",
+					TTL:       "24h",
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "valid config with github app",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubApp: &GithubAppConfig{
+						AppID:          12345,
+						InstallationID: 67890,
+						PrivateKey:     "key-content",
+					},
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid token rotation",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubTokens:  []string{"ghp_one", "ghp_two"},
+					TokenRotation: "random",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "auth.token_rotation",
+		},
+		{
+			name: "missing authentication",
+			config: &Config{
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "auth",
+		},
+		{
+			name: "offline without authentication",
+			config: &Config{
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+					Offline:            true,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "migration export and GH Archive combined",
+			config: &Config{
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Source:      SourceConfig{Archive: "./export.tar.gz", GHArchive: "./gharchive"},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "source",
+		},
+		{
+			name: "migration export without authentication",
+			config: &Config{
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Source:      SourceConfig{Archive: "./export.tar.gz"},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "no repositories",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{},
+				Granularity:  []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "repositories",
+		},
+		{
+			name: "repository missing owner",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "repositories[0].owner",
+		},
+		{
+			name: "repository missing name and pattern",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "repositories[0]",
+		},
+		{
+			name: "repository with pattern instead of name is valid",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Pattern: "*"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "lint without end report",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo", Lint: &LintConfig{Start: "start.json"}},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "repositories[0].lint",
+		},
+		{
+			name: "invalid granularity",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"invalid"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "granularity",
+		},
+		{
+			name: "team without name",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Teams: []TeamConfig{
+					{Members: []string{"user1"}},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "teams[0].name",
+		},
+		{
+			name: "team without members",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Teams: []TeamConfig{
+					{Name: "Backend", Members: []string{}},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "teams[0].members",
+		},
+		{
+			name: "negative team capacity",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Teams: []TeamConfig{
+					{Name: "Backend", Members: []string{"dev1"}, Capacity: -1},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "teams[0].capacity",
+		},
+		{
+			name: "service account in two teams",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Teams: []TeamConfig{
+					{Name: "Backend", Members: []string{"dev1"}, ServiceAccounts: []string{"deploy-bot"}},
+					{Name: "Frontend", Members: []string{"dev2"}, ServiceAccounts: []string{"Deploy-Bot"}},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "teams[1].service_accounts[0]",
+		},
+		{
+			name: "service account is a member",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Teams: []TeamConfig{
+					{Name: "Backend", Members: []string{"dev1", "ops"}, ServiceAccounts: []string{"ops"}},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "teams[0].service_accounts[0]",
+		},
+		// Note: Achievement validation tests removed because achievements are now hardcoded
+		// and not user-configurable to prevent manipulation
+		{
+			name: "missing output directory",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "output.directory",
+		},
+		{
+			name: "invalid output format",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"invalid"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "output.format",
+		},
+		{
+			name: "contributor end before start",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Contributors: []ContributorConfig{
+					{Login: "alice", Start: "2025-03-01", End: "2025-02-01"},
+				},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "contributors[0].end",
+		},
+		{
+			name: "contributor with invalid date",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Contributors: []ContributorConfig{
+					{Login: "alice", Start: "March 1st"},
+				},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "contributors[0]",
+		},
+		{
+			name: "contributor absence ending before it starts",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Contributors: []ContributorConfig{
+					{Login: "alice", Absences: []AbsenceConfig{{Start: "2025-03-10", End: "2025-03-01"}}},
+				},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "contributors[0].absences[0].end",
+		},
+		{
+			name: "unknown contributor time zone",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Contributors: []ContributorConfig{
+					{Login: "alice", Timezone: "Europe/Atlantis"},
+				},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "contributors[0].timezone",
+		},
+		{
+			name: "distant time zones over 12 hours apart",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests:   5,
+					DistantTimezoneHours: 13,
+				},
+			},
+			expectError: true,
+			errorField:  "options.distant_timezone_hours",
+		},
+		{
+			name: "invalid scoring normalization",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Scoring: ScoringConfig{
+					Normalization: "median",
+				},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "scoring.normalization",
+		},
+		{
+			name: "invalid achievement scope",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Scoring: ScoringConfig{
+					AchievementScope: "lifetime",
+				},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "scoring.achievement_scope",
+		},
+		{
+			name: "invalid team ranking",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Scoring: ScoringConfig{
+					TeamRanking: "max",
+				},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "scoring.team_ranking",
+		},
+		{
+			name: "invalid forecast method",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Forecast: ForecastConfig{
+					Enabled:    true,
+					Method:     "arima",
+					Weeks:      4,
+					Confidence: 0.9,
+				},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "forecast.method",
+		},
+		{
+			name: "unsupported output locale",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+					Locale:    "xx",
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "output.locale",
+		},
+		{
+			name: "unsupported icon set",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+					Icons:     IconsConfig{Set: "material"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "output.icons.set",
+		},
+		{
+			name: "svg icons without a pack",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+					Icons:     IconsConfig{Set: "svg"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "output.icons.path",
+		},
+		{
+			name: "invalid CSP placement",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+					Security:  SiteSecurityConfig{CSP: "http"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "output.security.csp",
+		},
+		{
+			name: "relative site URL",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+					SiteURL:   "velocity.example.com",
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "output.site_url",
+		},
+		{
+			name: "group without repositories",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Groups:      []GroupConfig{{Name: "Payments"}},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "groups[0].repos",
+		},
+		{
+			name: "group repository without owner",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Groups:      []GroupConfig{{Name: "Payments", Repos: []string{"ledger"}}},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "groups[0].repos[0]",
+		},
+		{
+			name: "duplicate group",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Groups:      []GroupConfig{{Name: "Payments", Repos: []string{"testorg/a"}}, {Name: "payments", Repos: []string{"testorg/b"}}},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "groups[1].name",
+		},
+		{
+			name: "team owning paths without members",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Teams:         []TeamConfig{{Name: "Payments"}},
+				PathOwnership: []PathOwnership{{Team: "Payments", Paths: []string{"services/payments/**"}}},
+				Granularity:   []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "path ownership of unknown team",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Teams:         []TeamConfig{{Name: "Payments", Members: []string{"alice"}}},
+				PathOwnership: []PathOwnership{{Team: "Billing", Paths: []string{"services/billing"}}},
+				Granularity:   []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "path_ownership[0].team",
+		},
+		{
+			name: "path ownership without paths",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Teams:         []TeamConfig{{Name: "Payments", Members: []string{"alice"}}},
+				PathOwnership: []PathOwnership{{Team: "Payments"}},
+				Granularity:   []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "path_ownership[0].paths",
+		},
+		{
+			name: "path ownership with invalid repository",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Teams:         []TeamConfig{{Name: "Payments", Members: []string{"alice"}}},
+				PathOwnership: []PathOwnership{{Team: "Payments", Paths: []string{"services/payments"}, Repos: []string{"monorepo"}}},
+				Granularity:   []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "path_ownership[0].repos[0]",
+		},
+		{
+			name: "cache enabled but no directory",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Cache: CacheConfig{
+					Enabled:   true,
+					Directory: "",
+					TTL:       "24h",
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "cache.directory",
+		},
+		{
+			name: "invalid cache TTL",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Cache: CacheConfig{
+					Enabled:   true,
+					Directory: "./.cache",
+					TTL:       "invalid",
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "cache.ttl",
+		},
+		{
+			name: "concurrent requests too low",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 0,
+				},
+			},
+			expectError: true,
+			errorField:  "options.concurrent_requests",
+		},
+		{
+			name: "invalid bot regex",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests:    5,
+					AdditionalBotPatterns: []string{"ci-*", "re:svc-(["},
+				},
+			},
+			expectError: true,
+			errorField:  "options.additional_bot_patterns[1]",
+		},
+		{
+			name: "invalid PR fetch mode",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+					PRFetchMode:        "merged",
+				},
+			},
+			expectError: true,
+			errorField:  "options.pr_fetch_mode",
+		},
+		{
+			name: "invalid fetch strategy",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+					FetchStrategy:      "graphql",
+				},
+			},
+			expectError: true,
+			errorField:  "options.fetch_strategy",
+		},
+		{
+			name: "invalid external contributor handling",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+					OrgMembers:         OrgMembersConfig{Enabled: true, External: "hide"},
+				},
+			},
+			expectError: true,
+			errorField:  "options.org_members.external",
+		},
+		{
+			name: "invalid preset",
+			config: &Config{
+				Preset: "enterprise",
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+			},
+			expectError: true,
+			errorField:  "preset",
+		},
+		{
+			name: "negative adoption page limit",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+					Adoption:           AdoptionConfig{Enabled: true, MaxPages: -1},
+				},
+			},
+			expectError: true,
+			errorField:  "options.adoption.max_pages",
+		},
+		{
+			name: "negative verification sample",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
@@ -25,32 +1149,120 @@ func TestValidate(t *testing.T) {
 				Repositories: []RepositoryConfig{
 					{Owner: "testorg", Name: "testrepo"},
 				},
-				Granularity: []string{"daily", "weekly"},
+				Granularity: []string{"daily"},
 				Output: OutputConfig{
 					Directory: "./dist",
-					Format:    []string{"html", "json"},
+					Format:    []string{"html"},
 				},
-				Cache: CacheConfig{
-					Enabled:   true,
-					Directory: "./.cache",
-					TTL:       "24h",
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+					Verify:             VerifyConfig{Enabled: true, Sample: -5},
+				},
+			},
+			expectError: true,
+			errorField:  "options.verify.sample",
+		},
+		{
+			name: "negative request rate",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
 				},
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
+					MaxRequestsPerHour: -1,
 				},
 			},
-			expectError: false,
+			expectError: true,
+			errorField:  "options.max_requests_per_hour",
 		},
 		{
-			name: "valid config with github app",
+			name: "invalid audit log action",
 			config: &Config{
 				Auth: AuthConfig{
-					GithubApp: &GithubAppConfig{
-						AppID:          12345,
-						InstallationID: 67890,
-						PrivateKey:     "key-content",
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+				Integrations: IntegrationsConfig{
+					AuditLog: AuditLogConfig{
+						Enabled: true,
+						Actions: []string{"action:git.push"},
 					},
 				},
+			},
+			expectError: true,
+			errorField:  "integrations.audit_log.actions[0]",
+		},
+		{
+			name: "SAML identities without GraphQL",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+				Integrations: IntegrationsConfig{
+					SAMLIdentities: SAMLIdentitiesConfig{Enabled: true},
+				},
+			},
+			expectError: true,
+			errorField:  "integrations.saml_identities.enabled",
+		},
+		{
+			name: "negative leaderboard page size",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+				Output: OutputConfig{
+					Directory:   "./dist",
+					Format:      []string{"html"},
+					Leaderboard: LeaderboardOutputConfig{PageSize: -1, Top: 10},
+				},
+			},
+			expectError: true,
+			errorField:  "output.leaderboard.page_size",
+		},
+		{
+			name: "warehouse exports",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
 				Repositories: []RepositoryConfig{
 					{Owner: "testorg", Name: "testrepo"},
 				},
@@ -62,12 +1274,20 @@ func TestValidate(t *testing.T) {
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				Warehouse: WarehouseConfig{
+					BigQuery:  BigQueryConfig{Enabled: true, Project: "acme", Dataset: "velocity", TokenCommand: "gcloud auth print-access-token"},
+					Snowflake: SnowflakeConfig{Enabled: true, Account: "acme-analytics", Database: "ENG", Schema: "VELOCITY", Token: "jwt", TokenType: SnowflakeKeyPairJWT},
+					Tables:    WarehouseTablesConfig{Contributors: "git_contributors"},
+				},
 			},
 			expectError: false,
 		},
 		{
-			name: "missing authentication",
+			name: "BigQuery export without a dataset",
 			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
 				Repositories: []RepositoryConfig{
 					{Owner: "testorg", Name: "testrepo"},
 				},
@@ -79,18 +1299,23 @@ func TestValidate(t *testing.T) {
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				Warehouse: WarehouseConfig{
+					BigQuery: BigQueryConfig{Enabled: true, Project: "acme", Token: "ya29.token"},
+				},
 			},
 			expectError: true,
-			errorField:  "auth",
+			errorField:  "warehouse.bigquery.dataset",
 		},
 		{
-			name: "no repositories",
+			name: "Snowflake export without a token",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
 				},
-				Repositories: []RepositoryConfig{},
-				Granularity:  []string{"daily"},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
 				Output: OutputConfig{
 					Directory: "./dist",
 					Format:    []string{"html"},
@@ -98,18 +1323,21 @@ func TestValidate(t *testing.T) {
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				Warehouse: WarehouseConfig{
+					Snowflake: SnowflakeConfig{Enabled: true, Account: "acme-analytics", Database: "ENG", Schema: "VELOCITY"},
+				},
 			},
 			expectError: true,
-			errorField:  "repositories",
+			errorField:  "warehouse.snowflake.token",
 		},
 		{
-			name: "repository missing owner",
+			name: "invalid Snowflake token type",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
 				},
 				Repositories: []RepositoryConfig{
-					{Name: "testrepo"},
+					{Owner: "testorg", Name: "testrepo"},
 				},
 				Granularity: []string{"daily"},
 				Output: OutputConfig{
@@ -119,18 +1347,21 @@ func TestValidate(t *testing.T) {
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				Warehouse: WarehouseConfig{
+					Snowflake: SnowflakeConfig{Enabled: true, Account: "acme-analytics", Database: "ENG", Schema: "VELOCITY", Token: "t", TokenType: "PASSWORD"},
+				},
 			},
 			expectError: true,
-			errorField:  "repositories[0].owner",
+			errorField:  "warehouse.snowflake.token_type",
 		},
 		{
-			name: "repository missing name and pattern",
+			name: "invalid warehouse table name",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
 				},
 				Repositories: []RepositoryConfig{
-					{Owner: "testorg"},
+					{Owner: "testorg", Name: "testrepo"},
 				},
 				Granularity: []string{"daily"},
 				Output: OutputConfig{
@@ -140,18 +1371,45 @@ func TestValidate(t *testing.T) {
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				Warehouse: WarehouseConfig{
+					Tables: WarehouseTablesConfig{Periods: "velocity-periods"},
+				},
 			},
 			expectError: true,
-			errorField:  "repositories[0]",
+			errorField:  "warehouse.tables.periods",
 		},
 		{
-			name: "repository with pattern instead of name is valid",
+			name: "warehouse tables sharing a name",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
 				},
 				Repositories: []RepositoryConfig{
-					{Owner: "testorg", Pattern: "*"},
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+				Warehouse: WarehouseConfig{
+					Tables: WarehouseTablesConfig{Repositories: "VELOCITY_CONTRIBUTORS"},
+				},
+			},
+			expectError: true,
+			errorField:  "warehouse.tables.repositories",
+		},
+		{
+			name: "event streams",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
 				},
 				Granularity: []string{"daily"},
 				Output: OutputConfig{
@@ -161,11 +1419,15 @@ func TestValidate(t *testing.T) {
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				Events: EventsConfig{
+					Kafka: KafkaConfig{Enabled: true, URL: "https://kafka-rest.internal:8082", Username: "velocity", Password: "secret"},
+					NATS:  NATSConfig{Enabled: true, URL: "tls://nats.internal:4222", Subject: "eng.velocity", Token: "s3cr3t"},
+				},
 			},
 			expectError: false,
 		},
 		{
-			name: "invalid granularity",
+			name: "Kafka events without a REST proxy",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
@@ -173,7 +1435,7 @@ func TestValidate(t *testing.T) {
 				Repositories: []RepositoryConfig{
 					{Owner: "testorg", Name: "testrepo"},
 				},
-				Granularity: []string{"invalid"},
+				Granularity: []string{"daily"},
 				Output: OutputConfig{
 					Directory: "./dist",
 					Format:    []string{"html"},
@@ -181,12 +1443,15 @@ func TestValidate(t *testing.T) {
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				Events: EventsConfig{
+					Kafka: KafkaConfig{Enabled: true, URL: "kafka.internal:9092"},
+				},
 			},
 			expectError: true,
-			errorField:  "granularity",
+			errorField:  "events.kafka.url",
 		},
 		{
-			name: "team without name",
+			name: "invalid Kafka topic",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
@@ -194,9 +1459,6 @@ func TestValidate(t *testing.T) {
 				Repositories: []RepositoryConfig{
 					{Owner: "testorg", Name: "testrepo"},
 				},
-				Teams: []TeamConfig{
-					{Members: []string{"user1"}},
-				},
 				Granularity: []string{"daily"},
 				Output: OutputConfig{
 					Directory: "./dist",
@@ -205,12 +1467,15 @@ func TestValidate(t *testing.T) {
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				Events: EventsConfig{
+					Kafka: KafkaConfig{Enabled: true, URL: "http://localhost:8082", Topic: "velocity events"},
+				},
 			},
 			expectError: true,
-			errorField:  "teams[0].name",
+			errorField:  "events.kafka.topic",
 		},
 		{
-			name: "team without members",
+			name: "NATS subject with a wildcard",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
@@ -218,9 +1483,6 @@ func TestValidate(t *testing.T) {
 				Repositories: []RepositoryConfig{
 					{Owner: "testorg", Name: "testrepo"},
 				},
-				Teams: []TeamConfig{
-					{Name: "Backend", Members: []string{}},
-				},
 				Granularity: []string{"daily"},
 				Output: OutputConfig{
 					Directory: "./dist",
@@ -229,14 +1491,15 @@ func TestValidate(t *testing.T) {
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				Events: EventsConfig{
+					NATS: NATSConfig{Enabled: true, URL: "nats://localhost:4222", Subject: "velocity.>"},
+				},
 			},
 			expectError: true,
-			errorField:  "teams[0].members",
+			errorField:  "events.nats.subject",
 		},
-		// Note: Achievement validation tests removed because achievements are now hardcoded
-		// and not user-configurable to prevent manipulation
 		{
-			name: "missing output directory",
+			name: "custom metric using an earlier one",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
@@ -246,18 +1509,21 @@ func TestValidate(t *testing.T) {
 				},
 				Granularity: []string{"daily"},
 				Output: OutputConfig{
-					Directory: "",
+					Directory: "./dist",
 					Format:    []string{"html"},
 				},
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				CustomMetrics: []CustomMetricConfig{
+					{Name: "review_ratio", Expression: "reviews_given / max(prs_merged, 1)"},
+					{Name: "reviewer", Expression: "review_ratio >= 2", Points: 50},
+				},
 			},
-			expectError: true,
-			errorField:  "output.directory",
+			expectError: false,
 		},
 		{
-			name: "invalid output format",
+			name: "custom metric with an unknown metric",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
@@ -268,17 +1534,20 @@ func TestValidate(t *testing.T) {
 				Granularity: []string{"daily"},
 				Output: OutputConfig{
 					Directory: "./dist",
-					Format:    []string{"invalid"},
+					Format:    []string{"html"},
 				},
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				CustomMetrics: []CustomMetricConfig{
+					{Name: "review_ratio", Expression: "reviews / max(prs_merged, 1)"},
+				},
 			},
 			expectError: true,
-			errorField:  "output.format",
+			errorField:  "custom_metrics[0].expression",
 		},
 		{
-			name: "cache enabled but no directory",
+			name: "custom metric with invalid expression",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
@@ -291,20 +1560,18 @@ func TestValidate(t *testing.T) {
 					Directory: "./dist",
 					Format:    []string{"html"},
 				},
-				Cache: CacheConfig{
-					Enabled:   true,
-					Directory: "",
-					TTL:       "24h",
-				},
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				CustomMetrics: []CustomMetricConfig{
+					{Name: "review_ratio", Expression: "reviews_given /"},
+				},
 			},
 			expectError: true,
-			errorField:  "cache.directory",
+			errorField:  "custom_metrics[0].expression",
 		},
 		{
-			name: "invalid cache TTL",
+			name: "custom metric named after a built-in metric",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
@@ -317,20 +1584,43 @@ func TestValidate(t *testing.T) {
 					Directory: "./dist",
 					Format:    []string{"html"},
 				},
-				Cache: CacheConfig{
-					Enabled:   true,
-					Directory: "./.cache",
-					TTL:       "invalid",
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+				CustomMetrics: []CustomMetricConfig{
+					{Name: "prs_merged", Expression: "prs_opened"},
+				},
+			},
+			expectError: true,
+			errorField:  "custom_metrics[0].name",
+		},
+		{
+			name: "duplicate custom metric",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
 				},
 				Options: OptionsConfig{
 					ConcurrentRequests: 5,
 				},
+				CustomMetrics: []CustomMetricConfig{
+					{Name: "ratio", Expression: "prs_merged"},
+					{Name: "ratio", Expression: "prs_opened"},
+				},
 			},
 			expectError: true,
-			errorField:  "cache.ttl",
+			errorField:  "custom_metrics[1].name",
 		},
 		{
-			name: "concurrent requests too low",
+			name: "custom metric name not snake_case",
 			config: &Config{
 				Auth: AuthConfig{
 					GithubToken: "ghp_test123",
@@ -344,11 +1634,14 @@ func TestValidate(t *testing.T) {
 					Format:    []string{"html"},
 				},
 				Options: OptionsConfig{
-					ConcurrentRequests: 0,
+					ConcurrentRequests: 5,
+				},
+				CustomMetrics: []CustomMetricConfig{
+					{Name: "Review Ratio", Expression: "prs_merged"},
 				},
 			},
 			expectError: true,
-			errorField:  "options.concurrent_requests",
+			errorField:  "custom_metrics[0].name",
 		},
 		{
 			name: "concurrent requests too high",
@@ -371,6 +1664,30 @@ func TestValidate(t *testing.T) {
 			expectError: true,
 			errorField:  "options.concurrent_requests",
 		},
+		{
+			name: "linear enabled without team keys",
+			config: &Config{
+				Auth: AuthConfig{
+					GithubToken: "ghp_test123",
+				},
+				Repositories: []RepositoryConfig{
+					{Owner: "testorg", Name: "testrepo"},
+				},
+				Granularity: []string{"daily"},
+				Output: OutputConfig{
+					Directory: "./dist",
+					Format:    []string{"html"},
+				},
+				Options: OptionsConfig{
+					ConcurrentRequests: 5,
+				},
+				Integrations: IntegrationsConfig{
+					Linear: LinearConfig{Enabled: true},
+				},
+			},
+			expectError: true,
+			errorField:  "integrations.linear.team_keys",
+		},
 	}
 
 	for _, tt := range tests {
@@ -438,3 +1755,33 @@ func TestValidationErrors_Error(t *testing.T) {
 		})
 	}
 }
+
+// Every achievement must have an English catalog entry whose name matches
+// its definition, or the localized dashboard would drift from the scoring
+func TestAchievementsHaveCatalogEntries(t *testing.T) {
+	t.Parallel()
+
+	catalog, err := i18n.Load(i18n.DefaultLocale)
+	require.NoError(t, err)
+
+	for _, a := range DefaultConfig().Scoring.GetAchievements() {
+		text, ok := catalog.Achievement(a.ID)
+		if assert.True(t, ok, "missing catalog entry for %s", a.ID) {
+			assert.Equal(t, a.Name, text.Name, a.ID)
+		}
+	}
+}
+
+// Every achievement icon needs an emoji of its own, or the emoji icon set
+// would draw the fallback for it
+func TestAchievementsHaveEmoji(t *testing.T) {
+	t.Parallel()
+
+	provider, err := icons.Load(icons.Emoji, "")
+	require.NoError(t, err)
+	fallback := provider.Icon("", "fa-not-an-icon").Emoji
+
+	for _, a := range DefaultConfig().Scoring.GetAchievements() {
+		assert.NotEqual(t, fallback, provider.Icon(a.ID, a.Icon).Emoji, "missing emoji for %s (%s)", a.ID, a.Icon)
+	}
+}
@@ -0,0 +1,87 @@
+package coverage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+)
+
+// DefaultCodecovURL is the Codecov API of codecov.io
+const DefaultCodecovURL = "https://api.codecov.io"
+
+// CodecovClient is a minimal Codecov API client for commit coverage lookups
+type CodecovClient struct {
+	endpoint   string
+	token      string
+	service    string
+	httpClient *http.Client
+}
+
+// NewCodecovClient creates a Codecov API client. Empty endpoint and service
+// default to codecov.io and github.
+func NewCodecovClient(endpoint, token, service string) *CodecovClient {
+	if endpoint == "" {
+		endpoint = DefaultCodecovURL
+	}
+	if service == "" {
+		service = "github"
+	}
+	return &CodecovClient{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		token:      token,
+		service:    service,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SetTransport sends requests through transport, for proxy and TLS settings
+func (c *CodecovClient) SetTransport(transport http.RoundTripper) {
+	c.httpClient.Transport = transport
+}
+
+// Commit returns the coverage percentage Codecov recorded for a commit. It
+// reports false when Codecov has no report for it.
+func (c *CodecovClient) Commit(ctx context.Context, owner, repo, sha string) (float64, bool, error) {
+	u := fmt.Sprintf("%s/api/v2/%s/%s/repos/%s/commits/%s/",
+		c.endpoint, url.PathEscape(c.service), url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(sha))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("codecov request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("codecov API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Totals *struct {
+			Coverage *float64 `json:"coverage"`
+		} `json:"totals"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, false, fmt.Errorf("failed to decode codecov response: %w", err)
+	}
+	// Commits still being processed have no totals yet
+	if result.Totals == nil || result.Totals.Coverage == nil {
+		return 0, false, nil
+	}
+	return *result.Totals.Coverage, true, nil
+}
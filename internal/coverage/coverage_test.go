@@ -0,0 +1,117 @@
+package coverage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected float64
+		wantErr  bool
+	}{
+		{
+			name:     "cobertura",
+			path:     write("report.xml", `<?xml version="1.0"?><coverage line-rate="0.8125" branch-rate="0.5"><packages/></coverage>`),
+			expected: 81.25,
+		},
+		{
+			name:     "lcov sums all files",
+			path:     write("report.info", "TN:\nSF:a.go\nLF:10\nLH:5\nend_of_record\nSF:b.go\nLF:30\nLH:27\nend_of_record\n"),
+			expected: 80,
+		},
+		{
+			name:    "lcov without lines",
+			path:    write("empty.lcov", "TN:\n"),
+			wantErr: true,
+		},
+		{
+			name:    "cobertura without line-rate",
+			path:    write("bad.xml", `<coverage/>`),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pct, err := ParseFile(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, pct, 0.001)
+		})
+	}
+}
+
+func TestFromDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "abc1234.info"), []byte("LF:4\nLH:3\n"), 0o600))
+
+	pct, ok, err := FromDir(dir, "abc1234def5678")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.InDelta(t, 75.0, pct, 0.001)
+
+	_, ok, err = FromDir(dir, "fff0000")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestCodecovClient_Commit(t *testing.T) {
+	t.Parallel()
+
+	var path, authHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		authHeader = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/api/v2/github/owner/repos/repo/commits/abc/":
+			_, _ = w.Write([]byte(`{"commitid": "abc", "totals": {"coverage": 87.5}}`))
+		case "/api/v2/github/owner/repos/repo/commits/pending/":
+			_, _ = w.Write([]byte(`{"commitid": "pending", "totals": null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewCodecovClient(server.URL+"/", "secret", "")
+
+	pct, ok, err := client.Commit(context.Background(), "owner", "repo", "abc")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.InDelta(t, 87.5, pct, 0.001)
+	assert.Equal(t, "/api/v2/github/owner/repos/repo/commits/abc/", path)
+	assert.Equal(t, "Bearer secret", authHeader)
+
+	_, ok, err = client.Commit(context.Background(), "owner", "repo", "pending")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = client.Commit(context.Background(), "owner", "repo", "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,100 @@
+// Package coverage reads test coverage of commits from Cobertura and LCOV
+// reports or the Codecov API.
+package coverage
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// reportExtensions are the report file extensions looked up in a directory, in order
+var reportExtensions = []string{".xml", ".info", ".lcov"}
+
+// FromDir reads the coverage percentage of a commit from a report named after
+// its full or 7-character SHA in dir. It reports false when there is no report.
+func FromDir(dir, sha string) (float64, bool, error) {
+	names := []string{sha}
+	if len(sha) > 7 {
+		names = append(names, sha[:7])
+	}
+
+	for _, name := range names {
+		for _, ext := range reportExtensions {
+			path := filepath.Join(dir, name+ext)
+			pct, err := ParseFile(path)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return 0, false, err
+			}
+			return pct, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// ParseFile returns the line coverage percentage of a Cobertura (.xml) or
+// LCOV (.info, .lcov) report
+func ParseFile(path string) (float64, error) {
+	f, err := os.Open(filepath.Clean(path)) // #nosec G304 -- path is user-provided coverage report directory
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		return parseCobertura(f, path)
+	}
+	return parseLCOV(f, path)
+}
+
+// parseCobertura reads the line-rate of the report's root element
+func parseCobertura(f *os.File, path string) (float64, error) {
+	var report struct {
+		LineRate string `xml:"line-rate,attr"`
+	}
+	if err := xml.NewDecoder(f).Decode(&report); err != nil {
+		return 0, fmt.Errorf("invalid Cobertura report %s: %w", path, err)
+	}
+	rate, err := strconv.ParseFloat(report.LineRate, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid line-rate in %s: %q", path, report.LineRate)
+	}
+	return rate * 100, nil
+}
+
+// parseLCOV sums the lines found (LF) and hit (LH) over all source files
+func parseLCOV(f *os.File, path string) (float64, error) {
+	found, hit := 0, 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || (key != "LF" && key != "LH") {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s record in %s: %q", key, path, value)
+		}
+		if key == "LF" {
+			found += n
+		} else {
+			hit += n
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read LCOV report %s: %w", path, err)
+	}
+	if found == 0 {
+		return 0, fmt.Errorf("LCOV report %s has no instrumented lines", path)
+	}
+	return float64(hit) / float64(found) * 100, nil
+}
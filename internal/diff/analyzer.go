@@ -75,6 +75,18 @@ func IsDocumentationFile(filename string) bool {
 	return false
 }
 
+// IsTestFile checks if a file holds tests
+func IsTestFile(filename string) bool {
+	testPatterns := []string{"_test.go", ".test.", ".spec.", "/tests/", "/test/", "__tests__"}
+
+	for _, pattern := range testPatterns {
+		if strings.Contains(filename, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsMeaningfulLine checks if a line of code is meaningful (not a comment or whitespace)
 func IsMeaningfulLine(line string) bool {
 	return !IsWhitespaceLine(line) && !IsCommentLine(line)
@@ -220,6 +220,28 @@ func TestIsDocumentationFile(t *testing.T) {
 	}
 }
 
+func TestIsTestFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		expected bool
+	}{
+		{"go test", "internal/git/run_test.go", true},
+		{"js test", "src/app.test.js", true},
+		{"spec", "src/app.spec.ts", true},
+		{"tests dir", "pkg/tests/helpers.py", true},
+		{"jest dir", "src/__tests__/app.js", true},
+		{"go source", "internal/git/run.go", false},
+		{"testdata lookalike", "internal/contest.go", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsTestFile(tt.filename), "IsTestFile(%q)", tt.filename)
+		})
+	}
+}
+
 func TestIsMeaningfulLine(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,105 @@
+package scoring
+
+import (
+	"fmt"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// ruleLog records the scoring rules applied to a contributor. Rules worth no
+// points are left out, as they cannot change a score.
+type ruleLog []models.ScoreRule
+
+// count applies a rule awarding whole points per unit of a metric
+func (l *ruleLog) count(rule, category, metric string, value, points int) int {
+	if points != 0 {
+		*l = append(*l, models.ScoreRule{
+			Rule:     rule,
+			Category: category,
+			Metric:   metric,
+			Value:    float64(value),
+			Points:   float64(points),
+			Awarded:  float64(value * points),
+		})
+	}
+	return value * points
+}
+
+// multiplied applies a rule awarding points per unit of a metric times a
+// multiplier, or none when multiplier is 0. The points of a category are
+// rounded down once they are summed.
+func (l *ruleLog) multiplied(rule, category, metric string, value int, points, multiplier float64) float64 {
+	awarded := float64(value) * points
+	if multiplier != 0 {
+		awarded *= multiplier
+	}
+	if points != 0 {
+		*l = append(*l, models.ScoreRule{
+			Rule:       rule,
+			Category:   category,
+			Metric:     metric,
+			Value:      float64(value),
+			Points:     points,
+			Multiplier: multiplier,
+			Awarded:    awarded,
+		})
+	}
+	return awarded
+}
+
+// weighted applies a rule awarding points per unit of a fractional metric.
+// The points of a category are rounded down once they are summed.
+func (l *ruleLog) weighted(rule, category, metric string, value, points float64) float64 {
+	if points != 0 {
+		*l = append(*l, models.ScoreRule{
+			Rule:     rule,
+			Category: category,
+			Metric:   metric,
+			Value:    value,
+			Points:   points,
+			Awarded:  value * points,
+		})
+	}
+	return value * points
+}
+
+// responseBonus records the response time tiers: only the fastest one the
+// average review time is within is awarded
+func (l *ruleLog) responseBonus(cm *models.ContributorMetrics, points config.PointsConfig) {
+	tiers := []struct {
+		rule   string
+		hours  float64
+		points int
+	}{
+		{"fast_review_1h", 1, points.FastReview1h},
+		{"fast_review_4h", 4, points.FastReview4h},
+		{"fast_review_24h", 24, points.FastReview24h},
+	}
+	reviewed := cm.ReviewsGiven > 0 && cm.AvgReviewTime > 0
+	awarded := false
+	for _, tier := range tiers {
+		r := models.ScoreRule{
+			Rule:     tier.rule,
+			Category: "response_bonus",
+			Metric:   "avg_review_time_hours",
+			Value:    cm.AvgReviewTime,
+			Points:   float64(tier.points),
+		}
+		within := reviewed && cm.AvgReviewTime <= tier.hours
+		switch {
+		case !reviewed:
+			r.Note = "no review response times"
+		case awarded && within:
+			r.Note = "capped: a faster tier was awarded"
+		case within:
+			r.Awarded = float64(tier.points)
+			awarded = true
+		default:
+			r.Note = fmt.Sprintf("average review time over %gh", tier.hours)
+		}
+		if tier.points != 0 {
+			*l = append(*l, r)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestCalculator_ScoreRules(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{
+		Commit:        10,
+		LinesAdded:    0.1,
+		PRMerged:      50,
+		FastReview1h:  30,
+		FastReview4h:  20,
+		FastReview24h: 10,
+	}
+	calc := NewCalculator(cfg)
+
+	cm := &models.ContributorMetrics{
+		Login:                "alice",
+		RegularHoursCount:    2,
+		EveningCount:         1,
+		MeaningfulLinesAdded: 15,
+		PRsMerged:            2,
+		ReviewsGiven:         3,
+		AvgReviewTime:        3,
+	}
+	score := calc.calculateScore(cm)
+
+	rules := make(map[string][]models.ScoreRule)
+	for _, r := range score.Rules {
+		rules[r.Rule] = append(rules[r.Rule], r)
+	}
+	require.Len(t, rules["commit"], 5, "one per time of day")
+	assert.Equal(t, models.ScoreRule{Rule: "commit", Category: "commits", Metric: "evening_count", Value: 1, Points: 10, Multiplier: 2, Awarded: 20}, rules["commit"][1])
+	assert.Equal(t, models.ScoreRule{Rule: "pr_merged", Category: "prs", Metric: "prs_merged", Value: 2, Points: 50, Awarded: 100}, rules["pr_merged"][0])
+	assert.NotContains(t, rules, "pr_opened", "rules worth no points are left out")
+
+	// Only the fastest tier reached is awarded
+	assert.Equal(t, "average review time over 1h", rules["fast_review_1h"][0].Note)
+	assert.Equal(t, 20.0, rules["fast_review_4h"][0].Awarded)
+	assert.Equal(t, "capped: a faster tier was awarded", rules["fast_review_24h"][0].Note)
+	assert.Zero(t, rules["fast_review_24h"][0].Awarded)
+
+	// Each category's rules add up to its breakdown, rounded down
+	categories := make(map[string]float64)
+	for _, r := range score.Rules {
+		categories[r.Category] += r.Awarded
+	}
+	assert.Equal(t, map[string]float64{"commits": 40, "line_changes": 1.5, "prs": 100, "response_bonus": 20}, categories)
+	assert.Equal(t, score.Breakdown.Commits, int(math.Floor(categories["commits"])))
+	assert.Equal(t, score.Breakdown.LineChanges, int(math.Floor(categories["line_changes"])))
+	assert.Equal(t, score.Breakdown.ResponseBonus, int(categories["response_bonus"]))
+	assert.Equal(t, 161, score.Total)
+}
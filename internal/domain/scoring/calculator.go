@@ -5,9 +5,13 @@ import (
 	"sort"
 
 	"github.com/lukaszraczylo/git-velocity/internal/config"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
+// minTraceablePRs is how many PRs a contributor must have merged before the
+// share of them linking an issue earns achievements
+const minTraceablePRs = 10
+
 // Calculator handles score and achievement calculations
 type Calculator struct {
 	config *config.Config
@@ -74,6 +78,26 @@ func (c *Calculator) Calculate(metrics *models.GlobalMetrics) *models.GlobalMetr
 					existing.IssuesClosed += cm.IssuesClosed
 					existing.IssueComments += cm.IssueComments
 					existing.IssueReferencesInCommits += cm.IssueReferencesInCommits
+					existing.LinkedPRs += cm.LinkedPRs
+					existing.LinearIssuesReferenced += cm.LinearIssuesReferenced
+					existing.LinearIssuesCompleted += cm.LinearIssuesCompleted
+					existing.BuildsBroken += cm.BuildsBroken
+					existing.BuildsFixed += cm.BuildsFixed
+					existing.CoverageImproved += cm.CoverageImproved
+					existing.CoverageDelta += cm.CoverageDelta
+					existing.TechDebtReduction += cm.TechDebtReduction
+					existing.SecurityFixes += cm.SecurityFixes
+					existing.HotspotStabilizations += cm.HotspotStabilizations
+					existing.RefactoringCommits += cm.RefactoringCommits
+					existing.NetLinesRemoved += cm.NetLinesRemoved
+					existing.PropagatedPatches += cm.PropagatedPatches
+					existing.PatchPropagation += cm.PatchPropagation
+					existing.DirectPushes += cm.DirectPushes
+					existing.AutoMergesEnabled += cm.AutoMergesEnabled
+					existing.EffortPoints += cm.EffortPoints
+					existing.KudosReceived += cm.KudosReceived
+					existing.KudosGiven += cm.KudosGiven
+					existing.ReviewTone = models.AddReviewTone(existing.ReviewTone, cm.ReviewTone)
 					// Activity pattern metrics (for achievements)
 					existing.EarlyBirdCount += cm.EarlyBirdCount
 					existing.NightOwlCount += cm.NightOwlCount
@@ -100,6 +124,8 @@ func (c *Calculator) Calculate(metrics *models.GlobalMetrics) *models.GlobalMetr
 	// Calculate scores for each contributor
 	for _, cm := range contributorMap {
 		cm.Score = c.calculateScore(cm)
+		c.decay(&cm.Score, cm.RecencyWeight)
+		c.prorate(&cm.Score, cm.Login, cm.MembershipShare, metrics.Period)
 		// Check achievements
 		cm.Achievements = c.checkAchievements(cm)
 	}
@@ -110,19 +136,34 @@ func (c *Calculator) Calculate(metrics *models.GlobalMetrics) *models.GlobalMetr
 		contributors = append(contributors, *cm)
 	}
 
+	// Rank by the normalized score when a normalization mode is set, keeping
+	// the raw score as the tie-breaker. Contributors tied on both share a
+	// rank and are listed by login.
+	mode := c.config.Scoring.Normalization
+	normalize(mode, contributors)
 	sort.Slice(contributors, func(i, j int) bool {
-		return contributors[i].Score.Total > contributors[j].Score.Total
+		if contributors[i].Score.Normalized != contributors[j].Score.Normalized {
+			return contributors[i].Score.Normalized > contributors[j].Score.Normalized
+		}
+		if contributors[i].Score.Total != contributors[j].Score.Total {
+			return contributors[i].Score.Total > contributors[j].Score.Total
+		}
+		return contributors[i].Login < contributors[j].Login
 	})
+	for _, cm := range contributors {
+		contributorMap[cm.Login].Score.Normalized = cm.Score.Normalized
+	}
 
-	// Assign ranks (guard against empty slice for percentile calculation)
+	// Assign ranks; tied contributors share the percentile rank of the
+	// highest position they hold
 	numContributors := len(contributors)
+	ranks := competitionRanks(numContributors, func(i int) bool {
+		return contributors[i].Score.Normalized == contributors[i-1].Score.Normalized &&
+			contributors[i].Score.Total == contributors[i-1].Score.Total
+	})
 	for i := range contributors {
-		contributors[i].Score.Rank = i + 1
-		if numContributors > 0 {
-			contributors[i].Score.PercentileRank = float64(numContributors-i) / float64(numContributors) * 100
-		} else {
-			contributors[i].Score.PercentileRank = 0
-		}
+		contributors[i].Score.Rank = ranks[i]
+		contributors[i].Score.PercentileRank = float64(numContributors-ranks[i]+1) / float64(numContributors) * 100
 	}
 
 	// Build leaderboard
@@ -140,11 +181,16 @@ func (c *Calculator) Calculate(metrics *models.GlobalMetrics) *models.GlobalMetr
 		topCategory := c.determineTopCategory(&cm)
 
 		leaderboard[i] = models.LeaderboardEntry{
-			Rank:         i + 1,
+			Rank:         cm.Score.Rank,
+			Tied:         sharesRank(ranks, i),
 			Login:        cm.Login,
 			Name:         cm.Name,
 			AvatarURL:    cm.AvatarURL,
 			Score:        cm.Score.Total,
+			Normalized:   cm.Score.Normalized,
+			ProRating:    cm.Score.ProRating,
+			Decay:        cm.Score.Decay,
+			Trend:        cm.Trend,
 			Team:         team,
 			TopCategory:  topCategory,
 			Achievements: cm.Achievements,
@@ -162,6 +208,11 @@ func (c *Calculator) Calculate(metrics *models.GlobalMetrics) *models.GlobalMetr
 	// Update the metrics
 	metrics.Leaderboard = leaderboard
 	metrics.TopAchievers = topAchievers
+	metrics.Points = c.config.Scoring.Points.Values()
+	metrics.Normalization = ""
+	if isNormalized(mode) {
+		metrics.Normalization = mode
+	}
 	metrics.Contributors = contributors // Update global contributors with scored data
 
 	// Calculate per-repository scores (based on repo-specific metrics, not global)
@@ -173,11 +224,17 @@ func (c *Calculator) Calculate(metrics *models.GlobalMetrics) *models.GlobalMetr
 			repoContrib.Achievements = c.checkAchievements(repoContrib)
 		}
 		// Re-sort by score after calculation
-		sort.Slice(metrics.Repositories[i].Contributors, func(a, b int) bool {
-			return metrics.Repositories[i].Contributors[a].Score.Total > metrics.Repositories[i].Contributors[b].Score.Total
+		repoContribs := metrics.Repositories[i].Contributors
+		sort.Slice(repoContribs, func(a, b int) bool {
+			if repoContribs[a].Score.Total != repoContribs[b].Score.Total {
+				return repoContribs[a].Score.Total > repoContribs[b].Score.Total
+			}
+			return repoContribs[a].Login < repoContribs[b].Login
 		})
 	}
 
+	c.rankGroups(metrics, contributorMap)
+
 	// Update team scores
 	for i := range metrics.Teams {
 		var totalScore int
@@ -193,15 +250,21 @@ func (c *Calculator) Calculate(metrics *models.GlobalMetrics) *models.GlobalMetr
 		if len(metrics.Teams[i].MemberMetrics) > 0 {
 			metrics.Teams[i].AvgScore = float64(totalScore) / float64(len(metrics.Teams[i].MemberMetrics))
 		}
+		summarizeScores(&metrics.Teams[i])
+		adjustForCapacity(&metrics.Teams[i])
 	}
+	rankTeams(metrics.Teams, c.config.Scoring.TeamRanking)
+	metrics.TeamRanking = c.config.Scoring.TeamRanking
 
 	return metrics
 }
 
-// calculateScore computes the score for a contributor based on their metrics
+// calculateScore computes the score for a contributor based on their metrics,
+// recording every rule applied for the score audit
 func (c *Calculator) calculateScore(cm *models.ContributorMetrics) models.Score {
 	points := c.config.Scoring.Points
 	breakdown := models.ScoreBreakdown{}
+	var rules ruleLog
 
 	// Get multipliers with defaults if not set
 	multRegular := points.MultiplierRegularHours
@@ -240,38 +303,40 @@ func (c *Calculator) calculateScore(cm *models.ContributorMetrics) models.Score
 	var commitScore float64
 	if timeBasedTotal > 0 {
 		// Use time-based multipliers
-		commitScore = float64(cm.RegularHoursCount)*baseCommitPoints*multRegular +
-			float64(cm.EveningCount)*baseCommitPoints*multEvening +
-			float64(cm.LateNightCount)*baseCommitPoints*multLateNight +
-			float64(cm.OvernightCount)*baseCommitPoints*multOvernight +
-			float64(cm.EarlyMorningCount)*baseCommitPoints*multEarlyMorning
+		commitScore = rules.multiplied("commit", "commits", "regular_hours_count", cm.RegularHoursCount, baseCommitPoints, multRegular) +
+			rules.multiplied("commit", "commits", "evening_count", cm.EveningCount, baseCommitPoints, multEvening) +
+			rules.multiplied("commit", "commits", "late_night_count", cm.LateNightCount, baseCommitPoints, multLateNight) +
+			rules.multiplied("commit", "commits", "overnight_count", cm.OvernightCount, baseCommitPoints, multOvernight) +
+			rules.multiplied("commit", "commits", "early_morning_count", cm.EarlyMorningCount, baseCommitPoints, multEarlyMorning)
 	} else {
 		// Fallback: use CommitCount with regular hours multiplier (backwards compatibility)
-		commitScore = float64(cm.CommitCount) * baseCommitPoints * multRegular
+		commitScore = rules.multiplied("commit", "commits", "commit_count", cm.CommitCount, baseCommitPoints, multRegular)
 	}
 	breakdown.Commits = int(commitScore)
 
 	// Line change points - always use meaningful lines (excluding comments/whitespace)
 	// to accurately reflect actual code contribution
-	breakdown.LineChanges = int(float64(cm.MeaningfulLinesAdded)*points.LinesAdded +
-		float64(cm.MeaningfulLinesDeleted)*points.LinesDeleted)
+	breakdown.LineChanges = int(rules.multiplied("lines_added", "line_changes", "meaningful_lines_added", cm.MeaningfulLinesAdded, points.LinesAdded, 0) +
+		rules.multiplied("lines_deleted", "line_changes", "meaningful_lines_deleted", cm.MeaningfulLinesDeleted, points.LinesDeleted, 0))
 
 	// PR points
-	breakdown.PRs = cm.PRsOpened*points.PROpened + cm.PRsMerged*points.PRMerged
+	breakdown.PRs = rules.count("pr_opened", "prs", "prs_opened", cm.PRsOpened, points.PROpened) +
+		rules.count("pr_merged", "prs", "prs_merged", cm.PRsMerged, points.PRMerged)
 
 	// Review points (PR reviews)
-	breakdown.Reviews = cm.ReviewsGiven * points.PRReviewed
+	breakdown.Reviews = rules.count("pr_reviewed", "reviews", "reviews_given", cm.ReviewsGiven, points.PRReviewed)
 
 	// Comment points (PR review comments)
-	breakdown.Comments = cm.ReviewComments * points.ReviewComment
+	breakdown.Comments = rules.count("review_comment", "comments", "review_comments", cm.ReviewComments, points.ReviewComment)
 
 	// Issue points
-	breakdown.Issues = cm.IssuesOpened*points.IssueOpened +
-		cm.IssuesClosed*points.IssueClosed +
-		cm.IssueComments*points.IssueComment +
-		cm.IssueReferencesInCommits*points.IssueReference
+	breakdown.Issues = rules.count("issue_opened", "issues", "issues_opened", cm.IssuesOpened, points.IssueOpened) +
+		rules.count("issue_closed", "issues", "issues_closed", cm.IssuesClosed, points.IssueClosed) +
+		rules.count("issue_comment", "issues", "issue_comments", cm.IssueComments, points.IssueComment) +
+		rules.count("issue_reference_commit", "issues", "issue_references_in_commits", cm.IssueReferencesInCommits, points.IssueReference) +
+		rules.count("linear_issue_completed", "issues", "linear_issues_completed", cm.LinearIssuesCompleted, points.LinearCompleted)
 
-	// Response time bonus
+	// Response time bonus - only the fastest tier reached counts
 	if cm.ReviewsGiven > 0 && cm.AvgReviewTime > 0 {
 		if cm.AvgReviewTime <= 1 {
 			breakdown.ResponseBonus = points.FastReview1h
@@ -281,25 +346,75 @@ func (c *Calculator) calculateScore(cm *models.ContributorMetrics) models.Score
 			breakdown.ResponseBonus = points.FastReview24h
 		}
 	}
+	rules.responseBonus(cm, points)
 
 	// Tests bonus - bonus points for commits that include test files
-	breakdown.TestsBonus = cm.CommitsWithTests * points.CommitWithTests
+	breakdown.TestsBonus = rules.count("commit_with_tests", "tests_bonus", "commits_with_tests", cm.CommitsWithTests, points.CommitWithTests)
 
 	// Out of hours bonus (legacy - kept for backwards compatibility but default is 0)
-	breakdown.OutOfHours = cm.OutOfHoursCount * points.OutOfHours
+	breakdown.OutOfHours = rules.count("out_of_hours", "out_of_hours", "out_of_hours_count", cm.OutOfHoursCount, points.OutOfHours)
+
+	// Build points - fixing the default branch, or a penalty for breaking it
+	breakdown.Builds = rules.count("build_broken", "builds", "builds_broken", cm.BuildsBroken, points.BuildBroken) +
+		rules.count("build_fixed", "builds", "builds_fixed", cm.BuildsFixed, points.BuildFixed)
+
+	// Coverage points - merged PRs raising test coverage
+	breakdown.Coverage = rules.count("coverage_improved", "coverage", "coverage_improved", cm.CoverageImproved, points.CoverageUp)
+
+	// Tech debt points - static-analysis findings fixed
+	breakdown.TechDebt = rules.count("lint_finding_fixed", "tech_debt", "tech_debt_reduction", cm.TechDebtReduction, points.LintFixed)
+
+	// Security points - on top of the regular points for the fixing PR or commit
+	breakdown.Security = rules.count("security_fix", "security", "security_fixes", cm.SecurityFixes, points.SecurityFix)
+
+	// Code Gardener points - refactoring commits, separate from raw line counts
+	breakdown.Gardening = rules.count("refactoring_commit", "gardening", "refactoring_commits", cm.RefactoringCommits, points.Refactoring)
+
+	// Propagation points - repositories a patch was rolled out to beyond the first
+	breakdown.Propagation = rules.count("patch_propagated", "propagation", "patch_propagation", cm.PatchPropagation, points.PatchPropagated)
+
+	// Direct push points - usually a penalty for bypassing PRs
+	breakdown.DirectPushes = rules.count("direct_push", "direct_pushes", "direct_pushes", cm.DirectPushes, points.DirectPush)
+
+	// Auto-merge points - merged PRs left to auto-merge or the merge queue
+	breakdown.AutoMerge = rules.count("auto_merge_enabled", "auto_merge", "auto_merges_enabled", cm.AutoMergesEnabled, points.AutoMerge)
+
+	// Effort points - estimated effort of merged PRs, from the effort hook
+	breakdown.Effort = int(rules.weighted("effort_point", "effort", "effort_points", cm.EffortPoints, points.EffortPoint))
+
+	// Kudos points - reactions others left on their work
+	breakdown.Kudos = rules.count("kudos_received", "kudos", "kudos_received", cm.KudosReceived, points.Kudos)
+
+	// Custom points - per unit of the custom metrics given points
+	custom := 0.0
+	for _, def := range c.config.CustomMetrics {
+		custom += rules.weighted(def.Name, "custom", "custom."+def.Name, cm.Custom[def.Name], def.Points)
+	}
+	breakdown.Custom = int(custom)
 
 	// Calculate total
 	total := breakdown.Commits + breakdown.LineChanges + breakdown.PRs +
 		breakdown.Reviews + breakdown.ResponseBonus + breakdown.Comments +
-		breakdown.Issues + breakdown.TestsBonus + breakdown.OutOfHours
+		breakdown.Issues + breakdown.TestsBonus + breakdown.OutOfHours +
+		breakdown.Builds + breakdown.Coverage + breakdown.TechDebt +
+		breakdown.Security + breakdown.Gardening + breakdown.Propagation +
+		breakdown.DirectPushes + breakdown.AutoMerge + breakdown.Effort +
+		breakdown.Kudos + breakdown.Custom
 
 	return models.Score{
 		Total:     total,
 		Breakdown: breakdown,
+		Rules:     rules,
 	}
 }
 
 func (c *Calculator) checkAchievements(cm *models.ContributorMetrics) []string {
+	// Under scoring.achievement_scope: period only activity dated within the
+	// period counts, not PRs and issues opened before it
+	if cm.PeriodActivity != nil {
+		cm = cm.PeriodActivity
+	}
+
 	// Collect ALL earned achievements (including all tiers)
 	var achievements []string
 
@@ -370,6 +485,14 @@ func (c *Calculator) checkAchievements(cm *models.ContributorMetrics) []string {
 			earned = float64(cm.IssueComments) >= ach.Condition.Threshold
 		case "issue_references":
 			earned = float64(cm.IssueReferencesInCommits) >= ach.Condition.Threshold
+		case "linked_prs":
+			earned = float64(cm.LinkedPRs) >= ach.Condition.Threshold
+		case "issue_traceability":
+			earned = cm.PRsMerged >= minTraceablePRs && cm.IssueTraceability >= ach.Condition.Threshold
+		case "coverage_improved":
+			earned = float64(cm.CoverageImproved) >= ach.Condition.Threshold
+		case "security_fixes":
+			earned = float64(cm.SecurityFixes) >= ach.Condition.Threshold
 		}
 
 		if earned {
@@ -381,21 +504,24 @@ func (c *Calculator) checkAchievements(cm *models.ContributorMetrics) []string {
 }
 
 func (c *Calculator) determineTopCategory(cm *models.ContributorMetrics) string {
-	// Determine what the contributor is best at
-	categories := map[string]int{
-		"Commits":  cm.CommitCount,
-		"PRs":      cm.PRsOpened,
-		"Reviews":  cm.ReviewsGiven,
-		"Comments": cm.ReviewComments,
+	// Determine what the contributor is best at; ties go to the earlier category
+	categories := []struct {
+		name  string
+		value int
+	}{
+		{"Commits", cm.CommitCount},
+		{"PRs", cm.PRsOpened},
+		{"Reviews", cm.ReviewsGiven},
+		{"Comments", cm.ReviewComments},
 	}
 
 	topCategory := ""
 	topValue := 0
 
-	for category, value := range categories {
-		if value > topValue {
-			topValue = value
-			topCategory = category
+	for _, category := range categories {
+		if category.value > topValue {
+			topValue = category.value
+			topCategory = category.name
 		}
 	}
 
@@ -403,8 +529,8 @@ func (c *Calculator) determineTopCategory(cm *models.ContributorMetrics) string
 }
 
 func (c *Calculator) findTopAchievers(contributors []models.ContributorMetrics, topAchievers map[string]string) {
-	var topCommitter, topReviewer, topPRAuthor string
-	var maxCommits, maxReviews, maxPRs int
+	var topCommitter, topReviewer, topPRAuthor, topAppreciated string
+	var maxCommits, maxReviews, maxPRs, maxKudos int
 
 	for _, cm := range contributors {
 		if cm.CommitCount > maxCommits {
@@ -419,6 +545,10 @@ func (c *Calculator) findTopAchievers(contributors []models.ContributorMetrics,
 			maxPRs = cm.PRsOpened
 			topPRAuthor = cm.Login
 		}
+		if cm.KudosReceived > maxKudos {
+			maxKudos = cm.KudosReceived
+			topAppreciated = cm.Login
+		}
 	}
 
 	if topCommitter != "" {
@@ -430,4 +560,7 @@ func (c *Calculator) findTopAchievers(contributors []models.ContributorMetrics,
 	if topPRAuthor != "" {
 		topAchievers["pull_requests"] = topPRAuthor
 	}
+	if topAppreciated != "" {
+		topAchievers["appreciated"] = topAppreciated
+	}
 }
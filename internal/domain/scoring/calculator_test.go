@@ -1,13 +1,15 @@
 package scoring
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/lukaszraczylo/git-velocity/internal/config"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
 func TestNewCalculator(t *testing.T) {
@@ -288,6 +290,299 @@ func TestCalculator_FastReviewBonus(t *testing.T) {
 	}
 }
 
+func TestCalculator_BuildPoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{
+		Commit:      10,
+		BuildBroken: -25,
+		BuildFixed:  15,
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{
+						Login:                   "user1",
+						CommitCount:             10,
+						BuildsBroken:            2,
+						BuildsFixed:             1,
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+				},
+			},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	contributor := result.Repositories[0].Contributors[0]
+	assert.Equal(t, -35, contributor.Score.Breakdown.Builds)
+	assert.Equal(t, 65, contributor.Score.Total)
+}
+
+func TestCalculator_DirectPushPoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{
+		Commit:     10,
+		DirectPush: -5,
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{
+						Login:                   "user1",
+						CommitCount:             10,
+						DirectPushes:            3,
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+				},
+			},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	contributor := result.Repositories[0].Contributors[0]
+	assert.Equal(t, -15, contributor.Score.Breakdown.DirectPushes)
+	assert.Equal(t, 85, contributor.Score.Total)
+}
+
+func TestCalculator_CoveragePoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{
+		Commit:     10,
+		CoverageUp: 15,
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{
+						Login:                   "user1",
+						CommitCount:             10,
+						CoverageImproved:        5,
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+				},
+			},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	contributor := result.Repositories[0].Contributors[0]
+	assert.Equal(t, 75, contributor.Score.Breakdown.Coverage)
+	assert.Equal(t, 175, contributor.Score.Total)
+	assert.Contains(t, contributor.Achievements, "coverage-5")
+	assert.NotContains(t, contributor.Achievements, "coverage-10")
+}
+
+func TestCalculator_EffortPoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{
+		Commit:      10,
+		EffortPoint: 4,
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{
+						Login:                   "user1",
+						CommitCount:             10,
+						EffortPoints:            7.5,
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+				},
+			},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	contributor := result.Repositories[0].Contributors[0]
+	assert.Equal(t, 30, contributor.Score.Breakdown.Effort)
+	assert.Equal(t, 130, contributor.Score.Total)
+	assert.Contains(t, contributor.Score.Rules, models.ScoreRule{
+		Rule: "effort_point", Category: "effort", Metric: "effort_points", Value: 7.5, Points: 4, Awarded: 30,
+	})
+}
+
+func TestCalculator_CustomMetricPoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+	cfg.CustomMetrics = []config.CustomMetricConfig{
+		{Name: "review_ratio", Expression: "reviews_given / max(prs_merged, 1)", Points: 15},
+		{Name: "reviewer", Expression: "review_ratio >= 2", Points: 20},
+		{Name: "net_lines", Expression: "lines_added - lines_deleted"}, // Reported only
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{
+						Login:                   "user1",
+						CommitCount:             10,
+						Custom:                  map[string]float64{"review_ratio": 2.5, "reviewer": 1, "net_lines": 300},
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+				},
+			},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	contributor := result.Repositories[0].Contributors[0]
+	assert.Equal(t, 57, contributor.Score.Breakdown.Custom)
+	assert.Equal(t, 157, contributor.Score.Total)
+	assert.Contains(t, contributor.Score.Rules, models.ScoreRule{
+		Rule: "review_ratio", Category: "custom", Metric: "custom.review_ratio", Value: 2.5, Points: 15, Awarded: 37.5,
+	})
+	for _, r := range contributor.Score.Rules {
+		assert.NotEqual(t, "net_lines", r.Rule)
+	}
+}
+
+func TestCalculator_TechDebtPoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{
+		Commit:    10,
+		LintFixed: 2,
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{
+						Login:                   "user1",
+						CommitCount:             10,
+						TechDebtReduction:       12,
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+				},
+			},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	contributor := result.Repositories[0].Contributors[0]
+	assert.Equal(t, 24, contributor.Score.Breakdown.TechDebt)
+	assert.Equal(t, 124, contributor.Score.Total)
+}
+
+func TestCalculator_SecurityPoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{
+		Commit:      10,
+		SecurityFix: 40,
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{
+						Login:                   "user1",
+						CommitCount:             10,
+						SecurityFixes:           5,
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+				},
+			},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	contributor := result.Repositories[0].Contributors[0]
+	assert.Equal(t, 200, contributor.Score.Breakdown.Security)
+	assert.Equal(t, 300, contributor.Score.Total)
+	assert.Contains(t, contributor.Achievements, "security-5")
+	assert.NotContains(t, contributor.Achievements, "security-10")
+}
+
+func TestCalculator_GardeningPoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{
+		Commit:       10,
+		LinesDeleted: 0.5,
+		Refactoring:  10,
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{
+						Login:                   "user1",
+						CommitCount:             4,
+						MeaningfulLinesDeleted:  200,
+						RefactoringCommits:      3,
+						NetLinesRemoved:         180,
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+				},
+			},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	contributor := result.Repositories[0].Contributors[0]
+	assert.Equal(t, 30, contributor.Score.Breakdown.Gardening)
+	assert.Equal(t, 100, contributor.Score.Breakdown.LineChanges)
+	assert.Equal(t, 170, contributor.Score.Total)
+}
+
 func TestCalculator_MultipleContributorsRanking(t *testing.T) {
 	t.Parallel()
 
@@ -389,6 +684,301 @@ func TestCalculator_PercentileRank(t *testing.T) {
 	assert.Equal(t, 400, result.Leaderboard[3].Score)
 }
 
+func TestCalculator_TiedRanks(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{Login: "dave", CommitCount: 10},
+					{Login: "carol", CommitCount: 80},
+					{Login: "bob", CommitCount: 80},
+					{Login: "alice", CommitCount: 100},
+				},
+			},
+		},
+		Groups: []models.GroupMetrics{
+			{Name: "All", Repositories: []string{"owner/repo"}},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	// Ties share a rank, are listed by login and the next rank skips them
+	type ranked struct {
+		Login string
+		Rank  int
+		Tied  bool
+	}
+	expected := []ranked{{"alice", 1, false}, {"bob", 2, true}, {"carol", 2, true}, {"dave", 4, false}}
+	var leaderboard, group []ranked
+	for _, e := range result.Leaderboard {
+		leaderboard = append(leaderboard, ranked{e.Login, e.Rank, e.Tied})
+	}
+	for _, e := range result.Groups[0].Leaderboard {
+		group = append(group, ranked{e.Login, e.Rank, e.Tied})
+	}
+	assert.Equal(t, expected, leaderboard)
+	assert.Equal(t, expected, group)
+
+	// Tied contributors share the percentile of the higher position
+	require.Len(t, result.Contributors, 4)
+	assert.Equal(t, 2, result.Contributors[2].Score.Rank)
+	assert.InDelta(t, 75.0, result.Contributors[1].Score.PercentileRank, 0.001)
+	assert.InDelta(t, 75.0, result.Contributors[2].Score.PercentileRank, 0.001)
+	assert.InDelta(t, 25.0, result.Contributors[3].Score.PercentileRank, 0.001)
+}
+
+func TestCalculator_Normalization(t *testing.T) {
+	t.Parallel()
+
+	// alice: 1000 points over 50 days, bob: 600 over 10, carol: 200 over 2
+	newMetrics := func() *models.GlobalMetrics {
+		return &models.GlobalMetrics{
+			Contributors: []models.ContributorMetrics{
+				{Login: "alice", CommitCount: 100, ActiveDays: 50},
+				{Login: "bob", CommitCount: 60, ActiveDays: 10},
+				{Login: "carol", CommitCount: 20, ActiveDays: 2},
+			},
+		}
+	}
+	calculate := func(mode string) *models.GlobalMetrics {
+		cfg := config.DefaultConfig()
+		cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+		cfg.Scoring.Normalization = mode
+		return NewCalculator(cfg).Calculate(newMetrics())
+	}
+	logins := func(m *models.GlobalMetrics) []string {
+		var out []string
+		for _, e := range m.Leaderboard {
+			out = append(out, e.Login)
+		}
+		return out
+	}
+	normalized := func(m *models.GlobalMetrics) []float64 {
+		var out []float64
+		for _, e := range m.Leaderboard {
+			out = append(out, e.Normalized)
+		}
+		return out
+	}
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+		result := calculate(config.NormalizationNone)
+		assert.Equal(t, []string{"alice", "bob", "carol"}, logins(result))
+		assert.Equal(t, []float64{0, 0, 0}, normalized(result))
+		assert.Empty(t, result.Normalization)
+	})
+
+	t.Run("percentile", func(t *testing.T) {
+		t.Parallel()
+		result := calculate(config.NormalizationPercentile)
+		assert.Equal(t, []string{"alice", "bob", "carol"}, logins(result))
+		assert.Equal(t, []float64{83.33, 50, 16.67}, normalized(result))
+		assert.Equal(t, config.NormalizationPercentile, result.Normalization)
+	})
+
+	t.Run("zscore", func(t *testing.T) {
+		t.Parallel()
+		result := calculate(config.NormalizationZScore)
+		assert.Equal(t, []string{"alice", "bob", "carol"}, logins(result))
+		assert.Equal(t, []float64{1.22, 0, -1.22}, normalized(result))
+	})
+
+	t.Run("per active day", func(t *testing.T) {
+		t.Parallel()
+		result := calculate(config.NormalizationPerActiveDay)
+		// The part-time contributor ranks first
+		assert.Equal(t, []string{"carol", "bob", "alice"}, logins(result))
+		assert.Equal(t, []float64{100, 60, 20}, normalized(result))
+		assert.Equal(t, 1, result.Contributors[0].Score.Rank)
+		assert.Equal(t, "carol", result.TopAchievers["overall"])
+		assert.Equal(t, 200, result.Leaderboard[0].Score, "raw score is kept")
+	})
+}
+
+func TestNormalize_PercentileTies(t *testing.T) {
+	t.Parallel()
+
+	contributors := []models.ContributorMetrics{
+		{Score: models.Score{Total: 10}},
+		{Score: models.Score{Total: 10}},
+		{Score: models.Score{Total: 0}},
+		{Score: models.Score{Total: 30}},
+	}
+	normalize(config.NormalizationPercentile, contributors)
+
+	assert.Equal(t, 50.0, contributors[0].Score.Normalized)
+	assert.Equal(t, 50.0, contributors[1].Score.Normalized)
+	assert.Equal(t, 12.5, contributors[2].Score.Normalized)
+	assert.Equal(t, 87.5, contributors[3].Score.Normalized)
+}
+
+func TestNormalize_ZScoreUniformScores(t *testing.T) {
+	t.Parallel()
+
+	contributors := []models.ContributorMetrics{
+		{Score: models.Score{Total: 5}},
+		{Score: models.Score{Total: 5}},
+	}
+	normalize(config.NormalizationZScore, contributors)
+
+	assert.Zero(t, contributors[0].Score.Normalized)
+	assert.Zero(t, contributors[1].Score.Normalized)
+}
+
+func TestCalculator_Decay(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+	metrics := func() *models.GlobalMetrics {
+		return &models.GlobalMetrics{
+			Contributors: []models.ContributorMetrics{
+				{Login: "old", CommitCount: 30, RecencyWeight: 0.5},
+				{Login: "recent", CommitCount: 20, RecencyWeight: 1.8},
+				{Login: "unweighted", CommitCount: 10},
+			},
+		}
+	}
+
+	// Without a half-life recency weights are ignored
+	result := NewCalculator(cfg).Calculate(metrics())
+	assert.Equal(t, "old", result.Leaderboard[0].Login)
+	assert.Nil(t, result.Leaderboard[0].Decay)
+
+	cfg.Scoring.DecayHalfLifeDays = 30
+	result = NewCalculator(cfg).Calculate(metrics())
+	require.Len(t, result.Leaderboard, 3)
+
+	assert.Equal(t, "recent", result.Leaderboard[0].Login, "recent work counts more")
+	assert.Equal(t, 360, result.Leaderboard[0].Score)
+	require.NotNil(t, result.Leaderboard[0].Decay)
+	assert.Equal(t, models.Decay{RawTotal: 200, Weight: 1.8, HalfLifeDays: 30}, *result.Leaderboard[0].Decay)
+
+	assert.Equal(t, 150, result.Leaderboard[1].Score)
+	assert.Equal(t, 100, result.Leaderboard[2].Score)
+	assert.Nil(t, result.Leaderboard[2].Decay, "contributors without a weight keep their score")
+}
+
+func TestCalculator_ProRating(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+	cfg.Contributors = []config.ContributorConfig{
+		{Login: "Joiner", Start: "2025-01-21"}, // 10 of 30 days
+		{Login: "leaver", End: "2025-01-15"},   // 15 of 30 days
+		{Login: "before", Start: "2024-06-01"}, // Whole period
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Period: models.Period{
+			Start: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2025, time.January, 30, 23, 59, 59, 0, time.UTC),
+		},
+		Contributors: []models.ContributorMetrics{
+			{Login: "fulltime", CommitCount: 50},
+			{Login: "joiner", CommitCount: 20},
+			{Login: "leaver", CommitCount: 30},
+			{Login: "before", CommitCount: 10},
+		},
+		Teams: []models.TeamMetrics{
+			{Name: "Core", MemberMetrics: []models.ContributorMetrics{{Login: "joiner"}}},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+	byLogin := make(map[string]models.LeaderboardEntry)
+	for _, e := range result.Leaderboard {
+		byLogin[e.Login] = e
+	}
+
+	assert.Equal(t, 600, byLogin["joiner"].Score)
+	require.NotNil(t, byLogin["joiner"].ProRating)
+	assert.Equal(t, models.ProRating{RawTotal: 200, MemberDays: 10, PeriodDays: 30, Factor: 3, Share: 1}, *byLogin["joiner"].ProRating)
+
+	assert.Equal(t, 600, byLogin["leaver"].Score)
+	require.NotNil(t, byLogin["leaver"].ProRating)
+	assert.Equal(t, 15, byLogin["leaver"].ProRating.MemberDays)
+
+	assert.Equal(t, 500, byLogin["fulltime"].Score)
+	assert.Nil(t, byLogin["fulltime"].ProRating)
+	assert.Equal(t, 100, byLogin["before"].Score)
+	assert.Nil(t, byLogin["before"].ProRating, "members for the whole period are not adjusted")
+
+	assert.Equal(t, 600, result.Teams[0].TotalScore, "team scores use the pro-rated score")
+}
+
+func TestCalculator_ProRatingBounds(t *testing.T) {
+	t.Parallel()
+
+	share := func(v float64) *float64 { return &v }
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+	cfg.Contributors = []config.ContributorConfig{
+		{Login: "late", Start: "2025-06-28"},   // 2 of 180 days
+		{Login: "recent", Start: "2025-06-20"}, // 10 of 180 days
+		{Login: "mixed", Start: "2025-06-01"},  // 30 of 180 days
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Period: models.Period{
+			Start: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2025, time.June, 29, 23, 59, 59, 0, time.UTC),
+		},
+		Contributors: []models.ContributorMetrics{
+			{Login: "late", CommitCount: 10, MembershipShare: share(1)},
+			{Login: "recent", CommitCount: 10, MembershipShare: share(1)},
+			{Login: "mixed", CommitCount: 10, MembershipShare: share(0.5)}, // Half of it before joining
+		},
+	}
+
+	result := calc.Calculate(metrics)
+	byLogin := make(map[string]models.LeaderboardEntry)
+	for _, e := range result.Leaderboard {
+		byLogin[e.Login] = e
+	}
+
+	assert.Equal(t, 100, byLogin["late"].Score, "too few member days to extrapolate from")
+	assert.Nil(t, byLogin["late"].ProRating)
+
+	assert.Equal(t, 400, byLogin["recent"].Score, "x18 capped")
+	require.NotNil(t, byLogin["recent"].ProRating)
+	assert.InDelta(t, 4.0, byLogin["recent"].ProRating.Factor, 0.001)
+
+	// 50 points from before joining count as is, the other 50 are scaled x4
+	assert.Equal(t, 250, byLogin["mixed"].Score)
+	require.NotNil(t, byLogin["mixed"].ProRating)
+	assert.InDelta(t, 0.5, byLogin["mixed"].ProRating.Share, 0.001)
+}
+
+func TestCalculator_ProRatingOpenPeriod(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+	cfg.Contributors = []config.ContributorConfig{{Login: "joiner", Start: "2025-01-21"}}
+
+	result := NewCalculator(cfg).Calculate(&models.GlobalMetrics{
+		Period:       models.Period{End: time.Now(), Label: "All Time"},
+		Contributors: []models.ContributorMetrics{{Login: "joiner", CommitCount: 20}},
+	})
+
+	assert.Equal(t, 200, result.Leaderboard[0].Score)
+	assert.Nil(t, result.Leaderboard[0].ProRating)
+}
+
 func TestCalculator_Achievements(t *testing.T) {
 	t.Parallel()
 
@@ -430,6 +1020,38 @@ func TestCalculator_Achievements(t *testing.T) {
 	assert.NotContains(t, contributor.Achievements, "review-10")
 }
 
+func TestCalculator_PeriodActivityAchievements(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	calc := NewCalculator(cfg)
+
+	// Ten PRs collected, one of them opened within the period
+	contributor := models.ContributorMetrics{
+		Login:          "user1",
+		CommitCount:    15,
+		PRsOpened:      10,
+		PeriodActivity: &models.ContributorMetrics{Login: "user1", CommitCount: 15, PRsOpened: 1},
+	}
+	metrics := &models.GlobalMetrics{
+		Contributors: []models.ContributorMetrics{contributor},
+		Repositories: []models.RepositoryMetrics{
+			{FullName: "owner/repo", Contributors: []models.ContributorMetrics{contributor}},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	for _, cm := range []models.ContributorMetrics{result.Contributors[0], result.Repositories[0].Contributors[0]} {
+		assert.Contains(t, cm.Achievements, "commit-10")
+		assert.Contains(t, cm.Achievements, "pr-1")
+		assert.NotContains(t, cm.Achievements, "pr-10", "earned from PRs opened before the period")
+	}
+	// The score still counts everything collected
+	assert.Equal(t, 10*cfg.Scoring.Points.PROpened, result.Contributors[0].Score.Breakdown.PRs)
+}
+
 func TestCalculator_AllAchievementTypes(t *testing.T) {
 	t.Parallel()
 
@@ -485,6 +1107,52 @@ func TestCalculator_AllAchievementTypes(t *testing.T) {
 	assert.Greater(t, len(contributor.Achievements), 10)
 }
 
+func TestCalculator_Kudos(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{
+		Commit: 10,
+		Kudos:  2,
+	}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{
+						Login:                   "user1",
+						CommitCount:             10,
+						KudosReceived:           3,
+						KudosGiven:              8,
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+					{
+						Login:                   "user2",
+						CommitCount:             20,
+						KudosReceived:           12,
+						RepositoriesContributed: []string{"owner/repo"},
+					},
+				},
+			},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	contributor := result.Repositories[0].Contributors[1]
+	require.Equal(t, "user1", contributor.Login)
+	assert.Equal(t, 6, contributor.Score.Breakdown.Kudos, "only kudos received score")
+	assert.Equal(t, 106, contributor.Score.Total)
+	assert.Contains(t, contributor.Score.Rules, models.ScoreRule{
+		Rule: "kudos_received", Category: "kudos", Metric: "kudos_received", Value: 3, Points: 2, Awarded: 6,
+	})
+	assert.Equal(t, "user2", result.TopAchievers["appreciated"])
+}
+
 func TestCalculator_TopAchievers(t *testing.T) {
 	t.Parallel()
 
@@ -533,6 +1201,7 @@ func TestCalculator_TopAchievers(t *testing.T) {
 	assert.Equal(t, "committer", result.TopAchievers["commits"])
 	assert.Equal(t, "pr-author", result.TopAchievers["pull_requests"])
 	assert.Equal(t, "reviewer", result.TopAchievers["reviews"])
+	assert.NotContains(t, result.TopAchievers, "appreciated", "no kudos received")
 	// Overall top achiever has highest score
 	assert.NotEmpty(t, result.TopAchievers["overall"])
 }
@@ -586,6 +1255,136 @@ func TestCalculator_TeamScoring(t *testing.T) {
 	// Check individual member scores
 	assert.Equal(t, 500, team.MemberMetrics[0].Score.Total)
 	assert.Equal(t, 300, team.MemberMetrics[1].Score.Total)
+
+	// Without a capacity each member counts as one full-time equivalent
+	assert.Equal(t, 2.0, team.Capacity)
+	assert.Equal(t, 400.0, team.PerFTE.Score)
+}
+
+func TestCalculator_TeamCapacity(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/repo",
+				Contributors: []models.ContributorMetrics{
+					{Login: "user1", CommitCount: 30, RepositoriesContributed: []string{"owner/repo"}},
+					{Login: "user2", CommitCount: 30, RepositoriesContributed: []string{"owner/repo"}},
+				},
+			},
+		},
+		Teams: []models.TeamMetrics{
+			{
+				Name:     "Part-time Team",
+				Members:  []string{"user1", "user2"},
+				Capacity: 1.5,
+				AggregatedMetrics: models.ContributorMetrics{
+					CommitCount: 60, PRsMerged: 4, ReviewsGiven: 9, LinesAdded: 1000,
+				},
+				MemberMetrics: []models.ContributorMetrics{
+					{Login: "user1"},
+					{Login: "user2"},
+				},
+			},
+		},
+	}
+
+	team := calc.Calculate(metrics).Teams[0]
+
+	assert.Equal(t, 600, team.TotalScore, "raw values are kept")
+	assert.Equal(t, 1.5, team.Capacity)
+	assert.Equal(t, models.TeamPerFTE{
+		Score:        400,
+		Commits:      40,
+		PRsMerged:    2.67,
+		ReviewsGiven: 6,
+		LinesAdded:   666.67,
+	}, team.PerFTE)
+}
+
+func TestCalculator_TeamRanking(t *testing.T) {
+	t.Parallel()
+
+	contributors := []models.ContributorMetrics{
+		{Login: "star", CommitCount: 100},
+		{Login: "a1", CommitCount: 1},
+		{Login: "a2", CommitCount: 1},
+		{Login: "a3", CommitCount: 1},
+		{Login: "a4", CommitCount: 1},
+		{Login: "b1", CommitCount: 20},
+		{Login: "b2", CommitCount: 20},
+		{Login: "b3", CommitCount: 20},
+	}
+	team := func(name string, members ...string) models.TeamMetrics {
+		tm := models.TeamMetrics{Name: name, Members: members}
+		for _, m := range members {
+			tm.MemberMetrics = append(tm.MemberMetrics, models.ContributorMetrics{Login: m})
+		}
+		return tm
+	}
+
+	tests := []struct {
+		mode  string
+		order []string
+	}{
+		{mode: "", order: []string{"Alpha", "Beta"}},
+		{mode: config.TeamRankingTotal, order: []string{"Alpha", "Beta"}},
+		{mode: config.TeamRankingMean, order: []string{"Alpha", "Beta"}},
+		{mode: config.TeamRankingMedian, order: []string{"Beta", "Alpha"}},
+		{mode: config.TeamRankingTrimmedMean, order: []string{"Beta", "Alpha"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("mode_"+tt.mode, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.DefaultConfig()
+			cfg.Scoring.Enabled = true
+			cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+			cfg.Scoring.TeamRanking = tt.mode
+
+			metrics := &models.GlobalMetrics{
+				Repositories: []models.RepositoryMetrics{{FullName: "owner/repo"}},
+				Contributors: slices.Clone(contributors),
+				Teams: []models.TeamMetrics{
+					team("Alpha", "star", "a1", "a2", "a3", "a4"),
+					team("Beta", "b1", "b2", "b3"),
+				},
+			}
+			result := NewCalculator(cfg).Calculate(metrics)
+
+			require.Len(t, result.Teams, 2)
+			assert.Equal(t, tt.mode, result.TeamRanking)
+			byName := make(map[string]models.TeamMetrics)
+			for i, tm := range result.Teams {
+				assert.Equal(t, tt.order[i], tm.Name)
+				if tt.mode == "" {
+					assert.Zero(t, tm.Rank)
+				} else {
+					assert.Equal(t, i+1, tm.Rank)
+				}
+				byName[tm.Name] = tm
+			}
+
+			// One prolific member lifts the mean but not the robust statistics
+			alpha := byName["Alpha"]
+			assert.Equal(t, 1040, alpha.TotalScore)
+			assert.Equal(t, 208.0, alpha.AvgScore)
+			assert.Equal(t, 10.0, alpha.MedianScore)
+			assert.Equal(t, 10.0, alpha.TrimmedMeanScore)
+
+			beta := byName["Beta"]
+			assert.Equal(t, 200.0, beta.AvgScore)
+			assert.Equal(t, 200.0, beta.MedianScore)
+			assert.Equal(t, 200.0, beta.TrimmedMeanScore)
+		})
+	}
 }
 
 func TestCalculator_TeamInLeaderboard(t *testing.T) {
@@ -723,6 +1522,56 @@ func TestCalculator_MultipleRepositories(t *testing.T) {
 	assert.Equal(t, 500, contributor.Score.Total)
 }
 
+func TestCalculator_GroupLeaderboard(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = config.PointsConfig{Commit: 10}
+	cfg.Teams = []config.TeamConfig{{Name: "Payments Team", Members: []string{"user2"}}}
+	calc := NewCalculator(cfg)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				FullName: "owner/ledger",
+				Contributors: []models.ContributorMetrics{
+					{Login: "user1", CommitCount: 5},
+					{Login: "user2", CommitCount: 20},
+				},
+			},
+			{
+				FullName: "owner/checkout",
+				Contributors: []models.ContributorMetrics{
+					{Login: "user1", CommitCount: 10},
+				},
+			},
+			{
+				FullName: "owner/website",
+				Contributors: []models.ContributorMetrics{
+					{Login: "user1", CommitCount: 100},
+				},
+			},
+		},
+		Groups: []models.GroupMetrics{
+			{Name: "Payments", Repositories: []string{"owner/checkout", "owner/ledger"}},
+		},
+	}
+
+	result := calc.Calculate(metrics)
+
+	require.Len(t, result.Groups, 1)
+	group := result.Groups[0]
+	// Only activity in the group's repositories counts
+	require.Len(t, group.Leaderboard, 2)
+	assert.Equal(t, "user2", group.Leaderboard[0].Login)
+	assert.Equal(t, 200, group.Leaderboard[0].Score)
+	assert.Equal(t, "Payments Team", group.Leaderboard[0].Team)
+	assert.Equal(t, 2, group.Leaderboard[1].Rank)
+	assert.Equal(t, 150, group.Leaderboard[1].Score)
+	assert.Equal(t, 350, group.TotalScore)
+}
+
 func TestCalculator_EmptyMetrics(t *testing.T) {
 	t.Parallel()
 
@@ -1315,6 +2164,36 @@ func TestCalculator_IssueAchievements(t *testing.T) {
 		assert.NotContains(t, contributor.Achievements, "issue-ref-25", "Should not earn issue-ref-25 for <25 references")
 	})
 
+	t.Run("earns traceability achievements", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.DefaultConfig()
+		cfg.Scoring.Enabled = true
+		calc := NewCalculator(cfg)
+
+		metrics := &models.GlobalMetrics{
+			Contributors: []models.ContributorMetrics{
+				{Login: "tracer", PRsMerged: 12, LinkedPRs: 11, IssueTraceability: 11.0 / 12 * 100},
+				{Login: "newcomer", PRsMerged: 5, LinkedPRs: 5, IssueTraceability: 100}, // Too few PRs for a percentage
+			},
+		}
+
+		result := calc.Calculate(metrics)
+
+		achievements := make(map[string][]string)
+		for _, c := range result.Contributors {
+			achievements[c.Login] = c.Achievements
+		}
+		assert.Contains(t, achievements["tracer"], "linked-pr-10")
+		assert.NotContains(t, achievements["tracer"], "linked-pr-25")
+		assert.Contains(t, achievements["tracer"], "traceable-75")
+		assert.Contains(t, achievements["tracer"], "traceable-90")
+		assert.NotContains(t, achievements["tracer"], "traceable-100")
+
+		assert.Contains(t, achievements["newcomer"], "linked-pr-5")
+		assert.NotContains(t, achievements["newcomer"], "traceable-75", "needs 10 merged PRs")
+	})
+
 	t.Run("earns all issue achievement tiers", func(t *testing.T) {
 		t.Parallel()
 
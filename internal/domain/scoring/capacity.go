@@ -0,0 +1,26 @@
+package scoring
+
+import "github.com/lukaszraczylo/git-velocity/pkg/models"
+
+// adjustForCapacity divides the team's totals by its capacity in full-time
+// equivalents. Teams without a configured capacity count each member as one.
+func adjustForCapacity(team *models.TeamMetrics) {
+	if team.Capacity <= 0 {
+		team.Capacity = float64(len(team.Members))
+	}
+	if team.Capacity <= 0 {
+		team.PerFTE = models.TeamPerFTE{}
+		return
+	}
+
+	perFTE := func(v int) float64 {
+		return round2(float64(v) / team.Capacity)
+	}
+	team.PerFTE = models.TeamPerFTE{
+		Score:        perFTE(team.TotalScore),
+		Commits:      perFTE(team.AggregatedMetrics.CommitCount),
+		PRsMerged:    perFTE(team.AggregatedMetrics.PRsMerged),
+		ReviewsGiven: perFTE(team.AggregatedMetrics.ReviewsGiven),
+		LinesAdded:   perFTE(team.AggregatedMetrics.LinesAdded),
+	}
+}
@@ -0,0 +1,22 @@
+package scoring
+
+import (
+	"math"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// decay weights the score by the recency of the contributor's activity when
+// a decay half-life is configured, and records the adjustment
+func (c *Calculator) decay(score *models.Score, weight float64) {
+	halfLife := c.config.Scoring.DecayHalfLifeDays
+	if halfLife <= 0 || weight <= 0 {
+		return
+	}
+	score.Decay = &models.Decay{
+		RawTotal:     score.Total,
+		Weight:       weight,
+		HalfLifeDays: halfLife,
+	}
+	score.Total = int(math.Round(float64(score.Total) * weight))
+}
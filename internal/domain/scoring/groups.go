@@ -0,0 +1,58 @@
+package scoring
+
+import (
+	"sort"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// rankGroups builds the leaderboard of each group from the scores
+// contributors earned in its repositories, scored on repository activity
+// like the per-repository rankings
+func (c *Calculator) rankGroups(metrics *models.GlobalMetrics, contributorMap map[string]*models.ContributorMetrics) {
+	repos := make(map[string]*models.RepositoryMetrics, len(metrics.Repositories))
+	for i := range metrics.Repositories {
+		repos[metrics.Repositories[i].FullName] = &metrics.Repositories[i]
+	}
+
+	for i := range metrics.Groups {
+		group := &metrics.Groups[i]
+		scores := make(map[string]int)
+		for _, name := range group.Repositories {
+			if rm, ok := repos[name]; ok {
+				for _, rc := range rm.Contributors {
+					scores[rc.Login] += rc.Score.Total
+				}
+			}
+		}
+
+		group.TotalScore = 0
+		group.Leaderboard = make([]models.LeaderboardEntry, 0, len(scores))
+		for login, score := range scores {
+			entry := models.LeaderboardEntry{Login: login, Score: score}
+			if cm, ok := contributorMap[login]; ok {
+				entry.Name = cm.Name
+				entry.AvatarURL = cm.AvatarURL
+				entry.Achievements = cm.Achievements
+			}
+			if team := c.config.GetTeamForUser(login); team != nil {
+				entry.Team = team.Name
+			}
+			group.TotalScore += score
+			group.Leaderboard = append(group.Leaderboard, entry)
+		}
+		sort.Slice(group.Leaderboard, func(a, b int) bool {
+			if group.Leaderboard[a].Score != group.Leaderboard[b].Score {
+				return group.Leaderboard[a].Score > group.Leaderboard[b].Score
+			}
+			return group.Leaderboard[a].Login < group.Leaderboard[b].Login
+		})
+		ranks := competitionRanks(len(group.Leaderboard), func(j int) bool {
+			return group.Leaderboard[j].Score == group.Leaderboard[j-1].Score
+		})
+		for j := range group.Leaderboard {
+			group.Leaderboard[j].Rank = ranks[j]
+			group.Leaderboard[j].Tied = sharesRank(ranks, j)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package scoring
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// isNormalized reports whether mode ranks by something other than the raw score
+func isNormalized(mode string) bool {
+	return mode != "" && mode != config.NormalizationNone
+}
+
+// normalize sets Score.Normalized of each contributor for the given mode.
+// Without a mode the raw total is used, so sorting by Normalized matches
+// sorting by score.
+func normalize(mode string, contributors []models.ContributorMetrics) {
+	switch mode {
+	case config.NormalizationPercentile:
+		percentiles(contributors)
+	case config.NormalizationZScore:
+		zScores(contributors)
+	case config.NormalizationPerActiveDay:
+		for i := range contributors {
+			days := max(contributors[i].ActiveDays, 1)
+			contributors[i].Score.Normalized = round2(float64(contributors[i].Score.Total) / float64(days))
+		}
+	default:
+		for i := range contributors {
+			contributors[i].Score.Normalized = 0
+		}
+	}
+}
+
+// percentiles sets the percentile of each score: the share of contributors
+// scoring lower, counting ties as half, so equal scores get equal values
+func percentiles(contributors []models.ContributorMetrics) {
+	n := len(contributors)
+	if n == 0 {
+		return
+	}
+	scores := make([]int, n)
+	for i := range contributors {
+		scores[i] = contributors[i].Score.Total
+	}
+	sort.Ints(scores)
+
+	for i := range contributors {
+		score := contributors[i].Score.Total
+		below := sort.SearchInts(scores, score)
+		equal := sort.SearchInts(scores, score+1) - below
+		contributors[i].Score.Normalized = round2((float64(below) + float64(equal)/2) / float64(n) * 100)
+	}
+}
+
+// zScores sets how many standard deviations each score is from the mean
+func zScores(contributors []models.ContributorMetrics) {
+	n := float64(len(contributors))
+	if n == 0 {
+		return
+	}
+	var sum float64
+	for i := range contributors {
+		sum += float64(contributors[i].Score.Total)
+	}
+	mean := sum / n
+
+	var variance float64
+	for i := range contributors {
+		d := float64(contributors[i].Score.Total) - mean
+		variance += d * d
+	}
+	stddev := math.Sqrt(variance / n)
+
+	for i := range contributors {
+		if stddev == 0 {
+			contributors[i].Score.Normalized = 0
+			continue
+		}
+		contributors[i].Score.Normalized = round2((float64(contributors[i].Score.Total) - mean) / stddev)
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
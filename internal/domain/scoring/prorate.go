@@ -0,0 +1,73 @@
+package scoring
+
+import (
+	"math"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+const day = 24 * time.Hour
+
+// Bounds of pro-rating: members for less than minMemberDays are too short a
+// sample to extrapolate from, and no score grows more than maxProRateFactor
+// times for the activity within the membership
+const (
+	minMemberDays    = 7
+	maxProRateFactor = 4.0
+)
+
+// prorate scales the score of a contributor who joined or left during the
+// period to what it would be over the whole period, and records the
+// adjustment. Only the share of the score earned within the membership is
+// scaled; activity outside it counts as is. Open-ended periods (no start
+// date) are never pro-rated.
+func (c *Calculator) prorate(score *models.Score, login string, share *float64, period models.Period) {
+	cc := c.config.GetContributor(login)
+	if cc == nil || period.Start.IsZero() || period.End.IsZero() {
+		return
+	}
+	start, end, err := cc.Membership()
+	if err != nil {
+		return // Rejected by config validation
+	}
+
+	from, to := period.Start, period.End
+	if start != nil && start.After(from) {
+		from = *start
+	}
+	if end != nil && end.Before(to) {
+		to = *end
+	}
+
+	periodDays := days(period.Start, period.End)
+	memberDays := days(from, to)
+	if memberDays < minMemberDays || memberDays >= periodDays {
+		return
+	}
+
+	factor := math.Min(float64(periodDays)/float64(memberDays), maxProRateFactor)
+	inside := 1.0 // Metrics without the share count all activity as within
+	if share != nil {
+		inside = math.Min(math.Max(*share, 0), 1)
+	}
+	score.ProRating = &models.ProRating{
+		RawTotal:   score.Total,
+		MemberDays: memberDays,
+		PeriodDays: periodDays,
+		Factor:     math.Round(factor*100) / 100,
+		Share:      math.Round(inside*100) / 100,
+	}
+	raw := float64(score.Total)
+	score.Total = int(math.Round(raw*(1-inside) + raw*inside*factor))
+}
+
+// days counts the calendar days from one time to another, both included
+func days(from, to time.Time) int {
+	if to.Before(from) {
+		return 0
+	}
+	from = from.UTC().Truncate(day)
+	to = to.UTC().Truncate(day)
+	return int(to.Sub(from)/day) + 1
+}
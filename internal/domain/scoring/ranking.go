@@ -0,0 +1,22 @@
+package scoring
+
+// competitionRanks numbers n sorted entries with standard competition
+// ranking: an entry tied with the one before it shares its rank, and the
+// next entry's rank skips the positions the tie took (1, 2, 2, 4). tied
+// reports whether entry i is tied with entry i-1.
+func competitionRanks(n int, tied func(i int) bool) []int {
+	ranks := make([]int, n)
+	for i := range ranks {
+		if i > 0 && tied(i) {
+			ranks[i] = ranks[i-1]
+		} else {
+			ranks[i] = i + 1
+		}
+	}
+	return ranks
+}
+
+// sharesRank reports whether the entry at i shares its rank with a neighbour
+func sharesRank(ranks []int, i int) bool {
+	return (i > 0 && ranks[i-1] == ranks[i]) || (i+1 < len(ranks) && ranks[i+1] == ranks[i])
+}
@@ -0,0 +1,39 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompetitionRanks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		scores []int
+		ranks  []int
+		tied   []bool
+	}{
+		{name: "empty", scores: []int{}, ranks: []int{}, tied: []bool{}},
+		{name: "distinct", scores: []int{9, 5, 1}, ranks: []int{1, 2, 3}, tied: []bool{false, false, false}},
+		{name: "tie for first", scores: []int{9, 9, 1}, ranks: []int{1, 1, 3}, tied: []bool{true, true, false}},
+		{name: "tie in the middle", scores: []int{9, 5, 5, 5, 1}, ranks: []int{1, 2, 2, 2, 5}, tied: []bool{false, true, true, true, false}},
+		{name: "all tied", scores: []int{3, 3}, ranks: []int{1, 1}, tied: []bool{true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ranks := competitionRanks(len(tt.scores), func(i int) bool {
+				return tt.scores[i] == tt.scores[i-1]
+			})
+			assert.Equal(t, tt.ranks, ranks)
+			tied := make([]bool, len(ranks))
+			for i := range ranks {
+				tied[i] = sharesRank(ranks, i)
+			}
+			assert.Equal(t, tt.tied, tied)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package scoring
+
+import (
+	"maps"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Rescore recalculates the scores of a run from the metrics it stores when
+// it was scored with point values other than the calculator's, so a run from
+// before a change of points can be compared with one from after. It reports
+// whether the run was rescored. Runs recording no point values predate the
+// recording and are left as they are, as are runs scored with the same values.
+func (c *Calculator) Rescore(metrics *models.GlobalMetrics) (*models.GlobalMetrics, bool) {
+	if !c.config.Scoring.Enabled || metrics.Points == nil || maps.Equal(metrics.Points, c.config.Scoring.Points.Values()) {
+		return metrics, false
+	}
+	return c.Calculate(metrics), true
+}
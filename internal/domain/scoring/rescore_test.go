@@ -0,0 +1,100 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func rescoreMetrics() *models.GlobalMetrics {
+	contributors := []models.ContributorMetrics{
+		{Login: "alice", CommitCount: 40, PRsMerged: 2, ReviewsGiven: 1, RepositoriesContributed: []string{"owner/repo"}},
+		{Login: "bob", CommitCount: 10, PRsMerged: 6, ReviewsGiven: 12, RepositoriesContributed: []string{"owner/repo"}},
+		{Login: "carol", CommitCount: 5, ReviewsGiven: 30, RepositoriesContributed: []string{"owner/repo"}},
+	}
+	return &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{{FullName: "owner/repo", Contributors: contributors}},
+		Contributors: append([]models.ContributorMetrics(nil), contributors...),
+	}
+}
+
+func rescoreConfig(points config.PointsConfig) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Enabled = true
+	cfg.Scoring.Points = points
+	return cfg
+}
+
+// roundTrip stores metrics and reads them back, as global.json is
+func roundTrip(t *testing.T, metrics *models.GlobalMetrics) *models.GlobalMetrics {
+	t.Helper()
+	data, err := json.Marshal(models.NewGlobalDocument(metrics, time.Time{}))
+	require.NoError(t, err)
+	var doc models.GlobalDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	return doc.GlobalMetrics
+}
+
+func TestCalculator_RecordsPoints(t *testing.T) {
+	t.Parallel()
+
+	points := config.PointsConfig{Commit: 10, PRMerged: 50}
+	metrics := NewCalculator(rescoreConfig(points)).Calculate(rescoreMetrics())
+	assert.Equal(t, points.Values(), metrics.Points)
+	assert.Equal(t, 10.0, roundTrip(t, metrics).Points["commit"])
+
+	disabled := config.DefaultConfig()
+	disabled.Scoring.Enabled = false
+	assert.Nil(t, NewCalculator(disabled).Calculate(rescoreMetrics()).Points)
+}
+
+func TestCalculator_Rescore(t *testing.T) {
+	t.Parallel()
+
+	before := config.PointsConfig{Commit: 10, PRMerged: 50, PRReviewed: 5}
+	after := config.PointsConfig{Commit: 2, PRMerged: 50, PRReviewed: 40}
+
+	leaderboard := func(m *models.GlobalMetrics) map[string]int {
+		scores := make(map[string]int)
+		for _, e := range m.Leaderboard {
+			scores[e.Login] = e.Score
+		}
+		return scores
+	}
+	fresh := NewCalculator(rescoreConfig(after)).Calculate(rescoreMetrics())
+	stored := roundTrip(t, NewCalculator(rescoreConfig(before)).Calculate(rescoreMetrics()))
+	require.NotEqual(t, leaderboard(fresh), leaderboard(stored))
+
+	t.Run("changed points", func(t *testing.T) {
+		t.Parallel()
+		rescored, ok := NewCalculator(rescoreConfig(after)).Rescore(roundTrip(t, stored))
+		assert.True(t, ok)
+		// Rescoring a stored run matches scoring its data with the new values
+		assert.Equal(t, leaderboard(fresh), leaderboard(rescored))
+		assert.Equal(t, fresh.Leaderboard[0].Login, rescored.Leaderboard[0].Login)
+		assert.Equal(t, after.Values(), rescored.Points)
+	})
+
+	t.Run("same points", func(t *testing.T) {
+		t.Parallel()
+		run := roundTrip(t, stored)
+		rescored, ok := NewCalculator(rescoreConfig(before)).Rescore(run)
+		assert.False(t, ok)
+		assert.Equal(t, leaderboard(stored), leaderboard(rescored))
+	})
+
+	t.Run("no recorded points", func(t *testing.T) {
+		t.Parallel()
+		run := roundTrip(t, stored)
+		run.Points = nil
+		rescored, ok := NewCalculator(rescoreConfig(after)).Rescore(run)
+		assert.False(t, ok)
+		assert.Equal(t, leaderboard(stored), leaderboard(rescored))
+	})
+}
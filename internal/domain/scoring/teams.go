@@ -0,0 +1,77 @@
+package scoring
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// trimFraction is the share of members dropped from each end of the score
+// distribution for the trimmed mean
+const trimFraction = 0.2
+
+// summarizeScores sets the team's outlier-robust score statistics, the median
+// and trimmed mean of its member scores
+func summarizeScores(team *models.TeamMetrics) {
+	scores := make([]float64, len(team.MemberMetrics))
+	for i, m := range team.MemberMetrics {
+		scores[i] = float64(m.Score.Total)
+	}
+	team.MedianScore = 0
+	team.TrimmedMeanScore = 0
+	n := len(scores)
+	if n == 0 {
+		return
+	}
+	slices.Sort(scores)
+
+	if n%2 == 1 {
+		team.MedianScore = scores[n/2]
+	} else {
+		team.MedianScore = (scores[n/2-1] + scores[n/2]) / 2
+	}
+
+	trim := int(math.Floor(float64(n) * trimFraction))
+	var sum float64
+	for _, s := range scores[trim : n-trim] {
+		sum += s
+	}
+	team.TrimmedMeanScore = round2(sum / float64(n-2*trim))
+}
+
+// rankTeams sorts teams by the given statistic and numbers them, teams with
+// equal values sharing a rank in their configured order. An empty mode
+// leaves the configured order and clears the ranks.
+func rankTeams(teams []models.TeamMetrics, mode string) {
+	if mode == "" {
+		for i := range teams {
+			teams[i].Rank = 0
+		}
+		return
+	}
+
+	value := func(t *models.TeamMetrics) float64 {
+		switch mode {
+		case config.TeamRankingMean:
+			return t.AvgScore
+		case config.TeamRankingMedian:
+			return t.MedianScore
+		case config.TeamRankingTrimmedMean:
+			return t.TrimmedMeanScore
+		default:
+			return float64(t.TotalScore)
+		}
+	}
+	sort.SliceStable(teams, func(i, j int) bool {
+		return value(&teams[i]) > value(&teams[j])
+	})
+	ranks := competitionRanks(len(teams), func(i int) bool {
+		return value(&teams[i]) == value(&teams[i-1])
+	})
+	for i := range teams {
+		teams[i].Rank = ranks[i]
+	}
+}
@@ -0,0 +1,183 @@
+// Package events publishes what changed in a run, such as contributors'
+// scores and newly earned achievements, to event streams, so recognition
+// bots and HR tools can react to each run as it finishes.
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/compare"
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Event types
+const (
+	ScoreUpdated      = "contributor-score-updated"
+	AchievementEarned = "achievement-earned"
+	RunCompleted      = "run-completed"
+)
+
+// Event is a single published event
+type Event struct {
+	ID     string    `json:"id"` // Unique and stable for a run, for deduplicating redeliveries
+	Type   string    `json:"type"`
+	RunAt  time.Time `json:"run_at"`
+	Period string    `json:"period"`
+	Data   any       `json:"data"` // ScoreUpdate, Achievement or RunSummary
+
+	// Key partitions contributor events by login, keeping each
+	// contributor's events in order; empty for run events
+	Key string `json:"-"`
+}
+
+// ScoreUpdate is the data of a contributor-score-updated event
+type ScoreUpdate struct {
+	Login         string `json:"login"`
+	Name          string `json:"name,omitempty"`
+	Team          string `json:"team,omitempty"`
+	Score         int    `json:"score"`
+	PreviousScore int    `json:"previous_score"`
+	Rank          int    `json:"rank"`
+	PreviousRank  int    `json:"previous_rank"` // 0 when not ranked in the previous run
+}
+
+// Achievement is the data of an achievement-earned event
+type Achievement struct {
+	Login       string `json:"login"`
+	Name        string `json:"name,omitempty"`
+	Team        string `json:"team,omitempty"`
+	Achievement string `json:"achievement"` // Achievement ID
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// RunSummary is the data of a run-completed event
+type RunSummary struct {
+	Contributors       int    `json:"contributors"`
+	Repositories       int    `json:"repositories"`
+	Commits            int    `json:"commits"`
+	PRs                int    `json:"prs"`
+	Reviews            int    `json:"reviews"`
+	Score              int    `json:"score"` // Sum of all contributor scores
+	ScoresUpdated      int    `json:"scores_updated"`
+	AchievementsEarned int    `json:"achievements_earned"`
+	FirstRun           bool   `json:"first_run,omitempty"` // No previous run to compare with
+	SiteURL            string `json:"site_url,omitempty"`
+}
+
+// Publisher sends events to an event stream
+type Publisher interface {
+	Name() string
+	Publish(ctx context.Context, events []Event) error
+}
+
+// Build returns the events of a run compared with the previous one, which is
+// nil on the first run: a score update per contributor whose score or rank
+// changed, an event per achievement earned and, last, the run's summary.
+// Contributors who opted out are left out.
+func Build(previous, current *models.GlobalMetrics, cfg *config.Config, runAt time.Time) []Event {
+	runAt = runAt.UTC()
+	firstRun := previous == nil
+	if firstRun {
+		previous = &models.GlobalMetrics{}
+	}
+
+	contributors := make(map[string]models.ContributorMetrics, len(current.Contributors))
+	for _, c := range current.Contributors {
+		if !c.OptedOut {
+			contributors[c.Login] = c
+		}
+	}
+	team := func(login string) string {
+		if t := cfg.GetTeamForUser(login); t != nil {
+			return t.Name
+		}
+		return ""
+	}
+	achievements := make(map[string]config.AchievementConfig)
+	for _, a := range cfg.Scoring.GetAchievements() {
+		achievements[a.ID] = a
+	}
+
+	var events []Event
+	add := func(eventType, key string, data any) {
+		events = append(events, Event{
+			ID:     fmt.Sprintf("%d-%d", runAt.UnixMilli(), len(events)+1),
+			Type:   eventType,
+			RunAt:  runAt,
+			Period: current.Period.Label,
+			Data:   data,
+			Key:    key,
+		})
+	}
+
+	// Largest score changes first
+	var scores, earned int
+	report := compare.Compare(previous, current)
+	for _, delta := range report.Contributors {
+		c, ok := contributors[delta.Login]
+		if !ok || (!delta.Score.Changed() && !delta.Rank.Changed()) {
+			continue
+		}
+		add(ScoreUpdated, c.Login, ScoreUpdate{
+			Login: c.Login, Name: c.Name, Team: team(c.Login),
+			Score: delta.Score.After, PreviousScore: delta.Score.Before,
+			Rank: delta.Rank.After, PreviousRank: delta.Rank.Before,
+		})
+		scores++
+	}
+	for _, delta := range report.Contributors {
+		c, ok := contributors[delta.Login]
+		if !ok {
+			continue
+		}
+		for _, id := range delta.AchievementsGained {
+			a, known := achievements[id]
+			if !known {
+				a = config.AchievementConfig{ID: id, Name: id}
+			}
+			add(AchievementEarned, c.Login, Achievement{
+				Login: c.Login, Name: c.Name, Team: team(c.Login),
+				Achievement: id, Title: a.Name, Description: a.Description,
+			})
+			earned++
+		}
+	}
+
+	add(RunCompleted, "", RunSummary{
+		Contributors:       current.TotalContributors,
+		Repositories:       len(current.Repositories),
+		Commits:            current.TotalCommits,
+		PRs:                current.TotalPRs,
+		Reviews:            current.TotalReviews,
+		Score:              report.Totals.Score.After,
+		ScoresUpdated:      scores,
+		AchievementsEarned: earned,
+		FirstRun:           firstRun,
+		SiteURL:            cfg.Output.SiteURL,
+	})
+	return events
+}
+
+// Publishers returns a publisher for every enabled event stream. Kafka
+// requests go through transport, whose TLS settings NATS connections share.
+func Publishers(cfg config.EventsConfig, transport *http.Transport) []Publisher {
+	var publishers []Publisher
+	if cfg.Kafka.Enabled {
+		kafka := NewKafka(cfg.Kafka)
+		kafka.SetTransport(transport)
+		publishers = append(publishers, kafka)
+	}
+	if cfg.NATS.Enabled {
+		nats := NewNATS(cfg.NATS)
+		if transport != nil && transport.TLSClientConfig != nil {
+			nats.tlsConfig = transport.TLSClientConfig.Clone()
+		}
+		publishers = append(publishers, nats)
+	}
+	return publishers
+}
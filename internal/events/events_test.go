@@ -0,0 +1,106 @@
+package events
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+var runAt = time.Date(2024, 4, 1, 6, 30, 0, 0, time.UTC)
+
+func run(contributors ...models.ContributorMetrics) *models.GlobalMetrics {
+	return &models.GlobalMetrics{
+		Period:            models.Period{Label: "March 2024"},
+		Contributors:      contributors,
+		Repositories:      []models.RepositoryMetrics{{FullName: "acme/api"}},
+		TotalContributors: len(contributors),
+		TotalCommits:      20,
+	}
+}
+
+func contributor(login string, score, rank int, achievements ...string) models.ContributorMetrics {
+	return models.ContributorMetrics{Login: login, Name: login, Score: models.Score{Total: score, Rank: rank}, Achievements: achievements}
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Teams = []config.TeamConfig{{Name: "Platform", Members: []string{"alice"}}}
+	cfg.Output.SiteURL = "https://velocity.example.com"
+
+	previous := run(contributor("alice", 100, 2), contributor("bob", 150, 1, "commit-1"), contributor("carol", 50, 3))
+	carol := contributor("carol", 400, 1, "commit-1")
+	carol.OptedOut = true
+	current := run(
+		contributor("alice", 300, 1, "commit-1"),
+		contributor("bob", 150, 2, "commit-1"),
+		carol,
+		contributor("dave", 10, 3),
+	)
+
+	events := Build(previous, current, cfg, runAt.In(time.FixedZone("CEST", 2*3600)))
+	require.Len(t, events, 5)
+	for i, e := range events {
+		assert.Equal(t, runAt, e.RunAt)
+		assert.Equal(t, "March 2024", e.Period)
+		assert.Equal(t, fmt.Sprintf("1711953000000-%d", i+1), e.ID)
+	}
+
+	// Largest score changes first; opted-out contributors are left out
+	assert.Equal(t, ScoreUpdated, events[0].Type)
+	assert.Equal(t, "alice", events[0].Key)
+	assert.Equal(t, ScoreUpdate{Login: "alice", Name: "alice", Team: "Platform", Score: 300, PreviousScore: 100, Rank: 1, PreviousRank: 2}, events[0].Data)
+	assert.Equal(t, ScoreUpdate{Login: "dave", Name: "dave", Score: 10, Rank: 3}, events[1].Data)
+	assert.Equal(t, ScoreUpdate{Login: "bob", Name: "bob", Score: 150, PreviousScore: 150, Rank: 2, PreviousRank: 1}, events[2].Data)
+
+	require.Equal(t, AchievementEarned, events[3].Type)
+	earned := events[3].Data.(Achievement)
+	assert.Equal(t, "alice", earned.Login)
+	assert.Equal(t, "commit-1", earned.Achievement)
+	assert.Equal(t, "First Steps", earned.Title)
+
+	assert.Equal(t, RunCompleted, events[4].Type)
+	assert.Empty(t, events[4].Key)
+	assert.Equal(t, RunSummary{
+		Contributors: 4, Repositories: 1, Commits: 20, Score: 860,
+		ScoresUpdated: 3, AchievementsEarned: 1, SiteURL: "https://velocity.example.com",
+	}, events[4].Data)
+}
+
+func TestBuild_FirstRun(t *testing.T) {
+	t.Parallel()
+
+	events := Build(nil, run(contributor("alice", 30, 1, "custom")), config.DefaultConfig(), runAt)
+	require.Len(t, events, 3)
+	assert.Equal(t, ScoreUpdate{Login: "alice", Name: "alice", Score: 30, Rank: 1}, events[0].Data)
+	// Achievements missing from the catalog are titled by their ID
+	assert.Equal(t, "custom", events[1].Data.(Achievement).Title)
+	assert.True(t, events[2].Data.(RunSummary).FirstRun)
+
+	// An unchanged run only completes
+	events = Build(run(contributor("alice", 30, 1)), run(contributor("alice", 30, 1)), config.DefaultConfig(), runAt)
+	require.Len(t, events, 1)
+	assert.Equal(t, RunCompleted, events[0].Type)
+}
+
+func TestPublishers(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, Publishers(config.EventsConfig{}, nil))
+
+	publishers := Publishers(config.EventsConfig{
+		Kafka: config.KafkaConfig{Enabled: true, URL: "http://localhost:8082"},
+		NATS:  config.NATSConfig{Enabled: true, URL: "nats://localhost:4222"},
+	}, &http.Transport{})
+	require.Len(t, publishers, 2)
+	assert.Equal(t, "Kafka", publishers[0].Name())
+	assert.Equal(t, "NATS", publishers[1].Name())
+}
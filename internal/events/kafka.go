@@ -0,0 +1,143 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/version"
+)
+
+// kafkaBatch is the number of records produced per request
+const kafkaBatch = 100
+
+// maxErrorBody bounds how much of an error response is read for its message
+const maxErrorBody = 64 << 10
+
+// Kafka produces events to a topic through the v2 API of a Kafka REST proxy,
+// which Confluent REST Proxy, Redpanda and Karapace all serve
+type Kafka struct {
+	endpoint   string
+	cfg        config.KafkaConfig
+	httpClient *http.Client
+}
+
+// NewKafka creates a Kafka publisher
+func NewKafka(cfg config.KafkaConfig) *Kafka {
+	return &Kafka{
+		endpoint:   strings.TrimSuffix(cfg.URL, "/"),
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SetTransport sends requests through transport, for proxy and TLS settings
+func (k *Kafka) SetTransport(transport http.RoundTripper) {
+	if transport != nil {
+		k.httpClient.Transport = transport
+	}
+}
+
+// Name identifies the publisher in logs
+func (k *Kafka) Name() string {
+	return "Kafka"
+}
+
+// kafkaRecord is a record produced with the JSON embedded format
+type kafkaRecord struct {
+	Key   string `json:"key,omitempty"`
+	Value Event  `json:"value"`
+}
+
+// kafkaOffsets is the response to a produce request, an offset or error per record
+type kafkaOffsets struct {
+	Offsets []struct {
+		Partition int    `json:"partition"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// kafkaError is the body of an error response
+type kafkaError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// Publish produces the events in order
+func (k *Kafka) Publish(ctx context.Context, events []Event) error {
+	for start := 0; start < len(events); start += kafkaBatch {
+		batch := events[start:min(start+kafkaBatch, len(events))]
+		records := make([]kafkaRecord, len(batch))
+		for i, e := range batch {
+			records[i] = kafkaRecord{Key: e.Key, Value: e}
+		}
+		if err := k.produce(ctx, records); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *Kafka) produce(ctx context.Context, records []kafkaRecord) error {
+	data, err := json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return err
+	}
+	endpoint := k.endpoint + "/topics/" + url.PathEscape(k.cfg.TopicName())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json, application/json")
+	req.Header.Set("User-Agent", "git-velocity/"+version.Version)
+	switch {
+	case k.cfg.Token != "":
+		req.Header.Set("Authorization", "Bearer "+k.cfg.Token)
+	case k.cfg.Username != "":
+		req.SetBasicAuth(k.cfg.Username, k.cfg.Password)
+	}
+
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("kafka REST proxy request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		var apiErr kafkaError
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("kafka REST proxy returned status %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("kafka REST proxy returned status %d", resp.StatusCode)
+	}
+
+	// Records can fail one by one, e.g. when a partition has no leader
+	var offsets kafkaOffsets
+	if err := json.NewDecoder(resp.Body).Decode(&offsets); err != nil {
+		return fmt.Errorf("failed to decode kafka REST proxy response: %w", err)
+	}
+	failed, first := 0, ""
+	for _, o := range offsets.Offsets {
+		if o.ErrorCode != nil || o.Error != "" {
+			if failed == 0 {
+				first = o.Error
+			}
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d records rejected, the first: %s", failed, len(records), first)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+// fakeRESTProxy serves the produce endpoint of a Kafka REST proxy
+type fakeRESTProxy struct {
+	mu      sync.Mutex
+	records []map[string]any
+	path    string
+	failing bool // Fail every record
+}
+
+func (f *fakeRESTProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if user, pass, ok := r.BasicAuth(); !ok || user != "velocity" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error_code": 40101, "message": "Authentication failed"}`))
+		return
+	}
+	if r.Header.Get("Content-Type") != "application/vnd.kafka.json.v2+json" {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	f.path = r.URL.Path
+	var body struct {
+		Records []map[string]any `json:"records"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	if f.failing {
+		_, _ = w.Write([]byte(`{"offsets": [{"partition": null, "offset": null, "error_code": 50003, "error": "Leader not available"}]}`))
+		return
+	}
+	f.records = append(f.records, body.Records...)
+	_, _ = w.Write([]byte(`{"offsets": [{"partition": 0, "offset": 1, "error_code": null, "error": null}]}`))
+}
+
+func newFakeRESTProxy(t *testing.T, cfg config.KafkaConfig) (*fakeRESTProxy, *Kafka) {
+	t.Helper()
+	fake := &fakeRESTProxy{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	cfg.Enabled, cfg.URL = true, server.URL+"/"
+	return fake, NewKafka(cfg)
+}
+
+func TestKafka_Publish(t *testing.T) {
+	t.Parallel()
+
+	fake, kafka := newFakeRESTProxy(t, config.KafkaConfig{Username: "velocity", Password: "secret"})
+	events := Build(nil, run(contributor("alice", 30, 1)), config.DefaultConfig(), runAt)
+	require.NoError(t, kafka.Publish(context.Background(), events))
+
+	assert.Equal(t, "/topics/"+config.DefaultKafkaTopic, fake.path)
+	require.Len(t, fake.records, 2)
+	assert.Equal(t, "alice", fake.records[0]["key"])
+	value := fake.records[0]["value"].(map[string]any)
+	assert.Equal(t, ScoreUpdated, value["type"])
+	assert.Equal(t, "alice", value["data"].(map[string]any)["login"])
+	assert.NotContains(t, fake.records[1], "key", "run events are not keyed")
+}
+
+func TestKafka_Errors(t *testing.T) {
+	t.Parallel()
+
+	events := Build(nil, run(), config.DefaultConfig(), runAt)
+
+	fake, kafka := newFakeRESTProxy(t, config.KafkaConfig{Username: "velocity", Password: "secret"})
+	fake.failing = true
+	assert.EqualError(t, kafka.Publish(context.Background(), events), "1 of 1 records rejected, the first: Leader not available")
+
+	_, kafka = newFakeRESTProxy(t, config.KafkaConfig{Token: "token"})
+	assert.EqualError(t, kafka.Publish(context.Background(), events), "kafka REST proxy returned status 401: Authentication failed")
+}
@@ -0,0 +1,186 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/version"
+)
+
+// natsTimeout bounds connecting to NATS and publishing a run's events
+const natsTimeout = 30 * time.Second
+
+// natsDefaultPort is the client port of URLs without one
+const natsDefaultPort = "4222"
+
+// NATS publishes events over the NATS client protocol, each to the subject
+// <subject>.<event type>
+type NATS struct {
+	cfg       config.NATSConfig
+	tlsConfig *tls.Config // Base TLS settings, e.g. extra CAs; nil for the defaults
+}
+
+// NewNATS creates a NATS publisher
+func NewNATS(cfg config.NATSConfig) *NATS {
+	return &NATS{cfg: cfg}
+}
+
+// Name identifies the publisher in logs
+func (n *NATS) Name() string {
+	return "NATS"
+}
+
+// natsInfo is the part of the server's INFO greeting a publisher needs
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+	MaxPayload  int  `json:"max_payload"`
+}
+
+// Publish connects, publishes the events in order and waits for the server
+// to acknowledge them before disconnecting
+func (n *NATS) Publish(ctx context.Context, events []Event) error {
+	u, err := url.Parse(n.cfg.URL)
+	if err != nil {
+		return fmt.Errorf("invalid NATS URL: %w", err)
+	}
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, natsTimeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	// Unblock reads and writes when the run is cancelled
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	r := bufio.NewReader(conn)
+	line, err := readLine(r)
+	if err != nil {
+		return fmt.Errorf("failed to read NATS server info: %w", err)
+	}
+	infoJSON, ok := strings.CutPrefix(line, "INFO ")
+	if !ok {
+		return fmt.Errorf("unexpected NATS greeting: %q", line)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		return fmt.Errorf("failed to parse NATS server info: %w", err)
+	}
+
+	useTLS := u.Scheme == "tls" || info.TLSRequired
+	if useTLS {
+		cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		if n.tlsConfig != nil {
+			cfg = n.tlsConfig.Clone()
+		}
+		if cfg.ServerName == "" {
+			cfg.ServerName = u.Hostname()
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return fmt.Errorf("NATS TLS handshake failed: %w", err)
+		}
+		conn = tlsConn
+		r = bufio.NewReader(conn)
+	}
+
+	w := bufio.NewWriter(conn)
+	if err := n.writeConnect(w, u, useTLS); err != nil {
+		return err
+	}
+	prefix := n.cfg.SubjectPrefix()
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if info.MaxPayload > 0 && len(payload) > info.MaxPayload {
+			return fmt.Errorf("%s event of %d bytes exceeds the NATS server's maximum payload of %d bytes", e.Type, len(payload), info.MaxPayload)
+		}
+		fmt.Fprintf(w, "PUB %s.%s %d\r\n", prefix, e.Type, len(payload))
+		_, _ = w.Write(payload)
+		_, _ = w.WriteString("\r\n")
+	}
+	// The server answers the PING once it has processed everything before it
+	_, _ = w.WriteString("PING\r\n")
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return fmt.Errorf("failed to publish to NATS: %w", err)
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := conn.Write([]byte("PONG\r\n")); err != nil {
+				return fmt.Errorf("failed to publish to NATS: %w", err)
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS server error: %s", strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		}
+	}
+}
+
+// writeConnect writes the CONNECT message with the configured credentials,
+// or those of the URL
+func (n *NATS) writeConnect(w *bufio.Writer, u *url.URL, useTLS bool) error {
+	connect := map[string]any{
+		"verbose":      false,
+		"pedantic":     false,
+		"tls_required": useTLS,
+		"name":         "git-velocity",
+		"lang":         "go",
+		"version":      version.Version,
+		"protocol":     0,
+	}
+	switch {
+	case n.cfg.Token != "":
+		connect["auth_token"] = n.cfg.Token
+	case n.cfg.Username != "":
+		connect["user"], connect["pass"] = n.cfg.Username, n.cfg.Password
+	case u.User != nil:
+		if password, ok := u.User.Password(); ok {
+			connect["user"], connect["pass"] = u.User.Username(), password
+		} else {
+			connect["auth_token"] = u.User.Username()
+		}
+	}
+	data, err := json.Marshal(connect)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "CONNECT %s\r\n", data)
+	return err
+}
+
+// readLine reads a protocol line without its CRLF
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
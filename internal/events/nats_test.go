@@ -0,0 +1,124 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+// natsMessage is a message the fake server received
+type natsMessage struct {
+	subject string
+	payload []byte
+}
+
+// fakeNATS accepts a single client connection, records what it publishes and
+// answers its PING, or -ERR when the CONNECT has the wrong token
+type fakeNATS struct {
+	listener net.Listener
+	connect  map[string]any
+	messages []natsMessage
+	done     chan struct{}
+}
+
+func newFakeNATS(t *testing.T, token string) *fakeNATS {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	f := &fakeNATS{listener: listener, done: make(chan struct{})}
+	go f.serve(token)
+	return f
+}
+
+func (f *fakeNATS) url() string {
+	return "nats://" + f.listener.Addr().String()
+}
+
+func (f *fakeNATS) serve(token string) {
+	defer close(f.done)
+	conn, err := f.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	_, _ = fmt.Fprint(conn, `INFO {"server_id":"fake","max_payload":1048576}`+"\r\n")
+
+	r := bufio.NewReader(conn)
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return
+		}
+		verb, args, _ := strings.Cut(line, " ")
+		switch verb {
+		case "CONNECT":
+			_ = json.Unmarshal([]byte(args), &f.connect)
+			if f.connect["auth_token"] != token {
+				_, _ = fmt.Fprint(conn, "-ERR 'Authorization Violation'\r\n")
+				_, _ = io.Copy(io.Discard, r) // Until the client hangs up
+				return
+			}
+		case "PUB":
+			subject, size, _ := strings.Cut(args, " ")
+			n, _ := strconv.Atoi(size)
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			f.messages = append(f.messages, natsMessage{subject: subject, payload: payload[:n]})
+		case "PING":
+			_, _ = fmt.Fprint(conn, "PONG\r\n")
+		}
+	}
+}
+
+func TestNATS_Publish(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeNATS(t, "s3cr3t")
+	nats := NewNATS(config.NATSConfig{Enabled: true, URL: fake.url(), Subject: "eng.velocity", Token: "s3cr3t"})
+	events := Build(nil, run(contributor("alice", 30, 1)), config.DefaultConfig(), runAt)
+	require.NoError(t, nats.Publish(context.Background(), events))
+	<-fake.done
+
+	assert.Equal(t, "git-velocity", fake.connect["name"])
+	assert.Equal(t, false, fake.connect["verbose"])
+	require.Len(t, fake.messages, 2)
+	assert.Equal(t, "eng.velocity.contributor-score-updated", fake.messages[0].subject)
+	assert.Equal(t, "eng.velocity.run-completed", fake.messages[1].subject)
+
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(fake.messages[0].payload, &event))
+	assert.Equal(t, "alice", event["data"].(map[string]any)["login"])
+}
+
+func TestNATS_Errors(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeNATS(t, "s3cr3t")
+	// Credentials in the URL are used when none are configured
+	nats := NewNATS(config.NATSConfig{Enabled: true, URL: "nats://wrong@" + fake.listener.Addr().String()})
+	err := nats.Publish(context.Background(), Build(nil, run(), config.DefaultConfig(), runAt))
+	assert.EqualError(t, err, "NATS server error: Authorization Violation")
+	<-fake.done
+	assert.Equal(t, "wrong", fake.connect["auth_token"])
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	nats = NewNATS(config.NATSConfig{Enabled: true, URL: "nats://" + address})
+	assert.ErrorContains(t, nats.Publish(context.Background(), nil), "failed to connect to NATS")
+}
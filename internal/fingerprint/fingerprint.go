@@ -0,0 +1,53 @@
+// Package fingerprint summarizes the JSON shape of Go types, so tests can
+// notice when persisted data changes structure without its version changing.
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Types returns a short hash of the field names, JSON tags and types of the
+// values' types, following nested types
+func Types(values ...any) string {
+	var b strings.Builder
+	seen := make(map[reflect.Type]bool)
+	for _, v := range values {
+		describe(reflect.TypeOf(v), seen, &b)
+		b.WriteString("\n")
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// describe writes the JSON shape of t, describing each struct once
+func describe(t reflect.Type, seen map[reflect.Type]bool, b *strings.Builder) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		if t.Kind() == reflect.Map {
+			fmt.Fprintf(b, "map[%s]", t.Key())
+		} else {
+			b.WriteString("[]")
+		}
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.PkgPath() == "time" {
+		b.WriteString(t.String())
+		return
+	}
+	fmt.Fprintf(b, "%s{", t)
+	if seen[t] {
+		b.WriteString("}")
+		return
+	}
+	seen[t] = true
+	for i := range t.NumField() {
+		f := t.Field(i)
+		fmt.Fprintf(b, "%s %q ", f.Name, f.Tag.Get("json"))
+		describe(f.Type, seen, b)
+		b.WriteString(";")
+	}
+	b.WriteString("}")
+}
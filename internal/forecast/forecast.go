@@ -0,0 +1,226 @@
+// Package forecast projects weekly activity series forward, either along a
+// least-squares trend line or with additive Holt-Winters smoothing, with a
+// confidence band derived from the fit's residuals.
+package forecast
+
+import (
+	"math"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// MinWeeks is the shortest history a series is projected from
+const MinWeeks = 3
+
+// Series is a named weekly series to project
+type Series struct {
+	Name string
+	Data []float64
+}
+
+// Forecaster projects weekly series as configured
+type Forecaster struct {
+	cfg config.ForecastConfig
+	z   float64 // Standard normal quantile of the confidence level
+}
+
+// New creates a forecaster for the given configuration
+func New(cfg config.ForecastConfig) *Forecaster {
+	return &Forecaster{
+		cfg: cfg,
+		z:   math.Sqrt2 * math.Erfinv(cfg.Confidence),
+	}
+}
+
+// Project projects each series for the configured number of weeks following
+// lastWeek, the start of the last week in the data. Series shorter than
+// MinWeeks are skipped; nil is returned when none is left.
+func (f *Forecaster) Project(lastWeek time.Time, series ...Series) *models.Forecast {
+	weeks := f.cfg.Weeks
+	result := &models.Forecast{
+		Method:     f.cfg.Method,
+		Confidence: f.cfg.Confidence,
+	}
+	for _, s := range series {
+		if len(s.Data) < MinWeeks {
+			continue
+		}
+		var values, stderr []float64
+		if f.cfg.Method == config.ForecastHoltWinters {
+			values, stderr = holtWinters(s.Data, weeks, f.cfg.SeasonLength)
+		} else {
+			values, stderr = linear(s.Data, weeks)
+		}
+
+		fs := models.ForecastSeries{
+			Name:  s.Name,
+			Data:  make([]float64, weeks),
+			Lower: make([]float64, weeks),
+			Upper: make([]float64, weeks),
+		}
+		for h := range weeks {
+			// Activity can't be negative
+			value := math.Max(values[h], 0)
+			fs.Data[h] = round2(value)
+			fs.Lower[h] = round2(math.Max(values[h]-f.z*stderr[h], 0))
+			fs.Upper[h] = round2(math.Max(values[h]+f.z*stderr[h], 0))
+			fs.Total += value
+		}
+		fs.Total = round2(fs.Total)
+		result.Series = append(result.Series, fs)
+	}
+	if len(result.Series) == 0 {
+		return nil
+	}
+
+	for h := 1; h <= weeks; h++ {
+		result.Labels = append(result.Labels, lastWeek.AddDate(0, 0, 7*h).Format("Jan 2"))
+	}
+	return result
+}
+
+// linear fits a least-squares line and returns the projected values with the
+// standard error of each prediction
+func linear(data []float64, weeks int) (values, stderr []float64) {
+	n := float64(len(data))
+	var meanX, meanY float64
+	for i, y := range data {
+		meanX += float64(i)
+		meanY += y
+	}
+	meanX /= n
+	meanY /= n
+
+	var sxx, sxy float64
+	for i, y := range data {
+		dx := float64(i) - meanX
+		sxx += dx * dx
+		sxy += dx * (y - meanY)
+	}
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+
+	var sse float64
+	for i, y := range data {
+		r := y - (intercept + slope*float64(i))
+		sse += r * r
+	}
+	sigma := math.Sqrt(sse / (n - 2))
+
+	for h := 1; h <= weeks; h++ {
+		x := n - 1 + float64(h)
+		values = append(values, intercept+slope*x)
+		dx := x - meanX
+		stderr = append(stderr, sigma*math.Sqrt(1+1/n+dx*dx/sxx))
+	}
+	return values, stderr
+}
+
+// smoothing holds a Holt-Winters fit
+type smoothing struct {
+	level, trend float64
+	seasonal     []float64 // Seasonal components of the last full season
+	sse          float64   // Sum of squared one-step errors
+	steps        int
+}
+
+// holtWinters fits additive Holt-Winters smoothing, choosing the smoothing
+// constants with the lowest one-step error. Histories shorter than two
+// seasons are fitted without a seasonal component (Holt's linear method).
+// The band widens with the square root of the horizon.
+func holtWinters(data []float64, weeks, season int) (values, stderr []float64) {
+	if len(data) < 2*season {
+		season = 0
+	}
+	grid := []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+	gammas := grid
+	if season == 0 {
+		gammas = []float64{0}
+	}
+
+	var best *smoothing
+	for _, alpha := range grid {
+		for _, beta := range grid {
+			for _, gamma := range gammas {
+				fit := smooth(data, season, alpha, beta, gamma)
+				if best == nil || fit.sse < best.sse {
+					best = fit
+				}
+			}
+		}
+	}
+
+	sigma := 0.0
+	if best.steps > 0 {
+		sigma = math.Sqrt(best.sse / float64(best.steps))
+	}
+	for h := 1; h <= weeks; h++ {
+		value := best.level + float64(h)*best.trend
+		if season > 0 {
+			value += best.seasonal[(h-1)%season]
+		}
+		values = append(values, value)
+		stderr = append(stderr, sigma*math.Sqrt(float64(h)))
+	}
+	return values, stderr
+}
+
+func smooth(data []float64, season int, alpha, beta, gamma float64) *smoothing {
+	fit := &smoothing{}
+	start := 1
+	if season > 0 {
+		// Initial level and trend from the first two seasons
+		first, second := mean(data[:season]), mean(data[season:2*season])
+		fit.level = first
+		fit.trend = (second - first) / float64(season)
+		fit.seasonal = make([]float64, season)
+		for i := range season {
+			fit.seasonal[i] = data[i] - first
+		}
+		start = season
+	} else {
+		fit.level = data[0]
+		fit.trend = data[1] - data[0]
+	}
+
+	for t := start; t < len(data); t++ {
+		var s float64
+		if season > 0 {
+			s = fit.seasonal[t%season]
+		}
+		err := data[t] - (fit.level + fit.trend + s)
+		fit.sse += err * err
+		fit.steps++
+
+		level := alpha*(data[t]-s) + (1-alpha)*(fit.level+fit.trend)
+		fit.trend = beta*(level-fit.level) + (1-beta)*fit.trend
+		fit.level = level
+		if season > 0 {
+			fit.seasonal[t%season] = gamma*(data[t]-level) + (1-gamma)*s
+		}
+	}
+
+	if season > 0 {
+		// Rotate so seasonal[0] belongs to the week after the data
+		rotated := make([]float64, season)
+		for i := range season {
+			rotated[i] = fit.seasonal[(len(data)+i)%season]
+		}
+		fit.seasonal = rotated
+	}
+	return fit
+}
+
+func mean(data []float64) float64 {
+	var sum float64
+	for _, v := range data {
+		sum += v
+	}
+	return sum / float64(len(data))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,108 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+func forecaster(method string) *Forecaster {
+	return New(config.ForecastConfig{
+		Enabled:      true,
+		Method:       method,
+		Weeks:        4,
+		SeasonLength: 4,
+		Confidence:   0.9,
+	})
+}
+
+var lastWeek = time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC) // Monday
+
+func TestProject_Linear(t *testing.T) {
+	t.Parallel()
+
+	result := forecaster(config.ForecastLinear).Project(lastWeek,
+		Series{Name: "Commits", Data: []float64{10, 12, 14, 16, 18}},
+		Series{Name: "PRs", Data: []float64{5, 7, 4, 8, 6}},
+	)
+	require.NotNil(t, result)
+
+	assert.Equal(t, "linear", result.Method)
+	assert.Equal(t, 0.9, result.Confidence)
+	assert.Equal(t, []string{"Apr 1", "Apr 8", "Apr 15", "Apr 22"}, result.Labels)
+	require.Len(t, result.Series, 2)
+
+	// A perfect line is continued with no uncertainty
+	commits := result.Series[0]
+	assert.Equal(t, "Commits", commits.Name)
+	assert.Equal(t, []float64{20, 22, 24, 26}, commits.Data)
+	assert.Equal(t, commits.Data, commits.Lower)
+	assert.Equal(t, commits.Data, commits.Upper)
+	assert.Equal(t, 92.0, commits.Total)
+
+	// Noisy data gets a band that widens with the horizon
+	prs := result.Series[1]
+	for h := range prs.Data {
+		assert.Less(t, prs.Lower[h], prs.Data[h])
+		assert.Greater(t, prs.Upper[h], prs.Data[h])
+	}
+	assert.Greater(t, prs.Upper[3]-prs.Lower[3], prs.Upper[0]-prs.Lower[0])
+}
+
+func TestProject_ClampsAtZero(t *testing.T) {
+	t.Parallel()
+
+	result := forecaster(config.ForecastLinear).Project(lastWeek,
+		Series{Name: "Commits", Data: []float64{30, 20, 10}},
+	)
+	require.NotNil(t, result)
+
+	commits := result.Series[0]
+	assert.Equal(t, []float64{0, 0, 0, 0}, commits.Data)
+	assert.Equal(t, 0.0, commits.Total)
+}
+
+func TestProject_HoltWinters(t *testing.T) {
+	t.Parallel()
+
+	// Three seasons of a repeating monthly pattern
+	pattern := []float64{10, 20, 30, 20}
+	var data []float64
+	for range 3 {
+		data = append(data, pattern...)
+	}
+
+	result := forecaster(config.ForecastHoltWinters).Project(lastWeek, Series{Name: "Commits", Data: data})
+	require.NotNil(t, result)
+	assert.Equal(t, "holt_winters", result.Method)
+
+	commits := result.Series[0]
+	for h, want := range pattern {
+		assert.InDelta(t, want, commits.Data[h], 2, "week %d keeps the seasonal shape", h+1)
+	}
+
+	// Shorter than two seasons: Holt's linear method follows the trend
+	result = forecaster(config.ForecastHoltWinters).Project(lastWeek, Series{Name: "PRs", Data: []float64{2, 4, 6, 8}})
+	require.NotNil(t, result)
+	assert.InDelta(t, 10, result.Series[0].Data[0], 0.5)
+	assert.Greater(t, result.Series[0].Data[3], result.Series[0].Data[0])
+}
+
+func TestProject_ShortHistory(t *testing.T) {
+	t.Parallel()
+
+	f := forecaster(config.ForecastLinear)
+	assert.Nil(t, f.Project(lastWeek, Series{Name: "Commits", Data: []float64{1, 2}}))
+
+	result := f.Project(lastWeek,
+		Series{Name: "Commits", Data: []float64{1, 2}},
+		Series{Name: "PRs", Data: []float64{1, 2, 3}},
+	)
+	require.NotNil(t, result)
+	require.Len(t, result.Series, 1, "series that are too short are skipped")
+	assert.Equal(t, "PRs", result.Series[0].Name)
+}
@@ -0,0 +1,109 @@
+package site
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// ShieldsEndpoint is the shields.io endpoint badge schema (schemaVersion 1)
+// See https://shields.io/badges/endpoint-badge
+type ShieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color,omitempty"`
+	NamedLogo     string `json:"namedLogo,omitempty"`
+	CacheSeconds  int    `json:"cacheSeconds,omitempty"`
+}
+
+// badgeCacheSeconds lets shields.io pick up a new run within the hour
+const badgeCacheSeconds = 3600
+
+func newShieldsEndpoint(label, message, color string) ShieldsEndpoint {
+	return ShieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         label,
+		Message:       message,
+		Color:         color,
+		CacheSeconds:  badgeCacheSeconds,
+	}
+}
+
+// generateBadges writes shields.io endpoint JSON files for contributors and repositories.
+// Badges are written to data/badges so that a badge can be embedded with:
+// https://img.shields.io/endpoint?url=<site>/data/badges/contributors/<login>/score.json
+func (g *Generator) generateBadges(dataDir string, metrics *models.GlobalMetrics) error {
+	badgeDir := filepath.Join(dataDir, "badges")
+
+	// Per-contributor badges (score and rank)
+	contributorDir := filepath.Join(badgeDir, "contributors")
+	for _, contributor := range metrics.Contributors {
+		dir := filepath.Join(contributorDir, contributor.Login)
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return err
+		}
+
+		color := percentileColor(contributor.Score.PercentileRank)
+
+		score := newShieldsEndpoint("velocity score", fmt.Sprintf("%d", contributor.Score.Total), color)
+		if err := writeJSON(filepath.Join(dir, "score.json"), score); err != nil {
+			return err
+		}
+
+		rankMessage := "unranked"
+		if contributor.Score.Rank > 0 {
+			rankMessage = fmt.Sprintf("#%d of %d", contributor.Score.Rank, len(metrics.Contributors))
+		}
+		rank := newShieldsEndpoint("velocity rank", rankMessage, color)
+		if err := writeJSON(filepath.Join(dir, "rank.json"), rank); err != nil {
+			return err
+		}
+	}
+
+	// Per-repository badges (total score, commits, contributors)
+	for _, repo := range metrics.Repositories {
+		dir := filepath.Join(badgeDir, "repos", repo.Owner, repo.Name)
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return err
+		}
+
+		var totalScore int
+		for _, c := range repo.Contributors {
+			totalScore += c.Score.Total
+		}
+
+		badges := map[string]ShieldsEndpoint{
+			"score.json":        newShieldsEndpoint("velocity score", fmt.Sprintf("%d", totalScore), "blueviolet"),
+			"commits.json":      newShieldsEndpoint("commits", fmt.Sprintf("%d", repo.TotalCommits), "blue"),
+			"contributors.json": newShieldsEndpoint("active contributors", fmt.Sprintf("%d", repo.ActiveContributors), "blue"),
+		}
+		for name, badge := range badges {
+			if err := writeJSON(filepath.Join(dir, name), badge); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// percentileColor maps a percentile rank (0-100, higher is better) to a shields.io color
+func percentileColor(percentile float64) string {
+	switch {
+	case percentile >= 90:
+		return "brightgreen"
+	case percentile >= 75:
+		return "green"
+	case percentile >= 50:
+		return "yellowgreen"
+	case percentile >= 25:
+		return "yellow"
+	case percentile > 0:
+		return "orange"
+	default:
+		return "lightgrey"
+	}
+}
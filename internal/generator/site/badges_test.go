@@ -0,0 +1,111 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func readBadge(t *testing.T, path string) ShieldsEndpoint {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var badge ShieldsEndpoint
+	require.NoError(t, json.Unmarshal(data, &badge))
+	return badge
+}
+
+func TestGenerator_GenerateBadges(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := config.DefaultConfig()
+	gen, err := NewGenerator(tempDir, cfg)
+	require.NoError(t, err)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{
+				Owner:              "org",
+				Name:               "repo1",
+				TotalCommits:       42,
+				ActiveContributors: 2,
+				Contributors: []models.ContributorMetrics{
+					{Login: "alice", Score: models.Score{Total: 300}},
+					{Login: "bob", Score: models.Score{Total: 120}},
+				},
+			},
+		},
+		Contributors: []models.ContributorMetrics{
+			{Login: "alice", Score: models.Score{Total: 500, Rank: 1, PercentileRank: 100}},
+			{Login: "bob", Score: models.Score{Total: 200, Rank: 2, PercentileRank: 50}},
+		},
+	}
+
+	require.NoError(t, gen.Generate(metrics))
+
+	badgeDir := filepath.Join(tempDir, "data", "badges")
+
+	score := readBadge(t, filepath.Join(badgeDir, "contributors", "alice", "score.json"))
+	assert.Equal(t, 1, score.SchemaVersion)
+	assert.Equal(t, "velocity score", score.Label)
+	assert.Equal(t, "500", score.Message)
+	assert.Equal(t, "brightgreen", score.Color)
+
+	rank := readBadge(t, filepath.Join(badgeDir, "contributors", "bob", "rank.json"))
+	assert.Equal(t, "#2 of 2", rank.Message)
+	assert.Equal(t, "yellowgreen", rank.Color)
+
+	repoScore := readBadge(t, filepath.Join(badgeDir, "repos", "org", "repo1", "score.json"))
+	assert.Equal(t, "420", repoScore.Message)
+
+	commits := readBadge(t, filepath.Join(badgeDir, "repos", "org", "repo1", "commits.json"))
+	assert.Equal(t, "42", commits.Message)
+}
+
+func TestGenerator_BadgesDisabled(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := config.DefaultConfig()
+	cfg.Output.Badges = false
+	gen, err := NewGenerator(tempDir, cfg)
+	require.NoError(t, err)
+
+	metrics := &models.GlobalMetrics{
+		Contributors: []models.ContributorMetrics{{Login: "alice"}},
+	}
+
+	require.NoError(t, gen.Generate(metrics))
+
+	_, err = os.Stat(filepath.Join(tempDir, "data", "badges"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPercentileColor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		percentile float64
+		expected   string
+	}{
+		{100, "brightgreen"},
+		{90, "brightgreen"},
+		{80, "green"},
+		{60, "yellowgreen"},
+		{30, "yellow"},
+		{10, "orange"},
+		{0, "lightgrey"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, percentileColor(tt.percentile))
+	}
+}
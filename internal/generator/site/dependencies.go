@@ -0,0 +1,63 @@
+package site
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// dependencyDOT renders the dependency graph in Graphviz DOT, with the
+// repositories of each team in a cluster and cross-team edges in red
+func dependencyDOT(graph *models.DependencyGraph) []byte {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	teams := make(map[string][]string)
+	var unowned []string
+	for _, n := range graph.Nodes {
+		if n.Team == "" {
+			unowned = append(unowned, n.Repository)
+			continue
+		}
+		teams[n.Team] = append(teams[n.Team], n.Repository)
+	}
+	names := make([]string, 0, len(teams))
+	for name := range teams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(name))
+		for _, repo := range teams[name] {
+			fmt.Fprintf(&b, "    %s;\n", dotQuote(repo))
+		}
+		b.WriteString("  }\n")
+	}
+	for _, repo := range unowned {
+		fmt.Fprintf(&b, "  %s;\n", dotQuote(repo))
+	}
+
+	for _, e := range graph.Edges {
+		packages := make([]string, 0, len(e.Packages))
+		for _, p := range e.Packages {
+			packages = append(packages, p.Name)
+		}
+		attrs := "label=" + dotQuote(strings.Join(packages, "\n"))
+		if e.CrossTeam {
+			attrs += ", color=red, fontcolor=red"
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(e.From), dotQuote(e.To), attrs)
+	}
+	b.WriteString("}\n")
+	return []byte(b.String())
+}
+
+// dotQuote quotes a DOT identifier, keeping non-ASCII characters as they are
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
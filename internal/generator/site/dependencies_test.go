@@ -0,0 +1,69 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestGenerator_Dependencies(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	gen, err := NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+
+	metrics := wallboardMetrics()
+	metrics.Dependencies = &models.DependencyGraph{
+		Nodes: []models.DependencyNode{
+			{Repository: "acme/api", Team: "Web"},
+			{Repository: "acme/proto", Team: "Platform"},
+			{Repository: "acme/tools"},
+		},
+		Edges: []models.DependencyEdge{{
+			From:      "acme/api",
+			To:        "acme/proto",
+			Packages:  []models.Package{{Name: "github.com/acme/proto", Ecosystem: models.EcosystemGo}, {Name: "@acme/proto", Ecosystem: models.EcosystemNPM}},
+			CrossTeam: true,
+		}},
+	}
+	require.NoError(t, gen.Generate(metrics))
+
+	content, err := os.ReadFile(filepath.Join(dir, "data", "dependencies.json"))
+	require.NoError(t, err)
+	var doc models.DependenciesDocument
+	require.NoError(t, json.Unmarshal(content, &doc))
+	assert.Equal(t, models.SchemaVersion, doc.SchemaVersion)
+	assert.Equal(t, metrics.Dependencies, doc.DependencyGraph)
+
+	dot, err := os.ReadFile(filepath.Join(dir, "data", "dependencies.dot"))
+	require.NoError(t, err)
+	assert.Contains(t, string(dot), "subgraph cluster_0 {\n    label=\"Platform\";\n    \"acme/proto\";\n  }")
+	assert.Contains(t, string(dot), "\n  \"acme/tools\";\n")
+	assert.Contains(t, string(dot), `"acme/api" -> "acme/proto" [label="github.com/acme/proto\n@acme/proto", color=red, fontcolor=red];`)
+}
+
+func TestGenerator_NoDependencies(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	gen, err := NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(wallboardMetrics()))
+
+	assert.NoFileExists(t, filepath.Join(dir, "data", "dependencies.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "data", "dependencies.dot"))
+}
+
+func TestDotQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"Zoë's \"team\"\nC:\\"`, dotQuote("Zoë's \"team\"\nC:\\"))
+}
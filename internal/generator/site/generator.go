@@ -3,15 +3,20 @@ package site
 import (
 	"embed"
 	"fmt"
+	"html/template"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	json "github.com/goccy/go-json"
 	"github.com/lukaszraczylo/git-velocity/internal/config"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
+	"github.com/lukaszraczylo/git-velocity/internal/i18n"
+	"github.com/lukaszraczylo/git-velocity/internal/icons"
+	"github.com/lukaszraczylo/git-velocity/internal/search"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
 //go:embed dist/*
@@ -21,16 +26,52 @@ var spaFS embed.FS
 type Generator struct {
 	outputDir string
 	config    *config.Config
+	catalog   *i18n.Catalog
+	icons     icons.Provider
+	run       *models.RunReport
+	bots      []models.BotActivity
+	avatars   AvatarSource
+	now       func() time.Time // Generation time written to global.json
 }
 
 // NewGenerator creates a new site generator
 func NewGenerator(outputDir string, cfg *config.Config) (*Generator, error) {
+	catalog, err := i18n.Load(cfg.Output.Locale)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := icons.Load(cfg.Output.Icons.Set, cfg.Output.Icons.Path)
+	if err != nil {
+		return nil, err
+	}
 	return &Generator{
 		outputDir: outputDir,
 		config:    cfg,
+		catalog:   catalog,
+		icons:     provider,
+		now:       generationTime,
 	}, nil
 }
 
+// generationTime returns SOURCE_DATE_EPOCH when set, so that identical input
+// generates byte-identical output, or else the current time
+func generationTime() time.Time {
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		return time.Unix(epoch, 0).UTC()
+	}
+	return time.Now()
+}
+
+// SetBots sets the identities filtered as bots, written to data/bots.json
+func (g *Generator) SetBots(bots []models.BotActivity) {
+	g.bots = bots
+}
+
+// SetRunReport sets the report written to data/run.json
+func (g *Generator) SetRunReport(r *models.RunReport) {
+	g.run = r
+}
+
 // Generate creates the static site from metrics
 func (g *Generator) Generate(metrics *models.GlobalMetrics) error {
 	// Create output directory
@@ -38,17 +79,76 @@ func (g *Generator) Generate(metrics *models.GlobalMetrics) error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	// Contributors who opted out are left out of everything published
+	metrics, optedOut := publicMetrics(metrics)
+
+	// The wallboard highlights and share cards show achievements earned since
+	// the data being replaced
+	var previous *models.GlobalMetrics
+	if g.config.Output.Wallboard || g.config.Output.ShareCards {
+		previous = g.loadPreviousRun()
+	}
+
+	// Lifetime achievements are carried over from the data being replaced
+	var ledger *models.HallOfFame
+	if g.config.Output.HallOfFame {
+		var err error
+		if ledger, err = g.loadHallOfFame(); err != nil {
+			return fmt.Errorf("failed to load hall of fame: %w", err)
+		}
+	}
+
 	// Generate data files
 	if err := g.generateDataFiles(metrics); err != nil {
 		return fmt.Errorf("failed to generate data files: %w", err)
 	}
 
+	if ledger != nil {
+		ledger.Forget(optedOut)
+		if err := g.generateHallOfFame(metrics, ledger); err != nil {
+			return fmt.Errorf("failed to generate hall of fame: %w", err)
+		}
+	}
+
 	// Copy Vue SPA files
 	if err := g.copySPAFiles(); err != nil {
 		return fmt.Errorf("failed to copy SPA files: %w", err)
 	}
+	if g.config.Output.OfflineAssets {
+		if err := g.writeOfflineStylesheet(); err != nil {
+			return fmt.Errorf("failed to write offline stylesheet: %w", err)
+		}
+	}
 
-	return nil
+	if err := g.copyIconFiles(); err != nil {
+		return fmt.Errorf("failed to copy icons: %w", err)
+	}
+
+	if err := g.generateTables(metrics); err != nil {
+		return fmt.Errorf("failed to generate data tables: %w", err)
+	}
+
+	if metrics.ReleaseNotes != nil {
+		if err := os.WriteFile(filepath.Join(g.outputDir, releaseNotesFile), releaseNotesMarkdown(metrics.ReleaseNotes, metrics.Period), 0600); err != nil {
+			return fmt.Errorf("failed to write release notes: %w", err)
+		}
+	}
+
+	if g.config.Output.Wallboard {
+		if err := g.generateWallboard(metrics, previous); err != nil {
+			return fmt.Errorf("failed to generate wallboard: %w", err)
+		}
+	}
+
+	if err := g.generateShareCards(metrics, previous); err != nil {
+		return fmt.Errorf("failed to generate share cards: %w", err)
+	}
+
+	if err := g.generateSharing(metrics); err != nil {
+		return fmt.Errorf("failed to generate link previews: %w", err)
+	}
+
+	return g.applySecurity()
 }
 
 func (g *Generator) generateDataFiles(metrics *models.GlobalMetrics) error {
@@ -63,32 +163,24 @@ func (g *Generator) generateDataFiles(metrics *models.GlobalMetrics) error {
 		return err
 	}
 
-	// Prepare global data with timestamp
-	globalData := struct {
-		*models.GlobalMetrics
-		GeneratedAt time.Time `json:"generated_at"`
-	}{
-		GlobalMetrics: metrics,
-		GeneratedAt:   time.Now(),
-	}
-
-	// Global metrics
-	if err := writeJSON(filepath.Join(dataDir, "global.json"), globalData); err != nil {
+	// Global metrics (with generation timestamp)
+	if err := writeJSON(filepath.Join(dataDir, "global.json"), models.NewGlobalDocument(metrics, g.now())); err != nil {
 		return err
 	}
 
-	// Leaderboard
-	if err := writeJSON(filepath.Join(dataDir, "leaderboard.json"), metrics.Leaderboard); err != nil {
+	// Leaderboard, its pages and its top entries
+	if err := generateLeaderboard(dataDir, metrics.Leaderboard, g.config.Output.Leaderboard); err != nil {
 		return err
 	}
 
 	// Per-repository data
-	for _, repo := range metrics.Repositories {
+	for i := range metrics.Repositories {
+		repo := &metrics.Repositories[i]
 		repoDir := filepath.Join(dataDir, "repos", repo.Owner, repo.Name)
 		if err := os.MkdirAll(repoDir, 0750); err != nil {
 			return err
 		}
-		if err := writeJSON(filepath.Join(repoDir, "metrics.json"), repo); err != nil {
+		if err := writeJSON(filepath.Join(repoDir, "metrics.json"), models.NewRepositoryDocument(repo)); err != nil {
 			return err
 		}
 	}
@@ -99,8 +191,23 @@ func (g *Generator) generateDataFiles(metrics *models.GlobalMetrics) error {
 		if err := os.MkdirAll(teamDir, 0750); err != nil {
 			return err
 		}
-		for _, team := range metrics.Teams {
-			if err := writeJSON(filepath.Join(teamDir, slugify(team.Name)+".json"), team); err != nil {
+		for i := range metrics.Teams {
+			team := &metrics.Teams[i]
+			if err := writeJSON(filepath.Join(teamDir, slugify(team.Name)+".json"), models.NewTeamDocument(team)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Per-group data
+	if len(metrics.Groups) > 0 {
+		groupDir := filepath.Join(dataDir, "groups")
+		if err := os.MkdirAll(groupDir, 0750); err != nil {
+			return err
+		}
+		for i := range metrics.Groups {
+			group := &metrics.Groups[i]
+			if err := writeJSON(filepath.Join(groupDir, slugify(group.Name)+".json"), models.NewGroupDocument(group)); err != nil {
 				return err
 			}
 		}
@@ -112,10 +219,86 @@ func (g *Generator) generateDataFiles(metrics *models.GlobalMetrics) error {
 		return err
 	}
 
-	for _, contributor := range metrics.Contributors {
-		if err := writeJSON(filepath.Join(contributorDir, contributor.Login+".json"), contributor); err != nil {
+	for i := range metrics.Contributors {
+		contributor := &metrics.Contributors[i]
+		if err := writeJSON(filepath.Join(contributorDir, contributor.Login+".json"), models.NewContributorDocument(contributor)); err != nil {
+			return err
+		}
+		// How the score was calculated, when scoring is enabled
+		if g.config.Scoring.Enabled {
+			dir := filepath.Join(contributorDir, contributor.Login)
+			if err := os.MkdirAll(dir, 0750); err != nil {
+				return err
+			}
+			if err := writeJSON(filepath.Join(dir, "score.json"), models.NewScoreAuditDocument(contributor, metrics.Normalization)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// How this run collected its data (API usage, retries, rate limits)
+	if g.run != nil {
+		if err := writeJSON(filepath.Join(dataDir, "run.json"), models.NewRunDocument(g.run)); err != nil {
+			return err
+		}
+	}
+
+	// Identities filtered as bots, to check patterns don't catch humans
+	if err := writeJSON(filepath.Join(dataDir, "bots.json"), models.NewBotsDocument(g.bots)); err != nil {
+		return err
+	}
+
+	// Most churned files per repository
+	if err := writeJSON(filepath.Join(dataDir, "hotspots.json"), models.NewHotspotsDocument(metrics.Repositories)); err != nil {
+		return err
+	}
+
+	// Repositories requiring each other's packages, when manifests were read
+	if metrics.Dependencies != nil {
+		if err := writeJSON(filepath.Join(dataDir, "dependencies.json"), models.NewDependenciesDocument(metrics.Dependencies)); err != nil {
 			return err
 		}
+		if err := os.WriteFile(filepath.Join(dataDir, "dependencies.dot"), dependencyDOT(metrics.Dependencies), 0600); err != nil {
+			return err
+		}
+	}
+
+	// When reviews happen and how long they take, by weekday and hour
+	if metrics.ReviewLatency != nil {
+		if err := writeJSON(filepath.Join(dataDir, "review-latency.json"), models.NewReviewLatencyDocument(metrics)); err != nil {
+			return err
+		}
+	}
+
+	// Prebuilt index for the dashboard's search and filters
+	if err := writeJSON(filepath.Join(dataDir, "search.json"), models.NewSearchDocument(search.Build(metrics))); err != nil {
+		return err
+	}
+
+	// Dashboard strings, achievement texts and formats of output.locale
+	if err := writeJSON(filepath.Join(dataDir, "locale.json"), g.catalog); err != nil {
+		return err
+	}
+
+	// Achievement icons of output.icons, by achievement ID
+	achievementIcons := make(map[string]icons.Icon)
+	for _, a := range g.config.Scoring.GetAchievements() {
+		achievementIcons[a.ID] = g.icons.Icon(a.ID, a.Icon)
+	}
+	if err := writeJSON(filepath.Join(dataDir, "icons.json"), achievementIcons); err != nil {
+		return err
+	}
+
+	// JSON Schemas describing every document above
+	if err := writeSchemas(filepath.Join(dataDir, "schema")); err != nil {
+		return fmt.Errorf("failed to generate JSON schemas: %w", err)
+	}
+
+	// Shields.io endpoint badges
+	if g.config.Output.Badges {
+		if err := g.generateBadges(dataDir, metrics); err != nil {
+			return fmt.Errorf("failed to generate badges: %w", err)
+		}
 	}
 
 	return nil
@@ -145,14 +328,81 @@ func (g *Generator) copySPAFiles() error {
 		if err != nil {
 			return fmt.Errorf("failed to read embedded file %s: %w", path, err)
 		}
+		if relPath == "index.html" && g.config.Output.OfflineAssets {
+			content = withoutCDN(content)
+		}
 
 		// Write to destination
 		return os.WriteFile(destPath, content, 0600)
 	})
 }
 
+// copyIconFiles copies the files of an SVG icon pack into the site,
+// replacing the icons of a previous run
+func (g *Generator) copyIconFiles() error {
+	files := g.icons.Files()
+	if err := os.RemoveAll(filepath.Join(g.outputDir, "icons")); err != nil {
+		return err
+	}
+	for rel, src := range files {
+		content, err := os.ReadFile(filepath.Clean(src)) // #nosec G304 -- src is a file of the configured icon pack
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(g.outputDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, content, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Helper functions
 
+// writeSchemas writes one JSON Schema file per generated document type
+func writeSchemas(dir string) error {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	for name, doc := range models.Documents() {
+		schema := models.GenerateJSONSchema("git-velocity "+name, doc)
+		if err := writeJSON(filepath.Join(dir, models.SchemaFileName(name)), schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateFuncs returns the functions shared by the HTML templates, which
+// translate and format values in the configured locale
+func (g *Generator) templateFuncs() template.FuncMap {
+	c := g.catalog
+	number := func(v any) string {
+		switch n := v.(type) {
+		case int:
+			return c.FormatNumber(float64(n), 0)
+		case float64:
+			return c.FormatNumber(n, 0)
+		}
+		return fmt.Sprint(v)
+	}
+	return template.FuncMap{
+		"t":       c.T,
+		"number":  number,
+		"decimal": func(v float64) string { return c.FormatNumber(v, 1) },
+		"signed": func(v float64) string {
+			if v > 0 {
+				return "+" + c.FormatNumber(v, 0)
+			}
+			return c.FormatNumber(v, 0)
+		},
+		"slug": slugify,
+	}
+}
+
 func writeJSON(path string, data interface{}) error {
 	cleanPath := filepath.Clean(path)
 	file, err := os.OpenFile(cleanPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) // #nosec G304 -- path is constructed internally
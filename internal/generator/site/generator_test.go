@@ -3,6 +3,7 @@ package site
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,7 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/lukaszraczylo/git-velocity/internal/config"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
+	"github.com/lukaszraczylo/git-velocity/internal/icons"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
 func TestNewGenerator(t *testing.T) {
@@ -114,6 +116,50 @@ func TestGenerator_GenerateGlobalJSON(t *testing.T) {
 	assert.False(t, result.GeneratedAt.IsZero())
 }
 
+func TestGenerator_Reproducible(t *testing.T) {
+	t.Parallel()
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{{Owner: "org", Name: "api", FullName: "org/api", TotalCommits: 3}},
+		Contributors: []models.ContributorMetrics{{Login: "alex", CommitCount: 3, RepositoriesContributed: []string{"org/api"}}},
+		Leaderboard:  []models.LeaderboardEntry{{Rank: 1, Login: "alex", Score: 30}},
+	}
+
+	generate := func() map[string][]byte {
+		dir := t.TempDir()
+		gen, err := NewGenerator(dir, config.DefaultConfig())
+		require.NoError(t, err)
+		gen.now = func() time.Time { return time.Unix(1700000000, 0).UTC() }
+		require.NoError(t, gen.Generate(metrics))
+
+		files := make(map[string][]byte)
+		require.NoError(t, filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := os.ReadFile(path) // #nosec G304 -- test output
+			files[strings.TrimPrefix(path, dir)] = data
+			return err
+		}))
+		return files
+	}
+
+	first, second := generate(), generate()
+	require.NotEmpty(t, first)
+	for path, data := range first {
+		assert.Equal(t, string(data), string(second[path]), path)
+	}
+	assert.Len(t, second, len(first))
+}
+
+func TestGenerationTime(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), generationTime())
+
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	assert.WithinDuration(t, time.Now(), generationTime(), time.Minute)
+}
+
 func TestGenerator_GenerateLeaderboardJSON(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -137,15 +183,160 @@ func TestGenerator_GenerateLeaderboardJSON(t *testing.T) {
 	data, err := os.ReadFile(leaderboardPath)
 	require.NoError(t, err)
 
-	var result []models.LeaderboardEntry
+	var result models.LeaderboardDocument
 	err = json.Unmarshal(data, &result)
 	require.NoError(t, err)
 
-	require.Len(t, result, 3)
-	assert.Equal(t, "user1", result[0].Login)
-	assert.Equal(t, 1000, result[0].Score)
-	assert.Equal(t, "user2", result[1].Login)
-	assert.Equal(t, 800, result[1].Score)
+	assert.Equal(t, models.SchemaVersion, result.SchemaVersion)
+	require.Len(t, result.Leaderboard, 3)
+	assert.Equal(t, "user1", result.Leaderboard[0].Login)
+	assert.Equal(t, 1000, result.Leaderboard[0].Score)
+	assert.Equal(t, "user2", result.Leaderboard[1].Login)
+	assert.Equal(t, 800, result.Leaderboard[1].Score)
+}
+
+func TestGenerator_SchemaVersionAndSchemas(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := config.DefaultConfig()
+	gen, err := NewGenerator(tempDir, cfg)
+	require.NoError(t, err)
+
+	metrics := &models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{{Owner: "org", Name: "repo"}},
+		Contributors: []models.ContributorMetrics{{Login: "alice"}},
+		Teams:        []models.TeamMetrics{{Name: "Core"}},
+	}
+	require.NoError(t, gen.Generate(metrics))
+
+	// Every data document carries the schema version
+	for _, path := range []string{
+		filepath.Join("data", "global.json"),
+		filepath.Join("data", "leaderboard.json"),
+		filepath.Join("data", "repos", "org", "repo", "metrics.json"),
+		filepath.Join("data", "teams", "core.json"),
+		filepath.Join("data", "contributors", "alice.json"),
+		filepath.Join("data", "search.json"),
+		filepath.Join("data", "hotspots.json"),
+	} {
+		data, err := os.ReadFile(filepath.Join(tempDir, path))
+		require.NoError(t, err, path)
+
+		var doc struct {
+			SchemaVersion int `json:"schema_version"`
+		}
+		require.NoError(t, json.Unmarshal(data, &doc), path)
+		assert.Equal(t, models.SchemaVersion, doc.SchemaVersion, path)
+	}
+
+	// A JSON Schema is published for every document type
+	for name := range models.Documents() {
+		data, err := os.ReadFile(filepath.Join(tempDir, "data", "schema", models.SchemaFileName(name)))
+		require.NoError(t, err, name)
+
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &schema), name)
+		assert.Equal(t, models.JSONSchemaDraft, schema["$schema"], name)
+		assert.Contains(t, schema["properties"], "schema_version", name)
+	}
+}
+
+func TestGenerator_GenerateRunJSON(t *testing.T) {
+	tempDir := t.TempDir()
+
+	gen, err := NewGenerator(tempDir, config.DefaultConfig())
+	require.NoError(t, err)
+	gen.SetRunReport(&models.RunReport{
+		StartedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Duration:  42.5,
+		API: &models.APIUsage{
+			RESTCalls:    10,
+			GraphQLCalls: 3,
+			CacheHits:    7,
+			RateLimits:   []models.RateLimitStatus{{Resource: "core", Limit: 5000, Remaining: 4990}},
+		},
+	})
+	require.NoError(t, gen.Generate(&models.GlobalMetrics{}))
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "data", "run.json"))
+	require.NoError(t, err)
+
+	var doc models.RunDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, models.SchemaVersion, doc.SchemaVersion)
+	assert.InDelta(t, 42.5, doc.Duration, 0.001)
+	require.NotNil(t, doc.API)
+	assert.Equal(t, 13, doc.API.Calls())
+	assert.Equal(t, int64(7), doc.API.CacheHits)
+	assert.Equal(t, 4990, doc.API.RateLimits[0].Remaining)
+}
+
+func TestGenerator_GenerateHotspotsJSON(t *testing.T) {
+	tempDir := t.TempDir()
+
+	gen, err := NewGenerator(tempDir, config.DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(&models.GlobalMetrics{
+		Repositories: []models.RepositoryMetrics{
+			{Owner: "org", Name: "quiet", FullName: "org/quiet"},
+			{Owner: "org", Name: "busy", FullName: "org/busy", Hotspots: []models.FileHotspot{
+				{Path: "main.go", Changes: 3, Additions: 10, Deletions: 2, Churn: 36, Authors: 2},
+			}},
+		},
+	}))
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "data", "hotspots.json"))
+	require.NoError(t, err)
+
+	var doc models.HotspotsDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Len(t, doc.Repositories, 1, "repositories without hotspots are left out")
+	assert.Equal(t, "org/busy", doc.Repositories[0].Repository)
+	assert.Equal(t, 36, doc.Repositories[0].Files[0].Churn)
+}
+
+func TestGenerator_GenerateIconsJSON(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	gen, err := NewGenerator(tempDir, config.DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(&models.GlobalMetrics{}))
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "data", "icons.json"))
+	require.NoError(t, err)
+	var achievementIcons map[string]icons.Icon
+	require.NoError(t, json.Unmarshal(data, &achievementIcons))
+	assert.Equal(t, icons.Icon{Class: "fa-baby"}, achievementIcons["commit-1"], "FontAwesome by default")
+	assert.NoDirExists(t, filepath.Join(tempDir, "icons"))
+}
+
+func TestGenerator_SVGIconPack(t *testing.T) {
+	t.Parallel()
+
+	pack := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(pack, "commit-1.svg"), []byte("<svg/>"), 0600))
+
+	tempDir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.Icons = config.IconsConfig{Set: icons.SVG, Path: pack}
+	cfg.Output.Wallboard = true
+	gen, err := NewGenerator(tempDir, cfg)
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1", "commit-10")))
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "data", "icons.json"))
+	require.NoError(t, err)
+	var achievementIcons map[string]icons.Icon
+	require.NoError(t, json.Unmarshal(data, &achievementIcons))
+	assert.Equal(t, icons.Icon{SVG: "icons/commit-1.svg"}, achievementIcons["commit-1"])
+	assert.Equal(t, icons.Icon{Emoji: "🌱"}, achievementIcons["commit-10"], "missing icons fall back to emoji")
+	assert.FileExists(t, filepath.Join(tempDir, "icons", "commit-1.svg"))
+
+	wallboard, err := os.ReadFile(filepath.Join(tempDir, "wallboard.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(wallboard), `<img src="icons/commit-1.svg" alt="">`)
+	assert.Contains(t, string(wallboard), `<span class="emoji">🌱</span>`)
 }
 
 func TestGenerator_GenerateRepositoryJSON(t *testing.T) {
@@ -247,6 +438,36 @@ func TestGenerator_GenerateTeamJSON(t *testing.T) {
 	assert.Len(t, result.Members, 2)
 }
 
+func TestGenerator_GenerateGroupJSON(t *testing.T) {
+	tempDir := t.TempDir()
+
+	gen, err := NewGenerator(tempDir, config.DefaultConfig())
+	require.NoError(t, err)
+
+	metrics := &models.GlobalMetrics{
+		Groups: []models.GroupMetrics{
+			{
+				Name:         "Payments Platform",
+				Repositories: []string{"acme/ledger"},
+				TotalScore:   300,
+				Leaderboard:  []models.LeaderboardEntry{{Rank: 1, Login: "user1", Score: 300}},
+			},
+		},
+	}
+	require.NoError(t, gen.Generate(metrics))
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "data", "groups", "payments-platform.json"))
+	require.NoError(t, err)
+
+	var result models.GroupDocument
+	require.NoError(t, json.Unmarshal(data, &result))
+	assert.Equal(t, models.SchemaVersion, result.SchemaVersion)
+	assert.Equal(t, "Payments Platform", result.Name)
+	assert.Equal(t, []string{"acme/ledger"}, result.Repositories)
+	require.Len(t, result.Leaderboard, 1)
+	assert.Equal(t, "user1", result.Leaderboard[0].Login)
+}
+
 func TestGenerator_GenerateContributorJSON(t *testing.T) {
 	tempDir := t.TempDir()
 
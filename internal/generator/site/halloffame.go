@@ -0,0 +1,55 @@
+package site
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// hallOfFameFile is the lifetime achievement ledger, relative to the output directory
+var hallOfFameFile = filepath.Join("data", "hall-of-fame.json")
+
+// loadHallOfFame reads the achievement ledger of the previous runs from the
+// output directory, before the data files are replaced. The ledger is empty
+// on the first run; an unreadable one is an error rather than being reset.
+func (g *Generator) loadHallOfFame() (*models.HallOfFame, error) {
+	path := filepath.Join(g.outputDir, hallOfFameFile)
+	content, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return &models.HallOfFame{Contributors: []models.HallOfFameEntry{}, Records: []models.Record{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var doc models.HallOfFameDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if doc.SchemaVersion > models.SchemaVersion {
+		return nil, fmt.Errorf("%s uses schema version %d, newer than supported version %d", path, doc.SchemaVersion, models.SchemaVersion)
+	}
+	if doc.HallOfFame == nil {
+		doc.HallOfFame = &models.HallOfFame{}
+	}
+	// Ledgers written before records were kept have none
+	if doc.Contributors == nil {
+		doc.Contributors = []models.HallOfFameEntry{}
+	}
+	if doc.Records == nil {
+		doc.Records = []models.Record{}
+	}
+	return doc.HallOfFame, nil
+}
+
+// generateHallOfFame adds the achievements and records of this run to the
+// ledger and writes it back
+func (g *Generator) generateHallOfFame(metrics *models.GlobalMetrics, ledger *models.HallOfFame) error {
+	ledger.Record(metrics.Contributors, metrics.Period.Label, g.now())
+	ledger.RecordBests(metrics.Contributors, metrics.Period.Label, g.now())
+	return writeJSON(filepath.Join(g.outputDir, hallOfFameFile), models.NewHallOfFameDocument(ledger))
+}
@@ -0,0 +1,144 @@
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func readHallOfFame(t *testing.T, dir string) models.HallOfFameDocument {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, "data", "hall-of-fame.json"))
+	require.NoError(t, err)
+	var doc models.HallOfFameDocument
+	require.NoError(t, json.Unmarshal(content, &doc))
+	return doc
+}
+
+func TestGenerator_HallOfFame(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.HallOfFame = true
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+
+	first := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)
+	gen.now = func() time.Time { return first }
+	sprint1 := wallboardMetrics("commit-1", "commit-10")
+	sprint1.Period.Label = "Sprint 1"
+	require.NoError(t, gen.Generate(sprint1))
+
+	// The next sprint's dashboard only holds its own achievements
+	second := first.AddDate(0, 0, 14)
+	gen.now = func() time.Time { return second }
+	sprint2 := wallboardMetrics("commit-1", "pr-1")
+	sprint2.Period.Label = "Sprint 2"
+	require.NoError(t, gen.Generate(sprint2))
+
+	doc := readHallOfFame(t, dir)
+	assert.Equal(t, models.SchemaVersion, doc.SchemaVersion)
+	assert.True(t, second.Equal(doc.UpdatedAt))
+	require.Len(t, doc.Contributors, 2)
+
+	alice := doc.Contributors[0]
+	assert.Equal(t, "alice", alice.Login)
+	assert.Equal(t, "https://example.com/alice.png", alice.AvatarURL)
+	require.Len(t, alice.Achievements, 3)
+	assert.Equal(t, models.EarnedAchievement{ID: "commit-1", EarnedAt: first, Period: "Sprint 1"}, alice.Achievements[0])
+	assert.Equal(t, "commit-10", alice.Achievements[1].ID, "kept although not earned again")
+	assert.Equal(t, models.EarnedAchievement{ID: "pr-1", EarnedAt: second, Period: "Sprint 2"}, alice.Achievements[2])
+
+	assert.Equal(t, "bob", doc.Contributors[1].Login)
+	assert.Len(t, doc.Contributors[1].Achievements, 1)
+}
+
+func TestGenerator_HallOfFameRecords(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.HallOfFame = true
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+
+	week := func(day int, score float64) *models.BestWeek {
+		return &models.BestWeek{Start: time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC), Score: score}
+	}
+	run := func(label string, alice, bob models.PersonalBests, aliceStreak, bobStreak int) *models.GlobalMetrics {
+		m := wallboardMetrics()
+		m.Period.Label = label
+		m.Contributors[0].Bests, m.Contributors[0].LongestStreak = &alice, aliceStreak
+		m.Contributors[1].Bests, m.Contributors[1].LongestStreak = &bob, bobStreak
+		return m
+	}
+
+	first := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	gen.now = func() time.Time { return first }
+	require.NoError(t, gen.Generate(run("January",
+		models.PersonalBests{BestWeek: week(8, 300), FastestReviewMonth: &models.ReviewMonth{Month: "2024-01", AvgReviewTime: 3}},
+		models.PersonalBests{BestWeek: week(15, 200), FastestReviewMonth: &models.ReviewMonth{Month: "2024-01", AvgReviewTime: 1.5}},
+		5, 9,
+	)))
+
+	// Bob beats Alice's week; Alice only equals Bob's streak, which he keeps
+	second := first.AddDate(0, 1, 0)
+	gen.now = func() time.Time { return second }
+	require.NoError(t, gen.Generate(run("February",
+		models.PersonalBests{BestWeek: week(5, 100), FastestReviewMonth: &models.ReviewMonth{Month: "2024-02", AvgReviewTime: 2}},
+		models.PersonalBests{BestWeek: week(12, 450)},
+		9, 2,
+	)))
+
+	doc := readHallOfFame(t, dir)
+	require.Len(t, doc.Records, 3)
+
+	assert.Equal(t, models.Record{
+		Type: models.RecordBiggestWeek, Login: "bob", Value: 450, When: "2024-01-12", Period: "February", SetAt: second,
+	}, doc.Records[0])
+	assert.Equal(t, models.RecordLongestStreak, doc.Records[1].Type)
+	assert.Equal(t, "bob", doc.Records[1].Login)
+	assert.InDelta(t, 9.0, doc.Records[1].Value, 0.001)
+	assert.Equal(t, "January", doc.Records[1].Period)
+	assert.Equal(t, models.RecordFastestReviewMonth, doc.Records[2].Type)
+	assert.Equal(t, "bob", doc.Records[2].Login)
+	assert.Equal(t, "2024-01", doc.Records[2].When)
+}
+
+func TestGenerator_HallOfFameDisabled(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	gen, err := NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1")))
+
+	assert.NoFileExists(t, filepath.Join(dir, "data", "hall-of-fame.json"))
+}
+
+func TestGenerator_HallOfFameUnreadable(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "data"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data", "hall-of-fame.json"), []byte("{"), 0600))
+
+	cfg := config.DefaultConfig()
+	cfg.Output.HallOfFame = true
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+
+	// The ledger is not reset over a bad file
+	err = gen.Generate(wallboardMetrics("commit-1"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hall of fame")
+}
@@ -0,0 +1,79 @@
+package site
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// generateLeaderboard writes data/leaderboard.json, the pages of the
+// leaderboard under data/leaderboard/ when a page size is set, and the top
+// entries in data/leaderboard-top.json. Entries scoring under min_score are
+// left out of all of them.
+func generateLeaderboard(dataDir string, entries []models.LeaderboardEntry, cfg config.LeaderboardOutputConfig) error {
+	if cfg.MinScore != 0 {
+		included := make([]models.LeaderboardEntry, 0, len(entries))
+		for _, e := range entries {
+			if e.Score >= cfg.MinScore {
+				included = append(included, e)
+			}
+		}
+		entries = included
+	}
+
+	if err := writeJSON(filepath.Join(dataDir, "leaderboard-top.json"), models.NewLeaderboardSummaryDocument(entries, cfg.Top)); err != nil {
+		return err
+	}
+
+	pages := leaderboardPages(entries, cfg.PageSize)
+	// The first page keeps leaderboard.json in place for existing consumers
+	if err := writeJSON(filepath.Join(dataDir, "leaderboard.json"), pages[0]); err != nil {
+		return err
+	}
+	if len(pages) == 1 && pages[0].Pagination == nil {
+		return nil
+	}
+	dir := filepath.Join(dataDir, "leaderboard")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	for _, page := range pages {
+		if err := writeJSON(filepath.Join(dataDir, leaderboardPagePath(page.Pagination.Page)), page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leaderboardPages splits leaderboard entries into pages of pageSize
+// entries, or returns one document of them all when pageSize is 0. There is
+// always at least one page, so an empty leaderboard has an empty first page.
+func leaderboardPages(entries []models.LeaderboardEntry, pageSize int) []models.LeaderboardDocument {
+	if pageSize <= 0 {
+		return []models.LeaderboardDocument{models.NewLeaderboardDocument(entries)}
+	}
+	count := max((len(entries)+pageSize-1)/pageSize, 1)
+	pages := make([]models.LeaderboardDocument, count)
+	for i := range pages {
+		start := min(i*pageSize, len(entries))
+		end := min(start+pageSize, len(entries))
+		p := &models.LeaderboardPagination{Page: i + 1, Pages: count, PageSize: pageSize, Total: len(entries)}
+		if i > 0 {
+			p.Prev = leaderboardPagePath(i)
+		}
+		if i+1 < count {
+			p.Next = leaderboardPagePath(i + 2)
+		}
+		pages[i] = models.NewLeaderboardDocument(entries[start:end])
+		pages[i].Pagination = p
+	}
+	return pages
+}
+
+// leaderboardPagePath returns the path of a leaderboard page relative to data/
+func leaderboardPagePath(page int) string {
+	return fmt.Sprintf("leaderboard/page-%d.json", page)
+}
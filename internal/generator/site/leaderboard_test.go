@@ -0,0 +1,109 @@
+package site
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func leaderboardEntries(n int) []models.LeaderboardEntry {
+	entries := make([]models.LeaderboardEntry, n)
+	for i := range entries {
+		entries[i] = models.LeaderboardEntry{Rank: i + 1, Login: fmt.Sprintf("user%d", i+1), Score: (n - i) * 10}
+	}
+	return entries
+}
+
+func readJSONFile[T any](t *testing.T, path string) T {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var v T
+	require.NoError(t, json.Unmarshal(data, &v))
+	return v
+}
+
+func TestLeaderboardPages(t *testing.T) {
+	t.Parallel()
+
+	pages := leaderboardPages(leaderboardEntries(7), 3)
+	require.Len(t, pages, 3)
+	assert.Equal(t, []string{"user1", "user2", "user3"}, logins(pages[0].Leaderboard))
+	assert.Equal(t, []string{"user7"}, logins(pages[2].Leaderboard))
+	assert.Equal(t, &models.LeaderboardPagination{
+		Page: 2, Pages: 3, PageSize: 3, Total: 7,
+		Prev: "leaderboard/page-1.json", Next: "leaderboard/page-3.json",
+	}, pages[1].Pagination)
+	assert.Empty(t, pages[0].Pagination.Prev)
+	assert.Empty(t, pages[2].Pagination.Next)
+
+	// Without a page size there is one unpaginated document
+	pages = leaderboardPages(leaderboardEntries(7), 0)
+	require.Len(t, pages, 1)
+	assert.Len(t, pages[0].Leaderboard, 7)
+	assert.Nil(t, pages[0].Pagination)
+
+	// An empty leaderboard still has a first page
+	pages = leaderboardPages(nil, 3)
+	require.Len(t, pages, 1)
+	assert.Equal(t, 1, pages[0].Pagination.Pages)
+	assert.Empty(t, pages[0].Leaderboard)
+}
+
+func logins(entries []models.LeaderboardEntry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.Login
+	}
+	return out
+}
+
+func TestGenerateLeaderboard(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.LeaderboardOutputConfig{PageSize: 2, Top: 3, MinScore: 30}
+	require.NoError(t, generateLeaderboard(dir, leaderboardEntries(6), cfg))
+
+	// user5 and user6 score under 30
+	top := readJSONFile[models.LeaderboardSummaryDocument](t, filepath.Join(dir, "leaderboard-top.json"))
+	assert.Equal(t, models.SchemaVersion, top.SchemaVersion)
+	assert.Equal(t, 4, top.Total)
+	assert.Equal(t, []string{"user1", "user2", "user3"}, logins(top.Leaderboard))
+
+	first := readJSONFile[models.LeaderboardDocument](t, filepath.Join(dir, "leaderboard.json"))
+	assert.Equal(t, []string{"user1", "user2"}, logins(first.Leaderboard))
+	require.NotNil(t, first.Pagination)
+	assert.Equal(t, 2, first.Pagination.Pages)
+
+	last := readJSONFile[models.LeaderboardDocument](t, filepath.Join(dir, first.Pagination.Next))
+	assert.Equal(t, []string{"user3", "user4"}, logins(last.Leaderboard))
+	assert.Equal(t, "leaderboard/page-1.json", last.Pagination.Prev)
+	assert.FileExists(t, filepath.Join(dir, "leaderboard", "page-1.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "leaderboard", "page-3.json"))
+}
+
+func TestGenerateLeaderboard_SingleFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, generateLeaderboard(dir, leaderboardEntries(12), config.DefaultConfig().Output.Leaderboard))
+
+	doc := readJSONFile[models.LeaderboardDocument](t, filepath.Join(dir, "leaderboard.json"))
+	assert.Len(t, doc.Leaderboard, 12)
+	assert.Nil(t, doc.Pagination)
+	assert.NoDirExists(t, filepath.Join(dir, "leaderboard"))
+
+	top := readJSONFile[models.LeaderboardSummaryDocument](t, filepath.Join(dir, "leaderboard-top.json"))
+	assert.Equal(t, 12, top.Total)
+	assert.Len(t, top.Leaderboard, 10)
+}
@@ -0,0 +1,35 @@
+package site
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/lukaszraczylo/git-velocity/internal/icons"
+)
+
+// offlineStylesheet is the path of the stylesheet replacing the CDN assets
+// with output.offline_assets, relative to the site root
+const offlineStylesheet = "assets/offline-icons.css"
+
+// cdnLink matches the <link> tags of the dashboard loading fonts and icons
+// from a CDN (and preconnecting to them), with the line they stand on
+var cdnLink = regexp.MustCompile(`(?m)^[ \t]*<link [^>]*href="https://[^"]*"[^>]*>\r?\n`)
+
+// withoutCDN replaces the CDN links of index.html with the local stylesheet.
+// The dashboard's CSS falls back to system fonts without the web fonts.
+func withoutCDN(html []byte) []byte {
+	html = cdnLink.ReplaceAll(html, nil)
+	link := []byte(`    <link rel="stylesheet" href="./` + offlineStylesheet + "\">\n</head>")
+	return bytes.Replace(html, []byte("</head>"), link, 1)
+}
+
+// writeOfflineStylesheet writes the stylesheet drawing icons with emoji
+func (g *Generator) writeOfflineStylesheet() error {
+	path := filepath.Join(g.outputDir, filepath.FromSlash(offlineStylesheet))
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(icons.Stylesheet()), 0600)
+}
@@ -0,0 +1,64 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+func TestGenerator_OfflineAssets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.OfflineAssets = true
+	cfg.Output.Wallboard = true
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1")))
+
+	for _, page := range []string{"index.html", "wallboard.html"} {
+		content, err := os.ReadFile(filepath.Join(dir, page))
+		require.NoError(t, err)
+		html := string(content)
+		assert.NotContains(t, html, "https://", "%s references no CDN", page)
+		assert.Contains(t, html, `assets/offline-icons.css"`, page)
+	}
+
+	css, err := os.ReadFile(filepath.Join(dir, "assets", "offline-icons.css"))
+	require.NoError(t, err)
+	assert.Contains(t, string(css), ".fa-baby::before")
+}
+
+func TestGenerator_CDNAssetsByDefault(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	gen, err := NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(wallboardMetrics()))
+
+	content, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "font-awesome")
+	assert.NoFileExists(t, filepath.Join(dir, "assets", "offline-icons.css"))
+}
+
+func TestWithoutCDN(t *testing.T) {
+	t.Parallel()
+
+	html := "<head>\n" +
+		"    <link rel=\"preconnect\" href=\"https://fonts.gstatic.com\" crossorigin>\n" +
+		"    <link rel=\"stylesheet\" href=\"https://cdn.example/all.min.css\">\n" +
+		"  <link rel=\"stylesheet\" crossorigin href=\"./assets/index.css\">\n" +
+		"</head>\n"
+	assert.Equal(t, "<head>\n"+
+		"  <link rel=\"stylesheet\" crossorigin href=\"./assets/index.css\">\n"+
+		"    <link rel=\"stylesheet\" href=\"./assets/offline-icons.css\">\n"+
+		"</head>\n", string(withoutCDN([]byte(html))))
+}
@@ -0,0 +1,171 @@
+package site
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// previewImage is the path of the social preview image, relative to the site
+// root, at the size link previews crop to
+const (
+	previewImage  = "preview.png"
+	previewWidth  = 1200
+	previewHeight = 630
+)
+
+// previewLeaders is the number of leaderboard entries drawn in the preview
+const previewLeaders = 5
+
+// Colors of the preview, matching the dashboard's dark theme
+var (
+	previewBackground = color.RGBA{0x11, 0x18, 0x27, 0xff} // gray-900
+	previewText       = color.RGBA{0xf9, 0xfa, 0xfb, 0xff} // gray-50
+	previewMuted      = color.RGBA{0x9c, 0xa3, 0xaf, 0xff} // gray-400
+	previewBar        = color.RGBA{0x63, 0x66, 0xf1, 0xff} // indigo-500
+	previewTrack      = color.RGBA{0x1f, 0x29, 0x37, 0xff} // gray-800
+)
+
+// renderPreview draws the top of the leaderboard as a PNG: a bar per
+// contributor, sized by score. Text is drawn with a built-in pixel font, so
+// no font files are needed.
+func renderPreview(leaderboard []models.LeaderboardEntry, period models.Period) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, previewWidth, previewHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{previewBackground}, image.Point{}, draw.Src)
+
+	drawText(img, "GIT VELOCITY", 60, 56, 8, previewText)
+	drawText(img, previewPeriod(period), 60, 136, 3, previewMuted)
+
+	leaders := leaderboard
+	if len(leaders) > previewLeaders {
+		leaders = leaders[:previewLeaders]
+	}
+	top := 1
+	for _, e := range leaders {
+		top = max(top, e.Score)
+	}
+	const barX, barWidth = 640, 360
+	for i, e := range leaders {
+		y := 210 + i*80
+		drawText(img, "#"+strconv.Itoa(e.Rank), 60, y, 5, previewMuted)
+		drawText(img, truncate(e.Login, 15), 170, y, 5, previewText)
+
+		draw.Draw(img, image.Rect(barX, y, barX+barWidth, y+35), &image.Uniform{previewTrack}, image.Point{}, draw.Src)
+		width := barWidth * max(0, e.Score) / top
+		draw.Draw(img, image.Rect(barX, y, barX+width, y+35), &image.Uniform{previewBar}, image.Point{}, draw.Src)
+		drawText(img, strconv.Itoa(e.Score), barX+barWidth+20, y+4, 4, previewText)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// previewPeriod labels the period with ISO dates, which the pixel font can
+// draw in every locale
+func previewPeriod(period models.Period) string {
+	if period.Start.IsZero() || period.End.IsZero() {
+		return "ALL TIME"
+	}
+	return period.Start.Format("2006-01-02") + " - " + period.End.Format("2006-01-02")
+}
+
+// truncate shortens s to n characters, marking the cut with dots
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-2]) + ".."
+}
+
+// drawText draws s with its top left corner at x, y, each font pixel scaled
+// to a square of scale pixels. Letters are drawn in capitals.
+func drawText(img draw.Image, s string, x, y, scale int, c color.Color) {
+	fill := &image.Uniform{c}
+	for _, r := range strings.ToUpper(s) {
+		rows, ok := glyphs[r]
+		if !ok {
+			rows = glyphs['?']
+		}
+		for row, bits := range rows {
+			for col := range glyphWidth {
+				if bits&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				px, py := x+col*scale, y+row*scale
+				draw.Draw(img, image.Rect(px, py, px+scale, py+scale), fill, image.Point{}, draw.Src)
+			}
+		}
+		x += (glyphWidth + 1) * scale
+	}
+}
+
+// glyphWidth is the width of the pixel font; rows hold a bit per column,
+// the leftmost column in the highest bit
+const glyphWidth = 5
+
+// glyphs is a 5x7 pixel font of the characters found in logins, dates and
+// achievement descriptions
+var glyphs = map[rune][7]uint8{
+	' ': {},
+	'0': {0x0e, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0e},
+	'1': {0x04, 0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'2': {0x0e, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1f},
+	'3': {0x1f, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0e},
+	'4': {0x02, 0x06, 0x0a, 0x12, 0x1f, 0x02, 0x02},
+	'5': {0x1f, 0x10, 0x1e, 0x01, 0x01, 0x11, 0x0e},
+	'6': {0x06, 0x08, 0x10, 0x1e, 0x11, 0x11, 0x0e},
+	'7': {0x1f, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08},
+	'8': {0x0e, 0x11, 0x11, 0x0e, 0x11, 0x11, 0x0e},
+	'9': {0x0e, 0x11, 0x11, 0x0f, 0x01, 0x02, 0x0c},
+	'A': {0x0e, 0x11, 0x11, 0x11, 0x1f, 0x11, 0x11},
+	'B': {0x1e, 0x11, 0x11, 0x1e, 0x11, 0x11, 0x1e},
+	'C': {0x0e, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0e},
+	'D': {0x1c, 0x12, 0x11, 0x11, 0x11, 0x12, 0x1c},
+	'E': {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x1f},
+	'F': {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x10},
+	'G': {0x0e, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0f},
+	'H': {0x11, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x11},
+	'I': {0x0e, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'J': {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0c},
+	'K': {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11},
+	'L': {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1f},
+	'M': {0x11, 0x1b, 0x15, 0x15, 0x11, 0x11, 0x11},
+	'N': {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11},
+	'O': {0x0e, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e},
+	'P': {0x1e, 0x11, 0x11, 0x1e, 0x10, 0x10, 0x10},
+	'Q': {0x0e, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0d},
+	'R': {0x1e, 0x11, 0x11, 0x1e, 0x14, 0x12, 0x11},
+	'S': {0x0f, 0x10, 0x10, 0x0e, 0x01, 0x01, 0x1e},
+	'T': {0x1f, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+	'U': {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e},
+	'V': {0x11, 0x11, 0x11, 0x11, 0x11, 0x0a, 0x04},
+	'W': {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0a},
+	'X': {0x11, 0x11, 0x0a, 0x04, 0x0a, 0x11, 0x11},
+	'Y': {0x11, 0x11, 0x11, 0x0a, 0x04, 0x04, 0x04},
+	'Z': {0x1f, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1f},
+	'-': {0x00, 0x00, 0x00, 0x1f, 0x00, 0x00, 0x00},
+	'_': {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1f},
+	'.': {0x00, 0x00, 0x00, 0x00, 0x00, 0x0c, 0x0c},
+	'#': {0x0a, 0x0a, 0x1f, 0x0a, 0x1f, 0x0a, 0x0a},
+	'/': {0x00, 0x01, 0x02, 0x04, 0x08, 0x10, 0x00},
+	'[': {0x0e, 0x08, 0x08, 0x08, 0x08, 0x08, 0x0e},
+	']': {0x0e, 0x02, 0x02, 0x02, 0x02, 0x02, 0x0e},
+	'+': {0x00, 0x04, 0x04, 0x1f, 0x04, 0x04, 0x00},
+	',': {0x00, 0x00, 0x00, 0x00, 0x0c, 0x04, 0x08},
+	':': {0x00, 0x0c, 0x0c, 0x00, 0x0c, 0x0c, 0x00},
+	'!': {0x04, 0x04, 0x04, 0x04, 0x04, 0x00, 0x04},
+	'(': {0x02, 0x04, 0x08, 0x08, 0x08, 0x04, 0x02},
+	')': {0x08, 0x04, 0x02, 0x02, 0x02, 0x04, 0x08},
+	'%': {0x18, 0x19, 0x02, 0x04, 0x08, 0x13, 0x03},
+	'?': {0x0e, 0x11, 0x01, 0x02, 0x04, 0x00, 0x04},
+}
@@ -0,0 +1,102 @@
+package site
+
+import (
+	"slices"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// publicMetrics returns the metrics without the contributors who opted out
+// of the leaderboards: no leaderboard rows, contributor pages or member
+// listings. Totals of the organization, teams, groups and repositories are
+// left as they are, so their work still counts.
+func publicMetrics(metrics *models.GlobalMetrics) (*models.GlobalMetrics, []string) {
+	hidden := make(map[string]bool)
+	for _, c := range metrics.Contributors {
+		if c.OptedOut {
+			hidden[c.Login] = true
+		}
+	}
+	if len(hidden) == 0 {
+		return metrics, nil
+	}
+	visible := func(c models.ContributorMetrics) bool { return !hidden[c.Login] }
+
+	public := *metrics
+	public.Contributors = keep(metrics.Contributors, visible)
+	public.Leaderboard = rerank(metrics.Leaderboard, hidden)
+
+	public.Repositories = slices.Clone(metrics.Repositories)
+	for i := range public.Repositories {
+		public.Repositories[i].Contributors = keep(public.Repositories[i].Contributors, visible)
+	}
+	public.Teams = slices.Clone(metrics.Teams)
+	for i := range public.Teams {
+		team := &public.Teams[i]
+		team.Members = keep(team.Members, func(login string) bool { return !hidden[login] })
+		team.MemberMetrics = keep(team.MemberMetrics, visible)
+		if team.Ownership != nil {
+			ownership := *team.Ownership
+			ownership.Contributors = keep(ownership.Contributors, func(c models.PathContributor) bool { return !hidden[c.Login] })
+			team.Ownership = &ownership
+		}
+	}
+	public.Groups = slices.Clone(metrics.Groups)
+	for i := range public.Groups {
+		public.Groups[i].Leaderboard = rerank(public.Groups[i].Leaderboard, hidden)
+	}
+
+	public.TopAchievers = make(map[string]string, len(metrics.TopAchievers))
+	for category, login := range metrics.TopAchievers {
+		if !hidden[login] {
+			public.TopAchievers[category] = login
+		}
+	}
+
+	logins := make([]string, 0, len(hidden))
+	for login := range hidden {
+		logins = append(logins, login)
+	}
+	slices.Sort(logins)
+	return &public, logins
+}
+
+// keep returns a copy of items with only those matching visible
+func keep[T any](items []T, visible func(T) bool) []T {
+	if items == nil {
+		return nil
+	}
+	kept := make([]T, 0, len(items))
+	for _, item := range items {
+		if visible(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// rerank removes hidden contributors from a leaderboard, ordered by rank,
+// and closes the gaps they leave; contributors sharing a rank keep sharing it,
+// unless the others they shared it with are hidden
+func rerank(entries []models.LeaderboardEntry, hidden map[string]bool) []models.LeaderboardEntry {
+	if entries == nil {
+		return nil
+	}
+	ranked := make([]models.LeaderboardEntry, 0, len(entries))
+	lastRank, newRank := 0, 0
+	for _, e := range entries {
+		if hidden[e.Login] {
+			continue
+		}
+		if e.Rank != lastRank {
+			lastRank, newRank = e.Rank, len(ranked)+1
+		}
+		e.Rank = newRank
+		ranked = append(ranked, e)
+	}
+	for i := range ranked {
+		ranked[i].Tied = (i > 0 && ranked[i-1].Rank == ranked[i].Rank) ||
+			(i+1 < len(ranked) && ranked[i+1].Rank == ranked[i].Rank)
+	}
+	return ranked
+}
@@ -0,0 +1,103 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func optedOutMetrics() *models.GlobalMetrics {
+	contributors := []models.ContributorMetrics{
+		{Login: "alice", CommitCount: 5},
+		{Login: "bob", CommitCount: 9, OptedOut: true},
+		{Login: "carol", CommitCount: 2},
+	}
+	return &models.GlobalMetrics{
+		Period:            models.Period{Label: "All Time"},
+		Contributors:      contributors,
+		TotalContributors: 3,
+		TotalCommits:      16,
+		Leaderboard: []models.LeaderboardEntry{
+			{Rank: 1, Login: "bob", Score: 90},
+			{Rank: 2, Tied: true, Login: "alice", Score: 50},
+			{Rank: 2, Tied: true, Login: "carol", Score: 50},
+		},
+		Repositories: []models.RepositoryMetrics{
+			{FullName: "acme/repo", Owner: "acme", Name: "repo", TotalCommits: 16, Contributors: contributors},
+		},
+		Teams: []models.TeamMetrics{
+			{Name: "Core", Members: []string{"alice", "bob"}, MemberMetrics: contributors[:2], TotalScore: 140},
+		},
+		Groups: []models.GroupMetrics{
+			{Name: "Platform", Leaderboard: []models.LeaderboardEntry{{Rank: 1, Login: "bob"}, {Rank: 2, Login: "carol"}}},
+		},
+		TopAchievers: map[string]string{"commits": "bob", "reviews": "alice"},
+	}
+}
+
+func TestPublicMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := optedOutMetrics()
+	public, optedOut := publicMetrics(metrics)
+	assert.Equal(t, []string{"bob"}, optedOut)
+
+	logins := func(contributors []models.ContributorMetrics) []string {
+		var l []string
+		for _, c := range contributors {
+			l = append(l, c.Login)
+		}
+		return l
+	}
+	assert.Equal(t, []string{"alice", "carol"}, logins(public.Contributors))
+	assert.Equal(t, []models.LeaderboardEntry{{Rank: 1, Tied: true, Login: "alice", Score: 50}, {Rank: 1, Tied: true, Login: "carol", Score: 50}}, public.Leaderboard)
+	assert.Equal(t, []string{"alice", "carol"}, logins(public.Repositories[0].Contributors))
+	assert.Equal(t, []string{"alice"}, public.Teams[0].Members)
+	assert.Equal(t, []string{"alice"}, logins(public.Teams[0].MemberMetrics))
+	assert.Equal(t, []models.LeaderboardEntry{{Rank: 1, Login: "carol"}}, public.Groups[0].Leaderboard)
+	assert.Equal(t, map[string]string{"reviews": "alice"}, public.TopAchievers)
+
+	// Totals still count them
+	assert.Equal(t, 3, public.TotalContributors)
+	assert.Equal(t, 16, public.Repositories[0].TotalCommits)
+	assert.Equal(t, 140, public.Teams[0].TotalScore)
+
+	// The metrics passed in are left alone
+	assert.Len(t, metrics.Contributors, 3)
+	assert.Len(t, metrics.Teams[0].Members, 2)
+	assert.Equal(t, 1, metrics.Leaderboard[0].Rank)
+}
+
+func TestGenerator_OptedOut(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.HallOfFame = true
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+
+	// Bob held achievements before opting out
+	metrics := optedOutMetrics()
+	metrics.Contributors[1].OptedOut = false
+	metrics.Contributors[1].Achievements = []string{"commit-1"}
+	require.NoError(t, gen.Generate(metrics))
+	assert.FileExists(t, filepath.Join(dir, "data", "contributors", "bob.json"))
+
+	metrics = optedOutMetrics()
+	require.NoError(t, gen.Generate(metrics))
+
+	assert.NoFileExists(t, filepath.Join(dir, "data", "contributors", "bob.json"))
+	assert.FileExists(t, filepath.Join(dir, "data", "contributors", "alice.json"))
+	for _, file := range []string{"leaderboard.json", "search.json", "hall-of-fame.json"} {
+		content, err := os.ReadFile(filepath.Join(dir, "data", file))
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), `"bob"`, file)
+	}
+}
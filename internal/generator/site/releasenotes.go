@@ -0,0 +1,75 @@
+package site
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// releaseNotesFile is written to the output directory with the release_notes format
+const releaseNotesFile = "RELEASE_NOTES.md"
+
+// changeHeadings are the section headings of each type of change
+var changeHeadings = map[string]string{
+	models.ChangeBreaking:     "Breaking Changes",
+	models.ChangeFeature:      "Features",
+	models.ChangeFix:          "Bug Fixes",
+	models.ChangePerformance:  "Performance",
+	models.ChangeRefactor:     "Refactoring",
+	models.ChangeDocs:         "Documentation",
+	models.ChangeTests:        "Tests",
+	models.ChangeDependencies: "Dependencies",
+	models.ChangeMaintenance:  "Maintenance",
+	models.ChangeOther:        "Other Changes",
+}
+
+// releaseNotesMarkdown renders the release notes of a period in Markdown: a
+// section per type of change, then the contributors by merged PRs
+func releaseNotesMarkdown(notes *models.ReleaseNotes, period models.Period) []byte {
+	var b strings.Builder
+	b.WriteString("# Release Notes\n\n")
+	if !period.Start.IsZero() {
+		fmt.Fprintf(&b, "Pull requests merged from %s to %s.\n", period.Start.Format("2006-01-02"), period.End.Format("2006-01-02"))
+	} else {
+		fmt.Fprintf(&b, "Pull requests merged until %s.\n", period.End.Format("2006-01-02"))
+	}
+	if len(notes.Sections) == 0 {
+		b.WriteString("\nNo pull requests were merged.\n")
+		return []byte(b.String())
+	}
+
+	for _, section := range notes.Sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", changeHeadings[section.Type])
+		for _, e := range section.Entries {
+			title := e.Title
+			if e.Scope != "" {
+				title = "**" + e.Scope + ":** " + title
+			}
+			ref := fmt.Sprintf("%s#%d", e.Repository, e.Number)
+			if e.URL != "" {
+				ref = "[" + ref + "](" + e.URL + ")"
+			}
+			fmt.Fprintf(&b, "- %s (%s) by @%s\n", title, ref, e.Author)
+		}
+	}
+
+	b.WriteString("\n## Contributors\n\n")
+	for _, a := range notes.Authors {
+		var types []string
+		for _, section := range notes.Sections {
+			if n := a.Types[section.Type]; n > 0 {
+				types = append(types, fmt.Sprintf("%d %s", n, section.Type))
+			}
+		}
+		fmt.Fprintf(&b, "- @%s: %d %s (%s)\n", a.Login, a.PRs, plural(a.PRs, "pull request", "pull requests"), strings.Join(types, ", "))
+	}
+	return []byte(b.String())
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,71 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestGenerator_ReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	gen, err := NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+
+	merged := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	metrics := wallboardMetrics()
+	metrics.Period = models.Period{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}
+	metrics.ReleaseNotes = &models.ReleaseNotes{
+		Sections: []models.ReleaseSection{
+			{Type: models.ChangeFeature, Entries: []models.ReleaseEntry{
+				{Repository: "acme/api", Number: 12, Title: "Rate limit requests", Scope: "api", URL: "https://github.com/acme/api/pull/12", Author: "alice", MergedAt: merged},
+				{Repository: "acme/web", Number: 3, Title: "Dark mode", Author: "bob", MergedAt: merged},
+			}},
+			{Type: models.ChangeFix, Entries: []models.ReleaseEntry{
+				{Repository: "acme/api", Number: 14, Title: "Retry on timeouts", URL: "https://github.com/acme/api/pull/14", Author: "alice", MergedAt: merged},
+			}},
+		},
+		Authors: []models.ReleaseAuthor{
+			{Login: "alice", PRs: 2, Types: map[string]int{models.ChangeFeature: 1, models.ChangeFix: 1}},
+			{Login: "bob", PRs: 1, Types: map[string]int{models.ChangeFeature: 1}},
+		},
+	}
+	require.NoError(t, gen.Generate(metrics))
+
+	content, err := os.ReadFile(filepath.Join(dir, "RELEASE_NOTES.md"))
+	require.NoError(t, err)
+	assert.Equal(t, `# Release Notes
+
+Pull requests merged from 2024-01-01 to 2024-01-31.
+
+## Features
+
+- **api:** Rate limit requests ([acme/api#12](https://github.com/acme/api/pull/12)) by @alice
+- Dark mode (acme/web#3) by @bob
+
+## Bug Fixes
+
+- Retry on timeouts ([acme/api#14](https://github.com/acme/api/pull/14)) by @alice
+
+## Contributors
+
+- @alice: 2 pull requests (1 feat, 1 fix)
+- @bob: 1 pull request (1 feat)
+`, string(content))
+
+	// Nothing is written unless the format is enabled
+	dir = t.TempDir()
+	gen, err = NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+	metrics.ReleaseNotes = nil
+	require.NoError(t, gen.Generate(metrics))
+	assert.NoFileExists(t, filepath.Join(dir, "RELEASE_NOTES.md"))
+}
@@ -0,0 +1,139 @@
+package site
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+// htmlPages are the generated pages, relative to the output directory
+var htmlPages = []string{"index.html", "tables.html", "wallboard.html"}
+
+// bundledAsset matches the <script> and <link> tags of index.html loading a
+// bundled file, capturing the tag without its closing bracket and the path
+var bundledAsset = regexp.MustCompile(`(<(?:script|link)\b[^>]*\b(?:src|href)="\./(assets/[^"]+)"[^>]*?)(\s*/?>)`)
+
+// inlineScript matches inline scripts, capturing their content
+var inlineScript = regexp.MustCompile(`(?s)<script>(.*?)</script>`)
+
+// applySecurity adds integrity hashes to index.html and writes the Content
+// Security Policy of output.security, once every page has been generated
+func (g *Generator) applySecurity() error {
+	security := g.config.Output.Security
+	if security.SRI {
+		if err := g.addIntegrity(); err != nil {
+			return fmt.Errorf("failed to add integrity hashes: %w", err)
+		}
+	}
+	if security.CSP == "" {
+		return nil
+	}
+
+	pages := make(map[string]string)
+	var hashes []string
+	for _, name := range htmlPages {
+		content, err := os.ReadFile(filepath.Join(g.outputDir, name)) // #nosec G304 -- generated page
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		pages[name] = string(content)
+		for _, m := range inlineScript.FindAllStringSubmatch(string(content), -1) {
+			hashes = append(hashes, "'sha256-"+digest(sha256.New(), m[1])+"'")
+		}
+	}
+	policy := g.contentSecurityPolicy(hashes)
+
+	if security.CSP == config.CSPMeta || security.CSP == config.CSPBoth {
+		// frame-ancestors is ignored in <meta> policies, so it is left out
+		meta := fmt.Sprintf("<head>\n  <meta http-equiv=\"Content-Security-Policy\" content=\"%s\">", policy)
+		for name, content := range pages {
+			content = strings.Replace(content, "<head>", meta, 1)
+			if err := os.WriteFile(filepath.Join(g.outputDir, name), []byte(content), 0600); err != nil {
+				return err
+			}
+		}
+	}
+	if security.CSP == config.CSPHeaders || security.CSP == config.CSPBoth {
+		headers := "/*\n" +
+			"  Content-Security-Policy: " + policy + "; frame-ancestors 'self'\n" +
+			"  X-Content-Type-Options: nosniff\n" +
+			"  Referrer-Policy: strict-origin-when-cross-origin\n"
+		if err := os.WriteFile(filepath.Join(g.outputDir, "_headers"), []byte(headers), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addIntegrity adds the SHA-384 hash of every bundled script and stylesheet
+// to their tags in index.html, so a tampered copy on the host isn't run
+func (g *Generator) addIntegrity() error {
+	indexPath := filepath.Join(g.outputDir, "index.html")
+	index, err := os.ReadFile(filepath.Clean(indexPath))
+	if err != nil {
+		return err
+	}
+
+	var readErr error
+	patched := bundledAsset.ReplaceAllStringFunc(string(index), func(tag string) string {
+		m := bundledAsset.FindStringSubmatch(tag)
+		if strings.Contains(m[1], " integrity=") {
+			return tag
+		}
+		content, err := os.ReadFile(filepath.Join(g.outputDir, filepath.FromSlash(m[2]))) // #nosec G304 -- bundled asset of the output
+		if err != nil {
+			readErr = err
+			return tag
+		}
+		return m[1] + ` integrity="sha384-` + digest(sha512.New384(), string(content)) + `"` + m[3]
+	})
+	if readErr != nil {
+		return readErr
+	}
+	return os.WriteFile(indexPath, []byte(patched), 0600)
+}
+
+// contentSecurityPolicy allows the site's own files, the inline scripts with
+// the given hashes and, unless assets are offline, the font and icon CDNs.
+// Inline styles stay allowed for the style attributes charts and the
+// wallboard set.
+func (g *Generator) contentSecurityPolicy(scriptHashes []string) string {
+	scripts := append([]string{"'self'"}, scriptHashes...)
+	styles := []string{"'self'", "'unsafe-inline'"}
+	fonts := []string{"'self'"}
+	if !g.config.Output.OfflineAssets {
+		styles = append(styles, "https://fonts.googleapis.com", "https://cdnjs.cloudflare.com")
+		fonts = append(fonts, "https://fonts.gstatic.com", "https://cdnjs.cloudflare.com")
+	}
+
+	return strings.Join([]string{
+		"default-src 'self'",
+		"script-src " + strings.Join(scripts, " "),
+		"style-src " + strings.Join(styles, " "),
+		"font-src " + strings.Join(fonts, " "),
+		// Avatars come from GitHub or an Enterprise Server host
+		"img-src 'self' data: https:",
+		"connect-src 'self'",
+		"object-src 'none'",
+		"base-uri 'self'",
+		"form-action 'self'",
+	}, "; ")
+}
+
+// digest returns the base64 hash of content, as used by integrity attributes
+// and CSP sources
+func digest(h hash.Hash, content string) string {
+	_, _ = h.Write([]byte(content))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,101 @@
+package site
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+func TestGenerator_SubresourceIntegrity(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	gen, err := NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(wallboardMetrics()))
+
+	content, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	tags := bundledAsset.FindAllStringSubmatch(string(content), -1)
+	require.NotEmpty(t, tags)
+	integrity := regexp.MustCompile(`integrity="(sha384-[^"]+)"`)
+	for _, tag := range tags {
+		asset, err := os.ReadFile(filepath.Join(dir, tag[2]))
+		require.NoError(t, err)
+		m := integrity.FindStringSubmatch(tag[0])
+		require.NotNil(t, m, "%s has no integrity hash", tag[2])
+		assert.Equal(t, "sha384-"+digest(sha512.New384(), string(asset)), m[1], tag[2])
+	}
+	assert.NoFileExists(t, filepath.Join(dir, "_headers"), "no CSP unless configured")
+	assert.NotContains(t, string(content), "Content-Security-Policy")
+}
+
+func TestGenerator_SubresourceIntegrityDisabled(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.Security.SRI = false
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(wallboardMetrics()))
+
+	content, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "integrity=")
+}
+
+func TestGenerator_ContentSecurityPolicy(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.Wallboard = true
+	cfg.Output.Security.CSP = config.CSPBoth
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1")))
+
+	headers, err := os.ReadFile(filepath.Join(dir, "_headers"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(headers), "/*\n  Content-Security-Policy: default-src 'self'; script-src 'self' 'sha256-"))
+	assert.Contains(t, string(headers), "frame-ancestors 'self'")
+	assert.Contains(t, string(headers), "https://fonts.gstatic.com", "CDN fonts are allowed without offline assets")
+
+	for _, page := range htmlPages {
+		content, err := os.ReadFile(filepath.Join(dir, page))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `<head>
+  <meta http-equiv="Content-Security-Policy" content="default-src 'self';`, page)
+		assert.NotContains(t, string(content), "frame-ancestors", "ignored in <meta> policies")
+	}
+
+	// The wallboard's inline script is allowed by its hash
+	wallboard, err := os.ReadFile(filepath.Join(dir, "wallboard.html"))
+	require.NoError(t, err)
+	script := inlineScript.FindStringSubmatch(string(wallboard))
+	require.NotNil(t, script)
+	assert.Contains(t, string(headers), "'sha256-"+digest(sha256.New(), script[1])+"'")
+}
+
+func TestContentSecurityPolicy_OfflineAssets(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Output.OfflineAssets = true
+	gen, err := NewGenerator(t.TempDir(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"default-src 'self'; script-src 'self'; style-src 'self' 'unsafe-inline'; font-src 'self'; "+
+			"img-src 'self' data: https:; connect-src 'self'; object-src 'none'; base-uri 'self'; form-action 'self'",
+		gen.contentSecurityPolicy(nil))
+}
@@ -0,0 +1,242 @@
+package site
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// shareDir holds a page per contributor, repository and team carrying their
+// link preview tags. The dashboard routes with the URL fragment, which link
+// unfurlers never see, so these pages redirect to the dashboard route instead.
+const shareDir = "share"
+
+// pageMeta is what a link to a page unfurls to
+type pageMeta struct {
+	Title       string
+	Description string
+	Path        string // Relative to the site root
+}
+
+// generateSharing writes link preview tags into the pages, the share pages,
+// the social preview image and sitemap.xml. It needs output.site_url, as
+// link unfurlers and crawlers only follow absolute URLs.
+func (g *Generator) generateSharing(metrics *models.GlobalMetrics) error {
+	// Drop the pages of contributors and teams gone since the last run
+	if err := os.RemoveAll(filepath.Join(g.outputDir, shareDir)); err != nil {
+		return fmt.Errorf("failed to clean share directory: %w", err)
+	}
+	if g.siteURL() == "" {
+		return nil
+	}
+
+	preview, err := renderPreview(metrics.Leaderboard, metrics.Period)
+	if err != nil {
+		return fmt.Errorf("failed to render preview image: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(g.outputDir, previewImage), preview, 0600); err != nil {
+		return err
+	}
+
+	c := g.catalog
+	period := g.periodLabel(metrics.Period)
+	site := c.T("share.site",
+		"contributors", c.FormatNumber(float64(metrics.TotalContributors), 0),
+		"commits", c.FormatNumber(float64(metrics.TotalCommits), 0),
+		"prs", c.FormatNumber(float64(metrics.TotalPRs), 0),
+		"period", period)
+
+	pages := []pageMeta{
+		{Title: "Git Velocity", Description: site, Path: "index.html"},
+		{Title: c.T("tables.title"), Description: site, Path: "tables.html"},
+	}
+	if g.config.Output.Wallboard {
+		pages = append(pages, pageMeta{Title: c.T("wallboard.title"), Description: site, Path: "wallboard.html"})
+	}
+	for _, p := range pages {
+		if err := g.addMetaTags(p); err != nil {
+			return err
+		}
+	}
+
+	shares := g.sharePages(metrics, period)
+	for _, p := range shares {
+		if err := g.writeSharePage(p); err != nil {
+			return err
+		}
+	}
+	return g.writeSitemap(append(pages, shares...))
+}
+
+// siteURL returns output.site_url with a trailing slash, or "" when unset
+func (g *Generator) siteURL() string {
+	u := g.config.Output.SiteURL
+	if u == "" || strings.HasSuffix(u, "/") {
+		return u
+	}
+	return u + "/"
+}
+
+// pageURL returns the absolute URL of a page
+func (g *Generator) pageURL(p pageMeta) string {
+	if p.Path == "index.html" {
+		return g.siteURL()
+	}
+	return g.siteURL() + p.Path
+}
+
+// sharePages describes the share page of every contributor, group,
+// repository and team, along with the dashboard route each redirects to
+func (g *Generator) sharePages(metrics *models.GlobalMetrics, period string) []pageMeta {
+	c := g.catalog
+	var pages []pageMeta
+	for i := range metrics.Contributors {
+		m := &metrics.Contributors[i]
+		name := m.Name
+		if name == "" {
+			name = m.Login
+		}
+		pages = append(pages, pageMeta{
+			Title: name + " · Git Velocity",
+			Description: c.T("share.contributor",
+				"score", c.FormatNumber(float64(m.Score.Total), 0),
+				"commits", c.FormatNumber(float64(m.CommitCount), 0),
+				"prs", c.FormatNumber(float64(m.PRsMerged), 0),
+				"period", period),
+			Path: shareDir + "/contributors/" + m.Login + ".html",
+		})
+	}
+	for i := range metrics.Groups {
+		gm := &metrics.Groups[i]
+		pages = append(pages, pageMeta{
+			Title: gm.Name + " · Git Velocity",
+			Description: c.T("share.group",
+				"repos", strconv.Itoa(len(gm.Repositories)),
+				"commits", c.FormatNumber(float64(gm.TotalCommits), 0),
+				"contributors", c.FormatNumber(float64(gm.ActiveContributors), 0),
+				"period", period),
+			Path: shareDir + "/groups/" + slugify(gm.Name) + ".html",
+		})
+	}
+	for i := range metrics.Repositories {
+		r := &metrics.Repositories[i]
+		pages = append(pages, pageMeta{
+			Title: r.Owner + "/" + r.Name + " · Git Velocity",
+			Description: c.T("share.repository",
+				"commits", c.FormatNumber(float64(r.TotalCommits), 0),
+				"prs", c.FormatNumber(float64(r.TotalPRs), 0),
+				"contributors", c.FormatNumber(float64(r.ActiveContributors), 0),
+				"period", period),
+			Path: shareDir + "/repos/" + r.Owner + "/" + r.Name + ".html",
+		})
+	}
+	for i := range metrics.Teams {
+		t := &metrics.Teams[i]
+		pages = append(pages, pageMeta{
+			Title: t.Name + " · Git Velocity",
+			Description: c.T("share.team",
+				"members", strconv.Itoa(len(t.Members)),
+				"score", c.FormatNumber(float64(t.TotalScore), 0),
+				"period", period),
+			Path: shareDir + "/teams/" + slugify(t.Name) + ".html",
+		})
+	}
+	return pages
+}
+
+// route returns the dashboard route of a share page
+func route(path string) string {
+	return "#/" + strings.TrimSuffix(strings.TrimPrefix(path, shareDir+"/"), ".html")
+}
+
+// metaTags returns the OpenGraph and Twitter tags of a page
+func (g *Generator) metaTags(p pageMeta) string {
+	image := g.siteURL() + previewImage
+	tags := []struct{ attr, key, content string }{
+		{"name", "description", p.Description},
+		{"property", "og:type", "website"},
+		{"property", "og:site_name", "Git Velocity"},
+		{"property", "og:title", p.Title},
+		{"property", "og:description", p.Description},
+		{"property", "og:url", g.pageURL(p)},
+		{"property", "og:image", image},
+		{"property", "og:image:width", strconv.Itoa(previewWidth)},
+		{"property", "og:image:height", strconv.Itoa(previewHeight)},
+		{"property", "og:image:alt", g.catalog.T("share.preview_alt")},
+		{"name", "twitter:card", "summary_large_image"},
+		{"name", "twitter:title", p.Title},
+		{"name", "twitter:description", p.Description},
+		{"name", "twitter:image", image},
+	}
+
+	var b strings.Builder
+	for _, t := range tags {
+		fmt.Fprintf(&b, "  <meta %s=\"%s\" content=\"%s\">\n", t.attr, t.key, html.EscapeString(t.content))
+	}
+	return b.String()
+}
+
+// addMetaTags adds the link preview tags of a page before its </head>
+func (g *Generator) addMetaTags(p pageMeta) error {
+	path := filepath.Join(g.outputDir, filepath.FromSlash(p.Path))
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	patched := strings.Replace(string(content), "</head>", g.metaTags(p)+"</head>", 1)
+	return os.WriteFile(path, []byte(patched), 0600)
+}
+
+// writeSharePage writes a page with the link preview tags of a contributor,
+// repository or team, redirecting browsers to its dashboard route
+func (g *Generator) writeSharePage(p pageMeta) error {
+	target := html.EscapeString(g.siteURL() + route(p.Path))
+	page := "<!DOCTYPE html>\n<html lang=\"" + g.catalog.Locale + "\">\n<head>\n" +
+		"  <meta charset=\"UTF-8\">\n" +
+		"  <title>" + html.EscapeString(p.Title) + "</title>\n" +
+		g.metaTags(p) +
+		"  <meta http-equiv=\"refresh\" content=\"0; url=" + target + "\">\n" +
+		"</head>\n<body>\n" +
+		"  <p><a href=\"" + target + "\">" + html.EscapeString(p.Title) + "</a></p>\n" +
+		"</body>\n</html>\n"
+
+	path := filepath.Join(g.outputDir, filepath.FromSlash(p.Path))
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(page), 0600)
+}
+
+// sitemap is the layout of sitemap.xml
+type sitemap struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// writeSitemap lists the pages in sitemap.xml, last modified at generation
+func (g *Generator) writeSitemap(pages []pageMeta) error {
+	s := sitemap{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	lastMod := g.now().UTC().Format("2006-01-02")
+	for _, p := range pages {
+		s.URLs = append(s.URLs, sitemapURL{Loc: g.pageURL(p), LastMod: lastMod})
+	}
+
+	content, err := xml.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	content = append([]byte(xml.Header), content...)
+	return os.WriteFile(filepath.Join(g.outputDir, "sitemap.xml"), append(content, '\n'), 0600)
+}
@@ -0,0 +1,118 @@
+package site
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestGenerator_Sharing(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.SiteURL = "https://velocity.example.com/acme"
+	cfg.Output.Wallboard = true
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+	gen.now = func() time.Time { return time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC) }
+
+	metrics := wallboardMetrics()
+	metrics.TotalContributors, metrics.TotalCommits, metrics.TotalPRs = 2, 1234, 56
+	metrics.Repositories = []models.RepositoryMetrics{{Owner: "acme", Name: "widgets", TotalCommits: 1234}}
+	metrics.Teams = []models.TeamMetrics{{Name: "Platform Team", Members: []string{"alice"}, TotalScore: 420}}
+	require.NoError(t, gen.Generate(metrics))
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), `<meta property="og:url" content="https://velocity.example.com/acme/">`)
+	assert.Contains(t, string(index), `<meta property="og:image" content="https://velocity.example.com/acme/preview.png">`)
+	assert.Contains(t, string(index), `<meta name="twitter:card" content="summary_large_image">`)
+	assert.Contains(t, string(index), "2 contributors · 1,234 commits · 56 pull requests · All Time")
+	for _, page := range []string{"tables.html", "wallboard.html"} {
+		content, err := os.ReadFile(filepath.Join(dir, page))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `content="https://velocity.example.com/acme/`+page+`"`, page)
+	}
+
+	share, err := os.ReadFile(filepath.Join(dir, "share", "contributors", "alice.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(share), `<meta property="og:title" content="alice · Git Velocity">`)
+	assert.Contains(t, string(share), `url=https://velocity.example.com/acme/#/contributors/alice"`)
+	assert.FileExists(t, filepath.Join(dir, "share", "repos", "acme", "widgets.html"))
+	assert.FileExists(t, filepath.Join(dir, "share", "teams", "platform-team.html"))
+
+	content, err := os.ReadFile(filepath.Join(dir, "sitemap.xml"))
+	require.NoError(t, err)
+	var sm sitemap
+	require.NoError(t, xml.Unmarshal(content, &sm))
+	require.Len(t, sm.URLs, 7)
+	assert.Equal(t, sitemapURL{Loc: "https://velocity.example.com/acme/", LastMod: "2024-03-04"}, sm.URLs[0])
+	assert.Equal(t, "https://velocity.example.com/acme/share/teams/platform-team.html", sm.URLs[6].Loc)
+
+	preview, err := os.ReadFile(filepath.Join(dir, previewImage))
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(preview))
+	require.NoError(t, err)
+	assert.Equal(t, previewWidth, img.Bounds().Dx())
+	assert.Equal(t, previewHeight, img.Bounds().Dy())
+
+	// Share pages of contributors gone from the data are removed
+	metrics.Contributors = metrics.Contributors[1:]
+	require.NoError(t, gen.Generate(metrics))
+	assert.NoFileExists(t, filepath.Join(dir, "share", "contributors", "alice.html"))
+}
+
+func TestGenerator_SharingNeedsSiteURL(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	gen, err := NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(wallboardMetrics()))
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(index), "og:")
+	assert.NoFileExists(t, filepath.Join(dir, "sitemap.xml"))
+	assert.NoFileExists(t, filepath.Join(dir, previewImage))
+	assert.NoDirExists(t, filepath.Join(dir, shareDir))
+}
+
+func TestRenderPreview(t *testing.T) {
+	t.Parallel()
+
+	leaders := []models.LeaderboardEntry{
+		{Rank: 1, Login: "alice", Score: 420},
+		{Rank: 2, Login: "a-very-long-login-name", Score: 10},
+		{Rank: 3, Login: "zoë", Score: -5},
+	}
+	first, err := renderPreview(leaders, models.Period{})
+	require.NoError(t, err)
+	second, err := renderPreview(leaders, models.Period{})
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "previews are reproducible")
+
+	img, err := png.Decode(bytes.NewReader(first))
+	require.NoError(t, err)
+	// The top contributor's bar fills the track; the next one's doesn't
+	assert.Equal(t, previewBar, img.At(990, 220))
+	assert.Equal(t, previewTrack, img.At(990, 300))
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "alice", truncate("alice", 15))
+	assert.Equal(t, "a-very-long-l..", truncate("a-very-long-login-name", 15))
+}
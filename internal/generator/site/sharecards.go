@@ -0,0 +1,424 @@
+package site
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"  // Avatar formats
+	_ "image/jpeg" // Avatar formats
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/internal/compare"
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/icons"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// sharesDir holds the share cards: a PNG and an SVG image per achievement
+// earned in the run, and index.json listing them
+const sharesDir = "shares"
+
+// Share cards have the size of the social preview, which chat apps show whole
+const (
+	cardWidth  = previewWidth
+	cardHeight = previewHeight
+	avatarSize = 240
+)
+
+// Colors of the medal drawn for the badge
+var (
+	medalGold   = color.RGBA{0xf5, 0x9e, 0x0b, 0xff} // amber-500
+	medalRim    = color.RGBA{0xb4, 0x53, 0x09, 0xff} // amber-700
+	medalRibbon = color.RGBA{0xec, 0x48, 0x99, 0xff} // pink-500
+)
+
+// AvatarSource fetches the image at a contributor's avatar URL
+type AvatarSource func(url string) ([]byte, error)
+
+// SetAvatarSource sets where share cards get avatars from. Without one, or
+// when an avatar cannot be fetched, cards show the contributor's initial.
+func (g *Generator) SetAvatarSource(src AvatarSource) {
+	g.avatars = src
+}
+
+// shareCard is an entry of shares/index.json
+type shareCard struct {
+	Login       string `json:"login"`
+	Name        string `json:"name,omitempty"`
+	Achievement string `json:"achievement"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	PNG         string `json:"png"`           // Relative to the site root
+	SVG         string `json:"svg"`           // Relative to the site root
+	URL         string `json:"url,omitempty"` // Of the PNG, with output.site_url
+}
+
+// shareCardsDocument is shares/index.json, from which a notifier picks the
+// cards to post
+type shareCardsDocument struct {
+	SchemaVersion int         `json:"schema_version"`
+	Period        string      `json:"period"`
+	Cards         []shareCard `json:"cards"`
+}
+
+// earnedAchievement is an achievement a contributor earned in the run
+type earnedAchievement struct {
+	contributor *models.ContributorMetrics
+	achievement config.AchievementConfig
+}
+
+// generateShareCards draws a card for every achievement earned since the
+// previous run, or every achievement held when there is none
+func (g *Generator) generateShareCards(metrics, previous *models.GlobalMetrics) error {
+	dir := filepath.Join(g.outputDir, sharesDir)
+	// Drop the cards of the previous run
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clean shares directory: %w", err)
+	}
+	if !g.config.Output.ShareCards {
+		return nil
+	}
+
+	doc := shareCardsDocument{SchemaVersion: models.SchemaVersion, Period: g.periodLabel(metrics.Period), Cards: []shareCard{}}
+	avatars := make(map[string]image.Image)
+	for _, e := range g.earnedAchievements(metrics, previous) {
+		c := e.contributor
+		card := shareCard{
+			Login:       c.Login,
+			Name:        c.Name,
+			Achievement: e.achievement.ID,
+			Title:       e.achievement.Name,
+			Description: e.achievement.Description,
+			PNG:         sharesDir + "/" + c.Login + "/" + e.achievement.ID + ".png",
+			SVG:         sharesDir + "/" + c.Login + "/" + e.achievement.ID + ".svg",
+		}
+		if text, ok := g.catalog.Achievement(e.achievement.ID); ok {
+			card.Title, card.Description = text.Name, text.Description
+		}
+		if u := g.siteURL(); u != "" {
+			card.URL = u + card.PNG
+		}
+
+		avatar, ok := avatars[c.Login]
+		if !ok {
+			avatar = g.avatar(c.AvatarURL)
+			avatars[c.Login] = avatar
+		}
+		pngCard, err := renderShareCard(e, avatar, metrics.Period)
+		if err != nil {
+			return fmt.Errorf("failed to render share card: %w", err)
+		}
+		svgCard := g.shareCardSVG(card, e.achievement.Icon, avatar, metrics.Period)
+
+		if err := os.MkdirAll(filepath.Join(dir, c.Login), 0750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(g.outputDir, filepath.FromSlash(card.PNG)), pngCard, 0600); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(g.outputDir, filepath.FromSlash(card.SVG)), []byte(svgCard), 0600); err != nil {
+			return err
+		}
+		doc.Cards = append(doc.Cards, card)
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(dir, "index.json"), doc)
+}
+
+// earnedAchievements returns the achievements earned since the previous run,
+// in leaderboard order
+func (g *Generator) earnedAchievements(metrics, previous *models.GlobalMetrics) []earnedAchievement {
+	definitions := make(map[string]config.AchievementConfig)
+	for _, a := range g.config.Scoring.GetAchievements() {
+		definitions[a.ID] = a
+	}
+
+	gained := make(map[string][]string)
+	if previous != nil {
+		for _, delta := range compare.Compare(previous, metrics).Contributors {
+			gained[delta.Login] = delta.AchievementsGained
+		}
+	} else {
+		for _, c := range metrics.Contributors {
+			gained[c.Login] = c.Achievements
+		}
+	}
+
+	ranks := make(map[string]int, len(metrics.Leaderboard))
+	for i, e := range metrics.Leaderboard {
+		ranks[e.Login] = i
+	}
+	contributors := make([]*models.ContributorMetrics, 0, len(metrics.Contributors))
+	for i := range metrics.Contributors {
+		contributors = append(contributors, &metrics.Contributors[i])
+	}
+	sort.SliceStable(contributors, func(i, j int) bool {
+		ri, iok := ranks[contributors[i].Login]
+		rj, jok := ranks[contributors[j].Login]
+		if iok != jok {
+			return iok
+		}
+		return ri < rj
+	})
+
+	var earned []earnedAchievement
+	for _, c := range contributors {
+		for _, id := range gained[c.Login] {
+			if a, ok := definitions[id]; ok {
+				earned = append(earned, earnedAchievement{contributor: c, achievement: a})
+			}
+		}
+	}
+	return earned
+}
+
+// avatar fetches and decodes an avatar, or returns nil
+func (g *Generator) avatar(url string) image.Image {
+	if g.avatars == nil || url == "" {
+		return nil
+	}
+	data, err := g.avatars(url)
+	if err != nil {
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	return img
+}
+
+// renderShareCard draws a card as a PNG: the avatar, a medal and the
+// achievement's name in the pixel font. The pixel font only has capitals,
+// digits and some punctuation, so the card uses the configured English texts.
+func renderShareCard(e earnedAchievement, avatar image.Image, period models.Period) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{previewBackground}, image.Point{}, draw.Src)
+
+	drawText(img, "GIT VELOCITY", 60, 50, 4, previewMuted)
+	drawAvatar(img, avatar, e.contributor.Login, 60, 160)
+
+	const textX = 340
+	drawText(img, "ACHIEVEMENT UNLOCKED", textX, 170, 3, previewBar)
+	drawText(img, truncate(e.achievement.Name, 16), textX, 215, 6, previewText)
+	drawText(img, truncate(e.contributor.Login, 24), textX, 290, 4, previewText)
+	for i, line := range wrapText(e.achievement.Description, 30, 2) {
+		drawText(img, line, textX, 360+i*36, 3, previewMuted)
+	}
+	drawMedal(img, 1040, 260)
+	drawText(img, previewPeriod(period), 60, 560, 3, previewMuted)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// drawAvatar draws the avatar in a circle with its top left corner at x, y,
+// or the login's initial when there is no avatar
+func drawAvatar(img *image.RGBA, avatar image.Image, login string, x, y int) {
+	const r = avatarSize / 2
+	inCircle := func(px, py int) bool {
+		dx, dy := px-r, py-r
+		return dx*dx+dy*dy <= r*r
+	}
+	for py := range avatarSize {
+		for px := range avatarSize {
+			if !inCircle(px, py) {
+				continue
+			}
+			c := color.Color(previewTrack)
+			if avatar != nil {
+				// Nearest neighbour scaling is enough for a photo this size
+				b := avatar.Bounds()
+				c = avatar.At(b.Min.X+px*b.Dx()/avatarSize, b.Min.Y+py*b.Dy()/avatarSize)
+			}
+			img.Set(x+px, y+py, c)
+		}
+	}
+	if avatar == nil && login != "" {
+		initial := string([]rune(login)[0])
+		const scale = 16
+		drawText(img, initial, x+r-glyphWidth*scale/2, y+r-7*scale/2, scale, previewText)
+	}
+}
+
+// drawMedal draws a medal on a ribbon centered on x, y
+func drawMedal(img *image.RGBA, x, y int) {
+	const radius, rim = 90, 12
+	draw.Draw(img, image.Rect(x-50, y-200, x-15, y-40), &image.Uniform{medalRibbon}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(x+15, y-200, x+50, y-40), &image.Uniform{medalRibbon}, image.Point{}, draw.Src)
+	for py := -radius; py <= radius; py++ {
+		for px := -radius; px <= radius; px++ {
+			d := px*px + py*py
+			switch {
+			case d <= (radius-rim)*(radius-rim):
+				img.Set(x+px, y+py, medalGold)
+			case d <= radius*radius:
+				img.Set(x+px, y+py, medalRim)
+			}
+		}
+	}
+	// A five-pointed star in the middle
+	star := make([][2]float64, 10)
+	for i := range star {
+		r := 50.0
+		if i%2 == 1 {
+			r = 20
+		}
+		angle := -math.Pi/2 + float64(i)*math.Pi/5
+		star[i] = [2]float64{float64(x) + r*math.Cos(angle), float64(y) + r*math.Sin(angle)}
+	}
+	for py := y - 50; py <= y+50; py++ {
+		for px := x - 50; px <= x+50; px++ {
+			if inPolygon(star, float64(px)+0.5, float64(py)+0.5) {
+				img.Set(px, py, medalRim)
+			}
+		}
+	}
+}
+
+// inPolygon reports whether the point x, y is inside the polygon, by the
+// even-odd rule
+func inPolygon(polygon [][2]float64, x, y float64) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a[1] > y) != (b[1] > y) && x < (b[0]-a[0])*(y-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// wrapText splits s into at most maxLines lines of up to width characters at
+// spaces, truncating what does not fit
+func wrapText(s string, width, maxLines int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, truncate(line, width))
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, truncate(line, width))
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := []rune(lines[maxLines-1])
+		lines[maxLines-1] = string(last[:min(len(last), width-2)]) + ".."
+	}
+	return lines
+}
+
+// shareCardSVG draws a card as an SVG. Unlike the PNG it has the localized
+// texts and the achievement's own icon. The avatar and icon files are embedded,
+// as images loaded from an SVG file cannot fetch anything.
+func (g *Generator) shareCardSVG(card shareCard, iconClass string, avatar image.Image, period models.Period) string {
+	esc := html.EscapeString
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Inter, system-ui, -apple-system, 'Segoe UI', sans-serif">`+"\n",
+		cardWidth, cardHeight, cardWidth, cardHeight)
+	fmt.Fprintf(&b, `  <rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(previewBackground))
+	fmt.Fprintf(&b, `  <text x="60" y="80" font-size="28" font-weight="600" fill="%s">Git Velocity</text>`+"\n", hexColor(previewMuted))
+
+	// Avatar
+	const r = avatarSize / 2
+	fmt.Fprintf(&b, `  <clipPath id="avatar"><circle cx="%d" cy="%d" r="%d"/></clipPath>`+"\n", 60+r, 160+r, r)
+	if uri := imageDataURI(avatar); uri != "" {
+		fmt.Fprintf(&b, `  <image href="%s" x="60" y="160" width="%d" height="%d" clip-path="url(#avatar)" preserveAspectRatio="xMidYMid slice"/>`+"\n", uri, avatarSize, avatarSize)
+	} else {
+		initial := ""
+		if card.Login != "" {
+			initial = strings.ToUpper(string([]rune(card.Login)[0]))
+		}
+		fmt.Fprintf(&b, `  <circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n", 60+r, 160+r, r, hexColor(previewTrack))
+		fmt.Fprintf(&b, `  <text x="%d" y="%d" font-size="120" font-weight="700" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`+"\n", 60+r, 160+r, hexColor(previewText), esc(initial))
+	}
+
+	// Texts
+	name := card.Name
+	if name == "" {
+		name = card.Login
+	}
+	fmt.Fprintf(&b, `  <text x="340" y="195" font-size="28" font-weight="600" fill="%s">%s</text>`+"\n", hexColor(previewBar), esc(g.catalog.T("share.achievement_unlocked")))
+	fmt.Fprintf(&b, `  <text x="340" y="265" font-size="56" font-weight="700" fill="%s">%s</text>`+"\n", hexColor(previewText), esc(card.Title))
+	fmt.Fprintf(&b, `  <text x="340" y="320" font-size="32" fill="%s">%s</text>`+"\n", hexColor(previewText), esc(name))
+	for i, line := range wrapText(card.Description, 40, 2) {
+		fmt.Fprintf(&b, `  <text x="340" y="%d" font-size="26" fill="%s">%s</text>`+"\n", 380+i*36, hexColor(previewMuted), esc(line))
+	}
+	fmt.Fprintf(&b, `  <text x="60" y="580" font-size="24" fill="%s">%s</text>`+"\n", hexColor(previewMuted), esc(g.periodLabel(period)))
+
+	// Badge: the medal with the achievement's icon
+	fmt.Fprintf(&b, `  <rect x="990" y="60" width="35" height="160" fill="%s"/>`+"\n", hexColor(medalRibbon))
+	fmt.Fprintf(&b, `  <rect x="1055" y="60" width="35" height="160" fill="%s"/>`+"\n", hexColor(medalRibbon))
+	fmt.Fprintf(&b, `  <circle cx="1040" cy="260" r="84" fill="%s" stroke="%s" stroke-width="12"/>`+"\n", hexColor(medalGold), hexColor(medalRim))
+	icon := g.icons.Icon(card.Achievement, iconClass)
+	if uri := g.iconDataURI(icon); uri != "" {
+		fmt.Fprintf(&b, `  <image href="%s" x="990" y="210" width="100" height="100"/>`+"\n", uri)
+	} else {
+		emoji := icon.Emoji
+		if emoji == "" {
+			emoji = emojiIcons.Icon(card.Achievement, iconClass).Emoji
+		}
+		fmt.Fprintf(&b, `  <text x="1040" y="260" font-size="88" text-anchor="middle" dominant-baseline="central">%s</text>`+"\n", esc(emoji))
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// emojiIcons stands in for FontAwesome icons, which an SVG file cannot load
+var emojiIcons, _ = icons.Load(icons.Emoji, "")
+
+// iconDataURI embeds the file of an SVG pack icon, or returns ""
+func (g *Generator) iconDataURI(icon icons.Icon) string {
+	if icon.SVG == "" {
+		return ""
+	}
+	source, ok := g.icons.Files()[icon.SVG]
+	if !ok {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Clean(source)) // #nosec G304 -- file of the configured icon pack
+	if err != nil {
+		return ""
+	}
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// imageDataURI encodes an image as a PNG data URI, or returns "" for nil
+func imageDataURI(img image.Image) string {
+	if img == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return ""
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// hexColor formats an opaque color as #rrggbb
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
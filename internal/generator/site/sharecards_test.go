@@ -0,0 +1,134 @@
+package site
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+var avatarColor = color.RGBA{0x12, 0x34, 0x56, 0xff}
+
+// avatarPNG is an avatar of a single color
+func avatarPNG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	draw.Draw(img, img.Bounds(), &image.Uniform{avatarColor}, image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func readShareCards(t *testing.T, dir string) shareCardsDocument {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, sharesDir, "index.json"))
+	require.NoError(t, err)
+	var doc shareCardsDocument
+	require.NoError(t, json.Unmarshal(content, &doc))
+	return doc
+}
+
+func TestGenerator_ShareCards(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.ShareCards = true
+	cfg.Output.SiteURL = "https://velocity.example.com"
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+	avatar := avatarPNG(t)
+	var fetched []string
+	gen.SetAvatarSource(func(url string) ([]byte, error) {
+		fetched = append(fetched, url)
+		return avatar, nil
+	})
+
+	// First run: every achievement held is new
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1", "commit-10")))
+	doc := readShareCards(t, dir)
+	require.Len(t, doc.Cards, 3)
+	assert.Equal(t, shareCard{
+		Login:       "alice",
+		Achievement: "commit-1",
+		Title:       "First Steps",
+		Description: "Made your first commit",
+		PNG:         "shares/alice/commit-1.png",
+		SVG:         "shares/alice/commit-1.svg",
+		URL:         "https://velocity.example.com/shares/alice/commit-1.png",
+	}, doc.Cards[0])
+	assert.Equal(t, "commit-10", doc.Cards[1].Achievement)
+	assert.Equal(t, "bob", doc.Cards[2].Login, "cards follow the leaderboard")
+	assert.Equal(t, []string{"https://example.com/alice.png"}, fetched, "avatars are fetched once, bob has none")
+
+	content, err := os.ReadFile(filepath.Join(dir, "shares", "alice", "commit-1.png"))
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, cardWidth, cardHeight), img.Bounds())
+	assert.Equal(t, color.Color(avatarColor), img.At(60+avatarSize/2, 160+avatarSize/2), "the avatar is drawn")
+
+	svg, err := os.ReadFile(filepath.Join(dir, "shares", "alice", "commit-1.svg"))
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), `<image href="data:image/png;base64,`)
+	assert.Contains(t, string(svg), ">Achievement unlocked</text>")
+	assert.Contains(t, string(svg), ">First Steps</text>")
+	svg, err = os.ReadFile(filepath.Join(dir, "shares", "bob", "commit-1.svg"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(svg), "<image", "without an avatar the initial is drawn")
+	assert.Contains(t, string(svg), ">B</text>")
+
+	// Next run: only what was earned since
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1", "commit-10", "commit-50")))
+	doc = readShareCards(t, dir)
+	require.Len(t, doc.Cards, 1)
+	assert.Equal(t, "commit-50", doc.Cards[0].Achievement)
+	assert.NoFileExists(t, filepath.Join(dir, "shares", "bob", "commit-1.png"), "cards of earlier runs are removed")
+
+	// Turned off: the cards are gone
+	cfg.Output.ShareCards = false
+	require.NoError(t, gen.Generate(wallboardMetrics()))
+	assert.NoDirExists(t, filepath.Join(dir, sharesDir))
+}
+
+func TestGenerator_ShareCardsWithoutAvatars(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.ShareCards = true
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+	gen.SetAvatarSource(func(string) ([]byte, error) { return nil, errors.New("offline") })
+
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1")))
+	doc := readShareCards(t, dir)
+	require.Len(t, doc.Cards, 2)
+	assert.Empty(t, doc.Cards[0].URL, "no URL without output.site_url")
+
+	content, err := os.ReadFile(filepath.Join(dir, "shares", "alice", "commit-1.png"))
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(content))
+	require.NoError(t, err)
+	r, g, b, _ := img.At(60+10, 160+avatarSize/2).RGBA()
+	assert.Equal(t, [3]uint32{0x1f1f, 0x2929, 0x3737}, [3]uint32{r, g, b}, "the initial's background is drawn")
+}
+
+func TestWrapText(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"Make your first", "commit"}, wrapText("Make your first commit", 15, 2))
+	assert.Equal(t, []string{"Merge 100 pull", "requests to t.."}, wrapText("Merge 100 pull requests to the main branch", 15, 2))
+	assert.Nil(t, wrapText("", 10, 2))
+}
@@ -0,0 +1,75 @@
+package site
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+//go:embed tables.html.tmpl
+var tablesTemplate string
+
+// spaMountPoint is the element of index.html the dashboard renders into; the
+// no-JavaScript fallback is inserted right after it
+const spaMountPoint = `<div id="app"></div>`
+
+// tablesData is the view model of tables.html and the index.html fallback
+type tablesData struct {
+	Locale       string
+	Period       string
+	Leaderboard  []models.LeaderboardEntry
+	Groups       []models.GroupMetrics
+	Repositories []models.RepositoryMetrics
+	Teams        []models.TeamMetrics
+	Contributors []models.ContributorMetrics
+}
+
+// generateTables writes tables.html, a server-rendered page with every metric
+// as a plain HTML table, and adds the leaderboard to index.html inside
+// <noscript>, so the dashboard is usable without JavaScript and by screen readers
+func (g *Generator) generateTables(metrics *models.GlobalMetrics) error {
+	tmpl, err := template.New("tables").Funcs(g.templateFuncs()).Parse(tablesTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse tables template: %w", err)
+	}
+
+	data := tablesData{
+		Locale:       g.catalog.Locale,
+		Period:       g.periodLabel(metrics.Period),
+		Leaderboard:  metrics.Leaderboard,
+		Groups:       metrics.Groups,
+		Repositories: metrics.Repositories,
+		Teams:        metrics.Teams,
+		Contributors: append([]models.ContributorMetrics(nil), metrics.Contributors...),
+	}
+	sort.SliceStable(data.Contributors, func(i, j int) bool {
+		return data.Contributors[i].Score.Total > data.Contributors[j].Score.Total
+	})
+
+	var page bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&page, "page", data); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(g.outputDir, "tables.html"), page.Bytes(), 0600); err != nil {
+		return err
+	}
+
+	var noscript bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&noscript, "noscript", data); err != nil {
+		return err
+	}
+	indexPath := filepath.Join(g.outputDir, "index.html")
+	index, err := os.ReadFile(filepath.Clean(indexPath))
+	if err != nil {
+		return err
+	}
+	patched := strings.Replace(string(index), spaMountPoint, spaMountPoint+noscript.String(), 1)
+	return os.WriteFile(indexPath, []byte(patched), 0600)
+}
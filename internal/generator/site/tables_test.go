@@ -0,0 +1,86 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func tablesMetrics() *models.GlobalMetrics {
+	metrics := wallboardMetrics("commit-1")
+	metrics.Contributors[0].CommitCount = 1234
+	metrics.Contributors[0].Score.Total = 420
+	metrics.Contributors[1].Score.Total = 10
+	metrics.Leaderboard[1].ProRating = &models.ProRating{RawTotal: 5, MemberDays: 15, PeriodDays: 30, Factor: 2}
+	metrics.Repositories = []models.RepositoryMetrics{
+		{Owner: "acme", Name: "api", FullName: "acme/api", TotalCommits: 1500, ActiveContributors: 2},
+	}
+	metrics.Teams = []models.TeamMetrics{
+		{Name: "Platform Team", Members: []string{"alice"}, TotalScore: 420, AvgScore: 420, Capacity: 0.5, PerFTE: models.TeamPerFTE{Score: 840}},
+	}
+	metrics.Groups = []models.GroupMetrics{
+		{Name: "Core Services", Repositories: []string{"acme/api"}, TotalCommits: 1500, TotalScore: 430},
+	}
+	return metrics
+}
+
+func TestGenerator_Tables(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	gen, err := NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, gen.Generate(tablesMetrics()))
+
+	content, err := os.ReadFile(filepath.Join(dir, "tables.html"))
+	require.NoError(t, err)
+	html := string(content)
+
+	assert.Contains(t, html, `<html lang="en">`)
+	assert.Contains(t, html, `<a class="skip" href="#content">Skip to content</a>`)
+	assert.Contains(t, html, `<caption>Leaderboard</caption>`)
+	assert.Contains(t, html, `<th scope="col">Contributor</th>`)
+	assert.Contains(t, html, `Alice &lt;Admin&gt; (alice)`, "names are escaped")
+	assert.Contains(t, html, `<a href="./#/repos/acme/api">acme/api</a>`)
+	assert.Contains(t, html, `<td class="num">1,500</td>`)
+	assert.Contains(t, html, `<a href="./#/teams/platform-team">Platform Team</a>`)
+	assert.Contains(t, html, `<a href="./#/groups/core-services">Core Services</a>`)
+	assert.Contains(t, html, `<td class="num">1,234</td>`)
+	assert.Contains(t, html, `<th scope="col" class="num">Score per FTE</th>`)
+	assert.Contains(t, html, `<td class="num">840.0</td>`)
+	assert.Contains(t, html, `<abbr title="Pro-rated from 5 points: member for 15 of 30 days">*</abbr>`)
+
+	// Contributors are listed by score
+	assert.Less(t, strings.Index(html, `id="contributor-alice"`), strings.Index(html, `id="contributor-bob"`))
+}
+
+func TestGenerator_TablesNoscriptFallback(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.Locale = "de"
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+
+	// Generating twice must not stack fallbacks, since index.html is re-copied
+	require.NoError(t, gen.Generate(tablesMetrics()))
+	require.NoError(t, gen.Generate(tablesMetrics()))
+
+	content, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	html := string(content)
+
+	assert.Equal(t, 1, strings.Count(html, "<noscript>"))
+	assert.Contains(t, html, `<div id="app"></div>`)
+	assert.Contains(t, html, `<a href="./tables.html">Alle Kennzahlen als Tabellen anzeigen</a>`)
+	assert.Contains(t, html, `<caption>Bestenliste</caption>`)
+	assert.NotContains(t, html, `id="contributors"`, "only the leaderboard is inlined")
+}
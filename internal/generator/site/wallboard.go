@@ -0,0 +1,209 @@
+package site
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/compare"
+	"github.com/lukaszraczylo/git-velocity/internal/icons"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+//go:embed wallboard.html.tmpl
+var wallboardTemplate string
+
+const (
+	// wallboardLeaders is the number of leaderboard rows that fit on a TV
+	wallboardLeaders = 10
+	// wallboardAchievements caps the achievements panel
+	wallboardAchievements = 12
+)
+
+// wallboardData is the view model of wallboard.html
+type wallboardData struct {
+	Locale            string
+	OfflineAssets     bool // Link the local icon stylesheet instead of the CDNs
+	OfflineStylesheet string
+	Period            string
+	Leaders           []models.LeaderboardEntry
+	Week              []wallboardStat
+	WeekLabel         string
+	Achievements      []wallboardAchievement
+	Recent            bool // Achievements were earned since the previous run, rather than the rarest ones
+}
+
+// wallboardStat is one weekly stat tile
+type wallboardStat struct {
+	Name  string
+	Color string
+	Value float64
+	Delta float64 // Change from the week before
+}
+
+// wallboardAchievement is an achievement shown with its earner
+type wallboardAchievement struct {
+	ID          string
+	Login       string
+	AvatarURL   string
+	Name        string
+	Description string
+	Icon        icons.Icon
+}
+
+// loadPreviousRun reads the metrics generated into the output directory by the
+// previous run, before they are replaced. It returns nil if there are none.
+func (g *Generator) loadPreviousRun() *models.GlobalMetrics {
+	previous, err := compare.Load(g.outputDir)
+	if err != nil {
+		return nil
+	}
+	return previous
+}
+
+// generateWallboard writes wallboard.html, a kiosk page for office TVs that
+// rotates between the leaderboard, this week's stats and achievements
+func (g *Generator) generateWallboard(metrics, previous *models.GlobalMetrics) error {
+	c := g.catalog
+	tmpl, err := template.New("wallboard").Funcs(g.templateFuncs()).Parse(wallboardTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse wallboard template: %w", err)
+	}
+
+	data := wallboardData{
+		Locale:            c.Locale,
+		OfflineAssets:     g.config.Output.OfflineAssets,
+		OfflineStylesheet: offlineStylesheet,
+		Period:            g.periodLabel(metrics.Period),
+		Leaders:           metrics.Leaderboard,
+	}
+	if len(data.Leaders) > wallboardLeaders {
+		data.Leaders = data.Leaders[:wallboardLeaders]
+	}
+	data.WeekLabel, data.Week = weeklyStats(metrics.VelocityTimeline)
+	data.WeekLabel = g.weekLabel(data.WeekLabel)
+	for i := range data.Week {
+		key := "series." + strings.ToLower(data.Week[i].Name)
+		if name := c.T(key); name != key {
+			data.Week[i].Name = name
+		}
+	}
+	data.Achievements, data.Recent = g.wallboardAchievements(metrics, previous)
+
+	path := filepath.Join(g.outputDir, "wallboard.html")
+	file, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return tmpl.Execute(file, data)
+}
+
+// periodLabel formats the analysis period in the configured locale
+func (g *Generator) periodLabel(period models.Period) string {
+	if period.Start.IsZero() || period.End.IsZero() {
+		return g.catalog.T("period.all_time")
+	}
+	return g.catalog.FormatDate(period.Start) + " – " + g.catalog.FormatDate(period.End)
+}
+
+// weekLabel reformats a velocity timeline label ("Jan 2") in the configured
+// locale, leaving labels in any other format as they are
+func (g *Generator) weekLabel(label string) string {
+	week, err := time.Parse("Jan 2", label)
+	if err != nil {
+		return label
+	}
+	return g.catalog.FormatShortDate(week)
+}
+
+// weeklyStats returns the latest week of the velocity timeline and its change
+// from the week before
+func weeklyStats(timeline *models.VelocityTimeline) (string, []wallboardStat) {
+	if timeline == nil || len(timeline.Labels) == 0 {
+		return "", nil
+	}
+	last := len(timeline.Labels) - 1
+
+	stats := make([]wallboardStat, 0, len(timeline.Series))
+	for _, s := range timeline.Series {
+		if len(s.Data) <= last {
+			continue
+		}
+		stat := wallboardStat{Name: s.Name, Color: s.Color, Value: s.Data[last]}
+		if last > 0 {
+			stat.Delta = s.Data[last] - s.Data[last-1]
+		}
+		stats = append(stats, stat)
+	}
+	return timeline.Labels[last], stats
+}
+
+// wallboardAchievements returns the achievements earned since the previous run.
+// Achievements carry no earn date, so without a previous run (or when nothing
+// new was earned) the rarest earned achievements are shown instead.
+func (g *Generator) wallboardAchievements(metrics, previous *models.GlobalMetrics) ([]wallboardAchievement, bool) {
+	definitions := make(map[string]wallboardAchievement)
+	for _, a := range g.config.Scoring.GetAchievements() {
+		definition := wallboardAchievement{ID: a.ID, Name: a.Name, Description: a.Description, Icon: g.icons.Icon(a.ID, a.Icon)}
+		if text, ok := g.catalog.Achievement(a.ID); ok {
+			definition.Name, definition.Description = text.Name, text.Description
+		}
+		definitions[a.ID] = definition
+	}
+	avatars := make(map[string]string, len(metrics.Contributors))
+	for _, c := range metrics.Contributors {
+		avatars[c.Login] = c.AvatarURL
+	}
+	earned := func(login, id string) (wallboardAchievement, bool) {
+		a, ok := definitions[id]
+		a.Login = login
+		a.AvatarURL = avatars[login]
+		return a, ok
+	}
+
+	var achievements []wallboardAchievement
+	if previous != nil {
+		for _, delta := range compare.Compare(previous, metrics).Contributors {
+			for _, id := range delta.AchievementsGained {
+				if a, ok := earned(delta.Login, id); ok {
+					achievements = append(achievements, a)
+				}
+			}
+		}
+	}
+	if len(achievements) > 0 {
+		if len(achievements) > wallboardAchievements {
+			achievements = achievements[:wallboardAchievements]
+		}
+		return achievements, true
+	}
+
+	// Rarest first; ties keep leaderboard order
+	earners := make(map[string]int)
+	for _, c := range metrics.Contributors {
+		for _, id := range c.Achievements {
+			earners[id]++
+		}
+	}
+	for _, entry := range metrics.Leaderboard {
+		for _, id := range entry.Achievements {
+			if a, ok := earned(entry.Login, id); ok {
+				achievements = append(achievements, a)
+			}
+		}
+	}
+	sort.SliceStable(achievements, func(i, j int) bool {
+		return earners[achievements[i].ID] < earners[achievements[j].ID]
+	})
+	if len(achievements) > wallboardAchievements {
+		achievements = achievements[:wallboardAchievements]
+	}
+	return achievements, false
+}
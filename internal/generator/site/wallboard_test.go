@@ -0,0 +1,153 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func wallboardMetrics(achievements ...string) *models.GlobalMetrics {
+	return &models.GlobalMetrics{
+		Period: models.Period{Label: "All Time"},
+		Contributors: []models.ContributorMetrics{
+			{Login: "alice", AvatarURL: "https://example.com/alice.png", Achievements: achievements},
+			{Login: "bob", Achievements: []string{"commit-1"}},
+		},
+		Leaderboard: []models.LeaderboardEntry{
+			{Rank: 1, Login: "alice", Name: "Alice <Admin>", Score: 420, Team: "Platform", Achievements: achievements},
+			{Rank: 2, Login: "bob", Score: 10, Achievements: []string{"commit-1"}},
+		},
+		VelocityTimeline: &models.VelocityTimeline{
+			Labels: []string{"Jan 1", "Jan 8"},
+			Series: []models.VelocityTimelineSeries{
+				{Name: "Commits", Color: "#10b981", Data: []float64{10, 14}},
+				{Name: "Reviews", Color: "#8b5cf6", Data: []float64{5, 2}},
+			},
+		},
+	}
+}
+
+func TestGenerator_Wallboard(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.Wallboard = true
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1", "commit-10")))
+
+	content, err := os.ReadFile(filepath.Join(dir, "wallboard.html"))
+	require.NoError(t, err)
+	html := string(content)
+
+	assert.Contains(t, html, "All Time")
+	assert.Contains(t, html, "Alice &lt;Admin&gt;", "names are escaped")
+	assert.Contains(t, html, "Platform")
+	assert.Contains(t, html, "Week of Jan 8")
+	assert.Contains(t, html, "&#43;4 vs previous week")
+	assert.Contains(t, html, `<html lang="en">`)
+	assert.Contains(t, html, "-3 vs previous week")
+	// First run: nothing to compare with, so the rarest achievements are shown
+	assert.Contains(t, html, "Rarest achievements")
+	assert.Contains(t, html, "Getting Started")
+}
+
+func TestGenerator_WallboardRecentAchievements(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.Wallboard = true
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1")))
+	require.NoError(t, gen.Generate(wallboardMetrics("commit-1", "commit-10")))
+
+	content, err := os.ReadFile(filepath.Join(dir, "wallboard.html"))
+	require.NoError(t, err)
+	html := string(content)
+
+	assert.Contains(t, html, "New achievements")
+	assert.Contains(t, html, "Getting Started")
+	assert.NotContains(t, html, "First Steps", "achievements held in the previous run are not new")
+}
+
+func TestGenerator_WallboardLocalized(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Output.Wallboard = true
+	cfg.Output.Locale = "de"
+	gen, err := NewGenerator(dir, cfg)
+	require.NoError(t, err)
+
+	metrics := wallboardMetrics("commit-1")
+	metrics.Leaderboard[0].Score = 12500
+	metrics.Period = models.Period{
+		Start: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, gen.Generate(metrics))
+
+	content, err := os.ReadFile(filepath.Join(dir, "wallboard.html"))
+	require.NoError(t, err)
+	html := string(content)
+
+	assert.Contains(t, html, `<html lang="de">`)
+	assert.Contains(t, html, "1. März 2025 – 31. März 2025")
+	assert.Contains(t, html, "Bestenliste")
+	assert.Contains(t, html, "12.500")
+	assert.Contains(t, html, "Woche vom 8. Jan.")
+	assert.Contains(t, html, "Erste Schritte")
+
+	locale, err := os.ReadFile(filepath.Join(dir, "data", "locale.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(locale), `"locale": "de"`)
+}
+
+func TestNewGenerator_UnsupportedLocale(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Output.Locale = "xx"
+	_, err := NewGenerator(t.TempDir(), cfg)
+	require.Error(t, err)
+}
+
+func TestGenerator_WallboardDisabled(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	gen, err := NewGenerator(dir, config.DefaultConfig())
+	require.NoError(t, err)
+
+	require.NoError(t, gen.Generate(wallboardMetrics()))
+	assert.NoFileExists(t, filepath.Join(dir, "wallboard.html"))
+}
+
+func TestWeeklyStats(t *testing.T) {
+	t.Parallel()
+
+	label, stats := weeklyStats(nil)
+	assert.Empty(t, label)
+	assert.Empty(t, stats)
+
+	label, stats = weeklyStats(&models.VelocityTimeline{
+		Labels: []string{"Jan 1"},
+		Series: []models.VelocityTimelineSeries{{Name: "PRs", Data: []float64{3}}},
+	})
+	assert.Equal(t, "Jan 1", label)
+	require.Len(t, stats, 1)
+	assert.Equal(t, wallboardStat{Name: "PRs", Value: 3}, stats[0])
+}
@@ -0,0 +1,83 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+// mainBranchNames are the long-lived branches counted with
+// config.CommitBranchesMain, besides the default branch
+var mainBranchNames = map[string]bool{
+	"main":        true,
+	"master":      true,
+	"develop":     true,
+	"development": true,
+	"trunk":       true,
+}
+
+// SetCommitBranches selects the branches whose commits are read
+// (config.CommitBranches*; empty reads all branches and tags)
+func (r *Repository) SetCommitBranches(mode string) {
+	r.commitBranches = mode
+}
+
+// commitRefs returns the refs whose history is read: every branch, remote
+// branch and tag, or only the default branch (HEAD of the clone) and,
+// with config.CommitBranchesMain, the long-lived branches
+func (r *Repository) commitRefs(repo *git.Repository) ([]*plumbing.Reference, error) {
+	var head *plumbing.Reference
+	if r.commitBranches == config.CommitBranchesDefault || r.commitBranches == config.CommitBranchesMain {
+		var err error
+		if head, err = repo.Head(); err != nil {
+			return nil, err
+		}
+		if r.commitBranches == config.CommitBranchesDefault {
+			return []*plumbing.Reference{head}, nil
+		}
+	}
+
+	iter, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+	var refs []*plumbing.Reference
+	if head != nil {
+		refs = append(refs, head)
+	}
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		name := ref.Name()
+		switch {
+		case head != nil:
+			if (name.IsBranch() || name.IsRemote()) && isMainBranch(name) {
+				refs = append(refs, ref)
+			}
+		case name.IsBranch() || name.IsRemote() || name.IsTag():
+			refs = append(refs, ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// isMainBranch reports whether a local or remote branch is long-lived:
+// main, master, develop, trunk or a release branch
+func isMainBranch(name plumbing.ReferenceName) bool {
+	short := name.Short()
+	if name.IsRemote() {
+		// origin/release/1.2 -> release/1.2
+		if _, branch, ok := strings.Cut(short, "/"); ok {
+			short = branch
+		}
+	}
+	return mainBranchNames[short] || strings.HasPrefix(short, "release/") || strings.HasPrefix(short, "release-")
+}
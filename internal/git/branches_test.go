@@ -0,0 +1,74 @@
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+func TestRepository_FetchCommitsBranches(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+	dir := r.repoPath("org", "branches")
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Hour)
+	commitFiles(t, repo, dir, start.Add(1*time.Minute), map[string]string{"main.go": "package main\n"})
+	master, err := repo.Head()
+	require.NoError(t, err)
+
+	branch := func(name string, minute time.Duration) {
+		require.NoError(t, wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(name), Hash: master.Hash(), Create: true}))
+		commitFiles(t, repo, dir, start.Add(minute*time.Minute), map[string]string{name + ".go": "package main\n"})
+	}
+	branch("release/1.0", 2)
+	branch("experiment", 3)
+	require.NoError(t, wt.Checkout(&gogit.CheckoutOptions{Branch: master.Name()}))
+
+	for mode, want := range map[string]int{
+		config.CommitBranchesAll:     3,
+		config.CommitBranchesMain:    2,
+		config.CommitBranchesDefault: 1,
+	} {
+		scoped := *r
+		scoped.SetCommitBranches(mode)
+		commits, err := scoped.FetchCommits(context.Background(), "org", "branches", &start, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, commits, want, mode)
+
+		if scoped.UseSystemGit("", 0) == nil {
+			commits, err = scoped.FetchCommits(context.Background(), "org", "branches", &start, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, commits, want, mode+" with system git")
+		}
+	}
+}
+
+func TestIsMainBranch(t *testing.T) {
+	t.Parallel()
+
+	tests := map[plumbing.ReferenceName]bool{
+		"refs/heads/main":                  true,
+		"refs/heads/develop":               true,
+		"refs/heads/release/2.1":           true,
+		"refs/remotes/origin/master":       true,
+		"refs/remotes/origin/release-2024": true,
+		"refs/heads/feature/main":          false,
+		"refs/remotes/origin/fix-login":    false,
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, isMainBranch(name), name)
+	}
+}
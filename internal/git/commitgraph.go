@@ -0,0 +1,166 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	commitgraphfmt "github.com/go-git/go-git/v5/plumbing/format/commitgraph/v2"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/object/commitgraph"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// commitGraphPath is where git and go-git look for the commit-graph file
+func commitGraphPath(repoPath string) string {
+	return filepath.Join(repoPath, ".git", "objects", "info", "commit-graph")
+}
+
+// openCommitNodes returns the commit nodes of a repository, read from its
+// commit-graph file when it has one. Walking the graph needs no commit
+// objects to be inflated, only those the caller asks for.
+func openCommitNodes(repo *git.Repository) (commitgraph.CommitNodeIndex, func()) {
+	if fs, ok := repo.Storer.(*filesystem.Storage); ok {
+		if index, err := commitgraphfmt.OpenChainOrFileIndex(fs.Filesystem()); err == nil {
+			return commitgraph.NewGraphCommitNodeIndex(index, repo.Storer), func() { _ = index.Close() }
+		}
+	}
+	return commitgraph.NewObjectCommitNodeIndex(repo.Storer), func() {}
+}
+
+// updateCommitGraph writes the commit-graph file of a clone unless it
+// already covers every ref. Shallow clones get none, as the graph can't
+// reference the missing parents.
+func updateCommitGraph(repoPath string) error {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+	if shallow, err := repo.Storer.Shallow(); err != nil || len(shallow) > 0 {
+		return err
+	}
+
+	if graphCoversRefs(repo) {
+		return nil
+	}
+
+	index, err := buildCommitGraph(repo)
+	if err != nil {
+		return err
+	}
+
+	path := commitGraphPath(repoPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "commit-graph-*")
+	if err != nil {
+		return fmt.Errorf("failed to write commit-graph: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := commitgraphfmt.NewEncoder(tmp).Encode(index); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write commit-graph: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write commit-graph: %w", err)
+	}
+	// The file takes precedence over a commit-graph chain written by git
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write commit-graph: %w", err)
+	}
+	return nil
+}
+
+// graphCoversRefs reports whether the commit-graph has the commit of every ref
+func graphCoversRefs(repo *git.Repository) bool {
+	fs, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return false
+	}
+	index, err := commitgraphfmt.OpenChainOrFileIndex(fs.Filesystem())
+	if err != nil {
+		return false
+	}
+	defer func() { _ = index.Close() }()
+
+	refs, err := repo.References()
+	if err != nil {
+		return false
+	}
+	covered := true
+	_ = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		if _, err := index.GetIndexByHash(ref.Hash()); err != nil {
+			// Annotated tags point to tag objects, which the graph doesn't hold
+			if _, err := repo.CommitObject(ref.Hash()); err == nil {
+				covered = false
+				return storer.ErrStop
+			}
+		}
+		return nil
+	})
+	return covered
+}
+
+// buildCommitGraph indexes every commit of a repository with its
+// generation number (1 for root commits, else one more than its parents')
+func buildCommitGraph(repo *git.Repository) (*commitgraphfmt.MemoryIndex, error) {
+	commits := make(map[plumbing.Hash]*commitgraphfmt.CommitData)
+	iter, err := repo.CommitObjects()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits[c.Hash] = &commitgraphfmt.CommitData{
+			TreeHash:     c.TreeHash,
+			ParentHashes: c.ParentHashes,
+			When:         c.Committer.When,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	// Depth-first without recursion, as histories can be very deep
+	for hash := range commits {
+		stack := []plumbing.Hash{hash}
+		for len(stack) > 0 {
+			top := commits[stack[len(stack)-1]]
+			if top.Generation > 0 {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			generation, ready := uint64(1), true
+			for _, parent := range top.ParentHashes {
+				p, ok := commits[parent]
+				if !ok {
+					return nil, fmt.Errorf("parent %s of a commit is missing", parent)
+				}
+				if p.Generation == 0 {
+					stack = append(stack, parent)
+					ready = false
+				} else {
+					generation = max(generation, p.Generation+1)
+				}
+			}
+			if ready {
+				top.Generation = generation
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	index := commitgraphfmt.NewMemoryIndex()
+	for hash, data := range commits {
+		index.Add(hash, data)
+	}
+	return index, nil
+}
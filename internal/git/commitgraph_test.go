@@ -0,0 +1,63 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCommitGraph(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+	dir := r.repoPath("org", "branches")
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Hour)
+	commitFiles(t, repo, dir, start.Add(1*time.Minute), map[string]string{"main.go": "package main\n"})
+	commitFiles(t, repo, dir, start.Add(2*time.Minute), map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+
+	// Branches sharing the history of master
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}))
+	commitFiles(t, repo, dir, start.Add(3*time.Minute), map[string]string{"feature.go": "package main\n"})
+	require.NoError(t, wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("stale"), Create: true}))
+
+	ctx := context.Background()
+	withoutGraph, err := r.FetchCommits(ctx, "org", "branches", &start, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, withoutGraph, 3, "every commit once")
+
+	require.NoError(t, updateCommitGraph(dir))
+	_, err = os.Stat(commitGraphPath(dir))
+	require.NoError(t, err)
+	assert.True(t, graphCoversRefs(repo))
+	if _, err := exec.LookPath("git"); err == nil {
+		out, err := exec.Command("git", "-C", dir, "commit-graph", "verify").CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	withGraph, err := r.FetchCommits(ctx, "org", "branches", &start, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, normalizeCommits(withoutGraph), normalizeCommits(withGraph))
+
+	// New commits are read before the graph is updated
+	commitFiles(t, repo, dir, start.Add(4*time.Minute), map[string]string{"stale.go": "package main\n"})
+	assert.False(t, graphCoversRefs(repo))
+	commits, err := r.FetchCommits(ctx, "org", "branches", &start, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, commits, 4)
+
+	require.NoError(t, updateCommitGraph(dir))
+	assert.True(t, graphCoversRefs(repo))
+}
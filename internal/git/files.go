@@ -0,0 +1,54 @@
+package git
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ReadFiles returns the content of the files at the tip of the current branch
+// of a repository's local clone whose slash-separated path matches, keyed by
+// path. Files are read from the commit rather than the working tree, so a
+// checkout left behind by an interrupted run doesn't change the result.
+func (r *Repository) ReadFiles(owner, name string, match func(path string) bool) (map[string][]byte, error) {
+	repo, err := git.PlainOpen(r.repoPath(owner, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD tree: %w", err)
+	}
+
+	files := make(map[string][]byte)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if !match(f.Name) {
+			return nil
+		}
+		reader, err := f.Reader()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		defer func() { _ = reader.Close() }()
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		files[f.Name] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,44 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ReadFiles(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+
+	dir := r.repoPath("org", "repo")
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	commitFiles(t, repo, dir, time.Now(), map[string]string{
+		"go.mod":           "module example.com/repo\n",
+		"web/package.json": `{"name": "web"}`,
+		"main.go":          "package main\n",
+	})
+
+	// Uncommitted changes are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/changed\n"), 0600))
+
+	files, err := r.ReadFiles("org", "repo", func(path string) bool {
+		return strings.HasSuffix(path, ".mod") || strings.HasSuffix(path, ".json")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"go.mod":           []byte("module example.com/repo\n"),
+		"web/package.json": []byte(`{"name": "web"}`),
+	}, files)
+
+	_, err = r.ReadFiles("org", "missing", func(string) bool { return true })
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Mainline returns the first-parent history of the current branch of a
+// repository's local clone, newest first. Merged branches appear as their
+// merge commit only. The walk stops a week before since, like FetchCommits.
+func (r *Repository) Mainline(owner, name string, since *time.Time) ([]models.MainlineCommit, error) {
+	repo, err := git.PlainOpen(r.repoPath(owner, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+
+	var cutoff time.Time
+	if since != nil {
+		cutoff = since.AddDate(0, 0, -7)
+	}
+
+	var mainline []models.MainlineCommit
+	for {
+		if commit.Committer.When.Before(cutoff) {
+			break
+		}
+		mainline = append(mainline, models.MainlineCommit{SHA: commit.Hash.String(), CommittedAt: commit.Committer.When})
+		if commit.NumParents() == 0 {
+			break
+		}
+		parent, err := commit.Parent(0)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			break // Shallow clones end at a parent missing from the object store
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read parent of %s: %w", commit.Hash, err)
+		}
+		commit = parent
+	}
+	return mainline, nil
+}
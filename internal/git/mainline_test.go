@@ -0,0 +1,58 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Mainline(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+
+	dir := r.repoPath("org", "repo")
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	commitFiles(t, repo, dir, start.AddDate(0, 0, -30), map[string]string{"old.txt": "old\n"})
+	commitFiles(t, repo, dir, start.Add(time.Hour), map[string]string{"a.txt": "a\n"})
+	main, err := repo.Head()
+	require.NoError(t, err)
+
+	// A feature branch merged with a merge commit
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}))
+	commitFiles(t, repo, dir, start.Add(2*time.Hour), map[string]string{"b.txt": "b\n"})
+	feature, err := repo.Head()
+	require.NoError(t, err)
+	require.NoError(t, wt.Checkout(&gogit.CheckoutOptions{Branch: main.Name()}))
+	sig := &object.Signature{Name: "Dev", Email: "dev@users.noreply.github.com", When: start.Add(3 * time.Hour)}
+	merge, err := wt.Commit("Merge pull request #1", &gogit.CommitOptions{
+		Author: sig, Committer: sig, AllowEmptyCommits: true,
+		Parents: []plumbing.Hash{main.Hash(), feature.Hash()},
+	})
+	require.NoError(t, err)
+
+	mainline, err := r.Mainline("org", "repo", &start)
+	require.NoError(t, err)
+	require.Len(t, mainline, 2, "the feature commit and the commit before the cutoff are left out")
+	assert.Equal(t, merge.String(), mainline[0].SHA)
+	assert.Equal(t, main.Hash().String(), mainline[1].SHA)
+	assert.True(t, mainline[1].CommittedAt.Equal(start.Add(time.Hour)))
+
+	all, err := r.Mainline("org", "repo", nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	_, err = r.Mainline("org", "missing", nil)
+	assert.Error(t, err)
+}
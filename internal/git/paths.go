@@ -0,0 +1,79 @@
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// windowsReservedNames cannot be used as file or directory names on Windows,
+// with or without an extension
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// pathComponent turns an owner or repository name into a directory name that
+// is valid on every platform.
+//
+// GitHub treats owner and repository names case-insensitively, so the name is
+// lowercased: "Owner/repo" and "owner/repo" share one clone instead of
+// colliding on case-insensitive file systems (Windows, macOS). Characters that
+// are invalid on Windows are replaced, and a short hash of the original name
+// is appended whenever that happens so different names never share a directory.
+func pathComponent(name string) string {
+	lower := strings.ToLower(name)
+
+	var b strings.Builder
+	for _, r := range lower {
+		switch {
+		case r < 0x20, strings.ContainsRune(`<>:"/\|?*`, r):
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	// Windows strips trailing dots and spaces, and "." / ".." would escape the base directory
+	clean := strings.TrimRight(b.String(), ". ")
+	if base, _, _ := strings.Cut(clean, "."); windowsReservedNames[strings.ToUpper(base)] {
+		clean += "_"
+	}
+
+	if clean == lower {
+		return clean
+	}
+	if clean == "" {
+		clean = "_"
+	}
+	sum := sha256.Sum256([]byte(lower))
+	return clean + "-" + hex.EncodeToString(sum[:3])
+}
+
+// migrateLegacyPath moves a clone made before paths were normalized
+// (baseDir/Owner/Name) to repoPath so it is not cloned again
+func migrateLegacyPath(baseDir, owner, name, repoPath string) {
+	// Names that needed more than lowercasing were never valid clone paths
+	if pathComponent(owner) != strings.ToLower(owner) || pathComponent(name) != strings.ToLower(name) {
+		return
+	}
+	legacyPath := filepath.Join(baseDir, owner, name)
+	if legacyPath == repoPath {
+		return
+	}
+	if _, err := os.Stat(filepath.Join(legacyPath, ".git")); err != nil {
+		return
+	}
+	// On case-insensitive file systems both paths name the same directory
+	if _, err := os.Stat(repoPath); err == nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(repoPath), 0750); err != nil {
+		return
+	}
+	_ = os.Rename(legacyPath, repoPath)
+}
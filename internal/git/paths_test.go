@@ -0,0 +1,68 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathComponent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "plain", input: "git-velocity", expected: "git-velocity"},
+		{name: "lowercased", input: "LukaszRaczylo", expected: "lukaszraczylo"},
+		{name: "dots allowed", input: "my.repo", expected: "my.repo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, pathComponent(tt.input))
+		})
+	}
+
+	// Same name in different case maps to the same directory
+	assert.Equal(t, pathComponent("Owner"), pathComponent("owner"))
+
+	// Invalid characters, reserved names and traversal get a disambiguating hash
+	for _, input := range []string{`a:b`, `a|b`, "con", "aux.txt", "..", "repo.", `..\evil`, "../evil"} {
+		got := pathComponent(input)
+		assert.NotContains(t, got, "/", input)
+		assert.NotContains(t, got, `\`, input)
+		assert.False(t, strings.HasSuffix(got, "."), input)
+		assert.NotEqual(t, strings.ToLower(input), got, input)
+		assert.Regexp(t, `-[0-9a-f]{6}$`, got, input)
+	}
+	assert.NotEqual(t, pathComponent("a:b"), pathComponent("a|b"))
+}
+
+func TestRepository_RepoPath(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+	assert.True(t, filepath.IsAbs(r.baseDir))
+	assert.Equal(t, r.repoPath("Owner", "Repo"), r.repoPath("owner", "repo"))
+	assert.Equal(t, filepath.Join(r.baseDir, "owner", "repo"), r.repoPath("Owner", "Repo"))
+}
+
+func TestMigrateLegacyPath(t *testing.T) {
+	t.Parallel()
+
+	baseDir := t.TempDir()
+	legacy := filepath.Join(baseDir, "Owner", "Repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(legacy, ".git"), 0750))
+
+	repoPath := filepath.Join(baseDir, "owner", "repo")
+	migrateLegacyPath(baseDir, "Owner", "Repo", repoPath)
+
+	_, err := os.Stat(filepath.Join(repoPath, ".git"))
+	assert.NoError(t, err)
+}
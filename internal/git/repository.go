@@ -2,7 +2,10 @@ package git
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
@@ -16,9 +19,12 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/object/commitgraph"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/lukaszraczylo/git-velocity/internal/diff"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
+	"github.com/lukaszraczylo/git-velocity/internal/pathfilter"
+	"github.com/lukaszraczylo/git-velocity/internal/redact"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
 // commitProgressBar handles terminal progress display for commit iteration
@@ -77,10 +83,36 @@ type ProgressCallback func(message string)
 type Repository struct {
 	baseDir  string
 	progress ProgressCallback
+
+	// TLS settings for clones and fetches behind corporate proxies
+	caBundle        []byte
+	insecureSkipTLS bool
+
+	// SSH key or agent when cloning over SSH (nil clones over HTTPS)
+	sshAuth transport.AuthMethod
+
+	// Remote holding <owner>/<name> repositories in place of github.com
+	// (mirrors and fixture repositories; empty clones from GitHub)
+	remoteBase string
+
+	// Branches whose commits are read (config.CommitBranches*)
+	commitBranches string
+
+	// git binary reading the commits of clones of at least systemGitMinSize
+	// bytes (empty always uses go-git)
+	systemGit        string
+	systemGitMinSize int64
 }
 
 // NewRepository creates a new repository manager
 func NewRepository(baseDir string) (*Repository, error) {
+	// Go only applies Windows long path handling (\\?\) to absolute paths, so deep
+	// checkouts below a relative clone directory would fail past MAX_PATH
+	baseDir, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base directory: %w", err)
+	}
+
 	// Create base directory if it doesn't exist
 	if err := os.MkdirAll(baseDir, 0750); err != nil {
 		return nil, fmt.Errorf("failed to create base directory: %w", err)
@@ -92,6 +124,14 @@ func NewRepository(baseDir string) (*Repository, error) {
 	}, nil
 }
 
+// SetTLS trusts the PEM certificates of caBundle on top of the system roots
+// for clones and fetches, or skips certificate verification when insecure is set.
+// Proxies are taken from the environment.
+func (r *Repository) SetTLS(caBundle []byte, insecure bool) {
+	r.caBundle = caBundle
+	r.insecureSkipTLS = insecure
+}
+
 // SetProgressCallback sets the callback function for progress reporting
 func (r *Repository) SetProgressCallback(cb ProgressCallback) {
 	if cb != nil {
@@ -101,7 +141,7 @@ func (r *Repository) SetProgressCallback(cb ProgressCallback) {
 
 // repoPath returns the local path for a repository
 func (r *Repository) repoPath(owner, name string) string {
-	return filepath.Join(r.baseDir, owner, name)
+	return filepath.Join(r.baseDir, pathComponent(owner), pathComponent(name))
 }
 
 // CloneOptions contains options for cloning a repository
@@ -113,22 +153,33 @@ type CloneOptions struct {
 // EnsureClonedWithOptions ensures a repository is cloned with specific options
 func (r *Repository) EnsureClonedWithOptions(ctx context.Context, owner, name, token string, opts *CloneOptions) error {
 	repoPath := r.repoPath(owner, name)
+	migrateLegacyPath(r.baseDir, owner, name, repoPath)
 
 	// Check if already cloned
 	gitDir := filepath.Join(repoPath, ".git")
 	if _, err := os.Stat(gitDir); err == nil {
 		// Repository exists, fetch latest
 		r.progress(fmt.Sprintf("      Updating local clone of %s/%s...", owner, name))
-		return r.fetch(ctx, repoPath, token)
+		if err := r.fetch(ctx, owner, name, repoPath, token); err != nil {
+			return err
+		}
+	} else {
+		// Clone the repository
+		if opts != nil && opts.Depth > 0 {
+			r.progress(fmt.Sprintf("      Shallow cloning %s/%s (depth: %d)...", owner, name, opts.Depth))
+		} else {
+			r.progress(fmt.Sprintf("      Cloning %s/%s...", owner, name))
+		}
+		if err := r.clone(ctx, owner, name, token, repoPath, opts); err != nil {
+			return err
+		}
 	}
 
-	// Clone the repository
-	if opts != nil && opts.Depth > 0 {
-		r.progress(fmt.Sprintf("      Shallow cloning %s/%s (depth: %d)...", owner, name, opts.Depth))
-	} else {
-		r.progress(fmt.Sprintf("      Cloning %s/%s...", owner, name))
+	// Speeds up walking the history; commits are read without it too
+	if err := updateCommitGraph(repoPath); err != nil {
+		r.progress(fmt.Sprintf("      Warning: failed to write commit-graph of %s/%s: %v", owner, name, err))
 	}
-	return r.clone(ctx, owner, name, token, repoPath, opts)
+	return nil
 }
 
 // clone clones a repository using go-git
@@ -138,11 +189,12 @@ func (r *Repository) clone(ctx context.Context, owner, name, token, destPath str
 		return fmt.Errorf("failed to create parent directory: %w", err)
 	}
 
-	cloneURL := fmt.Sprintf("https://github.com/%s/%s.git", owner, name)
-
 	cloneOpts := &git.CloneOptions{
-		URL:      cloneURL,
-		Progress: nil, // Could add progress writer here
+		URL:             r.cloneURL(owner, name),
+		Auth:            r.auth(token),
+		Progress:        nil, // Could add progress writer here
+		CABundle:        r.caBundle,
+		InsecureSkipTLS: r.insecureSkipTLS,
 	}
 
 	// Apply shallow clone depth if provided
@@ -150,71 +202,69 @@ func (r *Repository) clone(ctx context.Context, owner, name, token, destPath str
 		cloneOpts.Depth = opts.Depth
 	}
 
-	// Add authentication if token provided
-	if token != "" {
-		cloneOpts.Auth = &http.BasicAuth{
-			Username: "x-access-token",
-			Password: token,
-		}
-	}
-
 	_, err := git.PlainCloneContext(ctx, destPath, false, cloneOpts)
 	if err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
+		return fmt.Errorf("failed to clone repository: %w", redact.Error(err))
 	}
 
 	return nil
 }
 
 // fetch fetches latest changes from remote using go-git
-func (r *Repository) fetch(ctx context.Context, repoPath, token string) error {
+func (r *Repository) fetch(ctx context.Context, owner, name, repoPath, token string) error {
 	repo, err := git.PlainOpen(repoPath)
 	if err != nil {
 		return fmt.Errorf("failed to open repository: %w", err)
 	}
-
-	fetchOpts := &git.FetchOptions{
-		RemoteName: "origin",
-		Force:      true,
-		Prune:      true,
-		RefSpecs:   []config.RefSpec{"+refs/*:refs/*"},
+	if err := ensureOrigin(repo, r.cloneURL(owner, name)); err != nil {
+		return err
 	}
 
-	// Add authentication if token provided
-	if token != "" {
-		fetchOpts.Auth = &http.BasicAuth{
-			Username: "x-access-token",
-			Password: token,
-		}
+	fetchOpts := &git.FetchOptions{
+		RemoteName:      "origin",
+		Force:           true,
+		Prune:           true,
+		RefSpecs:        []config.RefSpec{"+refs/*:refs/*"},
+		Auth:            r.auth(token),
+		CABundle:        r.caBundle,
+		InsecureSkipTLS: r.insecureSkipTLS,
 	}
 
 	err = repo.FetchContext(ctx, fetchOpts)
 	if err != nil && err != git.NoErrAlreadyUpToDate {
-		return fmt.Errorf("failed to fetch: %w", err)
+		return fmt.Errorf("failed to fetch: %w", redact.Error(err))
 	}
 
 	return nil
 }
 
-// FetchCommits retrieves commits from the local repository using go-git
-func (r *Repository) FetchCommits(ctx context.Context, owner, name string, since, until *time.Time) ([]models.Commit, error) {
+// FetchCommits retrieves commits from the local repository using go-git.
+// When scope is set, only changes to matching paths are counted and commits
+// that touch none of them are skipped.
+func (r *Repository) FetchCommits(ctx context.Context, owner, name string, since, until *time.Time, scope *pathfilter.Filter) ([]models.Commit, error) {
 	repoPath := r.repoPath(owner, name)
+	if r.useSystemGit(repoPath) {
+		r.progress(fmt.Sprintf("      Reading commits of %s/%s with %s...", owner, name, r.systemGit))
+		return r.fetchCommitsSystem(ctx, owner, name, repoPath, since, until, scope)
+	}
 
 	repo, err := git.PlainOpen(repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open repository: %w", err)
 	}
 
-	// Get all references to iterate all branches
-	refs, err := repo.References()
+	// Get the references whose history is read
+	refs, err := r.commitRefs(repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get references: %w", err)
 	}
+	nodes, closeNodes := openCommitNodes(repo)
+	defer closeNodes()
 
-	// Collect all commit hashes from all branches
+	// Commits seen on any branch so far. Walks of later branches stop at
+	// them instead of revisiting the history they share.
 	seenCommits := make(map[plumbing.Hash]bool)
 	var commits []models.Commit
-	testPatterns := []string{"_test.go", ".test.", ".spec.", "/tests/", "/test/", "__tests__"}
 
 	// Progress bar for commit iteration
 	pbar := newCommitProgressBar("      Iterating commits:")
@@ -230,25 +280,25 @@ func (r *Repository) FetchCommits(ctx context.Context, owner, name string, since
 	// errStopIteration is used to signal early termination (not a real error)
 	var errStopIteration = fmt.Errorf("stop iteration")
 
-	err = refs.ForEach(func(ref *plumbing.Reference) error {
-		// Skip non-branch references
-		if !ref.Name().IsBranch() && !ref.Name().IsRemote() && !ref.Name().IsTag() {
+	// Commits whose changes couldn't be read, kept without line stats
+	statsFailed := 0
+	var statsErr error
+
+	walk := func(ref *plumbing.Reference) error {
+		if seenCommits[ref.Hash()] {
 			return nil
 		}
 
-		// Get commit iterator for this reference
-		commitIter, err := repo.Log(&git.LogOptions{
-			From:  ref.Hash(),
-			Order: git.LogOrderCommitterTime,
-			All:   false,
-		})
+		// Walk this reference in committer time order
+		node, err := nodes.Get(ref.Hash())
 		if err != nil {
 			// Skip refs that don't point to commits
 			return nil
 		}
+		commitIter := commitgraph.NewCommitNodeIterCTime(node, seenCommits, nil)
 
 		consecutiveOld := 0
-		err = commitIter.ForEach(func(c *object.Commit) error {
+		err = commitIter.ForEach(func(n commitgraph.CommitNode) error {
 			// Check context cancellation
 			select {
 			case <-ctx.Done():
@@ -256,11 +306,7 @@ func (r *Repository) FetchCommits(ctx context.Context, owner, name string, since
 			default:
 			}
 
-			// Skip already seen commits
-			if seenCommits[c.Hash] {
-				return nil
-			}
-			seenCommits[c.Hash] = true
+			seenCommits[n.ID()] = true
 			processedCount++
 
 			// Update progress every 10 commits to avoid too much I/O
@@ -268,6 +314,16 @@ func (r *Repository) FetchCommits(ctx context.Context, owner, name string, since
 				pbar.update(processedCount)
 			}
 
+			// Commits are authored before they are committed, so the commit
+			// time from the graph rules out old commits without loading them
+			if hardCutoff != nil && n.CommitTime().Before(*hardCutoff) {
+				return errStopIteration
+			}
+			c, err := n.Commit()
+			if err != nil {
+				return err
+			}
+
 			commitTime := c.Author.When
 
 			// Hard cutoff - stop entirely if past this date
@@ -291,44 +347,16 @@ func (r *Repository) FetchCommits(ctx context.Context, owner, name string, since
 			}
 
 			// Get file stats for this commit
-			stats := r.getCommitStats(c, testPatterns)
-
-			// Extract login from email
-			authorLogin := extractLoginFromEmail(c.Author.Email, c.Author.Name)
-			committerLogin := extractLoginFromEmail(c.Committer.Email, c.Committer.Name)
-
-			commit := models.Commit{
-				SHA:     c.Hash.String(),
-				Message: strings.Split(c.Message, "\n")[0], // First line only
-				Author: models.Author{
-					Login: authorLogin,
-					Name:  c.Author.Name,
-					Email: c.Author.Email,
-				},
-				Committer: models.Author{
-					Login: committerLogin,
-					Name:  c.Committer.Name,
-					Email: c.Committer.Email,
-				},
-				Date:                   commitTime,
-				Additions:              stats.Additions,
-				Deletions:              stats.Deletions,
-				MeaningfulAdditions:    stats.MeaningfulAdditions,
-				MeaningfulDeletions:    stats.MeaningfulDeletions,
-				CommentAdditions:       stats.CommentAdditions,
-				CommentDeletions:       stats.CommentDeletions,
-				DocCommentAdditions:    stats.DocCommentAdditions,
-				DocCommentDeletions:    stats.DocCommentDeletions,
-				CommentedCodeAdditions: stats.CommentedCodeAdditions,
-				CommentedCodeDeletions: stats.CommentedCodeDeletions,
-				FilesChanged:           stats.FilesChanged,
-				FilesModified:          stats.FilesModified,
-				Repository:             fmt.Sprintf("%s/%s", owner, name),
-				URL:                    fmt.Sprintf("https://github.com/%s/%s/commit/%s", owner, name, c.Hash.String()),
-				HasTests:               stats.HasTests,
+			stats, err := r.getCommitStats(c, scope)
+			if err != nil {
+				statsFailed++
+				statsErr = err
+			}
+			if !stats.InScope {
+				return nil
 			}
 
-			commits = append(commits, commit)
+			commits = append(commits, newCommit(owner, name, c.Hash.String(), c.Message, c.Author, c.Committer, stats))
 			return nil
 		})
 
@@ -344,10 +372,18 @@ func (r *Repository) FetchCommits(ctx context.Context, owner, name string, since
 		}
 
 		return err
-	})
+	}
+	for _, ref := range refs {
+		if err = walk(ref); err != nil {
+			break
+		}
+	}
 
 	// Complete progress bar
 	pbar.done(len(commits))
+	if statsFailed > 0 {
+		r.progress(fmt.Sprintf("      Warning: failed to read the changes of %d commits of %s/%s, counting them without line stats: %v", statsFailed, owner, name, statsErr))
+	}
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to iterate commits: %w", err)
@@ -369,13 +405,19 @@ type commitStats struct {
 	CommentedCodeAdditions int
 	CommentedCodeDeletions int
 	FilesChanged           int
-	FilesModified          []string // List of file paths modified
+	FilesModified          []string          // List of file paths modified
+	FileStats              []models.FileStat // Lines changed per file, for churn analysis
+	PatchID                string            // Hash of the changed lines, ignoring whitespace and line numbers
 	HasTests               bool
+	InScope                bool // Touches at least one path selected by the scope
 }
 
-// getCommitStats calculates additions, deletions, files changed for a commit
-func (r *Repository) getCommitStats(c *object.Commit, testPatterns []string) commitStats {
-	stats := commitStats{}
+// getCommitStats calculates additions, deletions, files changed for a commit,
+// counting only changes to paths selected by scope (nil selects everything)
+func (r *Repository) getCommitStats(c *object.Commit, scope *pathfilter.Filter) (commitStats, error) {
+	// Commits whose changes can't be read are kept: without their paths,
+	// they can't be told out of scope
+	unread := commitStats{InScope: true}
 
 	// Get parent commit for diff
 	parentIter := c.Parents()
@@ -388,7 +430,7 @@ func (r *Repository) getCommitStats(c *object.Commit, testPatterns []string) com
 
 	currentTree, err := c.Tree()
 	if err != nil {
-		return stats
+		return unread, fmt.Errorf("commit %s: %w", c.Hash, err)
 	}
 
 	// Get changes between parent and current
@@ -401,48 +443,16 @@ func (r *Repository) getCommitStats(c *object.Commit, testPatterns []string) com
 	}
 
 	if err != nil {
-		return stats
+		return unread, fmt.Errorf("commit %s: %w", c.Hash, err)
 	}
 
-	filesSet := make(map[string]bool)
-
+	counter := newStatsCounter(scope)
 	for _, change := range changes {
-		// Get the file path (prefer destination for renames/moves, fallback to source)
-		var filePath string
-		if change.To.Name != "" {
-			filePath = change.To.Name
-		} else if change.From.Name != "" {
-			filePath = change.From.Name
-		}
-
-		// Skip if no file path (shouldn't happen, but defensive)
-		if filePath == "" {
+		filePath, ok := counter.file(change.From.Name, change.To.Name)
+		if !ok {
 			continue
 		}
 
-		// Skip documentation files entirely
-		if diff.IsDocumentationFile(filePath) {
-			continue
-		}
-
-		// Check if this is a rename/move operation
-		isRename := diff.IsRenameOrMove(change.From.Name, change.To.Name)
-
-		// Count unique files (but NOT for renames - the file already existed)
-		if !isRename && !filesSet[filePath] {
-			filesSet[filePath] = true
-			stats.FilesChanged++
-			stats.FilesModified = append(stats.FilesModified, filePath)
-
-			// Check for test files
-			for _, pattern := range testPatterns {
-				if strings.Contains(filePath, pattern) {
-					stats.HasTests = true
-					break
-				}
-			}
-		}
-
 		// Get patch to count lines (even for renames, there may be content changes)
 		patch, err := change.Patch()
 		if err != nil {
@@ -456,48 +466,202 @@ func (r *Repository) getCommitStats(c *object.Commit, testPatterns []string) com
 			}
 
 			for _, chunk := range filePatch.Chunks() {
-				content := chunk.Content()
-				lines := strings.Split(content, "\n")
+				lines := strings.Split(chunk.Content(), "\n")
 
 				switch chunk.Type() {
 				case 1: // Add
-					for _, line := range lines {
-						stats.Additions++
-						if diff.IsMeaningfulLine(line) {
-							stats.MeaningfulAdditions++
-						} else if diff.IsCommentLine(line) {
-							stats.CommentAdditions++
-							// Further classify the comment type
-							if diff.IsDocCommentLine(line) {
-								stats.DocCommentAdditions++
-							} else if diff.IsCommentedOutCode(line) {
-								stats.CommentedCodeAdditions++
-							}
-						}
-						// Whitespace lines are neither meaningful nor comments
-					}
+					counter.lines(filePath, '+', lines)
 				case 2: // Delete
-					for _, line := range lines {
-						stats.Deletions++
-						if diff.IsMeaningfulLine(line) {
-							stats.MeaningfulDeletions++
-						} else if diff.IsCommentLine(line) {
-							stats.CommentDeletions++
-							// Further classify the comment type
-							if diff.IsDocCommentLine(line) {
-								stats.DocCommentDeletions++
-							} else if diff.IsCommentedOutCode(line) {
-								stats.CommentedCodeDeletions++
-							}
-						}
-						// Whitespace lines are neither meaningful nor comments
-					}
+					counter.lines(filePath, '-', lines)
+				}
+			}
+		}
+	}
+
+	return counter.result(), nil
+}
+
+// newCommit builds the commit model from a commit's metadata and stats
+func newCommit(owner, name, sha, message string, author, committer object.Signature, stats commitStats) models.Commit {
+	return models.Commit{
+		SHA:     sha,
+		Message: strings.Split(message, "\n")[0], // First line only
+		Author: models.Author{
+			Login: extractLoginFromEmail(author.Email, author.Name),
+			Name:  author.Name,
+			Email: author.Email,
+		},
+		Committer: models.Author{
+			Login: extractLoginFromEmail(committer.Email, committer.Name),
+			Name:  committer.Name,
+			Email: committer.Email,
+		},
+		Date:                   author.When,
+		Additions:              stats.Additions,
+		Deletions:              stats.Deletions,
+		MeaningfulAdditions:    stats.MeaningfulAdditions,
+		MeaningfulDeletions:    stats.MeaningfulDeletions,
+		CommentAdditions:       stats.CommentAdditions,
+		CommentDeletions:       stats.CommentDeletions,
+		DocCommentAdditions:    stats.DocCommentAdditions,
+		DocCommentDeletions:    stats.DocCommentDeletions,
+		CommentedCodeAdditions: stats.CommentedCodeAdditions,
+		CommentedCodeDeletions: stats.CommentedCodeDeletions,
+		FilesChanged:           stats.FilesChanged,
+		FilesModified:          stats.FilesModified,
+		FileStats:              stats.FileStats,
+		Repository:             fmt.Sprintf("%s/%s", owner, name),
+		URL:                    fmt.Sprintf("https://github.com/%s/%s/commit/%s", owner, name, sha),
+		HasTests:               stats.HasTests,
+		PatchID:                stats.PatchID,
+	}
+}
+
+// statsCounter accumulates the stats of a commit file by file. Both the
+// go-git and system git backends count through it, so that they agree.
+type statsCounter struct {
+	stats     commitStats
+	scope     *pathfilter.Filter
+	filesSet  map[string]bool
+	fileLines map[string]*models.FileStat
+	patchID   *patchHash
+}
+
+func newStatsCounter(scope *pathfilter.Filter) *statsCounter {
+	return &statsCounter{
+		stats:     commitStats{InScope: scope == nil},
+		scope:     scope,
+		filesSet:  make(map[string]bool),
+		fileLines: make(map[string]*models.FileStat),
+		patchID:   newPatchHash(),
+	}
+}
+
+// file records a change of a file from one path to another (either empty
+// for additions and deletions) and returns the path its lines count towards,
+// or false when the change is out of scope or to documentation
+func (s *statsCounter) file(from, to string) (string, bool) {
+	// Prefer destination for renames/moves, fallback to source
+	filePath := to
+	if filePath == "" {
+		filePath = from
+	}
+	if filePath == "" {
+		return "", false
+	}
+
+	// Monorepo scoping: a move into or out of scope still touches it
+	if !s.scope.MatchAny(from, to) {
+		return "", false
+	}
+	s.stats.InScope = true
+
+	// Skip documentation files entirely
+	if diff.IsDocumentationFile(filePath) {
+		return "", false
+	}
+
+	// Count unique files (but NOT for renames - the file already existed)
+	if !diff.IsRenameOrMove(from, to) && !s.filesSet[filePath] {
+		s.filesSet[filePath] = true
+		s.stats.FilesChanged++
+		s.stats.FilesModified = append(s.stats.FilesModified, filePath)
+
+		if diff.IsTestFile(filePath) {
+			s.stats.HasTests = true
+		}
+	}
+	return filePath, true
+}
+
+// lines counts lines added ('+') or deleted ('-') in a file
+func (s *statsCounter) lines(filePath string, op byte, lines []string) {
+	fileStat := s.fileLines[filePath]
+	if fileStat == nil {
+		fileStat = &models.FileStat{Path: filePath}
+		s.fileLines[filePath] = fileStat
+	}
+
+	added := op == '+'
+	if added {
+		fileStat.Additions += len(lines)
+		s.stats.Additions += len(lines)
+	} else {
+		fileStat.Deletions += len(lines)
+		s.stats.Deletions += len(lines)
+	}
+
+	for _, line := range lines {
+		s.patchID.add(filePath, op, line)
+		// Whitespace lines are neither meaningful nor comments
+		switch {
+		case diff.IsMeaningfulLine(line):
+			if added {
+				s.stats.MeaningfulAdditions++
+			} else {
+				s.stats.MeaningfulDeletions++
+			}
+		case diff.IsCommentLine(line):
+			// Further classify the comment type
+			docComment := diff.IsDocCommentLine(line)
+			commentedCode := !docComment && diff.IsCommentedOutCode(line)
+			if added {
+				s.stats.CommentAdditions++
+				if docComment {
+					s.stats.DocCommentAdditions++
+				} else if commentedCode {
+					s.stats.CommentedCodeAdditions++
+				}
+			} else {
+				s.stats.CommentDeletions++
+				if docComment {
+					s.stats.DocCommentDeletions++
+				} else if commentedCode {
+					s.stats.CommentedCodeDeletions++
 				}
 			}
 		}
 	}
+}
 
-	return stats
+// result returns the counted stats
+func (s *statsCounter) result() commitStats {
+	for _, path := range s.stats.FilesModified {
+		if fs := s.fileLines[path]; fs != nil && fs.Additions+fs.Deletions > 0 {
+			s.stats.FileStats = append(s.stats.FileStats, *fs)
+		}
+	}
+	s.stats.PatchID = s.patchID.sum()
+	return s.stats
+}
+
+// patchHash identifies a patch by its changed lines, so that the same change
+// applied to another repository, at other line numbers or reindented, hashes
+// the same (like git patch-id)
+type patchHash struct {
+	h     hash.Hash
+	lines int
+}
+
+func newPatchHash() *patchHash {
+	return &patchHash{h: sha256.New()}
+}
+
+func (p *patchHash) add(path string, op byte, line string) {
+	normalized := strings.Join(strings.Fields(line), "")
+	if normalized == "" {
+		return
+	}
+	p.lines++
+	_, _ = fmt.Fprintf(p.h, "%s\x00%c%s\n", path, op, normalized)
+}
+
+// sum returns the patch ID, or "" for a commit without line changes
+func (p *patchHash) sum() string {
+	if p.lines == 0 {
+		return ""
+	}
+	return hex.EncodeToString(p.h.Sum(nil)[:16])
 }
 
 // isShallowBoundaryError checks if an error indicates we've hit the shallow clone boundary
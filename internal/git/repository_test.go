@@ -0,0 +1,148 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/pathfilter"
+)
+
+// commitFiles writes files into the worktree of repo and commits them
+func commitFiles(t *testing.T, repo *gogit.Repository, dir string, when time.Time, files map[string]string) {
+	t.Helper()
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		_, err := wt.Add(name)
+		require.NoError(t, err)
+	}
+	sig := &object.Signature{Name: "Dev", Email: "dev@users.noreply.github.com", When: when}
+	_, err = wt.Commit("change", &gogit.CommitOptions{Author: sig, Committer: sig})
+	require.NoError(t, err)
+}
+
+func TestRepository_FetchCommitsScoped(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+
+	dir := r.repoPath("org", "monorepo")
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Hour)
+	commitFiles(t, repo, dir, start.Add(1*time.Minute), map[string]string{
+		"services/payments/api.go": "package api\n\nfunc Pay() {}\n",
+		"services/billing/api.go":  "package api\n\nfunc Bill() {}\n\nfunc Refund() {}\n",
+	})
+	commitFiles(t, repo, dir, start.Add(2*time.Minute), map[string]string{
+		"services/billing/api.go": "package api\n",
+	})
+
+	ctx := context.Background()
+	all, err := r.FetchCommits(ctx, "org", "monorepo", &start, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	scope, err := pathfilter.New([]string{"services/payments/**"})
+	require.NoError(t, err)
+	scoped, err := r.FetchCommits(ctx, "org", "monorepo", &start, nil, scope)
+	require.NoError(t, err)
+	require.Len(t, scoped, 1)
+	assert.Equal(t, 1, scoped[0].FilesChanged)
+	assert.Equal(t, []string{"services/payments/api.go"}, scoped[0].FilesModified)
+	// Lines in services/billing are not counted
+	assert.Less(t, scoped[0].Additions, all[1].Additions)
+
+	// Per-file line counts, for churn analysis
+	for _, commit := range all {
+		for _, fs := range commit.FileStats {
+			if fs.Path == "services/billing/api.go" && fs.Deletions > 0 {
+				assert.Positive(t, fs.Deletions)
+				assert.Less(t, fs.Additions, fs.Deletions)
+				return
+			}
+		}
+	}
+	t.Fatal("no file stats for the billing change")
+}
+
+func TestRepository_FetchCommitsPatchID(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+	start := time.Now().Add(-time.Hour)
+
+	fetchFix := func(name, base, fix string) string {
+		dir := r.repoPath("org", name)
+		repo, err := gogit.PlainInit(dir, false)
+		require.NoError(t, err)
+		commitFiles(t, repo, dir, start.Add(1*time.Minute), map[string]string{"util/fix.go": base})
+		commitFiles(t, repo, dir, start.Add(2*time.Minute), map[string]string{"util/fix.go": base + fix})
+
+		commits, err := r.FetchCommits(context.Background(), "org", name, &start, nil, nil)
+		require.NoError(t, err)
+		for _, c := range commits {
+			if c.Date.Equal(start.Add(2 * time.Minute).Truncate(time.Second)) {
+				return c.PatchID
+			}
+		}
+		t.Fatalf("fix commit not found in %s", name)
+		return ""
+	}
+
+	a := fetchFix("a", "package util\n\nfunc A() {}\n", "\nfunc Clamp(v int) int { return max(v, 0) }\n")
+	// Same change at other line numbers and indented differently
+	b := fetchFix("b", "package util\n\n// B does things\nfunc B() {}\n", "\nfunc Clamp(v int) int {  return max(v, 0)  }\n")
+	c := fetchFix("c", "package util\n\nfunc A() {}\n", "\nfunc Clamp(v int) int { return min(v, 0) }\n")
+
+	assert.NotEmpty(t, a)
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestRepository_FetchCommitsUnreadableTree(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+	var logged []string
+	r.SetProgressCallback(func(msg string) { logged = append(logged, msg) })
+
+	dir := r.repoPath("org", "broken")
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	start := time.Now().Add(-time.Hour)
+	commitFiles(t, repo, dir, start.Add(time.Minute), map[string]string{"services/payments/api.go": "package api\n"})
+
+	// Lose the commit's tree
+	head, err := repo.Head()
+	require.NoError(t, err)
+	commit, err := repo.CommitObject(head.Hash())
+	require.NoError(t, err)
+	tree := commit.TreeHash.String()
+	require.NoError(t, os.Remove(filepath.Join(dir, ".git", "objects", tree[:2], tree[2:])))
+
+	scope, err := pathfilter.New([]string{"services/payments/**"})
+	require.NoError(t, err)
+	commits, err := r.FetchCommits(context.Background(), "org", "broken", &start, nil, scope)
+	require.NoError(t, err)
+	require.Len(t, commits, 1, "kept although its paths can't be checked against the scope")
+	assert.Zero(t, commits[0].Additions)
+	assert.Contains(t, strings.Join(logged, "\n"), "Warning: failed to read the changes of 1 commits of org/broken")
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Dir returns the working tree of a repository's local clone
+func (r *Repository) Dir(owner, name string) string {
+	return r.repoPath(owner, name)
+}
+
+// RunAt checks out the last commit of the current branch made at or before at
+// (the branch tip when at is nil), runs a shell command in the working tree and
+// returns its stdout. The previous checkout is restored afterwards. A non-zero
+// exit status is tolerated when the command printed output, as linters exit
+// with 1 when they report findings.
+func (r *Repository) RunAt(ctx context.Context, owner, name string, at *time.Time, command string) ([]byte, error) {
+	repoPath := r.repoPath(owner, name)
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	target := head.Hash()
+	if at != nil {
+		target, err = lastCommitBefore(repo, head.Hash(), *at)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open worktree: %w", err)
+	}
+	if target != head.Hash() {
+		if err := wt.Checkout(&git.CheckoutOptions{Hash: target, Force: true}); err != nil {
+			return nil, fmt.Errorf("failed to check out %s: %w", target.String()[:7], err)
+		}
+		defer func() {
+			restore := &git.CheckoutOptions{Hash: head.Hash(), Force: true}
+			if head.Name().IsBranch() {
+				restore = &git.CheckoutOptions{Branch: head.Name(), Force: true}
+			}
+			if err := wt.Checkout(restore); err != nil {
+				r.progress(fmt.Sprintf("      Warning: failed to restore checkout of %s/%s: %v", owner, name, err))
+			}
+		}()
+	}
+
+	var stdout, stderr bytes.Buffer
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, command) // #nosec G204 -- command comes from the user's own config
+	cmd.Dir = repoPath
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || stdout.Len() == 0 {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("%w: %s", err, msg)
+			}
+			return nil, err
+		}
+	}
+	return stdout.Bytes(), nil
+}
+
+// lastCommitBefore returns the newest first-parent ancestor of from committed at or before at
+func lastCommitBefore(repo *git.Repository, from plumbing.Hash, at time.Time) (plumbing.Hash, error) {
+	c, err := repo.CommitObject(from)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to read commit: %w", err)
+	}
+	for c.Committer.When.After(at) {
+		if c.NumParents() == 0 {
+			return plumbing.ZeroHash, fmt.Errorf("no commit before %s", at.Format(time.DateOnly))
+		}
+		var parent *object.Commit
+		parent, err = c.Parent(0)
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to read parent of %s: %w", c.Hash.String()[:7], err)
+		}
+		c = parent
+	}
+	return c.Hash, nil
+}
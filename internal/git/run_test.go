@@ -0,0 +1,54 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_RunAt(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+
+	dir := r.repoPath("org", "repo")
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	commitFiles(t, repo, dir, start, map[string]string{"version.txt": "v1"})
+	commitFiles(t, repo, dir, start.AddDate(0, 0, 2), map[string]string{"version.txt": "v2"})
+
+	ctx := context.Background()
+	boundary := start.AddDate(0, 0, 1)
+	out, err := r.RunAt(ctx, "org", "repo", &boundary, "cat version.txt; exit 1")
+	require.NoError(t, err, "non-zero exit with output is tolerated")
+	assert.Equal(t, "v1", string(out))
+
+	out, err = r.RunAt(ctx, "org", "repo", nil, "cat version.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(out))
+
+	// The branch tip is checked out again afterwards
+	content, err := os.ReadFile(filepath.Join(dir, "version.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(content))
+
+	_, err = r.RunAt(ctx, "org", "repo", nil, "exit 2")
+	assert.Error(t, err)
+
+	before := start.AddDate(0, 0, -1)
+	_, err = r.RunAt(ctx, "org", "repo", &before, "true")
+	assert.ErrorContains(t, err, "no commit before")
+}
@@ -0,0 +1,109 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// SSHOptions configures cloning over SSH instead of HTTPS with a token
+type SSHOptions struct {
+	User       string // SSH user (default: git)
+	KeyPath    string // Private key file; empty uses the SSH agent
+	Passphrase string // Passphrase of an encrypted private key
+	KnownHosts string // known_hosts file; empty uses SSH_KNOWN_HOSTS or the default locations
+}
+
+// UseSSH clones and fetches repositories over SSH from now on. The key or
+// agent is checked up front, so configuration errors surface before cloning.
+func (r *Repository) UseSSH(opts SSHOptions) error {
+	user := opts.User
+	if user == "" {
+		user = "git"
+	}
+
+	var helper *ssh.HostKeyCallbackHelper
+	if opts.KeyPath != "" {
+		keys, err := ssh.NewPublicKeysFromFile(user, opts.KeyPath, opts.Passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to load SSH key %s: %w", opts.KeyPath, err)
+		}
+		r.sshAuth, helper = keys, &keys.HostKeyCallbackHelper
+	} else {
+		agent, err := ssh.NewSSHAgentAuth(user)
+		if err != nil {
+			return fmt.Errorf("failed to connect to the SSH agent (set options.ssh.key_path to use a key file): %w", err)
+		}
+		r.sshAuth, helper = agent, &agent.HostKeyCallbackHelper
+	}
+
+	if opts.KnownHosts != "" {
+		callback, err := ssh.NewKnownHostsCallback(opts.KnownHosts)
+		if err != nil {
+			return fmt.Errorf("failed to read known hosts: %w", err)
+		}
+		helper.HostKeyCallback = callback
+	}
+	return nil
+}
+
+// cloneURL returns the remote URL of a repository for the clone protocol in use
+func (r *Repository) cloneURL(owner, name string) string {
+	if r.remoteBase != "" {
+		remote := fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(r.remoteBase, "/"), owner, name)
+		if _, err := os.Stat(remote + ".git"); err == nil {
+			return remote + ".git"
+		}
+		return remote
+	}
+	if r.sshAuth != nil {
+		return fmt.Sprintf("git@github.com:%s/%s.git", owner, name)
+	}
+	return fmt.Sprintf("https://github.com/%s/%s.git", owner, name)
+}
+
+// SetRemoteBase clones repositories from base/<owner>/<name> instead of
+// GitHub, where base is a URL or a local directory. Local directories may
+// hold bare repositories named <name>.git, like migration exports.
+func (r *Repository) SetRemoteBase(base string) {
+	r.remoteBase = base
+}
+
+// auth returns the authentication for clones and fetches: the SSH key or
+// agent, or the token over HTTPS (nil for public repositories without one)
+func (r *Repository) auth(token string) transport.AuthMethod {
+	if r.sshAuth != nil {
+		return r.sshAuth
+	}
+	if token != "" {
+		return &http.BasicAuth{
+			Username: "x-access-token",
+			Password: token,
+		}
+	}
+	return nil
+}
+
+// ensureOrigin points the origin remote of an existing clone at url, so that
+// clones made over HTTPS are fetched over SSH after switching protocols and
+// the other way round
+func ensureOrigin(repo *git.Repository, url string) error {
+	cfg, err := repo.Config()
+	if err != nil {
+		return fmt.Errorf("failed to read repository config: %w", err)
+	}
+	origin, ok := cfg.Remotes["origin"]
+	if !ok || (len(origin.URLs) == 1 && origin.URLs[0] == url) {
+		return nil
+	}
+	origin.URLs = []string{url}
+	if err := repo.SetConfig(cfg); err != nil {
+		return fmt.Errorf("failed to update origin: %w", err)
+	}
+	return nil
+}
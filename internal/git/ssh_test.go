@@ -0,0 +1,79 @@
+package git
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_UseSSH(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	keyPath := filepath.Join(dir, "id_ecdsa")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+	knownHosts := filepath.Join(dir, "known_hosts")
+	require.NoError(t, os.WriteFile(knownHosts, nil, 0o600))
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+
+	// HTTPS with the token by default
+	assert.Equal(t, "https://github.com/org/repo.git", r.cloneURL("org", "repo"))
+	assert.IsType(t, &http.BasicAuth{}, r.auth("ghp_token"))
+	assert.Nil(t, r.auth(""))
+
+	require.Error(t, r.UseSSH(SSHOptions{KeyPath: filepath.Join(dir, "missing")}))
+
+	require.NoError(t, r.UseSSH(SSHOptions{KeyPath: keyPath, KnownHosts: knownHosts}))
+	assert.Equal(t, "git@github.com:org/repo.git", r.cloneURL("org", "repo"))
+	auth, ok := r.auth("ghp_token").(*ssh.PublicKeys)
+	require.True(t, ok, "the token is not used over SSH")
+	assert.Equal(t, "git", auth.User)
+	assert.NotNil(t, auth.HostKeyCallback)
+}
+
+func TestRepository_CloneURLRemoteBase(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(base, "org", "bare.git"), 0o750))
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+	r.SetRemoteBase(base)
+
+	assert.Equal(t, base+"/org/repo", r.cloneURL("org", "repo"))
+	assert.Equal(t, base+"/org/bare.git", r.cloneURL("org", "bare"), "bare repositories of exports")
+}
+
+func TestEnsureOrigin(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gogit.PlainInit(t.TempDir(), false)
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/org/repo.git"}})
+	require.NoError(t, err)
+
+	require.NoError(t, ensureOrigin(repo, "git@github.com:org/repo.git"))
+
+	remote, err := repo.Remote("origin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git@github.com:org/repo.git"}, remote.Config().URLs)
+}
@@ -0,0 +1,314 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/pathfilter"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// systemLogFormat starts every commit of the system git log with a record
+// separator, followed by NUL-separated fields ending in the raw message
+const systemLogFormat = "%x1e%H%x00%an%x00%ae%x00%aI%x00%cn%x00%ce%x00%B%x00"
+
+// systemLogFields is the number of NUL-terminated fields in systemLogFormat
+const systemLogFields = 7
+
+// UseSystemGit reads the commits of clones whose .git directory takes at
+// least minSizeMB with the git binary at path (git from PATH when empty).
+// Diffing with go-git gets orders of magnitude slower than git on monorepos.
+func (r *Repository) UseSystemGit(path string, minSizeMB int) error {
+	if path == "" {
+		path = "git"
+	}
+	bin, err := exec.LookPath(path)
+	if err != nil {
+		return fmt.Errorf("git binary not found: %w", err)
+	}
+	r.systemGit = bin
+	r.systemGitMinSize = int64(minSizeMB) << 20
+	return nil
+}
+
+// useSystemGit reports whether the commits of a clone are read with the
+// system git binary
+func (r *Repository) useSystemGit(repoPath string) bool {
+	if r.systemGit == "" {
+		return false
+	}
+	return dirSize(filepath.Join(repoPath, ".git")) >= r.systemGitMinSize
+}
+
+// dirSize returns the total size of the files below dir
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Count what can be read
+		}
+		if info, err := d.Info(); err == nil && d.Type().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// fetchCommitsSystem is FetchCommits on top of `git log -p`. Commits are
+// counted the same way as with go-git: diffed against their first parent
+// without rename detection, through the same statsCounter.
+func (r *Repository) fetchCommitsSystem(ctx context.Context, owner, name, repoPath string, since, until *time.Time, scope *pathfilter.Filter) ([]models.Commit, error) {
+	args := []string{
+		"-c", "core.quotePath=false",
+		"log", "--no-use-mailmap", "--no-renames", "--diff-merges=first-parent",
+		"--patch", "--unified=0", "--no-color", "--no-ext-diff", "--no-textconv",
+		"--format=" + systemLogFormat,
+	}
+	switch r.commitBranches {
+	case config.CommitBranchesDefault, config.CommitBranchesMain:
+		repo, err := git.PlainOpen(repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open repository: %w", err)
+		}
+		refs, err := r.commitRefs(repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get references: %w", err)
+		}
+		for _, ref := range refs {
+			args = append(args, ref.Hash().String())
+		}
+	default:
+		args = append(args, "--branches", "--remotes", "--tags")
+	}
+	if since != nil {
+		// Commits are filtered by author date below; the commit date bound
+		// only prunes the walk, with the same week of slack as go-git
+		args = append(args, "--since="+since.AddDate(0, 0, -7).Format(time.RFC3339))
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.systemGit, args...) // #nosec G204 -- fixed arguments, binary from the user's own config
+	cmd.Dir = repoPath
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git log: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run git log: %w", err)
+	}
+
+	pbar := newCommitProgressBar("      Iterating commits (git):")
+	processed := 0
+	var commits []models.Commit
+	parseErr := parseSystemLog(stdout, scope, func(c systemCommit) bool {
+		processed++
+		if processed%10 == 0 {
+			pbar.update(processed)
+		}
+		when := c.author.When
+		return (since == nil || !when.Before(*since)) && (until == nil || !when.After(*until))
+	}, func(c systemCommit, stats commitStats) {
+		if stats.InScope {
+			commits = append(commits, newCommit(owner, name, c.sha, c.message, c.author, c.committer, stats))
+		}
+	})
+	if parseErr != nil {
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+	waitErr := cmd.Wait()
+	pbar.done(len(commits))
+
+	if err := errors.Join(parseErr, waitErr); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git log failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+	return commits, nil
+}
+
+// systemCommit is the metadata of a commit read from git log
+type systemCommit struct {
+	sha       string
+	message   string
+	author    object.Signature
+	committer object.Signature
+}
+
+// parseSystemLog reads the output of git log --patch --unified=0 in
+// systemLogFormat. Every commit is passed to include, and the stats of those
+// it accepts to emit.
+func parseSystemLog(out io.Reader, scope *pathfilter.Filter, include func(systemCommit) bool, emit func(systemCommit, commitStats)) error {
+	rd := bufio.NewReaderSize(out, 64<<10)
+
+	var (
+		commit   systemCommit
+		counter  *statsCounter // nil while skipping a commit
+		file     string
+		counting bool // Lines of the current file count
+		hunk     struct{ deletions, additions int }
+		run      []string // Consecutive lines added or deleted
+		runOp    byte
+		noEOL    bool // The run's last line has no newline
+	)
+
+	// flushRun counts a run of lines as go-git counts a chunk: split on
+	// newlines, so that the newline ending the chunk adds an empty line
+	flushRun := func() {
+		if counter != nil && counting && len(run) > 0 {
+			if !noEOL {
+				run = append(run, "")
+			}
+			counter.lines(file, runOp, run)
+		}
+		run, noEOL = nil, false
+	}
+	flushCommit := func() {
+		flushRun()
+		if counter != nil {
+			emit(commit, counter.result())
+		}
+		counter, counting = nil, false
+	}
+
+	for {
+		line, err := rd.ReadString('\n')
+		if line == "" && err != nil {
+			if errors.Is(err, io.EOF) {
+				flushCommit()
+				return nil
+			}
+			return err
+		}
+
+		switch {
+		case hunk.deletions > 0 && strings.HasPrefix(line, "-"),
+			hunk.additions > 0 && strings.HasPrefix(line, "+"):
+			op := line[0]
+			if op == '-' {
+				hunk.deletions--
+			} else {
+				hunk.additions--
+			}
+			if op != runOp {
+				flushRun()
+				runOp = op
+			}
+			run = append(run, strings.TrimSuffix(line[1:], "\n"))
+
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file" after a line
+			noEOL = len(run) > 0
+
+		case strings.HasPrefix(line, "\x1e"):
+			flushCommit()
+			header := line[1:]
+			for strings.Count(header, "\x00") < systemLogFields && err == nil {
+				var more string
+				more, err = rd.ReadString('\n')
+				header += more
+			}
+			c, perr := parseSystemHeader(header)
+			if perr != nil {
+				return perr
+			}
+			commit = c
+			if include(commit) {
+				counter = newStatsCounter(scope)
+			}
+
+		case strings.HasPrefix(line, "diff --git "):
+			flushRun()
+			hunk.deletions, hunk.additions = 0, 0
+			counting = false
+			if counter != nil {
+				file = diffPath(strings.TrimSuffix(line, "\n"))
+				file, counting = counter.file(file, file)
+			}
+
+		case strings.HasPrefix(line, "@@ "):
+			flushRun()
+			hunk.deletions, hunk.additions = parseHunkHeader(line)
+		}
+		// Anything else is a file header (index, mode, ---, +++, Binary files)
+	}
+}
+
+// parseSystemHeader parses the fields of systemLogFormat after the record separator
+func parseSystemHeader(header string) (systemCommit, error) {
+	fields := strings.SplitN(header, "\x00", systemLogFields+1)
+	if len(fields) <= systemLogFields {
+		return systemCommit{}, fmt.Errorf("malformed git log header: %q", header)
+	}
+	when, err := time.Parse(time.RFC3339, fields[3])
+	if err != nil {
+		return systemCommit{}, fmt.Errorf("malformed author date of %s: %w", fields[0], err)
+	}
+	return systemCommit{
+		sha:       fields[0],
+		author:    object.Signature{Name: fields[1], Email: fields[2], When: when},
+		committer: object.Signature{Name: fields[4], Email: fields[5]},
+		message:   fields[6],
+	}, nil
+}
+
+// diffPath returns the path of a "diff --git a/<path> b/<path>" line. Without
+// rename detection both paths are the same, which resolves the ambiguity of
+// paths containing " b/".
+func diffPath(line string) string {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	if strings.HasPrefix(rest, `"`) {
+		// Quoted for special characters
+		if quoted, err := strconv.QuotedPrefix(rest); err == nil {
+			if path, err := strconv.Unquote(quoted); err == nil {
+				return strings.TrimPrefix(path, "a/")
+			}
+		}
+	}
+	n := (len(rest) - len("a/ b/")) / 2
+	if n <= 0 {
+		return rest
+	}
+	return rest[len("a/") : len("a/")+n]
+}
+
+// parseHunkHeader returns the number of lines deleted and added by a
+// "@@ -start[,count] +start[,count] @@" hunk
+func parseHunkHeader(line string) (int, int) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return 0, 0
+	}
+	return hunkCount(fields[1]), hunkCount(fields[2])
+}
+
+// hunkCount returns the line count of a "-start[,count]" range, 1 when omitted
+func hunkCount(r string) int {
+	_, count, found := strings.Cut(r, ",")
+	if !found {
+		return 1
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,135 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/pathfilter"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestRepository_FetchCommitsSystemGit(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+	dir := r.repoPath("org", "monorepo")
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Hour)
+	commitFiles(t, repo, dir, start.Add(1*time.Minute), map[string]string{
+		"services/api/server.go": "package api\n\n// Serve serves\nfunc Serve() {}\n\nfunc Stop() {}\n",
+		"services/web/app.js":    "const a = 1\nconst b = 2",
+		"README.md":              "# Monorepo\n",
+		"logo.png":               "\x89PNG\x00\x00\x01\x02",
+	})
+	commitFiles(t, repo, dir, start.Add(2*time.Minute), map[string]string{
+		"services/api/server.go":      "package api\n\n// Serve serves\nfunc Serve() { listen() }\n\n// func Stop() {}\n",
+		"services/api/server_test.go": "package api\n\nfunc TestServe(t *testing.T) {}\n",
+		"services/web/app.js":         "const a = 1\nconst b = 3\n",
+		"README.md":                   "# Monorepo\n\nRead me.\n",
+	})
+	commitFiles(t, repo, dir, start.Add(3*time.Minute), map[string]string{
+		"services/web/my app.js": "export {}\n",
+	})
+	before := start.Add(-time.Minute)
+
+	ctx := context.Background()
+	scope, err := pathfilter.New([]string{"services/api/**"})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name  string
+		since *time.Time
+		scope *pathfilter.Filter
+	}{
+		{name: "all", since: &before},
+		{name: "scoped", since: &before, scope: scope},
+	} {
+		goGit, err := r.FetchCommits(ctx, "org", "monorepo", tc.since, nil, tc.scope)
+		require.NoError(t, err)
+
+		system := *r
+		require.NoError(t, system.UseSystemGit("", 0))
+		git, err := system.FetchCommits(ctx, "org", "monorepo", tc.since, nil, tc.scope)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, goGit, tc.name)
+		assert.Equal(t, normalizeCommits(goGit), normalizeCommits(git), tc.name)
+	}
+}
+
+func TestRepository_UseSystemGitThreshold(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRepository(t.TempDir())
+	require.NoError(t, err)
+	dir := r.repoPath("org", "small")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "pack"), make([]byte, 1024), 0600))
+
+	assert.False(t, r.useSystemGit(dir), "disabled by default")
+	r.systemGit = "git"
+	r.systemGitMinSize = 1 << 20
+	assert.False(t, r.useSystemGit(dir), "below the threshold")
+	r.systemGitMinSize = 1024
+	assert.True(t, r.useSystemGit(dir))
+}
+
+func TestDiffPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"diff --git a/main.go b/main.go":               "main.go",
+		"diff --git a/x b/x":                           "x",
+		"diff --git a/with space.go b/with space.go":   "with space.go",
+		"diff --git a/a b/c.go b/a b/c.go":             "a b/c.go",
+		"diff --git a/dir/żółw.go b/dir/żółw.go":       "dir/żółw.go",
+		`diff --git "a/tab\there.go" "b/tab\there.go"`: "tab\there.go",
+	}
+	for line, want := range tests {
+		assert.Equal(t, want, diffPath(line), line)
+	}
+}
+
+func TestParseHunkHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		line                 string
+		deletions, additions int
+	}{
+		{"@@ -1 +1 @@\n", 1, 1},
+		{"@@ -0,0 +1,3 @@\n", 0, 3},
+		{"@@ -4,2 +3,0 @@ func main() {\n", 2, 0},
+	}
+	for _, tt := range tests {
+		deletions, additions := parseHunkHeader(tt.line)
+		assert.Equal(t, tt.deletions, deletions, tt.line)
+		assert.Equal(t, tt.additions, additions, tt.line)
+	}
+}
+
+// normalizeCommits orders commits by SHA and dates in UTC, as the backends
+// list commits in different orders and zones
+func normalizeCommits(commits []models.Commit) []models.Commit {
+	out := append([]models.Commit(nil), commits...)
+	for i := range out {
+		out[i].Date = out[i].Date.UTC()
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SHA < out[j].SHA })
+	return out
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// FetchAdoption fetches a repository's stars, forks and watchers, and when
+// the stars and forks since since were added. Stargazers are listed oldest
+// first, so they are read from the last page backwards. At most maxPages
+// pages of each are read (0 = unlimited).
+func (c *Client) FetchAdoption(ctx context.Context, owner, repo string, since time.Time, maxPages int) (models.RepositoryAdoption, error) {
+	cacheKey := fmt.Sprintf("adoption:%s/%s:%d:%d", owner, repo, since.Unix(), maxPages)
+	if adoption, ok := cache.Get[models.RepositoryAdoption](c.cache, cacheKey); ok {
+		return adoption, nil
+	}
+
+	var r *github.Repository
+	err := c.retryWithBackoff(ctx, "get repository", func() error {
+		var err error
+		r, _, err = c.gh.Repositories.Get(ctx, owner, repo)
+		return err
+	})
+	if err != nil {
+		return models.RepositoryAdoption{}, fmt.Errorf("failed to get repository: %w", err)
+	}
+	adoption := models.RepositoryAdoption{
+		Stars:    r.GetStargazersCount(),
+		Forks:    r.GetForksCount(),
+		Watchers: r.GetSubscribersCount(),
+	}
+
+	if err := c.fetchStargazers(ctx, owner, repo, since, maxPages, &adoption); err != nil {
+		return models.RepositoryAdoption{}, err
+	}
+	if err := c.fetchForks(ctx, owner, repo, since, maxPages, &adoption); err != nil {
+		return models.RepositoryAdoption{}, err
+	}
+
+	cache.Set(c.cache, cacheKey, adoption)
+	return adoption, nil
+}
+
+// fetchStargazers adds the times of the stars since since, newest first
+func (c *Client) fetchStargazers(ctx context.Context, owner, repo string, since time.Time, maxPages int, adoption *models.RepositoryAdoption) error {
+	list := func(page int) ([]*github.Stargazer, *github.Response, error) {
+		var stargazers []*github.Stargazer
+		var resp *github.Response
+		err := c.retryWithBackoff(ctx, "list stargazers", func() error {
+			var err error
+			stargazers, resp, err = c.gh.Activity.ListStargazers(ctx, owner, repo, &github.ListOptions{Page: page, PerPage: 100})
+			return err
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list stargazers: %w", err)
+		}
+		return stargazers, resp, nil
+	}
+
+	stargazers, resp, err := list(1)
+	if err != nil {
+		return err
+	}
+	page := max(resp.LastPage, 1)
+	for pages := 1; ; pages++ {
+		if page != 1 {
+			if stargazers, _, err = list(page); err != nil {
+				return err
+			}
+		}
+		reached := false
+		for i := len(stargazers) - 1; i >= 0; i-- {
+			at := stargazers[i].GetStarredAt().Time
+			if at.Before(since) {
+				reached = true
+				break
+			}
+			adoption.StarredAt = append(adoption.StarredAt, at)
+		}
+		if reached || page == 1 {
+			return nil
+		}
+		if maxPages > 0 && pages >= maxPages {
+			adoption.Truncated = true
+			return nil
+		}
+		page--
+	}
+}
+
+// fetchForks adds the creation times of the forks since since, newest first
+func (c *Client) fetchForks(ctx context.Context, owner, repo string, since time.Time, maxPages int, adoption *models.RepositoryAdoption) error {
+	opts := &github.RepositoryListForksOptions{Sort: "newest", ListOptions: github.ListOptions{PerPage: 100}}
+	for pages := 1; ; pages++ {
+		var forks []*github.Repository
+		var resp *github.Response
+		err := c.retryWithBackoff(ctx, "list forks", func() error {
+			var err error
+			forks, resp, err = c.gh.Repositories.ListForks(ctx, owner, repo, opts)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list forks: %w", err)
+		}
+
+		for _, fork := range forks {
+			at := fork.GetCreatedAt().Time
+			if at.Before(since) {
+				return nil
+			}
+			adoption.ForkedAt = append(adoption.ForkedAt, at)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		if maxPages > 0 && pages >= maxPages {
+			adoption.Truncated = true
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchAdoption(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 12, 0, 0, 0, time.UTC) }
+	// Three pages of stargazers, oldest first; the period starts on page 2
+	pages := [][]int{{1, 2}, {3, 10}, {15, 20}}
+
+	var stargazerPages []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/api", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"stargazers_count": 6, "forks_count": 3, "subscribers_count": 4}`))
+	})
+	mux.HandleFunc("/repos/acme/api/stargazers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Accept"), "star+json", "timestamps are only returned for the star media type")
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		stargazerPages = append(stargazerPages, page)
+		n, _ := strconv.Atoi(page)
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=3>; rel="last"`, r.URL.Path))
+		var stargazers []map[string]any
+		for _, d := range pages[n-1] {
+			stargazers = append(stargazers, map[string]any{"starred_at": day(d)})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(stargazers))
+	})
+	mux.HandleFunc("/repos/acme/api/forks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "newest", r.URL.Query().Get("sort"))
+		require.NoError(t, json.NewEncoder(w).Encode([]map[string]any{
+			{"created_at": day(25)},
+			{"created_at": day(8)},
+			{"created_at": day(2)},
+		}))
+	})
+	client := newTestClient(t, mux, "")
+
+	adoption, err := client.FetchAdoption(t.Context(), "acme", "api", day(5), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 6, adoption.Stars)
+	assert.Equal(t, 3, adoption.Forks)
+	assert.Equal(t, 4, adoption.Watchers)
+	assert.Equal(t, []time.Time{day(20), day(15), day(10)}, adoption.StarredAt)
+	assert.Equal(t, []time.Time{day(25), day(8)}, adoption.ForkedAt)
+	assert.False(t, adoption.Truncated)
+	assert.Equal(t, []string{"1", "3", "2"}, stargazerPages, "read backwards from the last page")
+
+	adoption, err = client.FetchAdoption(t.Context(), "acme", "api", day(5), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{day(20), day(15)}, adoption.StarredAt)
+	assert.True(t, adoption.Truncated)
+}
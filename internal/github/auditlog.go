@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// FetchAuditLog fetches the organization audit-log entries for the given actions
+// within the date range. Only GitHub Enterprise Cloud organizations expose the
+// audit log, and the token needs the read:audit_log scope. Entries not tied to
+// a repository are skipped.
+func (c *Client) FetchAuditLog(ctx context.Context, org string, actions []string, since, until *time.Time) ([]models.AuditEvent, error) {
+	cacheKey := fmt.Sprintf("audit_log:%s:%v:%v:%v", org, actions, since, until)
+	if events, ok := cache.Get[[]models.AuditEvent](c.cache, cacheKey); ok {
+		return events, nil
+	}
+
+	var created string
+	switch {
+	case since != nil && until != nil:
+		created = fmt.Sprintf(" created:%s..%s", since.UTC().Format(time.DateOnly), until.UTC().Format(time.DateOnly))
+	case since != nil:
+		created = fmt.Sprintf(" created:>=%s", since.UTC().Format(time.DateOnly))
+	case until != nil:
+		created = fmt.Sprintf(" created:<=%s", until.UTC().Format(time.DateOnly))
+	}
+
+	var events []models.AuditEvent
+	for _, action := range actions {
+		opts := &github.GetAuditLogOptions{
+			Phrase:            github.Ptr("action:" + action + created),
+			Include:           github.Ptr("all"),
+			ListCursorOptions: github.ListCursorOptions{PerPage: 100},
+		}
+		for {
+			var entries []*github.AuditEntry
+			var resp *github.Response
+			err := c.retryWithBackoff(ctx, "fetch audit log", func() error {
+				var err error
+				entries, resp, err = c.gh.Organizations.GetAuditLog(ctx, org, opts)
+				return err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch audit log for %s: %w", org, err)
+			}
+
+			for _, entry := range entries {
+				event, ok := convertAuditEntry(entry)
+				if !ok || !inRange(event.CreatedAt, since, until) {
+					continue
+				}
+				events = append(events, event)
+			}
+
+			if resp.After == "" {
+				break
+			}
+			opts.After = resp.After
+		}
+	}
+
+	cache.Set(c.cache, cacheKey, events)
+	return events, nil
+}
+
+// convertAuditEntry converts an audit-log entry, reporting false when the entry
+// isn't tied to a repository
+func convertAuditEntry(entry *github.AuditEntry) (models.AuditEvent, bool) {
+	repo, _ := entry.AdditionalFields["repo"].(string)
+	if repo == "" {
+		return models.AuditEvent{}, false
+	}
+
+	created := entry.GetCreatedAt().Time
+	if created.IsZero() {
+		created = entry.GetTimestamp().Time
+	}
+
+	return models.AuditEvent{
+		Action:     entry.GetAction(),
+		Actor:      entry.GetActor(),
+		Repository: repo,
+		CreatedAt:  created,
+	}, true
+}
+
+// inRange reports whether t falls within the optional date range
+func inRange(t time.Time, since, until *time.Time) bool {
+	if since != nil && t.Before(*since) {
+		return false
+	}
+	if until != nil && t.After(*until) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+func TestFetchAuditLog(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+	inside := since.AddDate(0, 0, 10).UnixMilli()
+
+	var phrases []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/orgs/acme/audit-log", r.URL.Path)
+		assert.Equal(t, "all", r.URL.Query().Get("include"))
+		phrase := r.URL.Query().Get("phrase")
+		phrases = append(phrases, phrase)
+
+		var entries []map[string]any
+		switch {
+		case strings.HasPrefix(phrase, "action:"+config.AuditBranchProtectionOverride) && r.URL.Query().Get("after") == "":
+			// First page links to a second one through the cursor
+			w.Header().Set("Link", fmt.Sprintf(`<%s?after=next>; rel="next"`, r.URL.Path))
+			entries = []map[string]any{
+				{"action": config.AuditBranchProtectionOverride, "actor": "admin", "repo": "acme/api", "created_at": inside},
+				// Not tied to a repository
+				{"action": config.AuditBranchProtectionOverride, "actor": "admin", "created_at": inside},
+			}
+		case strings.HasPrefix(phrase, "action:"+config.AuditBranchProtectionOverride):
+			entries = []map[string]any{
+				{"action": config.AuditBranchProtectionOverride, "actor": "admin", "repo": "acme/web", "created_at": inside},
+				// Before the period
+				{"action": config.AuditBranchProtectionOverride, "actor": "admin", "repo": "acme/web", "created_at": since.AddDate(0, 0, -1).UnixMilli()},
+			}
+		case strings.HasPrefix(phrase, "action:"+config.AuditRejectedRefUpdate):
+			entries = []map[string]any{
+				{"action": config.AuditRejectedRefUpdate, "actor": "alice", "repo": "acme/api", "@timestamp": inside},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(entries))
+	})
+
+	client := newTestClient(t, handler, "")
+	events, err := client.FetchAuditLog(t.Context(), "acme",
+		[]string{config.AuditBranchProtectionOverride, config.AuditRejectedRefUpdate}, &since, &until)
+	require.NoError(t, err)
+
+	require.Len(t, events, 3)
+	assert.Equal(t, "acme/api", events[0].Repository)
+	assert.Equal(t, "admin", events[0].Actor)
+	assert.Equal(t, "acme/web", events[1].Repository)
+	assert.Equal(t, config.AuditRejectedRefUpdate, events[2].Action)
+	assert.Equal(t, time.UnixMilli(inside).UTC(), events[2].CreatedAt.UTC())
+
+	require.Len(t, phrases, 3)
+	assert.Equal(t, "action:"+config.AuditBranchProtectionOverride+" created:2024-01-01..2024-01-31", phrases[0])
+}
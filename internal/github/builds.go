@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v68/github"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// FetchBuildStatus returns the combined CI result of a commit from its check
+// runs and commit statuses: failure if any failed, pending while any is still
+// running, success otherwise, or empty when the commit has no CI at all
+func (c *Client) FetchBuildStatus(ctx context.Context, owner, repo, sha string) (string, error) {
+	cacheKey := fmt.Sprintf("build_status:%s/%s:%s", owner, repo, sha)
+	if status, ok := cache.Get[string](c.cache, cacheKey); ok {
+		return status, nil
+	}
+
+	var runs []*github.CheckRun
+	opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		var result *github.ListCheckRunsResults
+		var resp *github.Response
+		err := c.retryWithBackoff(ctx, "list check runs", func() error {
+			var err error
+			result, resp, err = c.gh.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, opts)
+			return err
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to list check runs for %s: %w", sha, err)
+		}
+		runs = append(runs, result.CheckRuns...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var combined *github.CombinedStatus
+	err := c.retryWithBackoff(ctx, "get combined status", func() error {
+		var err error
+		combined, _, err = c.gh.Repositories.GetCombinedStatus(ctx, owner, repo, sha, nil)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get combined status for %s: %w", sha, err)
+	}
+
+	status := buildStatus(runs, combined)
+	// Pending results change, so only final ones are cached
+	if status != models.BuildPending {
+		cache.Set(c.cache, cacheKey, status)
+	}
+	return status, nil
+}
+
+// buildStatus combines check runs and commit statuses into a single result
+func buildStatus(runs []*github.CheckRun, combined *github.CombinedStatus) string {
+	failed, pending, passed := false, false, false
+
+	for _, run := range runs {
+		if run.GetStatus() != "completed" {
+			pending = true
+			continue
+		}
+		switch run.GetConclusion() {
+		case "failure", "timed_out", "cancelled", "action_required", "startup_failure":
+			failed = true
+		case "success":
+			passed = true
+		}
+	}
+
+	// The combined state is pending when no status was reported at all
+	if combined.GetTotalCount() > 0 {
+		switch combined.GetState() {
+		case "failure", "error":
+			failed = true
+		case "pending":
+			pending = true
+		case "success":
+			passed = true
+		}
+	}
+
+	switch {
+	case failed:
+		return models.BuildFailure
+	case pending:
+		return models.BuildPending
+	case passed:
+		return models.BuildSuccess
+	}
+	return ""
+}
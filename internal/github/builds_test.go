@@ -0,0 +1,44 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestBuildStatus(t *testing.T) {
+	t.Parallel()
+
+	run := func(status, conclusion string) *github.CheckRun {
+		return &github.CheckRun{Status: github.Ptr(status), Conclusion: github.Ptr(conclusion)}
+	}
+	statuses := func(total int, state string) *github.CombinedStatus {
+		return &github.CombinedStatus{TotalCount: github.Ptr(total), State: github.Ptr(state)}
+	}
+
+	tests := []struct {
+		name     string
+		runs     []*github.CheckRun
+		combined *github.CombinedStatus
+		want     string
+	}{
+		{"no CI", nil, statuses(0, "pending"), ""},
+		{"checks passed", []*github.CheckRun{run("completed", "success"), run("completed", "skipped")}, statuses(0, "pending"), models.BuildSuccess},
+		{"check failed", []*github.CheckRun{run("completed", "success"), run("completed", "timed_out")}, statuses(0, "pending"), models.BuildFailure},
+		{"check running", []*github.CheckRun{run("completed", "success"), run("in_progress", "")}, statuses(0, "pending"), models.BuildPending},
+		{"status failed", []*github.CheckRun{run("completed", "success")}, statuses(1, "error"), models.BuildFailure},
+		{"status pending", nil, statuses(2, "pending"), models.BuildPending},
+		{"status passed", nil, statuses(1, "success"), models.BuildSuccess},
+		{"failure wins over pending", []*github.CheckRun{run("queued", ""), run("completed", "failure")}, statuses(0, "pending"), models.BuildFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, buildStatus(tt.runs, tt.combined))
+		})
+	}
+}
@@ -1,21 +1,67 @@
 package cache
 
 import (
+	"bufio"
+	"bytes"
 	"crypto/sha256"
-	"encoding/gob"
 	"encoding/hex"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
+
+	json "github.com/goccy/go-json"
 )
 
-// Cache defines the interface for caching
+// FormatVersion is the version of the on-disk entry layout.
+// Entries written with a different format or DataVersion are treated as misses,
+// so upgrading git-velocity never decodes stale data into changed structs.
+const FormatVersion = 2
+
+// DataVersion is the version of the cached values, the models fetched from
+// GitHub. It is bumped whenever a cached type gains, loses or changes a field,
+// so older entries are fetched again instead of being served without the new
+// data. models.SchemaVersion versions the output instead and doesn't change
+// when fields are added.
+const DataVersion = 1
+
+// entryExt is the file extension of cache entries
+const entryExt = ".cache"
+
+// Cache defines the interface for caching encoded values.
+// Use the Get and Set helpers to store typed values.
 type Cache interface {
-	Get(key string) (interface{}, bool)
-	Set(key string, value interface{})
+	Load(key string) ([]byte, bool)
+	Store(key string, data []byte)
 	Delete(key string)
 	Clear() error
+	SaveStats() error
+	SessionStats() Counter
+}
+
+// Get retrieves a typed value from the cache.
+// Values that cannot be decoded into T are treated as misses.
+func Get[T any](c Cache, key string) (T, bool) {
+	var value T
+	data, ok := c.Load(key)
+	if !ok {
+		return value, false
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero T
+		return zero, false
+	}
+	return value, true
+}
+
+// Set stores a typed value in the cache
+func Set[T any](c Cache, key string, value T) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	c.Store(key, data)
 }
 
 // FileCache implements file-based caching
@@ -23,12 +69,25 @@ type FileCache struct {
 	directory string
 	ttl       time.Duration
 	mu        sync.RWMutex
+
+	// Hit/miss counters for this process, flushed to disk by SaveStats
+	statsMu  sync.Mutex
+	counters map[string]*Counter
+	session  Counter // Totals for this process, never flushed
 }
 
-// cacheEntry wraps a cached value with expiration
-type cacheEntry struct {
-	Value     interface{}
-	ExpiresAt time.Time
+// entryHeader is written as the first line of an entry so entries can be
+// inspected (stats, pruning, version checks) without reading the value
+type entryHeader struct {
+	Key         string    `json:"key"`
+	Format      int       `json:"format"`
+	DataVersion int       `json:"data_version"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// current reports whether the entry was written by a compatible version
+func (h entryHeader) current() bool {
+	return h.Format == FormatVersion && h.DataVersion == DataVersion
 }
 
 // NewFileCache creates a new file-based cache
@@ -41,11 +100,12 @@ func NewFileCache(directory string, ttl time.Duration) (*FileCache, error) {
 	return &FileCache{
 		directory: directory,
 		ttl:       ttl,
+		counters:  make(map[string]*Counter),
 	}, nil
 }
 
-// Get retrieves a value from the cache
-func (c *FileCache) Get(key string) (interface{}, bool) {
+// Load retrieves encoded data from the cache
+func (c *FileCache) Load(key string) ([]byte, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -53,33 +113,48 @@ func (c *FileCache) Get(key string) (interface{}, bool) {
 
 	file, err := os.Open(path) // #nosec G304 -- path is internally generated hash
 	if err != nil {
+		c.record(key, false)
 		return nil, false
 	}
 	defer file.Close()
 
-	var entry cacheEntry
-	decoder := gob.NewDecoder(file)
-	if err := decoder.Decode(&entry); err != nil {
+	reader := bufio.NewReader(file)
+	header, err := decodeHeader(reader)
+	if err != nil || header.Key != key {
+		c.record(key, false)
 		return nil, false
 	}
 
-	// Check expiration
-	if time.Now().After(entry.ExpiresAt) {
+	// Expired or written by an incompatible version
+	if !header.current() || time.Now().After(header.ExpiresAt) {
 		_ = os.Remove(path)
+		c.record(key, false)
 		return nil, false
 	}
 
-	return entry.Value, true
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		c.record(key, false)
+		return nil, false
+	}
+
+	c.record(key, true)
+	return data, true
 }
 
-// Set stores a value in the cache
-func (c *FileCache) Set(key string, value interface{}) {
+// Store saves encoded data in the cache
+func (c *FileCache) Store(key string, data []byte) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	entry := cacheEntry{
-		Value:     value,
-		ExpiresAt: time.Now().Add(c.ttl),
+	header, err := json.Marshal(entryHeader{
+		Key:         key,
+		Format:      FormatVersion,
+		DataVersion: DataVersion,
+		ExpiresAt:   time.Now().Add(c.ttl),
+	})
+	if err != nil {
+		return
 	}
 
 	path := c.keyToPath(key)
@@ -89,14 +164,22 @@ func (c *FileCache) Set(key string, value interface{}) {
 		return
 	}
 
-	file, err := os.Create(path) // #nosec G304 -- path is internally generated hash
-	if err != nil {
+	var buf bytes.Buffer
+	buf.Grow(len(header) + 1 + len(data))
+	buf.Write(header)
+	buf.WriteByte('\n')
+	buf.Write(data)
+
+	// Write atomically so concurrent readers never see a partial entry
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
 		return
 	}
-	defer file.Close()
-
-	encoder := gob.NewEncoder(file)
-	_ = encoder.Encode(entry)
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return
+	}
+	c.recordWrite(key)
 }
 
 // Delete removes a value from the cache
@@ -119,10 +202,21 @@ func (c *FileCache) Clear() error {
 // keyToPath converts a cache key to a file path
 func (c *FileCache) keyToPath(key string) string {
 	hash := sha256.Sum256([]byte(key))
-	filename := hex.EncodeToString(hash[:8]) + ".gob"
+	filename := hex.EncodeToString(hash[:8]) + entryExt
 	return filepath.Join(c.directory, filename)
 }
 
+// decodeHeader reads the header line of an entry
+func decodeHeader(r *bufio.Reader) (entryHeader, error) {
+	var header entryHeader
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return header, err
+	}
+	err = json.Unmarshal(line, &header)
+	return header, err
+}
+
 // NoopCache is a cache that doesn't cache anything
 type NoopCache struct{}
 
@@ -131,13 +225,13 @@ func NewNoopCache() *NoopCache {
 	return &NoopCache{}
 }
 
-// Get always returns false
-func (c *NoopCache) Get(key string) (interface{}, bool) {
+// Load always returns false
+func (c *NoopCache) Load(key string) ([]byte, bool) {
 	return nil, false
 }
 
-// Set does nothing
-func (c *NoopCache) Set(key string, value interface{}) {}
+// Store does nothing
+func (c *NoopCache) Store(key string, data []byte) {}
 
 // Delete does nothing
 func (c *NoopCache) Delete(key string) {}
@@ -147,9 +241,12 @@ func (c *NoopCache) Clear() error {
 	return nil
 }
 
-// Register types for gob encoding
-func init() {
-	// Register common types that might be cached
-	gob.Register([]interface{}{})
-	gob.Register(map[string]interface{}{})
+// SaveStats does nothing
+func (c *NoopCache) SaveStats() error {
+	return nil
+}
+
+// SessionStats returns zero counts
+func (c *NoopCache) SessionStats() Counter {
+	return Counter{}
 }
@@ -6,8 +6,11 @@ import (
 	"testing"
 	"time"
 
+	json "github.com/goccy/go-json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
 func TestFileCache_Basic(t *testing.T) {
@@ -18,9 +21,9 @@ func TestFileCache_Basic(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test Set and Get
-	cache.Set("test-key", "test-value")
+	Set(cache, "test-key", "test-value")
 
-	value, ok := cache.Get("test-key")
+	value, ok := Get[string](cache, "test-key")
 	assert.True(t, ok)
 	assert.Equal(t, "test-value", value)
 }
@@ -31,9 +34,9 @@ func TestFileCache_GetNonExistent(t *testing.T) {
 	cache, err := NewFileCache(tempDir, time.Hour)
 	require.NoError(t, err)
 
-	value, ok := cache.Get("non-existent")
+	value, ok := Get[string](cache, "non-existent")
 	assert.False(t, ok)
-	assert.Nil(t, value)
+	assert.Empty(t, value)
 }
 
 func TestFileCache_Expiration(t *testing.T) {
@@ -43,10 +46,10 @@ func TestFileCache_Expiration(t *testing.T) {
 	cache, err := NewFileCache(tempDir, 50*time.Millisecond)
 	require.NoError(t, err)
 
-	cache.Set("expire-key", "expire-value")
+	Set(cache, "expire-key", "expire-value")
 
 	// Should be available immediately
-	value, ok := cache.Get("expire-key")
+	value, ok := Get[string](cache, "expire-key")
 	assert.True(t, ok)
 	assert.Equal(t, "expire-value", value)
 
@@ -54,9 +57,9 @@ func TestFileCache_Expiration(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 
 	// Should be expired now
-	value, ok = cache.Get("expire-key")
+	value, ok = Get[string](cache, "expire-key")
 	assert.False(t, ok)
-	assert.Nil(t, value)
+	assert.Empty(t, value)
 }
 
 func TestFileCache_Delete(t *testing.T) {
@@ -65,19 +68,19 @@ func TestFileCache_Delete(t *testing.T) {
 	cache, err := NewFileCache(tempDir, time.Hour)
 	require.NoError(t, err)
 
-	cache.Set("delete-key", "delete-value")
+	Set(cache, "delete-key", "delete-value")
 
 	// Verify it exists
-	_, ok := cache.Get("delete-key")
+	_, ok := Get[string](cache, "delete-key")
 	assert.True(t, ok)
 
 	// Delete it
 	cache.Delete("delete-key")
 
 	// Should be gone
-	value, ok := cache.Get("delete-key")
+	value, ok := Get[string](cache, "delete-key")
 	assert.False(t, ok)
-	assert.Nil(t, value)
+	assert.Empty(t, value)
 }
 
 func TestFileCache_Clear(t *testing.T) {
@@ -87,20 +90,20 @@ func TestFileCache_Clear(t *testing.T) {
 	require.NoError(t, err)
 
 	// Add multiple entries
-	cache.Set("key1", "value1")
-	cache.Set("key2", "value2")
-	cache.Set("key3", "value3")
+	Set(cache, "key1", "value1")
+	Set(cache, "key2", "value2")
+	Set(cache, "key3", "value3")
 
 	// Clear the cache
 	err = cache.Clear()
 	require.NoError(t, err)
 
 	// All should be gone
-	_, ok := cache.Get("key1")
+	_, ok := Get[string](cache, "key1")
 	assert.False(t, ok)
-	_, ok = cache.Get("key2")
+	_, ok = Get[string](cache, "key2")
 	assert.False(t, ok)
-	_, ok = cache.Get("key3")
+	_, ok = Get[string](cache, "key3")
 	assert.False(t, ok)
 }
 
@@ -111,23 +114,77 @@ func TestFileCache_ComplexValues(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test with map
-	mapValue := map[string]interface{}{
-		"key1": "value1",
+	mapValue := map[string]int{
+		"key1": 1,
 		"key2": 123,
 	}
-	cache.Set("map-key", mapValue)
+	Set(cache, "map-key", mapValue)
 
-	retrieved, ok := cache.Get("map-key")
+	retrievedMap, ok := Get[map[string]int](cache, "map-key")
 	assert.True(t, ok)
-	assert.Equal(t, mapValue, retrieved)
+	assert.Equal(t, mapValue, retrievedMap)
 
 	// Test with slice
-	sliceValue := []interface{}{"a", "b", "c"}
-	cache.Set("slice-key", sliceValue)
+	sliceValue := []string{"a", "b", "c"}
+	Set(cache, "slice-key", sliceValue)
 
-	retrieved, ok = cache.Get("slice-key")
+	retrievedSlice, ok := Get[[]string](cache, "slice-key")
 	assert.True(t, ok)
-	assert.Equal(t, sliceValue, retrieved)
+	assert.Equal(t, sliceValue, retrievedSlice)
+}
+
+func TestFileCache_TypedStructsSurviveRoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cache, err := NewFileCache(tempDir, time.Hour)
+	require.NoError(t, err)
+
+	merged := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ttm := 3 * time.Hour
+	prs := []models.PullRequest{
+		{Number: 1, Title: "Add feature", State: models.PRStateMerged, MergedAt: &merged, TimeToMerge: &ttm},
+	}
+	Set(cache, "prs:org/repo", prs)
+
+	// A fresh cache instance reads what a previous run wrote
+	reopened, err := NewFileCache(tempDir, time.Hour)
+	require.NoError(t, err)
+
+	got, ok := Get[[]models.PullRequest](reopened, "prs:org/repo")
+	require.True(t, ok)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Add feature", got[0].Title)
+	assert.True(t, merged.Equal(*got[0].MergedAt))
+	assert.Equal(t, ttm, *got[0].TimeToMerge)
+
+	// Decoding into an incompatible type is a miss, not a panic
+	_, ok = Get[int](reopened, "prs:org/repo")
+	assert.False(t, ok)
+}
+
+func TestFileCache_VersionMismatchIsMiss(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cache, err := NewFileCache(tempDir, time.Hour)
+	require.NoError(t, err)
+
+	// Simulate an entry written by a build with different cached types
+	header, err := json.Marshal(entryHeader{
+		Key:         "prs:old",
+		Format:      FormatVersion,
+		DataVersion: DataVersion + 1,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	path := cache.keyToPath("prs:old")
+	require.NoError(t, os.WriteFile(path, append(append(header, '\n'), []byte(`"stale"`)...), 0600))
+
+	_, ok := Get[string](cache, "prs:old")
+	assert.False(t, ok)
+
+	// Outdated entries are removed on access
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
 }
 
 func TestFileCache_CreateDirectory(t *testing.T) {
@@ -143,8 +200,8 @@ func TestFileCache_CreateDirectory(t *testing.T) {
 	assert.True(t, info.IsDir())
 
 	// Should be usable
-	cache.Set("key", "value")
-	value, ok := cache.Get("key")
+	Set(cache, "key", "value")
+	value, ok := Get[string](cache, "key")
 	assert.True(t, ok)
 	assert.Equal(t, "value", value)
 }
@@ -155,12 +212,12 @@ func TestNoopCache_AlwaysReturnsFalse(t *testing.T) {
 	cache := NewNoopCache()
 
 	// Set something
-	cache.Set("key", "value")
+	Set(cache, "key", "value")
 
 	// Get should return false
-	value, ok := cache.Get("key")
+	value, ok := Get[string](cache, "key")
 	assert.False(t, ok)
-	assert.Nil(t, value)
+	assert.Empty(t, value)
 }
 
 func TestNoopCache_DeleteAndClear(t *testing.T) {
@@ -189,8 +246,8 @@ func TestFileCache_KeyToPath(t *testing.T) {
 	// Same key should produce same path
 	assert.Equal(t, path1, path1Again)
 
-	// Path should end with .gob
-	assert.Contains(t, path1, ".gob")
+	// Path should end with the entry extension
+	assert.Equal(t, entryExt, filepath.Ext(path1))
 }
 
 func TestCacheInterface(t *testing.T) {
@@ -200,3 +257,88 @@ func TestCacheInterface(t *testing.T) {
 	var _ Cache = (*FileCache)(nil)
 	var _ Cache = (*NoopCache)(nil)
 }
+
+func TestKeyType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "prs", KeyType("prs:org/repo:2024-01-01:<nil>"))
+	assert.Equal(t, "user_profile", KeyType("user_profile:alice"))
+	assert.Equal(t, "plain", KeyType("plain"))
+}
+
+func TestFileCache_Stats(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cache, err := NewFileCache(tempDir, time.Hour)
+	require.NoError(t, err)
+
+	Set(cache, "prs:org/a", "a")
+	Set(cache, "prs:org/b", "b")
+	Set(cache, "reviews:org/a:1", "r")
+
+	_, ok := Get[string](cache, "prs:org/a")
+	assert.True(t, ok)
+	_, ok = Get[string](cache, "prs:org/missing")
+	assert.False(t, ok)
+	require.NoError(t, cache.SaveStats())
+
+	// Session counters survive flushing
+	assert.Equal(t, Counter{Hits: 1, Misses: 1, Writes: 3}, cache.SessionStats())
+
+	// Counters accumulate across processes
+	other, err := NewFileCache(tempDir, time.Hour)
+	require.NoError(t, err)
+	_, ok = Get[string](other, "prs:org/b")
+	assert.True(t, ok)
+	require.NoError(t, other.SaveStats())
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Entries)
+	assert.Zero(t, stats.Expired)
+
+	require.Len(t, stats.Types, 2)
+	prs := stats.Types[0]
+	assert.Equal(t, "prs", prs.Type)
+	assert.Equal(t, 2, prs.Entries)
+	assert.Equal(t, int64(2), prs.Hits)
+	assert.Equal(t, int64(1), prs.Misses)
+	assert.Equal(t, int64(2), prs.Writes)
+	assert.InDelta(t, 66.67, prs.HitRate(), 0.01)
+	assert.Equal(t, "reviews", stats.Types[1].Type)
+	assert.Equal(t, 1, stats.Types[1].Entries)
+}
+
+func TestFileCache_Prune(t *testing.T) {
+	tempDir := t.TempDir()
+
+	short, err := NewFileCache(tempDir, 50*time.Millisecond)
+	require.NoError(t, err)
+	Set(short, "prs:old", "old")
+
+	time.Sleep(100 * time.Millisecond)
+
+	cache, err := NewFileCache(tempDir, time.Hour)
+	require.NoError(t, err)
+	Set(cache, "prs:fresh", "fresh")
+
+	// Unreadable entries are pruned too
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "corrupt"+entryExt), []byte("garbage"), 0600))
+	// Entries from the previous gob-based format are pruned as well
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "legacy"+legacyEntryExt), []byte("gob"), 0600))
+
+	removed, freed, err := cache.Prune()
+	require.NoError(t, err)
+	assert.Equal(t, 3, removed)
+	assert.Positive(t, freed)
+
+	_, ok := Get[string](cache, "prs:fresh")
+	assert.True(t, ok)
+}
+
+func TestCounter_HitRate(t *testing.T) {
+	t.Parallel()
+
+	assert.Zero(t, Counter{}.HitRate())
+	assert.Equal(t, 50.0, Counter{Hits: 1, Misses: 1}.HitRate())
+}
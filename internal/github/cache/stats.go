@@ -0,0 +1,279 @@
+package cache
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+)
+
+// statsFile stores hit/miss counters accumulated across runs
+const statsFile = "stats.json"
+
+// unknownKeyType groups entries whose key cannot be read (e.g. written by an older version)
+const unknownKeyType = "unknown"
+
+// legacyEntryExt is the extension of entries written before FormatVersion 2
+const legacyEntryExt = ".gob"
+
+// isEntryFile reports whether path is a current or legacy cache entry
+func isEntryFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == entryExt || ext == legacyEntryExt
+}
+
+// Counter holds hit/miss/write counts for a key type
+type Counter struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+	Writes int64 `json:"writes"`
+}
+
+// HitRate returns hits as a percentage of lookups
+func (c Counter) HitRate() float64 {
+	total := c.Hits + c.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(c.Hits) / float64(total) * 100
+}
+
+// TypeStats describes cached entries of a single key type
+type TypeStats struct {
+	Type    string `json:"type"`
+	Entries int    `json:"entries"`
+	Expired int    `json:"expired"`
+	Bytes   int64  `json:"bytes"`
+	Counter
+}
+
+// Stats describes the contents of a file cache
+type Stats struct {
+	Directory string       `json:"directory"`
+	Entries   int          `json:"entries"`
+	Expired   int          `json:"expired"`
+	Bytes     int64        `json:"bytes"` // Total size including non-entry files (e.g. raw data snapshot)
+	Types     []*TypeStats `json:"types"`
+}
+
+// KeyType returns the type portion of a cache key ("prs:owner/repo:..." -> "prs")
+func KeyType(key string) string {
+	if i := strings.Index(key, ":"); i > 0 {
+		return key[:i]
+	}
+	return key
+}
+
+func (c *FileCache) record(key string, hit bool) {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	counter := c.counter(KeyType(key))
+	if hit {
+		counter.Hits++
+		c.session.Hits++
+	} else {
+		counter.Misses++
+		c.session.Misses++
+	}
+}
+
+func (c *FileCache) recordWrite(key string) {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	c.counter(KeyType(key)).Writes++
+	c.session.Writes++
+}
+
+// SessionStats returns hit/miss/write counts of this process across all key types
+func (c *FileCache) SessionStats() Counter {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	return c.session
+}
+
+// counter returns the in-memory counter for a key type; statsMu must be held
+func (c *FileCache) counter(keyType string) *Counter {
+	if c.counters == nil {
+		c.counters = make(map[string]*Counter)
+	}
+	counter, ok := c.counters[keyType]
+	if !ok {
+		counter = &Counter{}
+		c.counters[keyType] = counter
+	}
+	return counter
+}
+
+// SaveStats adds this process's hit/miss counters to the persisted totals
+func (c *FileCache) SaveStats() error {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	if len(c.counters) == 0 {
+		return nil
+	}
+
+	totals := c.loadCounters()
+	for keyType, counter := range c.counters {
+		total := totals[keyType]
+		total.Hits += counter.Hits
+		total.Misses += counter.Misses
+		total.Writes += counter.Writes
+		totals[keyType] = total
+	}
+
+	data, err := json.Marshal(totals)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.directory, 0750); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(c.directory, statsFile), data, 0600); err != nil {
+		return err
+	}
+
+	c.counters = make(map[string]*Counter)
+	return nil
+}
+
+// loadCounters reads persisted counters, returning an empty map if none exist
+func (c *FileCache) loadCounters() map[string]Counter {
+	counters := make(map[string]Counter)
+	data, err := os.ReadFile(filepath.Join(c.directory, statsFile))
+	if err != nil {
+		return counters
+	}
+	_ = json.Unmarshal(data, &counters)
+	return counters
+}
+
+// Stats scans the cache directory and reports entry counts, sizes and
+// persisted hit rates per key type
+func (c *FileCache) Stats() (*Stats, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := &Stats{Directory: c.directory}
+	byType := make(map[string]*TypeStats)
+	typeStats := func(keyType string) *TypeStats {
+		ts, ok := byType[keyType]
+		if !ok {
+			ts = &TypeStats{Type: keyType}
+			byType[keyType] = ts
+		}
+		return ts
+	}
+
+	now := time.Now()
+	err := filepath.Walk(c.directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		stats.Bytes += info.Size()
+		if !isEntryFile(path) {
+			return nil
+		}
+
+		keyType := unknownKeyType
+		expired := true // Unreadable and outdated entries are never served
+		if header, ok := readHeader(path); ok {
+			keyType = KeyType(header.Key)
+			expired = !header.current() || now.After(header.ExpiresAt)
+		}
+
+		ts := typeStats(keyType)
+		ts.Entries++
+		ts.Bytes += info.Size()
+		stats.Entries++
+		if expired {
+			ts.Expired++
+			stats.Expired++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for keyType, counter := range c.loadCounters() {
+		typeStats(keyType).Counter = counter
+	}
+
+	for _, ts := range byType {
+		stats.Types = append(stats.Types, ts)
+	}
+	sort.Slice(stats.Types, func(i, j int) bool {
+		return stats.Types[i].Type < stats.Types[j].Type
+	})
+
+	return stats, nil
+}
+
+// Prune removes entries that have expired or are older than the cache TTL, as well
+// as unreadable entries and entries written by another format or schema version.
+// It returns the number of entries removed and the bytes freed.
+func (c *FileCache) Prune() (int, int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	var freed int64
+	now := time.Now()
+
+	err := filepath.Walk(c.directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || !isEntryFile(path) {
+			return nil
+		}
+
+		header, ok := readHeader(path)
+		stale := !ok || !header.current() || now.After(header.ExpiresAt) || now.Sub(info.ModTime()) > c.ttl
+		if !stale {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		freed += info.Size()
+		return nil
+	})
+
+	return removed, freed, err
+}
+
+// readHeader decodes only the header of a cache entry file
+func readHeader(path string) (entryHeader, bool) {
+	var header entryHeader
+
+	file, err := os.Open(path) // #nosec G304 -- path comes from walking the cache directory
+	if err != nil {
+		return header, false
+	}
+	defer file.Close()
+
+	header, err = decodeHeader(bufio.NewReader(file))
+	if err != nil || header.Key == "" {
+		return header, false
+	}
+	return header, true
+}
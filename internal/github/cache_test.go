@@ -0,0 +1,31 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lukaszraczylo/git-velocity/internal/fingerprint"
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// cachedTypes are the values the client keeps in the cache
+var cachedTypes = []any{
+	models.PullRequest{}, models.Review{}, models.ReviewComment{},
+	models.PullRequestFile{}, models.Issue{}, models.IssueComment{}, models.Reaction{},
+	models.RepositoryAdoption{}, models.RepositorySettings{},
+	models.AuditEvent{}, models.ExternalIdentity{},
+	UserProfile{}, CommitStats{},
+}
+
+func TestCachedTypes_Versioned(t *testing.T) {
+	t.Parallel()
+
+	// When a cached type changes, bump cache.DataVersion and record the
+	// new fingerprint and version here
+	const shape, version = "da329d83117e2302", 1
+
+	assert.Equal(t, shape, fingerprint.Types(cachedTypes...), "a cached type changed: bump cache.DataVersion")
+	assert.Equal(t, version, cache.DataVersion, "record the fingerprint of the new cache.DataVersion")
+}
@@ -8,14 +8,17 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v68/github"
 
 	"github.com/lukaszraczylo/git-velocity/internal/config"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
 	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+	"github.com/lukaszraczylo/git-velocity/internal/httpx"
+	"github.com/lukaszraczylo/git-velocity/internal/redact"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 )
 
 // ProgressCallback is called to report progress during API operations
@@ -39,21 +42,65 @@ func DefaultRetryConfig() RetryConfig {
 
 // Client wraps the GitHub API client with rate limiting and caching
 type Client struct {
-	gh       *github.Client
-	gql      *GraphQLClient // GraphQL client for batched queries
-	config   *config.Config
-	cache    cache.Cache
-	retry    RetryConfig
-	progress ProgressCallback
+	gh         *github.Client
+	gql        *GraphQLClient // GraphQL client for batched queries
+	config     *config.Config
+	cache      cache.Cache
+	retry      RetryConfig
+	resilience *Resilience // Circuit breakers and retry budget shared with the GraphQL client
+	usage      *Usage      // API call accounting shared with the GraphQL client
+	tokens     *TokenPool  // Nil with GitHub App authentication
+	throttle   *Throttle   // Nil without options.max_requests_per_hour
+	progress   ProgressCallback
+
+	// Set once the Search API rate limit was hit; searches fall back to
+	// listing for the rest of the run
+	searchLimited atomic.Bool
 }
 
 // NewClient creates a new GitHub client with the appropriate authentication
 func NewClient(ctx context.Context, cfg *config.Config) (*Client, error) {
+	return NewClientWithTransport(ctx, cfg, nil)
+}
+
+// NewClientWithTransport creates a GitHub client sending its requests through
+// base instead of the network transport built from cfg.Network; tests replay
+// recorded API responses through it
+func NewClientWithTransport(ctx context.Context, cfg *config.Config, base http.RoundTripper) (*Client, error) {
 	var gh *github.Client
 
+	cooldown, err := cfg.GetCircuitBreakerCooldown()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse circuit breaker cooldown: %w", err)
+	}
+	resilience := NewResilience(ResilienceConfig{
+		RetryBudget:      cfg.Options.RetryBudget,
+		FailureThreshold: cfg.Options.CircuitBreaker.Threshold,
+		Cooldown:         cooldown,
+	})
+	if base == nil {
+		network, err := httpx.New(cfg.Network)
+		if err != nil {
+			return nil, err
+		}
+		base = network
+	}
+	// Polite mode holds every request back, retries included
+	var throttle *Throttle
+	if perHour := cfg.Options.MaxRequestsPerHour; perHour > 0 {
+		throttle = NewThrottle(perHour)
+		base = throttle.Transport(base)
+	}
+	// Requests refused by an open circuit never reach the API, so they are not counted
+	usage := NewUsage()
+	transport := resilience.Transport(usage.Transport(base))
+
 	// Determine authentication method
+	var tokens *TokenPool
 	if cfg.HasGithubToken() {
-		gh = github.NewClient(nil).WithAuthToken(cfg.Auth.GithubToken)
+		// The pool sets the Authorization header, rotating among configured tokens
+		tokens = NewTokenPool(cfg.GithubTokens(), cfg.Auth.TokenRotation, transport)
+		gh = github.NewClient(&http.Client{Transport: tokens})
 	} else if cfg.HasGithubApp() {
 		// GitHub App authentication
 		privateKey, err := cfg.GetGithubAppPrivateKey()
@@ -62,7 +109,7 @@ func NewClient(ctx context.Context, cfg *config.Config) (*Client, error) {
 		}
 
 		itr, err := ghinstallation.New(
-			http.DefaultTransport,
+			transport,
 			cfg.Auth.GithubApp.AppID,
 			cfg.Auth.GithubApp.InstallationID,
 			privateKey,
@@ -93,17 +140,21 @@ func NewClient(ctx context.Context, cfg *config.Config) (*Client, error) {
 
 	// Initialize GraphQL client if using token auth (GraphQL doesn't support GitHub App auth easily)
 	var gql *GraphQLClient
-	if cfg.HasGithubToken() && cfg.Options.UseGraphQL {
-		gql = NewGraphQLClient(cfg.Auth.GithubToken)
+	if tokens != nil && cfg.Options.UseGraphQL {
+		gql = newGraphQLClient("", "", tokens, resilience)
 	}
 
 	return &Client{
-		gh:       gh,
-		gql:      gql,
-		config:   cfg,
-		cache:    c,
-		retry:    DefaultRetryConfig(),
-		progress: func(string) {}, // no-op by default
+		gh:         gh,
+		gql:        gql,
+		config:     cfg,
+		cache:      c,
+		retry:      DefaultRetryConfig(),
+		resilience: resilience,
+		usage:      usage,
+		tokens:     tokens,
+		throttle:   throttle,
+		progress:   func(string) {}, // no-op by default
 	}, nil
 }
 
@@ -114,12 +165,51 @@ func (c *Client) SetProgressCallback(cb ProgressCallback) {
 	}
 }
 
+// SaveCacheStats persists cache hit/miss counters for `git-velocity cache stats`
+func (c *Client) SaveCacheStats() error {
+	return c.cache.SaveStats()
+}
+
+// ResilienceReport returns retry budget and circuit breaker activity for this run
+func (c *Client) ResilienceReport() ResilienceReport {
+	return c.resilience.Report()
+}
+
+// APIUsage returns the API calls, bytes, cache lookups, retries, throttling
+// and rate limits of this run
+func (c *Client) APIUsage() models.APIUsage {
+	usage := c.usage.Snapshot()
+
+	cacheStats := c.cache.SessionStats()
+	usage.CacheHits = cacheStats.Hits
+	usage.CacheMisses = cacheStats.Misses
+
+	// Per-token limits replace the last limits seen across the pool
+	if c.tokens != nil && c.tokens.Size() > 1 {
+		usage.RateLimits = c.tokens.RateLimits()
+	}
+
+	if c.throttle != nil {
+		usage.ThrottledSeconds = c.throttle.Waited().Seconds()
+	}
+
+	report := c.resilience.Report()
+	usage.Retries = report.RetriesUsed
+	usage.RetriesRefused = report.RetriesRefused
+	for _, h := range report.Hosts {
+		usage.CircuitBreakerTrips += h.Trips
+	}
+
+	return usage
+}
+
 // HasGraphQL returns true if the GraphQL client is available
 func (c *Client) HasGraphQL() bool {
 	return c.gql != nil
 }
 
-// FetchPRsWithReviewsGraphQL fetches PRs and reviews using GraphQL (much fewer API calls)
+// FetchPRsWithReviewsGraphQL fetches PRs and reviews using GraphQL (much
+// fewer API calls). On error, the PRs fetched before it are returned too.
 func (c *Client) FetchPRsWithReviewsGraphQL(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.PullRequest, []models.Review, error) {
 	if c.gql == nil {
 		return nil, nil, fmt.Errorf("GraphQL client not initialized")
@@ -129,28 +219,27 @@ func (c *Client) FetchPRsWithReviewsGraphQL(ctx context.Context, owner, repo str
 
 	// Check cache
 	type cachedData struct {
-		PRs     []models.PullRequest
-		Reviews []models.Review
+		PRs     []models.PullRequest `json:"prs"`
+		Reviews []models.Review      `json:"reviews"`
 	}
-	if cached, ok := c.cache.Get(cacheKey); ok {
-		if data, ok := cached.(cachedData); ok {
-			c.progress("      Using cached PRs and reviews data (GraphQL)")
-			return data.PRs, data.Reviews, nil
-		}
+	if data, ok := cache.Get[cachedData](c.cache, cacheKey); ok {
+		c.progress("      Using cached PRs and reviews data (GraphQL)")
+		return data.PRs, data.Reviews, nil
 	}
 
 	prs, reviews, err := c.gql.FetchPRsWithReviews(ctx, owner, repo, since, until)
 	if err != nil {
-		return nil, nil, err
+		return prs, reviews, err // Partial results are not cached
 	}
 
 	// Cache results
-	c.cache.Set(cacheKey, cachedData{PRs: prs, Reviews: reviews})
+	cache.Set(c.cache, cacheKey, cachedData{PRs: prs, Reviews: reviews})
 
 	return prs, reviews, nil
 }
 
-// FetchIssuesWithCommentsGraphQL fetches issues and comments using GraphQL (much fewer API calls)
+// FetchIssuesWithCommentsGraphQL fetches issues and comments using GraphQL
+// (much fewer API calls). On error, the issues fetched before it are returned too.
 func (c *Client) FetchIssuesWithCommentsGraphQL(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.Issue, []models.IssueComment, error) {
 	if c.gql == nil {
 		return nil, nil, fmt.Errorf("GraphQL client not initialized")
@@ -160,23 +249,21 @@ func (c *Client) FetchIssuesWithCommentsGraphQL(ctx context.Context, owner, repo
 
 	// Check cache
 	type cachedData struct {
-		Issues   []models.Issue
-		Comments []models.IssueComment
+		Issues   []models.Issue        `json:"issues"`
+		Comments []models.IssueComment `json:"comments"`
 	}
-	if cached, ok := c.cache.Get(cacheKey); ok {
-		if data, ok := cached.(cachedData); ok {
-			c.progress("      Using cached issues and comments data (GraphQL)")
-			return data.Issues, data.Comments, nil
-		}
+	if data, ok := cache.Get[cachedData](c.cache, cacheKey); ok {
+		c.progress("      Using cached issues and comments data (GraphQL)")
+		return data.Issues, data.Comments, nil
 	}
 
 	issues, comments, err := c.gql.FetchIssuesWithComments(ctx, owner, repo, since, until)
 	if err != nil {
-		return nil, nil, err
+		return issues, comments, err // Partial results are not cached
 	}
 
 	// Cache results
-	c.cache.Set(cacheKey, cachedData{Issues: issues, Comments: comments})
+	cache.Set(c.cache, cacheKey, cachedData{Issues: issues, Comments: comments})
 
 	return issues, comments, nil
 }
@@ -190,7 +277,7 @@ func (c *Client) retryWithBackoff(ctx context.Context, operation string, fn func
 	networkRetries := 0
 
 	for {
-		lastErr = fn()
+		lastErr = redact.Error(fn())
 		if lastErr == nil {
 			return nil
 		}
@@ -225,6 +312,9 @@ func (c *Client) retryWithBackoff(ctx context.Context, operation string, fn func
 			if networkRetries > c.retry.MaxRetries {
 				return fmt.Errorf("%s failed after %d retries: %w", operation, c.retry.MaxRetries, lastErr)
 			}
+			if !c.resilience.AcquireRetry() {
+				return fmt.Errorf("%s failed (%w): %w", operation, ErrRetryBudgetExhausted, lastErr)
+			}
 
 			c.progress(fmt.Sprintf("      Retry %d/%d for %s (waiting %s)...", networkRetries, c.retry.MaxRetries, operation, backoff))
 
@@ -396,22 +486,37 @@ func (c *Client) GetCommitCountSince(ctx context.Context, owner, repo string, si
 var mainBranches = []string{"main", "master", "develop", "dev"}
 
 // FetchPullRequests fetches pull requests from a repository
-// Fetches PRs targeting main branches, filters by merge date
+// Fetches PRs targeting main branches, filters by merge date. With
+// options.pr_fetch_mode set to search they are found by the Search API.
 func (c *Client) FetchPullRequests(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.PullRequest, error) {
+	search := c.config.SearchPullRequests() && !c.searchLimited.Load()
 	cacheKey := fmt.Sprintf("prs:%s/%s:%v:%v", owner, repo, since, until)
+	if search {
+		cacheKey = fmt.Sprintf("prs_search:%s/%s:%v:%v", owner, repo, since, until)
+	}
 
 	// Check cache
-	if cached, ok := c.cache.Get(cacheKey); ok {
-		if prs, ok := cached.([]models.PullRequest); ok {
-			c.progress("      Using cached pull requests data")
-			return prs, nil
-		}
+	if prs, ok := cache.Get[[]models.PullRequest](c.cache, cacheKey); ok {
+		c.progress("      Using cached pull requests data")
+		return prs, nil
 	}
 
 	var allPRs []models.PullRequest
 
 	// Fetch PRs for each main branch separately (API supports base filter)
 	for _, baseBranch := range mainBranches {
+		if search {
+			prs, err := c.searchMergedPRs(ctx, owner, repo, baseBranch, since, until)
+			if err == nil {
+				allPRs = append(allPRs, prs...)
+				continue
+			}
+			if !errors.Is(err, errSearchLimited) {
+				return nil, err
+			}
+			search = false // List this and the remaining branches
+		}
+
 		prs, err := c.fetchPRsForBranch(ctx, owner, repo, baseBranch, since, until)
 		if err != nil {
 			// Branch might not exist, skip
@@ -423,12 +528,14 @@ func (c *Client) FetchPullRequests(ctx context.Context, owner, repo string, sinc
 	c.progress(fmt.Sprintf("      Found %d merged PRs to main branches in date range", len(allPRs)))
 
 	// Cache results
-	c.cache.Set(cacheKey, allPRs)
+	cache.Set(c.cache, cacheKey, allPRs)
 
 	return allPRs, nil
 }
 
-// fetchPRsForBranch fetches merged PRs for a specific base branch
+// fetchPRsForBranch fetches merged PRs for a specific base branch. PRs are
+// listed by last update, which is never before the merge, so listing stops
+// once whole pages were last updated before the date range.
 func (c *Client) fetchPRsForBranch(ctx context.Context, owner, repo, baseBranch string, since, until *time.Time) ([]models.PullRequest, error) {
 	opts := &github.PullRequestListOptions{
 		State:     "closed",
@@ -468,6 +575,9 @@ func (c *Client) fetchPRsForBranch(ctx context.Context, owner, repo, baseBranch
 			// Only consider merged PRs
 			return pr.MergedAt == nil
 		},
+		OrderDateFn: func(pr *github.PullRequest) time.Time {
+			return pr.GetUpdatedAt().Time
+		},
 		Since: since,
 		Until: until,
 	}
@@ -512,11 +622,54 @@ func (c *Client) FetchReviews(ctx context.Context, owner, repo string, prNumber
 	return FetchAllPages(ctx, c, cacheKey, config, fetcher)
 }
 
+// FetchPullRequestFiles fetches the files changed by a pull request
+func (c *Client) FetchPullRequestFiles(ctx context.Context, owner, repo string, prNumber int) ([]models.PullRequestFile, error) {
+	cacheKey := fmt.Sprintf("pr_files:%s/%s:%d", owner, repo, prNumber)
+
+	opts := &github.ListOptions{PerPage: 100}
+
+	fetcher := &SimpleFetcher[*github.CommitFile, models.PullRequestFile]{
+		FetchFn: func(ctx context.Context, page int) ([]*github.CommitFile, *github.Response, error) {
+			opts.Page = page
+			var files []*github.CommitFile
+			var resp *github.Response
+			err := c.retryWithBackoff(ctx, fmt.Sprintf("list files for PR #%d", prNumber), func() error {
+				var err error
+				files, resp, err = c.gh.PullRequests.ListFiles(ctx, owner, repo, prNumber, opts)
+				return err
+			})
+			return files, resp, err
+		},
+		ConvertFn: func(f *github.CommitFile) models.PullRequestFile {
+			return models.PullRequestFile{
+				Path:         f.GetFilename(),
+				PreviousPath: f.GetPreviousFilename(),
+				Additions:    f.GetAdditions(),
+				Deletions:    f.GetDeletions(),
+			}
+		},
+	}
+
+	config := DefaultFetchConfig("pull request files")
+	config.EarlyTermination = false // File lists are not ordered by date
+	config.Quiet = true             // Suppress per-page progress (called once per PR)
+
+	return FetchAllPages(ctx, c, cacheKey, config, fetcher)
+}
+
 // FetchIssues fetches issues from a repository
-// Uses early termination when sorted by date - stops when items are outside date range
+// Uses early termination when sorted by date - stops when items are outside date range.
+// With the search fetch strategy only the issues created in range are retrieved.
 func (c *Client) FetchIssues(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.Issue, error) {
 	cacheKey := fmt.Sprintf("issues:%s/%s:%v:%v", owner, repo, since, until)
 
+	if c.config.SearchIssues() && !c.searchLimited.Load() {
+		issues, err := c.searchIssues(ctx, owner, repo, since, until)
+		if !errors.Is(err, errSearchLimited) {
+			return issues, err
+		}
+	}
+
 	opts := &github.IssueListByRepoOptions{
 		State:     "all",
 		Sort:      "created",
@@ -625,16 +778,14 @@ func (c *Client) FetchUserProfiles(ctx context.Context, logins []string) (map[st
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			cacheKey := fmt.Sprintf("user_profile_%s", login)
-			if cached, ok := c.cache.Get(cacheKey); ok {
-				if profile, ok := cached.(UserProfile); ok {
-					results <- struct {
-						login   string
-						profile UserProfile
-						err     error
-					}{login, profile, nil}
-					return
-				}
+			cacheKey := fmt.Sprintf("user_profile:%s", login)
+			if profile, ok := cache.Get[UserProfile](c.cache, cacheKey); ok {
+				results <- struct {
+					login   string
+					profile UserProfile
+					err     error
+				}{login, profile, nil}
+				return
 			}
 
 			var profile UserProfile
@@ -654,7 +805,7 @@ func (c *Client) FetchUserProfiles(ctx context.Context, logins []string) (map[st
 			})
 
 			if err == nil {
-				c.cache.Set(cacheKey, profile)
+				cache.Set(c.cache, cacheKey, profile)
 			}
 			results <- struct {
 				login   string
@@ -713,9 +864,21 @@ func convertPullRequest(pr *github.PullRequest, owner, repo string) models.PullR
 		headBranch = pr.Head.GetRef()
 	}
 
+	// Unmerged PRs carry the SHA of a test merge commit
+	var mergeCommitSHA string
+	if mergedAt != nil {
+		mergeCommitSHA = pr.GetMergeCommitSHA()
+	}
+
+	var labels []string
+	for _, l := range pr.Labels {
+		labels = append(labels, l.GetName())
+	}
+
 	return models.PullRequest{
 		Number:       pr.GetNumber(),
 		Title:        pr.GetTitle(),
+		Body:         pr.GetBody(),
 		State:        state,
 		Author:       author,
 		Repository:   fmt.Sprintf("%s/%s", owner, repo),
@@ -724,13 +887,20 @@ func convertPullRequest(pr *github.PullRequest, owner, repo string) models.PullR
 		CreatedAt:    pr.GetCreatedAt().Time,
 		UpdatedAt:    pr.GetUpdatedAt().Time,
 		MergedAt:     mergedAt,
+		MergedBy:     pr.GetMergedBy().GetLogin(),
 		ClosedAt:     closedAt,
 		Additions:    pr.GetAdditions(),
 		Deletions:    pr.GetDeletions(),
 		FilesChanged: pr.GetChangedFiles(),
 		CommitCount:  pr.GetCommits(),
 		Comments:     pr.GetComments() + pr.GetReviewComments(),
+		Labels:       labels,
 		URL:          pr.GetHTMLURL(),
+		Association:  pr.GetAuthorAssociation(),
+		Draft:        pr.GetDraft(),
+		AutoMergeBy:  pr.GetAutoMerge().GetEnabledBy().GetLogin(),
+
+		MergeCommitSHA: mergeCommitSHA,
 	}
 }
 
@@ -760,6 +930,7 @@ func convertReview(r *github.PullRequestReview, owner, repo string, prNumber int
 		State:       state,
 		SubmittedAt: submittedAt,
 		Body:        r.GetBody(),
+		CommitID:    r.GetCommitID(),
 	}
 }
 
@@ -830,18 +1001,19 @@ func convertIssue(i *github.Issue, owner, repo string) models.Issue {
 	}
 
 	return models.Issue{
-		Number:     i.GetNumber(),
-		Title:      i.GetTitle(),
-		State:      state,
-		Author:     author,
-		Repository: fmt.Sprintf("%s/%s", owner, repo),
-		CreatedAt:  i.GetCreatedAt().Time,
-		UpdatedAt:  i.GetUpdatedAt().Time,
-		ClosedAt:   closedAt,
-		ClosedBy:   closedBy,
-		Comments:   i.GetComments(),
-		Labels:     labels,
-		URL:        i.GetHTMLURL(),
+		Number:      i.GetNumber(),
+		Title:       i.GetTitle(),
+		State:       state,
+		Author:      author,
+		Repository:  fmt.Sprintf("%s/%s", owner, repo),
+		CreatedAt:   i.GetCreatedAt().Time,
+		UpdatedAt:   i.GetUpdatedAt().Time,
+		ClosedAt:    closedAt,
+		ClosedBy:    closedBy,
+		Comments:    i.GetComments(),
+		Labels:      labels,
+		URL:         i.GetHTMLURL(),
+		Association: i.GetAuthorAssociation(),
 	}
 }
 
@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v68/github"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+)
+
+// CommitStats are the lines a commit changed according to GitHub
+type CommitStats struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+}
+
+// FetchCommitStats returns GitHub's line counts of a commit. Merge commits
+// are compared against their first parent, like the local diff analyzer.
+func (c *Client) FetchCommitStats(ctx context.Context, owner, repo, sha string) (CommitStats, error) {
+	cacheKey := fmt.Sprintf("commit_stats:%s/%s:%s", owner, repo, sha)
+	if stats, ok := cache.Get[CommitStats](c.cache, cacheKey); ok {
+		return stats, nil
+	}
+
+	var commit *github.RepositoryCommit
+	err := c.retryWithBackoff(ctx, "get commit", func() error {
+		var err error
+		// A single file per page: only the totals are needed
+		commit, _, err = c.gh.Repositories.GetCommit(ctx, owner, repo, sha, &github.ListOptions{PerPage: 1})
+		return err
+	})
+	if err != nil {
+		return CommitStats{}, fmt.Errorf("failed to get commit %s: %w", sha, err)
+	}
+
+	stats := CommitStats{
+		Additions: commit.GetStats().GetAdditions(),
+		Deletions: commit.GetStats().GetDeletions(),
+	}
+	// Commits never change, so their stats are cached for good
+	cache.Set(c.cache, cacheKey, stats)
+	return stats, nil
+}
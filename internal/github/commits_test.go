@@ -0,0 +1,31 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchCommitStats(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/widgets/commits/abc123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("per_page"), "only the totals are needed")
+		_, _ = w.Write([]byte(`{"sha":"abc123","stats":{"total":12,"additions":10,"deletions":2},"files":[{"filename":"main.go"}]}`))
+	})
+	mux.HandleFunc("/repos/acme/widgets/commits/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"No commit found for SHA: missing"}`))
+	})
+	client := newTestClient(t, mux, "")
+
+	stats, err := client.FetchCommitStats(t.Context(), "acme", "widgets", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, CommitStats{Additions: 10, Deletions: 2}, stats)
+
+	_, err = client.FetchCommitStats(t.Context(), "acme", "widgets", "missing")
+	require.ErrorContains(t, err, "failed to get commit missing")
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/google/go-github/v68/github"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
 )
 
 // DateFilterResult represents the result of date filtering
@@ -18,6 +20,9 @@ const (
 	DateTooNew
 	// DateTooOld means the item is older than the 'since' date
 	DateTooOld
+	// DateExclude means the item is outside the date range, but items after
+	// it may still be inside
+	DateExclude
 )
 
 // FilterByDate checks if a time falls within the specified date range
@@ -75,11 +80,9 @@ func FetchAllPages[T any, R any](
 ) ([]R, error) {
 	// Check cache first (skip if no cache key provided)
 	if cacheKey != "" {
-		if cached, ok := c.cache.Get(cacheKey); ok {
-			if results, ok := cached.([]R); ok {
-				c.progress(fmt.Sprintf("      Using cached %s data", config.ResourceName))
-				return results, nil
-			}
+		if results, ok := cache.Get[[]R](c.cache, cacheKey); ok {
+			c.progress(fmt.Sprintf("      Using cached %s data", config.ResourceName))
+			return results, nil
 		}
 	}
 
@@ -116,7 +119,7 @@ func FetchAllPages[T any, R any](
 
 			// Apply date filtering
 			switch fetcher.Filter(item) {
-			case DateTooNew:
+			case DateTooNew, DateExclude:
 				continue
 			case DateTooOld:
 				oldInPage++
@@ -148,7 +151,7 @@ func FetchAllPages[T any, R any](
 
 	// Cache results (skip if no cache key provided)
 	if cacheKey != "" {
-		c.cache.Set(cacheKey, allResults)
+		cache.Set(c.cache, cacheKey, allResults)
 	}
 
 	return allResults, nil
@@ -184,6 +187,11 @@ type DateFilteredFetcher[T any, R any] struct {
 	SkipFn    func(item T) bool
 	Since     *time.Time
 	Until     *time.Time
+
+	// OrderDateFn returns the date items are sorted by, when it isn't the
+	// date they are filtered by. Only items ordered before Since count as
+	// too old for early termination.
+	OrderDateFn func(item T) time.Time
 }
 
 func (f *DateFilteredFetcher[T, R]) Fetch(ctx context.Context, page int) ([]T, *github.Response, error) {
@@ -195,7 +203,11 @@ func (f *DateFilteredFetcher[T, R]) Convert(item T) R {
 }
 
 func (f *DateFilteredFetcher[T, R]) Filter(item T) DateFilterResult {
-	return FilterByDate(f.GetDateFn(item), f.Since, f.Until)
+	result := FilterByDate(f.GetDateFn(item), f.Since, f.Until)
+	if result == DateTooOld && f.OrderDateFn != nil && FilterByDate(f.OrderDateFn(item), f.Since, nil) != DateTooOld {
+		return DateExclude
+	}
+	return result
 }
 
 func (f *DateFilteredFetcher[T, R]) ShouldSkip(item T) bool {
@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/lukaszraczylo/git-velocity/internal/domain/models"
+	"github.com/lukaszraczylo/git-velocity/internal/redact"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
 	"github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
 )
@@ -68,19 +71,35 @@ func (p *progressBar) done() {
 
 // GraphQLClient wraps the githubv4 client for GitHub API
 type GraphQLClient struct {
-	client *githubv4.Client
+	client     *githubv4.Client
+	resilience *Resilience
 }
 
-// NewGraphQLClient creates a new GraphQL client for GitHub
-func NewGraphQLClient(token string) *GraphQLClient {
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	httpClient := oauth2.NewClient(context.Background(), src)
+// NewGraphQLClient creates a new GraphQL client for GitHub.
+// Requests are sent through transport and retried within the budget of resilience.
+func NewGraphQLClient(token string, transport http.RoundTripper, resilience *Resilience) *GraphQLClient {
+	return newGraphQLClient("", token, transport, resilience)
+}
+
+// newGraphQLClient creates a GraphQL client for the given endpoint, or for
+// github.com when it is empty. Without a token, transport authenticates
+// requests itself (as a TokenPool does).
+func newGraphQLClient(endpoint, token string, transport http.RoundTripper, resilience *Resilience) *GraphQLClient {
+	httpClient := &http.Client{Transport: transport}
+	if token != "" {
+		src := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		)
+		httpClient = oauth2.NewClient(context.WithValue(context.Background(), oauth2.HTTPClient, httpClient), src)
+	}
 	client := githubv4.NewClient(httpClient)
+	if endpoint != "" {
+		client = githubv4.NewEnterpriseClient(endpoint, httpClient)
+	}
 
 	return &GraphQLClient{
-		client: client,
+		client:     client,
+		resilience: resilience,
 	}
 }
 
@@ -109,10 +128,12 @@ type GQLFetchConfig[Q any, T any, R any] struct {
 	ConsecutiveOldPagesToStop int
 }
 
-// fetchGQLPaginated is a generic paginated fetcher for GraphQL queries
+// fetchGQLPaginated is a generic paginated fetcher for GraphQL queries.
+// When a page fails, the results of the pages before it are returned along
+// with the error.
 func fetchGQLPaginated[Q any, T any, R any](
 	ctx context.Context,
-	client *githubv4.Client,
+	client *GraphQLClient,
 	owner, repo string,
 	config GQLFetchConfig[Q, T, R],
 ) ([]R, error) {
@@ -134,28 +155,9 @@ func fetchGQLPaginated[Q any, T any, R any](
 	}
 
 	for {
-		// Retry logic for transient errors
-		var queryErr error
-		for retries := 0; retries < 3; retries++ {
-			queryErr = client.Query(ctx, config.Query, variables)
-			if queryErr == nil {
-				break
-			}
-			// Check if error is retryable
-			if !isGQLRetryableError(queryErr) {
-				break
-			}
-			// Wait before retry with exponential backoff
-			backoff := time.Duration(1<<retries) * time.Second
-			fmt.Fprintf(os.Stderr, "\r      GraphQL retry %d/3 (waiting %s): %v\n", retries+1, backoff, queryErr)
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(backoff):
-			}
-		}
+		queryErr := client.query(ctx, config.Query, variables)
 		if queryErr != nil {
-			return nil, fmt.Errorf("graphql query failed: %w", queryErr)
+			return allResults, fmt.Errorf("graphql query failed: %w", redact.Error(queryErr))
 		}
 
 		page := config.GetPageResult(config.Query)
@@ -214,6 +216,29 @@ func fetchGQLPaginated[Q any, T any, R any](
 	return allResults, nil
 }
 
+// query runs a GraphQL query, retrying transient errors with exponential
+// backoff within the retry budget
+func (c *GraphQLClient) query(ctx context.Context, q any, variables map[string]interface{}) error {
+	var err error
+	for retries := 0; retries < 3; retries++ {
+		err = c.client.Query(ctx, q, variables)
+		if err == nil || !isGQLRetryableError(err) {
+			return err
+		}
+		if !c.resilience.AcquireRetry() {
+			return fmt.Errorf("%w: %w", ErrRetryBudgetExhausted, err)
+		}
+		backoff := time.Duration(1<<retries) * time.Second
+		fmt.Fprintf(os.Stderr, "\r      GraphQL retry %d/3 (waiting %s): %v\n", retries+1, backoff, redact.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+	return err
+}
+
 // Query structs for PRs with reviews
 type gqlPRQuery struct {
 	Repository struct {
@@ -226,29 +251,60 @@ type gqlPRQuery struct {
 }
 
 type gqlPRNode struct {
-	Number       int
-	Title        string
-	State        string
-	Merged       bool
-	Additions    int
-	Deletions    int
-	ChangedFiles int
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	MergedAt     *time.Time
-	ClosedAt     *time.Time
-	BaseRefName  string
-	HeadRefName  string
-	URL          string
-	Commits      struct{ TotalCount int }
-	Author       gqlActor
-	Reviews      struct {
+	Number            int
+	Title             string
+	Body              string
+	State             string
+	Merged            bool
+	Additions         int
+	Deletions         int
+	ChangedFiles      int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	MergedAt          *time.Time
+	MergedBy          *gqlActor
+	ClosedAt          *time.Time
+	MergeCommit       *struct{ Oid string }
+	BaseRefName       string
+	HeadRefName       string
+	URL               string
+	Commits           struct{ TotalCount int }
+	Author            gqlActor
+	AuthorAssociation string
+	IsDraft           bool
+	Labels            struct {
+		Nodes []struct{ Name string }
+	} `graphql:"labels(first: 10)"`
+	TimelineItems struct {
+		Nodes []gqlTimelineEvent
+	} `graphql:"timelineItems(first: 20, itemTypes: [READY_FOR_REVIEW_EVENT, CONVERT_TO_DRAFT_EVENT, AUTO_MERGE_ENABLED_EVENT, ADDED_TO_MERGE_QUEUE_EVENT])"`
+	Reviews struct {
 		TotalCount int
 		Nodes      []gqlReviewNode
 		PageInfo   PageInfo
 	} `graphql:"reviews(first: 100)"`
 }
 
+// gqlTimelineEvent is a PR timeline item marking it ready or converting it
+// to a draft, enabling auto-merge or adding it to the merge queue
+type gqlTimelineEvent struct {
+	Typename       string `graphql:"__typename"`
+	ReadyForReview struct {
+		CreatedAt time.Time
+	} `graphql:"... on ReadyForReviewEvent"`
+	ConvertToDraft struct {
+		CreatedAt time.Time
+	} `graphql:"... on ConvertToDraftEvent"`
+	AutoMergeEnabled struct {
+		CreatedAt time.Time
+		Actor     *gqlActor
+	} `graphql:"... on AutoMergeEnabledEvent"`
+	AddedToMergeQueue struct {
+		CreatedAt time.Time
+		Actor     *gqlActor
+	} `graphql:"... on AddedToMergeQueueEvent"`
+}
+
 type gqlActor struct {
 	Login     string
 	AvatarURL string `graphql:"avatarUrl"`
@@ -256,10 +312,12 @@ type gqlActor struct {
 
 type gqlReviewNode struct {
 	ID          string `graphql:"id"`
+	DatabaseID  int64  `graphql:"databaseId"`
 	Author      gqlActor
 	State       string
 	SubmittedAt *time.Time
 	Body        string
+	Commit      *struct{ Oid string }
 	Comments    struct{ TotalCount int } `graphql:"comments"`
 }
 
@@ -275,15 +333,16 @@ type gqlIssueQuery struct {
 }
 
 type gqlIssueNode struct {
-	Number    int
-	Title     string
-	State     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	ClosedAt  *time.Time
-	URL       string
-	Author    gqlActor
-	Labels    struct {
+	Number            int
+	Title             string
+	State             string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	ClosedAt          *time.Time
+	URL               string
+	Author            gqlActor
+	AuthorAssociation string
+	Labels            struct {
 		Nodes []struct{ Name string }
 	} `graphql:"labels(first: 10)"`
 	Comments struct {
@@ -294,10 +353,11 @@ type gqlIssueNode struct {
 }
 
 type gqlCommentNode struct {
-	ID        string `graphql:"id"`
-	Author    gqlActor
-	Body      string
-	CreatedAt time.Time
+	ID         string `graphql:"id"`
+	DatabaseID int64  `graphql:"databaseId"`
+	Author     gqlActor
+	Body       string
+	CreatedAt  time.Time
 }
 
 // prWithReviews bundles a PR with its reviews for the generic fetcher
@@ -306,7 +366,8 @@ type prWithReviews struct {
 	Reviews []models.Review
 }
 
-// FetchPRsWithReviews fetches pull requests with their reviews using GraphQL
+// FetchPRsWithReviews fetches pull requests with their reviews using GraphQL.
+// On error, the pull requests fetched before it are returned too.
 func (g *GraphQLClient) FetchPRsWithReviews(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.PullRequest, []models.Review, error) {
 	var query gqlPRQuery
 
@@ -317,7 +378,7 @@ func (g *GraphQLClient) FetchPRsWithReviews(ctx context.Context, owner, repo str
 		hardCutoff = &cutoff
 	}
 
-	results, err := fetchGQLPaginated(ctx, g.client, owner, repo, GQLFetchConfig[gqlPRQuery, gqlPRNode, prWithReviews]{
+	results, err := fetchGQLPaginated(ctx, g, owner, repo, GQLFetchConfig[gqlPRQuery, gqlPRNode, prWithReviews]{
 		Label:                     "      Fetching PRs:",
 		Query:                     &query,
 		ConsecutiveOldPagesToStop: 2,
@@ -342,8 +403,10 @@ func (g *GraphQLClient) FetchPRsWithReviews(ctx context.Context, owner, repo str
 				relevantDate = node.CreatedAt
 			}
 
-			// Hard cutoff check - stop entirely if past this date
-			if hardCutoff != nil && relevantDate.Before(*hardCutoff) {
+			// PRs are ordered by last update, which is never before the
+			// relevant date, so only the update time tells whether the
+			// remaining PRs can still be in range
+			if hardCutoff != nil && node.UpdatedAt.Before(*hardCutoff) {
 				return nil, true, true // Hard stop
 			}
 
@@ -352,7 +415,9 @@ func (g *GraphQLClient) FetchPRsWithReviews(ctx context.Context, owner, repo str
 				return nil, false, false // Too new, not "old"
 			}
 			if since != nil && relevantDate.Before(*since) {
-				return nil, true, false // Too old - signal for early termination tracking
+				// Merged or closed before the range; old for early
+				// termination only if it wasn't updated since either
+				return nil, node.UpdatedAt.Before(*since), false
 			}
 
 			// Convert PR
@@ -367,11 +432,8 @@ func (g *GraphQLClient) FetchPRsWithReviews(ctx context.Context, owner, repo str
 			return []prWithReviews{{PR: pr, Reviews: reviews}}, false, false
 		},
 	})
-	if err != nil {
-		return nil, nil, err
-	}
 
-	// Flatten results
+	// Flatten results, which are partial on error
 	var prs []models.PullRequest
 	var reviews []models.Review
 	for _, r := range results {
@@ -379,7 +441,7 @@ func (g *GraphQLClient) FetchPRsWithReviews(ctx context.Context, owner, repo str
 		reviews = append(reviews, r.Reviews...)
 	}
 
-	return prs, reviews, nil
+	return prs, reviews, err
 }
 
 // issueWithComments bundles an issue with its comments for the generic fetcher
@@ -388,7 +450,8 @@ type issueWithComments struct {
 	Comments []models.IssueComment
 }
 
-// FetchIssuesWithComments fetches issues with their comments using GraphQL
+// FetchIssuesWithComments fetches issues with their comments using GraphQL.
+// On error, the issues fetched before it are returned too.
 func (g *GraphQLClient) FetchIssuesWithComments(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.Issue, []models.IssueComment, error) {
 	var query gqlIssueQuery
 
@@ -399,7 +462,7 @@ func (g *GraphQLClient) FetchIssuesWithComments(ctx context.Context, owner, repo
 		hardCutoff = &cutoff
 	}
 
-	results, err := fetchGQLPaginated(ctx, g.client, owner, repo, GQLFetchConfig[gqlIssueQuery, gqlIssueNode, issueWithComments]{
+	results, err := fetchGQLPaginated(ctx, g, owner, repo, GQLFetchConfig[gqlIssueQuery, gqlIssueNode, issueWithComments]{
 		Label:                     "      Fetching issues:",
 		Query:                     &query,
 		ConsecutiveOldPagesToStop: 2,
@@ -442,11 +505,8 @@ func (g *GraphQLClient) FetchIssuesWithComments(ctx context.Context, owner, repo
 			return []issueWithComments{{Issue: issue, Comments: comments}}, false, false
 		},
 	})
-	if err != nil {
-		return nil, nil, err
-	}
 
-	// Flatten results
+	// Flatten results, which are partial on error
 	var issues []models.Issue
 	var comments []models.IssueComment
 	for _, r := range results {
@@ -454,7 +514,7 @@ func (g *GraphQLClient) FetchIssuesWithComments(ctx context.Context, owner, repo
 		comments = append(comments, r.Comments...)
 	}
 
-	return issues, comments, nil
+	return issues, comments, err
 }
 
 // Conversion helpers
@@ -474,9 +534,54 @@ func convertPRNode(node gqlPRNode, repoName string) models.PullRequest {
 		state = models.PRStateClosed
 	}
 
+	var mergeCommitSHA string
+	if node.Merged && node.MergeCommit != nil {
+		mergeCommitSHA = node.MergeCommit.Oid
+	}
+
+	var mergedBy string
+	if node.MergedBy != nil {
+		mergedBy = node.MergedBy.Login
+	}
+
+	var labels []string
+	for _, l := range node.Labels.Nodes {
+		labels = append(labels, l.Name)
+	}
+
+	var draftEvents []models.DraftEvent
+	var autoMergeBy, queuedBy string
+	var autoMergeAt, queuedAt *time.Time
+	// The last time each counts, as auto-merge may be disabled and the PR
+	// removed from the queue in between
+	latest := func(at time.Time, actor *gqlActor, when **time.Time, by *string) {
+		if *when != nil && !at.After(**when) {
+			return
+		}
+		*when = &at
+		*by = ""
+		if actor != nil {
+			*by = actor.Login
+		}
+	}
+	for _, e := range node.TimelineItems.Nodes {
+		switch e.Typename {
+		case "ReadyForReviewEvent":
+			draftEvents = append(draftEvents, models.DraftEvent{At: e.ReadyForReview.CreatedAt})
+		case "ConvertToDraftEvent":
+			draftEvents = append(draftEvents, models.DraftEvent{At: e.ConvertToDraft.CreatedAt, Draft: true})
+		case "AutoMergeEnabledEvent":
+			latest(e.AutoMergeEnabled.CreatedAt, e.AutoMergeEnabled.Actor, &autoMergeAt, &autoMergeBy)
+		case "AddedToMergeQueueEvent":
+			latest(e.AddedToMergeQueue.CreatedAt, e.AddedToMergeQueue.Actor, &queuedAt, &queuedBy)
+		}
+	}
+	sort.SliceStable(draftEvents, func(i, j int) bool { return draftEvents[i].At.Before(draftEvents[j].At) })
+
 	return models.PullRequest{
 		Number:       node.Number,
 		Title:        node.Title,
+		Body:         node.Body,
 		State:        state,
 		Author:       convertActor(node.Author),
 		Repository:   repoName,
@@ -485,13 +590,24 @@ func convertPRNode(node gqlPRNode, repoName string) models.PullRequest {
 		CreatedAt:    node.CreatedAt,
 		UpdatedAt:    node.UpdatedAt,
 		MergedAt:     node.MergedAt,
+		MergedBy:     mergedBy,
 		ClosedAt:     node.ClosedAt,
 		Additions:    node.Additions,
 		Deletions:    node.Deletions,
 		FilesChanged: node.ChangedFiles,
 		CommitCount:  node.Commits.TotalCount,
 		Comments:     node.Reviews.TotalCount,
+		Labels:       labels,
 		URL:          node.URL,
+		Association:  node.AuthorAssociation,
+		Draft:        node.IsDraft,
+		DraftEvents:  draftEvents,
+		AutoMergeBy:  autoMergeBy,
+		AutoMergeAt:  autoMergeAt,
+		QueuedBy:     queuedBy,
+		QueuedAt:     queuedAt,
+
+		MergeCommitSHA: mergeCommitSHA,
 	}
 }
 
@@ -501,7 +617,13 @@ func convertReviewNode(node gqlReviewNode, repoName string, prNumber int) models
 		submittedAt = *node.SubmittedAt
 	}
 
+	var commitID string
+	if node.Commit != nil {
+		commitID = node.Commit.Oid
+	}
+
 	return models.Review{
+		ID:            node.DatabaseID,
 		PullRequest:   prNumber,
 		Repository:    repoName,
 		Author:        convertActor(node.Author),
@@ -509,6 +631,7 @@ func convertReviewNode(node gqlReviewNode, repoName string, prNumber int) models
 		SubmittedAt:   submittedAt,
 		Body:          node.Body,
 		CommentsCount: node.Comments.TotalCount,
+		CommitID:      commitID,
 	}
 }
 
@@ -524,22 +647,24 @@ func convertIssueNode(node gqlIssueNode, repoName string) models.Issue {
 	}
 
 	return models.Issue{
-		Number:     node.Number,
-		Title:      node.Title,
-		State:      state,
-		Author:     convertActor(node.Author),
-		Repository: repoName,
-		CreatedAt:  node.CreatedAt,
-		UpdatedAt:  node.UpdatedAt,
-		ClosedAt:   node.ClosedAt,
-		Comments:   node.Comments.TotalCount,
-		Labels:     labels,
-		URL:        node.URL,
+		Number:      node.Number,
+		Title:       node.Title,
+		State:       state,
+		Author:      convertActor(node.Author),
+		Repository:  repoName,
+		CreatedAt:   node.CreatedAt,
+		UpdatedAt:   node.UpdatedAt,
+		ClosedAt:    node.ClosedAt,
+		Comments:    node.Comments.TotalCount,
+		Labels:      labels,
+		URL:         node.URL,
+		Association: node.AuthorAssociation,
 	}
 }
 
 func convertCommentNode(node gqlCommentNode, repoName string, issueNumber int) models.IssueComment {
 	return models.IssueComment{
+		ID:         node.DatabaseID,
 		Issue:      issueNumber,
 		Repository: repoName,
 		Author:     convertActor(node.Author),
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v68/github"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+)
+
+// ErrNotOrganization is returned for the members of an owner that is a user
+// account rather than an organization
+var ErrNotOrganization = errors.New("not an organization")
+
+// FetchOrgMembers lists the logins of an organization's members. Tokens of
+// outside collaborators only see the members who made their membership public.
+func (c *Client) FetchOrgMembers(ctx context.Context, org string) ([]string, error) {
+	cacheKey := fmt.Sprintf("org_members:%s", org)
+	if members, ok := cache.Get[[]string](c.cache, cacheKey); ok {
+		return members, nil
+	}
+
+	opts := &github.ListMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var members []string
+	for {
+		var users []*github.User
+		var resp *github.Response
+		err := c.retryWithBackoff(ctx, "list organization members", func() error {
+			var err error
+			users, resp, err = c.gh.Organizations.ListMembers(ctx, org, opts)
+			return err
+		})
+		if isStatus(err, http.StatusNotFound) {
+			return nil, fmt.Errorf("%s: %w", org, ErrNotOrganization)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members of %s: %w", org, err)
+		}
+
+		for _, user := range users {
+			members = append(members, user.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	cache.Set(c.cache, cacheKey, members)
+	return members, nil
+}
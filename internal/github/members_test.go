@@ -0,0 +1,36 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchOrgMembers(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acme/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+			_, _ = w.Write([]byte(`[{"login":"alice"},{"login":"bob"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"login":"carol"}]`))
+	})
+	mux.HandleFunc("/orgs/alice/members", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+	client := newTestClient(t, mux, "")
+
+	members, err := client.FetchOrgMembers(t.Context(), "acme")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, members)
+
+	_, err = client.FetchOrgMembers(t.Context(), "alice")
+	assert.ErrorIs(t, err, ErrNotOrganization, "user accounts have no members")
+}
@@ -0,0 +1,34 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+)
+
+// OptOutFile is the file contributors add to their profile repository, the
+// repository named after their login, to be left off the leaderboards
+const OptOutFile = ".git-velocity-opt-out"
+
+// FetchProfileOptOut reports whether a user's profile repository has the
+// opt-out file. Users without a profile repository have not opted out.
+func (c *Client) FetchProfileOptOut(ctx context.Context, login string) (bool, error) {
+	cacheKey := fmt.Sprintf("profile_opt_out:%s", login)
+	if optedOut, ok := cache.Get[bool](c.cache, cacheKey); ok {
+		return optedOut, nil
+	}
+
+	err := c.retryWithBackoff(ctx, "check profile opt-out", func() error {
+		_, _, _, err := c.gh.Repositories.GetContents(ctx, login, login, OptOutFile, nil)
+		return err
+	})
+	optedOut := err == nil
+	if err != nil && !isStatus(err, http.StatusNotFound) {
+		return false, fmt.Errorf("failed to check the profile repository of %s: %w", login, err)
+	}
+
+	cache.Set(c.cache, cacheKey, optedOut)
+	return optedOut, nil
+}
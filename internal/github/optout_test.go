@@ -0,0 +1,38 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchProfileOptOut(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/alice/alice/contents/.git-velocity-opt-out", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"type":"file","name":".git-velocity-opt-out","path":".git-velocity-opt-out","content":""}`))
+	})
+	mux.HandleFunc("/repos/bob/bob/contents/.git-velocity-opt-out", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+	mux.HandleFunc("/repos/carol/carol/contents/.git-velocity-opt-out", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+	})
+	client := newTestClient(t, mux, "")
+
+	optedOut, err := client.FetchProfileOptOut(t.Context(), "alice")
+	require.NoError(t, err)
+	assert.True(t, optedOut)
+
+	optedOut, err = client.FetchProfileOptOut(t.Context(), "bob")
+	require.NoError(t, err)
+	assert.False(t, optedOut, "no file or no profile repository")
+
+	_, err = client.FetchProfileOptOut(t.Context(), "carol")
+	assert.Error(t, err)
+}
@@ -0,0 +1,376 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+)
+
+// fakePR is a merged pull request served by fakeGitHub
+type fakePR struct {
+	Number    int
+	MergedAt  time.Time
+	UpdatedAt time.Time
+}
+
+// fakeGitHub serves merged pull requests and issues through the REST list,
+// search and get endpoints and GraphQL, each with the ordering and limits of
+// GitHub
+type fakeGitHub struct {
+	prs    []fakePR    // Sorted by UpdatedAt, newest first
+	issues []time.Time // Creation dates, newest first; numbered from 1
+
+	searchLimited bool         // Answer searches with a rate limit error
+	graphQLFailAt int          // Fail GraphQL pages from this PR on (0 never fails)
+	searches      atomic.Int32 // Search requests received
+	listed        atomic.Int32 // Issue list pages served
+}
+
+var (
+	mergedQualifier  = regexp.MustCompile(`merged:(\S+)\.\.(\S+)`)
+	createdQualifier = regexp.MustCompile(`created:(\S+)\.\.(\S+)`)
+)
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	page = max(page, 1)
+
+	switch {
+	case r.URL.Path == "/graphql":
+		f.serveGraphQL(w, r)
+	case r.URL.Path == "/search/issues":
+		f.searches.Add(1)
+		if f.searchLimited {
+			w.Header().Set("X-RateLimit-Limit", "30")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+			w.Header().Set("X-RateLimit-Resource", "search")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+			return
+		}
+
+		q := r.URL.Query().Get("q")
+		var items []map[string]any
+		if m := mergedQualifier.FindStringSubmatch(q); m != nil && strings.Contains(q, "base:main") {
+			start, _ := time.Parse(time.RFC3339, m[1])
+			end, _ := time.Parse(time.RFC3339, m[2])
+			for _, pr := range f.prs {
+				if !pr.MergedAt.Before(start) && !pr.MergedAt.After(end) {
+					items = append(items, map[string]any{"number": pr.Number})
+				}
+			}
+		}
+		if m := createdQualifier.FindStringSubmatch(q); m != nil && strings.Contains(q, "is:issue") {
+			start, _ := time.Parse(time.RFC3339, m[1])
+			end, _ := time.Parse(time.RFC3339, m[2])
+			for i, created := range f.issues {
+				if !created.Before(start) && !created.After(end) {
+					items = append(items, restIssue(i+1, created))
+				}
+			}
+		}
+		total := len(items)
+		items = items[:min(len(items), searchResultLimit)] // GitHub returns no more than this
+		writePage(w, r, items, page, map[string]any{"total_count": total, "incomplete_results": false})
+	case strings.HasPrefix(r.URL.Path, "/repos/owner/repo/pulls/"):
+		number, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/pulls/"))
+		for _, pr := range f.prs {
+			if pr.Number == number {
+				_ = json.NewEncoder(w).Encode(restPR(pr))
+				return
+			}
+		}
+		http.NotFound(w, r)
+	case r.URL.Path == "/repos/owner/repo/issues":
+		f.listed.Add(1)
+		var items []map[string]any
+		for i, created := range f.issues {
+			items = append(items, restIssue(i+1, created))
+		}
+		writePage(w, r, items, page, nil)
+	case r.URL.Path == "/repos/owner/repo/pulls":
+		var items []map[string]any
+		if r.URL.Query().Get("base") == "main" {
+			for _, pr := range f.prs {
+				items = append(items, restPR(pr))
+			}
+		}
+		writePage(w, r, items, page, nil)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// writePage writes page of items, 100 per page, with a Link header to the
+// next one; a non-nil envelope wraps them as its "items"
+func writePage(w http.ResponseWriter, r *http.Request, items []map[string]any, page int, envelope map[string]any) {
+	from, to := min((page-1)*100, len(items)), min(page*100, len(items))
+	if to < len(items) {
+		next := *r.URL
+		q := next.Query()
+		q.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = q.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.String()))
+	}
+	if envelope == nil {
+		_ = json.NewEncoder(w).Encode(items[from:to])
+		return
+	}
+	envelope["items"] = items[from:to]
+	_ = json.NewEncoder(w).Encode(envelope)
+}
+
+func restPR(pr fakePR) map[string]any {
+	return map[string]any{
+		"number":     pr.Number,
+		"state":      "closed",
+		"user":       map[string]any{"login": "dev"},
+		"base":       map[string]any{"ref": "main"},
+		"created_at": pr.MergedAt.Add(-time.Hour),
+		"updated_at": pr.UpdatedAt,
+		"merged_at":  pr.MergedAt,
+		"closed_at":  pr.MergedAt,
+	}
+}
+
+func restIssue(number int, created time.Time) map[string]any {
+	return map[string]any{
+		"number":     number,
+		"state":      "open",
+		"user":       map[string]any{"login": "dev"},
+		"created_at": created,
+		"updated_at": created,
+	}
+}
+
+func (f *fakeGitHub) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Variables struct {
+			Cursor *string `json:"cursor"`
+		} `json:"variables"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	offset := 0
+	if req.Variables.Cursor != nil {
+		offset, _ = strconv.Atoi(*req.Variables.Cursor)
+	}
+
+	if f.graphQLFailAt > 0 && offset >= f.graphQLFailAt {
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": []any{map[string]any{"message": "Something went wrong while executing your query"}}})
+		return
+	}
+
+	end := min(offset+100, len(f.prs))
+	nodes := []map[string]any{}
+	for _, pr := range f.prs[offset:end] {
+		nodes = append(nodes, map[string]any{
+			"number":      pr.Number,
+			"state":       "MERGED",
+			"merged":      true,
+			"createdAt":   pr.MergedAt.Add(-time.Hour),
+			"updatedAt":   pr.UpdatedAt,
+			"mergedAt":    pr.MergedAt,
+			"closedAt":    pr.MergedAt,
+			"baseRefName": "main",
+			"author":      map[string]any{"login": "dev"},
+			"reviews":     map[string]any{"totalCount": 0, "nodes": []any{}},
+		})
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequests": map[string]any{
+		"totalCount": len(f.prs),
+		"pageInfo":   map[string]any{"hasNextPage": end < len(f.prs), "endCursor": strconv.Itoa(end)},
+		"nodes":      nodes,
+	}}}})
+}
+
+func newTestClient(t *testing.T, handler http.Handler, mode string) *Client {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Options.PRFetchMode = mode
+	return newTestClientWithConfig(t, handler, cfg)
+}
+
+func newTestClientWithConfig(t *testing.T, handler http.Handler, cfg *config.Config) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	gh := github.NewClient(server.Client())
+	base, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	gh.BaseURL = base
+
+	resilience := NewResilience(ResilienceConfig{})
+	return &Client{
+		gh:         gh,
+		gql:        newGraphQLClient(server.URL+"/graphql", "test", server.Client().Transport, resilience),
+		config:     cfg,
+		cache:      cache.NewNoopCache(),
+		retry:      DefaultRetryConfig(),
+		resilience: resilience,
+		usage:      NewUsage(),
+		progress:   func(string) {},
+	}
+}
+
+func TestFetchPullRequests_LongLivedRepository(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	// In order of last update: 250 PRs merged last year but relabelled
+	// during the range, 30 merged during the range, 100 untouched since last year
+	fake := &fakeGitHub{}
+	relabelled := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	for i := range 250 {
+		fake.prs = append(fake.prs, fakePR{Number: 1000 + i, MergedAt: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: relabelled.Add(-time.Duration(i) * time.Second)})
+	}
+	var want []int
+	for i := range 30 {
+		merged := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Hour)
+		fake.prs = append(fake.prs, fakePR{Number: 2000 + i, MergedAt: merged, UpdatedAt: merged})
+		want = append(want, 2000+i)
+	}
+	for i := range 100 {
+		merged := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Hour)
+		fake.prs = append(fake.prs, fakePR{Number: 3000 + i, MergedAt: merged, UpdatedAt: merged})
+	}
+
+	for _, mode := range []string{config.PRFetchUpdated, config.PRFetchSearch} {
+		t.Run(mode, func(t *testing.T) {
+			t.Parallel()
+
+			prs, err := newTestClient(t, fake, mode).FetchPullRequests(t.Context(), "owner", "repo", &since, &until)
+			require.NoError(t, err)
+
+			numbers := make([]int, 0, len(prs))
+			for _, pr := range prs {
+				numbers = append(numbers, pr.Number)
+			}
+			sort.Ints(numbers)
+			assert.Equal(t, want, numbers)
+		})
+	}
+
+	// GraphQL finds the same merged PRs
+	prs, _, err := newTestClient(t, fake, config.PRFetchUpdated).FetchPRsWithReviewsGraphQL(t.Context(), "owner", "repo", &since, &until)
+	require.NoError(t, err)
+	numbers := make([]int, 0, len(prs))
+	for _, pr := range prs {
+		if pr.IsMerged() {
+			numbers = append(numbers, pr.Number)
+		}
+	}
+	sort.Ints(numbers)
+	assert.Equal(t, want, numbers)
+}
+
+func TestSearchPRNumbers_SplitsLargeWindows(t *testing.T) {
+	t.Parallel()
+
+	// More PRs than a single search returns
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := &fakeGitHub{}
+	for i := range 2500 {
+		merged := start.Add(time.Duration(i) * 10 * time.Minute)
+		fake.prs = append(fake.prs, fakePR{Number: i + 1, MergedAt: merged, UpdatedAt: merged})
+	}
+
+	client := newTestClient(t, fake, config.PRFetchSearch)
+	numbers, err := client.searchPRNumbers(t.Context(), "repo:owner/repo is:pr is:merged base:main", start, start.AddDate(0, 1, 0))
+	require.NoError(t, err)
+
+	require.Len(t, numbers, 2500)
+	sort.Ints(numbers)
+	for i, n := range numbers {
+		require.Equal(t, i+1, n, "every PR is found exactly once")
+	}
+}
+
+func TestFetchIssues_SearchStrategy(t *testing.T) {
+	t.Parallel()
+
+	// A year of daily issues; the range is a week in the middle
+	fake := &fakeGitHub{}
+	last := time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC)
+	for i := range 366 {
+		fake.issues = append(fake.issues, last.AddDate(0, 0, -i))
+	}
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 6, 7, 23, 59, 59, 0, time.UTC)
+
+	cfg := config.DefaultConfig()
+	cfg.Options.FetchStrategy = config.FetchSearch
+	client := newTestClientWithConfig(t, fake, cfg)
+
+	issues, err := client.FetchIssues(t.Context(), "owner", "repo", &since, &until)
+	require.NoError(t, err)
+	assert.Len(t, issues, 7)
+	assert.Equal(t, int32(1), fake.searches.Load())
+	assert.Zero(t, fake.listed.Load(), "issues after the range are not paged through")
+}
+
+func TestFetch_SearchRateLimitFallsBackToListing(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeGitHub{searchLimited: true}
+	merged := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	fake.prs = []fakePR{{Number: 1, MergedAt: merged, UpdatedAt: merged}}
+	fake.issues = []time.Time{merged}
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	cfg := config.DefaultConfig()
+	cfg.Options.FetchStrategy = config.FetchSearch
+	client := newTestClientWithConfig(t, fake, cfg)
+	var messages []string
+	client.progress = func(msg string) { messages = append(messages, msg) }
+
+	prs, err := client.FetchPullRequests(t.Context(), "owner", "repo", &since, &until)
+	require.NoError(t, err)
+	assert.Len(t, prs, 1)
+
+	issues, err := client.FetchIssues(t.Context(), "owner", "repo", &since, &until)
+	require.NoError(t, err)
+	assert.Len(t, issues, 1)
+
+	// The limit is not waited for, and later fetches don't search again
+	assert.Equal(t, int32(1), fake.searches.Load())
+	assert.Positive(t, fake.listed.Load())
+	assert.Contains(t, strings.Join(messages, "\n"), "falling back to listing")
+}
+
+func TestFetchPRsWithReviewsGraphQL_PartialResults(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	fake := &fakeGitHub{graphQLFailAt: 100}
+	for i := range 250 {
+		merged := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Minute)
+		fake.prs = append(fake.prs, fakePR{Number: i + 1, MergedAt: merged, UpdatedAt: merged})
+	}
+
+	// The first page is kept for the REST fallback to complete
+	prs, _, err := newTestClient(t, fake, config.PRFetchUpdated).FetchPRsWithReviewsGraphQL(t.Context(), "owner", "repo", &since, nil)
+	require.Error(t, err)
+	assert.Len(t, prs, 100)
+}
@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/lukaszraczylo/git-velocity/internal/redact"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// gqlReactionContents maps the GraphQL reaction contents counted as kudos to
+// their REST names
+var gqlReactionContents = map[string]string{
+	"THUMBS_UP": models.ReactionThumbsUp,
+	"HEART":     models.ReactionHeart,
+	"HOORAY":    models.ReactionHooray,
+}
+
+// FetchIssueReactions returns the kudos reactions on an issue or pull request
+func (c *Client) FetchIssueReactions(ctx context.Context, owner, repo string, number int) ([]models.Reaction, error) {
+	cacheKey := fmt.Sprintf("issue_reactions:%s/%s:%d", owner, repo, number)
+	return c.fetchReactions(ctx, cacheKey, func(opts *github.ListOptions) ([]*github.Reaction, *github.Response, error) {
+		return c.gh.Reactions.ListIssueReactions(ctx, owner, repo, number, opts)
+	})
+}
+
+// FetchCommentReactions returns the kudos reactions on an issue comment
+func (c *Client) FetchCommentReactions(ctx context.Context, owner, repo string, id int64) ([]models.Reaction, error) {
+	cacheKey := fmt.Sprintf("comment_reactions:%s/%s:%d", owner, repo, id)
+	return c.fetchReactions(ctx, cacheKey, func(opts *github.ListOptions) ([]*github.Reaction, *github.Response, error) {
+		return c.gh.Reactions.ListIssueCommentReactions(ctx, owner, repo, id, opts)
+	})
+}
+
+// fetchReactions pages through reactions with list and keeps the kudos
+func (c *Client) fetchReactions(ctx context.Context, cacheKey string, list func(*github.ListOptions) ([]*github.Reaction, *github.Response, error)) ([]models.Reaction, error) {
+	opts := &github.ListOptions{PerPage: 100}
+
+	fetcher := &SimpleFetcher[*github.Reaction, models.Reaction]{
+		FetchFn: func(ctx context.Context, page int) ([]*github.Reaction, *github.Response, error) {
+			opts.Page = page
+			var reactions []*github.Reaction
+			var resp *github.Response
+			err := c.retryWithBackoff(ctx, "list reactions", func() error {
+				var err error
+				reactions, resp, err = list(opts)
+				return err
+			})
+			return reactions, resp, err
+		},
+		ConvertFn: func(r *github.Reaction) models.Reaction {
+			return models.Reaction{Login: r.GetUser().GetLogin(), Content: r.GetContent()}
+		},
+	}
+
+	config := DefaultFetchConfig("reactions")
+	config.EarlyTermination = false
+	config.Quiet = true
+
+	reactions, err := FetchAllPages(ctx, c, cacheKey, config, fetcher)
+	if err != nil {
+		return nil, err
+	}
+	return kudos(reactions), nil
+}
+
+// kudos returns the reactions counting as kudos
+func kudos(reactions []models.Reaction) []models.Reaction {
+	var out []models.Reaction
+	for _, r := range reactions {
+		if r.Login != "" && models.IsKudos(r.Content) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// FetchReviewReactions returns the kudos reactions on the reviews of a pull
+// request by review ID. The REST API has no reactions on reviews, so this
+// needs GraphQL.
+func (c *Client) FetchReviewReactions(ctx context.Context, owner, repo string, number int) (map[int64][]models.Reaction, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized")
+	}
+	return c.gql.FetchReviewReactions(ctx, owner, repo, number)
+}
+
+// Query struct for the reactions on the reviews of a pull request
+type gqlReviewReactionsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			Reviews struct {
+				PageInfo PageInfo
+				Nodes    []struct {
+					DatabaseID int64 `graphql:"databaseId"`
+					Reactions  struct {
+						Nodes []struct {
+							Content string
+							User    *struct{ Login string }
+						}
+					} `graphql:"reactions(first: 100)"`
+				}
+			} `graphql:"reviews(first: 100, after: $cursor)"`
+		} `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// FetchReviewReactions returns the kudos reactions on the reviews of a pull
+// request by review ID; only the first 100 reactions of each review are read
+func (g *GraphQLClient) FetchReviewReactions(ctx context.Context, owner, repo string, number int) (map[int64][]models.Reaction, error) {
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"repo":   githubv4.String(repo),
+		"number": githubv4.Int(number),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	reactions := make(map[int64][]models.Reaction)
+	for {
+		var q gqlReviewReactionsQuery
+		if err := g.query(ctx, &q, variables); err != nil {
+			return nil, fmt.Errorf("graphql query failed: %w", redact.Error(err))
+		}
+		reviews := q.Repository.PullRequest.Reviews
+		for _, review := range reviews.Nodes {
+			for _, r := range review.Reactions.Nodes {
+				content, ok := gqlReactionContents[r.Content]
+				if !ok || r.User == nil || r.User.Login == "" {
+					continue
+				}
+				reactions[review.DatabaseID] = append(reactions[review.DatabaseID], models.Reaction{Login: r.User.Login, Content: content})
+			}
+		}
+		if !reviews.PageInfo.HasNextPage {
+			return reactions, nil
+		}
+		variables["cursor"] = githubv4.NewString(reviews.PageInfo.EndCursor)
+	}
+}
@@ -0,0 +1,249 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the API while a host's circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// ErrRetryBudgetExhausted is returned when a call fails after the run's retry budget is used up
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// ResilienceConfig holds circuit breaker and retry budget settings
+type ResilienceConfig struct {
+	RetryBudget      int           // Total retries per run (0 = unlimited)
+	FailureThreshold int           // Consecutive 5xx responses before a host's circuit opens (0 = disabled)
+	Cooldown         time.Duration // How long a circuit stays open before a trial request
+}
+
+// Resilience tracks per-host circuit breakers and the run-wide retry budget.
+// It is shared by the REST and GraphQL clients so both back off from a failing host.
+type Resilience struct {
+	config ResilienceConfig
+	now    func() time.Time
+
+	mu          sync.Mutex
+	retriesUsed int
+	exhausted   int // Retries refused because the budget was used up
+	hosts       map[string]*hostBreaker
+}
+
+// hostBreaker is the circuit state of a single host
+type hostBreaker struct {
+	consecutiveFailures int
+	openUntil           time.Time
+	halfOpen            bool // A trial request is in flight after the cooldown
+	trips               int
+	rejected            int
+	lastStatus          int
+}
+
+// NewResilience creates circuit breakers and a retry budget with the given settings
+func NewResilience(cfg ResilienceConfig) *Resilience {
+	return &Resilience{
+		config: cfg,
+		now:    time.Now,
+		hosts:  make(map[string]*hostBreaker),
+	}
+}
+
+// AcquireRetry takes one retry from the run's budget.
+// It returns false once the budget is used up.
+func (r *Resilience) AcquireRetry() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.config.RetryBudget > 0 && r.retriesUsed >= r.config.RetryBudget {
+		r.exhausted++
+		return false
+	}
+	r.retriesUsed++
+	return true
+}
+
+// Transport wraps base so requests to a host with an open circuit fail fast
+// and 5xx responses count towards opening it
+func (r *Resilience) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &breakerTransport{base: base, resilience: r}
+}
+
+// allow reports whether a request to host may be sent
+func (r *Resilience) allow(host string) bool {
+	if r.config.FailureThreshold <= 0 {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.host(host)
+	if b.openUntil.IsZero() {
+		return true
+	}
+	if r.now().Before(b.openUntil) || b.halfOpen {
+		b.rejected++
+		return false
+	}
+	// Cooldown elapsed: let a single trial request through
+	b.halfOpen = true
+	return true
+}
+
+// record updates the circuit of host with the outcome of a request
+func (r *Resilience) record(host string, status int) {
+	if r.config.FailureThreshold <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.host(host)
+	b.lastStatus = status
+	if status < http.StatusInternalServerError {
+		b.consecutiveFailures = 0
+		b.openUntil = time.Time{}
+		b.halfOpen = false
+		return
+	}
+
+	b.consecutiveFailures++
+	if b.halfOpen || b.consecutiveFailures >= r.config.FailureThreshold {
+		b.openUntil = r.now().Add(r.config.Cooldown)
+		b.halfOpen = false
+		b.trips++
+	}
+}
+
+// abort ends a trial request that failed without a response by reopening the circuit
+func (r *Resilience) abort(host string) {
+	if r.config.FailureThreshold <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.host(host)
+	if b.halfOpen {
+		b.openUntil = r.now().Add(r.config.Cooldown)
+		b.halfOpen = false
+	}
+}
+
+// host returns the breaker for host; mu must be held
+func (r *Resilience) host(host string) *hostBreaker {
+	b, ok := r.hosts[host]
+	if !ok {
+		b = &hostBreaker{}
+		r.hosts[host] = b
+	}
+	return b
+}
+
+// HostReport summarizes circuit breaker activity for a host
+type HostReport struct {
+	Host       string
+	Trips      int  // Times the circuit opened
+	Rejected   int  // Requests refused while open
+	Open       bool // Still open at the end of the run
+	LastStatus int
+}
+
+// ResilienceReport summarizes retries and circuit breaker activity for a run
+type ResilienceReport struct {
+	RetriesUsed    int
+	RetryBudget    int
+	RetriesRefused int
+	Hosts          []HostReport // Only hosts whose circuit opened
+}
+
+// Degraded reports whether any data may be missing because calls were cut short
+func (r ResilienceReport) Degraded() bool {
+	return r.RetriesRefused > 0 || len(r.Hosts) > 0
+}
+
+// Lines formats the report for the end-of-run log
+func (r ResilienceReport) Lines() []string {
+	budget := "unlimited"
+	if r.RetryBudget > 0 {
+		budget = fmt.Sprintf("%d", r.RetryBudget)
+	}
+	lines := []string{fmt.Sprintf("API retries: %d used (budget: %s)", r.RetriesUsed, budget)}
+	if r.RetriesRefused > 0 {
+		lines = append(lines, fmt.Sprintf("Warning: retry budget exhausted, %d failed calls were not retried", r.RetriesRefused))
+	}
+	for _, h := range r.Hosts {
+		state := "closed"
+		if h.Open {
+			state = "open"
+		}
+		lines = append(lines, fmt.Sprintf("Warning: circuit breaker for %s opened %d time(s) after HTTP %d responses, %d requests skipped (now %s)",
+			h.Host, h.Trips, h.LastStatus, h.Rejected, state))
+	}
+	if r.Degraded() {
+		lines = append(lines, "Warning: some data may be missing; re-run once GitHub has recovered (cached responses are reused)")
+	}
+	return lines
+}
+
+// Report returns a snapshot of retry budget and circuit breaker activity
+func (r *Resilience) Report() ResilienceReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := ResilienceReport{
+		RetriesUsed:    r.retriesUsed,
+		RetryBudget:    r.config.RetryBudget,
+		RetriesRefused: r.exhausted,
+	}
+	now := r.now()
+	for host, b := range r.hosts {
+		if b.trips == 0 {
+			continue
+		}
+		report.Hosts = append(report.Hosts, HostReport{
+			Host:       host,
+			Trips:      b.trips,
+			Rejected:   b.rejected,
+			Open:       now.Before(b.openUntil),
+			LastStatus: b.lastStatus,
+		})
+	}
+	sort.Slice(report.Hosts, func(i, j int) bool {
+		return report.Hosts[i].Host < report.Hosts[j].Host
+	})
+	return report
+}
+
+// breakerTransport applies a Resilience's circuit breakers to HTTP requests
+type breakerTransport struct {
+	base       http.RoundTripper
+	resilience *Resilience
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if !t.resilience.allow(host) {
+		return nil, fmt.Errorf("%s: %w", host, ErrCircuitOpen)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		// Transport errors are retried per call; only server errors trip the circuit
+		t.resilience.abort(host)
+		return nil, err
+	}
+	t.resilience.record(host, resp.StatusCode)
+	return resp, nil
+}
@@ -0,0 +1,123 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResilience_AcquireRetry(t *testing.T) {
+	t.Parallel()
+
+	r := NewResilience(ResilienceConfig{RetryBudget: 2})
+	assert.True(t, r.AcquireRetry())
+	assert.True(t, r.AcquireRetry())
+	assert.False(t, r.AcquireRetry())
+
+	report := r.Report()
+	assert.Equal(t, 2, report.RetriesUsed)
+	assert.Equal(t, 1, report.RetriesRefused)
+	assert.True(t, report.Degraded())
+
+	unlimited := NewResilience(ResilienceConfig{})
+	for i := 0; i < 1000; i++ {
+		require.True(t, unlimited.AcquireRetry())
+	}
+	assert.False(t, unlimited.Report().Degraded())
+}
+
+func TestResilience_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	var status atomic.Int32
+	status.Store(http.StatusBadGateway)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	r := NewResilience(ResilienceConfig{FailureThreshold: 3, Cooldown: time.Minute})
+	r.now = func() time.Time { return now }
+	client := &http.Client{Transport: r.Transport(nil)}
+
+	get := func() error {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	// Opens after three consecutive 5xx responses
+	for i := 0; i < 3; i++ {
+		require.NoError(t, get())
+	}
+	err := get()
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), calls.Load())
+
+	report := r.Report()
+	require.Len(t, report.Hosts, 1)
+	assert.Equal(t, 1, report.Hosts[0].Trips)
+	assert.Equal(t, 1, report.Hosts[0].Rejected)
+	assert.True(t, report.Hosts[0].Open)
+	assert.Equal(t, http.StatusBadGateway, report.Hosts[0].LastStatus)
+
+	// A failing trial request after the cooldown reopens the circuit immediately
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, get())
+	require.ErrorIs(t, get(), ErrCircuitOpen)
+	assert.Equal(t, 2, r.Report().Hosts[0].Trips)
+
+	// A successful trial request closes it
+	now = now.Add(2 * time.Minute)
+	status.Store(http.StatusOK)
+	require.NoError(t, get())
+	require.NoError(t, get())
+	assert.False(t, r.Report().Hosts[0].Open)
+}
+
+func TestResilience_CircuitBreakerDisabled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	r := NewResilience(ResilienceConfig{})
+	client := &http.Client{Transport: r.Transport(nil)}
+	for i := 0; i < 10; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	assert.Empty(t, r.Report().Hosts)
+}
+
+func TestResilienceReport_Lines(t *testing.T) {
+	t.Parallel()
+
+	healthy := ResilienceReport{RetriesUsed: 3, RetryBudget: 100}
+	assert.Equal(t, []string{"API retries: 3 used (budget: 100)"}, healthy.Lines())
+
+	degraded := ResilienceReport{
+		RetriesUsed:    10,
+		RetriesRefused: 2,
+		Hosts:          []HostReport{{Host: "api.github.com", Trips: 1, Rejected: 4, Open: true, LastStatus: 503}},
+	}
+	lines := degraded.Lines()
+	require.Len(t, lines, 4)
+	assert.Equal(t, "API retries: 10 used (budget: unlimited)", lines[0])
+	assert.Contains(t, lines[1], "2 failed calls were not retried")
+	assert.Contains(t, lines[2], "api.github.com opened 1 time(s) after HTTP 503")
+	assert.Contains(t, lines[3], "some data may be missing")
+}
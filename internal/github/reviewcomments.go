@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// FetchReviewComments fetches the inline review comments on a repository's
+// pull requests, the comments left on lines of a diff
+// Uses early termination when sorted by date - stops when items are outside date range
+func (c *Client) FetchReviewComments(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.ReviewComment, error) {
+	cacheKey := fmt.Sprintf("review_comments:%s/%s:%v:%v", owner, repo, since, until)
+
+	opts := &github.PullRequestListCommentsOptions{
+		Sort:        "created",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	// GitHub filters by update time; the fetcher filters by creation time
+	if since != nil {
+		opts.Since = *since
+	}
+
+	fetcher := &DateFilteredFetcher[*github.PullRequestComment, models.ReviewComment]{
+		FetchFn: func(ctx context.Context, page int) ([]*github.PullRequestComment, *github.Response, error) {
+			opts.Page = page
+			var comments []*github.PullRequestComment
+			var resp *github.Response
+			err := c.retryWithBackoff(ctx, "list review comments", func() error {
+				var err error
+				comments, resp, err = c.gh.PullRequests.ListComments(ctx, owner, repo, 0, opts)
+				return err
+			})
+			return comments, resp, err
+		},
+		ConvertFn: func(comment *github.PullRequestComment) models.ReviewComment {
+			return convertReviewComment(comment, owner, repo)
+		},
+		GetDateFn: func(comment *github.PullRequestComment) time.Time {
+			return comment.GetCreatedAt().Time
+		},
+		Since: since,
+		Until: until,
+	}
+
+	return FetchAllPages(ctx, c, cacheKey, DefaultFetchConfig("review comments"), fetcher)
+}
+
+func convertReviewComment(comment *github.PullRequestComment, owner, repo string) models.ReviewComment {
+	// Pull request URL format: https://api.github.com/repos/{owner}/{repo}/pulls/{number}
+	prNumber := 0
+	if i := strings.LastIndex(comment.GetPullRequestURL(), "/"); i >= 0 {
+		prNumber, _ = strconv.Atoi(comment.GetPullRequestURL()[i+1:])
+	}
+
+	var author models.Author
+	if comment.User != nil {
+		author = models.Author{
+			ID:        comment.User.GetID(),
+			Login:     comment.User.GetLogin(),
+			Name:      comment.User.GetName(),
+			AvatarURL: comment.User.GetAvatarURL(),
+		}
+	}
+
+	return models.ReviewComment{
+		ID:          comment.GetID(),
+		ReviewID:    comment.GetPullRequestReviewID(),
+		PullRequest: prNumber,
+		Repository:  fmt.Sprintf("%s/%s", owner, repo),
+		Author:      author,
+		Body:        comment.GetBody(),
+		Path:        comment.GetPath(),
+		Line:        comment.GetLine(),
+		CreatedAt:   comment.GetCreatedAt().Time,
+	}
+}
@@ -0,0 +1,48 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestFetchReviewComments(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/api/pulls/comments", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "created", r.URL.Query().Get("sort"))
+		assert.Equal(t, "desc", r.URL.Query().Get("direction"))
+		_, _ = w.Write([]byte(`[
+			{"id": 3, "body": "Too new", "created_at": "2024-02-02T00:00:00Z",
+			 "pull_request_url": "https://api.github.com/repos/acme/api/pulls/9", "user": {"login": "bob"}},
+			{"id": 2, "pull_request_review_id": 20, "body": "Handle the error here.", "path": "cache.go", "line": 12,
+			 "created_at": "2024-01-10T12:00:00Z", "pull_request_url": "https://api.github.com/repos/acme/api/pulls/7",
+			 "user": {"id": 5, "login": "bob"}},
+			{"id": 1, "body": "Too old", "created_at": "2023-12-01T00:00:00Z",
+			 "pull_request_url": "https://api.github.com/repos/acme/api/pulls/3", "user": {"login": "bob"}}
+		]`))
+	})
+	client := newTestClient(t, mux, "")
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	comments, err := client.FetchReviewComments(t.Context(), "acme", "api", &since, &until)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ReviewComment{{
+		ID:          2,
+		ReviewID:    20,
+		PullRequest: 7,
+		Repository:  "acme/api",
+		Author:      models.Author{ID: 5, Login: "bob"},
+		Body:        "Handle the error here.",
+		Path:        "cache.go",
+		Line:        12,
+		CreatedAt:   time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+	}}, comments)
+}
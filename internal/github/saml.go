@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+	"github.com/lukaszraczylo/git-velocity/internal/redact"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// ErrNoSAMLProvider is returned for the external identities of an
+// organization without SAML single sign-on
+var ErrNoSAMLProvider = errors.New("no SAML identity provider")
+
+// FetchExternalIdentities lists the SAML SSO identities linked to logins in
+// an organization. Only GitHub Enterprise Cloud organizations with SAML SSO
+// have them, and only organization owners' tokens with the admin:org scope
+// can read them. Identities not linked to a login are skipped.
+func (c *Client) FetchExternalIdentities(ctx context.Context, org string) ([]models.ExternalIdentity, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized")
+	}
+
+	cacheKey := fmt.Sprintf("saml_identities:%s", org)
+	if identities, ok := cache.Get[[]models.ExternalIdentity](c.cache, cacheKey); ok {
+		return identities, nil
+	}
+
+	identities, err := c.gql.FetchExternalIdentities(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Set(c.cache, cacheKey, identities)
+	return identities, nil
+}
+
+// gqlExternalIdentitiesQuery pages through an organization's SAML identities
+type gqlExternalIdentitiesQuery struct {
+	Organization struct {
+		SamlIdentityProvider *struct {
+			ExternalIdentities struct {
+				PageInfo PageInfo
+				Nodes    []gqlExternalIdentity
+			} `graphql:"externalIdentities(first: 100, after: $cursor)"`
+		}
+	} `graphql:"organization(login: $org)"`
+}
+
+type gqlExternalIdentity struct {
+	SamlIdentity *struct {
+		NameID     string `graphql:"nameId"`
+		GivenName  string
+		FamilyName string
+		Emails     []struct {
+			Value string
+		}
+	}
+	User *struct {
+		Login string
+	}
+}
+
+// FetchExternalIdentities lists the SAML identities linked to logins in an organization
+func (c *GraphQLClient) FetchExternalIdentities(ctx context.Context, org string) ([]models.ExternalIdentity, error) {
+	var q gqlExternalIdentitiesQuery
+	variables := map[string]interface{}{
+		"org":    githubv4.String(org),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var identities []models.ExternalIdentity
+	for {
+		if err := c.query(ctx, &q, variables); err != nil {
+			return nil, fmt.Errorf("failed to list SAML identities of %s: %w", org, redact.Error(err))
+		}
+		provider := q.Organization.SamlIdentityProvider
+		if provider == nil {
+			return nil, fmt.Errorf("%s: %w", org, ErrNoSAMLProvider)
+		}
+
+		for _, node := range provider.ExternalIdentities.Nodes {
+			if node.User == nil || node.User.Login == "" || node.SamlIdentity == nil {
+				continue
+			}
+			identities = append(identities, externalIdentity(node))
+		}
+
+		if !provider.ExternalIdentities.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(provider.ExternalIdentities.PageInfo.EndCursor)
+	}
+	return identities, nil
+}
+
+// externalIdentity names an identity by its given and family names and
+// lists its emails, starting with the NameID when identity providers use
+// emails as NameIDs
+func externalIdentity(node gqlExternalIdentity) models.ExternalIdentity {
+	saml := node.SamlIdentity
+	identity := models.ExternalIdentity{
+		Login: node.User.Login,
+		Name:  strings.TrimSpace(strings.TrimSpace(saml.GivenName) + " " + strings.TrimSpace(saml.FamilyName)),
+	}
+	seen := make(map[string]bool)
+	add := func(email string) {
+		email = strings.TrimSpace(email)
+		if strings.Contains(email, "@") && !seen[strings.ToLower(email)] {
+			seen[strings.ToLower(email)] = true
+			identity.Emails = append(identity.Emails, email)
+		}
+	}
+	add(saml.NameID)
+	for _, email := range saml.Emails {
+		add(email.Value)
+	}
+	return identity
+}
@@ -0,0 +1,74 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestFetchExternalIdentities(t *testing.T) {
+	t.Parallel()
+
+	identity := func(login, nameID, given, family string, emails ...string) map[string]any {
+		values := []any{}
+		for _, email := range emails {
+			values = append(values, map[string]any{"value": email})
+		}
+		node := map[string]any{
+			"samlIdentity": map[string]any{"nameId": nameID, "givenName": given, "familyName": family, "emails": values},
+			"user":         nil,
+		}
+		if login != "" {
+			node["user"] = map[string]any{"login": login}
+		}
+		return node
+	}
+	pages := [][]any{
+		{
+			identity("alice", "alice@corp.example", "Alice", "Smith", "Alice@corp.example", "a.smith@corp.example"),
+			identity("", "left@corp.example", "Former", "Employee"), // Not linked to a login
+		},
+		{identity("bob", "E1234", "Bob", "", "bob@corp.example")},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				Org    string  `json:"org"`
+				Cursor *string `json:"cursor"`
+			} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables.Org != "acme" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"organization": map[string]any{"samlIdentityProvider": nil}}})
+			return
+		}
+		page := 0
+		if req.Variables.Cursor != nil {
+			page = 1
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"organization": map[string]any{
+			"samlIdentityProvider": map[string]any{"externalIdentities": map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": page == 0, "endCursor": "next"},
+				"nodes":    pages[page],
+			}},
+		}}})
+	})
+	client := newTestClient(t, mux, "")
+
+	identities, err := client.FetchExternalIdentities(t.Context(), "acme")
+	require.NoError(t, err)
+	assert.Equal(t, []models.ExternalIdentity{
+		{Login: "alice", Name: "Alice Smith", Emails: []string{"alice@corp.example", "a.smith@corp.example"}},
+		{Login: "bob", Name: "Bob", Emails: []string{"bob@corp.example"}},
+	}, identities, "emails are deduplicated and NameIDs that aren't emails skipped")
+
+	_, err = client.FetchExternalIdentities(t.Context(), "other")
+	assert.ErrorIs(t, err, ErrNoSAMLProvider)
+}
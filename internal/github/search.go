@@ -0,0 +1,202 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// searchResultLimit is the most results the Search API returns for a query
+const searchResultLimit = 1000
+
+// errSearchLimited reports that a search hit the Search API rate limit. The
+// caller falls back to listing instead of waiting for the limit to reset.
+var errSearchLimited = errors.New("search rate limit reached")
+
+// searchEpoch bounds search windows without a start date; GitHub holds no
+// earlier pull requests
+var searchEpoch = time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// searchMergedPRs fetches the PRs merged into baseBranch within the date
+// range. The Search API filters by merge date on the server, so unlike
+// listing by last update it can't stop too early or too late.
+func (c *Client) searchMergedPRs(ctx context.Context, owner, repo, baseBranch string, since, until *time.Time) ([]models.PullRequest, error) {
+	start, end := searchWindow(since, until)
+	query := fmt.Sprintf("repo:%s/%s is:pr is:merged base:%s", owner, repo, baseBranch)
+	numbers, err := c.searchPRNumbers(ctx, query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) > 0 {
+		c.progress(fmt.Sprintf("      Found %d PRs merged to '%s' via search, fetching details...", len(numbers), baseBranch))
+	}
+
+	prs := make([]models.PullRequest, 0, len(numbers))
+	for _, number := range numbers {
+		pr, err := c.getPullRequest(ctx, owner, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+// searchPRNumbers returns the numbers of the PRs matching query that were
+// merged between start and end, both included. Windows with more matches
+// than one query returns are halved until each fits.
+func (c *Client) searchPRNumbers(ctx context.Context, query string, start, end time.Time) ([]int, error) {
+	q := fmt.Sprintf("%s merged:%s..%s", query, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	opts := &github.SearchOptions{
+		Sort:        "created",
+		Order:       "asc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var numbers []int
+	for {
+		result, resp, err := c.searchPage(ctx, "search pull requests", q, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.Page == 0 && result.GetTotal() > searchResultLimit && end.Sub(start) > time.Second {
+			mid := start.Add(end.Sub(start) / 2).Truncate(time.Second)
+			first, err := c.searchPRNumbers(ctx, query, start, mid)
+			if err != nil {
+				return nil, err
+			}
+			second, err := c.searchPRNumbers(ctx, query, mid.Add(time.Second), end)
+			if err != nil {
+				return nil, err
+			}
+			return append(first, second...), nil
+		}
+		for _, issue := range result.Issues {
+			numbers = append(numbers, issue.GetNumber())
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return numbers, nil
+}
+
+// getPullRequest fetches a single pull request
+func (c *Client) getPullRequest(ctx context.Context, owner, repo string, number int) (models.PullRequest, error) {
+	cacheKey := fmt.Sprintf("pr:%s/%s:%d", owner, repo, number)
+	if pr, ok := cache.Get[models.PullRequest](c.cache, cacheKey); ok {
+		return pr, nil
+	}
+
+	var pr *github.PullRequest
+	err := c.retryWithBackoff(ctx, fmt.Sprintf("get PR #%d", number), func() error {
+		var err error
+		pr, _, err = c.gh.PullRequests.Get(ctx, owner, repo, number)
+		return err
+	})
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("failed to get PR #%d: %w", number, err)
+	}
+
+	result := convertPullRequest(pr, owner, repo)
+	cache.Set(c.cache, cacheKey, result)
+	return result, nil
+}
+
+// searchIssues fetches the issues created within the date range. Unlike
+// listing, it doesn't page through the issues created after the range.
+func (c *Client) searchIssues(ctx context.Context, owner, repo string, since, until *time.Time) ([]models.Issue, error) {
+	start, end := searchWindow(since, until)
+	return c.searchIssuesIn(ctx, fmt.Sprintf("repo:%s/%s is:issue", owner, repo), owner, repo, start, end)
+}
+
+// searchIssuesIn returns the issues matching query created between start and
+// end, both included, halving windows with too many matches like
+// searchPRNumbers
+func (c *Client) searchIssuesIn(ctx context.Context, query, owner, repo string, start, end time.Time) ([]models.Issue, error) {
+	q := fmt.Sprintf("%s created:%s..%s", query, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	opts := &github.SearchOptions{
+		Sort:        "created",
+		Order:       "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var issues []models.Issue
+	for {
+		result, resp, err := c.searchPage(ctx, "search issues", q, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.Page == 0 && result.GetTotal() > searchResultLimit && end.Sub(start) > time.Second {
+			mid := start.Add(end.Sub(start) / 2).Truncate(time.Second)
+			first, err := c.searchIssuesIn(ctx, query, owner, repo, start, mid)
+			if err != nil {
+				return nil, err
+			}
+			second, err := c.searchIssuesIn(ctx, query, owner, repo, mid.Add(time.Second), end)
+			if err != nil {
+				return nil, err
+			}
+			return append(first, second...), nil
+		}
+
+		for _, issue := range result.Issues {
+			issues = append(issues, convertIssue(issue, owner, repo))
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return issues, nil
+}
+
+// searchPage runs one page of an issue search. Hitting the rate limit
+// returns errSearchLimited and makes later fetches list instead.
+func (c *Client) searchPage(ctx context.Context, operation, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error) {
+	var result *github.IssuesSearchResult
+	var resp *github.Response
+	err := c.retryWithBackoff(ctx, operation, func() error {
+		var err error
+		result, resp, err = c.gh.Search.Issues(ctx, query, opts)
+		if getRateLimitResetTime(err) != nil {
+			// Not wrapped, so retryWithBackoff doesn't wait for the reset
+			return fmt.Errorf("%w: %v", errSearchLimited, err)
+		}
+		return err
+	})
+	if errors.Is(err, errSearchLimited) {
+		if !c.searchLimited.Swap(true) {
+			c.progress("      Warning: search rate limit reached, falling back to listing for the rest of the run")
+		}
+		return nil, nil, err
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to %s: %w", operation, err)
+	}
+	if result.GetIncompleteResults() {
+		c.progress("      Warning: search results are incomplete (the search timed out on GitHub)")
+	}
+	return result, resp, nil
+}
+
+// searchWindow returns the date range to search, bounding open ends
+func searchWindow(since, until *time.Time) (time.Time, time.Time) {
+	start, end := searchEpoch, time.Now().UTC()
+	if since != nil {
+		start = *since
+	}
+	if until != nil {
+		end = *until
+	}
+	return start, end
+}
@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v68/github"
+
+	"github.com/lukaszraczylo/git-velocity/internal/github/cache"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// codeOwnersPaths are the locations GitHub looks for a CODEOWNERS file
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// protectionRules are the ruleset rule types that protect a branch
+var protectionRules = map[string]bool{
+	"pull_request":     true,
+	"non_fast_forward": true,
+	"deletion":         true,
+	"update":           true,
+}
+
+// FetchRepositorySettings fetches the settings inspected by repository health
+// checks. Classic branch protection is only readable by repository admins; when
+// it isn't, the protection state comes from the rulesets alone, or is left
+// unknown if no ruleset applies.
+func (c *Client) FetchRepositorySettings(ctx context.Context, owner, repo string) (models.RepositorySettings, error) {
+	cacheKey := fmt.Sprintf("repo_settings:%s/%s", owner, repo)
+	if settings, ok := cache.Get[models.RepositorySettings](c.cache, cacheKey); ok {
+		return settings, nil
+	}
+
+	var r *github.Repository
+	err := c.retryWithBackoff(ctx, "get repository", func() error {
+		var err error
+		r, _, err = c.gh.Repositories.Get(ctx, owner, repo)
+		return err
+	})
+	if err != nil {
+		return models.RepositorySettings{}, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	settings := models.RepositorySettings{
+		DefaultBranch: r.GetDefaultBranch(),
+		License:       r.GetLicense().GetSPDXID(),
+	}
+
+	if err := c.fetchBranchProtection(ctx, owner, repo, &settings); err != nil {
+		return models.RepositorySettings{}, err
+	}
+
+	for _, path := range codeOwnersPaths {
+		err := c.retryWithBackoff(ctx, "get CODEOWNERS", func() error {
+			_, _, _, err := c.gh.Repositories.GetContents(ctx, owner, repo, path, nil)
+			return err
+		})
+		if err == nil {
+			settings.CodeOwners = true
+			break
+		}
+		if !isStatus(err, http.StatusNotFound) {
+			return models.RepositorySettings{}, fmt.Errorf("failed to look up %s: %w", path, err)
+		}
+	}
+
+	cache.Set(c.cache, cacheKey, settings)
+	return settings, nil
+}
+
+// fetchBranchProtection combines classic branch protection and rulesets on the
+// default branch into the protection state and required approvals
+func (c *Client) fetchBranchProtection(ctx context.Context, owner, repo string, settings *models.RepositorySettings) error {
+	branch := settings.DefaultBranch
+	if branch == "" {
+		return nil
+	}
+
+	protected, reviews := false, 0
+	known := true
+
+	var protection *github.Protection
+	err := c.retryWithBackoff(ctx, "get branch protection", func() error {
+		var err error
+		protection, _, err = c.gh.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+		return err
+	})
+	switch {
+	case err == nil:
+		protected = true
+		reviews = protection.GetRequiredPullRequestReviews().RequiredApprovingReviewCount
+	case errors.Is(err, github.ErrBranchNotProtected):
+	case isStatus(err, http.StatusForbidden, http.StatusNotFound):
+		// Needs admin access to the repository
+		known = false
+	default:
+		return fmt.Errorf("failed to get branch protection: %w", err)
+	}
+
+	var rules []*github.RepositoryRule
+	err = c.retryWithBackoff(ctx, "get branch rules", func() error {
+		var err error
+		rules, _, err = c.gh.Repositories.GetRulesForBranch(ctx, owner, repo, branch)
+		return err
+	})
+	if err != nil && !isStatus(err, http.StatusForbidden, http.StatusNotFound) {
+		return fmt.Errorf("failed to get branch rules: %w", err)
+	}
+	for _, rule := range rules {
+		if !protectionRules[rule.Type] {
+			continue
+		}
+		protected, known = true, true
+		if rule.Type == "pull_request" && rule.Parameters != nil {
+			var params github.PullRequestRuleParameters
+			if json.Unmarshal(*rule.Parameters, &params) == nil {
+				reviews = max(reviews, params.RequiredApprovingReviewCount)
+			}
+		}
+	}
+
+	if known {
+		settings.BranchProtected = &protected
+		settings.RequiredReviews = &reviews
+	}
+	return nil
+}
+
+// isStatus reports whether err is a GitHub API error with one of the given HTTP statuses
+func isStatus(err error, statuses ...int) bool {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return false
+	}
+	for _, status := range statuses {
+		if errResp.Response.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
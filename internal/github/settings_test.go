@@ -0,0 +1,116 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchRepositorySettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		protection int    // status of the classic branch protection endpoint
+		rules      string // rules applying to the default branch
+		want       func(t *testing.T, protected *bool, reviews *int)
+	}{
+		{
+			name:       "classic protection",
+			protection: http.StatusOK,
+			rules:      `[]`,
+			want: func(t *testing.T, protected *bool, reviews *int) {
+				require.NotNil(t, protected)
+				assert.True(t, *protected)
+				require.NotNil(t, reviews)
+				assert.Equal(t, 1, *reviews)
+			},
+		},
+		{
+			name:       "ruleset without admin access",
+			protection: http.StatusForbidden,
+			rules:      `[{"type":"pull_request","parameters":{"required_approving_review_count":2}}]`,
+			want: func(t *testing.T, protected *bool, reviews *int) {
+				require.NotNil(t, protected)
+				assert.True(t, *protected)
+				require.NotNil(t, reviews)
+				assert.Equal(t, 2, *reviews)
+			},
+		},
+		{
+			name:       "not protected",
+			protection: http.StatusNotFound,
+			rules:      `[]`,
+			want: func(t *testing.T, protected *bool, reviews *int) {
+				assert.Nil(t, protected, "a plain 404 may mean missing admin access")
+				assert.Nil(t, reviews)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/repos/acme/api", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"default_branch":"main","license":{"spdx_id":"MIT"}}`))
+			})
+			mux.HandleFunc("/repos/acme/api/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.protection)
+				if tt.protection == http.StatusOK {
+					_, _ = w.Write([]byte(`{"required_pull_request_reviews":{"required_approving_review_count":1}}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			})
+			mux.HandleFunc("/repos/acme/api/rules/branches/main", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.rules))
+			})
+			mux.HandleFunc("/repos/acme/api/contents/", func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/acme/api/contents/CODEOWNERS" {
+					http.NotFound(w, r)
+					return
+				}
+				_, _ = w.Write([]byte(`{"type":"file","name":"CODEOWNERS","path":"CODEOWNERS"}`))
+			})
+
+			settings, err := newTestClient(t, mux, "").FetchRepositorySettings(t.Context(), "acme", "api")
+			require.NoError(t, err)
+
+			assert.Equal(t, "main", settings.DefaultBranch)
+			assert.Equal(t, "MIT", settings.License)
+			assert.True(t, settings.CodeOwners)
+			tt.want(t, settings.BranchProtected, settings.RequiredReviews)
+		})
+	}
+}
+
+func TestFetchRepositorySettings_BranchNotProtected(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/api", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"default_branch":"main"}`))
+	})
+	mux.HandleFunc("/repos/acme/api/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Branch not protected"}`))
+	})
+	mux.HandleFunc("/repos/acme/api/rules/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("/repos/acme/api/contents/", http.NotFound)
+
+	settings, err := newTestClient(t, mux, "").FetchRepositorySettings(t.Context(), "acme", "api")
+	require.NoError(t, err)
+
+	require.NotNil(t, settings.BranchProtected)
+	assert.False(t, *settings.BranchProtected)
+	require.NotNil(t, settings.RequiredReviews)
+	assert.Zero(t, *settings.RequiredReviews)
+	assert.False(t, settings.CodeOwners)
+	assert.Empty(t, settings.License)
+}
@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Throttle limits API requests to a number per hour with a token bucket, so
+// a run can share a token with other automation without starving it. A
+// minute's worth of requests may go out at once; after that they are
+// spaced evenly.
+type Throttle struct {
+	interval time.Duration // Time to earn one request
+	burst    float64
+	now      func() time.Time
+
+	mu     sync.Mutex
+	tokens float64 // Negative while requests are waiting for their turn
+	last   time.Time
+	waited time.Duration
+}
+
+// NewThrottle creates a throttle allowing perHour requests an hour
+func NewThrottle(perHour int) *Throttle {
+	now := time.Now
+	return &Throttle{
+		interval: time.Hour / time.Duration(perHour),
+		burst:    max(1, float64(perHour)/60),
+		now:      now,
+		tokens:   max(1, float64(perHour)/60),
+		last:     now(),
+	}
+}
+
+// Wait blocks until a request may be sent, or ctx is done
+func (t *Throttle) Wait(ctx context.Context) error {
+	delay := t.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a request from the bucket and returns how long to wait for it
+func (t *Throttle) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.tokens = min(t.burst, t.tokens+float64(now.Sub(t.last))/float64(t.interval))
+	t.last = now
+	t.tokens--
+	if t.tokens >= 0 {
+		return 0
+	}
+	delay := time.Duration(-t.tokens * float64(t.interval))
+	t.waited += delay
+	return delay
+}
+
+// Waited returns the total time requests were held back
+func (t *Throttle) Waited() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.waited
+}
+
+// Transport wraps base so every request waits for its turn
+func (t *Throttle) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &throttleTransport{base: base, throttle: t}
+}
+
+// throttleTransport holds requests back to the throttle's rate
+type throttleTransport struct {
+	base     http.RoundTripper
+	throttle *Throttle
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.throttle.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottle_Reserve(t *testing.T) {
+	t.Parallel()
+
+	// 600 requests an hour: one every 6s, bursts of 10
+	throttle := NewThrottle(600)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	throttle.now = func() time.Time { return now }
+	throttle.last = now
+
+	for i := 0; i < 10; i++ {
+		assert.Zero(t, throttle.reserve(), "request %d is within the burst", i+1)
+	}
+	assert.Equal(t, 6*time.Second, throttle.reserve())
+	assert.Equal(t, 12*time.Second, throttle.reserve(), "waiting requests queue up")
+
+	// A minute later the queue is drained and 8 requests were earned
+	now = now.Add(time.Minute)
+	for i := 0; i < 8; i++ {
+		assert.Zero(t, throttle.reserve())
+	}
+	assert.Equal(t, 6*time.Second, throttle.reserve())
+	assert.Equal(t, 24*time.Second, throttle.Waited())
+
+	// Idle time never earns more than a burst
+	now = now.Add(time.Hour)
+	for i := 0; i < 10; i++ {
+		assert.Zero(t, throttle.reserve())
+	}
+	assert.Positive(t, throttle.reserve())
+}
+
+func TestThrottle_Transport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	throttle := NewThrottle(60) // Bursts of 1
+	client := &http.Client{Transport: throttle.Transport(nil)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// The next request would wait a minute
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.ErrorIs(t, err, context.Canceled)
+}
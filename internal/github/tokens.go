@@ -0,0 +1,219 @@
+package github
+
+import (
+	"hash/fnv"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// TokenPool authenticates requests with one of several tokens, multiplying
+// the rate limit of a single token. It tracks each token's rate limits from
+// the responses, skips tokens that ran out until they reset, and retries a
+// rate-limited request with another token. Once every token is out, requests
+// go to the token resetting first and the client waits for the reset as it
+// would with a single token.
+type TokenPool struct {
+	base     http.RoundTripper
+	rotation string
+	now      func() time.Time
+
+	mu     sync.Mutex
+	tokens []*pooledToken
+	next   int // Round-robin position
+}
+
+// pooledToken is a token with the last rate limits reported for it
+type pooledToken struct {
+	token  string
+	limits map[string]models.RateLimitStatus // By resource
+}
+
+// NewTokenPool creates a pool sending requests through base, rotating
+// tokens per request or per repository (config.TokenRotation*)
+func NewTokenPool(tokens []string, rotation string, base http.RoundTripper) *TokenPool {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	p := &TokenPool{base: base, rotation: rotation, now: time.Now}
+	for _, token := range tokens {
+		p.tokens = append(p.tokens, &pooledToken{token: token, limits: make(map[string]models.RateLimitStatus)})
+	}
+	return p
+}
+
+// Size returns the number of tokens in the pool
+func (p *TokenPool) Size() int {
+	return len(p.tokens)
+}
+
+// RateLimits returns the last reported rate limits of every token, numbered
+// from 1 in configuration order
+func (p *TokenPool) RateLimits() []models.RateLimitStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var limits []models.RateLimitStatus
+	for i, t := range p.tokens {
+		for _, rl := range t.limits {
+			rl.Token = i + 1
+			limits = append(limits, rl)
+		}
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		if limits[i].Resource != limits[j].Resource {
+			return limits[i].Resource < limits[j].Resource
+		}
+		return limits[i].Token < limits[j].Token
+	})
+	return limits
+}
+
+// RoundTrip implements http.RoundTripper
+func (p *TokenPool) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := requestResource(req)
+	tried := make(map[int]bool)
+
+	for {
+		i := p.pick(req, resource, tried)
+		tried[i] = true
+
+		attempt := req.Clone(req.Context())
+		if len(tried) > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+		attempt.Header.Set("Authorization", "Bearer "+p.tokens[i].token)
+
+		resp, err := p.base.RoundTrip(attempt)
+		if err != nil {
+			return nil, err
+		}
+		rl, ok := parseRateLimit(resp.Header)
+		if ok {
+			p.record(i, rl)
+		}
+		if !ok || rl.Remaining > 0 {
+			return resp, nil
+		}
+
+		// This token ran out; retry a refused request with another one
+		other, found := p.available(rl.Resource, tried)
+		if !found {
+			return resp, nil
+		}
+		if isRateLimited(resp) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil) {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			continue
+		}
+		// Don't let the client hold back requests another token can serve
+		resp.Header.Set("X-RateLimit-Remaining", strconv.Itoa(max(other.Remaining, 1)))
+		return resp, nil
+	}
+}
+
+// pick chooses the token for a request: the next untried token with quota
+// left for resource in rotation order, or the untried token resetting first
+// when none has any
+func (p *TokenPool) pick(req *http.Request, resource string, tried map[int]bool) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := len(p.tokens)
+	start := p.next
+	if repo := requestRepository(req); p.rotation == config.TokenRotationRepository && repo != "" {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(repo))
+		start = int(h.Sum32() % uint32(n)) // #nosec G115 -- n is a small positive token count
+	}
+
+	best := -1
+	for k := 0; k < n; k++ {
+		i := (start + k) % n
+		if tried[i] {
+			continue
+		}
+		if !p.exhausted(i, resource) {
+			if start == p.next {
+				p.next = (i + 1) % n
+			}
+			return i
+		}
+		if best < 0 || p.tokens[i].limits[resource].ResetAt.Before(p.tokens[best].limits[resource].ResetAt) {
+			best = i
+		}
+	}
+	if best < 0 {
+		// Every token was tried; retries only follow when one has quota left
+		return start % n
+	}
+	return best
+}
+
+// available returns the quota of an untried token not exhausted for resource
+func (p *TokenPool) available(resource string, tried map[int]bool) (models.RateLimitStatus, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, t := range p.tokens {
+		if !tried[i] && !p.exhausted(i, resource) {
+			return t.limits[resource], true
+		}
+	}
+	return models.RateLimitStatus{}, false
+}
+
+// exhausted reports whether token i has no requests left for resource until
+// its reset; tokens without a reported limit are assumed to have quota
+func (p *TokenPool) exhausted(i int, resource string) bool {
+	rl, ok := p.tokens[i].limits[resource]
+	return ok && rl.Remaining == 0 && p.now().Before(rl.ResetAt)
+}
+
+func (p *TokenPool) record(i int, rl models.RateLimitStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tokens[i].limits[rl.Resource] = rl
+}
+
+// requestResource guesses the rate limit resource a request counts against,
+// before its response reports it
+func requestResource(req *http.Request) string {
+	switch {
+	case isGraphQLRequest(req):
+		return "graphql"
+	case strings.HasPrefix(req.URL.Path, "/search/") || strings.Contains(req.URL.Path, "/api/v3/search/"):
+		return "search"
+	default:
+		return "core"
+	}
+}
+
+// requestRepository returns "owner/repo" for REST calls under /repos/
+func requestRepository(req *http.Request) string {
+	path := req.URL.Path
+	if i := strings.Index(path, "/repos/"); i >= 0 {
+		parts := strings.SplitN(path[i+len("/repos/"):], "/", 3)
+		if len(parts) >= 2 && parts[0] != "" && parts[1] != "" {
+			return parts[0] + "/" + parts[1]
+		}
+	}
+	return ""
+}
+
+// isRateLimited reports whether GitHub refused a request for the primary rate limit
+func isRateLimited(resp *http.Response) bool {
+	return (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+		resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+// rateLimitedServer serves requests while each token has quota left and
+// refuses them once it runs out, recording the token of every request
+type rateLimitedServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	remaining map[string]int
+	reset     map[string]time.Time
+	seen      []string
+}
+
+func newRateLimitedServer(t *testing.T, remaining map[string]int) *rateLimitedServer {
+	t.Helper()
+
+	s := &rateLimitedServer{remaining: remaining, reset: make(map[string]time.Time)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		// A retried request must carry its body
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, `{"query":"{}"}`, string(body))
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.seen = append(s.seen, token)
+
+		reset := s.reset[token]
+		if reset.IsZero() {
+			reset = time.Now().Add(time.Hour)
+		}
+		resource := "core"
+		if r.URL.Path == "/graphql" {
+			resource = "graphql"
+		}
+		w.Header().Set("X-RateLimit-Resource", resource)
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		if s.remaining[token] == 0 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		s.remaining[token]--
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(s.remaining[token]))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *rateLimitedServer) tokens() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := s.seen
+	s.seen = nil
+	return seen
+}
+
+func get(t *testing.T, client *http.Client, url string) *http.Response {
+	t.Helper()
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestTokenPool_RotatesPerRequest(t *testing.T) {
+	t.Parallel()
+
+	server := newRateLimitedServer(t, map[string]int{"a": 100, "b": 100, "c": 100})
+	pool := NewTokenPool([]string{"a", "b", "c"}, config.TokenRotationRequest, server.Client().Transport)
+	client := &http.Client{Transport: pool}
+
+	for range 4 {
+		get(t, client, server.URL+"/repos/org/repo/pulls")
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, server.tokens())
+
+	limits := pool.RateLimits()
+	require.Len(t, limits, 3)
+	assert.Equal(t, 1, limits[0].Token)
+	assert.Equal(t, 98, limits[0].Remaining)
+	assert.Equal(t, 99, limits[2].Remaining)
+}
+
+func TestTokenPool_RotatesPerRepository(t *testing.T) {
+	t.Parallel()
+
+	server := newRateLimitedServer(t, map[string]int{"a": 100, "b": 100})
+	pool := NewTokenPool([]string{"a", "b"}, config.TokenRotationRepository, server.Client().Transport)
+	client := &http.Client{Transport: pool}
+
+	for range 3 {
+		get(t, client, server.URL+"/repos/org/repo/pulls")
+		get(t, client, server.URL+"/repos/org/repo/issues")
+	}
+	seen := server.tokens()
+	for _, token := range seen {
+		assert.Equal(t, seen[0], token, "one repository stays on one token")
+	}
+}
+
+func TestTokenPool_MovesOnWhenExhausted(t *testing.T) {
+	t.Parallel()
+
+	server := newRateLimitedServer(t, map[string]int{"a": 1, "b": 1})
+	pool := NewTokenPool([]string{"a", "b"}, config.TokenRotationRequest, server.Client().Transport)
+	client := &http.Client{Transport: pool}
+
+	// a's last request reports it empty, but b still has quota
+	resp := get(t, client, server.URL+"/repos/org/repo/pulls")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("X-RateLimit-Remaining"), "the client must not hold back")
+	assert.Equal(t, []string{"a"}, server.tokens())
+}
+
+func TestTokenPool_RetriesWithAnotherToken(t *testing.T) {
+	t.Parallel()
+
+	server := newRateLimitedServer(t, map[string]int{"a": 0, "b": 5})
+	pool := NewTokenPool([]string{"a", "b"}, config.TokenRotationRequest, server.Client().Transport)
+	client := &http.Client{Transport: pool}
+
+	resp, err := client.Post(server.URL+"/graphql", "application/json", strings.NewReader(`{"query":"{}"}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"a", "b"}, server.tokens())
+
+	// a is skipped until it resets
+	get(t, client, server.URL+"/graphql")
+	assert.Equal(t, []string{"b"}, server.tokens())
+}
+
+func TestTokenPool_AllExhausted(t *testing.T) {
+	t.Parallel()
+
+	server := newRateLimitedServer(t, map[string]int{"a": 0, "b": 0})
+	server.reset["a"] = time.Now().Add(time.Hour)
+	server.reset["b"] = time.Now().Add(time.Minute)
+	pool := NewTokenPool([]string{"a", "b"}, config.TokenRotationRequest, server.Client().Transport)
+	client := &http.Client{Transport: pool}
+
+	resp := get(t, client, server.URL+"/repos/org/repo/pulls")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, []string{"a", "b"}, server.tokens())
+
+	// Later requests go to the token resetting first, for the client to wait on
+	resp = get(t, client, server.URL+"/repos/org/repo/pulls")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, []string{"b"}, server.tokens())
+}
@@ -0,0 +1,196 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Usage counts API calls, bytes transferred and the last reported rate limits
+type Usage struct {
+	mu            sync.Mutex
+	restCalls     int
+	graphQLCalls  int
+	bytesSent     int64
+	bytesReceived int64
+	rateLimits    map[string]models.RateLimitStatus
+}
+
+// NewUsage creates an empty API usage tracker
+func NewUsage() *Usage {
+	return &Usage{rateLimits: make(map[string]models.RateLimitStatus)}
+}
+
+// Transport wraps base so every request and response is accounted for
+func (u *Usage) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &usageTransport{base: base, usage: u}
+}
+
+// Snapshot returns the usage so far
+func (u *Usage) Snapshot() models.APIUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage := models.APIUsage{
+		RESTCalls:     u.restCalls,
+		GraphQLCalls:  u.graphQLCalls,
+		BytesSent:     u.bytesSent,
+		BytesReceived: u.bytesReceived,
+	}
+	for _, rl := range u.rateLimits {
+		usage.RateLimits = append(usage.RateLimits, rl)
+	}
+	sort.Slice(usage.RateLimits, func(i, j int) bool {
+		return usage.RateLimits[i].Resource < usage.RateLimits[j].Resource
+	})
+	return usage
+}
+
+func (u *Usage) recordRequest(req *http.Request) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if isGraphQLRequest(req) {
+		u.graphQLCalls++
+	} else {
+		u.restCalls++
+	}
+	if req.ContentLength > 0 {
+		u.bytesSent += req.ContentLength
+	}
+}
+
+func (u *Usage) recordResponse(resp *http.Response) {
+	rl, ok := parseRateLimit(resp.Header)
+	if !ok {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.rateLimits[rl.Resource] = rl
+}
+
+func (u *Usage) addReceived(n int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.bytesReceived += int64(n)
+}
+
+// isGraphQLRequest reports whether req targets the GraphQL endpoint
+func isGraphQLRequest(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/graphql")
+}
+
+// parseRateLimit reads GitHub's X-RateLimit-* response headers
+func parseRateLimit(h http.Header) (models.RateLimitStatus, bool) {
+	var rl models.RateLimitStatus
+
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return rl, false
+	}
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return rl, false
+	}
+
+	rl.Limit = limit
+	rl.Remaining = remaining
+	rl.Used, _ = strconv.Atoi(h.Get("X-RateLimit-Used"))
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.ResetAt = time.Unix(reset, 0).UTC()
+	}
+	rl.Resource = h.Get("X-RateLimit-Resource")
+	if rl.Resource == "" {
+		rl.Resource = "core"
+	}
+	return rl, true
+}
+
+// usageTransport records each round trip in a Usage
+type usageTransport struct {
+	base  http.RoundTripper
+	usage *Usage
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *usageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.usage.recordRequest(req)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.usage.recordResponse(resp)
+	// Count bytes as they are read, since compressed and chunked responses have no length
+	resp.Body = &countingBody{ReadCloser: resp.Body, usage: t.usage}
+	return resp, nil
+}
+
+// countingBody adds the bytes read from a response body to a Usage
+type countingBody struct {
+	io.ReadCloser
+	usage *Usage
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.usage.addReceived(n)
+	}
+	return n, err
+}
+
+// UsageLines formats API usage for the end-of-run log
+func UsageLines(u *models.APIUsage) []string {
+	lines := []string{
+		fmt.Sprintf("API calls: %d (%d REST, %d GraphQL), %s sent, %s received",
+			u.Calls(), u.RESTCalls, u.GraphQLCalls, FormatBytes(u.BytesSent), FormatBytes(u.BytesReceived)),
+	}
+
+	if lookups := u.CacheHits + u.CacheMisses; lookups > 0 {
+		lines = append(lines, fmt.Sprintf("Cache: %d hits, %d misses (%.1f%% hit rate)",
+			u.CacheHits, u.CacheMisses, float64(u.CacheHits)/float64(lookups)*100))
+	}
+
+	if u.ThrottledSeconds > 0 {
+		lines = append(lines, fmt.Sprintf("Throttled: requests held back %s by max_requests_per_hour",
+			time.Duration(u.ThrottledSeconds*float64(time.Second)).Round(time.Second)))
+	}
+
+	for _, rl := range u.RateLimits {
+		resource := rl.Resource
+		if rl.Token > 0 {
+			resource = fmt.Sprintf("%s, token %d", resource, rl.Token)
+		}
+		lines = append(lines, fmt.Sprintf("Rate limit (%s): %d/%d remaining, resets %s",
+			resource, rl.Remaining, rl.Limit, rl.ResetAt.Local().Format("15:04:05")))
+	}
+
+	return lines
+}
+
+// FormatBytes renders a byte count with a binary unit (1.5 MiB)
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,111 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestUsage_Transport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := "core"
+		if r.URL.Path == "/graphql" {
+			resource = "graphql"
+		}
+		w.Header().Set("X-RateLimit-Resource", resource)
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.Header().Set("X-RateLimit-Used", "679")
+		w.Header().Set("X-RateLimit-Reset", "1717243200")
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	usage := NewUsage()
+	client := &http.Client{Transport: usage.Transport(nil)}
+
+	for _, path := range []string{"/repos/org/repo/pulls", "/repos/org/repo/issues"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, resp.Body.Close())
+	}
+	resp, err := client.Post(server.URL+"/graphql", "application/json", strings.NewReader(`{"query":"{}"}`))
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, resp.Body.Close())
+
+	snapshot := usage.Snapshot()
+	assert.Equal(t, 2, snapshot.RESTCalls)
+	assert.Equal(t, 1, snapshot.GraphQLCalls)
+	assert.Equal(t, int64(14), snapshot.BytesSent)
+	assert.Equal(t, int64(30), snapshot.BytesReceived)
+
+	require.Len(t, snapshot.RateLimits, 2)
+	assert.Equal(t, "core", snapshot.RateLimits[0].Resource)
+	assert.Equal(t, "graphql", snapshot.RateLimits[1].Resource)
+	assert.Equal(t, 4321, snapshot.RateLimits[0].Remaining)
+	assert.Equal(t, 679, snapshot.RateLimits[0].Used)
+	assert.Equal(t, time.Unix(1717243200, 0).UTC(), snapshot.RateLimits[0].ResetAt)
+}
+
+func TestParseRateLimit(t *testing.T) {
+	t.Parallel()
+
+	_, ok := parseRateLimit(http.Header{})
+	assert.False(t, ok)
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", "60")
+	h.Set("X-RateLimit-Remaining", "59")
+	rl, ok := parseRateLimit(h)
+	require.True(t, ok)
+	assert.Equal(t, "core", rl.Resource)
+	assert.Equal(t, 60, rl.Limit)
+	assert.Equal(t, 59, rl.Remaining)
+}
+
+func TestUsageLines(t *testing.T) {
+	t.Parallel()
+
+	lines := UsageLines(&models.APIUsage{
+		RESTCalls:     120,
+		GraphQLCalls:  8,
+		BytesSent:     512,
+		BytesReceived: 3 * 1024 * 1024,
+		CacheHits:     30,
+		CacheMisses:   10,
+		RateLimits:    []models.RateLimitStatus{{Resource: "core", Limit: 5000, Remaining: 4872}},
+	})
+	require.Len(t, lines, 3)
+	assert.Equal(t, "API calls: 128 (120 REST, 8 GraphQL), 512 B sent, 3.0 MiB received", lines[0])
+	assert.Equal(t, "Cache: 30 hits, 10 misses (75.0% hit rate)", lines[1])
+	assert.Contains(t, lines[2], "Rate limit (core): 4872/5000 remaining")
+}
+
+func TestUsageLines_Throttled(t *testing.T) {
+	t.Parallel()
+
+	lines := UsageLines(&models.APIUsage{RESTCalls: 10, ThrottledSeconds: 754.6})
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Throttled: requests held back 12m35s by max_requests_per_hour", lines[1])
+}
+
+func TestFormatBytes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "0 B", FormatBytes(0))
+	assert.Equal(t, "1023 B", FormatBytes(1023))
+	assert.Equal(t, "1.5 KiB", FormatBytes(1536))
+	assert.Equal(t, "2.0 GiB", FormatBytes(2*1024*1024*1024))
+}
@@ -0,0 +1,614 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+)
+
+// Execute runs the query of a request against root, a value of the schema's
+// query type
+func (s *Schema) Execute(ctx context.Context, root any, req Request) *Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		var syntaxErr *SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return requestError(syntaxErr.Message, syntaxErr.Location)
+		}
+		return requestError(err.Error())
+	}
+
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return requestError(err.Error())
+	}
+	if op.kind != "query" {
+		return requestError(fmt.Sprintf("only queries are supported, not %ss", op.kind), op.loc)
+	}
+
+	e := &executor{ctx: ctx, schema: s, doc: doc}
+	if e.variables, err = variableValues(op, req.Variables); err != nil {
+		return requestError(err.Error(), op.loc)
+	}
+
+	query := s.objectType(s.query)
+	e.validate(query, op.selections, nil)
+	if len(e.errors) > 0 {
+		return &Response{Errors: e.errors}
+	}
+
+	v := reflect.ValueOf(root)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || v.Type() != s.query {
+		return requestError(fmt.Sprintf("no %s to query", query.name))
+	}
+	data := e.selectionSet(query, v, op.selections, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+// requestError is the response to a request that cannot be executed
+func requestError(message string, locations ...Location) *Response {
+	return &Response{Errors: []Error{{Message: message, Locations: locations}}}
+}
+
+// selectOperation returns the operation of a document to execute
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, errors.New("an operation name is required when the query contains several operations")
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// variableValues returns the values of an operation's variables, with
+// defaults for those not provided
+func variableValues(op *operation, provided map[string]any) (map[string]any, error) {
+	values := make(map[string]any, len(op.variables))
+	for _, def := range op.variables {
+		v, ok := provided[def.name]
+		switch {
+		case ok:
+			values[def.name] = v
+		case def.defaultValue != nil:
+			values[def.name] = def.defaultValue.resolve(nil)
+		case strings.HasSuffix(def.typ, "!"):
+			return nil, fmt.Errorf("variable $%s of required type %s was not provided", def.name, def.typ)
+		}
+	}
+	return values, nil
+}
+
+// executor runs one operation
+type executor struct {
+	ctx       context.Context
+	schema    *Schema
+	doc       *document
+	variables map[string]any
+	errors    []Error
+}
+
+func (e *executor) fail(message string, loc Location, path []any) {
+	e.errors = append(e.errors, Error{Message: message, Locations: []Location{loc}, Path: path})
+}
+
+// fieldGroup is the fields selected under the same response key, whose
+// selections are merged
+type fieldGroup struct {
+	key    string
+	fields []*field
+}
+
+// collectFields returns the fields a selection set selects on an object
+// type, in order, with fragments expanded and @skip and @include applied
+func (e *executor) collectFields(t *objectType, selections []selection, groups []fieldGroup, visited map[string]bool) []fieldGroup {
+	for _, sel := range selections {
+		if !e.included(sel.directives) {
+			continue
+		}
+		switch {
+		case sel.field != nil:
+			key := sel.field.responseKey()
+			i := 0
+			for i < len(groups) && groups[i].key != key {
+				i++
+			}
+			if i == len(groups) {
+				groups = append(groups, fieldGroup{key: key})
+			}
+			groups[i].fields = append(groups[i].fields, sel.field)
+		case sel.inline != nil:
+			if sel.inline.typeCondition == "" || sel.inline.typeCondition == t.name {
+				groups = e.collectFields(t, sel.inline.selections, groups, visited)
+			}
+		default:
+			f := e.doc.fragments[sel.spread]
+			if f == nil || visited[sel.spread] || f.typeCondition != t.name {
+				continue
+			}
+			visited[sel.spread] = true
+			groups = e.collectFields(t, f.selections, groups, visited)
+		}
+	}
+	return groups
+}
+
+// included applies the @skip and @include directives
+func (e *executor) included(directives []directive) bool {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			continue
+		}
+		condition := false
+		for _, arg := range d.arguments {
+			if arg.name == "if" {
+				condition, _ = arg.value.resolve(e.variables).(bool)
+			}
+		}
+		if condition == (d.name == "skip") {
+			return false
+		}
+	}
+	return true
+}
+
+// validate checks a selection set against an object type before anything is
+// executed: that the fields, fragments, directives and arguments exist, and
+// that objects and only objects have selections
+func (e *executor) validate(t *objectType, selections []selection, spreading []string) {
+	for _, sel := range selections {
+		for _, d := range sel.directives {
+			if d.name != "skip" && d.name != "include" {
+				e.fail(fmt.Sprintf("unknown directive @%s", d.name), d.loc, nil)
+				continue
+			}
+			if len(d.arguments) != 1 || d.arguments[0].name != "if" {
+				e.fail(fmt.Sprintf("directive @%s takes a single Boolean argument \"if\"", d.name), d.loc, nil)
+				continue
+			}
+			if _, err := coerce("Boolean!", d.arguments[0].value.resolve(e.variables)); err != nil {
+				e.fail(fmt.Sprintf("argument \"if\" of @%s %v", d.name, err), d.loc, nil)
+			}
+		}
+
+		switch {
+		case sel.field != nil:
+			e.validateField(t, sel.field, spreading)
+		case sel.inline != nil:
+			if c := sel.inline.typeCondition; c != "" && c != t.name {
+				e.fail(fmt.Sprintf("fragment on %s cannot be spread on %s", c, t.name), sel.loc, nil)
+				continue
+			}
+			e.validate(t, sel.inline.selections, spreading)
+		default:
+			f := e.doc.fragments[sel.spread]
+			switch {
+			case f == nil:
+				e.fail(fmt.Sprintf("unknown fragment %q", sel.spread), sel.loc, nil)
+			case contains(spreading, sel.spread):
+				e.fail(fmt.Sprintf("fragment %q spreads itself", sel.spread), sel.loc, nil)
+			case f.typeCondition != t.name:
+				e.fail(fmt.Sprintf("fragment %q on %s cannot be spread on %s", f.name, f.typeCondition, t.name), sel.loc, nil)
+			default:
+				e.validate(t, f.selections, append(spreading, sel.spread))
+			}
+		}
+	}
+}
+
+func (e *executor) validateField(t *objectType, f *field, spreading []string) {
+	if f.name == "__typename" {
+		if f.selections != nil {
+			e.fail("field \"__typename\" of type String must not have a selection", f.loc, nil)
+		}
+		return
+	}
+	if strings.HasPrefix(f.name, "__") {
+		e.fail(fmt.Sprintf("introspection (%s) is not supported; the schema is available in SDL", f.name), f.loc, nil)
+		return
+	}
+	def := t.fields[f.name]
+	if def == nil {
+		e.fail(fmt.Sprintf("cannot query field %q on type %s", f.name, t.name), f.loc, nil)
+		return
+	}
+	if _, err := e.arguments(def, f); err != nil {
+		e.fail(err.Error(), f.loc, nil)
+	}
+
+	named := namedType(def.typ)
+	switch {
+	case isObject(named) && f.selections == nil:
+		e.fail(fmt.Sprintf("field %q of type %s must have a selection of subfields", f.name, typeString(def.typ)), f.loc, nil)
+	case !isObject(named) && f.selections != nil:
+		e.fail(fmt.Sprintf("field %q of type %s must not have a selection", f.name, typeString(def.typ)), f.loc, nil)
+	case f.selections != nil:
+		e.validate(e.schema.objectType(named), f.selections, spreading)
+	}
+}
+
+// arguments returns the coerced arguments of a field
+func (e *executor) arguments(def *fieldDef, f *field) (map[string]any, error) {
+	args := make(map[string]any, len(f.arguments))
+	for _, arg := range f.arguments {
+		typ, ok := def.args[arg.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument %q on field %q", arg.name, f.name)
+		}
+		v, err := coerce(typ, arg.value.resolve(e.variables))
+		if err != nil {
+			return nil, fmt.Errorf("argument %q of field %q %v", arg.name, f.name, err)
+		}
+		if v != nil {
+			args[arg.name] = v
+		}
+	}
+	for _, name := range def.argOrder {
+		if _, ok := args[name]; !ok && strings.HasSuffix(def.args[name], "!") {
+			return nil, fmt.Errorf("argument %q of field %q is required", name, f.name)
+		}
+	}
+	if by, ok := args[argOrderBy].(string); ok && def.list != nil {
+		sortField := def.list.fields[by]
+		if sortField == nil || sortField.computed != nil || !sortable(sortField.typ) {
+			return nil, fmt.Errorf("cannot order %q by %q, which is not a string, number, boolean or time field of %s", f.name, by, def.list.name)
+		}
+	}
+	return args, nil
+}
+
+// coerce converts a value to an input type: String, Int, Float, Boolean,
+// or lists of them, optionally required with "!". A single value is
+// accepted for a list. Nil stays nil.
+func coerce(typ string, v any) (any, error) {
+	if required, ok := strings.CutSuffix(typ, "!"); ok {
+		if v == nil {
+			return nil, fmt.Errorf("must not be null")
+		}
+		typ = required
+	}
+	if v == nil {
+		return nil, nil
+	}
+	if inner, ok := strings.CutPrefix(typ, "["); ok {
+		inner = strings.TrimSuffix(inner, "]")
+		items, ok := v.([]any)
+		if !ok {
+			items = []any{v}
+		}
+		list := make([]any, len(items))
+		for i, item := range items {
+			c, err := coerce(inner, item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = c
+		}
+		return list, nil
+	}
+
+	switch typ {
+	case "String":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "Boolean":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case "Int":
+		switch n := v.(type) {
+		case int:
+			return n, nil
+		case float64:
+			if n == math.Trunc(n) && math.Abs(n) <= math.MaxInt32 {
+				return int(n), nil
+			}
+		}
+	case "Float":
+		switch n := v.(type) {
+		case int:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("must be of type %s", typ)
+}
+
+// selectionSet resolves the fields of an object
+func (e *executor) selectionSet(t *objectType, v reflect.Value, selections []selection, path []any) *object {
+	groups := e.collectFields(t, selections, nil, make(map[string]bool))
+	result := &object{}
+	for _, group := range groups {
+		fieldPath := append(append([]any(nil), path...), group.key)
+		result.add(group.key, e.resolveField(t, v, group, fieldPath))
+	}
+	return result
+}
+
+// resolveField resolves a field, returning nil and recording the error when
+// it fails
+func (e *executor) resolveField(t *objectType, parent reflect.Value, group fieldGroup, path []any) any {
+	f := group.fields[0]
+	if f.name == "__typename" {
+		return t.name
+	}
+	def := t.fields[f.name]
+	args, _ := e.arguments(def, f) // Validated already
+	var selections []selection
+	for _, f := range group.fields {
+		selections = append(selections, f.selections...)
+	}
+
+	var v reflect.Value
+	if def.computed != nil {
+		result, err := def.computed.Resolve(pointerTo(parent).Interface(), args)
+		if err != nil {
+			e.fail(err.Error(), f.loc, path)
+			return nil
+		}
+		v = reflect.ValueOf(result)
+	} else {
+		var err error
+		if v, err = parent.FieldByIndexErr(def.index); err != nil {
+			return nil // Through a nil embedded pointer
+		}
+	}
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		items := make([]reflect.Value, v.Len())
+		for i := range items {
+			items[i] = v.Index(i)
+		}
+		if def.list != nil {
+			items = filterItems(def.list, items, args)
+			items = sortItems(def.list, items, args)
+		}
+		items = pageItems(items, args)
+		return e.completeList(items, selections, f, path)
+	}
+	return e.complete(v, selections, f, path)
+}
+
+// complete returns the response value of a resolved value
+func (e *executor) complete(v reflect.Value, selections []selection, f *field, path []any) any {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch {
+	case isObject(v.Type()):
+		return e.selectionSet(e.schema.objectType(v.Type()), v, selections, path)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		items := make([]reflect.Value, v.Len())
+		for i := range items {
+			items[i] = v.Index(i)
+		}
+		return e.completeList(items, selections, f, path)
+	default:
+		return v.Interface()
+	}
+}
+
+func (e *executor) completeList(items []reflect.Value, selections []selection, f *field, path []any) any {
+	list := make([]any, len(items))
+	for i, item := range items {
+		if err := e.ctx.Err(); err != nil {
+			e.fail(err.Error(), f.loc, path)
+			return nil
+		}
+		list[i] = e.complete(item, selections, f, append(append([]any(nil), path...), i))
+	}
+	return list
+}
+
+// filterItems keeps the listed structs whose fields match the filter arguments
+func filterItems(t *objectType, items []reflect.Value, args map[string]any) []reflect.Value {
+	kept := items[:0:0]
+	for _, item := range items {
+		item = reflect.Indirect(item)
+		if !item.IsValid() {
+			continue
+		}
+		matches := true
+		for name, want := range args {
+			def := t.fields[name]
+			if def == nil || def.computed != nil || name == argFirst || name == argSkip || name == argOrderBy || name == argDesc {
+				continue
+			}
+			got, err := item.FieldByIndexErr(def.index)
+			if err != nil {
+				matches = false
+				break
+			}
+			got = reflect.Indirect(got)
+			switch want := want.(type) {
+			case []any:
+				matches = got.IsValid() && got.Kind() == reflect.String && containsFold(want, got.String())
+			case bool:
+				matches = got.IsValid() && got.Kind() == reflect.Bool && got.Bool() == want
+			}
+			if !matches {
+				break
+			}
+		}
+		if matches {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// sortItems sorts the listed structs by the order_by field, stably, so
+// equal items keep their order
+func sortItems(t *objectType, items []reflect.Value, args map[string]any) []reflect.Value {
+	by, ok := args[argOrderBy].(string)
+	if !ok {
+		return items
+	}
+	desc, _ := args[argDesc].(bool)
+	index := t.fields[by].index
+	key := func(item reflect.Value) reflect.Value {
+		v, err := item.FieldByIndexErr(index)
+		if err != nil {
+			return reflect.Value{}
+		}
+		return reflect.Indirect(v)
+	}
+	sorted := append([]reflect.Value(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := key(sorted[i]), key(sorted[j])
+		if desc {
+			a, b = b, a
+		}
+		return less(a, b)
+	})
+	return sorted
+}
+
+// pageItems applies the skip and first arguments
+func pageItems(items []reflect.Value, args map[string]any) []reflect.Value {
+	if skip, ok := args[argSkip].(int); ok && skip > 0 {
+		items = items[min(skip, len(items)):]
+	}
+	if first, ok := args[argFirst].(int); ok && first >= 0 && first < len(items) {
+		items = items[:first]
+	}
+	return items
+}
+
+// sortable reports whether values of t can be ordered
+func sortable(t reflect.Type) bool {
+	t = indirect(t)
+	if t == timeType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// less orders two values of a sortable type; missing values come first
+func less(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return !a.IsValid() && b.IsValid()
+	}
+	if a.Type() == timeType {
+		return a.Interface().(time.Time).Before(b.Interface().(time.Time))
+	}
+	switch a.Kind() {
+	case reflect.String:
+		return strings.ToLower(a.String()) < strings.ToLower(b.String())
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	default:
+		return a.Float() < b.Float()
+	}
+}
+
+// pointerTo returns a pointer to a struct value, copying it when it is not
+// addressable
+func pointerTo(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []any, s string) bool {
+	for _, v := range values {
+		if v, ok := v.(string); ok && strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// object is a response object, keeping its fields in the order selected
+type object struct {
+	keys   []string
+	values []any
+}
+
+func (o *object) add(key string, v any) {
+	o.keys = append(o.keys, key)
+	o.values = append(o.values, v)
+}
+
+// MarshalJSON implements json.Marshaler
+func (o *object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		v, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
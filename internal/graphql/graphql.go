@@ -0,0 +1,262 @@
+// Package graphql answers GraphQL queries over Go values. The object types
+// are the structs reachable from the root value, their fields the ones
+// encoding/json writes, named by their JSON tags, so a query selects from the
+// same documents the JSON files and API serve. Lists of structs take
+// arguments to filter, sort and page them. Only queries are supported;
+// instead of introspection, the schema is available in SDL.
+package graphql
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is the result of a request. Data is nil when the request could
+// not be executed at all, e.g. because it does not parse.
+type Response struct {
+	Data   any     `json:"data,omitempty"`
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Error is an error of a request or of one of its fields
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"` // Response keys and list indexes of the failed field
+}
+
+// Field is a field computed from its parent rather than read from a struct
+// field, such as a lookup by an argument
+type Field struct {
+	Args    map[string]string // Argument types by name, e.g. "String!"
+	Type    reflect.Type      // Type of the values Resolve returns
+	Resolve func(parent any, args map[string]any) (any, error)
+}
+
+// Arguments of every list field, besides a filter per string and boolean
+// field of the listed structs
+const (
+	argFirst   = "first"    // Return at most this many items
+	argSkip    = "skip"     // Skip this many items
+	argOrderBy = "order_by" // Sort by this field of the listed structs
+	argDesc    = "desc"     // Sort in descending order
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Schema is the schema of a root type
+type Schema struct {
+	query  reflect.Type
+	fields map[reflect.Type]map[string]Field
+
+	mu    sync.Mutex
+	types map[reflect.Type]*objectType
+}
+
+// NewSchema creates a schema queried from values of the type of query, a
+// struct or a pointer to one
+func NewSchema(query any) *Schema {
+	return &Schema{
+		query:  indirect(reflect.TypeOf(query)),
+		fields: make(map[reflect.Type]map[string]Field),
+		types:  make(map[reflect.Type]*objectType),
+	}
+}
+
+// AddField adds a computed field to the type of parent, a struct or a
+// pointer to one. Resolve is passed a pointer to the parent struct.
+func (s *Schema) AddField(parent any, name string, f Field) {
+	t := indirect(reflect.TypeOf(parent))
+	if s.fields[t] == nil {
+		s.fields[t] = make(map[string]Field)
+	}
+	s.fields[t][name] = f
+}
+
+// objectType is a struct type and its fields
+type objectType struct {
+	name   string
+	fields map[string]*fieldDef
+	order  []string // Field names in declaration order
+}
+
+// fieldDef is a field of an object type
+type fieldDef struct {
+	name     string
+	typ      reflect.Type
+	index    []int  // Of the struct field, nil for computed fields
+	computed *Field // Set for computed fields
+	args     map[string]string
+	argOrder []string
+	list     *objectType // Type of the listed structs of list fields
+}
+
+// objectType returns the object type of a struct type
+func (s *Schema) objectType(t reflect.Type) *objectType {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.objectTypeLocked(t)
+}
+
+func (s *Schema) objectTypeLocked(t reflect.Type) *objectType {
+	t = indirect(t)
+	if ot, ok := s.types[t]; ok {
+		return ot
+	}
+	ot := &objectType{name: t.Name(), fields: make(map[string]*fieldDef)}
+	s.types[t] = ot // Registered first so self-referencing types terminate
+	s.addFields(ot, t, nil)
+
+	for _, name := range slices.Sorted(maps.Keys(s.fields[t])) {
+		f := s.fields[t][name]
+		def := &fieldDef{name: name, typ: f.Type, computed: &f, args: f.Args}
+		def.argOrder = slices.Sorted(maps.Keys(f.Args))
+		if _, exists := ot.fields[name]; !exists {
+			ot.order = append(ot.order, name)
+		}
+		ot.fields[name] = def
+	}
+	return ot
+}
+
+// addFields adds the json-visible fields of t, flattening untagged embedded
+// structs the same way encoding/json does
+func (s *Schema) addFields(ot *objectType, t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if f.Anonymous && name == "" {
+			if ft := indirect(f.Type); ft.Kind() == reflect.Struct {
+				s.addFields(ot, ft, fieldIndex)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, exists := ot.fields[name]; exists {
+			continue
+		}
+
+		def := &fieldDef{name: name, typ: f.Type, index: fieldIndex}
+		s.addListArgs(def)
+		ot.fields[name] = def
+		ot.order = append(ot.order, name)
+	}
+}
+
+// addListArgs gives list fields their paging arguments and, for lists of
+// structs, sorting and a filter per string and boolean field
+func (s *Schema) addListArgs(def *fieldDef) {
+	t := indirect(def.typ)
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return
+	}
+	def.args = map[string]string{argFirst: "Int", argSkip: "Int"}
+	def.argOrder = []string{argFirst, argSkip}
+
+	elem := indirect(t.Elem())
+	if !isObject(elem) {
+		return
+	}
+	def.list = s.objectTypeLocked(elem)
+	def.args[argOrderBy], def.args[argDesc] = "String", "Boolean"
+	def.argOrder = append(def.argOrder, argOrderBy, argDesc)
+	for _, name := range def.list.order {
+		f := def.list.fields[name]
+		if f.computed != nil || def.args[name] != "" {
+			continue
+		}
+		switch indirect(f.typ).Kind() {
+		case reflect.String:
+			def.args[name] = "[String]"
+		case reflect.Bool:
+			def.args[name] = "Boolean"
+		default:
+			continue
+		}
+		def.argOrder = append(def.argOrder, name)
+	}
+}
+
+// indirect returns the type pointers point to
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isObject reports whether values of t are GraphQL objects
+func isObject(t reflect.Type) bool {
+	t = indirect(t)
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// namedType returns the type of the values of t once lists are unwrapped
+func namedType(t reflect.Type) reflect.Type {
+	t = indirect(t)
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = indirect(t.Elem())
+	}
+	return t
+}
+
+// typeName returns the GraphQL name of a type once lists are unwrapped
+func typeName(t reflect.Type) string {
+	t = namedType(t)
+	switch t {
+	case timeType:
+		return "Time"
+	case durationType:
+		return "Int"
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t.Name()
+	case reflect.String:
+		return "String"
+	case reflect.Bool:
+		return "Boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "Int"
+	case reflect.Float32, reflect.Float64:
+		return "Float"
+	default:
+		// Maps and anything else are returned whole
+		return "JSON"
+	}
+}
+
+// typeString returns the GraphQL type of t, e.g. "[ContributorMetrics]"
+func typeString(t reflect.Type) string {
+	t = indirect(t)
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		return "[" + typeString(t.Elem()) + "]"
+	}
+	return typeName(t)
+}
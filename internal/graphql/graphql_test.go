@@ -0,0 +1,280 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRoot struct {
+	Name    string       `json:"name"`
+	Created time.Time    `json:"created"`
+	People  []testPerson `json:"people"`
+	Tags    []string     `json:"tags,omitempty"`
+	Boss    *testPerson  `json:"boss,omitempty"`
+	Extra   map[string]int
+	hidden  string
+}
+
+type testPerson struct {
+	testBase
+	Login  string  `json:"login"`
+	Score  float64 `json:"score"`
+	Active bool    `json:"active"`
+	Skip   string  `json:"-"`
+}
+
+type testBase struct {
+	ID int `json:"id"`
+}
+
+func testSchema() *Schema {
+	s := NewSchema(testRoot{})
+	s.AddField(testRoot{}, "person", Field{
+		Args: map[string]string{"login": "String!"},
+		Type: reflect.TypeOf(&testPerson{}),
+		Resolve: func(parent any, args map[string]any) (any, error) {
+			root := parent.(*testRoot)
+			for i := range root.People {
+				if root.People[i].Login == args["login"] {
+					return &root.People[i], nil
+				}
+			}
+			return nil, nil
+		},
+	})
+	s.AddField(testPerson{}, "rank", Field{
+		Type: reflect.TypeOf(0),
+		Resolve: func(parent any, args map[string]any) (any, error) {
+			if parent.(*testPerson).Score == 0 {
+				return nil, errors.New("unranked")
+			}
+			return int(parent.(*testPerson).Score / 10), nil
+		},
+	})
+	return s
+}
+
+func testData() *testRoot {
+	return &testRoot{
+		Name:    "velocity",
+		Created: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		People: []testPerson{
+			{testBase: testBase{ID: 1}, Login: "alice", Score: 30, Active: true},
+			{testBase: testBase{ID: 2}, Login: "bob", Score: 50},
+			{testBase: testBase{ID: 3}, Login: "carol", Score: 30, Active: true},
+		},
+		Extra: map[string]int{"a": 1},
+	}
+}
+
+// execute runs a query and returns the response as JSON
+func execute(t *testing.T, query string, variables map[string]any) string {
+	t.Helper()
+	resp := testSchema().Execute(context.Background(), testData(), Request{Query: query, Variables: variables})
+	data, err := json.Marshal(resp)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		want      string
+	}{
+		{
+			name:  "fields in the selected order",
+			query: `{ created name Extra __typename }`,
+			want:  `{"data":{"created":"2024-01-02T00:00:00Z","name":"velocity","Extra":{"a":1},"__typename":"testRoot"}}`,
+		},
+		{
+			name:  "aliases and embedded fields",
+			query: `{ people { who: login id } }`,
+			want:  `{"data":{"people":[{"who":"alice","id":1},{"who":"bob","id":2},{"who":"carol","id":3}]}}`,
+		},
+		{
+			name:  "filters",
+			query: `{ people(login: ["ALICE", "bob"], active: true) { login } }`,
+			want:  `{"data":{"people":[{"login":"alice"}]}}`,
+		},
+		{
+			name:  "a single value for a list filter",
+			query: `{ people(login: "carol") { login } }`,
+			want:  `{"data":{"people":[{"login":"carol"}]}}`,
+		},
+		{
+			name:  "stable ordering and paging",
+			query: `{ people(order_by: "score", desc: true, skip: 1, first: 1) { login } }`,
+			want:  `{"data":{"people":[{"login":"alice"}]}}`,
+		},
+		{
+			name:      "variables and defaults",
+			query:     `query People($first: Int = 1, $login: String!) { people(first: $first) { login } person(login: $login) { id } }`,
+			variables: map[string]any{"login": "bob"},
+			want:      `{"data":{"people":[{"login":"alice"}],"person":{"id":2}}}`,
+		},
+		{
+			name:  "missing lookups and nil values are null",
+			query: `{ person(login: "dave") { id } boss { id } tags }`,
+			want:  `{"data":{"person":null,"boss":null,"tags":null}}`,
+		},
+		{
+			name:  "fragments and directives",
+			query: `query { people(first: 1) { ...names ... on testPerson { score } id @skip(if: true) } } fragment names on testPerson { login }`,
+			want:  `{"data":{"people":[{"login":"alice","score":30}]}}`,
+		},
+		{
+			name:  "merged selections",
+			query: `{ boss: person(login: "bob") { login } boss: person(login: "bob") { score } }`,
+			want:  `{"data":{"boss":{"login":"bob","score":50}}}`,
+		},
+		{
+			name:  "computed fields",
+			query: `{ person(login: "bob") { rank } }`,
+			want:  `{"data":{"person":{"rank":5}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.JSONEq(t, tt.want, execute(t, tt.query, tt.variables))
+		})
+	}
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	t.Parallel()
+
+	root := testData()
+	root.People[1].Score = 0
+	resp := testSchema().Execute(context.Background(), root, Request{Query: `{ people { login rank } }`})
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "unranked", resp.Errors[0].Message)
+	assert.Equal(t, []any{"people", 1, "rank"}, resp.Errors[0].Path)
+	assert.Equal(t, []Location{{Line: 1, Column: 18}}, resp.Errors[0].Locations)
+
+	data, err := json.Marshal(resp.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"people":[{"login":"alice","rank":3},{"login":"bob","rank":null},{"login":"carol","rank":3}]}`, string(data))
+}
+
+func TestExecute_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		op    string
+		want  string
+	}{
+		{"syntax", `{ people { login }`, "", "unexpected end of query"},
+		{"mutations", `mutation { name }`, "", "only queries are supported"},
+		{"several operations", `query A { name } query B { name }`, "", "an operation name is required"},
+		{"unknown operation", `query A { name }`, "B", `unknown operation "B"`},
+		{"unknown field", `{ people { hidden } }`, "", `cannot query field "hidden" on type testPerson`},
+		{"ignored field", `{ people { Skip } }`, "", `cannot query field "Skip"`},
+		{"introspection", `{ __schema { types { name } } }`, "", "introspection (__schema) is not supported"},
+		{"unknown argument", `{ name(first: 1) }`, "", `unknown argument "first" on field "name"`},
+		{"argument type", `{ people(first: "one") { id } }`, "", `argument "first" of field "people" must be of type Int`},
+		{"required argument", `{ person { id } }`, "", `argument "login" of field "person" is required`},
+		{"required variable", `query($login: String!) { person(login: $login) { id } }`, "", "variable $login of required type String! was not provided"},
+		{"order by", `{ people(order_by: "rank") { id } }`, "", `cannot order "people" by "rank"`},
+		{"missing selection", `{ people }`, "", `field "people" of type [testPerson] must have a selection of subfields`},
+		{"leaf selection", `{ name { id } }`, "", `field "name" of type String must not have a selection`},
+		{"unknown fragment", `{ people { ...missing } }`, "", `unknown fragment "missing"`},
+		{"fragment cycle", `{ people { ...a } } fragment a on testPerson { ...a }`, "", `fragment "a" spreads itself`},
+		{"fragment type", `{ ...a } fragment a on testPerson { id }`, "", `fragment "a" on testPerson cannot be spread on testRoot`},
+		{"directive", `{ name @deprecated }`, "", "unknown directive @deprecated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := testSchema().Execute(context.Background(), testData(), Request{Query: tt.query, OperationName: tt.op})
+			assert.Nil(t, resp.Data)
+			require.NotEmpty(t, resp.Errors)
+			assert.Contains(t, resp.Errors[0].Message, tt.want)
+		})
+	}
+}
+
+func TestExecute_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp := testSchema().Execute(ctx, testData(), Request{Query: `{ name people { id } }`})
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, context.Canceled.Error(), resp.Errors[0].Message)
+}
+
+func TestParse_Values(t *testing.T) {
+	t.Parallel()
+
+	doc, err := parse(`query Q($v: [Int!] = [1, 2]) {
+		# A comment
+		f(a: -1.5e2, b: "esc\"apedé", c: """
+			block
+			  string
+		""", d: ENUM, e: {k: [true, null]}, f: $v)
+	}`)
+	require.NoError(t, err)
+	require.Len(t, doc.operations, 1)
+	op := doc.operations[0]
+	assert.Equal(t, "Q", op.name)
+	assert.Equal(t, "[Int!]", op.variables[0].typ)
+	assert.Equal(t, []any{1, 2}, op.variables[0].defaultValue.resolve(nil))
+
+	args := op.selections[0].field.arguments
+	values := make(map[string]any)
+	for _, arg := range args {
+		values[arg.name] = arg.value.resolve(map[string]any{"v": []any{3}})
+	}
+	assert.Equal(t, map[string]any{
+		"a": -150.0,
+		"b": `esc"apedé`,
+		"c": "block\n  string",
+		"d": "ENUM",
+		"e": map[string]any{"k": []any{true, nil}},
+		"f": []any{3},
+	}, values)
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{``, `{`, `{ a(b: ) }`, `{ "unterminated }`, `query { a } extra`, `{ a(b: 1.) }`} {
+		_, err := parse(query)
+		var syntaxErr *SyntaxError
+		assert.ErrorAs(t, err, &syntaxErr, query)
+	}
+
+	_, err := parse("{\n  a(b: ) }")
+	assert.EqualError(t, err, "syntax error at 2:8: unexpected \")\"")
+}
+
+func TestSDL(t *testing.T) {
+	t.Parallel()
+
+	sdl := testSchema().SDL()
+	assert.True(t, strings.HasPrefix(sdl, "schema {\n  query: testRoot\n}\n\ntype testRoot {\n"))
+	assert.Contains(t, sdl, "  people(first: Int, skip: Int, order_by: String, desc: Boolean, login: [String], active: Boolean): [testPerson]\n")
+	assert.Contains(t, sdl, "  tags(first: Int, skip: Int): [String]\n")
+	assert.Contains(t, sdl, "  person(login: String!): testPerson\n")
+	assert.Contains(t, sdl, "type testPerson {\n  id: Int\n  login: String\n  score: Float\n  active: Boolean\n  rank: Int\n}\n")
+	assert.True(t, strings.HasSuffix(sdl, "\nscalar Time\n\nscalar JSON\n"))
+	assert.NotContains(t, sdl, "hidden")
+	assert.NotContains(t, sdl, "Skip")
+}
@@ -0,0 +1,819 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Location is a line and column in a query, both starting at 1
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// document is a parsed query document
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// operation is a query, mutation or subscription
+type operation struct {
+	kind       string
+	name       string
+	variables  []variableDefinition
+	selections []selection
+	loc        Location
+}
+
+// variableDefinition declares a variable of an operation
+type variableDefinition struct {
+	name         string
+	typ          string // As written, e.g. "[String!]!"
+	defaultValue *value
+	loc          Location
+}
+
+// fragment is a named fragment definition
+type fragment struct {
+	name          string
+	typeCondition string
+	selections    []selection
+}
+
+// selection is a field, a fragment spread or an inline fragment
+type selection struct {
+	field      *field
+	spread     string // Name of a spread fragment
+	inline     *fragment
+	directives []directive
+	loc        Location
+}
+
+// field is a selected field
+type field struct {
+	alias      string
+	name       string
+	arguments  []argument
+	selections []selection
+	loc        Location
+}
+
+// responseKey is the key of the field in the response
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type argument struct {
+	name  string
+	value value
+	loc   Location
+}
+
+type directive struct {
+	name      string
+	arguments []argument
+	loc       Location
+}
+
+// Value kinds
+const (
+	variableValue = iota
+	intValue
+	floatValue
+	stringValue
+	booleanValue
+	nullValue
+	enumValue
+	listValue
+	objectValue
+)
+
+// value is a literal or variable in a query
+type value struct {
+	kind   int
+	raw    string // Name, number, string or enum value
+	list   []value
+	fields []argument // Fields of an object value
+	loc    Location
+}
+
+// resolve returns the value with variables substituted, as the Go values
+// JSON decodes to: string, float64 or int for numbers, bool, nil, []any and
+// map[string]any
+func (v value) resolve(variables map[string]any) any {
+	switch v.kind {
+	case variableValue:
+		return variables[v.raw]
+	case intValue:
+		n, err := strconv.Atoi(v.raw)
+		if err != nil {
+			f, _ := strconv.ParseFloat(v.raw, 64)
+			return f
+		}
+		return n
+	case floatValue:
+		f, _ := strconv.ParseFloat(v.raw, 64)
+		return f
+	case stringValue, enumValue:
+		return v.raw
+	case booleanValue:
+		return v.raw == "true"
+	case listValue:
+		list := make([]any, len(v.list))
+		for i, item := range v.list {
+			list[i] = item.resolve(variables)
+		}
+		return list
+	case objectValue:
+		object := make(map[string]any, len(v.fields))
+		for _, f := range v.fields {
+			object[f.name] = f.value.resolve(variables)
+		}
+		return object
+	}
+	return nil
+}
+
+// SyntaxError is a query that cannot be parsed
+type SyntaxError struct {
+	Message  string
+	Location Location
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.Location.Line, e.Location.Column, e.Message)
+}
+
+// Token kinds
+const (
+	tokenEOF = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  int
+	value string
+	loc   Location
+}
+
+// lexer splits a query into tokens, skipping whitespace, commas and comments
+type lexer struct {
+	src       string
+	pos       int
+	line      int
+	lineStart int
+}
+
+func (l *lexer) location() Location {
+	return Location{Line: l.line, Column: utf8.RuneCountInString(l.src[l.lineStart:l.pos]) + 1}
+}
+
+func (l *lexer) errorf(loc Location, format string, args ...any) error {
+	return &SyntaxError{Message: fmt.Sprintf(format, args...), Location: loc}
+}
+
+func (l *lexer) newline() {
+	l.line++
+	l.lineStart = l.pos
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; c {
+		case ' ', '\t', ',', '\r':
+			l.pos++
+		case '\n':
+			l.pos++
+			l.newline()
+		case '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			if strings.HasPrefix(l.src[l.pos:], "\uFEFF") {
+				l.pos += len("\uFEFF")
+				continue
+			}
+			return l.read()
+		}
+	}
+	return token{kind: tokenEOF, loc: l.location()}, nil
+}
+
+func (l *lexer) read() (token, error) {
+	loc := l.location()
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case strings.ContainsRune("!$&()=:@[]{}|", rune(c)):
+		l.pos++
+		return token{kind: tokenPunctuator, value: string(c), loc: loc}, nil
+	case c == '.':
+		if !strings.HasPrefix(l.src[l.pos:], "...") {
+			return token{}, l.errorf(loc, "unexpected %q", ".")
+		}
+		l.pos += 3
+		return token{kind: tokenPunctuator, value: "...", loc: loc}, nil
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], loc: loc}, nil
+	case c == '-' || isDigit(c):
+		return l.readNumber(loc)
+	case c == '"':
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			return l.readBlockString(loc)
+		}
+		return l.readString(loc)
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return token{}, l.errorf(loc, "unexpected character %q", r)
+}
+
+func (l *lexer) readNumber(loc Location) (token, error) {
+	start := l.pos
+	kind := tokenInt
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return token{}, l.errorf(loc, "invalid number")
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.pos++
+		if digits() == 0 {
+			return token{}, l.errorf(loc, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if digits() == 0 {
+			return token{}, l.errorf(loc, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || l.src[l.pos] == '.') {
+		return token{}, l.errorf(loc, "invalid number")
+	}
+	return token{kind: kind, value: l.src[start:l.pos], loc: loc}, nil
+}
+
+func (l *lexer) readString(loc Location) (token, error) {
+	l.pos++ // Opening quote
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return token{kind: tokenString, value: b.String(), loc: loc}, nil
+		case c == '\n':
+			return token{}, l.errorf(loc, "unterminated string")
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, l.errorf(loc, "unterminated string")
+			}
+			escape := l.src[l.pos+1]
+			l.pos += 2
+			switch escape {
+			case '"', '\\', '/':
+				b.WriteByte(escape)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return token{}, l.errorf(loc, "invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(l.src[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, l.errorf(loc, "invalid unicode escape")
+				}
+				b.WriteRune(rune(r))
+				l.pos += 4
+			default:
+				return token{}, l.errorf(loc, "invalid escape \\%c", escape)
+			}
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return token{}, l.errorf(loc, "unterminated string")
+}
+
+// readBlockString reads a """block string""", removing the indentation its
+// lines share and its leading and trailing blank lines
+func (l *lexer) readBlockString(loc Location) (token, error) {
+	l.pos += 3
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		switch {
+		case strings.HasPrefix(l.src[l.pos:], `\"""`):
+			b.WriteString(`"""`)
+			l.pos += 4
+		case strings.HasPrefix(l.src[l.pos:], `"""`):
+			l.pos += 3
+			return token{kind: tokenString, value: blockStringValue(b.String()), loc: loc}, nil
+		default:
+			b.WriteByte(l.src[l.pos])
+			if l.src[l.pos] == '\n' {
+				l.pos++
+				l.newline()
+				continue
+			}
+			l.pos++
+		}
+	}
+	return token{}, l.errorf(loc, "unterminated string")
+}
+
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = strings.TrimLeft(lines[i], " \t")
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parser builds a document from the tokens of a query
+type parser struct {
+	lexer *lexer
+	tok   token
+}
+
+// parse parses a query document
+func parse(query string) (*document, error) {
+	p := &parser{lexer: &lexer{src: query, line: 1}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: selections, loc: selections[0].loc})
+		case p.tok.kind == tokenName && (p.tok.value == "query" || p.tok.value == "mutation" || p.tok.value == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.tok.kind == tokenName && p.tok.value == "fragment":
+			loc := p.tok.loc
+			f, err := p.fragmentDefinition()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[f.name]; ok {
+				return nil, &SyntaxError{Message: fmt.Sprintf("fragment %q is defined more than once", f.name), Location: loc}
+			}
+			doc.fragments[f.name] = f
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, &SyntaxError{Message: "the document contains no operation", Location: p.tok.loc}
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// peek reports whether the current token is the punctuator s
+func (p *parser) peek(s string) bool {
+	return p.tok.kind == tokenPunctuator && p.tok.value == s
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return &SyntaxError{Message: "unexpected end of query", Location: p.tok.loc}
+	}
+	return &SyntaxError{Message: fmt.Sprintf("unexpected %q", p.tok.value), Location: p.tok.loc}
+}
+
+// expect consumes the punctuator s
+func (p *parser) expect(s string) error {
+	if !p.peek(s) {
+		if p.tok.kind == tokenEOF {
+			return &SyntaxError{Message: fmt.Sprintf("expected %q, found the end of the query", s), Location: p.tok.loc}
+		}
+		return &SyntaxError{Message: fmt.Sprintf("expected %q, found %q", s, p.tok.value), Location: p.tok.loc}
+	}
+	return p.advance()
+}
+
+// name consumes a name
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: p.tok.value, loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		op.name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for !p.peek(")") {
+			def, err := p.variableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, def)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+func (p *parser) variableDefinition() (variableDefinition, error) {
+	def := variableDefinition{loc: p.tok.loc}
+	if err := p.expect("$"); err != nil {
+		return def, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return def, err
+	}
+	def.name = name
+	if err := p.expect(":"); err != nil {
+		return def, err
+	}
+	if def.typ, err = p.typeReference(); err != nil {
+		return def, err
+	}
+	if p.peek("=") {
+		if err := p.advance(); err != nil {
+			return def, err
+		}
+		v, err := p.value(true)
+		if err != nil {
+			return def, err
+		}
+		def.defaultValue = &v
+	}
+	_, err = p.directives()
+	return def, err
+}
+
+func (p *parser) typeReference() (string, error) {
+	var typ string
+	if p.peek("[") {
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		inner, err := p.typeReference()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		typ = name
+	}
+	if p.peek("!") {
+		typ += "!"
+		return typ, p.advance()
+	}
+	return typ, nil
+}
+
+func (p *parser) fragmentDefinition() (*fragment, error) {
+	if err := p.advance(); err != nil { // "fragment"
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, &SyntaxError{Message: `a fragment cannot be named "on"`, Location: p.tok.loc}
+	}
+	if p.tok.kind != tokenName || p.tok.value != "on" {
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	typeCondition, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &fragment{name: name, typeCondition: typeCondition, selections: selections}, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []selection
+	for !p.peek("}") {
+		s, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, s)
+	}
+	if len(selections) == 0 {
+		return nil, &SyntaxError{Message: "empty selection set", Location: p.tok.loc}
+	}
+	return selections, p.advance()
+}
+
+func (p *parser) selection() (selection, error) {
+	s := selection{loc: p.tok.loc}
+	if p.peek("...") {
+		if err := p.advance(); err != nil {
+			return s, err
+		}
+		inline := &fragment{}
+		switch {
+		case p.tok.kind == tokenName && p.tok.value == "on":
+			if err := p.advance(); err != nil {
+				return s, err
+			}
+			typeCondition, err := p.name()
+			if err != nil {
+				return s, err
+			}
+			inline.typeCondition = typeCondition
+		case p.tok.kind == tokenName:
+			s.spread = p.tok.value
+			if err := p.advance(); err != nil {
+				return s, err
+			}
+			directives, err := p.directives()
+			s.directives = directives
+			return s, err
+		}
+		directives, err := p.directives()
+		if err != nil {
+			return s, err
+		}
+		s.directives = directives
+		if inline.selections, err = p.selectionSet(); err != nil {
+			return s, err
+		}
+		s.inline = inline
+		return s, nil
+	}
+
+	f := &field{loc: p.tok.loc}
+	name, err := p.name()
+	if err != nil {
+		return s, err
+	}
+	f.name = name
+	if p.peek(":") {
+		if err := p.advance(); err != nil {
+			return s, err
+		}
+		f.alias = name
+		if f.name, err = p.name(); err != nil {
+			return s, err
+		}
+	}
+	if f.arguments, err = p.arguments(false); err != nil {
+		return s, err
+	}
+	if s.directives, err = p.directives(); err != nil {
+		return s, err
+	}
+	if p.peek("{") {
+		if f.selections, err = p.selectionSet(); err != nil {
+			return s, err
+		}
+	}
+	s.field = f
+	return s, nil
+}
+
+func (p *parser) arguments(constant bool) ([]argument, error) {
+	if !p.peek("(") {
+		return nil, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var args []argument
+	for !p.peek(")") {
+		arg := argument{loc: p.tok.loc}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		arg.name = name
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if arg.value, err = p.value(constant); err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if len(args) == 0 {
+		return nil, &SyntaxError{Message: "empty argument list", Location: p.tok.loc}
+	}
+	return args, p.advance()
+}
+
+func (p *parser) directives() ([]directive, error) {
+	var directives []directive
+	for p.peek("@") {
+		d := directive{loc: p.tok.loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		d.name = name
+		if d.arguments, err = p.arguments(false); err != nil {
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// value parses a value; constant values cannot contain variables
+func (p *parser) value(constant bool) (value, error) {
+	v := value{loc: p.tok.loc, raw: p.tok.value}
+	switch p.tok.kind {
+	case tokenInt:
+		v.kind = intValue
+	case tokenFloat:
+		v.kind = floatValue
+	case tokenString:
+		v.kind = stringValue
+	case tokenName:
+		switch p.tok.value {
+		case "true", "false":
+			v.kind = booleanValue
+		case "null":
+			v.kind = nullValue
+		default:
+			v.kind = enumValue
+		}
+	case tokenPunctuator:
+		switch p.tok.value {
+		case "$":
+			if constant {
+				return v, &SyntaxError{Message: "variables are not allowed here", Location: v.loc}
+			}
+			if err := p.advance(); err != nil {
+				return v, err
+			}
+			name, err := p.name()
+			return value{kind: variableValue, raw: name, loc: v.loc}, err
+		case "[":
+			v.kind = listValue
+			if err := p.advance(); err != nil {
+				return v, err
+			}
+			for !p.peek("]") {
+				item, err := p.value(constant)
+				if err != nil {
+					return v, err
+				}
+				v.list = append(v.list, item)
+			}
+			return v, p.advance()
+		case "{":
+			v.kind = objectValue
+			if err := p.advance(); err != nil {
+				return v, err
+			}
+			for !p.peek("}") {
+				f := argument{loc: p.tok.loc}
+				name, err := p.name()
+				if err != nil {
+					return v, err
+				}
+				f.name = name
+				if err := p.expect(":"); err != nil {
+					return v, err
+				}
+				if f.value, err = p.value(constant); err != nil {
+					return v, err
+				}
+				v.fields = append(v.fields, f)
+			}
+			return v, p.advance()
+		default:
+			return v, p.unexpected()
+		}
+	default:
+		return v, p.unexpected()
+	}
+	return v, p.advance()
+}
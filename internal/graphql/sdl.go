@@ -0,0 +1,60 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SDL returns the schema in the GraphQL schema definition language, with the
+// object types in the order they are reached from the query type
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	query := s.objectType(s.query)
+	fmt.Fprintf(&b, "schema {\n  query: %s\n}\n", query.name)
+
+	var scalars []string
+	seen := map[string]bool{query.name: true}
+	queue := []reflect.Type{s.query}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		ot := s.objectType(t)
+
+		fmt.Fprintf(&b, "\ntype %s {\n", ot.name)
+		for _, name := range ot.order {
+			def := ot.fields[name]
+			fmt.Fprintf(&b, "  %s%s: %s\n", name, argumentList(def), typeString(def.typ))
+
+			named := namedType(def.typ)
+			typ := typeName(named)
+			switch {
+			case seen[typ]:
+			case isObject(named):
+				queue = append(queue, named)
+			case typ == "Time" || typ == "JSON":
+				scalars = append(scalars, typ)
+			}
+			seen[typ] = true
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, scalar := range scalars {
+		fmt.Fprintf(&b, "\nscalar %s\n", scalar)
+	}
+	return b.String()
+}
+
+// argumentList returns the arguments of a field as written in SDL, e.g.
+// "(first: Int, skip: Int)"
+func argumentList(def *fieldDef) string {
+	if len(def.argOrder) == 0 {
+		return ""
+	}
+	args := make([]string, len(def.argOrder))
+	for i, name := range def.argOrder {
+		args[i] = name + ": " + def.args[name]
+	}
+	return "(" + strings.Join(args, ", ") + ")"
+}
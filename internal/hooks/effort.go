@@ -0,0 +1,112 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// SizePoints are the effort points of t-shirt sizes
+var SizePoints = map[string]float64{
+	"XS":  1,
+	"S":   2,
+	"M":   3,
+	"L":   5,
+	"XL":  8,
+	"XXL": 13,
+}
+
+// effortPR is a PR as the effort command reads it
+type effortPR struct {
+	Repository   string     `json:"repository"`
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	Author       string     `json:"author"`
+	State        string     `json:"state"`
+	Labels       []string   `json:"labels,omitempty"`
+	Additions    int        `json:"additions"`
+	Deletions    int        `json:"deletions"`
+	FilesChanged int        `json:"files_changed"`
+	CreatedAt    time.Time  `json:"created_at"`
+	MergedAt     *time.Time `json:"merged_at,omitempty"`
+	URL          string     `json:"url"`
+}
+
+// effortEstimate is an estimate printed by the effort command: points, a
+// size, or both
+type effortEstimate struct {
+	Repository string   `json:"repository"`
+	Number     int      `json:"number"`
+	Points     *float64 `json:"points,omitempty"`
+	Size       string   `json:"size,omitempty"`
+}
+
+// EstimateEffort runs the effort command with the PRs as a JSON array on
+// stdin and reads the estimates it prints as a JSON array of objects with
+// repository, number, and points or size. PRs without an estimate are left
+// out of the result, keyed by owner/name#number.
+func EstimateEffort(ctx context.Context, command string, prs []models.PullRequest) (map[string]models.Effort, error) {
+	input := make([]effortPR, 0, len(prs))
+	for _, pr := range prs {
+		input = append(input, effortPR{
+			Repository:   pr.Repository,
+			Number:       pr.Number,
+			Title:        pr.Title,
+			Author:       pr.Author.Login,
+			State:        string(pr.State),
+			Labels:       pr.Labels,
+			Additions:    pr.Additions,
+			Deletions:    pr.Deletions,
+			FilesChanged: pr.FilesChanged,
+			CreatedAt:    pr.CreatedAt,
+			MergedAt:     pr.MergedAt,
+			URL:          pr.URL,
+		})
+	}
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode PRs: %w", err)
+	}
+
+	out, err := Run(ctx, command, bytes.NewReader(stdin), nil)
+	if err != nil {
+		return nil, fmt.Errorf("effort command failed: %w", err)
+	}
+	var estimates []effortEstimate
+	if err := json.Unmarshal(out, &estimates); err != nil {
+		return nil, fmt.Errorf("effort command printed invalid estimates: %w", err)
+	}
+
+	efforts := make(map[string]models.Effort, len(estimates))
+	for _, e := range estimates {
+		size := strings.ToUpper(strings.TrimSpace(e.Size))
+		effort := models.Effort{Size: size}
+		switch {
+		case e.Points != nil:
+			effort.Points = *e.Points
+		case size != "":
+			points, ok := SizePoints[size]
+			if !ok {
+				return nil, fmt.Errorf("effort command printed unknown size %q for %s#%d", e.Size, e.Repository, e.Number)
+			}
+			effort.Points = points
+		default:
+			continue
+		}
+		if effort.Points < 0 {
+			return nil, fmt.Errorf("effort command printed negative points for %s#%d", e.Repository, e.Number)
+		}
+		efforts[EffortKey(e.Repository, e.Number)] = effort
+	}
+	return efforts, nil
+}
+
+// EffortKey is the key of a PR's estimate
+func EffortKey(repository string, number int) string {
+	return fmt.Sprintf("%s#%d", strings.ToLower(repository), number)
+}
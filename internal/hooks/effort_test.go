@@ -0,0 +1,51 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestEstimateEffort(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell commands")
+	}
+
+	dir := t.TempDir()
+	estimates := filepath.Join(dir, "estimates.json")
+	require.NoError(t, os.WriteFile(estimates, []byte(`[
+		{"repository": "Acme/API", "number": 1, "points": 3},
+		{"repository": "acme/api", "number": 2, "size": "l"},
+		{"repository": "acme/api", "number": 3, "size": "M", "points": 2.5},
+		{"repository": "acme/api", "number": 4}
+	]`), 0o600))
+	stdin := filepath.Join(dir, "stdin.json")
+
+	prs := []models.PullRequest{
+		{Number: 1, Repository: "acme/api", Title: "Add login", Author: models.Author{Login: "alice"}, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	efforts, err := EstimateEffort(t.Context(), "cat > "+stdin+"; cat "+estimates, prs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]models.Effort{
+		"acme/api#1": {Points: 3},
+		"acme/api#2": {Points: 5, Size: "L"},
+		"acme/api#3": {Points: 2.5, Size: "M"},
+	}, efforts, "points win over sizes and PRs without either are left out")
+
+	input, err := os.ReadFile(stdin)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"repository":"acme/api","number":1,"title":"Add login","author":"alice","state":"","additions":0,"deletions":0,"files_changed":0,"created_at":"2024-01-01T00:00:00Z","url":""}]`, string(input))
+
+	_, err = EstimateEffort(t.Context(), `echo '[{"repository":"acme/api","number":1,"size":"huge"}]'`, prs)
+	assert.ErrorContains(t, err, "unknown size")
+	_, err = EstimateEffort(t.Context(), "echo not json", prs)
+	assert.ErrorContains(t, err, "invalid estimates")
+}
@@ -0,0 +1,97 @@
+// Package hooks runs the external commands configured under hooks, letting
+// scripts feed data into a run or act on its results.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timeout bounds every hook command
+const Timeout = 10 * time.Minute
+
+// Run runs a command through the shell with stdin and the environment of
+// the process plus env, and returns its stdout. A failing command's stderr
+// is included in the error.
+func Run(ctx context.Context, command string, stdin io.Reader, env []string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, shell, flag, command) // #nosec G204 -- command comes from the user's own config
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), env...)
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// Hooks run around an analysis
+const (
+	PreAnalyze   = "pre_analyze"
+	PostGenerate = "post_generate"
+)
+
+// RunContext describes the run to the pre_analyze and post_generate hooks
+type RunContext struct {
+	Hook       string // PreAnalyze or PostGenerate
+	ConfigPath string
+	OutputDir  string
+	CacheDir   string
+	Offline    bool
+	Start      *time.Time // Period start, nil when open
+	End        *time.Time
+}
+
+// Env returns the run context as environment variables. Directories are
+// absolute, times RFC 3339 and empty when the period is open.
+func (c RunContext) Env() []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	outputDir := absolute(c.OutputDir)
+	return []string{
+		"GIT_VELOCITY_RUN_HOOK=" + c.Hook,
+		"GIT_VELOCITY_RUN_CONFIG=" + absolute(c.ConfigPath),
+		"GIT_VELOCITY_RUN_OUTPUT_DIR=" + outputDir,
+		"GIT_VELOCITY_RUN_DATA_DIR=" + filepath.Join(outputDir, "data"),
+		"GIT_VELOCITY_RUN_CACHE_DIR=" + absolute(c.CacheDir),
+		"GIT_VELOCITY_RUN_OFFLINE=" + strconv.FormatBool(c.Offline),
+		"GIT_VELOCITY_RUN_PERIOD_START=" + formatTime(c.Start),
+		"GIT_VELOCITY_RUN_PERIOD_END=" + formatTime(c.End),
+	}
+}
+
+// absolute makes a path absolute, keeping it as is when it can't be
+func absolute(path string) string {
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,43 @@
+package hooks
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell commands")
+	}
+
+	out, err := Run(t.Context(), `cat; echo " $HOOK_VALUE"`, strings.NewReader("input"), []string{"HOOK_VALUE=set"})
+	require.NoError(t, err)
+	assert.Equal(t, "input set\n", string(out))
+
+	_, err = Run(t.Context(), "echo broken >&2; exit 3", nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken", "stderr explains the failure")
+}
+
+func TestRunContext_Env(t *testing.T) {
+	t.Parallel()
+
+	end := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+	env := RunContext{Hook: PreAnalyze, OutputDir: "dist", Offline: true, End: &end}.Env()
+
+	abs, err := filepath.Abs("dist")
+	require.NoError(t, err)
+	assert.Contains(t, env, "GIT_VELOCITY_RUN_OUTPUT_DIR="+abs, "directories are absolute")
+	assert.Contains(t, env, "GIT_VELOCITY_RUN_DATA_DIR="+filepath.Join(abs, "data"))
+	assert.Contains(t, env, "GIT_VELOCITY_RUN_CONFIG=", "unset paths stay empty")
+	assert.Contains(t, env, "GIT_VELOCITY_RUN_OFFLINE=true")
+	assert.Contains(t, env, "GIT_VELOCITY_RUN_PERIOD_START=", "open periods have no start")
+	assert.Contains(t, env, "GIT_VELOCITY_RUN_PERIOD_END=2024-03-31T23:59:59Z")
+}
@@ -0,0 +1,50 @@
+package httpx
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(bundle, cert, 0o600))
+	garbage := filepath.Join(dir, "garbage.pem")
+	require.NoError(t, os.WriteFile(garbage, []byte("not a certificate"), 0o600))
+
+	get := func(cfg config.NetworkConfig) error {
+		transport, err := New(cfg)
+		require.NoError(t, err)
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	assert.Error(t, get(config.NetworkConfig{}), "the test server's certificate is not trusted by default")
+	assert.NoError(t, get(config.NetworkConfig{CABundle: bundle}))
+	assert.NoError(t, get(config.NetworkConfig{InsecureSkipVerify: true}))
+
+	_, err := New(config.NetworkConfig{CABundle: garbage})
+	assert.ErrorContains(t, err, "no PEM certificates")
+	_, err = New(config.NetworkConfig{CABundle: filepath.Join(dir, "missing.pem")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,194 @@
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+)
+
+// DefaultLocale is used when no locale is configured and supplies any
+// string missing from another catalog
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// supported lists the bundled catalogs
+var supported = []string{"en", "de", "pl", "fr"}
+
+// Catalog holds the dashboard strings, achievement texts and number and
+// date formats of one locale. It is also written to data/locale.json for the
+// dashboard, so the JSON layout is part of the generated output.
+type Catalog struct {
+	Locale       string                 `json:"locale"`
+	Format       Format                 `json:"format"`
+	Strings      map[string]string      `json:"strings"`
+	Achievements map[string]Achievement `json:"achievements"`
+}
+
+// Format describes how a locale writes numbers and dates
+type Format struct {
+	Decimal     string   `json:"decimal"`      // Decimal separator
+	Group       string   `json:"group"`        // Thousands separator
+	MinGrouping int      `json:"min_grouping"` // Digits above the first group needed before grouping (2 in Polish: 1234 but 12 345)
+	Months      []string `json:"months"`       // Abbreviated month names, January first
+	Date        string   `json:"date"`         // Date pattern using {day}, {month} and {year}
+	DateShort   string   `json:"date_short"`   // Date pattern without the year
+}
+
+// Achievement is the translated name and description of an achievement
+type Achievement struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Supported returns the available locales
+func Supported() []string {
+	return append([]string(nil), supported...)
+}
+
+// IsSupported reports whether locale has a bundled catalog
+func IsSupported(locale string) bool {
+	for _, l := range supported {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Load returns the catalog for locale. Strings and achievements missing from
+// it fall back to English, so a partial translation is still usable.
+func Load(locale string) (*Catalog, error) {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	if !IsSupported(locale) {
+		return nil, fmt.Errorf("unsupported locale %q (supported: %s)", locale, strings.Join(supported, ", "))
+	}
+
+	catalog, err := read(locale)
+	if err != nil {
+		return nil, err
+	}
+	if locale == DefaultLocale {
+		return catalog, nil
+	}
+
+	fallback, err := read(DefaultLocale)
+	if err != nil {
+		return nil, err
+	}
+	mergeFallback(catalog, fallback)
+	return catalog, nil
+}
+
+// mergeFallback copies the strings and achievements missing from catalog
+func mergeFallback(catalog, fallback *Catalog) {
+	for key, value := range fallback.Strings {
+		if _, ok := catalog.Strings[key]; !ok {
+			catalog.Strings[key] = value
+		}
+	}
+	for id, a := range fallback.Achievements {
+		if _, ok := catalog.Achievements[id]; !ok {
+			catalog.Achievements[id] = a
+		}
+	}
+}
+
+// read parses a single bundled catalog
+func read(locale string) (*Catalog, error) {
+	data, err := localesFS.ReadFile("locales/" + locale + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s catalog: %w", locale, err)
+	}
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse %s catalog: %w", locale, err)
+	}
+	if catalog.Strings == nil {
+		catalog.Strings = make(map[string]string)
+	}
+	if catalog.Achievements == nil {
+		catalog.Achievements = make(map[string]Achievement)
+	}
+	return &catalog, nil
+}
+
+// T returns the string for key with {name} placeholders replaced by the
+// name/value pairs in args. Unknown keys are returned as is.
+func (c *Catalog) T(key string, args ...string) string {
+	s, ok := c.Strings[key]
+	if !ok {
+		return key
+	}
+	for i := 0; i+1 < len(args); i += 2 {
+		s = strings.ReplaceAll(s, "{"+args[i]+"}", args[i+1])
+	}
+	return s
+}
+
+// Achievement returns the translated texts of an achievement
+func (c *Catalog) Achievement(id string) (Achievement, bool) {
+	a, ok := c.Achievements[id]
+	return a, ok
+}
+
+// FormatNumber formats n with the given number of decimals, using the
+// locale's decimal and thousands separators
+func (c *Catalog) FormatNumber(n float64, decimals int) string {
+	s := strconv.FormatFloat(math.Abs(n), 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(s, ".")
+
+	minGrouping := max(c.Format.MinGrouping, 1)
+	if len(integer) >= 3+minGrouping {
+		var b strings.Builder
+		lead := len(integer) % 3
+		if lead > 0 {
+			b.WriteString(integer[:lead])
+		}
+		for i := lead; i < len(integer); i += 3 {
+			if b.Len() > 0 {
+				b.WriteString(c.Format.Group)
+			}
+			b.WriteString(integer[i : i+3])
+		}
+		integer = b.String()
+	}
+
+	if fraction != "" {
+		integer += c.Format.Decimal + fraction
+	}
+	if n < 0 && strings.Trim(s, "0.") != "" {
+		integer = "-" + integer
+	}
+	return integer
+}
+
+// FormatDate formats t as a medium date, e.g. "Jan 2, 2006" or "2. Jan. 2006"
+func (c *Catalog) FormatDate(t time.Time) string {
+	return c.formatDate(c.Format.Date, t)
+}
+
+// FormatShortDate formats t without the year, e.g. "Jan 2" or "2. Jan."
+func (c *Catalog) FormatShortDate(t time.Time) string {
+	return c.formatDate(c.Format.DateShort, t)
+}
+
+func (c *Catalog) formatDate(pattern string, t time.Time) string {
+	month := t.Format("Jan")
+	if m := int(t.Month()) - 1; m < len(c.Format.Months) {
+		month = c.Format.Months[m]
+	}
+	return strings.NewReplacer(
+		"{day}", strconv.Itoa(t.Day()),
+		"{month}", month,
+		"{year}", strconv.Itoa(t.Year()),
+	).Replace(pattern)
+}
@@ -0,0 +1,128 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	for _, locale := range Supported() {
+		catalog, err := Load(locale)
+		require.NoError(t, err, locale)
+		assert.Equal(t, locale, catalog.Locale)
+		assert.Len(t, catalog.Format.Months, 12, locale)
+	}
+
+	catalog, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLocale, catalog.Locale)
+
+	_, err = Load("xx")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported locale")
+}
+
+// Every catalog must translate every achievement and every English string,
+// so no locale silently shows English text
+func TestCatalogsAreComplete(t *testing.T) {
+	t.Parallel()
+
+	english, err := read(DefaultLocale)
+	require.NoError(t, err)
+
+	for _, locale := range Supported() {
+		catalog, err := read(locale)
+		require.NoError(t, err)
+		for key := range english.Strings {
+			assert.NotEmpty(t, catalog.Strings[key], "%s is missing string %s", locale, key)
+		}
+		for id := range english.Achievements {
+			a := catalog.Achievements[id]
+			assert.NotEmpty(t, a.Name, "%s is missing the name of %s", locale, id)
+			assert.NotEmpty(t, a.Description, "%s is missing the description of %s", locale, id)
+		}
+	}
+}
+
+func TestCatalog_T(t *testing.T) {
+	t.Parallel()
+
+	catalog, err := Load("de")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bestenliste", catalog.T("nav.leaderboard"))
+	assert.Equal(t, "Woche vom 6. Jan.", catalog.T("wallboard.week_of", "week", "6. Jan."))
+	assert.Equal(t, "no.such.key", catalog.T("no.such.key"))
+
+	a, ok := catalog.Achievement("commit-1")
+	require.True(t, ok)
+	assert.Equal(t, "Erste Schritte", a.Name)
+}
+
+func TestMergeFallback(t *testing.T) {
+	t.Parallel()
+
+	catalog := &Catalog{
+		Strings:      map[string]string{"nav.leaderboard": "Classement"},
+		Achievements: map[string]Achievement{},
+	}
+	fallback := &Catalog{
+		Strings:      map[string]string{"nav.leaderboard": "Leaderboard", "nav.dashboard": "Dashboard"},
+		Achievements: map[string]Achievement{"commit-1": {Name: "First Steps"}},
+	}
+	mergeFallback(catalog, fallback)
+
+	assert.Equal(t, "Classement", catalog.T("nav.leaderboard"))
+	assert.Equal(t, "Dashboard", catalog.T("nav.dashboard"))
+	assert.Equal(t, "First Steps", catalog.Achievements["commit-1"].Name)
+}
+
+func TestCatalog_FormatNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		locale   string
+		n        float64
+		decimals int
+		want     string
+	}{
+		{"en", 1234567.891, 2, "1,234,567.89"},
+		{"en", 999, 0, "999"},
+		{"en", -1234, 0, "-1,234"},
+		{"en", -0.001, 1, "0.0"},
+		{"de", 1234.5, 1, "1.234,5"},
+		{"fr", 1234.5, 1, "1 234,5"},
+		{"pl", 1234, 0, "1234"},
+		{"pl", 12345.5, 1, "12 345,5"},
+	}
+
+	for _, tt := range tests {
+		catalog, err := Load(tt.locale)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, catalog.FormatNumber(tt.n, tt.decimals), "%s %v", tt.locale, tt.n)
+	}
+}
+
+func TestCatalog_FormatDate(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2025, time.March, 6, 12, 0, 0, 0, time.UTC)
+	tests := map[string][2]string{
+		"en": {"Mar 6, 2025", "Mar 6"},
+		"de": {"6. März 2025", "6. März"},
+		"pl": {"6 mar 2025", "6 mar"},
+		"fr": {"6 mars 2025", "6 mars"},
+	}
+
+	for locale, want := range tests {
+		catalog, err := Load(locale)
+		require.NoError(t, err)
+		assert.Equal(t, want[0], catalog.FormatDate(date), locale)
+		assert.Equal(t, want[1], catalog.FormatShortDate(date), locale)
+	}
+}
@@ -0,0 +1,173 @@
+package icons
+
+// defaultEmoji stands in for classes without an emoji of their own
+const defaultEmoji = "🎖️"
+
+// emojis maps the FontAwesome classes of the achievements and the dashboard
+// to emoji
+var emojis = map[string]string{
+	"fa-arrow-right":               "➡️",
+	"fa-arrow-trend-down":          "📉",
+	"fa-arrow-trend-up":            "📈",
+	"fa-arrows-left-right-to-line": "↔️",
+	"fa-atom":                      "⚛️",
+	"fa-award":                     "🏆",
+	"fa-baby":                      "👶",
+	"fa-ban":                       "🚫",
+	"fa-bandage":                   "🩹",
+	"fa-bars":                      "☰",
+	"fa-bed":                       "🛏️",
+	"fa-bolt":                      "⚡",
+	"fa-book":                      "📕",
+	"fa-book-open":                 "📖",
+	"fa-briefcase":                 "💼",
+	"fa-broom":                     "🧹",
+	"fa-bug":                       "🐛",
+	"fa-bug-slash":                 "🐞",
+	"fa-building":                  "🏢",
+	"fa-bullhorn":                  "📣",
+	"fa-business-time":             "🕘",
+	"fa-calculator":                "🧮",
+	"fa-calendar-alt":              "📆",
+	"fa-calendar-check":            "✅",
+	"fa-calendar-day":              "📅",
+	"fa-calendar-week":             "🗓️",
+	"fa-chart-gantt":               "📊",
+	"fa-chart-line":                "📈",
+	"fa-chart-pie":                 "🥧",
+	"fa-chart-simple":              "📊",
+	"fa-check":                     "✔️",
+	"fa-check-double":              "☑️",
+	"fa-chevron-right":             "›",
+	"fa-circle-check":              "✅",
+	"fa-circle-exclamation":        "❗",
+	"fa-circle-question":           "❓",
+	"fa-circle-xmark":              "❌",
+	"fa-clipboard-check":           "📋",
+	"fa-clipboard-list":            "📋",
+	"fa-clock":                     "🕐",
+	"fa-clock-rotate-left":         "⏪",
+	"fa-cloud-moon":                "🌙",
+	"fa-cloud-sun":                 "🌤️",
+	"fa-code":                      "💻",
+	"fa-code-branch":               "🌿",
+	"fa-code-commit":               "🔘",
+	"fa-code-compare":              "🔀",
+	"fa-code-fork":                 "🍴",
+	"fa-code-merge":                "🔗",
+	"fa-code-pull-request":         "📬",
+	"fa-cog":                       "⚙️",
+	"fa-coins":                     "🪙",
+	"fa-comment":                   "💬",
+	"fa-comment-dots":              "💭",
+	"fa-comments":                  "🗨️",
+	"fa-compress":                  "🗜️",
+	"fa-couch":                     "🛋️",
+	"fa-crosshairs":                "🎯",
+	"fa-crown":                     "👑",
+	"fa-cubes":                     "🧊",
+	"fa-diagram-project":           "🗺️",
+	"fa-dumbbell":                  "🏋️",
+	"fa-dumpster-fire":             "🔥",
+	"fa-equals":                    "🟰",
+	"fa-eraser":                    "🧽",
+	"fa-exclamation-triangle":      "⚠️",
+	"fa-expand":                    "↔️",
+	"fa-external-link-alt":         "↗️",
+	"fa-eye":                       "👀",
+	"fa-file-lines":                "📄",
+	"fa-filter":                    "🔽",
+	"fa-fire":                      "🔥",
+	"fa-fire-flame-curved":         "☄️",
+	"fa-flag":                      "🚩",
+	"fa-flask":                     "⚗️",
+	"fa-folder":                    "📁",
+	"fa-folder-tree":               "🗂️",
+	"fa-forward":                   "⏩",
+	"fa-function":                  "ƒ",
+	"fa-gamepad":                   "🎮",
+	"fa-gears":                     "⚙️",
+	"fa-gem":                       "💎",
+	"fa-ghost":                     "👻",
+	"fa-github":                    "🐙",
+	"fa-glasses":                   "👓",
+	"fa-graduation-cap":            "🎓",
+	"fa-hands-helping":             "🤝",
+	"fa-heart-pulse":               "💓",
+	"fa-home":                      "🏠",
+	"fa-hourglass-end":             "⌛",
+	"fa-hourglass-half":            "⏳",
+	"fa-house-laptop":              "🏠",
+	"fa-inbox":                     "📥",
+	"fa-infinity":                  "♾️",
+	"fa-info-circle":               "ℹ️",
+	"fa-landmark":                  "🏛️",
+	"fa-layer-group":               "📚",
+	"fa-link":                      "🔗",
+	"fa-list-check":                "📝",
+	"fa-list-ol":                   "🔢",
+	"fa-lock":                      "🔒",
+	"fa-magnifying-glass":          "🔍",
+	"fa-medal":                     "🏅",
+	"fa-message":                   "✉️",
+	"fa-microchip":                 "🔌",
+	"fa-minimize":                  "🤏",
+	"fa-minus":                     "➖",
+	"fa-moon":                      "🌛",
+	"fa-mountain":                  "⛰️",
+	"fa-mountain-sun":              "🏔️",
+	"fa-mug-hot":                   "☕",
+	"fa-network-wired":             "🌐",
+	"fa-pen-ruler":                 "📐",
+	"fa-people-arrows":             "🤝",
+	"fa-people-group":              "👥",
+	"fa-people-roof":               "🏘️",
+	"fa-person-running":            "🏃",
+	"fa-plus":                      "➕",
+	"fa-recycle":                   "♻️",
+	"fa-reply":                     "↩️",
+	"fa-robot":                     "🤖",
+	"fa-rocket":                    "🚀",
+	"fa-rotate":                    "🔄",
+	"fa-scale-balanced":            "⚖️",
+	"fa-scalpel":                   "🔪",
+	"fa-scissors":                  "✂️",
+	"fa-scroll":                    "📜",
+	"fa-search":                    "🔍",
+	"fa-seedling":                  "🌱",
+	"fa-share-nodes":               "🔗",
+	"fa-shield":                    "🛡️",
+	"fa-shield-halved":             "🛡️",
+	"fa-sitemap":                   "🧭",
+	"fa-skull":                     "💀",
+	"fa-skull-crossbones":          "☠️",
+	"fa-spinner":                   "⏳",
+	"fa-square-check":              "✅",
+	"fa-star":                      "⭐",
+	"fa-star-and-crescent":         "🌟",
+	"fa-stopwatch":                 "⏱️",
+	"fa-sun":                       "☀️",
+	"fa-sunrise":                   "🌅",
+	"fa-times":                     "✖️",
+	"fa-trash-can":                 "🗑️",
+	"fa-trophy":                    "🏆",
+	"fa-unlock":                    "🔓",
+	"fa-user-check":                "🙋",
+	"fa-user-clock":                "⏰",
+	"fa-user-doctor":               "🧑‍⚕️",
+	"fa-user-graduate":             "🧑‍🎓",
+	"fa-user-group":                "👫",
+	"fa-user-shield":               "👮",
+	"fa-users":                     "👥",
+	"fa-vial":                      "🧪",
+	"fa-volume-xmark":              "🔇",
+	"fa-weight-hanging":            "🏋️",
+}
+
+// emojiFor returns the emoji standing in for a FontAwesome class
+func emojiFor(class string) string {
+	if e, ok := emojis[class]; ok {
+		return e
+	}
+	return defaultEmoji
+}
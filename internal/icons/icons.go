@@ -0,0 +1,134 @@
+// Package icons resolves the icons drawn for achievements. Achievements name
+// a FontAwesome class; an icon set maps them to FontAwesome itself, to emoji,
+// or to the SVG files of a custom pack, so dashboards also render without
+// access to the FontAwesome CDN.
+package icons
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Icon sets selectable with output.icons.set
+const (
+	FontAwesome = "fontawesome"
+	Emoji       = "emoji"
+	SVG         = "svg"
+)
+
+// supported lists the icon sets
+var supported = []string{FontAwesome, Emoji, SVG}
+
+// Icon is how an achievement is drawn; exactly one field is set. Icons are
+// written to data/icons.json for the dashboard, so the JSON layout is part of
+// the generated output.
+type Icon struct {
+	Class string `json:"class,omitempty"` // FontAwesome class (fa-trophy)
+	Emoji string `json:"emoji,omitempty"`
+	SVG   string `json:"svg,omitempty"` // Path of the SVG file, relative to the site root
+}
+
+// Provider resolves the icons of achievements
+type Provider interface {
+	// Icon returns the icon of an achievement from its ID and FontAwesome class
+	Icon(id, class string) Icon
+	// Files returns the files to copy into the site, from their path
+	// relative to the site root to their source
+	Files() map[string]string
+}
+
+// Supported returns the available icon sets
+func Supported() []string {
+	return append([]string(nil), supported...)
+}
+
+// IsSupported reports whether set is an icon set
+func IsSupported(set string) bool {
+	for _, s := range supported {
+		if s == set {
+			return true
+		}
+	}
+	return false
+}
+
+// Load returns the provider of an icon set. dir is the directory of the SVG
+// pack; an empty set selects FontAwesome.
+func Load(set, dir string) (Provider, error) {
+	switch set {
+	case "", FontAwesome:
+		return fontAwesome{}, nil
+	case Emoji:
+		return emoji{}, nil
+	case SVG:
+		return loadPack(dir)
+	default:
+		return nil, fmt.Errorf("unsupported icon set %q (supported: %s)", set, strings.Join(supported, ", "))
+	}
+}
+
+// fontAwesome keeps the FontAwesome classes
+type fontAwesome struct{}
+
+func (fontAwesome) Icon(_, class string) Icon { return Icon{Class: class} }
+
+func (fontAwesome) Files() map[string]string { return nil }
+
+// emoji draws achievements with emoji, which need no assets at all
+type emoji struct{}
+
+func (emoji) Icon(_, class string) Icon { return Icon{Emoji: emojiFor(class)} }
+
+func (emoji) Files() map[string]string { return nil }
+
+// packDir is where the files of an SVG pack are copied in the site
+const packDir = "icons"
+
+// pack draws achievements with the SVG files of a directory, named after the
+// achievement ID (commit-100.svg) or its FontAwesome icon (trophy.svg).
+// Achievements without a file fall back to emoji.
+type pack struct {
+	files map[string]string // By name without the extension
+}
+
+func loadPack(dir string) (*pack, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("the svg icon set needs the directory of the pack")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read icon pack: %w", err)
+	}
+
+	p := &pack{files: make(map[string]string)}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(name), ".svg") {
+			continue
+		}
+		p.files[strings.TrimSuffix(name, filepath.Ext(name))] = filepath.Join(dir, name)
+	}
+	if len(p.files) == 0 {
+		return nil, fmt.Errorf("no SVG files found in icon pack %s", dir)
+	}
+	return p, nil
+}
+
+func (p *pack) Icon(id, class string) Icon {
+	for _, name := range []string{id, strings.TrimPrefix(class, "fa-")} {
+		if _, ok := p.files[name]; ok {
+			return Icon{SVG: packDir + "/" + name + ".svg"}
+		}
+	}
+	return Icon{Emoji: emojiFor(class)}
+}
+
+func (p *pack) Files() map[string]string {
+	files := make(map[string]string, len(p.files))
+	for name, path := range p.files {
+		files[packDir+"/"+name+".svg"] = path
+	}
+	return files
+}
@@ -0,0 +1,74 @@
+package icons
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_FontAwesome(t *testing.T) {
+	t.Parallel()
+
+	for _, set := range []string{"", FontAwesome} {
+		p, err := Load(set, "")
+		require.NoError(t, err)
+		assert.Equal(t, Icon{Class: "fa-trophy"}, p.Icon("pr-100", "fa-trophy"))
+		assert.Empty(t, p.Files())
+	}
+}
+
+func TestLoad_Emoji(t *testing.T) {
+	t.Parallel()
+
+	p, err := Load(Emoji, "")
+	require.NoError(t, err)
+	assert.Equal(t, Icon{Emoji: "🏆"}, p.Icon("pr-100", "fa-trophy"))
+	assert.Equal(t, Icon{Emoji: defaultEmoji}, p.Icon("custom", "fa-unknown"))
+	assert.Empty(t, p.Files())
+}
+
+func TestLoad_SVG(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"commit-100.svg", "trophy.svg", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("<svg/>"), 0600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested.svg"), 0750))
+
+	p, err := Load(SVG, dir)
+	require.NoError(t, err)
+	assert.Equal(t, Icon{SVG: "icons/commit-100.svg"}, p.Icon("commit-100", "fa-fire"), "achievement IDs win")
+	assert.Equal(t, Icon{SVG: "icons/trophy.svg"}, p.Icon("pr-100", "fa-trophy"))
+	assert.Equal(t, Icon{Emoji: "👶"}, p.Icon("commit-1", "fa-baby"), "missing icons fall back to emoji")
+	assert.Equal(t, map[string]string{
+		"icons/commit-100.svg": filepath.Join(dir, "commit-100.svg"),
+		"icons/trophy.svg":     filepath.Join(dir, "trophy.svg"),
+	}, p.Files())
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load("material", "")
+	require.ErrorContains(t, err, "unsupported icon set")
+	_, err = Load(SVG, "")
+	require.ErrorContains(t, err, "needs the directory")
+	_, err = Load(SVG, filepath.Join(t.TempDir(), "missing"))
+	require.ErrorContains(t, err, "failed to read icon pack")
+	_, err = Load(SVG, t.TempDir())
+	require.ErrorContains(t, err, "no SVG files")
+}
+
+func TestStylesheet(t *testing.T) {
+	t.Parallel()
+
+	css := Stylesheet()
+	assert.Contains(t, css, `.fa-trophy::before { content: "🏆"; }`)
+	assert.Contains(t, css, "@keyframes fa-spin")
+	assert.Less(t, strings.Index(css, ".fa-atom::"), strings.Index(css, ".fa-trophy::"), "rules are sorted")
+}
@@ -0,0 +1,39 @@
+package icons
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// stylesheetBase makes the FontAwesome element classes render emoji inline,
+// and keeps spinners spinning
+const stylesheetBase = `.fas, .far, .fab, .fa {
+  display: inline-block;
+  font-style: normal;
+  font-weight: normal;
+  line-height: 1;
+  text-align: center;
+}
+.fa-spin { animation: fa-spin 2s linear infinite; }
+@keyframes fa-spin { to { transform: rotate(360deg); } }
+`
+
+// Stylesheet returns CSS drawing the FontAwesome classes of the dashboard
+// with emoji, so pages keep their icons without loading FontAwesome. Rules
+// are sorted, so the output is reproducible.
+func Stylesheet() string {
+	classes := make([]string, 0, len(emojis))
+	for class := range emojis {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	var b strings.Builder
+	b.WriteString(stylesheetBase)
+	for _, class := range classes {
+		// Emoji hold no quotes or backslashes, so they need no escaping
+		fmt.Fprintf(&b, ".%s::before { content: \"%s\"; }\n", class, emojis[class])
+	}
+	return b.String()
+}
@@ -0,0 +1,146 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// DefaultEndpoint is the Linear GraphQL API endpoint
+const DefaultEndpoint = "https://api.linear.app/graphql"
+
+// batchSize limits the number of aliased issue lookups per GraphQL request
+const batchSize = 50
+
+// Client is a minimal Linear GraphQL API client for issue state lookups
+type Client struct {
+	endpoint   string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewClient creates a new Linear API client
+func NewClient(apiKey string) *Client {
+	return &Client{
+		endpoint:   DefaultEndpoint,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SetTransport sends requests through transport, for proxy and TLS settings
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.httpClient.Transport = transport
+}
+
+// SetEndpoint overrides the API endpoint (useful for testing)
+func (c *Client) SetEndpoint(endpoint string) {
+	c.endpoint = endpoint
+}
+
+type gqlIssue struct {
+	Identifier  string     `json:"identifier"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	CompletedAt *time.Time `json:"completedAt"`
+	State       struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"state"`
+}
+
+type gqlResponse struct {
+	Data   map[string]*gqlIssue `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// FetchIssues looks up the given issue identifiers and returns them keyed by identifier.
+// Identifiers that don't exist (or aren't visible to the API key) are omitted.
+func (c *Client) FetchIssues(ctx context.Context, identifiers []string) (map[string]models.LinearIssue, error) {
+	issues := make(map[string]models.LinearIssue)
+
+	for start := 0; start < len(identifiers); start += batchSize {
+		end := start + batchSize
+		if end > len(identifiers) {
+			end = len(identifiers)
+		}
+
+		batch, err := c.fetchBatch(ctx, identifiers[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for id, issue := range batch {
+			issues[id] = issue
+		}
+	}
+
+	return issues, nil
+}
+
+func (c *Client) fetchBatch(ctx context.Context, identifiers []string) (map[string]models.LinearIssue, error) {
+	// Build one aliased lookup per identifier: i0: issue(id: "ENG-1") { ... }
+	var sb strings.Builder
+	sb.WriteString("query {")
+	for i, id := range identifiers {
+		fmt.Fprintf(&sb, ` i%d: issue(id: %q) { identifier title url completedAt state { name type } }`, i, id)
+	}
+	sb.WriteString(" }")
+
+	body, err := json.Marshal(map[string]string{"query": sb.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("linear request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("linear API returned status %d", resp.StatusCode)
+	}
+
+	var result gqlResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode linear response: %w", err)
+	}
+
+	// Missing issues are reported as errors alongside partial data; only fail when nothing came back
+	if len(result.Data) == 0 && len(result.Errors) > 0 {
+		return nil, fmt.Errorf("linear API error: %s", result.Errors[0].Message)
+	}
+
+	issues := make(map[string]models.LinearIssue)
+	for _, node := range result.Data {
+		if node == nil || node.Identifier == "" {
+			continue
+		}
+		issues[node.Identifier] = models.LinearIssue{
+			Identifier:  node.Identifier,
+			Title:       node.Title,
+			StateName:   node.State.Name,
+			StateType:   node.State.Type,
+			CompletedAt: node.CompletedAt,
+			URL:         node.URL,
+		}
+	}
+
+	return issues, nil
+}
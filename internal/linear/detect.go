@@ -0,0 +1,68 @@
+package linear
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Detector finds Linear issue identifiers (e.g., ENG-123) in free text.
+// Only identifiers belonging to the configured team keys are matched, which
+// avoids false positives such as "UTF-8" or "SHA-256".
+type Detector struct {
+	re *regexp.Regexp
+}
+
+// NewDetector creates a detector for the given Linear team keys
+func NewDetector(teamKeys []string) *Detector {
+	if len(teamKeys) == 0 {
+		return &Detector{}
+	}
+
+	quoted := make([]string, 0, len(teamKeys))
+	for _, key := range teamKeys {
+		key = strings.TrimSpace(key)
+		if key != "" {
+			quoted = append(quoted, regexp.QuoteMeta(strings.ToUpper(key)))
+		}
+	}
+	if len(quoted) == 0 {
+		return &Detector{}
+	}
+
+	// Case-insensitive: Linear branch names use lowercase identifiers (eng-123-fix-login).
+	// The start of a match is checked by Find: matching the character before
+	// it would consume the separator of adjacent identifiers (ENG-1,ENG-2).
+	pattern := `(?i)(?:` + strings.Join(quoted, "|") + `)-[0-9]+`
+	return &Detector{re: regexp.MustCompile(pattern)}
+}
+
+// Find returns the unique, upper-cased issue identifiers found in the given texts
+func (d *Detector) Find(texts ...string) []string {
+	if d == nil || d.re == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, m := range d.re.FindAllStringIndex(text, -1) {
+			// Digits are matched greedily, so only the start needs a boundary
+			if m[0] > 0 && isAlphanumeric(text[m[0]-1]) {
+				continue
+			}
+			seen[strings.ToUpper(text[m[0]:m[1]])] = true
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// isAlphanumeric reports whether an ASCII byte is a letter or digit
+func isAlphanumeric(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
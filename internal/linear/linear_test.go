@@ -0,0 +1,99 @@
+package linear
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Find(t *testing.T) {
+	t.Parallel()
+
+	detector := NewDetector([]string{"ENG", "ops"})
+
+	tests := []struct {
+		name     string
+		texts    []string
+		expected []string
+	}{
+		{"title prefix", []string{"ENG-123: fix login"}, []string{"ENG-123"}},
+		{"lowercase branch", []string{"eng-42-add-cache"}, []string{"ENG-42"}},
+		{"multiple teams", []string{"Fix OPS-7 and ENG-8"}, []string{"ENG-8", "OPS-7"}},
+		{"deduplicated across texts", []string{"ENG-1 title", "eng-1-branch"}, []string{"ENG-1"}},
+		{"unknown team ignored", []string{"UTF-8 and SHA-256"}, []string{}},
+		{"embedded in word ignored", []string{"XENG-12"}, []string{}},
+		{"adjacent", []string{"ENG-1 ENG-2"}, []string{"ENG-1", "ENG-2"}},
+		{"comma-separated", []string{"Fixes ENG-1,ENG-2,OPS-3"}, []string{"ENG-1", "ENG-2", "OPS-3"}},
+		{"after an embedded one", []string{"XENG-1 ENG-2"}, []string{"ENG-2"}},
+		{"no match", []string{"plain message"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, detector.Find(tt.texts...))
+		})
+	}
+}
+
+func TestDetector_NoTeamKeys(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, NewDetector(nil).Find("ENG-123"))
+}
+
+func TestClient_FetchIssues(t *testing.T) {
+	t.Parallel()
+
+	var authHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `issue(id: \"ENG-1\")`)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"data": {
+				"i0": {"identifier": "ENG-1", "title": "Login", "url": "https://linear.app/x/issue/ENG-1",
+				       "completedAt": "2024-06-01T10:00:00Z", "state": {"name": "Done", "type": "completed"}},
+				"i1": null
+			},
+			"errors": [{"message": "Entity not found"}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("lin_api_test")
+	client.SetEndpoint(server.URL)
+
+	issues, err := client.FetchIssues(context.Background(), []string{"ENG-1", "ENG-404"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "lin_api_test", authHeader)
+	require.Len(t, issues, 1)
+	issue := issues["ENG-1"]
+	assert.Equal(t, "Done", issue.StateName)
+	assert.True(t, issue.IsCompleted())
+	require.NotNil(t, issue.CompletedAt)
+}
+
+func TestClient_FetchIssuesError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient("bad")
+	client.SetEndpoint(server.URL)
+
+	_, err := client.FetchIssues(context.Background(), []string{"ENG-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
@@ -0,0 +1,90 @@
+// Package lint reads static-analysis findings from golangci-lint and ESLint
+// JSON reports, counted per file.
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	json "github.com/goccy/go-json"
+)
+
+// golangciReport is the relevant part of golangci-lint's --out-format json output
+type golangciReport struct {
+	Issues []struct {
+		Pos struct {
+			Filename string `json:"Filename"`
+		} `json:"Pos"`
+	} `json:"Issues"`
+}
+
+// eslintResult is one file of ESLint's --format json output
+type eslintResult struct {
+	FilePath     string `json:"filePath"`
+	ErrorCount   int    `json:"errorCount"`
+	WarningCount int    `json:"warningCount"`
+}
+
+// ParseFile reads the findings per file of a golangci-lint or ESLint JSON report
+func ParseFile(path string) (map[string]int, error) {
+	data, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- path comes from the user's own config
+	if err != nil {
+		return nil, err
+	}
+	findings, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return findings, nil
+}
+
+// Parse counts the findings per file of a golangci-lint (JSON object) or
+// ESLint (JSON array) report. Paths are returned with forward slashes and no
+// leading "./".
+func Parse(data []byte) (map[string]int, error) {
+	data = bytes.TrimSpace(data)
+	findings := make(map[string]int)
+
+	switch {
+	case bytes.HasPrefix(data, []byte("[")):
+		var results []eslintResult
+		if err := json.Unmarshal(data, &results); err != nil {
+			return nil, fmt.Errorf("invalid ESLint report: %w", err)
+		}
+		for _, r := range results {
+			if n := r.ErrorCount + r.WarningCount; n > 0 {
+				findings[normalizePath(r.FilePath)] += n
+			}
+		}
+	case bytes.HasPrefix(data, []byte("{")):
+		var report golangciReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("invalid golangci-lint report: %w", err)
+		}
+		for _, issue := range report.Issues {
+			findings[normalizePath(issue.Pos.Filename)]++
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized report: expected golangci-lint or ESLint JSON")
+	}
+
+	return findings, nil
+}
+
+// StripPrefix makes the paths of findings relative to dir, for reports
+// written with absolute paths (as ESLint does)
+func StripPrefix(findings map[string]int, dir string) map[string]int {
+	prefix := strings.TrimSuffix(normalizePath(dir), "/") + "/"
+	stripped := make(map[string]int, len(findings))
+	for path, n := range findings {
+		stripped[strings.TrimPrefix(path, prefix)] += n
+	}
+	return stripped
+}
+
+func normalizePath(path string) string {
+	return strings.TrimPrefix(filepath.ToSlash(path), "./")
+}
@@ -0,0 +1,83 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		report   string
+		expected map[string]int
+		wantErr  bool
+	}{
+		{
+			name: "golangci-lint",
+			report: `{"Issues": [
+				{"FromLinter": "errcheck", "Pos": {"Filename": "internal/app/app.go", "Line": 10}},
+				{"FromLinter": "unused", "Pos": {"Filename": "./internal/app/app.go", "Line": 20}},
+				{"FromLinter": "govet", "Pos": {"Filename": "main.go", "Line": 3}}
+			], "Report": {}}`,
+			expected: map[string]int{"internal/app/app.go": 2, "main.go": 1},
+		},
+		{
+			name:     "golangci-lint without issues",
+			report:   `{"Issues": null}`,
+			expected: map[string]int{},
+		},
+		{
+			name: "eslint",
+			report: `[
+				{"filePath": "/src/app/index.js", "errorCount": 2, "warningCount": 1, "messages": []},
+				{"filePath": "/src/app/clean.js", "errorCount": 0, "warningCount": 0, "messages": []}
+			]`,
+			expected: map[string]int{"/src/app/index.js": 3},
+		},
+		{
+			name:    "not json",
+			report:  "main.go:1:1: error",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			findings, err := Parse([]byte(tt.report))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, findings)
+		})
+	}
+}
+
+func TestStripPrefix(t *testing.T) {
+	t.Parallel()
+
+	findings := map[string]int{"/src/app/index.js": 3, "lib/util.js": 1}
+	assert.Equal(t, map[string]int{"index.js": 3, "lib/util.js": 1}, StripPrefix(findings, "/src/app/"))
+}
+
+func TestParseFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lint.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"Issues": [{"Pos": {"Filename": "a.go"}}]}`), 0o600))
+
+	findings, err := ParseFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a.go": 1}, findings)
+
+	_, err = ParseFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
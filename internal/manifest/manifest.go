@@ -0,0 +1,159 @@
+// Package manifest reads the packages a repository publishes and requires
+// from its go.mod and package.json files.
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// skippedDirs hold copies of other projects' manifests rather than the
+// repository's own
+var skippedDirs = map[string]bool{"vendor": true, "node_modules": true, "testdata": true}
+
+// IsManifest reports whether a slash-separated path within a repository is a
+// go.mod or package.json file of the repository itself
+func IsManifest(p string) bool {
+	base := path.Base(p)
+	if base != "go.mod" && base != "package.json" {
+		return false
+	}
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		if skippedDirs[dir] {
+			return false
+		}
+	}
+	return true
+}
+
+// Read builds the manifest of a repository from its manifest files, keyed by
+// path. Monorepos may hold several; packages are listed once, sorted.
+func Read(repository string, files map[string][]byte) (models.RepositoryManifest, error) {
+	m := models.RepositoryManifest{Repository: repository, Provides: []models.Package{}, Requires: []models.Package{}}
+	provided := make(map[models.Package]bool)
+	required := make(map[models.Package]bool)
+	for p, content := range files {
+		var name string
+		var requires []string
+		var err error
+		ecosystem := models.EcosystemGo
+		if path.Base(p) == "package.json" {
+			ecosystem = models.EcosystemNPM
+			name, requires, err = ParsePackageJSON(content)
+		} else {
+			name, requires, err = ParseGoMod(content)
+		}
+		if err != nil {
+			return m, fmt.Errorf("%s: %w", p, err)
+		}
+		if name != "" {
+			provided[models.Package{Name: name, Ecosystem: ecosystem}] = true
+		}
+		for _, r := range requires {
+			required[models.Package{Name: r, Ecosystem: ecosystem}] = true
+		}
+	}
+
+	// Packages of a monorepo requiring each other are not dependencies
+	for pkg := range provided {
+		delete(required, pkg)
+	}
+	m.Provides = sortedPackages(provided)
+	m.Requires = sortedPackages(required)
+	return m, nil
+}
+
+// ParseGoMod returns the module path of a go.mod file and the modules it requires
+func ParseGoMod(content []byte) (module string, requires []string, err error) {
+	inRequire := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if inRequire {
+			if fields[0] == ")" {
+				inRequire = false
+				continue
+			}
+			requires = append(requires, unquote(fields[0]))
+			continue
+		}
+		switch fields[0] {
+		case "module":
+			if len(fields) < 2 {
+				return "", nil, fmt.Errorf("malformed module directive")
+			}
+			module = unquote(fields[1])
+		case "require":
+			switch {
+			case len(fields) == 2 && fields[1] == "(":
+				inRequire = true
+			case len(fields) >= 3:
+				requires = append(requires, unquote(fields[1]))
+			default:
+				return "", nil, fmt.Errorf("malformed require directive")
+			}
+		}
+	}
+	return module, requires, scanner.Err()
+}
+
+// ParsePackageJSON returns the name of a package.json file and the packages
+// it depends on, including development, peer and optional dependencies
+func ParsePackageJSON(content []byte) (name string, requires []string, err error) {
+	var pkg struct {
+		Name                 string            `json:"name"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return "", nil, err
+	}
+	for _, deps := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.PeerDependencies, pkg.OptionalDependencies} {
+		for dep := range deps {
+			requires = append(requires, dep)
+		}
+	}
+	sort.Strings(requires)
+	return pkg.Name, requires, nil
+}
+
+// unquote strips the quotes go.mod allows around module paths
+func unquote(s string) string {
+	if u, err := strconv.Unquote(s); err == nil {
+		return u
+	}
+	return s
+}
+
+func sortedPackages(set map[models.Package]bool) []models.Package {
+	pkgs := make([]models.Package, 0, len(set))
+	for pkg := range set {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Slice(pkgs, func(i, j int) bool {
+		if pkgs[i].Ecosystem != pkgs[j].Ecosystem {
+			return pkgs[i].Ecosystem < pkgs[j].Ecosystem
+		}
+		return pkgs[i].Name < pkgs[j].Name
+	})
+	return pkgs
+}
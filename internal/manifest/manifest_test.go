@@ -0,0 +1,89 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestParseGoMod(t *testing.T) {
+	t.Parallel()
+
+	module, requires, err := ParseGoMod([]byte(`// The API client
+module github.com/acme/client
+
+go 1.22
+
+require github.com/acme/proto v1.2.0
+
+require (
+	"github.com/acme/auth" v0.3.1 // pinned
+	golang.org/x/sync v0.7.0 // indirect
+)
+
+replace github.com/acme/auth => ../auth
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "github.com/acme/client", module)
+	assert.Equal(t, []string{"github.com/acme/proto", "github.com/acme/auth", "golang.org/x/sync"}, requires)
+
+	_, _, err = ParseGoMod([]byte("module\n"))
+	assert.Error(t, err)
+}
+
+func TestParsePackageJSON(t *testing.T) {
+	t.Parallel()
+
+	name, requires, err := ParsePackageJSON([]byte(`{
+		"name": "@acme/web",
+		"dependencies": {"@acme/ui": "^2.0.0", "vue": "^3.4.0"},
+		"devDependencies": {"vite": "^5.0.0"},
+		"peerDependencies": {"@acme/theme": "*"}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, "@acme/web", name)
+	assert.Equal(t, []string{"@acme/theme", "@acme/ui", "vite", "vue"}, requires)
+
+	_, _, err = ParsePackageJSON([]byte(`{"name": `))
+	assert.Error(t, err)
+}
+
+func TestIsManifest(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsManifest("go.mod"))
+	assert.True(t, IsManifest("services/api/go.mod"))
+	assert.True(t, IsManifest("web/package.json"))
+	assert.False(t, IsManifest("go.sum"))
+	assert.False(t, IsManifest("web/node_modules/vue/package.json"))
+	assert.False(t, IsManifest("vendor/github.com/acme/proto/go.mod"))
+	assert.False(t, IsManifest("internal/testdata/go.mod"))
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	m, err := Read("acme/platform", map[string][]byte{
+		"go.mod":           []byte("module github.com/acme/platform\n\nrequire (\n\tgithub.com/acme/proto v1.0.0\n\tgithub.com/acme/platform/sdk v0.1.0\n)\n"),
+		"sdk/go.mod":       []byte("module github.com/acme/platform/sdk\n\nrequire github.com/acme/proto v1.0.0\n"),
+		"web/package.json": []byte(`{"name": "@acme/console", "dependencies": {"@acme/ui": "^1.0.0"}}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "acme/platform", m.Repository)
+	assert.Equal(t, []models.Package{
+		{Name: "github.com/acme/platform", Ecosystem: models.EcosystemGo},
+		{Name: "github.com/acme/platform/sdk", Ecosystem: models.EcosystemGo},
+		{Name: "@acme/console", Ecosystem: models.EcosystemNPM},
+	}, m.Provides)
+	// The monorepo's own modules aren't dependencies
+	assert.Equal(t, []models.Package{
+		{Name: "github.com/acme/proto", Ecosystem: models.EcosystemGo},
+		{Name: "@acme/ui", Ecosystem: models.EcosystemNPM},
+	}, m.Requires)
+
+	_, err = Read("acme/broken", map[string][]byte{"package.json": []byte("{")})
+	assert.ErrorContains(t, err, "package.json")
+}
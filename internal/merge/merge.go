@@ -0,0 +1,732 @@
+package merge
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Result holds the combined metrics of several analysis runs
+type Result struct {
+	Metrics  *models.GlobalMetrics
+	Warnings []string // Data that could not be combined exactly
+}
+
+// Merge combines metrics from separately analyzed runs into one data set.
+//
+// Repositories are combined by full name and contributors by login. Counts are
+// summed, averages are re-weighted by the counts they were taken over and
+// maxima (largest PR, streaks) keep the highest value. Scores, ranks,
+// achievements and the leaderboard are left for the scoring calculator to
+// rebuild from the combined contributors.
+func Merge(runs []*models.GlobalMetrics) *Result {
+	result := &Result{Metrics: &models.GlobalMetrics{}}
+	merged := result.Metrics
+
+	merged.Period = mergePeriods(runs)
+
+	// Repositories
+	seenRepos := make(map[string]bool)
+	for _, run := range runs {
+		for _, repo := range run.Repositories {
+			key := strings.ToLower(repo.FullName)
+			if seenRepos[key] {
+				// Its contributors are counted again by the later run, so totals may be inflated
+				result.Warnings = append(result.Warnings,
+					fmt.Sprintf("repository %s appears in more than one run; keeping the first copy", repo.FullName))
+				continue
+			}
+			seenRepos[key] = true
+			merged.Repositories = append(merged.Repositories, repo)
+		}
+	}
+	sort.SliceStable(merged.Repositories, func(i, j int) bool {
+		return merged.Repositories[i].FullName < merged.Repositories[j].FullName
+	})
+
+	// Contributors
+	contributorMap := make(map[string]*models.ContributorMetrics)
+	var logins []string
+	for _, run := range runs {
+		for _, cm := range run.Contributors {
+			key := strings.ToLower(cm.Login)
+			existing, ok := contributorMap[key]
+			if !ok {
+				c := cm
+				c.RepositoriesContributed = slices.Clone(cm.RepositoriesContributed)
+				c.Rolling = slices.Clone(cm.Rolling)
+				// Custom metrics may be ratios, so they are computed again from
+				// the merged metrics rather than summed
+				c.Custom = nil
+				contributorMap[key] = &c
+				logins = append(logins, key)
+				continue
+			}
+			mergeContributor(existing, &cm)
+		}
+	}
+	maxActiveDays := periodDays(merged.Period)
+	for _, key := range logins {
+		cm := contributorMap[key]
+		cm.Period = merged.Period
+		if maxActiveDays > 0 && cm.ActiveDays > maxActiveDays {
+			cm.ActiveDays = maxActiveDays
+		}
+		if maxActiveDays > 0 && cm.AvailableDays > 0 {
+			cm.AvailableDays = max(maxActiveDays-cm.AbsenceDays, 1)
+			cm.ActivityRate = float64(min(cm.ActiveDays, cm.AvailableDays)) / float64(cm.AvailableDays) * 100
+		}
+		merged.Contributors = append(merged.Contributors, *cm)
+	}
+
+	merged.Teams = mergeTeams(runs, contributorMap, merged.Period)
+	merged.Groups = mergeGroups(runs, merged.Repositories, merged.Period)
+	merged.Dependencies = mergeDependencies(runs)
+	merged.ReleaseNotes = mergeReleaseNotes(runs)
+	for _, run := range runs {
+		merged.ReviewLatency = mergeReviewLatency(merged.ReviewLatency, run.ReviewLatency)
+	}
+
+	// Totals
+	merged.TotalContributors = len(merged.Contributors)
+	for _, repo := range merged.Repositories {
+		merged.TotalCommits += repo.TotalCommits
+		merged.TotalPRs += repo.TotalPRs
+		merged.TotalReviews += repo.TotalReviews
+		merged.TotalLinesAdded += repo.TotalLinesAdded
+		merged.TotalLinesDeleted += repo.TotalLinesDeleted
+		merged.TotalMeaningfulLinesAdded += repo.TotalMeaningfulLinesAdded
+		merged.TotalMeaningfulLinesDeleted += repo.TotalMeaningfulLinesDeleted
+	}
+
+	merged.VelocityTimeline = mergeTimelines(runs, merged.Period)
+
+	return result
+}
+
+// TeamConfigs returns the combined teams as configuration, so the leaderboard
+// can show team membership when the merge config defines no teams
+func (r *Result) TeamConfigs() []config.TeamConfig {
+	teams := make([]config.TeamConfig, 0, len(r.Metrics.Teams))
+	for _, t := range r.Metrics.Teams {
+		teams = append(teams, config.TeamConfig{Name: t.Name, Members: t.Members, Color: t.Color, Capacity: t.Capacity})
+	}
+	return teams
+}
+
+// mergeContributor adds the metrics of src to dst
+func mergeContributor(dst, src *models.ContributorMetrics) {
+	if dst.Name == "" {
+		dst.Name = src.Name
+	}
+	if dst.AvatarURL == "" {
+		dst.AvatarURL = src.AvatarURL
+	}
+
+	// Averages are weighted by the counts they were calculated over
+	dst.AvgPRSize = weightedAverage(dst.AvgPRSize, dst.PRsMerged, src.AvgPRSize, src.PRsMerged)
+	dst.AvgTimeToMerge = weightedAverage(dst.AvgTimeToMerge, dst.PRsMerged, src.AvgTimeToMerge, src.PRsMerged)
+	dst.AvgReviewTime = weightedAverage(dst.AvgReviewTime, dst.ReviewsGiven, src.AvgReviewTime, src.ReviewsGiven)
+	dst.AvgReviewIterations = weightedAverage(dst.AvgReviewIterations, dst.PRsWithReviews, src.AvgReviewIterations, src.PRsWithReviews)
+	dst.AvgTimeInDraft = weightedAverage(dst.AvgTimeInDraft, dst.DraftPRs, src.AvgTimeInDraft, src.DraftPRs)
+	dst.AvgDraftToReady = weightedAverage(dst.AvgDraftToReady, dst.DraftsReady, src.AvgDraftToReady, src.DraftsReady)
+	dst.AvgTimeToClose = weightedAverage(dst.AvgTimeToClose, dst.PRsClosed, src.AvgTimeToClose, src.PRsClosed)
+	dst.LinearLinkageRate = weightedAverage(dst.LinearLinkageRate, dst.PRsOpened, src.LinearLinkageRate, src.PRsOpened)
+	dst.RecencyWeight = weightedAverage(dst.RecencyWeight, dst.ActiveDays, src.RecencyWeight, src.ActiveDays)
+	switch {
+	case dst.MembershipShare == nil:
+		dst.MembershipShare = src.MembershipShare
+	case src.MembershipShare != nil:
+		share := weightedAverage(*dst.MembershipShare, dst.ActiveDays, *src.MembershipShare, src.ActiveDays)
+		dst.MembershipShare = &share
+	}
+
+	dst.CommitCount += src.CommitCount
+	dst.CommitsWithTests += src.CommitsWithTests
+	dst.LinesAdded += src.LinesAdded
+	dst.LinesDeleted += src.LinesDeleted
+	dst.FilesChanged += src.FilesChanged
+	dst.MeaningfulLinesAdded += src.MeaningfulLinesAdded
+	dst.MeaningfulLinesDeleted += src.MeaningfulLinesDeleted
+	dst.CommentLinesAdded += src.CommentLinesAdded
+	dst.CommentLinesDeleted += src.CommentLinesDeleted
+
+	dst.PRsOpened += src.PRsOpened
+	dst.PRsMerged += src.PRsMerged
+	dst.PRsClosed += src.PRsClosed
+	if dst.PRsClosed > 0 {
+		dst.AbandonmentRate = float64(dst.PRsClosed) / float64(dst.PRsMerged+dst.PRsClosed) * 100
+	}
+	dst.LargestPRSize = max(dst.LargestPRSize, src.LargestPRSize)
+	dst.SmallPRCount += src.SmallPRCount
+	dst.PerfectPRs += src.PerfectPRs
+	dst.PRsWithReviews += src.PRsWithReviews
+	dst.DraftPRs += src.DraftPRs
+	dst.DraftsReady += src.DraftsReady
+	dst.DraftsAbandoned += src.DraftsAbandoned
+
+	dst.ReviewsGiven += src.ReviewsGiven
+	dst.ReviewComments += src.ReviewComments
+	dst.ApprovalsGiven += src.ApprovalsGiven
+	dst.ChangesRequested += src.ChangesRequested
+
+	dst.IssuesOpened += src.IssuesOpened
+	dst.IssuesClosed += src.IssuesClosed
+	dst.IssueComments += src.IssueComments
+	dst.IssueReferencesInCommits += src.IssueReferencesInCommits
+	dst.LinkedPRs += src.LinkedPRs
+	if dst.PRsMerged > 0 {
+		dst.IssueTraceability = float64(dst.LinkedPRs) / float64(dst.PRsMerged) * 100
+	}
+
+	dst.LinearIssuesReferenced += src.LinearIssuesReferenced
+	dst.LinearIssuesCompleted += src.LinearIssuesCompleted
+
+	dst.BuildsBroken += src.BuildsBroken
+	dst.BuildsFixed += src.BuildsFixed
+	dst.CoverageImproved += src.CoverageImproved
+	dst.CoverageDelta += src.CoverageDelta
+	dst.TechDebtReduction += src.TechDebtReduction
+	dst.SecurityFixes += src.SecurityFixes
+	dst.HotspotStabilizations += src.HotspotStabilizations
+	dst.RefactoringCommits += src.RefactoringCommits
+	dst.NetLinesRemoved += src.NetLinesRemoved
+	dst.PropagatedPatches += src.PropagatedPatches
+	dst.PatchPropagation += src.PatchPropagation
+	dst.DirectPushes += src.DirectPushes
+	dst.AutoMergesEnabled += src.AutoMergesEnabled
+	dst.EffortPoints += src.EffortPoints
+	dst.KudosReceived += src.KudosReceived
+	dst.KudosGiven += src.KudosGiven
+	dst.ReviewTone = models.AddReviewTone(dst.ReviewTone, src.ReviewTone)
+
+	// Activity days are not stored per day, so overlapping days cannot be
+	// deduplicated; Merge caps the sum at the length of the period
+	dst.ActiveDays += src.ActiveDays
+	dst.CurrentStreak = max(dst.CurrentStreak, src.CurrentStreak)
+	dst.LongestStreak = max(dst.LongestStreak, src.LongestStreak)
+	dst.WorkWeekStreak = max(dst.WorkWeekStreak, src.WorkWeekStreak)
+	// Runs of the same contributor share one calendar, so the larger count
+	// covers the most of it; available days are rebuilt for the merged period
+	dst.AbsenceDays = max(dst.AbsenceDays, src.AbsenceDays)
+	mergeRolling(dst, src)
+	mergeBests(dst, src)
+	dst.EarlyBirdCount += src.EarlyBirdCount
+	dst.NightOwlCount += src.NightOwlCount
+	dst.MidnightCount += src.MidnightCount
+	dst.WeekendWarrior += src.WeekendWarrior
+	dst.OutOfHoursCount += src.OutOfHoursCount
+
+	dst.RegularHoursCount += src.RegularHoursCount
+	dst.EveningCount += src.EveningCount
+	dst.LateNightCount += src.LateNightCount
+	dst.OvernightCount += src.OvernightCount
+	dst.EarlyMorningCount += src.EarlyMorningCount
+
+	for _, r := range src.RepositoriesContributed {
+		if !slices.Contains(dst.RepositoriesContributed, r) {
+			dst.RepositoriesContributed = append(dst.RepositoriesContributed, r)
+		}
+	}
+	dst.UniqueReviewees += src.UniqueReviewees
+}
+
+// mergeRolling combines rolling averages, which cover the last days of each
+// run: runs ending on the same day are summed, otherwise the latest run wins
+func mergeRolling(dst, src *models.ContributorMetrics) {
+	if len(src.Rolling) == 0 {
+		return
+	}
+	dstEnd := dst.Period.End.Format("2006-01-02")
+	srcEnd := src.Period.End.Format("2006-01-02")
+	switch {
+	case len(dst.Rolling) == 0 || srcEnd > dstEnd:
+		dst.Rolling = slices.Clone(src.Rolling)
+		dst.Period = src.Period // Compared with later runs; reset once merged
+	case srcEnd == dstEnd:
+		for i := range dst.Rolling {
+			for _, r := range src.Rolling {
+				if r.WindowDays == dst.Rolling[i].WindowDays {
+					dst.Rolling[i].Commits += r.Commits
+					dst.Rolling[i].PRs += r.PRs
+					dst.Rolling[i].Score += r.Score
+				}
+			}
+		}
+	default:
+		return
+	}
+	dst.Trend = models.NewTrend(dst.Rolling)
+}
+
+// mergeBests keeps the better of each personal best. Runs over different
+// repositories are not summed week by week, so a best week may be understated.
+func mergeBests(dst, src *models.ContributorMetrics) {
+	if src.Bests == nil {
+		return
+	}
+	if dst.Bests == nil {
+		dst.Bests = &models.PersonalBests{}
+	} else {
+		bests := *dst.Bests
+		dst.Bests = &bests // Not shared with the run it was copied from
+	}
+	if w := src.Bests.BestWeek; w != nil && (dst.Bests.BestWeek == nil || w.Score > dst.Bests.BestWeek.Score) {
+		dst.Bests.BestWeek = w
+	}
+	if m := src.Bests.FastestReviewMonth; m != nil && (dst.Bests.FastestReviewMonth == nil || m.AvgReviewTime < dst.Bests.FastestReviewMonth.AvgReviewTime) {
+		dst.Bests.FastestReviewMonth = m
+	}
+}
+
+// weightedAverage combines two averages taken over n1 and n2 samples
+func weightedAverage(avg1 float64, n1 int, avg2 float64, n2 int) float64 {
+	if n1+n2 == 0 {
+		return 0
+	}
+	return (avg1*float64(n1) + avg2*float64(n2)) / float64(n1+n2)
+}
+
+// mergePeriods returns a period covering every run
+func mergePeriods(runs []*models.GlobalMetrics) models.Period {
+	var period models.Period
+	for _, run := range runs {
+		p := run.Period
+		if !p.Start.IsZero() && (period.Start.IsZero() || p.Start.Before(period.Start)) {
+			period.Start = p.Start
+		}
+		if p.End.After(period.End) {
+			period.End = p.End
+		}
+		if period.Granularity == "" {
+			period.Granularity = p.Granularity
+		}
+	}
+
+	// Keep a shared label such as "All Time", otherwise describe the combined range
+	for i, run := range runs {
+		if i == 0 {
+			period.Label = run.Period.Label
+		} else if run.Period.Label != period.Label {
+			period.Label = ""
+			break
+		}
+	}
+	if period.Label == "" && !period.Start.IsZero() && !period.End.IsZero() {
+		period.Label = fmt.Sprintf("%s - %s", period.Start.Format("Jan 2, 2006"), period.End.Format("Jan 2, 2006"))
+	}
+	return period
+}
+
+// periodDays returns the number of calendar days in a period, or 0 if unbounded
+func periodDays(p models.Period) int {
+	if p.Start.IsZero() || p.End.IsZero() || p.End.Before(p.Start) {
+		return 0
+	}
+	return int(p.End.Sub(p.Start).Hours()/24) + 1
+}
+
+// mergeTeams combines teams by name and rebuilds their member metrics from the
+// combined contributors
+func mergeTeams(runs []*models.GlobalMetrics, contributors map[string]*models.ContributorMetrics, period models.Period) []models.TeamMetrics {
+	teamMap := make(map[string]*models.TeamMetrics)
+	var names []string
+	for _, run := range runs {
+		for _, t := range run.Teams {
+			team, ok := teamMap[t.Name]
+			if !ok {
+				team = &models.TeamMetrics{Name: t.Name, Color: t.Color}
+				teamMap[t.Name] = team
+				names = append(names, t.Name)
+			}
+			if team.Color == "" {
+				team.Color = t.Color
+			}
+			if team.Capacity == 0 {
+				team.Capacity = t.Capacity
+			}
+			for _, m := range t.Members {
+				if !slices.Contains(team.Members, m) {
+					team.Members = append(team.Members, m)
+				}
+			}
+			for _, r := range t.Upstream {
+				if !slices.Contains(team.Upstream, r) {
+					team.Upstream = append(team.Upstream, r)
+				}
+			}
+			if t.Ownership != nil {
+				if team.Ownership == nil {
+					team.Ownership = &models.PathOwnershipMetrics{}
+				}
+				mergeOwnership(team.Ownership, t.Ownership)
+			}
+			team.ReviewLatency = mergeReviewLatency(team.ReviewLatency, t.ReviewLatency)
+			team.Timezones = mergeTimezones(team.Timezones, t.Timezones)
+			if t.ServiceAccounts != nil {
+				if team.ServiceAccounts == nil {
+					team.ServiceAccounts = &models.ContributorMetrics{Login: t.ServiceAccounts.Login, Name: t.ServiceAccounts.Name}
+				}
+				sa := team.ServiceAccounts
+				sa.CommitCount += t.ServiceAccounts.CommitCount
+				sa.LinesAdded += t.ServiceAccounts.LinesAdded
+				sa.LinesDeleted += t.ServiceAccounts.LinesDeleted
+				sa.PRsOpened += t.ServiceAccounts.PRsOpened
+				sa.PRsMerged += t.ServiceAccounts.PRsMerged
+				sa.ReviewsGiven += t.ServiceAccounts.ReviewsGiven
+			}
+		}
+	}
+
+	teams := make([]models.TeamMetrics, 0, len(names))
+	for _, name := range names {
+		team := teamMap[name]
+		team.Period = period
+		for _, member := range team.Members {
+			cm, ok := contributors[strings.ToLower(member)]
+			if !ok {
+				continue
+			}
+			team.MemberMetrics = append(team.MemberMetrics, *cm)
+			team.AggregatedMetrics.CommitCount += cm.CommitCount
+			team.AggregatedMetrics.LinesAdded += cm.LinesAdded
+			team.AggregatedMetrics.LinesDeleted += cm.LinesDeleted
+			team.AggregatedMetrics.PRsOpened += cm.PRsOpened
+			team.AggregatedMetrics.PRsMerged += cm.PRsMerged
+			team.AggregatedMetrics.ReviewsGiven += cm.ReviewsGiven
+		}
+		if sa := team.ServiceAccounts; sa != nil {
+			sa.Period = period
+			team.AggregatedMetrics.CommitCount += sa.CommitCount
+			team.AggregatedMetrics.LinesAdded += sa.LinesAdded
+			team.AggregatedMetrics.LinesDeleted += sa.LinesDeleted
+			team.AggregatedMetrics.PRsOpened += sa.PRsOpened
+			team.AggregatedMetrics.PRsMerged += sa.PRsMerged
+			team.AggregatedMetrics.ReviewsGiven += sa.ReviewsGiven
+		}
+		slices.Sort(team.Upstream)
+		teams = append(teams, *team)
+	}
+	return teams
+}
+
+// mergeOwnership adds the activity in a team's owned paths of another run
+func mergeOwnership(dst, src *models.PathOwnershipMetrics) {
+	for _, p := range src.Paths {
+		if !slices.Contains(dst.Paths, p) {
+			dst.Paths = append(dst.Paths, p)
+		}
+	}
+	dst.Commits += src.Commits
+	dst.MemberCommits += src.MemberCommits
+	dst.LinesAdded += src.LinesAdded
+	dst.LinesDeleted += src.LinesDeleted
+	dst.PRsOpened += src.PRsOpened
+	dst.PRsMerged += src.PRsMerged
+	dst.Reviews += src.Reviews
+
+	for _, c := range src.Contributors {
+		i := slices.IndexFunc(dst.Contributors, func(d models.PathContributor) bool { return strings.EqualFold(d.Login, c.Login) })
+		if i < 0 {
+			dst.Contributors = append(dst.Contributors, c)
+			continue
+		}
+		dst.Contributors[i].Commits += c.Commits
+		dst.Contributors[i].Member = dst.Contributors[i].Member || c.Member
+	}
+	sort.SliceStable(dst.Contributors, func(i, j int) bool {
+		if dst.Contributors[i].Commits != dst.Contributors[j].Commits {
+			return dst.Contributors[i].Commits > dst.Contributors[j].Commits
+		}
+		return dst.Contributors[i].Login < dst.Contributors[j].Login
+	})
+}
+
+// mergeReviewLatency adds the review latency of another run to dst, returning
+// the combined latency. Counts add up exactly, but percentiles can't be
+// combined without the response times, so they are averaged weighted by
+// their counts.
+func mergeReviewLatency(dst, src *models.ReviewLatency) *models.ReviewLatency {
+	if src == nil {
+		return dst
+	}
+	merged := *src
+	if dst == nil {
+		return &merged // Not shared with the run it was copied from
+	}
+	merged.Reviews += dst.Reviews
+	merged.Timed += dst.Timed
+	merged.Heatmap = slices.Clone(dst.Heatmap)
+	for _, cell := range src.Heatmap {
+		i := slices.IndexFunc(merged.Heatmap, func(c models.ReviewLatencyCell) bool {
+			return c.Weekday == cell.Weekday && c.Hour == cell.Hour
+		})
+		if i < 0 {
+			merged.Heatmap = append(merged.Heatmap, cell)
+			continue
+		}
+		merged.Heatmap[i].Submitted += cell.Submitted
+		merged.Heatmap[i].Latency = mergeLatencyStats(merged.Heatmap[i].Latency, cell.Latency)
+	}
+	sort.Slice(merged.Heatmap, func(i, j int) bool {
+		if merged.Heatmap[i].Weekday != merged.Heatmap[j].Weekday {
+			return merged.Heatmap[i].Weekday < merged.Heatmap[j].Weekday
+		}
+		return merged.Heatmap[i].Hour < merged.Heatmap[j].Hour
+	})
+	for hour := range merged.Hours {
+		merged.Hours[hour] = mergeLatencyStats(dst.Hours[hour], src.Hours[hour])
+	}
+	merged.Overall = mergeLatencyStats(dst.Overall, src.Overall)
+	return &merged
+}
+
+// mergeLatencyStats combines two response time distributions
+func mergeLatencyStats(a, b models.LatencyStats) models.LatencyStats {
+	return models.LatencyStats{
+		Count:  a.Count + b.Count,
+		Median: weightedAverage(a.Median, a.Count, b.Median, b.Count),
+		P75:    weightedAverage(a.P75, a.Count, b.P75, b.Count),
+		P90:    weightedAverage(a.P90, a.Count, b.P90, b.Count),
+	}
+}
+
+// mergeTimezones adds the cross-timezone reviews of a team in another run to
+// dst, returning the combined collaboration. Members keep the time zone of
+// the first run that knew it.
+func mergeTimezones(dst, src *models.TimezoneCollaboration) *models.TimezoneCollaboration {
+	if src == nil {
+		return dst
+	}
+	merged := &models.TimezoneCollaboration{}
+	if dst != nil {
+		merged.Reviews = dst.Reviews
+		merged.CrossTimezone = dst.CrossTimezone
+		merged.Members = slices.Clone(dst.Members)
+		merged.Unknown = slices.Clone(dst.Unknown)
+		merged.Pairs = slices.Clone(dst.Pairs)
+	}
+	merged.Reviews += src.Reviews
+	merged.CrossTimezone += src.CrossTimezone
+	if merged.Reviews > 0 {
+		merged.Index = float64(merged.CrossTimezone) / float64(merged.Reviews) * 100
+	}
+
+	known := func(login string) bool {
+		return slices.ContainsFunc(merged.Members, func(m models.MemberTimezone) bool { return strings.EqualFold(m.Login, login) })
+	}
+	for _, m := range src.Members {
+		if !known(m.Login) {
+			merged.Members = append(merged.Members, m)
+		}
+	}
+	merged.Unknown = slices.DeleteFunc(merged.Unknown, known)
+	for _, login := range src.Unknown {
+		if !known(login) && !slices.ContainsFunc(merged.Unknown, func(u string) bool { return strings.EqualFold(u, login) }) {
+			merged.Unknown = append(merged.Unknown, login)
+		}
+	}
+	sort.SliceStable(merged.Members, func(i, j int) bool { return merged.Members[i].Offset < merged.Members[j].Offset })
+
+	for _, p := range src.Pairs {
+		i := slices.IndexFunc(merged.Pairs, func(d models.TimezonePair) bool {
+			return strings.EqualFold(d.Reviewer, p.Reviewer) && strings.EqualFold(d.Author, p.Author)
+		})
+		if i < 0 {
+			merged.Pairs = append(merged.Pairs, p)
+			continue
+		}
+		merged.Pairs[i].Reviews += p.Reviews
+	}
+	sort.SliceStable(merged.Pairs, func(i, j int) bool { return merged.Pairs[i].Reviews > merged.Pairs[j].Reviews })
+	return merged
+}
+
+// mergeDependencies combines the dependency graphs of the runs. Packages are
+// only resolved within a run, so repositories analyzed in different runs
+// stay unlinked.
+func mergeDependencies(runs []*models.GlobalMetrics) *models.DependencyGraph {
+	var graph *models.DependencyGraph
+	nodes := make(map[string]bool)
+	edges := make(map[string]bool)
+	for _, run := range runs {
+		if run.Dependencies == nil {
+			continue
+		}
+		if graph == nil {
+			graph = &models.DependencyGraph{Nodes: []models.DependencyNode{}, Edges: []models.DependencyEdge{}}
+		}
+		for _, n := range run.Dependencies.Nodes {
+			if key := strings.ToLower(n.Repository); !nodes[key] {
+				nodes[key] = true
+				graph.Nodes = append(graph.Nodes, n)
+			}
+		}
+		for _, e := range run.Dependencies.Edges {
+			if key := strings.ToLower(e.From + " " + e.To); !edges[key] {
+				edges[key] = true
+				graph.Edges = append(graph.Edges, e)
+			}
+		}
+	}
+	if graph != nil {
+		sort.SliceStable(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].Repository < graph.Nodes[j].Repository })
+		sort.SliceStable(graph.Edges, func(i, j int) bool {
+			if graph.Edges[i].From != graph.Edges[j].From {
+				return graph.Edges[i].From < graph.Edges[j].From
+			}
+			return graph.Edges[i].To < graph.Edges[j].To
+		})
+	}
+	return graph
+}
+
+// mergeReleaseNotes combines the release notes of the runs, listing PRs
+// present in several runs once
+func mergeReleaseNotes(runs []*models.GlobalMetrics) *models.ReleaseNotes {
+	var entries map[string][]models.ReleaseEntry
+	seen := make(map[string]bool) // repository#number
+	for _, run := range runs {
+		if run.ReleaseNotes == nil {
+			continue
+		}
+		if entries == nil {
+			entries = make(map[string][]models.ReleaseEntry)
+		}
+		for _, section := range run.ReleaseNotes.Sections {
+			for _, e := range section.Entries {
+				key := fmt.Sprintf("%s#%d", strings.ToLower(e.Repository), e.Number)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				entries[section.Type] = append(entries[section.Type], e)
+			}
+		}
+	}
+	if entries == nil {
+		return nil
+	}
+	return models.NewReleaseNotes(entries)
+}
+
+// mergeGroups combines groups by name and rolls their totals up again from
+// the combined repositories; the leaderboards are left to scoring
+func mergeGroups(runs []*models.GlobalMetrics, repositories []models.RepositoryMetrics, period models.Period) []models.GroupMetrics {
+	members := make(map[string]map[string]bool) // Group name -> lowercase repository names
+	var groups []models.GroupMetrics
+	for _, run := range runs {
+		for _, g := range run.Groups {
+			repos, ok := members[g.Name]
+			if !ok {
+				repos = make(map[string]bool)
+				members[g.Name] = repos
+				groups = append(groups, models.GroupMetrics{Name: g.Name, Color: g.Color})
+			}
+			for _, r := range g.Repositories {
+				repos[strings.ToLower(r)] = true
+			}
+		}
+	}
+
+	for i := range groups {
+		group := &groups[i]
+		group.Repositories = []string{}
+		group.Period = period
+		contributors := make(map[string]bool)
+		for _, rm := range repositories {
+			if !members[group.Name][strings.ToLower(rm.FullName)] {
+				continue
+			}
+			group.Repositories = append(group.Repositories, rm.FullName)
+			group.TotalCommits += rm.TotalCommits
+			group.TotalPRs += rm.TotalPRs
+			group.TotalReviews += rm.TotalReviews
+			group.TotalLinesAdded += rm.TotalLinesAdded
+			group.TotalLinesDeleted += rm.TotalLinesDeleted
+			for _, c := range rm.Contributors {
+				contributors[strings.ToLower(c.Login)] = true
+			}
+		}
+		group.ActiveContributors = len(contributors)
+	}
+	return groups
+}
+
+// mergeTimelines sums weekly velocity series on a week grid covering the
+// combined period. Each run's weeks are located from its own period start,
+// which is how the aggregator lays them out.
+func mergeTimelines(runs []*models.GlobalMetrics, period models.Period) *models.VelocityTimeline {
+	if period.Start.IsZero() {
+		return nil
+	}
+	origin := weekStart(period.Start)
+
+	var timeline *models.VelocityTimeline
+	seriesIndex := make(map[string]int)
+	for _, run := range runs {
+		if run.VelocityTimeline == nil || run.Period.Start.IsZero() {
+			continue
+		}
+		if timeline == nil {
+			timeline = &models.VelocityTimeline{}
+		}
+		// Rounded, since a daylight saving change shifts the difference by an hour
+		offset := int(math.Round(weekStart(run.Period.Start).Sub(origin).Hours() / (24 * 7)))
+
+		for _, s := range run.VelocityTimeline.Series {
+			idx, ok := seriesIndex[s.Name]
+			if !ok {
+				idx = len(timeline.Series)
+				seriesIndex[s.Name] = idx
+				timeline.Series = append(timeline.Series, models.VelocityTimelineSeries{Name: s.Name, Color: s.Color})
+			}
+			series := &timeline.Series[idx]
+			for i, v := range s.Data {
+				week := offset + i
+				if week < 0 {
+					continue
+				}
+				for len(series.Data) <= week {
+					series.Data = append(series.Data, 0)
+				}
+				series.Data[week] += v
+			}
+		}
+	}
+	if timeline == nil {
+		return nil
+	}
+
+	// Pad every series to the same length and label the weeks
+	weeks := 0
+	for _, s := range timeline.Series {
+		weeks = max(weeks, len(s.Data))
+	}
+	for i := range timeline.Series {
+		for len(timeline.Series[i].Data) < weeks {
+			timeline.Series[i].Data = append(timeline.Series[i].Data, 0)
+		}
+	}
+	timeline.Labels = make([]string, weeks)
+	for i := range timeline.Labels {
+		timeline.Labels[i] = origin.AddDate(0, 0, 7*i).Format("Jan 2")
+	}
+	return timeline
+}
+
+// weekStart returns midnight on the Monday of t's week
+func weekStart(t time.Time) time.Time {
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	d := t.AddDate(0, 0, -(weekday - 1))
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+}
@@ -0,0 +1,414 @@
+package merge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func platformRun() *models.GlobalMetrics {
+	return &models.GlobalMetrics{
+		Period: models.Period{
+			Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), // Monday
+			End:   time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			Label: "All Time",
+		},
+		Repositories: []models.RepositoryMetrics{
+			{FullName: "platform/api", TotalCommits: 10, TotalPRs: 4, TotalLinesAdded: 100},
+		},
+		Contributors: []models.ContributorMetrics{
+			{
+				Login: "alice", Name: "Alice", CommitCount: 8, PRsOpened: 3, PRsMerged: 2,
+				AvgPRSize: 100, AvgTimeToMerge: 10, LargestPRSize: 150, ActiveDays: 20, LongestStreak: 5,
+				DraftPRs: 1, DraftsReady: 1, AvgTimeInDraft: 6, AvgDraftToReady: 6,
+				PRsClosed: 1, AbandonmentRate: 100.0 / 3, AvgTimeToClose: 4,
+				LinkedPRs: 2, IssueTraceability: 100,
+				KudosReceived: 4, KudosGiven: 1,
+				ReviewTone:              &models.ReviewTone{Comments: 2, Sentences: 4, Questions: 1, Imperatives: 1},
+				RepositoriesContributed: []string{"platform/api"},
+				Custom:                  map[string]float64{"review_ratio": 0.5},
+				Score:                   models.Score{Total: 500, Rank: 1},
+			},
+			{Login: "bob", CommitCount: 2, ReviewsGiven: 4, AvgReviewTime: 2},
+		},
+		Teams: []models.TeamMetrics{
+			{Name: "Platform", Color: "#123456", Members: []string{"alice"}},
+		},
+		VelocityTimeline: &models.VelocityTimeline{
+			Labels: []string{"Jan 1", "Jan 8"},
+			Series: []models.VelocityTimelineSeries{{Name: "Commits", Data: []float64{6, 4}}},
+		},
+	}
+}
+
+func mobileRun() *models.GlobalMetrics {
+	return &models.GlobalMetrics{
+		Period: models.Period{
+			Start: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), // Wednesday of the second week
+			End:   time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+			Label: "All Time",
+		},
+		Repositories: []models.RepositoryMetrics{
+			{FullName: "mobile/app", TotalCommits: 5, TotalPRs: 2, TotalLinesAdded: 50},
+			{FullName: "Platform/API", TotalCommits: 99},
+		},
+		Contributors: []models.ContributorMetrics{
+			{
+				Login: "Alice", AvatarURL: "https://example.com/alice.png", CommitCount: 4, PRsOpened: 2, PRsMerged: 2,
+				AvgPRSize: 50, AvgTimeToMerge: 20, LargestPRSize: 300, ActiveDays: 30, LongestStreak: 3,
+				DraftPRs: 2, DraftsAbandoned: 1, AvgTimeInDraft: 12,
+				PRsClosed: 3, AbandonmentRate: 60, AvgTimeToClose: 8,
+				LinkedPRs: 1, IssueTraceability: 50,
+				KudosReceived:           2,
+				ReviewTone:              &models.ReviewTone{Comments: 2, Sentences: 4, Questions: 2, Imperatives: 3, WithEmoji: 1},
+				RepositoriesContributed: []string{"mobile/app"},
+			},
+			{Login: "carol", CommitCount: 1},
+		},
+		Teams: []models.TeamMetrics{
+			{Name: "Platform", Members: []string{"carol"}},
+			{Name: "Mobile", Members: []string{"Alice"}},
+		},
+		VelocityTimeline: &models.VelocityTimeline{
+			Labels: []string{"Jan 8", "Jan 15"},
+			Series: []models.VelocityTimelineSeries{
+				{Name: "Commits", Data: []float64{1, 3}},
+				{Name: "PRs", Data: []float64{2, 0}},
+			},
+		},
+	}
+}
+
+func TestMerge_Contributors(t *testing.T) {
+	t.Parallel()
+
+	result := Merge([]*models.GlobalMetrics{platformRun(), mobileRun()})
+	metrics := result.Metrics
+
+	require.Len(t, metrics.Contributors, 3)
+	assert.Equal(t, 3, metrics.TotalContributors)
+
+	alice := metrics.Contributors[0]
+	assert.Equal(t, "alice", alice.Login)
+	assert.Equal(t, "Alice", alice.Name)
+	assert.Equal(t, "https://example.com/alice.png", alice.AvatarURL)
+	assert.Equal(t, 12, alice.CommitCount)
+	assert.Equal(t, 5, alice.PRsOpened)
+	assert.Equal(t, 4, alice.PRsMerged)
+	assert.InDelta(t, 75.0, alice.AvgPRSize, 0.001)
+	assert.InDelta(t, 15.0, alice.AvgTimeToMerge, 0.001)
+	assert.Equal(t, 3, alice.DraftPRs)
+	assert.Equal(t, 1, alice.DraftsAbandoned)
+	assert.InDelta(t, 10.0, alice.AvgTimeInDraft, 0.001)
+	assert.InDelta(t, 6.0, alice.AvgDraftToReady, 0.001)
+	assert.Equal(t, 4, alice.PRsClosed)
+	assert.InDelta(t, 50.0, alice.AbandonmentRate, 0.001)
+	assert.InDelta(t, 7.0, alice.AvgTimeToClose, 0.001)
+	assert.Equal(t, 3, alice.LinkedPRs)
+	assert.InDelta(t, 75.0, alice.IssueTraceability, 0.001)
+	assert.Equal(t, 6, alice.KudosReceived)
+	assert.Equal(t, 1, alice.KudosGiven)
+	require.NotNil(t, alice.ReviewTone)
+	assert.InDelta(t, 75.0, alice.ReviewTone.QuestionRatio, 0.001)
+	assert.InDelta(t, 50.0, alice.ReviewTone.ImperativeDensity, 0.001)
+	assert.InDelta(t, 25.0, alice.ReviewTone.EmojiRatio, 0.001)
+	assert.Equal(t, 300, alice.LargestPRSize)
+	assert.Equal(t, 5, alice.LongestStreak)
+	assert.Equal(t, 46, alice.ActiveDays, "active days are capped at the combined period length")
+	assert.Equal(t, []string{"platform/api", "mobile/app"}, alice.RepositoriesContributed)
+	assert.Equal(t, metrics.Period, alice.Period)
+	assert.Nil(t, alice.Custom, "custom metrics are computed again")
+
+	bob := metrics.Contributors[1]
+	assert.InDelta(t, 2.0, bob.AvgReviewTime, 0.001)
+}
+
+func TestMerge_RepositoriesAndTotals(t *testing.T) {
+	t.Parallel()
+
+	result := Merge([]*models.GlobalMetrics{platformRun(), mobileRun()})
+	metrics := result.Metrics
+
+	require.Len(t, metrics.Repositories, 2)
+	assert.Equal(t, "mobile/app", metrics.Repositories[0].FullName)
+	assert.Equal(t, "platform/api", metrics.Repositories[1].FullName)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "Platform/API")
+
+	assert.Equal(t, 15, metrics.TotalCommits)
+	assert.Equal(t, 6, metrics.TotalPRs)
+	assert.Equal(t, 150, metrics.TotalLinesAdded)
+
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), metrics.Period.Start)
+	assert.Equal(t, time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), metrics.Period.End)
+	assert.Equal(t, "All Time", metrics.Period.Label)
+}
+
+func TestMerge_Teams(t *testing.T) {
+	t.Parallel()
+
+	result := Merge([]*models.GlobalMetrics{platformRun(), mobileRun()})
+	teams := result.Metrics.Teams
+
+	require.Len(t, teams, 2)
+	assert.Equal(t, "Platform", teams[0].Name)
+	assert.Equal(t, "#123456", teams[0].Color)
+	assert.Equal(t, []string{"alice", "carol"}, teams[0].Members)
+	require.Len(t, teams[0].MemberMetrics, 2)
+	assert.Equal(t, 13, teams[0].AggregatedMetrics.CommitCount)
+
+	assert.Equal(t, "Mobile", teams[1].Name)
+	require.Len(t, teams[1].MemberMetrics, 1)
+	assert.Equal(t, 12, teams[1].MemberMetrics[0].CommitCount)
+
+	configs := result.TeamConfigs()
+	require.Len(t, configs, 2)
+	assert.Equal(t, "Platform", configs[0].Name)
+	assert.Equal(t, []string{"alice", "carol"}, configs[0].Members)
+}
+
+func TestMerge_Groups(t *testing.T) {
+	t.Parallel()
+
+	platform := platformRun()
+	platform.Groups = []models.GroupMetrics{{Name: "Core", Color: "#10b981", Repositories: []string{"platform/api"}}}
+	mobile := mobileRun()
+	mobile.Groups = []models.GroupMetrics{{Name: "Core", Repositories: []string{"mobile/app", "Platform/API"}}}
+
+	groups := Merge([]*models.GlobalMetrics{platform, mobile}).Metrics.Groups
+
+	require.Len(t, groups, 1)
+	assert.Equal(t, "#10b981", groups[0].Color)
+	assert.Equal(t, []string{"mobile/app", "platform/api"}, groups[0].Repositories, "repositories are combined case-insensitively")
+	assert.Equal(t, 15, groups[0].TotalCommits, "totals come from the combined repositories")
+	assert.Equal(t, 150, groups[0].TotalLinesAdded)
+}
+
+func TestMerge_Dependencies(t *testing.T) {
+	t.Parallel()
+
+	platform := platformRun()
+	platform.Dependencies = &models.DependencyGraph{
+		Nodes: []models.DependencyNode{{Repository: "platform/proto", Team: "Platform"}, {Repository: "platform/api", Team: "Platform"}},
+		Edges: []models.DependencyEdge{{From: "platform/api", To: "platform/proto"}},
+	}
+	platform.Teams[0].Upstream = []string{"platform/proto"}
+	mobile := mobileRun()
+	mobile.Dependencies = &models.DependencyGraph{
+		Nodes: []models.DependencyNode{{Repository: "mobile/app", Team: "Mobile"}, {Repository: "platform/api", Team: "Platform"}},
+		Edges: []models.DependencyEdge{{From: "mobile/app", To: "platform/api", CrossTeam: true}, {From: "platform/api", To: "platform/proto"}},
+	}
+
+	merged := Merge([]*models.GlobalMetrics{platform, mobile, mobileRun()}).Metrics
+	graph := merged.Dependencies
+	require.NotNil(t, graph)
+	assert.Equal(t, []models.DependencyNode{
+		{Repository: "mobile/app", Team: "Mobile"},
+		{Repository: "platform/api", Team: "Platform"},
+		{Repository: "platform/proto", Team: "Platform"},
+	}, graph.Nodes)
+	assert.Equal(t, []models.DependencyEdge{
+		{From: "mobile/app", To: "platform/api", CrossTeam: true},
+		{From: "platform/api", To: "platform/proto"},
+	}, graph.Edges, "edges are combined once")
+	assert.Equal(t, []string{"platform/proto"}, merged.Teams[0].Upstream)
+
+	assert.Nil(t, Merge([]*models.GlobalMetrics{platformRun()}).Metrics.Dependencies)
+}
+
+func TestMerge_ReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	merged := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	fix := models.ReleaseEntry{Repository: "platform/api", Number: 7, Title: "Retry on timeouts", Author: "bob", MergedAt: merged}
+	platform := platformRun()
+	platform.ReleaseNotes = &models.ReleaseNotes{Sections: []models.ReleaseSection{
+		{Type: models.ChangeFix, Entries: []models.ReleaseEntry{fix}},
+	}}
+	mobile := mobileRun()
+	mobile.ReleaseNotes = &models.ReleaseNotes{Sections: []models.ReleaseSection{
+		{Type: models.ChangeFeature, Entries: []models.ReleaseEntry{{Repository: "mobile/app", Number: 3, Title: "Dark mode", Author: "alice", MergedAt: merged}}},
+		{Type: models.ChangeFix, Entries: []models.ReleaseEntry{fix}},
+	}}
+
+	notes := Merge([]*models.GlobalMetrics{platform, mobile}).Metrics.ReleaseNotes
+	require.NotNil(t, notes)
+	require.Len(t, notes.Sections, 2)
+	assert.Equal(t, models.ChangeFeature, notes.Sections[0].Type)
+	assert.Equal(t, []models.ReleaseEntry{fix}, notes.Sections[1].Entries, "PRs are listed once")
+	assert.Equal(t, []models.ReleaseAuthor{
+		{Login: "alice", PRs: 1, Types: map[string]int{models.ChangeFeature: 1}},
+		{Login: "bob", PRs: 1, Types: map[string]int{models.ChangeFix: 1}},
+	}, notes.Authors)
+
+	assert.Nil(t, Merge([]*models.GlobalMetrics{platformRun()}).Metrics.ReleaseNotes)
+}
+
+func TestMerge_PathOwnership(t *testing.T) {
+	t.Parallel()
+
+	jan := platformRun()
+	jan.Teams[0].Ownership = &models.PathOwnershipMetrics{
+		Paths: []string{"services/api"}, Commits: 3, MemberCommits: 2, LinesAdded: 30, PRsMerged: 1,
+		Contributors: []models.PathContributor{{Login: "alice", Commits: 2, Member: true}, {Login: "dave", Commits: 1}},
+	}
+	feb := platformRun()
+	feb.Repositories = nil
+	feb.Teams[0].Ownership = &models.PathOwnershipMetrics{
+		Paths: []string{"services/api", "infra"}, Commits: 4, LinesAdded: 5, Reviews: 2,
+		Contributors: []models.PathContributor{{Login: "Dave", Commits: 4}},
+	}
+
+	ownership := Merge([]*models.GlobalMetrics{jan, feb}).Metrics.Teams[0].Ownership
+	require.NotNil(t, ownership)
+	assert.Equal(t, []string{"services/api", "infra"}, ownership.Paths)
+	assert.Equal(t, 7, ownership.Commits)
+	assert.Equal(t, 2, ownership.MemberCommits)
+	assert.Equal(t, 35, ownership.LinesAdded)
+	assert.Equal(t, 1, ownership.PRsMerged)
+	assert.Equal(t, 2, ownership.Reviews)
+	assert.Equal(t, []models.PathContributor{{Login: "dave", Commits: 5}, {Login: "alice", Commits: 2, Member: true}}, ownership.Contributors)
+}
+
+func TestMerge_ReviewLatency(t *testing.T) {
+	t.Parallel()
+
+	stats := func(count int, median float64) models.LatencyStats {
+		return models.LatencyStats{Count: count, Median: median, P75: median, P90: median}
+	}
+	jan := platformRun()
+	jan.ReviewLatency = &models.ReviewLatency{
+		Reviews: 3, Timed: 3,
+		Heatmap: []models.ReviewLatencyCell{{Weekday: 0, Hour: 9, Submitted: 3, Latency: stats(3, 2)}},
+		Overall: stats(3, 2),
+	}
+	jan.ReviewLatency.Hours[9] = stats(3, 2)
+	jan.Teams[0].ReviewLatency = jan.ReviewLatency
+	feb := mobileRun()
+	feb.ReviewLatency = &models.ReviewLatency{
+		Reviews: 2, Timed: 1,
+		Heatmap: []models.ReviewLatencyCell{
+			{Weekday: 4, Hour: 16, Submitted: 1},
+			{Weekday: 0, Hour: 9, Submitted: 1, Latency: stats(1, 10)},
+		},
+		Overall: stats(1, 10),
+	}
+	feb.ReviewLatency.Hours[9] = stats(1, 10)
+
+	merged := Merge([]*models.GlobalMetrics{jan, feb}).Metrics
+	latency := merged.ReviewLatency
+	require.NotNil(t, latency)
+	assert.Equal(t, 5, latency.Reviews)
+	assert.Equal(t, 4, latency.Timed)
+	assert.Equal(t, []models.ReviewLatencyCell{
+		{Weekday: 0, Hour: 9, Submitted: 4, Latency: stats(4, 4)},
+		{Weekday: 4, Hour: 16, Submitted: 1},
+	}, latency.Heatmap)
+	assert.Equal(t, stats(4, 4), latency.Hours[9], "percentiles are weighted by their counts")
+	assert.Equal(t, stats(4, 4), latency.Overall)
+	assert.Equal(t, 3, jan.ReviewLatency.Reviews, "the runs are left as they were")
+	assert.Len(t, jan.ReviewLatency.Heatmap, 1)
+
+	require.NotNil(t, merged.Teams[0].ReviewLatency)
+	assert.NotSame(t, jan.ReviewLatency, merged.Teams[0].ReviewLatency)
+	assert.Equal(t, 3, merged.Teams[0].ReviewLatency.Reviews)
+}
+
+func TestMerge_Timezones(t *testing.T) {
+	t.Parallel()
+
+	jan := platformRun()
+	jan.Teams[0].Timezones = &models.TimezoneCollaboration{
+		Reviews: 4, CrossTimezone: 1, Index: 25,
+		Members: []models.MemberTimezone{{Login: "alice", Offset: 2, Inferred: true}},
+		Unknown: []string{"bob"},
+		Pairs:   []models.TimezonePair{{Reviewer: "carol", Author: "alice", Reviews: 1, Hours: 6}},
+	}
+	feb := mobileRun()
+	feb.Teams[0].Timezones = &models.TimezoneCollaboration{
+		Reviews: 6, CrossTimezone: 4, Index: 200.0 / 3,
+		Members: []models.MemberTimezone{{Login: "Alice", Offset: 1, Inferred: true}, {Login: "bob", Offset: -5, Zone: "America/New_York"}},
+		Pairs: []models.TimezonePair{
+			{Reviewer: "dave", Author: "bob", Reviews: 2, Hours: 7},
+			{Reviewer: "Carol", Author: "alice", Reviews: 2, Hours: 6},
+		},
+	}
+
+	timezones := Merge([]*models.GlobalMetrics{jan, feb}).Metrics.Teams[0].Timezones
+	require.NotNil(t, timezones)
+	assert.Equal(t, 10, timezones.Reviews)
+	assert.Equal(t, 5, timezones.CrossTimezone)
+	assert.InDelta(t, 50, timezones.Index, 1e-9)
+	assert.Equal(t, []models.MemberTimezone{
+		{Login: "bob", Offset: -5, Zone: "America/New_York"},
+		{Login: "alice", Offset: 2, Inferred: true},
+	}, timezones.Members)
+	assert.Empty(t, timezones.Unknown, "bob's zone became known")
+	assert.Equal(t, []models.TimezonePair{
+		{Reviewer: "carol", Author: "alice", Reviews: 3, Hours: 6},
+		{Reviewer: "dave", Author: "bob", Reviews: 2, Hours: 7},
+	}, timezones.Pairs)
+	assert.Equal(t, 1, jan.Teams[0].Timezones.Pairs[0].Reviews, "the runs are left as they were")
+}
+
+func TestMerge_VelocityTimeline(t *testing.T) {
+	t.Parallel()
+
+	timeline := Merge([]*models.GlobalMetrics{platformRun(), mobileRun()}).Metrics.VelocityTimeline
+
+	require.NotNil(t, timeline)
+	assert.Equal(t, []string{"Jan 1", "Jan 8", "Jan 15"}, timeline.Labels)
+	require.Len(t, timeline.Series, 2)
+	assert.Equal(t, "Commits", timeline.Series[0].Name)
+	assert.Equal(t, []float64{6, 5, 3}, timeline.Series[0].Data)
+	assert.Equal(t, "PRs", timeline.Series[1].Name)
+	assert.Equal(t, []float64{0, 2, 0}, timeline.Series[1].Data)
+}
+
+func TestMerge_PeriodLabel(t *testing.T) {
+	t.Parallel()
+
+	a := platformRun()
+	b := mobileRun()
+	b.Period.Label = "Q1"
+
+	period := Merge([]*models.GlobalMetrics{a, b}).Metrics.Period
+	assert.Equal(t, "Jan 1, 2024 - Feb 15, 2024", period.Label)
+}
+
+func TestMerge_Rolling(t *testing.T) {
+	t.Parallel()
+
+	jan := models.Period{End: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}
+	feb := models.Period{End: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)}
+	rolling := func(short, long float64) []models.RollingAverage {
+		return []models.RollingAverage{{WindowDays: 7, Score: short}, {WindowDays: 30, Score: long}}
+	}
+	run := func(login string, period models.Period, r []models.RollingAverage) *models.GlobalMetrics {
+		return &models.GlobalMetrics{
+			Period:       period,
+			Contributors: []models.ContributorMetrics{{Login: login, Period: period, Rolling: r, Trend: models.NewTrend(r)}},
+		}
+	}
+
+	// Runs ending on the same day are summed
+	first := run("alice", jan, rolling(10, 10))
+	merged := Merge([]*models.GlobalMetrics{first, run("Alice", jan, rolling(20, 5))}).Metrics
+	assert.Equal(t, rolling(30, 15), merged.Contributors[0].Rolling)
+	assert.Equal(t, &models.Trend{Direction: models.TrendUp, Change: 100}, merged.Contributors[0].Trend)
+	assert.Equal(t, rolling(10, 10), first.Contributors[0].Rolling, "input runs are not modified")
+
+	// Otherwise the run ending last wins, in either order
+	for _, runs := range [][]*models.GlobalMetrics{
+		{run("bob", jan, rolling(50, 10)), run("bob", feb, rolling(1, 2))},
+		{run("bob", feb, rolling(1, 2)), run("bob", jan, rolling(50, 10))},
+	} {
+		bob := Merge(runs).Metrics.Contributors[0]
+		assert.Equal(t, rolling(1, 2), bob.Rolling)
+		assert.Equal(t, models.TrendDown, bob.Trend.Direction)
+	}
+}
@@ -0,0 +1,314 @@
+// Package metricexpr evaluates the expressions of custom metrics: arithmetic
+// over the numeric contributor metrics, named by their JSON keys.
+//
+//	reviews_given / max(prs_merged, 1)
+//	(lines_added - lines_deleted) * (commits_with_tests > 0)
+//
+// Every value is a number. Comparisons and the logical operators yield 1 for
+// true and 0 for false, and a division by zero yields 0 so that a ratio of a
+// contributor without activity is 0 rather than infinite.
+package metricexpr
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// functions are the functions expressions may call, by name
+var functions = map[string]struct {
+	args int // Required arguments, or -1 for one or more
+	call func(args []float64) float64
+}{
+	"min": {-1, func(args []float64) float64 {
+		v := args[0]
+		for _, a := range args[1:] {
+			v = math.Min(v, a)
+		}
+		return v
+	}},
+	"max": {-1, func(args []float64) float64 {
+		v := args[0]
+		for _, a := range args[1:] {
+			v = math.Max(v, a)
+		}
+		return v
+	}},
+	"abs":   {1, func(args []float64) float64 { return math.Abs(args[0]) }},
+	"round": {1, func(args []float64) float64 { return math.Round(args[0]) }},
+	"floor": {1, func(args []float64) float64 { return math.Floor(args[0]) }},
+	"ceil":  {1, func(args []float64) float64 { return math.Ceil(args[0]) }},
+	"sqrt":  {1, func(args []float64) float64 { return math.Sqrt(math.Max(args[0], 0)) }},
+	"when": {3, func(args []float64) float64 {
+		if args[0] != 0 {
+			return args[1]
+		}
+		return args[2]
+	}},
+}
+
+// Expression is a compiled custom metric expression
+type Expression struct {
+	source string
+	root   ast.Expr
+	vars   []string
+}
+
+// Compile parses an expression, rejecting any syntax beyond numbers,
+// variables, arithmetic, comparisons, logical operators and calls of the
+// supported functions
+func Compile(source string) (*Expression, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+	root, err := parser.ParseExpr(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", source, err)
+	}
+	vars := make(map[string]bool)
+	if err := check(root, vars); err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", source, err)
+	}
+	e := &Expression{source: source, root: root}
+	for name := range vars {
+		e.vars = append(e.vars, name)
+	}
+	sort.Strings(e.vars)
+	return e, nil
+}
+
+// String returns the source of the expression
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Vars returns the variables the expression refers to, sorted
+func (e *Expression) Vars() []string {
+	return e.vars
+}
+
+// check walks an expression, rejecting unsupported syntax and collecting
+// the variables it refers to
+func check(node ast.Expr, vars map[string]bool) error {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		if n.Kind != token.INT && n.Kind != token.FLOAT {
+			return fmt.Errorf("unsupported literal %s", n.Value)
+		}
+		if _, err := strconv.ParseFloat(n.Value, 64); err != nil {
+			return fmt.Errorf("invalid number %s", n.Value)
+		}
+	case *ast.Ident:
+		if n.Name != "true" && n.Name != "false" {
+			vars[n.Name] = true
+		}
+	case *ast.ParenExpr:
+		return check(n.X, vars)
+	case *ast.UnaryExpr:
+		switch n.Op {
+		case token.ADD, token.SUB, token.NOT:
+		default:
+			return fmt.Errorf("unsupported operator %s", n.Op)
+		}
+		return check(n.X, vars)
+	case *ast.BinaryExpr:
+		switch n.Op {
+		case token.ADD, token.SUB, token.MUL, token.QUO, token.REM,
+			token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ,
+			token.LAND, token.LOR:
+		default:
+			return fmt.Errorf("unsupported operator %s", n.Op)
+		}
+		if err := check(n.X, vars); err != nil {
+			return err
+		}
+		return check(n.Y, vars)
+	case *ast.CallExpr:
+		ident, ok := n.Fun.(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("unsupported call")
+		}
+		fn, ok := functions[ident.Name]
+		if !ok {
+			return fmt.Errorf("unknown function %s", ident.Name)
+		}
+		if n.Ellipsis.IsValid() {
+			return fmt.Errorf("unsupported call of %s", ident.Name)
+		}
+		if (fn.args < 0 && len(n.Args) == 0) || (fn.args >= 0 && len(n.Args) != fn.args) {
+			return fmt.Errorf("wrong number of arguments to %s", ident.Name)
+		}
+		for _, arg := range n.Args {
+			if err := check(arg, vars); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported syntax")
+	}
+	return nil
+}
+
+// Eval evaluates the expression, variables missing from vars being 0
+func (e *Expression) Eval(vars map[string]float64) float64 {
+	v := eval(e.root, vars)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
+
+func eval(node ast.Expr, vars map[string]float64) float64 {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		v, _ := strconv.ParseFloat(n.Value, 64)
+		return v
+	case *ast.Ident:
+		switch n.Name {
+		case "true":
+			return 1
+		case "false":
+			return 0
+		}
+		return vars[n.Name]
+	case *ast.ParenExpr:
+		return eval(n.X, vars)
+	case *ast.UnaryExpr:
+		x := eval(n.X, vars)
+		switch n.Op {
+		case token.SUB:
+			return -x
+		case token.NOT:
+			return truth(x == 0)
+		}
+		return x
+	case *ast.BinaryExpr:
+		x := eval(n.X, vars)
+		// Short-circuit the logical operators
+		switch n.Op {
+		case token.LAND:
+			return truth(x != 0 && eval(n.Y, vars) != 0)
+		case token.LOR:
+			return truth(x != 0 || eval(n.Y, vars) != 0)
+		}
+		y := eval(n.Y, vars)
+		switch n.Op {
+		case token.ADD:
+			return x + y
+		case token.SUB:
+			return x - y
+		case token.MUL:
+			return x * y
+		case token.QUO:
+			if y == 0 {
+				return 0
+			}
+			return x / y
+		case token.REM:
+			if y == 0 {
+				return 0
+			}
+			return math.Mod(x, y)
+		case token.EQL:
+			return truth(x == y)
+		case token.NEQ:
+			return truth(x != y)
+		case token.LSS:
+			return truth(x < y)
+		case token.LEQ:
+			return truth(x <= y)
+		case token.GTR:
+			return truth(x > y)
+		case token.GEQ:
+			return truth(x >= y)
+		}
+	case *ast.CallExpr:
+		args := make([]float64, len(n.Args))
+		for i, arg := range n.Args {
+			args[i] = eval(arg, vars)
+		}
+		return functions[n.Fun.(*ast.Ident).Name].call(args)
+	}
+	return 0
+}
+
+func truth(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// fields maps the JSON keys of the numeric contributor metrics to their
+// struct field indexes
+var fields = func() map[string]int {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(models.ContributorMetrics{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.Int, reflect.Float64, reflect.Bool:
+			fields[name] = i
+		case reflect.Slice:
+			// Achievements are awarded after custom metrics are computed
+			if name == "repositories_contributed" {
+				fields[name] = i
+			}
+		}
+	}
+	return fields
+}()
+
+// Fields returns the names of the contributor metrics expressions may refer
+// to, sorted. Booleans are 1 or 0, and repositories_contributed is the
+// number of repositories.
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsField reports whether name is a contributor metric expressions may refer to
+func IsField(name string) bool {
+	_, ok := fields[name]
+	return ok
+}
+
+// Vars returns the contributor's metrics as expression variables, along
+// with the custom metrics computed so far
+func Vars(cm *models.ContributorMetrics) map[string]float64 {
+	vars := make(map[string]float64, len(fields)+len(cm.Custom))
+	v := reflect.ValueOf(cm).Elem()
+	for name, i := range fields {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Int:
+			vars[name] = float64(f.Int())
+		case reflect.Float64:
+			vars[name] = f.Float()
+		case reflect.Bool:
+			vars[name] = truth(f.Bool())
+		case reflect.Slice:
+			vars[name] = float64(f.Len())
+		}
+	}
+	for name, value := range cm.Custom {
+		vars[name] = value
+	}
+	return vars
+}
@@ -0,0 +1,114 @@
+package metricexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestExpression_Eval(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]float64{"a": 6, "b": 4, "zero": 0}
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"a + b * 2", 14},
+		{"(a + b) * 2", 20},
+		{"a / b", 1.5},
+		{"a % b", 2},
+		{"-a + 1.5", -4.5},
+		{"a / zero", 0},
+		{"a % zero", 0},
+		{"a > b", 1},
+		{"a <= b", 0},
+		{"a == 6 && b != 4", 0},
+		{"a == 6 || b != 4", 1},
+		{"!zero", 1},
+		{"true + false", 1},
+		{"min(a, b, 5)", 4},
+		{"max(a, b)", 6},
+		{"abs(b - a)", 2},
+		{"round(a / b)", 2},
+		{"floor(a / b)", 1},
+		{"ceil(b / a)", 1},
+		{"sqrt(b)", 2},
+		{"sqrt(-b)", 0},
+		{"when(a > b, a, b)", 6},
+		{"when(zero, a, b)", 4},
+		{"missing * 2", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+
+			expr, err := Compile(tt.expr)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, expr.Eval(vars), 0.0001)
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, source := range []string{
+		"",
+		"a +",
+		`"text"`,
+		"a.b",
+		"a[0]",
+		"a & b",
+		"a << 1",
+		"pow(a, 2)",
+		"min()",
+		"abs(a, b)",
+		"when(a, b)",
+		"fn(a)(b)",
+		"min(a...)",
+	} {
+		_, err := Compile(source)
+		assert.Error(t, err, source)
+	}
+}
+
+func TestExpression_Vars(t *testing.T) {
+	t.Parallel()
+
+	expr, err := Compile("reviews_given / max(prs_merged, 1) + reviews_given * true")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prs_merged", "reviews_given"}, expr.Vars())
+	assert.Equal(t, "reviews_given / max(prs_merged, 1) + reviews_given * true", expr.String())
+}
+
+func TestVars(t *testing.T) {
+	t.Parallel()
+
+	cm := &models.ContributorMetrics{
+		Login:                   "alice",
+		CommitCount:             12,
+		AvgReviewTime:           2.5,
+		External:                true,
+		RepositoriesContributed: []string{"acme/api", "acme/web"},
+		Achievements:            []string{"first-commit"},
+		Custom:                  map[string]float64{"review_ratio": 0.5},
+	}
+	vars := Vars(cm)
+	assert.Equal(t, 12.0, vars["commit_count"])
+	assert.Equal(t, 2.5, vars["avg_review_time_hours"])
+	assert.Equal(t, 1.0, vars["external"])
+	assert.Equal(t, 0.0, vars["first_time_contributor"])
+	assert.Equal(t, 2.0, vars["repositories_contributed"])
+	assert.Equal(t, 0.5, vars["review_ratio"])
+	assert.NotContains(t, vars, "login")
+	assert.NotContains(t, vars, "achievements")
+	assert.NotContains(t, vars, "score")
+
+	assert.True(t, IsField("prs_merged"))
+	assert.False(t, IsField("opted_out"))
+	assert.Contains(t, Fields(), "effort_points")
+}
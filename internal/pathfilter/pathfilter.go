@@ -0,0 +1,116 @@
+package pathfilter
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Filter matches repository-relative file paths against glob patterns.
+//
+// Patterns use path.Match syntax per segment, plus "**" for any number of
+// directories. A pattern without glob characters matches that file or
+// everything below that directory, so "services/payments" is the same as
+// "services/payments/**". A nil Filter matches every path.
+type Filter struct {
+	patterns [][]string
+}
+
+// New compiles patterns into a Filter. It returns nil when there are no patterns.
+func New(patterns []string) (*Filter, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	f := &Filter{}
+	for _, p := range patterns {
+		segments, err := compile(p)
+		if err != nil {
+			return nil, err
+		}
+		f.patterns = append(f.patterns, segments)
+	}
+	return f, nil
+}
+
+// Validate checks that every pattern is well-formed
+func Validate(patterns []string) error {
+	_, err := New(patterns)
+	return err
+}
+
+// compile splits a pattern into segments and checks their syntax
+func compile(pattern string) ([]string, error) {
+	p := strings.Trim(strings.TrimSpace(pattern), "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty path pattern")
+	}
+
+	segments := strings.Split(p, "/")
+	for _, s := range segments {
+		if s == "**" {
+			continue
+		}
+		if _, err := path.Match(s, ""); err != nil {
+			return nil, fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+
+	// A plain directory also covers its contents
+	if !strings.ContainsAny(p, "*?[") {
+		segments = append(segments, "**")
+	}
+	return segments, nil
+}
+
+// Match reports whether name is selected by any pattern
+func (f *Filter) Match(name string) bool {
+	if f == nil {
+		return true
+	}
+	if name == "" {
+		return false
+	}
+
+	parts := strings.Split(strings.Trim(name, "/"), "/")
+	for _, segments := range f.patterns {
+		if matchSegments(segments, parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchAny reports whether any of names is selected
+func (f *Filter) MatchAny(names ...string) bool {
+	for _, name := range names {
+		if f.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path parts against pattern segments, where "**"
+// consumes zero or more parts
+func matchSegments(segments, parts []string) bool {
+	for len(segments) > 0 {
+		if segments[0] == "**" {
+			rest := segments[1:]
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(rest, parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(segments[0], parts[0]); !ok {
+			return false
+		}
+		segments, parts = segments[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
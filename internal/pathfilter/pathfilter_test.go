@@ -0,0 +1,70 @@
+package pathfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Match(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		expected bool
+	}{
+		{"double star directory", []string{"services/payments/**"}, "services/payments/api/handler.go", true},
+		{"double star direct child", []string{"services/payments/**"}, "services/payments/go.mod", true},
+		{"double star other service", []string{"services/payments/**"}, "services/billing/main.go", false},
+		{"plain directory", []string{"services/payments"}, "services/payments/api/handler.go", true},
+		{"plain directory prefix only", []string{"services/pay"}, "services/payments/main.go", false},
+		{"trailing slash", []string{"services/payments/"}, "services/payments/main.go", true},
+		{"single star segment", []string{"services/*/api/**"}, "services/billing/api/v1/routes.go", true},
+		{"single star does not cross dirs", []string{"services/*.go"}, "services/billing/main.go", false},
+		{"leading double star", []string{"**/*.proto"}, "api/v1/payments.proto", true},
+		{"leading double star root file", []string{"**/*.proto"}, "payments.proto", true},
+		{"middle double star", []string{"libs/**/testdata/*"}, "libs/a/b/testdata/case.json", true},
+		{"multiple patterns", []string{"web/**", "services/payments/**"}, "web/src/app.ts", true},
+		{"empty path", []string{"**"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := New(tt.patterns)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, f.Match(tt.path))
+		})
+	}
+}
+
+func TestFilter_Nil(t *testing.T) {
+	t.Parallel()
+
+	f, err := New(nil)
+	require.NoError(t, err)
+	assert.Nil(t, f)
+	assert.True(t, f.Match("anything/at/all.go"))
+	assert.True(t, f.MatchAny("a", "b"))
+}
+
+func TestFilter_MatchAny(t *testing.T) {
+	t.Parallel()
+
+	f, err := New([]string{"services/payments/**"})
+	require.NoError(t, err)
+	// A file moved out of scope still touches it
+	assert.True(t, f.MatchAny("services/shared/util.go", "services/payments/util.go"))
+	assert.False(t, f.MatchAny("docs/a.md", ""))
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Validate([]string{"services/**", "*.go"}))
+	assert.Error(t, Validate([]string{"services/[a-"}))
+	assert.Error(t, Validate([]string{"  "}))
+}
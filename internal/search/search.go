@@ -0,0 +1,126 @@
+// Package search builds the static search index the dashboard queries
+// without a server
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Entry types
+const (
+	TypeContributor = "contributor"
+	TypeRepository  = "repository"
+)
+
+// Build indexes the contributors and repositories of metrics. Contributors are
+// ordered by leaderboard rank, so unranked matches come last.
+func Build(metrics *models.GlobalMetrics) *models.SearchIndex {
+	idx := &models.SearchIndex{Tokens: make(map[string][]int)}
+
+	rank := make(map[string]int, len(metrics.Leaderboard))
+	team := make(map[string]string, len(metrics.Leaderboard))
+	for _, entry := range metrics.Leaderboard {
+		rank[entry.Login] = entry.Rank
+		team[entry.Login] = entry.Team
+	}
+	contributors := append([]models.ContributorMetrics(nil), metrics.Contributors...)
+	sort.SliceStable(contributors, func(i, j int) bool {
+		ri, rj := rank[contributors[i].Login], rank[contributors[j].Login]
+		if (ri == 0) != (rj == 0) {
+			return ri != 0
+		}
+		return ri < rj
+	})
+
+	teams := make(map[string]bool)
+	repos := make(map[string]bool)
+	achievements := make(map[string]bool)
+
+	for _, c := range contributors {
+		title := c.Name
+		if title == "" {
+			title = c.Login
+		}
+		entry := models.SearchEntry{
+			Type:         TypeContributor,
+			ID:           c.Login,
+			Title:        title,
+			Route:        "/contributors/" + c.Login,
+			Team:         team[c.Login],
+			Repositories: c.RepositoriesContributed,
+			Achievements: c.Achievements,
+			Score:        c.Score.Total,
+		}
+		add(idx, entry, c.Login, c.Name, entry.Team)
+
+		if entry.Team != "" {
+			teams[entry.Team] = true
+		}
+		for _, id := range c.Achievements {
+			achievements[id] = true
+		}
+	}
+
+	for _, r := range metrics.Repositories {
+		add(idx, models.SearchEntry{
+			Type:  TypeRepository,
+			ID:    r.FullName,
+			Title: r.FullName,
+			Route: "/repos/" + r.Owner + "/" + r.Name,
+		}, r.FullName)
+		repos[r.FullName] = true
+	}
+
+	idx.Facets = models.SearchFacets{
+		Teams:        sortedKeys(teams),
+		Repositories: sortedKeys(repos),
+		Achievements: sortedKeys(achievements),
+	}
+	return idx
+}
+
+// add appends entry and indexes it under the tokens of each text
+func add(idx *models.SearchIndex, entry models.SearchEntry, texts ...string) {
+	position := len(idx.Entries)
+	idx.Entries = append(idx.Entries, entry)
+
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, token := range Tokenize(text) {
+			if !seen[token] {
+				seen[token] = true
+				idx.Tokens[token] = append(idx.Tokens[token], position)
+			}
+		}
+	}
+}
+
+// Tokenize splits text into lowercase words. The whole lowercased text is
+// kept as a token too, so "octo-cat" is found by "octo-cat", "octo" and "cat".
+// The dashboard tokenizes queries the same way.
+func Tokenize(text string) []string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return nil
+	}
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 1 && words[0] == text {
+		return words
+	}
+	return append(words, text)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, Tokenize("  "))
+	assert.Equal(t, []string{"alice"}, Tokenize("Alice"))
+	assert.Equal(t, []string{"octo", "cat", "octo-cat"}, Tokenize("Octo-Cat"))
+	assert.Equal(t, []string{"acme", "api", "acme/api"}, Tokenize("acme/api"))
+	assert.Equal(t, []string{"zoë", "müller", "zoë müller"}, Tokenize("Zoë Müller"))
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	metrics := &models.GlobalMetrics{
+		Contributors: []models.ContributorMetrics{
+			{Login: "carol"},
+			{Login: "bob-smith", Name: "Bob Smith", RepositoriesContributed: []string{"acme/web"}, Achievements: []string{"commit-1"}},
+			{Login: "alice", Name: "Alice", RepositoriesContributed: []string{"acme/api"}, Achievements: []string{"review-1", "commit-1"}},
+		},
+		Leaderboard: []models.LeaderboardEntry{
+			{Rank: 1, Login: "alice", Team: "Platform"},
+			{Rank: 2, Login: "bob-smith", Team: "Web"},
+		},
+		Repositories: []models.RepositoryMetrics{
+			{Owner: "acme", Name: "api", FullName: "acme/api"},
+		},
+	}
+
+	idx := Build(metrics)
+	require.Len(t, idx.Entries, 4)
+
+	// Ranked contributors first, then unranked, then repositories
+	assert.Equal(t, "alice", idx.Entries[0].ID)
+	assert.Equal(t, "bob-smith", idx.Entries[1].ID)
+	assert.Equal(t, "carol", idx.Entries[2].ID)
+	assert.Equal(t, TypeRepository, idx.Entries[3].Type)
+	assert.Equal(t, "/repos/acme/api", idx.Entries[3].Route)
+
+	assert.Equal(t, "Platform", idx.Entries[0].Team)
+	assert.Equal(t, "carol", idx.Entries[2].Title, "login is the title without a name")
+
+	assert.Equal(t, []int{1}, idx.Tokens["smith"])
+	assert.Equal(t, []int{1}, idx.Tokens["bob-smith"])
+	assert.Equal(t, []int{0}, idx.Tokens["platform"])
+	assert.Equal(t, []int{3}, idx.Tokens["api"])
+
+	assert.Equal(t, []string{"Platform", "Web"}, idx.Facets.Teams)
+	assert.Equal(t, []string{"acme/api"}, idx.Facets.Repositories)
+	assert.Equal(t, []string{"commit-1", "review-1"}, idx.Facets.Achievements)
+}
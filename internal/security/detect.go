@@ -0,0 +1,63 @@
+// Package security recognizes security fixes by pull request labels and by
+// GitHub Security Advisory (GHSA) or CVE identifiers.
+package security
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// advisoryPattern matches GHSA IDs (GHSA-xxxx-xxxx-xxxx) and CVE IDs (CVE-2024-12345)
+var advisoryPattern = regexp.MustCompile(`(?i)\b(GHSA(?:-[23456789cfghjmpqrvwx]{4}){3}|CVE-\d{4}-\d{4,})\b`)
+
+// Detector flags pull requests as security fixes
+type Detector struct {
+	labels map[string]bool
+}
+
+// NewDetector creates a detector treating the given labels (case-insensitive)
+// as marking security fixes
+func NewDetector(labels []string) *Detector {
+	set := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if label = strings.TrimSpace(label); label != "" {
+			set[strings.ToLower(label)] = true
+		}
+	}
+	return &Detector{labels: set}
+}
+
+// IsFix reports whether a pull request with these labels, title and branch
+// fixes a security issue
+func (d *Detector) IsFix(labels []string, title, branch string) bool {
+	for _, label := range labels {
+		if d.labels[strings.ToLower(label)] {
+			return true
+		}
+	}
+	return len(Advisories(title, branch)) > 0
+}
+
+// Advisories returns the unique GHSA and CVE identifiers found in the given
+// texts, in their canonical case (GHSA-abcd-efgh-ijkl, CVE-2024-12345)
+func Advisories(texts ...string) []string {
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, m := range advisoryPattern.FindAllString(text, -1) {
+			prefix, rest, _ := strings.Cut(m, "-")
+			if strings.EqualFold(prefix, "GHSA") {
+				seen["GHSA-"+strings.ToLower(rest)] = true
+			} else {
+				seen[strings.ToUpper(m)] = true
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
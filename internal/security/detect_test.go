@@ -0,0 +1,43 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisories(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		texts    []string
+		expected []string
+	}{
+		{"ghsa in title", []string{"Fix GHSA-jfh8-c2jp-5v3q in parser"}, []string{"GHSA-jfh8-c2jp-5v3q"}},
+		{"ghsa case normalized", []string{"fix/ghsa-JFH8-C2JP-5V3Q"}, []string{"GHSA-jfh8-c2jp-5v3q"}},
+		{"cve", []string{"Patch cve-2024-3094"}, []string{"CVE-2024-3094"}},
+		{"deduplicated and sorted", []string{"CVE-2021-44228 and GHSA-jfh8-c2jp-5v3q", "CVE-2021-44228"}, []string{"CVE-2021-44228", "GHSA-jfh8-c2jp-5v3q"}},
+		{"ghsa alphabet enforced", []string{"GHSA-aaaa-bbbb-cccc"}, []string{}},
+		{"short cve number ignored", []string{"CVE-2024-12"}, []string{}},
+		{"none", []string{"Bump lodash"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, Advisories(tt.texts...))
+		})
+	}
+}
+
+func TestDetector_IsFix(t *testing.T) {
+	t.Parallel()
+
+	d := NewDetector([]string{"Security", " vuln "})
+
+	assert.True(t, d.IsFix([]string{"dependencies", "security"}, "Bump lodash", "dependabot/npm_and_yarn/lodash"))
+	assert.True(t, d.IsFix([]string{"VULN"}, "Harden input", "harden"))
+	assert.True(t, d.IsFix(nil, "Fix CVE-2024-3094", "main"))
+	assert.False(t, d.IsFix([]string{"bug"}, "Fix login", "fix-login"))
+}
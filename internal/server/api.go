@@ -0,0 +1,164 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/internal/compare"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// MetricsSource provides the metrics served by the JSON API
+type MetricsSource interface {
+	Metrics() (*models.GlobalMetrics, error)
+}
+
+// StaticSource serves metrics held in memory, e.g. by a long-running process
+// that re-analyzes on a schedule
+type StaticSource struct {
+	mu      sync.RWMutex
+	metrics *models.GlobalMetrics
+}
+
+// NewStaticSource creates a source serving m
+func NewStaticSource(m *models.GlobalMetrics) *StaticSource {
+	return &StaticSource{metrics: m}
+}
+
+// Set replaces the served metrics
+func (s *StaticSource) Set(m *models.GlobalMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics = m
+}
+
+// Metrics implements MetricsSource
+func (s *StaticSource) Metrics() (*models.GlobalMetrics, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.metrics == nil {
+		return nil, errNoData
+	}
+	return s.metrics, nil
+}
+
+// errNoData is returned while there are no metrics to serve
+var errNoData = errors.New("no generated data available")
+
+// fileSource serves data/global.json from a generated site, reloading it
+// whenever the file changes so a new analyze run is picked up without a restart
+type fileSource struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	metrics *models.GlobalMetrics
+}
+
+func newFileSource(directory string) *fileSource {
+	return &fileSource{path: filepath.Join(directory, "data", "global.json")}
+}
+
+// Metrics implements MetricsSource
+func (s *fileSource) Metrics() (*models.GlobalMetrics, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, errNoData
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.metrics != nil && info.ModTime().Equal(s.modTime) {
+		return s.metrics, nil
+	}
+
+	metrics, err := compare.Load(s.path)
+	if err != nil {
+		return nil, err
+	}
+	s.metrics = metrics
+	s.modTime = info.ModTime()
+	return metrics, nil
+}
+
+// teamsDocument is the response of /api/teams
+type teamsDocument struct {
+	SchemaVersion int                  `json:"schema_version"`
+	Teams         []models.TeamMetrics `json:"teams"`
+}
+
+// apiError is the body of every API error response
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// registerAPI adds the read-only JSON endpoints to mux. Responses use the
+// same documents as the generated data files.
+func (s *Server) registerAPI(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/leaderboard", s.withMetrics(func(m *models.GlobalMetrics, w http.ResponseWriter, r *http.Request) {
+		writeAPIJSON(w, http.StatusOK, models.NewLeaderboardDocument(m.Leaderboard))
+	}))
+
+	mux.HandleFunc("GET /api/contributors/{login}", s.withMetrics(func(m *models.GlobalMetrics, w http.ResponseWriter, r *http.Request) {
+		login := r.PathValue("login")
+		for i := range m.Contributors {
+			if strings.EqualFold(m.Contributors[i].Login, login) {
+				writeAPIJSON(w, http.StatusOK, models.NewContributorDocument(&m.Contributors[i]))
+				return
+			}
+		}
+		writeAPIJSON(w, http.StatusNotFound, apiError{Error: "contributor not found: " + login})
+	}))
+
+	mux.HandleFunc("GET /api/repos/{owner}/{repo}", s.withMetrics(func(m *models.GlobalMetrics, w http.ResponseWriter, r *http.Request) {
+		fullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+		for i := range m.Repositories {
+			if strings.EqualFold(m.Repositories[i].FullName, fullName) {
+				writeAPIJSON(w, http.StatusOK, models.NewRepositoryDocument(&m.Repositories[i]))
+				return
+			}
+		}
+		writeAPIJSON(w, http.StatusNotFound, apiError{Error: "repository not found: " + fullName})
+	}))
+
+	mux.HandleFunc("GET /api/teams", s.withMetrics(func(m *models.GlobalMetrics, w http.ResponseWriter, r *http.Request) {
+		teams := m.Teams
+		if teams == nil {
+			teams = []models.TeamMetrics{}
+		}
+		writeAPIJSON(w, http.StatusOK, teamsDocument{SchemaVersion: models.SchemaVersion, Teams: teams})
+	}))
+
+	mux.HandleFunc("GET /api/events", s.handleEvents)
+	s.registerGraphQL(mux)
+}
+
+// withMetrics loads the current metrics before calling handler
+func (s *Server) withMetrics(handler func(*models.GlobalMetrics, http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		metrics, err := s.source.Metrics()
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, errNoData) {
+				status = http.StatusServiceUnavailable
+			}
+			writeAPIJSON(w, status, apiError{Error: err.Error()})
+			return
+		}
+		handler(metrics, w, r)
+	}
+}
+
+// writeAPIJSON writes v as a JSON response
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
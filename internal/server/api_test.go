@@ -0,0 +1,191 @@
+package server
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func testMetrics() *models.GlobalMetrics {
+	return &models.GlobalMetrics{
+		Leaderboard: []models.LeaderboardEntry{
+			{Rank: 1, Login: "alice", Score: 120},
+			{Rank: 2, Login: "bob", Score: 80},
+		},
+		Contributors: []models.ContributorMetrics{
+			{Login: "alice", CommitCount: 12},
+			{Login: "bob", CommitCount: 3},
+		},
+		Repositories: []models.RepositoryMetrics{
+			{Owner: "org", Name: "api", FullName: "org/api", TotalCommits: 15},
+		},
+		Teams: []models.TeamMetrics{
+			{Name: "Platform", Members: []string{"alice"}},
+		},
+	}
+}
+
+func writeGlobalJSON(t *testing.T, dir string, m *models.GlobalMetrics) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "data"), 0750))
+	data, err := json.Marshal(models.NewGlobalDocument(m, time.Now()))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data", "global.json"), data, 0600))
+}
+
+func apiGet(t *testing.T, handler http.Handler, path string, v any) int {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+	if v != nil && rr.Code == http.StatusOK {
+		assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), v))
+	}
+	return rr.Code
+}
+
+func TestAPI_Endpoints(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeGlobalJSON(t, dir, testMetrics())
+	handler, err := New(dir, "0").CreateHandler()
+	require.NoError(t, err)
+
+	var leaderboard models.LeaderboardDocument
+	require.Equal(t, http.StatusOK, apiGet(t, handler, "/api/leaderboard", &leaderboard))
+	assert.Equal(t, models.SchemaVersion, leaderboard.SchemaVersion)
+	require.Len(t, leaderboard.Leaderboard, 2)
+	assert.Equal(t, "alice", leaderboard.Leaderboard[0].Login)
+
+	var contributor models.ContributorDocument
+	require.Equal(t, http.StatusOK, apiGet(t, handler, "/api/contributors/Alice", &contributor))
+	assert.Equal(t, "alice", contributor.Login)
+	assert.Equal(t, 12, contributor.CommitCount)
+
+	var repo models.RepositoryDocument
+	require.Equal(t, http.StatusOK, apiGet(t, handler, "/api/repos/org/api", &repo))
+	assert.Equal(t, 15, repo.TotalCommits)
+
+	var teams teamsDocument
+	require.Equal(t, http.StatusOK, apiGet(t, handler, "/api/teams", &teams))
+	require.Len(t, teams.Teams, 1)
+	assert.Equal(t, "Platform", teams.Teams[0].Name)
+
+	assert.Equal(t, http.StatusNotFound, apiGet(t, handler, "/api/contributors/nobody", nil))
+	assert.Equal(t, http.StatusNotFound, apiGet(t, handler, "/api/repos/org/missing", nil))
+}
+
+func TestAPI_ReloadsGeneratedData(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	handler, err := New(dir, "0").CreateHandler()
+	require.NoError(t, err)
+
+	// Nothing generated yet
+	assert.Equal(t, http.StatusServiceUnavailable, apiGet(t, handler, "/api/leaderboard", nil))
+
+	writeGlobalJSON(t, dir, testMetrics())
+	var teams teamsDocument
+	require.Equal(t, http.StatusOK, apiGet(t, handler, "/api/teams", &teams))
+	assert.Len(t, teams.Teams, 1)
+
+	// A new run replaces the file
+	updated := testMetrics()
+	updated.Teams = nil
+	writeGlobalJSON(t, dir, updated)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "data", "global.json"), future, future))
+
+	require.Equal(t, http.StatusOK, apiGet(t, handler, "/api/teams", &teams))
+	assert.NotNil(t, teams.Teams)
+	assert.Empty(t, teams.Teams)
+}
+
+func TestAPI_StaticSource(t *testing.T) {
+	t.Parallel()
+
+	s := New(t.TempDir(), "0")
+	source := NewStaticSource(nil)
+	s.SetMetricsSource(source)
+	handler, err := s.CreateHandler()
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusServiceUnavailable, apiGet(t, handler, "/api/leaderboard", nil))
+
+	source.Set(testMetrics())
+	var contributor models.ContributorDocument
+	require.Equal(t, http.StatusOK, apiGet(t, handler, "/api/contributors/bob", &contributor))
+	assert.Equal(t, 3, contributor.CommitCount)
+}
+
+func TestAPI_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	s := New(t.TempDir(), "0")
+	s.SetMetricsSource(NewStaticSource(testMetrics()))
+	handler, err := s.CreateHandler()
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/leaderboard", nil))
+	assert.NotEqual(t, http.StatusOK, rr.Code)
+}
+
+func TestAPI_EventsStreamUpdates(t *testing.T) {
+	t.Parallel()
+
+	s := New(t.TempDir(), "0")
+	s.pollInterval = 10 * time.Millisecond
+	source := NewStaticSource(testMetrics())
+	s.SetMetricsSource(source)
+	handler, err := s.CreateHandler()
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "retry: 5000\n", line)
+
+	// A new analysis result is pushed to the client
+	source.Set(testMetrics())
+
+	var event, data string
+	for event == "" || data == "" {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if v, ok := strings.CutPrefix(line, "event: "); ok {
+			event = strings.TrimSpace(v)
+		}
+		if v, ok := strings.CutPrefix(line, "data: "); ok {
+			data = strings.TrimSpace(v)
+		}
+	}
+	assert.Equal(t, "update", event)
+
+	var update updateEvent
+	require.NoError(t, json.Unmarshal([]byte(data), &update))
+	assert.Equal(t, models.SchemaVersion, update.SchemaVersion)
+	assert.False(t, update.UpdatedAt.IsZero())
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+const (
+	// defaultPollInterval is how often event streams check the metrics source for changes
+	defaultPollInterval = 2 * time.Second
+	// heartbeatInterval keeps idle event streams open through proxies
+	heartbeatInterval = 30 * time.Second
+)
+
+// updateEvent is the data of an "update" event
+type updateEvent struct {
+	SchemaVersion int       `json:"schema_version"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// handleEvents streams Server-Sent Events to dashboard clients. An "update"
+// event is sent whenever the metrics source returns new data, e.g. after
+// analyze rewrites the generated files, so wallboards refresh without a reload.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	// The server's write timeout would otherwise end the stream
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// Metrics sources return the same pointer until their data changes
+	current, _ := s.source.Metrics()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", (5 * time.Second).Milliseconds()); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	poll := time.NewTicker(s.pollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		case <-poll.C:
+			metrics, loadErr := s.source.Metrics()
+			if loadErr != nil || metrics == current {
+				continue
+			}
+			current = metrics
+			err = writeEvent(w, "update", updateEvent{SchemaVersion: models.SchemaVersion, UpdatedAt: time.Now().UTC()})
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes a single named event with a JSON payload
+func writeEvent(w http.ResponseWriter, name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/internal/graphql"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// maxGraphQLRequestBytes limits the size of a GraphQL request body
+const maxGraphQLRequestBytes = 64 << 10
+
+// dateLayout is the format of the from and to arguments of velocity_timeline
+const dateLayout = "2006-01-02"
+
+// metricsSchema is the GraphQL schema of the served metrics. Besides the
+// fields of the JSON documents it looks up a single contributor, repository
+// or team, and narrows the velocity timeline to a date range.
+var metricsSchema = newMetricsSchema()
+
+func newMetricsSchema() *graphql.Schema {
+	schema := graphql.NewSchema(&models.GlobalMetrics{})
+
+	schema.AddField(&models.GlobalMetrics{}, "contributor", graphql.Field{
+		Args: map[string]string{"login": "String!"},
+		Type: reflect.TypeOf(&models.ContributorMetrics{}),
+		Resolve: func(parent any, args map[string]any) (any, error) {
+			m := parent.(*models.GlobalMetrics)
+			for i := range m.Contributors {
+				if strings.EqualFold(m.Contributors[i].Login, args["login"].(string)) {
+					return &m.Contributors[i], nil
+				}
+			}
+			return nil, nil
+		},
+	})
+	schema.AddField(&models.GlobalMetrics{}, "repository", graphql.Field{
+		Args: map[string]string{"full_name": "String!"},
+		Type: reflect.TypeOf(&models.RepositoryMetrics{}),
+		Resolve: func(parent any, args map[string]any) (any, error) {
+			m := parent.(*models.GlobalMetrics)
+			for i := range m.Repositories {
+				if strings.EqualFold(m.Repositories[i].FullName, args["full_name"].(string)) {
+					return &m.Repositories[i], nil
+				}
+			}
+			return nil, nil
+		},
+	})
+	schema.AddField(&models.GlobalMetrics{}, "team", graphql.Field{
+		Args: map[string]string{"name": "String!"},
+		Type: reflect.TypeOf(&models.TeamMetrics{}),
+		Resolve: func(parent any, args map[string]any) (any, error) {
+			m := parent.(*models.GlobalMetrics)
+			for i := range m.Teams {
+				if strings.EqualFold(m.Teams[i].Name, args["name"].(string)) {
+					return &m.Teams[i], nil
+				}
+			}
+			return nil, nil
+		},
+	})
+	schema.AddField(&models.GlobalMetrics{}, "velocity_timeline", graphql.Field{
+		Args: map[string]string{"from": "String", "to": "String"},
+		Type: reflect.TypeOf(&models.VelocityTimeline{}),
+		Resolve: func(parent any, args map[string]any) (any, error) {
+			m := parent.(*models.GlobalMetrics)
+			from, err := dateArg(args, "from")
+			if err != nil {
+				return nil, err
+			}
+			to, err := dateArg(args, "to")
+			if err != nil {
+				return nil, err
+			}
+			return timelineBetween(m.VelocityTimeline, m.Period, from, to), nil
+		},
+	})
+	return schema
+}
+
+// dateArg parses an optional YYYY-MM-DD argument
+func dateArg(args map[string]any, name string) (time.Time, error) {
+	s, ok := args[name].(string)
+	if !ok {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date %q, expected YYYY-MM-DD", name, s)
+	}
+	return t, nil
+}
+
+// timelineBetween returns the weeks of a timeline that overlap the days from
+// through to; zero bounds are open. Week i of the timeline starts on the
+// Monday of the period's first week plus i weeks. The forecast is kept only
+// while the last week is.
+func timelineBetween(timeline *models.VelocityTimeline, period models.Period, from, to time.Time) *models.VelocityTimeline {
+	if timeline == nil || (from.IsZero() && to.IsZero()) {
+		return timeline
+	}
+
+	start := period.Start
+	weekday := int(start.Weekday())
+	if weekday == 0 {
+		weekday = 7 // Sunday = 7
+	}
+	monday := start.AddDate(0, 0, -(weekday - 1))
+	monday = time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
+
+	first, last := -1, -1
+	for i := range timeline.Labels {
+		weekStart := monday.AddDate(0, 0, 7*i)
+		weekEnd := weekStart.AddDate(0, 0, 7)
+		if (!from.IsZero() && !weekEnd.After(from)) || (!to.IsZero() && weekStart.After(to)) {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+
+	narrowed := &models.VelocityTimeline{Labels: []string{}, Series: make([]models.VelocityTimelineSeries, len(timeline.Series))}
+	if first >= 0 {
+		narrowed.Labels = timeline.Labels[first : last+1]
+		if last == len(timeline.Labels)-1 {
+			narrowed.Forecast = timeline.Forecast
+		}
+	}
+	for i, series := range timeline.Series {
+		series.Data = []float64{}
+		if first >= 0 && last < len(timeline.Series[i].Data) {
+			series.Data = timeline.Series[i].Data[first : last+1]
+		}
+		narrowed.Series[i] = series
+	}
+	return narrowed
+}
+
+// registerGraphQL adds the GraphQL endpoint and its schema to mux
+func (s *Server) registerGraphQL(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/graphql", s.withMetrics(s.handleGraphQL))
+	mux.HandleFunc("POST /api/graphql", s.withMetrics(s.handleGraphQL))
+
+	mux.HandleFunc("GET /api/graphql/schema", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = io.WriteString(w, metricsSchema.SDL())
+	})
+}
+
+// handleGraphQL answers a GraphQL query. Requests that cannot be executed at
+// all, e.g. because they do not parse, are answered with status 400.
+func (s *Server) handleGraphQL(m *models.GlobalMetrics, w http.ResponseWriter, r *http.Request) {
+	req, err := readGraphQLRequest(w, r)
+	if err != nil {
+		writeAPIJSON(w, http.StatusBadRequest, graphql.Response{Errors: []graphql.Error{{Message: err.Error()}}})
+		return
+	}
+
+	resp := metricsSchema.Execute(r.Context(), m, req)
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	writeAPIJSON(w, status, resp)
+}
+
+// readGraphQLRequest reads a request from the query string of a GET, or from
+// the body of a POST, either JSON or a bare application/graphql query
+func readGraphQLRequest(w http.ResponseWriter, r *http.Request) (graphql.Request, error) {
+	var req graphql.Request
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if variables := q.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return req, fmt.Errorf("invalid variables: %w", err)
+			}
+		}
+	} else {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxGraphQLRequestBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return req, fmt.Errorf("request body exceeds %d bytes", tooLarge.Limit)
+			}
+			return req, fmt.Errorf("failed to read request body: %w", err)
+		}
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType == "application/graphql" {
+			req.Query = string(body)
+		} else if err := json.Unmarshal(body, &req); err != nil {
+			return req, fmt.Errorf("invalid request body: %w", err)
+		}
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return req, errors.New("missing query")
+	}
+	return req, nil
+}
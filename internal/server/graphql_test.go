@@ -0,0 +1,170 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func graphQLHandler(t *testing.T) http.Handler {
+	t.Helper()
+	m := testMetrics()
+	m.Period = models.Period{Start: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)}
+	m.VelocityTimeline = &models.VelocityTimeline{
+		Labels: []string{"Jan 1", "Jan 8", "Jan 15"},
+		Series: []models.VelocityTimelineSeries{{Name: "Commits", Data: []float64{4, 5, 6}}},
+	}
+	s := New(t.TempDir(), "0")
+	s.SetMetricsSource(NewStaticSource(m))
+	handler, err := s.CreateHandler()
+	require.NoError(t, err)
+	return handler
+}
+
+// postGraphQL posts a body and returns the status and response
+func postGraphQL(t *testing.T, handler http.Handler, contentType, body string) (int, string) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	handler.ServeHTTP(rr, req)
+	return rr.Code, rr.Body.String()
+}
+
+func TestGraphQL_Queries(t *testing.T) {
+	t.Parallel()
+
+	handler := graphQLHandler(t)
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "filtered and sorted lists",
+			body: `{"query": "{ contributors(order_by: \"commit_count\", first: 1) { login commit_count } teams { name members } }"}`,
+			want: `{"data":{"contributors":[{"login":"bob","commit_count":3}],"teams":[{"name":"Platform","members":["alice"]}]}}`,
+		},
+		{
+			name: "lookups with variables",
+			body: `{"query": "query Lookup($login: String!) { contributor(login: $login) { commit_count } repository(full_name: \"ORG/API\") { total_commits } team(name: \"none\") { name } }", "variables": {"login": "Alice"}}`,
+			want: `{"data":{"contributor":{"commit_count":12},"repository":{"total_commits":15},"team":null}}`,
+		},
+		{
+			name: "timeline between dates",
+			body: `{"query": "{ velocity_timeline(from: \"2024-01-09\", to: \"2024-01-15\") { labels series { name data } } }"}`,
+			want: `{"data":{"velocity_timeline":{"labels":["Jan 8","Jan 15"],"series":[{"name":"Commits","data":[5,6]}]}}}`,
+		},
+		{
+			name: "whole timeline",
+			body: `{"query": "{ velocity_timeline { labels } }"}`,
+			want: `{"data":{"velocity_timeline":{"labels":["Jan 1","Jan 8","Jan 15"]}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			status, body := postGraphQL(t, handler, "application/json", tt.body)
+			assert.Equal(t, http.StatusOK, status)
+			assert.JSONEq(t, tt.want, body)
+		})
+	}
+}
+
+func TestGraphQL_Transports(t *testing.T) {
+	t.Parallel()
+
+	handler := graphQLHandler(t)
+
+	status, body := postGraphQL(t, handler, "application/graphql; charset=utf-8", `{ repositories { full_name } }`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"data":{"repositories":[{"full_name":"org/api"}]}}`, body)
+
+	query := url.Values{
+		"query":     {`query Q($n: Int) { leaderboard(first: $n) { login } }`},
+		"variables": {`{"n": 1}`},
+	}
+	var resp map[string]any
+	require.Equal(t, http.StatusOK, apiGet(t, handler, "/api/graphql?"+query.Encode(), &resp))
+	assert.Equal(t, map[string]any{"leaderboard": []any{map[string]any{"login": "alice"}}}, resp["data"])
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/graphql/schema", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), "  contributor(login: String!): ContributorMetrics\n")
+	assert.Contains(t, rr.Body.String(), "  velocity_timeline(from: String, to: String): VelocityTimeline\n")
+}
+
+func TestGraphQL_Errors(t *testing.T) {
+	t.Parallel()
+
+	handler := graphQLHandler(t)
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		want        string
+	}{
+		{"invalid JSON", "application/json", `{"query": `, http.StatusBadRequest, "invalid request body"},
+		{"missing query", "application/json", `{}`, http.StatusBadRequest, "missing query"},
+		{"too large", "application/graphql", "{ " + strings.Repeat("total_commits ", maxGraphQLRequestBytes/10) + "}", http.StatusBadRequest, "request body exceeds 65536 bytes"},
+		{"unknown field", "application/graphql", `{ secrets }`, http.StatusBadRequest, `cannot query field \"secrets\" on type GlobalMetrics`},
+		{"invalid date", "application/graphql", `{ velocity_timeline(from: "last week") { labels } }`, http.StatusOK, `invalid from date \"last week\", expected YYYY-MM-DD`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			status, body := postGraphQL(t, handler, tt.contentType, tt.body)
+			assert.Equal(t, tt.status, status)
+			assert.Contains(t, body, tt.want)
+		})
+	}
+
+	// Not served before there is data
+	s := New(t.TempDir(), "0")
+	s.SetMetricsSource(NewStaticSource(nil))
+	empty, err := s.CreateHandler()
+	require.NoError(t, err)
+	status, _ := postGraphQL(t, empty, "application/graphql", `{ total_commits }`)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestTimelineBetween(t *testing.T) {
+	t.Parallel()
+
+	period := models.Period{Start: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)} // A Sunday, in the week of Jan 1
+	timeline := &models.VelocityTimeline{
+		Labels:   []string{"Jan 1", "Jan 8"},
+		Series:   []models.VelocityTimelineSeries{{Name: "PRs", Data: []float64{1, 2}}},
+		Forecast: &models.Forecast{Method: "linear"},
+	}
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	narrowed := timelineBetween(timeline, period, time.Time{}, day(7))
+	assert.Equal(t, []string{"Jan 1"}, narrowed.Labels)
+	assert.Equal(t, []float64{1}, narrowed.Series[0].Data)
+	assert.Nil(t, narrowed.Forecast, "the forecast follows the last week")
+
+	narrowed = timelineBetween(timeline, period, day(8), time.Time{})
+	assert.Equal(t, []string{"Jan 8"}, narrowed.Labels)
+	assert.NotNil(t, narrowed.Forecast)
+
+	narrowed = timelineBetween(timeline, period, day(20), time.Time{})
+	assert.Empty(t, narrowed.Labels)
+	assert.Empty(t, narrowed.Series[0].Data)
+
+	assert.Same(t, timeline, timelineBetween(timeline, period, time.Time{}, time.Time{}))
+	assert.Nil(t, timelineBetween(nil, period, day(1), day(2)))
+}
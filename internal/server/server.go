@@ -12,16 +12,28 @@ import (
 type Server struct {
 	directory string
 	port      string
+	source    MetricsSource
+
+	pollInterval time.Duration
 }
 
-// New creates a new preview server
+// New creates a new preview server. The JSON API serves the metrics generated
+// into directory unless SetMetricsSource is called.
 func New(directory, port string) *Server {
 	return &Server{
 		directory: directory,
 		port:      port,
+		source:    newFileSource(directory),
+
+		pollInterval: defaultPollInterval,
 	}
 }
 
+// SetMetricsSource replaces where the JSON API reads metrics from
+func (s *Server) SetMetricsSource(src MetricsSource) {
+	s.source = src
+}
+
 // Start starts the HTTP server
 func (s *Server) Start() error {
 	handler, err := s.CreateHandler()
@@ -78,10 +90,12 @@ func (s *Server) CreateHandler() (http.Handler, error) {
 	}
 
 	// Create file server with directory listing disabled for security
-	fs := http.FileServer(http.Dir(absPath))
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir(absPath)))
+	s.registerAPI(mux)
 
 	// Wrap with middleware
-	return s.loggingMiddleware(s.cacheMiddleware(fs)), nil
+	return s.loggingMiddleware(s.cacheMiddleware(mux)), nil
 }
 
 // GetAddress returns the server address in the format :port
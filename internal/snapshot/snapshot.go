@@ -0,0 +1,106 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/internal/aggregator"
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// FileName is the name of the raw data snapshot within the cache directory
+const FileName = "rawdata.json"
+
+// Version is the version of the snapshot's structure. It is bumped whenever
+// the raw data or the user profiles gain, lose or change a field, so older
+// snapshots are rejected instead of being rebuilt without the new data.
+// models.SchemaVersion versions the output instead and doesn't change when
+// fields are added.
+const Version = 1
+
+// Snapshot holds everything collected from the network during an analysis run,
+// so metrics can be re-aggregated and re-scored without fetching again.
+type Snapshot struct {
+	Version      int                               `json:"snapshot_version"`
+	CreatedAt    time.Time                         `json:"created_at"`
+	Start        *time.Time                        `json:"start,omitempty"`
+	End          *time.Time                        `json:"end,omitempty"`
+	Data         *models.RawData                   `json:"data"`
+	UserProfiles map[string]aggregator.UserProfile `json:"user_profiles"`
+}
+
+// New creates a snapshot of collected data for the given date range
+func New(data *models.RawData, profiles map[string]aggregator.UserProfile, dateRange *config.ParsedDateRange) *Snapshot {
+	s := &Snapshot{
+		Version:      Version,
+		CreatedAt:    time.Now(),
+		Data:         data,
+		UserProfiles: profiles,
+	}
+	if dateRange != nil {
+		s.Start = dateRange.Start
+		s.End = dateRange.End
+	}
+	return s
+}
+
+// DateRange returns the date range the snapshot was collected for
+func (s *Snapshot) DateRange() *config.ParsedDateRange {
+	return &config.ParsedDateRange{Start: s.Start, End: s.End}
+}
+
+// Path returns the snapshot file path within a cache directory
+func Path(directory string) string {
+	return filepath.Join(directory, FileName)
+}
+
+// Save writes the snapshot to the cache directory
+func Save(directory string, s *Snapshot) error {
+	if err := os.MkdirAll(directory, 0750); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	// Write atomically so an interrupted run never leaves a truncated snapshot
+	tmp := Path(directory) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, Path(directory))
+}
+
+// Load reads the snapshot from the cache directory
+func Load(directory string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Clean(Path(directory)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no raw data snapshot in %s; run analyze with caching enabled first", directory)
+		}
+		return nil, err
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("snapshot version %d does not match %d; run analyze again", s.Version, Version)
+	}
+	if s.Data == nil {
+		s.Data = &models.RawData{}
+	}
+	if s.UserProfiles == nil {
+		s.UserProfiles = make(map[string]aggregator.UserProfile)
+	}
+
+	return &s, nil
+}
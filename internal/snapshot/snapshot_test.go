@@ -0,0 +1,92 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/aggregator"
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/fingerprint"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mergeTime := 2 * time.Hour
+
+	data := &models.RawData{
+		Commits: []models.Commit{{SHA: "abc", Author: models.Author{Login: "alice"}, Repository: "org/repo"}},
+		PullRequests: []models.PullRequest{
+			{Number: 1, Author: models.Author{Login: "alice"}, State: models.PRStateMerged, TimeToMerge: &mergeTime},
+		},
+		LinearIssues: map[string]models.LinearIssue{"ENG-1": {Identifier: "ENG-1", StateType: "completed"}},
+	}
+	profiles := map[string]aggregator.UserProfile{"alice": {Login: "alice", Email: "alice@example.com"}}
+
+	require.NoError(t, Save(dir, New(data, profiles, &config.ParsedDateRange{Start: &start})))
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, Version, loaded.Version)
+	require.Len(t, loaded.Data.Commits, 1)
+	assert.Equal(t, "abc", loaded.Data.Commits[0].SHA)
+	require.Len(t, loaded.Data.PullRequests, 1)
+	require.NotNil(t, loaded.Data.PullRequests[0].TimeToMerge)
+	assert.Equal(t, mergeTime, *loaded.Data.PullRequests[0].TimeToMerge)
+	linearIssue := loaded.Data.LinearIssues["ENG-1"]
+	assert.True(t, linearIssue.IsCompleted())
+	assert.Equal(t, "alice@example.com", loaded.UserProfiles["alice"].Email)
+
+	dateRange := loaded.DateRange()
+	require.NotNil(t, dateRange.Start)
+	assert.True(t, start.Equal(*dateRange.Start))
+	assert.Nil(t, dateRange.End)
+}
+
+func TestLoad_Missing(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load(t.TempDir())
+	assert.ErrorContains(t, err, "no raw data snapshot")
+}
+
+func TestLoad_VersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"older version":                  fmt.Sprintf(`{"snapshot_version": %d}`, Version-1),
+		"newer version":                  fmt.Sprintf(`{"snapshot_version": %d}`, Version+1),
+		"versioned by the output schema": `{"schema_version": 1}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(Path(dir), []byte(content), 0600))
+
+			_, err := Load(dir)
+			assert.ErrorContains(t, err, "run analyze again")
+		})
+	}
+}
+
+func TestSnapshot_Versioned(t *testing.T) {
+	t.Parallel()
+
+	// When the raw data or the user profiles change, bump Version and
+	// record the new fingerprint and version here
+	const shape, version = "5d03a3a9c87fe260", 1
+
+	assert.Equal(t, shape, fingerprint.Types(Snapshot{}), "the snapshot changed: bump snapshot.Version")
+	assert.Equal(t, version, Version, "record the fingerprint of the new snapshot.Version")
+}
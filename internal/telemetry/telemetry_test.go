@@ -0,0 +1,66 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/internal/redact"
+)
+
+func TestSetup_Disabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), config.TelemetryConfig{})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_Enabled(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	shutdown, err := Setup(context.Background(), config.TelemetryConfig{
+		Enabled:  true,
+		Endpoint: "localhost:4318",
+		Insecure: true,
+		Headers:  map[string]string{"Authorization": "Bearer test"},
+	})
+	require.NoError(t, err)
+	_, isSDK := otel.GetTracerProvider().(*sdktrace.TracerProvider)
+	assert.True(t, isSDK)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestStartEnd(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, parent := Start(context.Background(), "analyze")
+	_, child := Start(ctx, "collect_repo", attribute.String("repository", "org/repo"))
+	redact.Register("telemetry-secret")
+	End(child, errors.New("clone failed: telemetry-secret"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	repo := spans[0]
+	assert.Equal(t, "collect_repo", repo.Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), repo.Parent().SpanID())
+	assert.Contains(t, repo.Attributes(), attribute.String("repository", "org/repo"))
+	assert.Equal(t, codes.Error, repo.Status().Code)
+	assert.Equal(t, "clone failed: ***", repo.Status().Description)
+
+	assert.Equal(t, "analyze", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
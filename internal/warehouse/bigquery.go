@@ -0,0 +1,172 @@
+package warehouse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+// DefaultBigQueryURL is the BigQuery API of Google Cloud
+const DefaultBigQueryURL = "https://bigquery.googleapis.com"
+
+// bigQueryBatch is the number of rows streamed per insertAll request, the
+// size BigQuery recommends
+const bigQueryBatch = 500
+
+// bigQueryTypes maps column types to BigQuery's
+var bigQueryTypes = map[string]string{
+	String:    "STRING",
+	Integer:   "INT64",
+	Float:     "FLOAT64",
+	Timestamp: "TIMESTAMP",
+}
+
+// BigQuery streams fact tables into a BigQuery dataset over the REST API
+type BigQuery struct {
+	endpoint   string
+	project    string
+	dataset    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewBigQuery creates a BigQuery exporter. The token must be resolved first.
+func NewBigQuery(cfg config.BigQueryConfig) *BigQuery {
+	endpoint := cfg.URL
+	if endpoint == "" {
+		endpoint = DefaultBigQueryURL
+	}
+	return &BigQuery{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		project:    cfg.Project,
+		dataset:    cfg.Dataset,
+		token:      cfg.Token,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// SetTransport sends requests through transport, for proxy and TLS settings
+func (b *BigQuery) SetTransport(transport http.RoundTripper) {
+	b.httpClient.Transport = transport
+}
+
+// Name identifies the exporter in logs
+func (b *BigQuery) Name() string {
+	return "BigQuery"
+}
+
+// Export creates the tables that don't exist yet and streams the rows into them
+func (b *BigQuery) Export(ctx context.Context, tables []Table) error {
+	for _, table := range tables {
+		if err := b.ensureTable(ctx, table); err != nil {
+			return fmt.Errorf("table %s: %w", table.Name, err)
+		}
+		for start := 0; start < len(table.Rows); start += bigQueryBatch {
+			rows := table.Rows[start:min(start+bigQueryBatch, len(table.Rows))]
+			if err := b.insert(ctx, table, rows); err != nil {
+				return fmt.Errorf("table %s: %w", table.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *BigQuery) tablesURL() string {
+	return fmt.Sprintf("%s/bigquery/v2/projects/%s/datasets/%s/tables", b.endpoint, url.PathEscape(b.project), url.PathEscape(b.dataset))
+}
+
+func (b *BigQuery) headers() map[string]string {
+	return map[string]string{"Authorization": "Bearer " + b.token}
+}
+
+// ensureTable creates a table unless it exists. Existing tables are left as
+// they are, so columns added to them by hand are kept.
+func (b *BigQuery) ensureTable(ctx context.Context, table Table) error {
+	status, err := doJSON(ctx, b.httpClient, "BigQuery", http.MethodGet, b.tablesURL()+"/"+url.PathEscape(table.Name),
+		b.headers(), nil, nil, bigQueryMessage, http.StatusOK, http.StatusNotFound)
+	if err != nil || status == http.StatusOK {
+		return err
+	}
+
+	type field struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+		Mode string `json:"mode"`
+	}
+	fields := make([]field, len(table.Columns))
+	for i, c := range table.Columns {
+		fields[i] = field{Name: c.Name, Type: bigQueryTypes[c.Type], Mode: "NULLABLE"}
+	}
+	body := map[string]any{
+		"tableReference": map[string]string{"projectId": b.project, "datasetId": b.dataset, "tableId": table.Name},
+		"schema":         map[string]any{"fields": fields},
+	}
+	// A concurrent run may have created it in the meantime
+	_, err = doJSON(ctx, b.httpClient, "BigQuery", http.MethodPost, b.tablesURL(),
+		b.headers(), body, nil, bigQueryMessage, http.StatusOK, http.StatusConflict)
+	return err
+}
+
+// insert streams rows into a table, failing when BigQuery rejects any of them
+func (b *BigQuery) insert(ctx context.Context, table Table, rows [][]any) error {
+	type row struct {
+		JSON map[string]any `json:"json"`
+	}
+	body := struct {
+		Rows []row `json:"rows"`
+	}{Rows: make([]row, len(rows))}
+	for i, values := range rows {
+		r := make(map[string]any, len(table.Columns))
+		for j, c := range table.Columns {
+			if t, ok := values[j].(time.Time); ok {
+				r[c.Name] = t.UTC().Format("2006-01-02 15:04:05.000000 UTC")
+			} else {
+				r[c.Name] = values[j]
+			}
+		}
+		body.Rows[i] = row{JSON: r}
+	}
+
+	var result struct {
+		InsertErrors []struct {
+			Index  int `json:"index"`
+			Errors []struct {
+				Reason  string `json:"reason"`
+				Message string `json:"message"`
+			} `json:"errors"`
+		} `json:"insertErrors"`
+	}
+	if _, err := doJSON(ctx, b.httpClient, "BigQuery", http.MethodPost, b.tablesURL()+"/"+url.PathEscape(table.Name)+"/insertAll",
+		b.headers(), body, &result, bigQueryMessage, http.StatusOK); err != nil {
+		return err
+	}
+	if len(result.InsertErrors) > 0 {
+		first := result.InsertErrors[0]
+		message := "rejected"
+		if len(first.Errors) > 0 {
+			message = first.Errors[0].Message
+		}
+		return fmt.Errorf("%d of %d rows rejected, the first: %s", len(result.InsertErrors), len(rows), message)
+	}
+	return nil
+}
+
+// bigQueryMessage reads the message of a Google API error response
+func bigQueryMessage(body []byte) string {
+	var e struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &e) == nil && e.Error.Message != "" {
+		return e.Error.Message
+	}
+	return string(body)
+}
@@ -0,0 +1,126 @@
+package warehouse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+// fakeBigQuery serves the table and insertAll endpoints of one dataset
+type fakeBigQuery struct {
+	mu       sync.Mutex
+	tables   map[string][]string // Table ID -> column types
+	rows     map[string][]map[string]any
+	rejected bool // Reject every inserted row
+}
+
+func (f *fakeBigQuery) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer ya29.token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": {"code": 401, "message": "Request had invalid authentication credentials."}}`))
+		return
+	}
+	const prefix = "/bigquery/v2/projects/acme/datasets/velocity/tables"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path != prefix:
+		if _, ok := f.tables[r.URL.Path[len(prefix)+1:]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Not found: Table"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	case r.Method == http.MethodPost && r.URL.Path == prefix:
+		var body struct {
+			TableReference struct {
+				TableID string `json:"tableId"`
+			} `json:"tableReference"`
+			Schema struct {
+				Fields []struct {
+					Type string `json:"type"`
+				} `json:"fields"`
+			} `json:"schema"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		var types []string
+		for _, field := range body.Schema.Fields {
+			types = append(types, field.Type)
+		}
+		f.tables[body.TableReference.TableID] = types
+		_, _ = w.Write([]byte(`{}`))
+	case r.Method == http.MethodPost:
+		table := r.URL.Path[len(prefix)+1 : len(r.URL.Path)-len("/insertAll")]
+		var body struct {
+			Rows []struct {
+				JSON map[string]any `json:"json"`
+			} `json:"rows"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if f.rejected {
+			_, _ = w.Write([]byte(`{"insertErrors": [{"index": 0, "errors": [{"reason": "invalid", "message": "no such field: login"}]}]}`))
+			return
+		}
+		for _, row := range body.Rows {
+			f.rows[table] = append(f.rows[table], row.JSON)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func newFakeBigQuery(t *testing.T) (*fakeBigQuery, *BigQuery) {
+	t.Helper()
+	fake := &fakeBigQuery{tables: map[string][]string{}, rows: map[string][]map[string]any{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, NewBigQuery(config.BigQueryConfig{Enabled: true, Project: "acme", Dataset: "velocity", Token: "ya29.token", URL: server.URL})
+}
+
+func TestBigQuery_Export(t *testing.T) {
+	t.Parallel()
+
+	fake, bq := newFakeBigQuery(t)
+	tables := Facts(factsMetrics(), config.DefaultConfig(), runAt)
+	require.NoError(t, bq.Export(context.Background(), tables))
+
+	require.Contains(t, fake.tables, config.DefaultContributorsTable)
+	assert.Equal(t, []string{"TIMESTAMP", "STRING", "TIMESTAMP", "TIMESTAMP", "STRING"}, fake.tables[config.DefaultContributorsTable][:5])
+
+	rows := fake.rows[config.DefaultContributorsTable]
+	require.Len(t, rows, 2)
+	assert.Equal(t, "alice", rows[0]["login"])
+	assert.Equal(t, "2024-04-01 06:30:00.000000 UTC", rows[0]["run_at"])
+	assert.EqualValues(t, 12, rows[0]["commits"])
+	assert.Len(t, fake.rows[config.DefaultPeriodsTable], 1)
+
+	// A second run appends to the existing tables
+	require.NoError(t, bq.Export(context.Background(), tables))
+	assert.Len(t, fake.rows[config.DefaultContributorsTable], 4)
+}
+
+func TestBigQuery_Errors(t *testing.T) {
+	t.Parallel()
+
+	tables := Facts(factsMetrics(), config.DefaultConfig(), runAt)
+
+	fake, bq := newFakeBigQuery(t)
+	fake.rejected = true
+	err := bq.Export(context.Background(), tables)
+	assert.ErrorContains(t, err, "table velocity_contributors: 1 of 2 rows rejected, the first: no such field: login")
+
+	_, bq = newFakeBigQuery(t)
+	bq.token = "expired"
+	err = bq.Export(context.Background(), tables)
+	assert.ErrorContains(t, err, "BigQuery API returned status 401: Request had invalid authentication credentials.")
+}
@@ -0,0 +1,78 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/pkg/version"
+)
+
+// maxErrorBody bounds how much of an error response is read for its message
+const maxErrorBody = 64 << 10
+
+// apiError is an unexpected response of a warehouse API
+type apiError struct {
+	api     string
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	if e.message == "" {
+		return fmt.Sprintf("%s API returned status %d", e.api, e.status)
+	}
+	return fmt.Sprintf("%s API returned status %d: %s", e.api, e.status, e.message)
+}
+
+// doJSON sends body as JSON with the given headers and decodes a successful
+// response into out, if not nil. Responses with another status than the
+// accepted ones are returned as an *apiError, its message read by message.
+func doJSON(ctx context.Context, client *http.Client, api, method, url string, headers map[string]string, body, out any, message func([]byte) string, accepted ...int) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "git-velocity/"+version.Version)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%s request failed: %w", api, err)
+	}
+	defer resp.Body.Close()
+
+	for _, status := range accepted {
+		if resp.StatusCode != status {
+			continue
+		}
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return resp.StatusCode, fmt.Errorf("failed to decode %s response: %w", api, err)
+			}
+		}
+		return resp.StatusCode, nil
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return resp.StatusCode, &apiError{api: api, status: resp.StatusCode, message: strings.TrimSpace(message(data))}
+}
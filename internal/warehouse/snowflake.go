@@ -0,0 +1,206 @@
+package warehouse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+// snowflakeBatch is the number of rows bound per INSERT statement
+const snowflakeBatch = 1000
+
+// snowflakeStatementTimeout is how long, in seconds, Snowflake may run a statement
+const snowflakeStatementTimeout = 300
+
+// snowflakePollInterval is how often a statement still running is checked on
+const snowflakePollInterval = 2 * time.Second
+
+// snowflakeTypes maps column types to Snowflake's
+var snowflakeTypes = map[string]string{
+	String:    "VARCHAR",
+	Integer:   "NUMBER(38,0)",
+	Float:     "FLOAT",
+	Timestamp: "TIMESTAMP_TZ",
+}
+
+// snowflakeBindings maps column types to the SQL API's binding types.
+// Timestamps are bound as text, which Snowflake casts on insert.
+var snowflakeBindings = map[string]string{
+	String:    "TEXT",
+	Integer:   "FIXED",
+	Float:     "REAL",
+	Timestamp: "TEXT",
+}
+
+// Snowflake inserts fact tables through the Snowflake SQL API
+type Snowflake struct {
+	endpoint     string
+	cfg          config.SnowflakeConfig
+	httpClient   *http.Client
+	pollInterval time.Duration
+}
+
+// NewSnowflake creates a Snowflake exporter. The token must be resolved first.
+func NewSnowflake(cfg config.SnowflakeConfig) *Snowflake {
+	endpoint := cfg.URL
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.snowflakecomputing.com", cfg.Account)
+	}
+	if cfg.TokenType == "" {
+		cfg.TokenType = config.SnowflakeOAuth
+	}
+	return &Snowflake{
+		endpoint:     strings.TrimSuffix(endpoint, "/"),
+		cfg:          cfg,
+		httpClient:   &http.Client{Timeout: 60 * time.Second},
+		pollInterval: snowflakePollInterval,
+	}
+}
+
+// SetTransport sends requests through transport, for proxy and TLS settings
+func (s *Snowflake) SetTransport(transport http.RoundTripper) {
+	s.httpClient.Transport = transport
+}
+
+// Name identifies the exporter in logs
+func (s *Snowflake) Name() string {
+	return "Snowflake"
+}
+
+// Export creates the tables that don't exist yet and inserts the rows into them
+func (s *Snowflake) Export(ctx context.Context, tables []Table) error {
+	for _, table := range tables {
+		if err := s.execute(ctx, createTable(table), nil); err != nil {
+			return fmt.Errorf("table %s: %w", table.Name, err)
+		}
+		for start := 0; start < len(table.Rows); start += snowflakeBatch {
+			rows := table.Rows[start:min(start+snowflakeBatch, len(table.Rows))]
+			if err := s.execute(ctx, insertRows(table), bindRows(table, rows)); err != nil {
+				return fmt.Errorf("table %s: %w", table.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// createTable returns the statement creating a table unless it exists
+func createTable(table Table) string {
+	columns := make([]string, len(table.Columns))
+	for i, c := range table.Columns {
+		columns[i] = c.Name + " " + snowflakeTypes[c.Type]
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", table.Name, strings.Join(columns, ", "))
+}
+
+// insertRows returns the statement inserting rows bound by position
+func insertRows(table Table) string {
+	columns := make([]string, len(table.Columns))
+	for i, c := range table.Columns {
+		columns[i] = c.Name
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.Name, strings.Join(columns, ", "), placeholders)
+}
+
+// snowflakeBinding binds the values of a column, one per row
+type snowflakeBinding struct {
+	Type  string    `json:"type"`
+	Value []*string `json:"value"`
+}
+
+// bindRows binds rows to the placeholders of insertRows as arrays, inserting
+// them all with one statement. The SQL API takes every value as a string.
+func bindRows(table Table, rows [][]any) map[string]snowflakeBinding {
+	bindings := make(map[string]snowflakeBinding, len(table.Columns))
+	for i, c := range table.Columns {
+		values := make([]*string, len(rows))
+		for j, row := range rows {
+			var v string
+			switch value := row[i].(type) {
+			case nil:
+				continue
+			case string:
+				v = value
+			case int:
+				v = strconv.Itoa(value)
+			case float64:
+				v = strconv.FormatFloat(value, 'g', -1, 64)
+			case time.Time:
+				v = value.Format(time.RFC3339Nano)
+			default:
+				v = fmt.Sprint(value)
+			}
+			values[j] = &v
+		}
+		bindings[strconv.Itoa(i+1)] = snowflakeBinding{Type: snowflakeBindings[c.Type], Value: values}
+	}
+	return bindings
+}
+
+// snowflakeStatus is the response to a statement, finished or not
+type snowflakeStatus struct {
+	Message            string `json:"message"`
+	StatementHandle    string `json:"statementHandle"`
+	StatementStatusURL string `json:"statementStatusUrl"`
+}
+
+// execute runs a statement, waiting for it to finish
+func (s *Snowflake) execute(ctx context.Context, statement string, bindings map[string]snowflakeBinding) error {
+	body := map[string]any{
+		"statement": statement,
+		"timeout":   snowflakeStatementTimeout,
+		"database":  s.cfg.Database,
+		"schema":    s.cfg.Schema,
+	}
+	if s.cfg.Warehouse != "" {
+		body["warehouse"] = s.cfg.Warehouse
+	}
+	if s.cfg.Role != "" {
+		body["role"] = s.cfg.Role
+	}
+	if bindings != nil {
+		body["bindings"] = bindings
+	}
+
+	var status snowflakeStatus
+	code, err := doJSON(ctx, s.httpClient, "Snowflake", http.MethodPost, s.endpoint+"/api/v2/statements",
+		s.headers(), body, &status, snowflakeMessage, http.StatusOK, http.StatusAccepted)
+	// Statements running longer than the API waits for are polled
+	for err == nil && code == http.StatusAccepted {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.pollInterval):
+		}
+		next := s.endpoint + status.StatementStatusURL
+		if status.StatementStatusURL == "" {
+			next = s.endpoint + "/api/v2/statements/" + status.StatementHandle
+		}
+		code, err = doJSON(ctx, s.httpClient, "Snowflake", http.MethodGet, next,
+			s.headers(), nil, &status, snowflakeMessage, http.StatusOK, http.StatusAccepted)
+	}
+	return err
+}
+
+func (s *Snowflake) headers() map[string]string {
+	return map[string]string{
+		"Authorization":                        "Bearer " + s.cfg.Token,
+		"X-Snowflake-Authorization-Token-Type": s.cfg.TokenType,
+	}
+}
+
+// snowflakeMessage reads the message of a SQL API error response
+func snowflakeMessage(body []byte) string {
+	var status snowflakeStatus
+	if json.Unmarshal(body, &status) == nil && status.Message != "" {
+		return status.Message
+	}
+	return string(body)
+}
@@ -0,0 +1,128 @@
+package warehouse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+)
+
+// snowflakeRequest is a statement submitted to the SQL API
+type snowflakeRequest struct {
+	Statement string                      `json:"statement"`
+	Database  string                      `json:"database"`
+	Schema    string                      `json:"schema"`
+	Warehouse string                      `json:"warehouse"`
+	Bindings  map[string]snowflakeBinding `json:"bindings"`
+}
+
+// fakeSnowflake records submitted statements. The first one is reported as
+// still running until it is polled once.
+type fakeSnowflake struct {
+	mu         sync.Mutex
+	statements []snowflakeRequest
+	tokenType  string
+	polled     bool
+	fail       string // Statements starting with it fail
+}
+
+func (f *fakeSnowflake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.tokenType = r.Header.Get("X-Snowflake-Authorization-Token-Type")
+	if r.Method == http.MethodGet {
+		f.polled = true
+		_, _ = w.Write([]byte(`{"message": "Statement executed successfully."}`))
+		return
+	}
+
+	var req snowflakeRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	f.statements = append(f.statements, req)
+	switch {
+	case f.fail != "" && strings.HasPrefix(req.Statement, f.fail):
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"code": "002003", "message": "SQL compilation error: Object 'VELOCITY_CONTRIBUTORS' does not exist or not authorized."}`))
+	case len(f.statements) == 1:
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"statementHandle": "01b2", "statementStatusUrl": "/api/v2/statements/01b2"}`))
+	default:
+		_, _ = w.Write([]byte(`{"message": "Statement executed successfully."}`))
+	}
+}
+
+func newFakeSnowflake(t *testing.T) (*fakeSnowflake, *Snowflake) {
+	t.Helper()
+	fake := &fakeSnowflake{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	sf := NewSnowflake(config.SnowflakeConfig{
+		Enabled: true, Database: "ENG", Schema: "VELOCITY", Warehouse: "REPORTING",
+		Token: "token", URL: server.URL,
+	})
+	sf.pollInterval = time.Millisecond
+	return fake, sf
+}
+
+func TestSnowflake_Export(t *testing.T) {
+	t.Parallel()
+
+	fake, sf := newFakeSnowflake(t)
+	tables := Facts(factsMetrics(), config.DefaultConfig(), runAt)
+	require.NoError(t, sf.Export(context.Background(), tables))
+
+	assert.True(t, fake.polled, "a statement still running is polled")
+	assert.Equal(t, config.SnowflakeOAuth, fake.tokenType)
+	// A CREATE and an INSERT per table
+	require.Len(t, fake.statements, 6)
+	create, insert := fake.statements[0], fake.statements[1]
+	assert.Equal(t, "ENG", create.Database)
+	assert.Equal(t, "VELOCITY", create.Schema)
+	assert.Equal(t, "REPORTING", create.Warehouse)
+	assert.Contains(t, create.Statement, "CREATE TABLE IF NOT EXISTS velocity_contributors (run_at TIMESTAMP_TZ, period_label VARCHAR")
+	assert.Contains(t, insert.Statement, "INSERT INTO velocity_contributors (run_at, period_label, period_start, period_end, login,")
+	assert.Nil(t, create.Bindings)
+
+	// Rows are bound as arrays per column
+	require.Len(t, insert.Bindings, len(contributorColumns))
+	login := insert.Bindings["5"]
+	assert.Equal(t, "TEXT", login.Type)
+	require.Len(t, login.Value, 2)
+	assert.Equal(t, "alice", *login.Value[0])
+	assert.Equal(t, "bob", *login.Value[1])
+	assert.Equal(t, "2024-04-01T06:30:00Z", *insert.Bindings["1"].Value[0])
+	assert.Equal(t, "FIXED", insert.Bindings["8"].Type)
+	assert.Equal(t, "12", *insert.Bindings["8"].Value[0])
+}
+
+func TestSnowflake_Errors(t *testing.T) {
+	t.Parallel()
+
+	fake, sf := newFakeSnowflake(t)
+	fake.fail = "INSERT"
+	err := sf.Export(context.Background(), Facts(factsMetrics(), config.DefaultConfig(), runAt))
+	assert.ErrorContains(t, err, "table velocity_contributors: Snowflake API returned status 422: SQL compilation error")
+}
+
+func TestBindRows_Null(t *testing.T) {
+	t.Parallel()
+
+	table := Table{Columns: []Column{{"period_start", Timestamp}, {"score", Float}}}
+	bindings := bindRows(table, [][]any{{nil, 1.5}})
+	assert.Nil(t, bindings["1"].Value[0])
+	assert.Equal(t, "1.5", *bindings["2"].Value[0])
+
+	data, err := json.Marshal(bindings["1"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "TEXT", "value": [null]}`, string(data))
+}
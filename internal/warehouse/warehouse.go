@@ -0,0 +1,167 @@
+// Package warehouse exports the metrics of a run to data warehouses as fact
+// tables: a row per contributor, per repository, and a row of totals. Rows
+// are appended on every run and carry the run's time and period, so the
+// tables build up a history that can be joined with HR or delivery data.
+package warehouse
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+// Column types, mapped to each warehouse's own
+const (
+	String    = "string"
+	Integer   = "integer"
+	Float     = "float"
+	Timestamp = "timestamp"
+)
+
+// Column is a column of a fact table
+type Column struct {
+	Name string
+	Type string // One of the column types
+}
+
+// Table is a fact table and the rows a run adds to it. Values are strings,
+// ints, float64s or time.Times as their column's type, or nil for NULL.
+type Table struct {
+	Name    string
+	Columns []Column
+	Rows    [][]any
+}
+
+// Exporter loads fact tables into a warehouse, creating missing tables
+type Exporter interface {
+	Name() string
+	Export(ctx context.Context, tables []Table) error
+}
+
+// periodColumns start every table: when the run happened and what it covered
+var periodColumns = []Column{
+	{"run_at", Timestamp},
+	{"period_label", String},
+	{"period_start", Timestamp}, // NULL for open-ended periods
+	{"period_end", Timestamp},
+}
+
+var contributorColumns = append(append([]Column(nil), periodColumns...),
+	Column{"login", String},
+	Column{"name", String},
+	Column{"team", String},
+	Column{"commits", Integer},
+	Column{"lines_added", Integer},
+	Column{"lines_deleted", Integer},
+	Column{"prs_opened", Integer},
+	Column{"prs_merged", Integer},
+	Column{"prs_closed", Integer},
+	Column{"reviews_given", Integer},
+	Column{"review_comments", Integer},
+	Column{"issues_opened", Integer},
+	Column{"issues_closed", Integer},
+	Column{"active_days", Integer},
+	Column{"avg_time_to_merge_hours", Float},
+	Column{"avg_review_time_hours", Float},
+	Column{"score", Integer},
+	Column{"rank", Integer},
+)
+
+var repositoryColumns = append(append([]Column(nil), periodColumns...),
+	Column{"repository", String},
+	Column{"commits", Integer},
+	Column{"prs", Integer},
+	Column{"reviews", Integer},
+	Column{"issues", Integer},
+	Column{"active_contributors", Integer},
+	Column{"lines_added", Integer},
+	Column{"lines_deleted", Integer},
+	Column{"score", Integer},
+)
+
+var periodTotalColumns = append(append([]Column(nil), periodColumns...),
+	Column{"contributors", Integer},
+	Column{"repositories", Integer},
+	Column{"commits", Integer},
+	Column{"prs", Integer},
+	Column{"reviews", Integer},
+	Column{"lines_added", Integer},
+	Column{"lines_deleted", Integer},
+	Column{"score", Integer},
+)
+
+// Facts builds the fact tables of a run. Contributors who opted out are
+// left out of the contributor table, though their work is in the totals.
+func Facts(metrics *models.GlobalMetrics, cfg *config.Config, runAt time.Time) []Table {
+	runAt = runAt.UTC()
+	var start any
+	if !metrics.Period.Start.IsZero() {
+		start = metrics.Period.Start.UTC()
+	}
+	period := []any{runAt, metrics.Period.Label, start, metrics.Period.End.UTC()}
+	row := func(values ...any) []any {
+		return append(append(make([]any, 0, len(period)+len(values)), period...), values...)
+	}
+
+	contributors := Table{Name: cfg.Warehouse.Tables.ContributorsTable(), Columns: contributorColumns, Rows: [][]any{}}
+	score := 0
+	for _, c := range metrics.Contributors {
+		score += c.Score.Total
+		if c.OptedOut {
+			continue
+		}
+		team := ""
+		if t := cfg.GetTeamForUser(c.Login); t != nil {
+			team = t.Name
+		}
+		contributors.Rows = append(contributors.Rows, row(
+			c.Login, c.Name, team,
+			c.CommitCount, c.LinesAdded, c.LinesDeleted,
+			c.PRsOpened, c.PRsMerged, c.PRsClosed,
+			c.ReviewsGiven, c.ReviewComments,
+			c.IssuesOpened, c.IssuesClosed,
+			c.ActiveDays, c.AvgTimeToMerge, c.AvgReviewTime,
+			c.Score.Total, c.Score.Rank,
+		))
+	}
+
+	repositories := Table{Name: cfg.Warehouse.Tables.RepositoriesTable(), Columns: repositoryColumns, Rows: [][]any{}}
+	for _, r := range metrics.Repositories {
+		repoScore := 0
+		for _, c := range r.Contributors {
+			repoScore += c.Score.Total
+		}
+		repositories.Rows = append(repositories.Rows, row(
+			r.FullName, r.TotalCommits, r.TotalPRs, r.TotalReviews, r.TotalIssues,
+			r.ActiveContributors, r.TotalLinesAdded, r.TotalLinesDeleted, repoScore,
+		))
+	}
+
+	periods := Table{Name: cfg.Warehouse.Tables.PeriodsTable(), Columns: periodTotalColumns, Rows: [][]any{row(
+		metrics.TotalContributors, len(metrics.Repositories),
+		metrics.TotalCommits, metrics.TotalPRs, metrics.TotalReviews,
+		metrics.TotalLinesAdded, metrics.TotalLinesDeleted, score,
+	)}}
+
+	return []Table{contributors, repositories, periods}
+}
+
+// Exporters returns an exporter for every enabled warehouse, sending
+// requests through transport
+func Exporters(cfg config.WarehouseConfig, transport http.RoundTripper) []Exporter {
+	var exporters []Exporter
+	if cfg.BigQuery.Enabled {
+		bq := NewBigQuery(cfg.BigQuery)
+		bq.SetTransport(transport)
+		exporters = append(exporters, bq)
+	}
+	if cfg.Snowflake.Enabled {
+		sf := NewSnowflake(cfg.Snowflake)
+		sf.SetTransport(transport)
+		exporters = append(exporters, sf)
+	}
+	return exporters
+}
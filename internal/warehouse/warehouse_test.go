@@ -0,0 +1,110 @@
+package warehouse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/git-velocity/internal/config"
+	"github.com/lukaszraczylo/git-velocity/pkg/models"
+)
+
+var runAt = time.Date(2024, 4, 1, 6, 30, 0, 0, time.UTC)
+
+func factsMetrics() *models.GlobalMetrics {
+	alice := models.ContributorMetrics{Login: "alice", Name: "Alice", CommitCount: 12, PRsMerged: 3, AvgTimeToMerge: 5.5, Score: models.Score{Total: 300, Rank: 1}}
+	bob := models.ContributorMetrics{Login: "bob", CommitCount: 4, Score: models.Score{Total: 80, Rank: 2}}
+	carol := models.ContributorMetrics{Login: "carol", CommitCount: 1, OptedOut: true, Score: models.Score{Total: 10}}
+	return &models.GlobalMetrics{
+		Period:       models.Period{Label: "March 2024", Start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		Contributors: []models.ContributorMetrics{alice, bob, carol},
+		Repositories: []models.RepositoryMetrics{
+			{FullName: "acme/api", TotalCommits: 13, TotalIssues: 2, ActiveContributors: 2, Contributors: []models.ContributorMetrics{alice, carol}},
+			{FullName: "acme/web", TotalCommits: 4, ActiveContributors: 1, Contributors: []models.ContributorMetrics{bob}},
+		},
+		TotalContributors: 3,
+		TotalCommits:      17,
+	}
+}
+
+// column returns the value of a named column of a row
+func column(t *testing.T, table Table, row int, name string) any {
+	t.Helper()
+	for i, c := range table.Columns {
+		if c.Name == name {
+			return table.Rows[row][i]
+		}
+	}
+	t.Fatalf("no column %s in %s", name, table.Name)
+	return nil
+}
+
+func TestFacts(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Teams = []config.TeamConfig{{Name: "Platform", Members: []string{"alice"}}}
+	cfg.Warehouse.Tables.Contributors = "people"
+
+	tables := Facts(factsMetrics(), cfg, runAt.In(time.FixedZone("CEST", 2*3600)))
+	require.Len(t, tables, 3)
+	contributors, repositories, periods := tables[0], tables[1], tables[2]
+	assert.Equal(t, "people", contributors.Name)
+	assert.Equal(t, config.DefaultRepositoriesTable, repositories.Name)
+	assert.Equal(t, config.DefaultPeriodsTable, periods.Name)
+
+	for _, table := range tables {
+		for _, row := range table.Rows {
+			require.Len(t, row, len(table.Columns), table.Name)
+		}
+		assert.Equal(t, runAt, column(t, table, 0, "run_at"))
+		assert.Equal(t, "March 2024", column(t, table, 0, "period_label"))
+	}
+
+	// Opted-out contributors have no row of their own
+	require.Len(t, contributors.Rows, 2)
+	assert.Equal(t, "alice", column(t, contributors, 0, "login"))
+	assert.Equal(t, "Platform", column(t, contributors, 0, "team"))
+	assert.Equal(t, 12, column(t, contributors, 0, "commits"))
+	assert.Equal(t, 5.5, column(t, contributors, 0, "avg_time_to_merge_hours"))
+	assert.Equal(t, 1, column(t, contributors, 0, "rank"))
+	assert.Equal(t, "", column(t, contributors, 1, "team"))
+
+	require.Len(t, repositories.Rows, 2)
+	assert.Equal(t, "acme/api", column(t, repositories, 0, "repository"))
+	assert.Equal(t, 2, column(t, repositories, 0, "issues"))
+	assert.Equal(t, 310, column(t, repositories, 0, "score"))
+
+	// Totals include everyone
+	require.Len(t, periods.Rows, 1)
+	assert.Equal(t, 3, column(t, periods, 0, "contributors"))
+	assert.Equal(t, 2, column(t, periods, 0, "repositories"))
+	assert.Equal(t, 390, column(t, periods, 0, "score"))
+}
+
+func TestFacts_OpenPeriod(t *testing.T) {
+	t.Parallel()
+
+	metrics := &models.GlobalMetrics{Period: models.Period{Label: "All Time", End: runAt}}
+	tables := Facts(metrics, config.DefaultConfig(), runAt)
+	assert.Nil(t, column(t, tables[2], 0, "period_start"))
+	assert.Equal(t, runAt, column(t, tables[2], 0, "period_end"))
+	assert.Empty(t, tables[0].Rows)
+	assert.NotNil(t, tables[0].Rows)
+}
+
+func TestExporters(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, Exporters(config.WarehouseConfig{}, nil))
+
+	exporters := Exporters(config.WarehouseConfig{
+		BigQuery:  config.BigQueryConfig{Enabled: true},
+		Snowflake: config.SnowflakeConfig{Enabled: true},
+	}, nil)
+	require.Len(t, exporters, 2)
+	assert.Equal(t, "BigQuery", exporters[0].Name())
+	assert.Equal(t, "Snowflake", exporters[1].Name())
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// RepositoryAdoption is a repository's audience and when it grew, fetched
+// when options.adoption is enabled
+type RepositoryAdoption struct {
+	// Totals at the time of the run
+	Stars    int `json:"stars"`
+	Forks    int `json:"forks"`
+	Watchers int `json:"watchers"`
+
+	// Times of the stars and forks since the period start, including those
+	// after its end
+	StarredAt []time.Time `json:"starred_at,omitempty"`
+	ForkedAt  []time.Time `json:"forked_at,omitempty"`
+
+	// The page limit was reached before the period start, so the earliest
+	// stars or forks of the period are missing
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// AdoptionMetrics is a repository's growth over the period next to its development activity
+type AdoptionMetrics struct {
+	// Stars and forks at the end of the period, and the current watchers
+	Stars    int `json:"stars"`
+	Forks    int `json:"forks"`
+	Watchers int `json:"watchers"`
+
+	StarsGained int  `json:"stars_gained"`
+	ForksGained int  `json:"forks_gained"`
+	Truncated   bool `json:"truncated,omitempty"` // Gains are lower bounds
+
+	// Weekly new stars and forks, commits and merged PRs
+	Timeline *VelocityTimeline `json:"timeline,omitempty"`
+}
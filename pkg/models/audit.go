@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AuditEvent is an organization audit-log entry tied to a repository
+type AuditEvent struct {
+	Action     string    `json:"action"`     // e.g. protected_branch.policy_override
+	Actor      string    `json:"actor"`      // Login of the user who triggered the event
+	Repository string    `json:"repository"` // owner/name
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AuditSummary counts the audit-log events recorded for a repository
+type AuditSummary struct {
+	BranchProtectionOverrides int            `json:"branch_protection_overrides"` // Admin bypasses of branch protection
+	RejectedPushes            int            `json:"rejected_pushes"`             // Pushes blocked by branch protection
+	ForcePushPolicyChanges    int            `json:"force_push_policy_changes"`   // Force-push setting changes on protected branches
+	Events                    map[string]int `json:"events,omitempty"`            // Count per audit-log action
+}
@@ -0,0 +1,18 @@
+package models
+
+// BotActivity is an identity filtered out as a bot during a run, with the
+// pattern it matched and how much of its activity was dropped
+type BotActivity struct {
+	Login         string `json:"login"`
+	Pattern       string `json:"pattern"`
+	Commits       int    `json:"commits"`
+	PullRequests  int    `json:"pull_requests"`
+	Reviews       int    `json:"reviews"`
+	Issues        int    `json:"issues"`
+	IssueComments int    `json:"issue_comments"`
+}
+
+// Total returns the number of activities dropped for the identity
+func (b *BotActivity) Total() int {
+	return b.Commits + b.PullRequests + b.Reviews + b.Issues + b.IssueComments
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// GitHub author associations of pull requests and issues
+const (
+	AssociationOwner                = "OWNER"
+	AssociationMember               = "MEMBER"
+	AssociationCollaborator         = "COLLABORATOR"
+	AssociationContributor          = "CONTRIBUTOR"            // Has contributed to the repository before
+	AssociationFirstTimeContributor = "FIRST_TIME_CONTRIBUTOR" // First contribution to the repository
+	AssociationFirstTimer           = "FIRST_TIMER"            // First contribution to GitHub
+)
+
+// CommunityMetrics summarizes the pull requests and issues of contributors
+// who aren't maintainers, and how quickly maintainers respond to them
+type CommunityMetrics struct {
+	Contributors int `json:"contributors"` // Community members opening PRs or issues
+
+	// Community members whose pull requests in the period were their first to
+	// the repository, and those who had contributed before
+	FirstTimeContributors []CommunityContributor `json:"first_time_contributors,omitempty"`
+	ReturningContributors []CommunityContributor `json:"returning_contributors,omitempty"`
+
+	PullRequests       int `json:"pull_requests"`
+	PullRequestsMerged int `json:"pull_requests_merged"`
+	Issues             int `json:"issues"`
+
+	// Mean hours to the first maintainer review or comment, for PRs from
+	// when they were ready for review
+	AvgPRResponseHours    float64 `json:"avg_pr_response_hours"`
+	AvgIssueResponseHours float64 `json:"avg_issue_response_hours"`
+
+	// Open community PRs and issues no maintainer has responded to yet,
+	// excluding drafts
+	AwaitingResponse int `json:"awaiting_response"`
+
+	// Maintainers by the community PRs and issues they responded to first
+	Responsiveness []MaintainerResponsiveness `json:"responsiveness,omitempty"`
+}
+
+// CommunityContributor is a community member's activity in the period
+type CommunityContributor struct {
+	Login              string    `json:"login"`
+	AvatarURL          string    `json:"avatar_url,omitempty"`
+	PullRequests       int       `json:"pull_requests"`
+	PullRequestsMerged int       `json:"pull_requests_merged"`
+	Issues             int       `json:"issues"`
+	FirstContribution  time.Time `json:"first_contribution"` // Earliest PR or issue in the period
+}
+
+// MaintainerResponsiveness is how often and how quickly a maintainer was the
+// first to respond to the community
+type MaintainerResponsiveness struct {
+	Login            string  `json:"login"`
+	Responses        int     `json:"responses"`
+	AvgResponseHours float64 `json:"avg_response_hours"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// PolicyViolation is a merged PR that bypassed review
+type PolicyViolation struct {
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	Author     string    `json:"author"`
+	MergedBy   string    `json:"merged_by,omitempty"`
+	MergedAt   time.Time `json:"merged_at"`
+	Unapproved bool      `json:"unapproved"` // Merged without an approval
+	Overridden bool      `json:"overridden"` // Merged by its author over a change request
+}
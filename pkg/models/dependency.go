@@ -0,0 +1,43 @@
+package models
+
+// Package ecosystems read from repository manifests
+const (
+	EcosystemGo  = "go"  // go.mod
+	EcosystemNPM = "npm" // package.json
+)
+
+// Package is a Go module or npm package
+type Package struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"` // go or npm
+}
+
+// RepositoryManifest lists the packages a repository publishes and the ones
+// it requires, read from the go.mod and package.json files of its clone
+type RepositoryManifest struct {
+	Repository string    `json:"repository"` // owner/repo format
+	Provides   []Package `json:"provides"`
+	Requires   []Package `json:"requires"`
+}
+
+// DependencyGraph links analyzed repositories requiring packages published
+// by other analyzed repositories
+type DependencyGraph struct {
+	Nodes []DependencyNode `json:"nodes"`
+	Edges []DependencyEdge `json:"edges"`
+}
+
+// DependencyNode is an analyzed repository with its owning team, the team
+// whose members made most of its commits
+type DependencyNode struct {
+	Repository string `json:"repository"`
+	Team       string `json:"team,omitempty"`
+}
+
+// DependencyEdge is a repository (From) requiring packages of another (To)
+type DependencyEdge struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Packages  []Package `json:"packages"`
+	CrossTeam bool      `json:"cross_team"` // The repositories are owned by different teams
+}
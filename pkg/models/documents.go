@@ -0,0 +1,289 @@
+package models
+
+import "time"
+
+// SchemaVersion is the version of the generated JSON output format.
+// It is bumped whenever a field is removed, renamed or changes type;
+// adding new optional fields does not change the version.
+const SchemaVersion = 1
+
+// GlobalDocument is the content of data/global.json
+type GlobalDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*GlobalMetrics
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// LeaderboardDocument is the content of data/leaderboard.json, and of
+// data/leaderboard/page-<n>.json when the leaderboard is paginated
+type LeaderboardDocument struct {
+	SchemaVersion int                    `json:"schema_version"`
+	Leaderboard   []LeaderboardEntry     `json:"leaderboard"`
+	Pagination    *LeaderboardPagination `json:"pagination,omitempty"` // Set when the leaderboard is paginated
+}
+
+// LeaderboardPagination places a page of the leaderboard among the others
+type LeaderboardPagination struct {
+	Page     int    `json:"page"` // From 1
+	Pages    int    `json:"pages"`
+	PageSize int    `json:"page_size"`
+	Total    int    `json:"total"`          // Entries over all pages
+	Prev     string `json:"prev,omitempty"` // Path of the previous page, relative to data/
+	Next     string `json:"next,omitempty"` // Path of the next page, relative to data/
+}
+
+// LeaderboardSummaryDocument is the content of data/leaderboard-top.json,
+// the top of the leaderboard for consumers that don't need all of it
+type LeaderboardSummaryDocument struct {
+	SchemaVersion int                `json:"schema_version"`
+	Total         int                `json:"total"` // Entries of the whole leaderboard
+	Leaderboard   []LeaderboardEntry `json:"leaderboard"`
+}
+
+// RepositoryDocument is the content of data/repos/<owner>/<name>/metrics.json
+type RepositoryDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*RepositoryMetrics
+}
+
+// TeamDocument is the content of data/teams/<slug>.json
+type TeamDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*TeamMetrics
+}
+
+// GroupDocument is the content of data/groups/<slug>.json
+type GroupDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*GroupMetrics
+}
+
+// ContributorDocument is the content of data/contributors/<login>.json
+type ContributorDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*ContributorMetrics
+}
+
+// ScoreAuditDocument is the content of data/contributors/<login>/score.json,
+// explaining a contributor's score: the rules summed into the breakdown, then
+// the recency decay and pro-rating applied to the total, in that order
+type ScoreAuditDocument struct {
+	SchemaVersion  int            `json:"schema_version"`
+	Login          string         `json:"login"`
+	Total          int            `json:"total"`
+	Rank           int            `json:"rank"`
+	PercentileRank float64        `json:"percentile_rank"`
+	Normalization  string         `json:"normalization,omitempty"` // Ranking mode when not the raw score
+	Normalized     float64        `json:"normalized,omitempty"`
+	Breakdown      ScoreBreakdown `json:"breakdown"`
+	Rules          []ScoreRule    `json:"rules"`
+	Decay          *Decay         `json:"decay,omitempty"`
+	ProRating      *ProRating     `json:"pro_rating,omitempty"`
+}
+
+// RunDocument is the content of data/run.json
+type RunDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*RunReport
+}
+
+// BotsDocument is the content of data/bots.json, listing the identities
+// filtered as bots so misfiltered humans can be spotted
+type BotsDocument struct {
+	SchemaVersion int           `json:"schema_version"`
+	Bots          []BotActivity `json:"bots"`
+}
+
+// HotspotsDocument is the content of data/hotspots.json, listing the most
+// churned files of each repository
+type HotspotsDocument struct {
+	SchemaVersion int                  `json:"schema_version"`
+	Repositories  []RepositoryHotspots `json:"repositories"`
+}
+
+// DependenciesDocument is the content of data/dependencies.json, the graph
+// of analyzed repositories requiring each other's packages
+type DependenciesDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*DependencyGraph
+}
+
+// ReviewLatencyDocument is the content of data/review-latency.json, the
+// review latency heatmaps of all reviews and of each team's
+type ReviewLatencyDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*ReviewLatency
+	Teams []TeamReviewLatency `json:"teams"`
+}
+
+// HallOfFameDocument is the content of data/hall-of-fame.json, the lifetime
+// ledger of achievements carried over from run to run
+type HallOfFameDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*HallOfFame
+}
+
+// NewGlobalDocument wraps global metrics with the current schema version
+func NewGlobalDocument(m *GlobalMetrics, generatedAt time.Time) GlobalDocument {
+	return GlobalDocument{SchemaVersion: SchemaVersion, GlobalMetrics: m, GeneratedAt: generatedAt}
+}
+
+// NewLeaderboardDocument wraps leaderboard entries with the current schema version
+func NewLeaderboardDocument(entries []LeaderboardEntry) LeaderboardDocument {
+	return LeaderboardDocument{SchemaVersion: SchemaVersion, Leaderboard: entries}
+}
+
+// NewLeaderboardSummaryDocument wraps the top entries of a leaderboard
+// with the current schema version
+func NewLeaderboardSummaryDocument(entries []LeaderboardEntry, top int) LeaderboardSummaryDocument {
+	return LeaderboardSummaryDocument{SchemaVersion: SchemaVersion, Total: len(entries), Leaderboard: entries[:min(top, len(entries))]}
+}
+
+// NewRepositoryDocument wraps repository metrics with the current schema version
+func NewRepositoryDocument(m *RepositoryMetrics) RepositoryDocument {
+	return RepositoryDocument{SchemaVersion: SchemaVersion, RepositoryMetrics: m}
+}
+
+// NewTeamDocument wraps team metrics with the current schema version
+func NewTeamDocument(m *TeamMetrics) TeamDocument {
+	return TeamDocument{SchemaVersion: SchemaVersion, TeamMetrics: m}
+}
+
+// NewGroupDocument wraps group metrics with the current schema version
+func NewGroupDocument(m *GroupMetrics) GroupDocument {
+	return GroupDocument{SchemaVersion: SchemaVersion, GroupMetrics: m}
+}
+
+// NewContributorDocument wraps contributor metrics with the current schema version
+func NewContributorDocument(m *ContributorMetrics) ContributorDocument {
+	return ContributorDocument{SchemaVersion: SchemaVersion, ContributorMetrics: m}
+}
+
+// SearchDocument is the content of data/search.json, a prebuilt index behind
+// the dashboard's search and filter controls
+type SearchDocument struct {
+	SchemaVersion int `json:"schema_version"`
+	*SearchIndex
+}
+
+// SearchIndex lists searchable contributors and repositories with an
+// inverted index from lowercase tokens to entry positions
+type SearchIndex struct {
+	Entries []SearchEntry    `json:"entries"`
+	Tokens  map[string][]int `json:"tokens"`
+	Facets  SearchFacets     `json:"facets"`
+}
+
+// SearchEntry is one searchable contributor or repository
+type SearchEntry struct {
+	Type         string   `json:"type"`  // contributor, repository
+	ID           string   `json:"id"`    // Login or owner/name
+	Title        string   `json:"title"` // Display name
+	Route        string   `json:"route"` // Dashboard route, e.g. /contributors/octocat
+	Team         string   `json:"team,omitempty"`
+	Repositories []string `json:"repositories,omitempty"`
+	Achievements []string `json:"achievements,omitempty"`
+	Score        int      `json:"score,omitempty"`
+}
+
+// SearchFacets are the values offered by the dashboard's filter controls
+type SearchFacets struct {
+	Teams        []string `json:"teams"`
+	Repositories []string `json:"repositories"`
+	Achievements []string `json:"achievements"`
+}
+
+// NewSearchDocument wraps a search index with the current schema version
+func NewSearchDocument(idx *SearchIndex) SearchDocument {
+	return SearchDocument{SchemaVersion: SchemaVersion, SearchIndex: idx}
+}
+
+// NewRunDocument wraps a run report with the current schema version
+func NewRunDocument(r *RunReport) RunDocument {
+	return RunDocument{SchemaVersion: SchemaVersion, RunReport: r}
+}
+
+// NewBotsDocument wraps the bot audit with the current schema version
+func NewBotsDocument(bots []BotActivity) BotsDocument {
+	if bots == nil {
+		bots = []BotActivity{}
+	}
+	return BotsDocument{SchemaVersion: SchemaVersion, Bots: bots}
+}
+
+// NewHotspotsDocument collects the hotspots of every repository with the current schema version
+func NewHotspotsDocument(repos []RepositoryMetrics) HotspotsDocument {
+	doc := HotspotsDocument{SchemaVersion: SchemaVersion, Repositories: []RepositoryHotspots{}}
+	for _, repo := range repos {
+		if len(repo.Hotspots) > 0 {
+			doc.Repositories = append(doc.Repositories, RepositoryHotspots{Repository: repo.FullName, Files: repo.Hotspots})
+		}
+	}
+	return doc
+}
+
+// NewDependenciesDocument wraps a dependency graph with the current schema version
+func NewDependenciesDocument(g *DependencyGraph) DependenciesDocument {
+	return DependenciesDocument{SchemaVersion: SchemaVersion, DependencyGraph: g}
+}
+
+// NewReviewLatencyDocument collects the review latency of all reviews and of
+// every team with the current schema version
+func NewReviewLatencyDocument(m *GlobalMetrics) ReviewLatencyDocument {
+	doc := ReviewLatencyDocument{SchemaVersion: SchemaVersion, ReviewLatency: m.ReviewLatency, Teams: []TeamReviewLatency{}}
+	for _, team := range m.Teams {
+		if team.ReviewLatency != nil {
+			doc.Teams = append(doc.Teams, TeamReviewLatency{Team: team.Name, ReviewLatency: team.ReviewLatency})
+		}
+	}
+	return doc
+}
+
+// NewHallOfFameDocument wraps the achievement ledger with the current schema version
+func NewHallOfFameDocument(h *HallOfFame) HallOfFameDocument {
+	return HallOfFameDocument{SchemaVersion: SchemaVersion, HallOfFame: h}
+}
+
+// NewScoreAuditDocument explains the score of a contributor, ranked under
+// the given normalization mode
+func NewScoreAuditDocument(m *ContributorMetrics, normalization string) ScoreAuditDocument {
+	rules := m.Score.Rules
+	if rules == nil {
+		rules = []ScoreRule{}
+	}
+	return ScoreAuditDocument{
+		SchemaVersion:  SchemaVersion,
+		Login:          m.Login,
+		Total:          m.Score.Total,
+		Rank:           m.Score.Rank,
+		PercentileRank: m.Score.PercentileRank,
+		Normalization:  normalization,
+		Normalized:     m.Score.Normalized,
+		Breakdown:      m.Score.Breakdown,
+		Rules:          rules,
+		Decay:          m.Score.Decay,
+		ProRating:      m.Score.ProRating,
+	}
+}
+
+// Documents maps each generated document name to an empty instance of its type.
+// It is used to publish a JSON Schema per output file.
+func Documents() map[string]any {
+	return map[string]any{
+		"global":          GlobalDocument{},
+		"leaderboard":     LeaderboardDocument{},
+		"leaderboard-top": LeaderboardSummaryDocument{},
+		"repository":      RepositoryDocument{},
+		"team":            TeamDocument{},
+		"group":           GroupDocument{},
+		"contributor":     ContributorDocument{},
+		"score":           ScoreAuditDocument{},
+		"run":             RunDocument{},
+		"bots":            BotsDocument{},
+		"hotspots":        HotspotsDocument{},
+		"dependencies":    DependenciesDocument{},
+		"review-latency":  ReviewLatencyDocument{},
+		"search":          SearchDocument{},
+		"hall-of-fame":    HallOfFameDocument{},
+	}
+}
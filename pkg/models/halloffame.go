@@ -0,0 +1,186 @@
+package models
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Records kept in the hall of fame, in the order they are listed
+const (
+	RecordBiggestWeek        = "biggest_week"         // Most points in a calendar week
+	RecordLongestStreak      = "longest_streak"       // Most consecutive days with activity
+	RecordFastestReviewMonth = "fastest_review_month" // Lowest average review response time over a calendar month
+)
+
+// RecordTypes lists the records in the order the hall of fame shows them
+var RecordTypes = []string{RecordBiggestWeek, RecordLongestStreak, RecordFastestReviewMonth}
+
+// HallOfFame is the lifetime ledger of achievements and records, kept across
+// runs apart from the achievements of the period a dashboard shows
+type HallOfFame struct {
+	UpdatedAt    time.Time         `json:"updated_at"`
+	Contributors []HallOfFameEntry `json:"contributors"`
+	Records      []Record          `json:"records"`
+}
+
+// Record is the current holder of an all-time record
+type Record struct {
+	Type      string    `json:"type"` // One of the Record* types
+	Login     string    `json:"login"`
+	Name      string    `json:"name"`
+	AvatarURL string    `json:"avatar_url"`
+	Value     float64   `json:"value"`          // Points, days or hours
+	When      string    `json:"when,omitempty"` // Week (its Monday, 2006-01-02) or month (2006-01) the record was set in
+	Period    string    `json:"period"`         // Label of the period the record was set in
+	SetAt     time.Time `json:"set_at"`
+}
+
+// PersonalBests are a contributor's best week and month within the period
+type PersonalBests struct {
+	BestWeek           *BestWeek    `json:"best_week,omitempty"`
+	FastestReviewMonth *ReviewMonth `json:"fastest_review_month,omitempty"`
+}
+
+// BestWeek is the calendar week, Monday to Sunday, with the most points
+// from commits, PRs and reviews, as counted by the velocity timeline
+type BestWeek struct {
+	Start   time.Time `json:"start"` // Monday of the week
+	Score   float64   `json:"score"`
+	Commits int       `json:"commits"`
+	PRs     int       `json:"prs"`
+	Reviews int       `json:"reviews"`
+}
+
+// ReviewMonth is the calendar month with the lowest average review response
+// time, out of the months with enough timed reviews to count
+type ReviewMonth struct {
+	Month         string  `json:"month"` // 2006-01
+	AvgReviewTime float64 `json:"avg_review_time_hours"`
+	Reviews       int     `json:"reviews"`
+}
+
+// HallOfFameEntry lists every achievement a contributor has earned
+type HallOfFameEntry struct {
+	Login        string              `json:"login"`
+	Name         string              `json:"name"`
+	AvatarURL    string              `json:"avatar_url"`
+	Achievements []EarnedAchievement `json:"achievements"`
+}
+
+// EarnedAchievement is an achievement and the run it was first earned in
+type EarnedAchievement struct {
+	ID       string    `json:"id"`
+	EarnedAt time.Time `json:"earned_at"`
+	Period   string    `json:"period"` // Label of the period it was earned in
+}
+
+// Record adds the achievements the contributors earned in a period that are
+// not in the ledger yet, as earned at the given time. Contributors are listed
+// by achievements earned, each one's achievements by when they were earned.
+func (h *HallOfFame) Record(contributors []ContributorMetrics, period string, at time.Time) {
+	entries := make(map[string]*HallOfFameEntry, len(h.Contributors))
+	for i := range h.Contributors {
+		entries[h.Contributors[i].Login] = &h.Contributors[i]
+	}
+
+	var added []*HallOfFameEntry
+	for _, c := range contributors {
+		if len(c.Achievements) == 0 {
+			continue
+		}
+		entry, ok := entries[c.Login]
+		if !ok {
+			entry = &HallOfFameEntry{Login: c.Login}
+			entries[c.Login] = entry
+			added = append(added, entry)
+		}
+		// Names and avatars follow the contributor's profile
+		entry.Name, entry.AvatarURL = c.Name, c.AvatarURL
+		earned := make(map[string]bool, len(entry.Achievements))
+		for _, a := range entry.Achievements {
+			earned[a.ID] = true
+		}
+		for _, id := range c.Achievements {
+			if !earned[id] {
+				entry.Achievements = append(entry.Achievements, EarnedAchievement{ID: id, EarnedAt: at, Period: period})
+				earned[id] = true
+			}
+		}
+	}
+	for _, entry := range added {
+		h.Contributors = append(h.Contributors, *entry)
+	}
+	h.UpdatedAt = at
+
+	for i := range h.Contributors {
+		achievements := h.Contributors[i].Achievements
+		sort.SliceStable(achievements, func(a, b int) bool {
+			return achievements[a].EarnedAt.Before(achievements[b].EarnedAt)
+		})
+	}
+	sort.Slice(h.Contributors, func(i, j int) bool {
+		if a, b := len(h.Contributors[i].Achievements), len(h.Contributors[j].Achievements); a != b {
+			return a > b
+		}
+		return strings.ToLower(h.Contributors[i].Login) < strings.ToLower(h.Contributors[j].Login)
+	})
+}
+
+// RecordBests sets the records the contributors' bests in a period beat.
+// A record is only taken by beating it, so the first to set a value keeps it.
+func (h *HallOfFame) RecordBests(contributors []ContributorMetrics, period string, at time.Time) {
+	beat := func(recordType string, c *ContributorMetrics, value float64, when string, lower bool) {
+		i := slices.IndexFunc(h.Records, func(r Record) bool { return r.Type == recordType })
+		if i >= 0 && (value == h.Records[i].Value || (value < h.Records[i].Value) != lower) {
+			return
+		}
+		record := Record{
+			Type: recordType, Login: c.Login, Name: c.Name, AvatarURL: c.AvatarURL,
+			Value: value, When: when, Period: period, SetAt: at,
+		}
+		if i < 0 {
+			h.Records = append(h.Records, record)
+			return
+		}
+		h.Records[i] = record
+	}
+
+	for i := range contributors {
+		c := &contributors[i]
+		if c.LongestStreak > 0 {
+			beat(RecordLongestStreak, c, float64(c.LongestStreak), "", false)
+		}
+		if c.Bests == nil {
+			continue
+		}
+		if w := c.Bests.BestWeek; w != nil && w.Score > 0 {
+			beat(RecordBiggestWeek, c, w.Score, w.Start.Format("2006-01-02"), false)
+		}
+		if m := c.Bests.FastestReviewMonth; m != nil {
+			beat(RecordFastestReviewMonth, c, m.AvgReviewTime, m.Month, true)
+		}
+	}
+
+	order := make(map[string]int, len(RecordTypes))
+	for i, t := range RecordTypes {
+		order[t] = i
+	}
+	sort.SliceStable(h.Records, func(i, j int) bool {
+		return order[h.Records[i].Type] < order[h.Records[j].Type]
+	})
+}
+
+// Forget removes contributors from the ledger, with the records they hold
+func (h *HallOfFame) Forget(logins []string) {
+	if len(logins) == 0 {
+		return
+	}
+	h.Contributors = slices.DeleteFunc(h.Contributors, func(e HallOfFameEntry) bool {
+		return slices.Contains(logins, e.Login)
+	})
+	h.Records = slices.DeleteFunc(h.Records, func(r Record) bool {
+		return slices.Contains(logins, r.Login)
+	})
+}
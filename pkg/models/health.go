@@ -0,0 +1,32 @@
+package models
+
+// RepositorySettings holds the repository configuration inspected by health checks.
+// Nil pointers mean the setting couldn't be read with the token's permissions.
+type RepositorySettings struct {
+	DefaultBranch   string `json:"default_branch"`
+	BranchProtected *bool  `json:"branch_protected,omitempty"` // Classic protection or a ruleset on the default branch
+	RequiredReviews *int   `json:"required_reviews,omitempty"` // Approvals required to merge into the default branch
+	CodeOwners      bool   `json:"codeowners"`                 // A CODEOWNERS file exists
+	License         string `json:"license,omitempty"`          // SPDX identifier of the detected license
+}
+
+// Health check statuses
+const (
+	HealthPass    = "pass"
+	HealthFail    = "fail"
+	HealthUnknown = "unknown" // The setting couldn't be read
+)
+
+// HealthCheck is one item of a repository's health checklist
+type HealthCheck struct {
+	ID     string `json:"id"`              // branch_protection, required_reviews, codeowners or license
+	Status string `json:"status"`          // pass, fail or unknown
+	Value  string `json:"value,omitempty"` // e.g. the license or the number of required approvals
+}
+
+// RepositoryHealth summarizes a repository's settings checklist and audit-log events
+type RepositoryHealth struct {
+	Score  *int          `json:"score,omitempty"` // Percentage of the known checks that pass
+	Checks []HealthCheck `json:"checks,omitempty"`
+	Audit  *AuditSummary `json:"audit,omitempty"` // When the audit log integration is enabled
+}
@@ -0,0 +1,20 @@
+package models
+
+// FileHotspot is a frequently and heavily changed file of a repository
+type FileHotspot struct {
+	Path      string `json:"path"`
+	Changes   int    `json:"changes"` // Commits changing the file
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Churn     int    `json:"churn"`   // Changes × lines changed
+	Authors   int    `json:"authors"` // Distinct contributors changing the file
+
+	// Commits shrinking the file (more lines deleted than added), by login
+	Stabilizers map[string]int `json:"stabilizers,omitempty"`
+}
+
+// RepositoryHotspots lists a repository's top hotspot files
+type RepositoryHotspots struct {
+	Repository string        `json:"repository"` // owner/repo format
+	Files      []FileHotspot `json:"files"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// ExternalIdentity is the SAML SSO identity an organization's identity
+// provider links to a login
+type ExternalIdentity struct {
+	Login  string   `json:"login"`
+	Name   string   `json:"name,omitempty"`   // Given and family name
+	Emails []string `json:"emails,omitempty"` // Corporate emails, the NameID first when it is one
+}
@@ -25,6 +25,9 @@ type Issue struct {
 	Labels     []string   `json:"labels,omitempty"`
 	URL        string     `json:"url"`
 
+	// The author's GitHub association with the repository, e.g. MEMBER or CONTRIBUTOR
+	Association string `json:"author_association,omitempty"`
+
 	// Derived fields
 	TimeToClose *time.Duration `json:"time_to_close,omitempty"`
 }
@@ -51,4 +54,7 @@ type IssueComment struct {
 	Author     Author    `json:"author"`
 	Body       string    `json:"body"`
 	CreatedAt  time.Time `json:"created_at"`
+
+	// Kudos reactions on the comment, when options.reactions is enabled
+	Reactions []Reaction `json:"reactions,omitempty"`
 }
@@ -0,0 +1,186 @@
+package models
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect used for generated schemas
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a minimal JSON Schema (draft 2020-12) representation,
+// sufficient to describe the structs in this package.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 SchemaType             `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Const                *int                   `json:"const,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The standard library encoder is used because
+// goccy/go-json cannot build an indenting encoder for this mutually recursive type.
+func (s JSONSchema) MarshalJSON() ([]byte, error) {
+	type plain JSONSchema
+	return stdjson.Marshal(plain(s))
+}
+
+// SchemaType is a JSON Schema "type" keyword; a single type is encoded as a string,
+// several (e.g. nullable values) as an array.
+type SchemaType []string
+
+// MarshalJSON implements json.Marshaler
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return stdjson.Marshal(t[0])
+	}
+	return stdjson.Marshal([]string(t))
+}
+
+func schemaType(types ...string) SchemaType {
+	return SchemaType(types)
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// GenerateJSONSchema builds a JSON Schema for the given value's type using its json tags.
+// Named struct types are emitted once under $defs and referenced with $ref.
+// The schema_version property is pinned to the current SchemaVersion.
+func GenerateJSONSchema(title string, v any) *JSONSchema {
+	g := &schemaGenerator{defs: make(map[string]*JSONSchema)}
+	root := g.structSchema(reflect.TypeOf(v))
+	root.Schema = JSONSchemaDraft
+	root.Title = title
+	if prop, ok := root.Properties["schema_version"]; ok {
+		version := SchemaVersion
+		prop.Const = &version
+	}
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+type schemaGenerator struct {
+	defs map[string]*JSONSchema
+}
+
+func (g *schemaGenerator) schemaFor(t reflect.Type) *JSONSchema {
+	switch t {
+	case timeType:
+		return &JSONSchema{Type: schemaType("string"), Format: "date-time"}
+	case durationType:
+		// time.Duration is serialised as integer nanoseconds
+		return &JSONSchema{Type: schemaType("integer")}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(g.schemaFor(t.Elem()))
+	case reflect.Struct:
+		return g.refFor(t)
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: schemaType("array", "null"), Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: schemaType("object", "null"), AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.String:
+		return &JSONSchema{Type: schemaType("string")}
+	case reflect.Bool:
+		return &JSONSchema{Type: schemaType("boolean")}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: schemaType("integer")}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: schemaType("number")}
+	default:
+		// interface{} and anything else accepts any value
+		return &JSONSchema{}
+	}
+}
+
+// refFor registers a named struct under $defs and returns a reference to it
+func (g *schemaGenerator) refFor(t reflect.Type) *JSONSchema {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+	name := t.Name()
+	if _, ok := g.defs[name]; !ok {
+		// Reserve the name first so self-referencing types terminate
+		g.defs[name] = &JSONSchema{}
+		*g.defs[name] = *g.structSchema(t)
+	}
+	return &JSONSchema{Ref: "#/$defs/" + name}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	s := &JSONSchema{Type: schemaType("object"), Properties: make(map[string]*JSONSchema)}
+	g.addFields(s, t)
+	return s
+}
+
+// addFields adds the json-visible fields of t to s, flattening untagged embedded structs
+// the same way encoding/json does.
+func (g *schemaGenerator) addFields(s *JSONSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		s.Properties[name] = g.schemaFor(f.Type)
+		if !strings.Contains(","+opts+",", ",omitempty,") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+func nullable(s *JSONSchema) *JSONSchema {
+	switch len(s.Type) {
+	case 0:
+		return &JSONSchema{AnyOf: []*JSONSchema{s, {Type: schemaType("null")}}}
+	case 1:
+		s.Type = append(s.Type, "null")
+	}
+	return s
+}
+
+// SchemaFileName returns the file name used to publish the schema for a document
+func SchemaFileName(document string) string {
+	return fmt.Sprintf("%s.schema.json", document)
+}
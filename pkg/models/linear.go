@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// LinearIssue represents an issue tracked in Linear (https://linear.app)
+type LinearIssue struct {
+	Identifier  string     `json:"identifier"` // e.g., ENG-123
+	Title       string     `json:"title,omitempty"`
+	StateName   string     `json:"state_name,omitempty"` // Workflow state name (e.g., "In Progress")
+	StateType   string     `json:"state_type,omitempty"` // backlog, unstarted, started, completed, canceled
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	URL         string     `json:"url,omitempty"`
+}
+
+// IsCompleted returns true if the issue reached a completed workflow state
+func (i *LinearIssue) IsCompleted() bool {
+	return i.StateType == "completed"
+}
@@ -0,0 +1,9 @@
+package models
+
+// LintReport holds a repository's static-analysis findings per file at the
+// start and end of the analyzed period
+type LintReport struct {
+	Repository string         `json:"repository"` // owner/repo format
+	Start      map[string]int `json:"start"`
+	End        map[string]int `json:"end"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// MainlineCommit is a commit on the first-parent history of a repository's
+// default branch: a PR's merge or squash commit, or a commit pushed directly
+type MainlineCommit struct {
+	SHA         string    `json:"sha"`
+	CommittedAt time.Time `json:"committed_at"`
+}
@@ -0,0 +1,610 @@
+package models
+
+import "time"
+
+// Period represents a time period for metrics aggregation
+type Period struct {
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Granularity string    `json:"granularity"` // daily, weekly, monthly, custom
+	Label       string    `json:"label"`       // e.g., "Week 42", "December 2024", "Q1 2024"
+}
+
+// ContributorMetrics holds aggregated metrics for a single contributor
+type ContributorMetrics struct {
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+	Period    Period `json:"period"`
+
+	// Commit metrics
+	CommitCount      int `json:"commit_count"`
+	CommitsWithTests int `json:"commits_with_tests"` // Commits that include test files
+	LinesAdded       int `json:"lines_added"`
+	LinesDeleted     int `json:"lines_deleted"`
+	FilesChanged     int `json:"files_changed"`
+
+	// Meaningful line counts (excludes comments and whitespace)
+	MeaningfulLinesAdded   int `json:"meaningful_lines_added"`
+	MeaningfulLinesDeleted int `json:"meaningful_lines_deleted"`
+
+	// Comment and documentation line counts
+	CommentLinesAdded   int `json:"comment_lines_added"`
+	CommentLinesDeleted int `json:"comment_lines_deleted"`
+
+	// PR metrics
+	PRsOpened      int     `json:"prs_opened"`
+	PRsMerged      int     `json:"prs_merged"`
+	PRsClosed      int     `json:"prs_closed"` // Closed without merging
+	AvgPRSize      float64 `json:"avg_pr_size"`
+	AvgTimeToMerge float64 `json:"avg_time_to_merge_hours"`
+	LargestPRSize  int     `json:"largest_pr_size"` // Biggest single PR by lines changed
+	SmallPRCount   int     `json:"small_pr_count"`  // PRs under 100 lines (good practice)
+	PerfectPRs     int     `json:"perfect_prs"`     // PRs merged without changes requested
+
+	// Share of their merged or closed PRs that were closed without merging,
+	// and the average hours from opening those to closing them
+	AbandonmentRate float64 `json:"abandonment_rate,omitempty"`
+	AvgTimeToClose  float64 `json:"avg_time_to_close_hours,omitempty"`
+
+	// Their PRs that were reviewed, and the average review rounds those went
+	// through: the first review, then one per re-review after changes were requested
+	PRsWithReviews      int     `json:"prs_with_reviews,omitempty"`
+	AvgReviewIterations float64 `json:"avg_review_iterations,omitempty"`
+
+	// Draft PRs, only tracked in full with use_graphql: PRs opened as or
+	// converted to drafts, drafts opened and later marked ready for review,
+	// and drafts closed unmerged, with the average hours spent in draft and
+	// from opening a draft to marking it ready
+	DraftPRs        int     `json:"draft_prs,omitempty"`
+	DraftsReady     int     `json:"drafts_ready,omitempty"`
+	DraftsAbandoned int     `json:"drafts_abandoned,omitempty"`
+	AvgTimeInDraft  float64 `json:"avg_time_in_draft_hours,omitempty"`
+	AvgDraftToReady float64 `json:"avg_draft_to_ready_hours,omitempty"`
+
+	// Review metrics
+	ReviewsGiven     int     `json:"reviews_given"`
+	ReviewComments   int     `json:"review_comments"`
+	ApprovalsGiven   int     `json:"approvals_given"`
+	ChangesRequested int     `json:"changes_requested"`
+	AvgReviewTime    float64 `json:"avg_review_time_hours"`
+
+	// Issue metrics
+	IssuesOpened             int `json:"issues_opened"`
+	IssuesClosed             int `json:"issues_closed"`
+	IssueComments            int `json:"issue_comments"`
+	IssueReferencesInCommits int `json:"issue_references_in_commits"` // Commits referencing issues (fixes #123, etc.)
+
+	// Merged PRs referencing an issue in their title or body, and their
+	// percentage of the PRs merged
+	LinkedPRs         int     `json:"linked_prs,omitempty"`
+	IssueTraceability float64 `json:"issue_traceability,omitempty"`
+
+	// Linear integration metrics (only populated when integrations.linear is enabled)
+	LinearIssuesReferenced int     `json:"linear_issues_referenced,omitempty"` // Unique Linear issues referenced in PRs/commits
+	LinearIssuesCompleted  int     `json:"linear_issues_completed,omitempty"`  // Referenced Linear issues in a completed state
+	LinearLinkageRate      float64 `json:"linear_linkage_rate,omitempty"`      // % of opened PRs referencing a Linear issue
+
+	// CI metrics (only populated when options.build_status is enabled)
+	BuildsBroken int `json:"builds_broken,omitempty"` // Merged PRs turning a green default branch red
+	BuildsFixed  int `json:"builds_fixed,omitempty"`  // Merged PRs turning a red default branch green
+
+	// Coverage metrics (only populated when coverage reports or Codecov are configured)
+	CoverageImproved int     `json:"coverage_improved,omitempty"` // Merged PRs raising line coverage
+	CoverageDelta    float64 `json:"coverage_delta,omitempty"`    // Net change in coverage percentage points over merged PRs
+
+	// Tech debt reduction (only populated when lint reports are configured)
+	TechDebtReduction int `json:"tech_debt_reduction,omitempty"` // Static-analysis findings fixed in files they changed last
+
+	// Security fixes: merged PRs labelled or referencing a GHSA/CVE, direct commits
+	// referencing other advisories, and approved Dependabot security updates
+	SecurityFixes int `json:"security_fixes,omitempty"`
+
+	// Commits shrinking a hotspot file of its repository
+	HotspotStabilizations int `json:"hotspot_stabilizations,omitempty"`
+
+	// Refactoring: commits deleting far more non-test code than they add
+	RefactoringCommits int `json:"refactoring_commits,omitempty"`
+	NetLinesRemoved    int `json:"net_lines_removed,omitempty"` // Non-test lines removed by refactoring commits, net of additions
+
+	// Commits pushed to the default branch without a PR, when options.direct_pushes is enabled
+	DirectPushes int `json:"direct_pushes,omitempty"`
+
+	// Merged PRs they enabled auto-merge on or added to the merge queue,
+	// merged in line with the review policy
+	AutoMergesEnabled int `json:"auto_merges_enabled,omitempty"`
+
+	// Effort estimated for the merged PRs they authored, when hooks.effort is set
+	EffortPoints float64 `json:"effort_points,omitempty"`
+
+	// 👍, ❤️ and 🎉 reactions others left on their PRs, reviews and issue
+	// comments, and the ones they left on others', when options.reactions is enabled
+	KudosReceived int `json:"kudos_received,omitempty"`
+	KudosGiven    int `json:"kudos_given,omitempty"`
+
+	// Tone of the review comments they wrote, when options.review_tone is
+	// enabled with per_contributor
+	ReviewTone *ReviewTone `json:"review_tone,omitempty"`
+
+	// Custom metrics computed from the others, by name, when custom_metrics are configured
+	Custom map[string]float64 `json:"custom,omitempty"`
+
+	// Patches applied to more than one repository, and the repositories they
+	// reached beyond the first
+	PropagatedPatches int `json:"propagated_patches,omitempty"`
+	PatchPropagation  int `json:"patch_propagation,omitempty"`
+
+	// Activity patterns
+	ActiveDays      int `json:"active_days"`        // Unique days with activity
+	CurrentStreak   int `json:"current_streak"`     // Current consecutive days
+	LongestStreak   int `json:"longest_streak"`     // Longest consecutive days
+	WorkWeekStreak  int `json:"work_week_streak"`   // Longest consecutive weekdays (Mon-Fri, weekends don't break streak)
+	EarlyBirdCount  int `json:"early_bird_count"`   // Commits before 9am
+	NightOwlCount   int `json:"night_owl_count"`    // Commits after 9pm
+	MidnightCount   int `json:"midnight_count"`     // Commits between midnight and 4am
+	WeekendWarrior  int `json:"weekend_warrior"`    // Weekend commits
+	OutOfHoursCount int `json:"out_of_hours_count"` // Commits outside 9am-5pm (legacy, kept for achievements)
+
+	// Time-based commit counts for multiplier scoring
+	RegularHoursCount int `json:"regular_hours_count"` // Commits 9am-5pm (x1 multiplier)
+	EveningCount      int `json:"evening_count"`       // Commits 5pm-9pm (x2 multiplier)
+	LateNightCount    int `json:"late_night_count"`    // Commits 9pm-midnight (x2.5 multiplier)
+	OvernightCount    int `json:"overnight_count"`     // Commits midnight-6am (x5 multiplier)
+	EarlyMorningCount int `json:"early_morning_count"` // Commits 6am-9am (x2 multiplier)
+
+	// Out-of-office days in the period, the remaining available days and
+	// the percentage of available days with activity; empty for open-ended periods
+	AbsenceDays   int     `json:"absence_days,omitempty"`
+	AvailableDays int     `json:"available_days,omitempty"`
+	ActivityRate  float64 `json:"activity_rate,omitempty"`
+
+	// How recent the activity is under scoring.decay_half_life_days: 1 for
+	// activity spread evenly over the period, above 1 when it is mostly recent
+	RecencyWeight float64 `json:"recency_weight,omitempty"`
+
+	// Share of their activity (0-1) dated within their membership, for
+	// contributors with a start or end date; nil for everyone else
+	MembershipShare *float64 `json:"membership_share,omitempty"`
+
+	// Opted out of the leaderboards; left out of the published dashboard
+	// but counted in team, repository and organization totals
+	OptedOut bool `json:"-"`
+
+	// Metrics of their activity dated within the period, which achievements
+	// are earned from under scoring.achievement_scope: period
+	PeriodActivity *ContributorMetrics `json:"-"`
+
+	// Rolling averages over the end of the period and the trend between them
+	Rolling []RollingAverage `json:"rolling,omitempty"`
+	Trend   *Trend           `json:"trend,omitempty"`
+
+	// Best week and month of the period, held against the all-time records
+	Bests *PersonalBests `json:"bests,omitempty"`
+
+	// Not a member of the analyzed organizations (only set when options.org_members is enabled)
+	External bool `json:"external,omitempty"`
+
+	// Opened a first pull request to one of the repositories in the period
+	// (only set when community metrics are enabled)
+	FirstTimeContributor bool `json:"first_time_contributor,omitempty"`
+
+	// Repository participation
+	RepositoriesContributed []string `json:"repositories_contributed,omitempty"`
+	UniqueReviewees         int      `json:"unique_reviewees"`
+
+	// Scoring
+	Score        Score    `json:"score"`
+	Achievements []string `json:"achievements"` // Achievement IDs
+}
+
+// Score holds the calculated score and breakdown
+type Score struct {
+	Total          int            `json:"total"`
+	Breakdown      ScoreBreakdown `json:"breakdown"`
+	Rank           int            `json:"rank"`
+	PercentileRank float64        `json:"percentile_rank"`
+	Normalized     float64        `json:"normalized,omitempty"` // Ranking value under scoring.normalization
+	ProRating      *ProRating     `json:"pro_rating,omitempty"` // Set when Total was scaled for a partial-period member
+	Decay          *Decay         `json:"decay,omitempty"`      // Set when Total was weighted by recency
+
+	// Rules behind Breakdown, written to the contributor's score audit
+	Rules []ScoreRule `json:"-"`
+}
+
+// ProRating records how a joiner's or leaver's score was scaled to the full period
+type ProRating struct {
+	RawTotal   int     `json:"raw_total"`   // Score before pro-rating
+	MemberDays int     `json:"member_days"` // Days of the period the contributor was a member
+	PeriodDays int     `json:"period_days"` // Days in the analysis period
+	Factor     float64 `json:"factor"`      // PeriodDays / MemberDays, capped
+	Share      float64 `json:"share"`       // Share of RawTotal earned during the membership, the part scaled
+}
+
+// Decay records how a score was weighted by the recency of the activity
+type Decay struct {
+	RawTotal     int     `json:"raw_total"`      // Score before weighting
+	Weight       float64 `json:"weight"`         // The contributor's recency weight
+	HalfLifeDays float64 `json:"half_life_days"` // Configured half-life
+}
+
+// ScoreBreakdown shows how the score was calculated
+type ScoreBreakdown struct {
+	Commits       int `json:"commits"`
+	PRs           int `json:"prs"`
+	Reviews       int `json:"reviews"`
+	Comments      int `json:"comments"` // PR review comments (not code comments)
+	Issues        int `json:"issues"`   // Issue-related points (opened, closed, comments, references)
+	ResponseBonus int `json:"response_bonus"`
+	LineChanges   int `json:"line_changes"`
+	TestsBonus    int `json:"tests_bonus"`             // Bonus for commits that include test files
+	OutOfHours    int `json:"out_of_hours"`            // Bonus for out-of-hours commits
+	Builds        int `json:"builds,omitempty"`        // Points for fixing, or penalty for breaking, the default branch build
+	Coverage      int `json:"coverage,omitempty"`      // Points for merged PRs raising test coverage
+	TechDebt      int `json:"tech_debt,omitempty"`     // Points for static-analysis findings fixed
+	Security      int `json:"security,omitempty"`      // Points for security fixes
+	Gardening     int `json:"gardening,omitempty"`     // Points for refactoring commits ("Code Gardener")
+	Propagation   int `json:"propagation,omitempty"`   // Points for rolling patches out to several repositories
+	DirectPushes  int `json:"direct_pushes,omitempty"` // Points, usually a penalty, for commits pushed without a PR
+	AutoMerge     int `json:"auto_merge,omitempty"`    // Points for merged PRs they enabled auto-merge on or queued
+	Effort        int `json:"effort,omitempty"`        // Points for the estimated effort of merged PRs
+	Kudos         int `json:"kudos,omitempty"`         // Points for kudos reactions received
+	Custom        int `json:"custom,omitempty"`        // Points for the custom metrics
+}
+
+// ScoreRule is a scoring rule applied to a contributor: the metric it read,
+// the points per unit configured, and the points it awarded
+type ScoreRule struct {
+	Rule       string  `json:"rule"`                 // scoring.points setting, e.g. pr_merged
+	Category   string  `json:"category"`             // ScoreBreakdown field the points count towards
+	Metric     string  `json:"metric"`               // Contributor metric read, e.g. prs_merged
+	Value      float64 `json:"value"`                // The metric's value
+	Points     float64 `json:"points"`               // Points per unit
+	Multiplier float64 `json:"multiplier,omitempty"` // Time-of-day multiplier of commit points
+	Awarded    float64 `json:"awarded"`              // Before the category's total is rounded down
+	Note       string  `json:"note,omitempty"`       // Why the rule awarded nothing or less, such as a cap
+}
+
+// RepositoryMetrics holds aggregated metrics for a single repository
+type RepositoryMetrics struct {
+	Owner              string               `json:"owner"`
+	Name               string               `json:"name"`
+	FullName           string               `json:"full_name"` // owner/name
+	Period             Period               `json:"period"`
+	Contributors       []ContributorMetrics `json:"contributors"`
+	TotalCommits       int                  `json:"total_commits"`
+	TotalPRs           int                  `json:"total_prs"`
+	TotalReviews       int                  `json:"total_reviews"`
+	TotalIssues        int                  `json:"total_issues"` // Issues opened
+	ActiveContributors int                  `json:"active_contributors"`
+	TotalLinesAdded    int                  `json:"total_lines_added"`
+	TotalLinesDeleted  int                  `json:"total_lines_deleted"`
+
+	// Meaningful line counts (excludes comments and whitespace)
+	TotalMeaningfulLinesAdded   int `json:"total_meaningful_lines_added"`
+	TotalMeaningfulLinesDeleted int `json:"total_meaningful_lines_deleted"`
+
+	// Review rounds per reviewed PR, counting re-reviews after changes were requested
+	AvgReviewIterations float64 `json:"avg_review_iterations,omitempty"`
+
+	// Rolling averages over the end of the period and the trend between them
+	Rolling []RollingAverage `json:"rolling,omitempty"`
+	Trend   *Trend           `json:"trend,omitempty"`
+
+	// Projected commits and PRs, when forecasting is enabled
+	Forecast *Forecast `json:"forecast,omitempty"`
+
+	// Share of merged PRs whose merge commit passed CI, when options.build_status is enabled
+	BuildSuccessRate *float64 `json:"build_success_rate,omitempty"`
+	BuildsBroken     int      `json:"builds_broken,omitempty"`
+
+	// Line coverage after the last merged PR with a report, and its change over the period
+	Coverage      *float64 `json:"coverage,omitempty"`
+	CoverageDelta float64  `json:"coverage_delta,omitempty"`
+
+	// Static-analysis findings at the end of the period, and their change over it
+	LintFindings *int `json:"lint_findings,omitempty"`
+	LintDelta    int  `json:"lint_delta,omitempty"`
+
+	// Security fixes merged or committed in the period
+	SecurityFixes int `json:"security_fixes,omitempty"`
+
+	// Commits pushed to the default branch without a PR, when options.direct_pushes is enabled
+	DirectPushes int `json:"direct_pushes,omitempty"`
+
+	// Merged PRs referencing an issue in their title or body, and their
+	// percentage of the PRs merged, nil without merged PRs
+	LinkedPRs         int      `json:"linked_prs,omitempty"`
+	IssueTraceability *float64 `json:"issue_traceability,omitempty"`
+
+	// Tone of the review comments written in the repository, when
+	// options.review_tone is enabled, nil without any
+	ReviewTone *ReviewTone `json:"review_tone,omitempty"`
+
+	// Merged PRs bypassing review: merged without an approval, or merged by
+	// their author over a change request; and the share of merged PRs that
+	// did neither, nil without merged PRs
+	UnapprovedMerges   int               `json:"unapproved_merges,omitempty"`
+	OverriddenReviews  int               `json:"overridden_reviews,omitempty"`
+	MergeCompliance    *float64          `json:"merge_compliance,omitempty"`
+	NonCompliantMerges []PolicyViolation `json:"non_compliant_merges,omitempty"`
+
+	// Merged PRs merged by auto-merge, through the merge queue, or either;
+	// the share of merged PRs that were, nil when none were; and the average
+	// hours from joining the queue, or enabling auto-merge, to the merge
+	AutoMerges      int      `json:"auto_merges,omitempty"`
+	QueuedMerges    int      `json:"queued_merges,omitempty"`
+	AutomatedMerges int      `json:"automated_merges,omitempty"`
+	AutoMergeRate   *float64 `json:"auto_merge_rate,omitempty"`
+	AvgQueueWait    float64  `json:"avg_queue_wait_hours,omitempty"`
+
+	// Effort estimated for the merged PRs, when hooks.effort is set
+	EffortPoints float64 `json:"effort_points,omitempty"`
+
+	// PRs closed without merging; their share of the merged or closed PRs,
+	// nil when none were; the average hours from opening them to closing
+	// them; and whether the share is high enough to call out
+	PRsAbandoned    int      `json:"prs_abandoned,omitempty"`
+	AbandonmentRate *float64 `json:"abandonment_rate,omitempty"`
+	AvgTimeToClose  float64  `json:"avg_time_to_close_hours,omitempty"`
+	HighAbandonment bool     `json:"high_abandonment,omitempty"`
+
+	// Most churned files of the period (changes × lines changed), highest first
+	Hotspots []FileHotspot `json:"hotspots,omitempty"`
+
+	// Settings checklist and audit-log events
+	Health *RepositoryHealth `json:"health,omitempty"`
+
+	// Community contributions, when community metrics are enabled
+	Community *CommunityMetrics `json:"community,omitempty"`
+	// Stars and forks gained next to development activity, when options.adoption is enabled
+	Adoption *AdoptionMetrics `json:"adoption,omitempty"`
+
+	// Analyzed repositories this one requires packages of, and the ones
+	// requiring its packages, when options.dependencies is enabled
+	Upstream   []string `json:"upstream,omitempty"`
+	Downstream []string `json:"downstream,omitempty"`
+}
+
+// TeamMetrics holds aggregated metrics for a team
+type TeamMetrics struct {
+	Name              string               `json:"name"`
+	Color             string               `json:"color"`
+	Members           []string             `json:"members"`
+	Period            Period               `json:"period"`
+	AggregatedMetrics ContributorMetrics   `json:"aggregated_metrics"`
+	MemberMetrics     []ContributorMetrics `json:"member_metrics"`
+	TotalScore        int                  `json:"total_score"`
+	AvgScore          float64              `json:"avg_score"`
+	MedianScore       float64              `json:"median_score"`
+	TrimmedMeanScore  float64              `json:"trimmed_mean_score"` // Mean without the top and bottom 20% of members
+
+	// Activity of the team's service accounts, included in
+	// AggregatedMetrics but not in the member statistics
+	ServiceAccounts *ContributorMetrics `json:"service_accounts,omitempty"`
+
+	// Position when teams are ranked by scoring.team_ranking
+	Rank int `json:"rank,omitempty"`
+
+	// Capacity in full-time equivalents, and the team's velocity divided by
+	// it, for comparing teams of different sizes
+	Capacity float64    `json:"capacity"`
+	PerFTE   TeamPerFTE `json:"per_fte"`
+
+	// Projected commits and PRs, when forecasting is enabled
+	Forecast *Forecast `json:"forecast,omitempty"`
+
+	// Analyzed repositories required by the repositories the team's members
+	// worked on, other than those, when options.dependencies is enabled
+	Upstream []string `json:"upstream,omitempty"`
+
+	// Activity inside the paths the team owns, whoever did it, when
+	// path_ownership lists the team
+	Ownership *PathOwnershipMetrics `json:"ownership,omitempty"`
+
+	// When the team's members review and how long they take, by weekday and hour
+	ReviewLatency *ReviewLatency `json:"review_latency,omitempty"`
+
+	// How often the team's reviews cross distant time zones
+	Timezones *TimezoneCollaboration `json:"timezones,omitempty"`
+}
+
+// PathOwnershipMetrics holds the activity inside a team's paths. A commit or
+// PR touching the paths counts once; its lines count only within them.
+type PathOwnershipMetrics struct {
+	Paths         []string `json:"paths"` // Owned path patterns
+	Commits       int      `json:"commits"`
+	MemberCommits int      `json:"member_commits"` // Commits by the team's own members
+	LinesAdded    int      `json:"lines_added"`
+	LinesDeleted  int      `json:"lines_deleted"`
+	PRsOpened     int      `json:"prs_opened"`
+	PRsMerged     int      `json:"prs_merged"`
+	Reviews       int      `json:"reviews"` // Reviews of the PRs touching the paths
+
+	// Contributors committing to the paths, most commits first
+	Contributors []PathContributor `json:"contributors"`
+}
+
+// PathContributor is a contributor's share of the commits to a team's paths
+type PathContributor struct {
+	Login   string `json:"login"`
+	Commits int    `json:"commits"`
+	Member  bool   `json:"member"` // A member of the owning team
+}
+
+// TeamPerFTE holds team totals divided by the team's capacity
+type TeamPerFTE struct {
+	Score        float64 `json:"score"`
+	Commits      float64 `json:"commits"`
+	PRsMerged    float64 `json:"prs_merged"`
+	ReviewsGiven float64 `json:"reviews_given"`
+	LinesAdded   float64 `json:"lines_added"`
+}
+
+// GroupMetrics rolls up the repositories of a group, a product or portfolio
+// configured between the organization and its repositories
+type GroupMetrics struct {
+	Name               string   `json:"name"`
+	Color              string   `json:"color,omitempty"`
+	Repositories       []string `json:"repositories"` // Full names of the grouped repositories
+	Period             Period   `json:"period"`
+	TotalCommits       int      `json:"total_commits"`
+	TotalPRs           int      `json:"total_prs"`
+	TotalReviews       int      `json:"total_reviews"`
+	ActiveContributors int      `json:"active_contributors"` // Distinct across the repositories
+	TotalLinesAdded    int      `json:"total_lines_added"`
+	TotalLinesDeleted  int      `json:"total_lines_deleted"`
+	TotalScore         int      `json:"total_score"`
+
+	// Contributors ranked by their score in the group's repositories
+	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+}
+
+// GlobalMetrics holds metrics aggregated across all repositories
+type GlobalMetrics struct {
+	Period       Period               `json:"period"`
+	Repositories []RepositoryMetrics  `json:"repositories"`
+	Contributors []ContributorMetrics `json:"contributors"` // Aggregated across all repos
+	Teams        []TeamMetrics        `json:"teams"`
+	Groups       []GroupMetrics       `json:"groups,omitempty"` // Products or portfolios of repositories
+	Leaderboard  []LeaderboardEntry   `json:"leaderboard"`
+	TopAchievers map[string]string    `json:"top_achievers"` // category -> login
+
+	// Leaderboard normalization mode; empty when ranked by raw score
+	Normalization string `json:"normalization,omitempty"`
+
+	// Team statistic the teams are ranked by; empty when they are not ranked
+	TeamRanking string `json:"team_ranking,omitempty"`
+
+	// Point values the scores were calculated with, keyed like scoring.points,
+	// so runs scored with different values can be told apart and rescored
+	Points map[string]float64 `json:"points,omitempty"`
+
+	// Summary stats
+	TotalContributors int `json:"total_contributors"`
+	TotalCommits      int `json:"total_commits"`
+	TotalPRs          int `json:"total_prs"`
+	TotalReviews      int `json:"total_reviews"`
+	TotalLinesAdded   int `json:"total_lines_added"`
+	TotalLinesDeleted int `json:"total_lines_deleted"`
+
+	// Meaningful line counts (excludes comments and whitespace)
+	TotalMeaningfulLinesAdded   int `json:"total_meaningful_lines_added"`
+	TotalMeaningfulLinesDeleted int `json:"total_meaningful_lines_deleted"`
+
+	// Velocity timeline (weekly granularity)
+	VelocityTimeline *VelocityTimeline `json:"velocity_timeline,omitempty"`
+
+	// Community contributions across the repositories, when community metrics are enabled
+	Community *CommunityMetrics `json:"community,omitempty"`
+
+	// Repositories requiring each other's packages, when options.dependencies is enabled
+	Dependencies *DependencyGraph `json:"dependencies,omitempty"`
+
+	// PRs merged in the period by type of change, when the release_notes output format is enabled
+	ReleaseNotes *ReleaseNotes `json:"release_notes,omitempty"`
+
+	// When reviews are submitted and how long they take, by weekday and hour
+	ReviewLatency *ReviewLatency `json:"review_latency,omitempty"`
+}
+
+// VelocityTimeline holds weekly velocity data for trend visualization
+type VelocityTimeline struct {
+	Labels   []string                 `json:"labels"`             // Week labels (e.g., "Dec 2", "Dec 9")
+	Series   []VelocityTimelineSeries `json:"series"`             // Data series (commits, PRs, reviews, score)
+	Forecast *Forecast                `json:"forecast,omitempty"` // Projection of the following weeks
+}
+
+// Forecast projects weekly series past the last complete week of the period
+type Forecast struct {
+	Method     string           `json:"method"`     // linear or holt_winters
+	Confidence float64          `json:"confidence"` // Confidence level of the bands
+	Labels     []string         `json:"labels"`     // Week labels of the projected weeks
+	Series     []ForecastSeries `json:"series"`
+}
+
+// ForecastSeries is the projection of one series with its confidence band
+type ForecastSeries struct {
+	Name  string    `json:"name"`  // Series name (e.g., "Commits", "PRs")
+	Data  []float64 `json:"data"`  // Projected values for each week
+	Lower []float64 `json:"lower"` // Lower bound of the band for each week
+	Upper []float64 `json:"upper"` // Upper bound of the band for each week
+	Total float64   `json:"total"` // Projected total over all weeks
+}
+
+// VelocityTimelineSeries represents a single data series in the velocity timeline
+type VelocityTimelineSeries struct {
+	Name  string    `json:"name"`  // Series name (e.g., "Commits", "PRs", "Score")
+	Color string    `json:"color"` // Series color
+	Data  []float64 `json:"data"`  // Values for each week
+}
+
+// LeaderboardEntry represents a single entry in the leaderboard
+type LeaderboardEntry struct {
+	Rank         int        `json:"rank"`
+	Tied         bool       `json:"tied,omitempty"` // Shares its rank with another entry
+	Login        string     `json:"login"`
+	Name         string     `json:"name"`
+	AvatarURL    string     `json:"avatar_url"`
+	Score        int        `json:"score"`
+	Normalized   float64    `json:"normalized_score,omitempty"` // Ranking value under scoring.normalization
+	ProRating    *ProRating `json:"pro_rating,omitempty"`       // Set when the score was pro-rated
+	Decay        *Decay     `json:"decay,omitempty"`            // Set when the score was weighted by recency
+	Trend        *Trend     `json:"trend,omitempty"`            // Short-term against long-term activity
+	Team         string     `json:"team,omitempty"`
+	TopCategory  string     `json:"top_category,omitempty"` // What they're best at
+	Achievements []string   `json:"achievements,omitempty"` // Achievement IDs earned
+}
+
+// TimeSeriesPoint represents a single data point in a time series
+type TimeSeriesPoint struct {
+	Date  time.Time `json:"date"`
+	Label string    `json:"label"`
+	Value float64   `json:"value"`
+}
+
+// TimeSeries represents a series of data points over time
+type TimeSeries struct {
+	Name   string            `json:"name"`
+	Color  string            `json:"color,omitempty"`
+	Points []TimeSeriesPoint `json:"points"`
+}
+
+// ChartData holds data formatted for charts
+type ChartData struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Type        string       `json:"type"` // line, bar, pie, doughnut
+	Labels      []string     `json:"labels"`
+	Series      []TimeSeries `json:"series"`
+}
+
+// DashboardData holds all data needed for the dashboard
+type DashboardData struct {
+	GeneratedAt   time.Time       `json:"generated_at"`
+	Period        Period          `json:"period"`
+	GlobalMetrics GlobalMetrics   `json:"global_metrics"`
+	Charts        []ChartData     `json:"charts"`
+	Achievements  []Achievement   `json:"achievements"`
+	Configuration DashboardConfig `json:"configuration"`
+}
+
+// DashboardConfig holds UI configuration
+type DashboardConfig struct {
+	Title            string   `json:"title"`
+	Description      string   `json:"description,omitempty"`
+	Repositories     []string `json:"repositories"`
+	Teams            []string `json:"teams,omitempty"`
+	Granularities    []string `json:"granularities"`
+	ScoringEnabled   bool     `json:"scoring_enabled"`
+	ShowAchievements bool     `json:"show_achievements"`
+}
+
+// Achievement represents an earned achievement badge
+type Achievement struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	EarnedBy    string `json:"earned_by"` // Login of user who earned it
+	EarnedAt    string `json:"earned_at"` // When it was earned (period label)
+}
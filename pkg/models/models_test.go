@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuthor_DisplayName(t *testing.T) {
@@ -189,6 +190,69 @@ func TestPullRequest_CalculateTimeToFirstReview(t *testing.T) {
 	})
 }
 
+func TestPullRequest_Drafts(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return created.Add(time.Duration(hours) * time.Hour) }
+
+	t.Run("opened ready", func(t *testing.T) {
+		t.Parallel()
+
+		pr := PullRequest{CreatedAt: created}
+		assert.False(t, pr.WasDraft())
+		assert.Equal(t, created, *pr.ReadyAt())
+		assert.Zero(t, pr.TimeInDraft(at(10)))
+	})
+
+	t.Run("opened as draft and marked ready", func(t *testing.T) {
+		t.Parallel()
+
+		pr := PullRequest{CreatedAt: created, DraftEvents: []DraftEvent{
+			{At: at(3), Draft: false},
+			{At: at(5), Draft: true},
+			{At: at(6), Draft: false},
+		}}
+		assert.True(t, pr.OpenedAsDraft())
+		assert.True(t, pr.WasDraft())
+		assert.Equal(t, at(3), *pr.ReadyAt())
+		assert.Equal(t, 4*time.Hour, pr.TimeInDraft(at(10)))
+	})
+
+	t.Run("converted to draft", func(t *testing.T) {
+		t.Parallel()
+
+		pr := PullRequest{CreatedAt: created, Draft: true, DraftEvents: []DraftEvent{{At: at(2), Draft: true}}}
+		assert.False(t, pr.OpenedAsDraft())
+		assert.Equal(t, created, *pr.ReadyAt())
+		assert.Equal(t, 8*time.Hour, pr.TimeInDraft(at(10)))
+	})
+
+	t.Run("abandoned draft", func(t *testing.T) {
+		t.Parallel()
+
+		closed := at(4)
+		pr := PullRequest{CreatedAt: created, State: PRStateClosed, ClosedAt: &closed, Draft: true}
+		assert.Nil(t, pr.ReadyAt())
+		assert.Equal(t, 4*time.Hour, pr.TimeInDraft(at(10)))
+	})
+
+	t.Run("reviews of a draft don't start the review clock", func(t *testing.T) {
+		t.Parallel()
+
+		pr := PullRequest{
+			CreatedAt:   created,
+			DraftEvents: []DraftEvent{{At: at(3), Draft: false}},
+			Reviews:     []Review{{SubmittedAt: at(1)}, {SubmittedAt: at(5)}},
+		}
+		assert.Equal(t, 2*time.Hour, *pr.CalculateTimeToFirstReview())
+
+		pr.DraftEvents = nil
+		pr.Draft = true
+		assert.Nil(t, pr.CalculateTimeToFirstReview())
+	})
+}
+
 func TestReview_IsApproval(t *testing.T) {
 	t.Parallel()
 
@@ -321,3 +385,59 @@ func TestPullRequest_TotalChanges(t *testing.T) {
 	pr := PullRequest{Additions: 200, Deletions: 100}
 	assert.Equal(t, 300, pr.TotalChanges())
 }
+
+func TestGenerateJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := GenerateJSONSchema("contributor", ContributorDocument{})
+
+	assert.Equal(t, JSONSchemaDraft, schema.Schema)
+	assert.Equal(t, SchemaType{"object"}, schema.Type)
+
+	// Embedded metrics are flattened next to schema_version
+	require.Contains(t, schema.Properties, "schema_version")
+	require.NotNil(t, schema.Properties["schema_version"].Const)
+	assert.Equal(t, SchemaVersion, *schema.Properties["schema_version"].Const)
+	assert.Contains(t, schema.Properties, "login")
+	assert.Contains(t, schema.Required, "login")
+
+	// omitempty fields are optional
+	assert.Contains(t, schema.Properties, "linear_issues_referenced")
+	assert.NotContains(t, schema.Required, "linear_issues_referenced")
+
+	// Nested named structs are referenced via $defs
+	assert.Equal(t, "#/$defs/Period", schema.Properties["period"].Ref)
+	require.Contains(t, schema.Defs, "Period")
+	assert.Equal(t, "date-time", schema.Defs["Period"].Properties["start"].Format)
+
+	// Slices are nullable arrays
+	assert.Equal(t, SchemaType{"array", "null"}, schema.Properties["achievements"].Type)
+}
+
+func TestSchemaType_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	single, err := SchemaType{"string"}.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"string"`, string(single))
+
+	multi, err := SchemaType{"array", "null"}.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `["array","null"]`, string(multi))
+}
+
+func TestNewTrend(t *testing.T) {
+	t.Parallel()
+
+	rolling := func(short, long float64) []RollingAverage {
+		return []RollingAverage{{WindowDays: 7, Score: short}, {WindowDays: 30, Score: long}}
+	}
+
+	assert.Nil(t, NewTrend(nil))
+	assert.Nil(t, NewTrend(rolling(1, 1)[:1]))
+	assert.Equal(t, &Trend{Direction: TrendUp, Change: 50}, NewTrend(rolling(15, 10)))
+	assert.Equal(t, &Trend{Direction: TrendDown, Change: -25}, NewTrend(rolling(7.5, 10)))
+	assert.Equal(t, &Trend{Direction: TrendFlat, Change: 4}, NewTrend(rolling(10.4, 10)))
+	assert.Equal(t, &Trend{Direction: TrendFlat}, NewTrend(rolling(0, 0)))
+	assert.Equal(t, &Trend{Direction: TrendUp}, NewTrend(rolling(1, 0)))
+}
@@ -0,0 +1,239 @@
+package models
+
+import "time"
+
+// PRState represents the state of a pull request
+type PRState string
+
+const (
+	PRStateOpen   PRState = "open"
+	PRStateClosed PRState = "closed"
+	PRStateMerged PRState = "merged"
+)
+
+// Build statuses of a merge commit
+const (
+	BuildSuccess = "success"
+	BuildFailure = "failure"
+	BuildPending = "pending"
+)
+
+// PullRequest represents a GitHub pull request
+type PullRequest struct {
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	Body         string     `json:"body,omitempty"`
+	State        PRState    `json:"state"`
+	Author       Author     `json:"author"`
+	Repository   string     `json:"repository"`  // owner/repo format
+	BaseBranch   string     `json:"base_branch"` // Target branch (e.g., main, master)
+	HeadBranch   string     `json:"head_branch"` // Source branch
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	MergedAt     *time.Time `json:"merged_at,omitempty"`
+	MergedBy     string     `json:"merged_by,omitempty"` // Login of who merged it; unknown when PRs are listed over REST
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+	Additions    int        `json:"additions"`
+	Deletions    int        `json:"deletions"`
+	FilesChanged int        `json:"files_changed"`
+	CommitCount  int        `json:"commit_count"`
+	Comments     int        `json:"comments"`
+	Reviews      []Review   `json:"reviews,omitempty"`
+	Labels       []string   `json:"labels,omitempty"`
+	URL          string     `json:"url"`
+
+	// The author's GitHub association with the repository, e.g. MEMBER or FIRST_TIME_CONTRIBUTOR
+	Association string `json:"author_association,omitempty"`
+
+	// CI result on the merge commit; only collected when options.build_status is enabled
+	MergeCommitSHA string `json:"merge_commit_sha,omitempty"`
+	BuildStatus    string `json:"build_status,omitempty"` // success, failure, pending, or empty without CI
+
+	// Line coverage percentage at the merge commit, when coverage reports are configured
+	Coverage *float64 `json:"coverage,omitempty"`
+
+	// Whether the PR is currently a draft, and when it was marked ready for
+	// review or converted back to a draft; transitions need use_graphql
+	Draft       bool         `json:"draft,omitempty"`
+	DraftEvents []DraftEvent `json:"draft_events,omitempty"`
+
+	// Who enabled auto-merge and who added the PR to the merge queue, and
+	// when they last did; the times need use_graphql
+	AutoMergeBy string     `json:"auto_merge_by,omitempty"`
+	AutoMergeAt *time.Time `json:"auto_merge_at,omitempty"`
+	QueuedBy    string     `json:"queued_by,omitempty"`
+	QueuedAt    *time.Time `json:"queued_at,omitempty"`
+
+	// Effort estimate from the hooks.effort command
+	Effort *Effort `json:"effort,omitempty"`
+
+	// Kudos reactions on the PR, when options.reactions is enabled
+	Reactions []Reaction `json:"reactions,omitempty"`
+
+	// Paths changed by the PR; only collected when the repository is scoped to paths
+	FilesModified []string `json:"files_modified,omitempty"`
+
+	// Meaningful line counts (excludes comments and whitespace)
+	MeaningfulAdditions int `json:"meaningful_additions"`
+	MeaningfulDeletions int `json:"meaningful_deletions"`
+
+	// Derived fields
+	TimeToMerge       *time.Duration `json:"time_to_merge,omitempty"`
+	TimeToFirstReview *time.Duration `json:"time_to_first_review,omitempty"`
+}
+
+// DraftEvent is a PR being marked ready for review (Draft false) or
+// converted to a draft (Draft true)
+type DraftEvent struct {
+	At    time.Time `json:"at"`
+	Draft bool      `json:"draft"`
+}
+
+// Effort is the work a PR was estimated to take
+type Effort struct {
+	Points float64 `json:"points"`         // Story points, or the points of the size
+	Size   string  `json:"size,omitempty"` // T-shirt size, when estimated as one
+}
+
+// PullRequestFile is a file changed by a pull request
+type PullRequestFile struct {
+	Path         string `json:"path"`
+	PreviousPath string `json:"previous_path,omitempty"` // Set when the file was renamed
+	Additions    int    `json:"additions"`
+	Deletions    int    `json:"deletions"`
+}
+
+// IsMerged returns true if the PR has been merged
+func (pr *PullRequest) IsMerged() bool {
+	return pr.State == PRStateMerged || pr.MergedAt != nil
+}
+
+// TotalChanges returns the total lines changed (additions + deletions)
+func (pr *PullRequest) TotalChanges() int {
+	return pr.Additions + pr.Deletions
+}
+
+// CalculateTimeToMerge calculates the time from PR creation to merge
+func (pr *PullRequest) CalculateTimeToMerge() *time.Duration {
+	if pr.MergedAt == nil {
+		return nil
+	}
+	d := pr.MergedAt.Sub(pr.CreatedAt)
+	return &d
+}
+
+// OpenedAsDraft reports whether the PR was opened as a draft
+func (pr *PullRequest) OpenedAsDraft() bool {
+	if len(pr.DraftEvents) == 0 {
+		return pr.Draft
+	}
+	// The first transition leaves the state the PR was opened in
+	return !pr.DraftEvents[0].Draft
+}
+
+// WasDraft reports whether the PR was a draft at any point
+func (pr *PullRequest) WasDraft() bool {
+	return pr.Draft || len(pr.DraftEvents) > 0
+}
+
+// ReadyAt returns when the PR was first ready for review: its creation unless
+// it was opened as a draft, or nil while it has never left draft
+func (pr *PullRequest) ReadyAt() *time.Time {
+	if !pr.OpenedAsDraft() {
+		t := pr.CreatedAt
+		return &t
+	}
+	for _, e := range pr.DraftEvents {
+		if !e.Draft {
+			t := e.At
+			return &t
+		}
+	}
+	return nil
+}
+
+// TimeInDraft sums the time the PR spent as a draft. A PR still in draft
+// counts until it was closed, or until the given time while open.
+func (pr *PullRequest) TimeInDraft(until time.Time) time.Duration {
+	if pr.ClosedAt != nil {
+		until = *pr.ClosedAt
+	} else if pr.MergedAt != nil {
+		until = *pr.MergedAt
+	}
+
+	var total time.Duration
+	var since *time.Time
+	if pr.OpenedAsDraft() {
+		t := pr.CreatedAt
+		since = &t
+	}
+	for _, e := range pr.DraftEvents {
+		switch {
+		case e.Draft && since == nil:
+			t := e.At
+			since = &t
+		case !e.Draft && since != nil:
+			total += e.At.Sub(*since)
+			since = nil
+		}
+	}
+	if since != nil && until.After(*since) {
+		total += until.Sub(*since)
+	}
+	return total
+}
+
+// CalculateTimeToFirstReview calculates the time from the PR being ready for
+// review to its first review; reviews of a draft don't count
+func (pr *PullRequest) CalculateTimeToFirstReview() *time.Duration {
+	ready := pr.ReadyAt()
+	if len(pr.Reviews) == 0 || ready == nil {
+		return nil
+	}
+
+	var firstReview *time.Time
+	for _, review := range pr.Reviews {
+		if review.SubmittedAt.Before(*ready) {
+			continue
+		}
+		if firstReview == nil || review.SubmittedAt.Before(*firstReview) {
+			t := review.SubmittedAt
+			firstReview = &t
+		}
+	}
+
+	if firstReview == nil {
+		return nil
+	}
+
+	d := firstReview.Sub(*ready)
+	return &d
+}
+
+// PRSize represents the size category of a pull request
+type PRSize string
+
+const (
+	PRSizeXS PRSize = "xs" // < 10 lines
+	PRSizeS  PRSize = "s"  // 10-50 lines
+	PRSizeM  PRSize = "m"  // 50-200 lines
+	PRSizeL  PRSize = "l"  // 200-500 lines
+	PRSizeXL PRSize = "xl" // > 500 lines
+)
+
+// Size returns the size category of the PR based on total changes
+func (pr *PullRequest) Size() PRSize {
+	total := pr.TotalChanges()
+	switch {
+	case total < 10:
+		return PRSizeXS
+	case total < 50:
+		return PRSizeS
+	case total < 200:
+		return PRSizeM
+	case total < 500:
+		return PRSizeL
+	default:
+		return PRSizeXL
+	}
+}
@@ -0,0 +1,56 @@
+package models
+
+// RawData holds the raw collected data from GitHub
+type RawData struct {
+	Commits       []Commit       `json:"commits"`
+	PullRequests  []PullRequest  `json:"pull_requests"`
+	Reviews       []Review       `json:"reviews"`
+	Issues        []Issue        `json:"issues"`
+	IssueComments []IssueComment `json:"issue_comments"`
+
+	// ReviewComments holds the inline comments on the pull requests' diffs.
+	// Only populated when options.review_tone is enabled.
+	ReviewComments []ReviewComment `json:"review_comments,omitempty"`
+
+	// LinearIssues holds Linear issue states keyed by identifier (e.g., ENG-123).
+	// Only populated when the Linear integration is enabled with an API key.
+	LinearIssues map[string]LinearIssue `json:"linear_issues,omitempty"`
+
+	// RepositorySettings holds the settings inspected by health checks, keyed by owner/name
+	RepositorySettings map[string]RepositorySettings `json:"repository_settings,omitempty"`
+
+	// AuditEvents holds organization audit-log entries for the analyzed repositories.
+	// Only populated when the audit log integration is enabled.
+	AuditEvents []AuditEvent `json:"audit_events,omitempty"`
+
+	// OrgMembers holds the member logins of the organizations owning the
+	// repositories, keyed by lowercase organization.
+	// Only populated when options.org_members is enabled.
+	OrgMembers map[string][]string `json:"org_members,omitempty"`
+
+	// OptOuts holds the logins whose profile repository has the opt-out
+	// file. Only populated when options.profile_opt_out is enabled.
+	OptOuts []string `json:"opt_outs,omitempty"`
+
+	// ExternalIdentities holds the SAML SSO identities linked to logins in
+	// the organizations owning the repositories. Only populated when the
+	// SAML identities integration is enabled.
+	ExternalIdentities []ExternalIdentity `json:"external_identities,omitempty"`
+
+	// Adoption holds stars, forks and watchers, keyed by owner/name.
+	// Only populated when options.adoption is enabled.
+	Adoption map[string]RepositoryAdoption `json:"adoption,omitempty"`
+
+	// LintReports holds static-analysis findings at the period boundaries.
+	// Only populated for repositories with lint configured.
+	LintReports []LintReport `json:"lint_reports,omitempty"`
+
+	// Manifests holds the packages each repository publishes and requires.
+	// Only populated when options.dependencies is enabled.
+	Manifests []RepositoryManifest `json:"manifests,omitempty"`
+
+	// Mainline holds the first-parent history of each repository's default
+	// branch, keyed by owner/name. Only populated when options.direct_pushes
+	// is enabled.
+	Mainline map[string][]MainlineCommit `json:"mainline,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Reactions counted as kudos, named as the REST API names them
+const (
+	ReactionThumbsUp = "+1"     // 👍
+	ReactionHeart    = "heart"  // ❤️
+	ReactionHooray   = "hooray" // 🎉
+)
+
+// Reaction is a kudos reaction left on a pull request, review or issue
+// comment. Only collected when options.reactions is enabled.
+type Reaction struct {
+	Login   string `json:"login"`
+	Content string `json:"content"` // +1, heart or hooray
+}
+
+// IsKudos returns true if a reaction's content counts as kudos
+func IsKudos(content string) bool {
+	switch content {
+	case ReactionThumbsUp, ReactionHeart, ReactionHooray:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Change types of release notes entries, in the order they are listed
+const (
+	ChangeBreaking     = "breaking"
+	ChangeFeature      = "feat"
+	ChangeFix          = "fix"
+	ChangePerformance  = "perf"
+	ChangeRefactor     = "refactor"
+	ChangeDocs         = "docs"
+	ChangeTests        = "test"
+	ChangeDependencies = "deps"
+	ChangeMaintenance  = "chore"
+	ChangeOther        = "other"
+)
+
+// ChangeTypes lists the types of change in the order release notes show them
+var ChangeTypes = []string{
+	ChangeBreaking, ChangeFeature, ChangeFix, ChangePerformance, ChangeRefactor,
+	ChangeDocs, ChangeTests, ChangeDependencies, ChangeMaintenance, ChangeOther,
+}
+
+// ReleaseNotes lists the PRs merged in the period by type of change, when
+// the release_notes output format is enabled
+type ReleaseNotes struct {
+	Sections []ReleaseSection `json:"sections"`
+	Authors  []ReleaseAuthor  `json:"authors"`
+}
+
+// ReleaseSection holds the merged PRs of one type of change, by author
+type ReleaseSection struct {
+	Type    string         `json:"type"` // One of the Change* types
+	Entries []ReleaseEntry `json:"entries"`
+}
+
+// ReleaseEntry is a merged PR, its title stripped of any conventional
+// commit prefix
+type ReleaseEntry struct {
+	Repository string    `json:"repository"` // owner/repo format
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	Scope      string    `json:"scope,omitempty"` // Conventional commit scope, feat(api): -> api
+	URL        string    `json:"url"`
+	Author     string    `json:"author"`
+	MergedAt   time.Time `json:"merged_at"`
+}
+
+// ReleaseAuthor counts the merged PRs of an author by type of change
+type ReleaseAuthor struct {
+	Login string         `json:"login"`
+	PRs   int            `json:"prs"`
+	Types map[string]int `json:"types"`
+}
+
+// NewReleaseNotes builds release notes from merged PRs by type of change,
+// each type's entries by author and then merge time, and the authors by
+// merged PRs
+func NewReleaseNotes(entries map[string][]ReleaseEntry) *ReleaseNotes {
+	notes := &ReleaseNotes{Sections: []ReleaseSection{}, Authors: []ReleaseAuthor{}}
+	authors := make(map[string]*ReleaseAuthor)
+	for _, change := range ChangeTypes {
+		section := entries[change]
+		if len(section) == 0 {
+			continue
+		}
+		sort.Slice(section, func(i, j int) bool {
+			if a, b := strings.ToLower(section[i].Author), strings.ToLower(section[j].Author); a != b {
+				return a < b
+			}
+			if !section[i].MergedAt.Equal(section[j].MergedAt) {
+				return section[i].MergedAt.Before(section[j].MergedAt)
+			}
+			if section[i].Repository != section[j].Repository {
+				return section[i].Repository < section[j].Repository
+			}
+			return section[i].Number < section[j].Number
+		})
+		notes.Sections = append(notes.Sections, ReleaseSection{Type: change, Entries: section})
+
+		for _, e := range section {
+			author, ok := authors[e.Author]
+			if !ok {
+				author = &ReleaseAuthor{Login: e.Author, Types: make(map[string]int)}
+				authors[e.Author] = author
+			}
+			author.PRs++
+			author.Types[change]++
+		}
+	}
+
+	for _, author := range authors {
+		notes.Authors = append(notes.Authors, *author)
+	}
+	// Most merged PRs first
+	sort.Slice(notes.Authors, func(i, j int) bool {
+		if notes.Authors[i].PRs != notes.Authors[j].PRs {
+			return notes.Authors[i].PRs > notes.Authors[j].PRs
+		}
+		return strings.ToLower(notes.Authors[i].Login) < strings.ToLower(notes.Authors[j].Login)
+	})
+	return notes
+}
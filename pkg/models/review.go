@@ -23,6 +23,10 @@ type Review struct {
 	SubmittedAt   time.Time   `json:"submitted_at"`
 	Body          string      `json:"body,omitempty"`
 	CommentsCount int         `json:"comments_count"`
+	CommitID      string      `json:"commit_id,omitempty"` // Head commit the review was submitted on
+
+	// Kudos reactions on the review, when options.reactions is enabled with use_graphql
+	Reactions []Reaction `json:"reactions,omitempty"`
 
 	// Derived fields
 	ResponseTime *time.Duration `json:"response_time,omitempty"` // Time from PR creation or review request to review
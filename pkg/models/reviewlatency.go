@@ -0,0 +1,40 @@
+package models
+
+// ReviewLatency shows when reviews happen and how long they take, by weekday
+// and hour in UTC, so distributed teams can pick the hours to hand reviews
+// over in. Weekdays are indexed from Monday (0) to Sunday (6).
+type ReviewLatency struct {
+	Reviews int `json:"reviews"` // Reviews submitted
+	Timed   int `json:"timed"`   // Reviews with a response time
+
+	// Hours of the week with reviews submitted or requested, by weekday and hour
+	Heatmap []ReviewLatencyCell `json:"heatmap"`
+
+	// Response times by the hour the review was requested in, over all weekdays
+	Hours [24]LatencyStats `json:"hours"`
+
+	// Response times of all timed reviews
+	Overall LatencyStats `json:"overall"`
+}
+
+// ReviewLatencyCell is an hour of the week
+type ReviewLatencyCell struct {
+	Weekday   int          `json:"weekday"`   // From Monday (0)
+	Hour      int          `json:"hour"`      // UTC
+	Submitted int          `json:"submitted"` // Reviews submitted in the hour
+	Latency   LatencyStats `json:"latency"`   // Response times of the reviews requested in the hour
+}
+
+// LatencyStats is the distribution of review response times, in hours
+type LatencyStats struct {
+	Count  int     `json:"count"`
+	Median float64 `json:"median"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+}
+
+// TeamReviewLatency is the review latency of the reviews a team's members gave
+type TeamReviewLatency struct {
+	Team string `json:"team"`
+	*ReviewLatency
+}
@@ -0,0 +1,49 @@
+package models
+
+// ReviewTone summarizes the tone of review comments with local heuristics:
+// how many ask questions, how many sentences are directives and how many
+// comments carry emoji. Only collected when options.review_tone is enabled.
+type ReviewTone struct {
+	Comments    int `json:"comments"`    // Reviews with a comment analyzed
+	Sentences   int `json:"sentences"`   // Sentences in them, outside code and quotes
+	Questions   int `json:"questions"`   // Comments asking at least one question
+	Imperatives int `json:"imperatives"` // Sentences starting with an imperative verb
+	WithEmoji   int `json:"with_emoji"`  // Comments using at least one emoji
+
+	QuestionRatio     float64 `json:"question_ratio"`     // Percentage of the comments asking a question
+	ImperativeDensity float64 `json:"imperative_density"` // Percentage of the sentences that are imperative
+	EmojiRatio        float64 `json:"emoji_ratio"`        // Percentage of the comments using emoji
+}
+
+// AddReviewTone returns the combined tone of a and b, either of which may be
+// nil, without modifying them
+func AddReviewTone(a, b *ReviewTone) *ReviewTone {
+	if a == nil && b == nil {
+		return nil
+	}
+	var t ReviewTone
+	for _, src := range []*ReviewTone{a, b} {
+		if src == nil {
+			continue
+		}
+		t.Comments += src.Comments
+		t.Sentences += src.Sentences
+		t.Questions += src.Questions
+		t.Imperatives += src.Imperatives
+		t.WithEmoji += src.WithEmoji
+	}
+	t.updateRatios()
+	return &t
+}
+
+// updateRatios derives the percentages from the counts
+func (t *ReviewTone) updateRatios() {
+	t.QuestionRatio, t.EmojiRatio, t.ImperativeDensity = 0, 0, 0
+	if t.Comments > 0 {
+		t.QuestionRatio = float64(t.Questions) / float64(t.Comments) * 100
+		t.EmojiRatio = float64(t.WithEmoji) / float64(t.Comments) * 100
+	}
+	if t.Sentences > 0 {
+		t.ImperativeDensity = float64(t.Imperatives) / float64(t.Sentences) * 100
+	}
+}
@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+// RunReport describes how an analysis run collected its data
+type RunReport struct {
+	StartedAt time.Time `json:"started_at"`
+	Duration  float64   `json:"duration_seconds"`
+	Offline   bool      `json:"offline"`       // Rebuilt from the cached raw data snapshot
+	API       *APIUsage `json:"api,omitempty"` // Nil for offline runs
+
+	// How the pull requests and issues of each repository were fetched
+	Repositories []RepositoryFetch `json:"repositories,omitempty"`
+
+	// Line counts of sampled commits compared with GitHub (analyze --verify)
+	Verification *LineVerification `json:"verification,omitempty"`
+}
+
+// How the data of a repository was fetched
+const (
+	FetchGraphQL      = "graphql"
+	FetchREST         = "rest"
+	FetchPartial      = "graphql_partial" // GraphQL until it failed, the rest over REST
+	FetchRESTFallback = "rest_fallback"   // GraphQL failed before returning anything
+	FetchArchive      = "archive"         // Read from a migration export (source.archive)
+	FetchGHArchive    = "gharchive"       // Read from GH Archive files (source.gharchive)
+)
+
+// RepositoryFetch records the API used for the pull requests and the issues
+// of a repository (Fetch*), which fall back to REST independently
+type RepositoryFetch struct {
+	Repository   string `json:"repository"`
+	PullRequests string `json:"pull_requests"`
+	Issues       string `json:"issues"`
+}
+
+// LineVerification compares the additions and deletions counted from the
+// local clones with GitHub's commit stats for a sample of commits
+type LineVerification struct {
+	Sampled    int     `json:"sampled"`
+	Mismatched int     `json:"mismatched"`
+	Percent    float64 `json:"mismatch_percent"` // Share of the sampled commits whose counts differ
+
+	// Difference of the local totals from GitHub's, relative to GitHub's
+	AdditionsDelta float64 `json:"additions_delta_percent"`
+	DeletionsDelta float64 `json:"deletions_delta_percent"`
+
+	Mismatches []LineMismatch `json:"mismatches,omitempty"`
+}
+
+// LineMismatch is a sampled commit whose line counts differ from GitHub's
+type LineMismatch struct {
+	Repository      string `json:"repository"`
+	SHA             string `json:"sha"`
+	Additions       int    `json:"additions"`
+	Deletions       int    `json:"deletions"`
+	GitHubAdditions int    `json:"github_additions"`
+	GitHubDeletions int    `json:"github_deletions"`
+}
+
+// APIUsage accounts for the GitHub API calls made during a run
+type APIUsage struct {
+	RESTCalls     int   `json:"rest_calls"`
+	GraphQLCalls  int   `json:"graphql_calls"`
+	BytesSent     int64 `json:"bytes_sent"`
+	BytesReceived int64 `json:"bytes_received"`
+
+	// Response cache lookups (see `git-velocity cache stats` for totals across runs)
+	CacheHits   int64 `json:"cache_hits"`
+	CacheMisses int64 `json:"cache_misses"`
+
+	// Retries of transient errors and circuit breaker trips
+	Retries             int `json:"retries"`
+	RetriesRefused      int `json:"retries_refused"`
+	CircuitBreakerTrips int `json:"circuit_breaker_trips"`
+
+	// Time requests were held back by options.max_requests_per_hour
+	ThrottledSeconds float64 `json:"throttled_seconds,omitempty"`
+
+	// Last rate limit seen per resource ("core", "graphql", "search", ...)
+	RateLimits []RateLimitStatus `json:"rate_limits,omitempty"`
+}
+
+// RateLimitStatus is the last reported rate limit of an API resource
+type RateLimitStatus struct {
+	Resource  string    `json:"resource"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	ResetAt   time.Time `json:"reset_at"`
+	Token     int       `json:"token,omitempty"` // Position of the token in a token pool (1-based)
+}
+
+// Calls returns the total number of API calls
+func (u *APIUsage) Calls() int {
+	return u.RESTCalls + u.GraphQLCalls
+}
@@ -0,0 +1,33 @@
+package models
+
+// TimezoneCollaboration measures how often a team's reviews cross distant
+// time zones. Only reviews between contributors whose time zones are known,
+// configured or inferred from their commits, are counted.
+type TimezoneCollaboration struct {
+	Reviews       int     `json:"reviews"`        // Reviews its members gave or received
+	CrossTimezone int     `json:"cross_timezone"` // Of which between distant time zones
+	Index         float64 `json:"index"`          // Percentage of the reviews between distant time zones
+
+	// Time zones of the members, and the members whose zone isn't known
+	Members []MemberTimezone `json:"members"`
+	Unknown []string         `json:"unknown,omitempty"`
+
+	// Reviewers and PR authors reviewing each other across distant time zones, most reviews first
+	Pairs []TimezonePair `json:"pairs,omitempty"`
+}
+
+// MemberTimezone is the time zone a team member works in
+type MemberTimezone struct {
+	Login    string  `json:"login"`
+	Offset   float64 `json:"offset"`             // Hours from UTC at the end of the period
+	Zone     string  `json:"zone,omitempty"`     // IANA name, when configured
+	Inferred bool    `json:"inferred,omitempty"` // Taken from the UTC offsets of their commits
+}
+
+// TimezonePair counts a reviewer's reviews of an author's PRs across distant time zones
+type TimezonePair struct {
+	Reviewer string  `json:"reviewer"`
+	Author   string  `json:"author"`
+	Reviews  int     `json:"reviews"`
+	Hours    float64 `json:"hours"` // Hours between their time zones at the latest review
+}
@@ -0,0 +1,53 @@
+package models
+
+import "math"
+
+// TrendDirection tells whether activity is rising, falling or steady
+type TrendDirection string
+
+const (
+	TrendUp   TrendDirection = "up"
+	TrendDown TrendDirection = "down"
+	TrendFlat TrendDirection = "flat"
+)
+
+// TrendFlatThreshold is the change, in percent, within which a trend is flat
+const TrendFlatThreshold = 5.0
+
+// RollingAverage holds per-day averages over the last WindowDays days of the period
+type RollingAverage struct {
+	WindowDays int     `json:"window_days"`
+	Commits    float64 `json:"commits"`
+	PRs        float64 `json:"prs"`
+	Score      float64 `json:"score"`
+}
+
+// Trend compares the shortest rolling average score with the longest one
+type Trend struct {
+	Direction TrendDirection `json:"direction"`
+	Change    float64        `json:"change_percent"` // Change of the short average against the long one
+}
+
+// NewTrend returns the trend between the first (shortest) and last (longest)
+// rolling averages, or nil with fewer than two
+func NewTrend(averages []RollingAverage) *Trend {
+	if len(averages) < 2 {
+		return nil
+	}
+	short, long := averages[0].Score, averages[len(averages)-1].Score
+	trend := &Trend{Direction: TrendFlat}
+	if long == 0 {
+		if short > 0 {
+			trend.Direction = TrendUp
+		}
+		return trend
+	}
+	trend.Change = math.Round((short-long)/long*1000) / 10
+	switch {
+	case trend.Change > TrendFlatThreshold:
+		trend.Direction = TrendUp
+	case trend.Change < -TrendFlatThreshold:
+		trend.Direction = TrendDown
+	}
+	return trend
+}
@@ -54,6 +54,14 @@ const achievementCategories = {
   'issue-comment': ['issue-comment-5', 'issue-comment-10', 'issue-comment-25', 'issue-comment-50', 'issue-comment-100'],
   // Issue references in commits
   'issue-ref': ['issue-ref-5', 'issue-ref-10', 'issue-ref-25', 'issue-ref-50', 'issue-ref-100'],
+  // Merged PRs linking issues
+  'linked-pr': ['linked-pr-5', 'linked-pr-10', 'linked-pr-25', 'linked-pr-50'],
+  // Share of merged PRs linking issues
+  'traceable': ['traceable-75', 'traceable-90', 'traceable-100'],
+  // Coverage improved
+  'coverage': ['coverage-1', 'coverage-5', 'coverage-10', 'coverage-25'],
+  // Security fixes
+  'security': ['security-1', 'security-5', 'security-10', 'security-25'],
 }
 
 // Get the category for an achievement ID
@@ -115,6 +123,7 @@ const categoryPriority = {
   'review': 8,
   'lines-added': 7,
   'perfect-pr': 6,
+  'security': 5.8,
   'issue': 5.5,
   'issue-close': 5.4,
   'streak': 5,
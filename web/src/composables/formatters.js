@@ -1,3 +1,5 @@
+import { localeTag } from './i18n.js'
+
 // Number formatting thresholds
 const ONE_MILLION = 1_000_000
 const ONE_THOUSAND = 1_000
@@ -40,7 +42,7 @@ export function formatDuration(hours) {
 export function formatDate(dateInput) {
   if (!dateInput) return ''
   const date = new Date(dateInput)
-  return date.toLocaleDateString('en-US', {
+  return date.toLocaleDateString(localeTag(), {
     year: 'numeric',
     month: 'short',
     day: 'numeric'
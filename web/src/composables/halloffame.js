@@ -0,0 +1,27 @@
+// The lifetime achievement ledger, data/hall-of-fame.json, written when
+// output.hall_of_fame is enabled
+
+import { ref } from 'vue'
+
+const ledger = ref(null)
+let loading = null
+
+/**
+ * Load the ledger once; resolves to null for output without one
+ */
+export function loadHallOfFame() {
+  if (!loading) {
+    loading = fetch('./data/hall-of-fame.json', { cache: 'no-store' })
+      .then(response => (response.ok ? response.json() : null))
+      .catch(() => null)
+      .then(data => {
+        ledger.value = data
+        return data
+      })
+  }
+  return loading
+}
+
+export function useHallOfFame() {
+  return ledger
+}
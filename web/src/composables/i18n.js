@@ -0,0 +1,67 @@
+// Dashboard localization backed by data/locale.json, which the generator
+// writes for the configured output.locale
+
+import { ref } from 'vue'
+
+// Used until locale.json has loaded, and for dashboards generated before it existed
+const fallback = {
+  locale: 'en',
+  strings: {
+    'nav.dashboard': 'Dashboard',
+    'nav.leaderboard': 'Leaderboard',
+    'nav.how_scoring_works': 'How Scoring Works',
+    'app.loading': 'Loading dashboard...',
+    'app.load_failed': 'Failed to load data',
+    'footer.generated_by': 'Generated by',
+    'a11y.skip_to_content': 'Skip to content',
+    'a11y.main_navigation': 'Main navigation',
+    'a11y.toggle_menu': 'Toggle menu',
+    'a11y.velocity_chart': 'Velocity chart',
+    'chart.forecast': 'forecast',
+    'chart.forecast_low': 'low',
+    'chart.forecast_high': 'high'
+  },
+  achievements: {}
+}
+
+const catalog = ref(fallback)
+
+/**
+ * Load the generated catalog, keeping the English fallback if it is missing
+ */
+export async function loadLocale() {
+  try {
+    const response = await fetch('./data/locale.json', { cache: 'no-store' })
+    if (!response.ok) return
+    catalog.value = await response.json()
+    document.documentElement.lang = catalog.value.locale
+  } catch {
+    // Older output without locale.json
+  }
+}
+
+/**
+ * BCP 47 tag of the dashboard locale, for Intl formatting
+ */
+export function localeTag() {
+  return catalog.value.locale || 'en'
+}
+
+/**
+ * Translate a key, replacing {name} placeholders from params
+ */
+export function t(key, params = {}) {
+  const s = catalog.value.strings?.[key] ?? fallback.strings[key] ?? key
+  return s.replace(/\{(\w+)\}/g, (match, name) => (name in params ? params[name] : match))
+}
+
+/**
+ * Translated name and description of an achievement, or the given defaults
+ */
+export function achievementText(id, defaults = {}) {
+  const text = catalog.value.achievements?.[id]
+  return {
+    name: text?.name || defaults.name || id,
+    description: text?.description || defaults.description || ''
+  }
+}
@@ -0,0 +1,28 @@
+// Achievement icons backed by data/icons.json, which the generator writes
+// for the configured output.icons set
+
+import { ref } from 'vue'
+
+// Empty until icons.json has loaded, and for dashboards generated before it
+// existed: achievements then keep their FontAwesome classes
+const icons = ref({})
+
+/**
+ * Load the generated icons, keeping the FontAwesome classes if they are missing
+ */
+export async function loadIcons() {
+  try {
+    const response = await fetch('./data/icons.json', { cache: 'no-store' })
+    if (!response.ok) return
+    icons.value = await response.json()
+  } catch {
+    // Older output without icons.json
+  }
+}
+
+/**
+ * Icon of an achievement: { class }, { emoji } or { svg }
+ */
+export function achievementIcon(id, fallbackClass) {
+  return icons.value[id] || { class: fallbackClass }
+}
@@ -0,0 +1,59 @@
+// View state kept in the URL query, so a filtered view can be bookmarked or
+// shared and survives a reload
+
+import { computed, ref } from 'vue'
+import { useRoute, useRouter } from 'vue-router'
+
+/**
+ * A writable ref backed by the query parameter name, for v-model. Empty
+ * values remove the parameter; changes replace the history entry rather
+ * than adding one per keystroke.
+ */
+export function useQueryParam(name, defaultValue = '') {
+  const route = useRoute()
+  const router = useRouter()
+
+  return computed({
+    get() {
+      const value = route.query[name]
+      return (Array.isArray(value) ? value[0] : value) ?? defaultValue
+    },
+    set(value) {
+      const query = { ...route.query, [name]: value === defaultValue || value === '' ? undefined : value }
+      router.replace({ query })
+    }
+  })
+}
+
+/**
+ * A boolean query parameter, present as name=1 while set
+ */
+export function useQueryFlag(name) {
+  const param = useQueryParam(name)
+  return computed({
+    get: () => param.value === '1',
+    set: value => { param.value = value ? '1' : '' }
+  })
+}
+
+/**
+ * Copy the link of the current view, state included. copied is set for a
+ * moment after a copy succeeds.
+ */
+export function useShareLink() {
+  const copied = ref(false)
+  let timer = null
+
+  async function copyLink() {
+    try {
+      await window.navigator.clipboard.writeText(window.location.href)
+    } catch {
+      return // Insecure context or denied; the address bar still has the link
+    }
+    copied.value = true
+    clearTimeout(timer)
+    timer = setTimeout(() => { copied.value = false }, 2000)
+  }
+
+  return { copied, copyLink }
+}
@@ -0,0 +1,61 @@
+// Search over data/search.json, the index the generator builds for
+// contributors and repositories
+
+import { ref } from 'vue'
+
+const index = ref(null)
+let loading = null
+
+/**
+ * Load the search index once; resolves to null for output without one
+ */
+export function loadSearchIndex() {
+  if (!loading) {
+    loading = fetch('./data/search.json', { cache: 'no-store' })
+      .then(response => (response.ok ? response.json() : null))
+      .catch(() => null)
+      .then(data => {
+        index.value = data
+        return data
+      })
+  }
+  return loading
+}
+
+export function useSearchIndex() {
+  return index
+}
+
+// Split a query into lowercase words, matching the generator's tokenizer
+function tokenize(text) {
+  return text.toLowerCase().split(/[^\p{L}\p{N}]+/u).filter(Boolean)
+}
+
+/**
+ * Return the entries matching every word of query (as a prefix of an indexed
+ * token) and every set filter: { type, team, repository, achievement }
+ */
+export function search(query, filters = {}) {
+  const data = index.value
+  if (!data) return []
+
+  let positions = null
+  for (const word of tokenize(query || '')) {
+    const matches = new Set()
+    for (const [token, entries] of Object.entries(data.tokens)) {
+      if (token.startsWith(word)) entries.forEach(i => matches.add(i))
+    }
+    positions = positions ? new Set([...positions].filter(i => matches.has(i))) : matches
+  }
+
+  const candidates = positions
+    ? [...positions].sort((a, b) => a - b).map(i => data.entries[i])
+    : data.entries
+
+  return candidates.filter(entry =>
+    (!filters.type || entry.type === filters.type) &&
+    (!filters.team || entry.team === filters.team) &&
+    (!filters.repository || (entry.repositories || []).includes(filters.repository)) &&
+    (!filters.achievement || (entry.achievements || []).includes(filters.achievement))
+  )
+}
@@ -8,22 +8,30 @@ import Dashboard from './views/Dashboard.vue'
 import Leaderboard from './views/Leaderboard.vue'
 import Repository from './views/Repository.vue'
 import Team from './views/Team.vue'
+import Group from './views/Group.vue'
 import Contributor from './views/Contributor.vue'
 import HowScoringWorks from './views/HowScoringWorks.vue'
+import HallOfFame from './views/HallOfFame.vue'
+import Records from './views/Records.vue'
 
 const routes = [
   { path: '/', name: 'dashboard', component: Dashboard },
   { path: '/leaderboard', name: 'leaderboard', component: Leaderboard },
   { path: '/how-scoring-works', name: 'how-scoring-works', component: HowScoringWorks },
+  { path: '/hall-of-fame', name: 'hall-of-fame', component: HallOfFame },
+  { path: '/records', name: 'records', component: Records },
   { path: '/repos/:owner/:name', name: 'repository', component: Repository },
   { path: '/teams/:slug', name: 'team', component: Team },
+  { path: '/groups/:slug', name: 'group', component: Group },
   { path: '/contributors/:login', name: 'contributor', component: Contributor },
 ]
 
 const router = createRouter({
   history: createWebHashHistory(),
   routes,
-  scrollBehavior() {
+  scrollBehavior(to, from) {
+    // Filters kept in the query change it in place; stay where the user is
+    if (to.path === from.path) return false
     return { top: 0 }
   }
 })